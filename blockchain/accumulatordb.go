@@ -125,6 +125,16 @@ func (adb *AccumulatorDB) Accumulator() *Accumulator {
 	return adb.acc.Clone()
 }
 
+// committed returns the committed accumulator without cloning it. The
+// returned accumulator is replaced, never modified, by later commits so
+// it may be read concurrently but must not be changed.
+func (adb *AccumulatorDB) committed() *Accumulator {
+	adb.mtx.RLock()
+	defer adb.mtx.RUnlock()
+
+	return adb.acc
+}
+
 // Commit updates the accumulator in memory and flushes the change to disk using the flushMode.
 // This commit is atomic. If there is an error flushing to the accumulator state in memory will
 // not change.
@@ -37,6 +37,7 @@ type Batch struct {
 	ops          int
 	size         int
 	committed    bool
+	closed       bool
 	blockChan    chan *batchWork
 	validateChan chan *validateWork
 	wg           *sync.WaitGroup
@@ -92,19 +93,33 @@ func (b *Batch) AddBlock(blk *blocks.Block, flags BehaviorFlags) error {
 // If the database commit fails (which really should not happen), then the validator set
 // and others could be left in a bad state.
 func (b *Batch) Commit() error {
-	if b.committed {
+	if b.closed {
 		return errors.New("batch was discarded")
 	}
 	b.wg.Wait()
-	b.committed = true
+	b.closed = true
 	defer b.chain.stateLock.Unlock()
 	close(b.blockChan)
 	close(b.validateChan)
 
+	if !b.committed {
+		if err := b.commit(); err != nil {
+			return err
+		}
+	}
+	return b.errResp
+}
+
+// commit commits the blocks connected so far to disk and publishes a
+// snapshot of the chain state which includes them. Any blocks added to
+// the batch after this is called are discarded.
+func (b *Batch) commit() error {
+	b.committed = true
 	if err := b.dbtx.Commit(context.Background()); err != nil {
 		return err
 	}
-	return b.errResp
+	b.chain.publishSnapshot()
+	return nil
 }
 
 func (b *Batch) worker() {
@@ -135,17 +150,19 @@ func (b *Batch) handler() {
 		flags := work.flags | BFBatchCommit | BFNoValidation
 		if _, err := b.chain.validateBlock(work.blk, flags); err != nil {
 			b.errResp = fmt.Errorf("batch validate error at height: %d, err: %s", work.blk.Header.Height, err)
+			if err := b.commit(); err != nil {
+				b.errResp = err
+			}
 			b.wg.Done()
-			b.committed = true
-			go b.Commit()
 			continue
 		}
 		err := b.chain.connectBlock(b.dbtx, work.blk, flags)
 		if err != nil {
 			b.errResp = fmt.Errorf("batch connect error at height: %d, err: %s", work.blk.Header.Height, err)
+			if err := b.commit(); err != nil {
+				b.errResp = err
+			}
 			b.wg.Done()
-			b.committed = true
-			go b.Commit()
 			continue
 		}
 		b.wg.Done()
@@ -14,6 +14,12 @@ import (
 
 const blockIndexCacheSize = 1000
 
+// blockIndexRecentNodes is the number of nodes below the tip that are
+// never evicted from the cache. Blocks connected in a batch are not in
+// the database until the batch is committed, so the median time past of
+// the next block can only be computed from the cached nodes.
+const blockIndexRecentNodes = 64
+
 // blockNode represents a block in the chain. It stores the hash
 // and height as well as links to the parent and child making it
 // possible to traverse the chain back and forward from this blocknode.
@@ -264,6 +270,9 @@ func (bi *blockIndex) GetNodeByID(blockID types.ID) (*blockNode, error) {
 func (bi *blockIndex) limitCache() {
 	if len(bi.cacheByID) > blockIndexCacheSize {
 		for id, node := range bi.cacheByID {
+			if bi.isRecent(node) {
+				continue
+			}
 			if node.parent != nil {
 				node.parent.child = nil
 			}
@@ -276,6 +285,9 @@ func (bi *blockIndex) limitCache() {
 	}
 	if len(bi.cacheByHeight) > blockIndexCacheSize {
 		for height, node := range bi.cacheByHeight {
+			if bi.isRecent(node) {
+				continue
+			}
 			if node.parent != nil {
 				node.parent.child = nil
 			}
@@ -287,3 +299,7 @@ func (bi *blockIndex) limitCache() {
 		}
 	}
 }
+
+func (bi *blockIndex) isRecent(node *blockNode) bool {
+	return bi.tip != nil && node.height+blockIndexRecentNodes > bi.tip.height
+}
@@ -58,10 +58,22 @@ type Blockchain struct {
 	proofCache        *ProofCache
 	indexManager      IndexManager
 	verifier          zk.Verifier
+	timeSource        MedianTimeSource
+	chainTips         *chainTips
 	notifications     []NotificationCallback
 	prune             bool
 	notificationsLock sync.RWMutex
 
+	// epochHeight is the height of the block which started the
+	// current epoch and credited the validators with their rewards.
+	epochHeight uint32
+
+	// snapshot is the view of the chain state served to readers. It is
+	// replaced once a block is fully connected so readers never wait on
+	// the stateLock.
+	snapshot     *ChainSnapshot
+	snapshotLock sync.RWMutex
+
 	// stateLock protects concurrent access to the chain state
 	stateLock sync.RWMutex
 }
@@ -78,6 +90,9 @@ func NewBlockchain(opts ...Option) (*Blockchain, error) {
 	if err := cfg.validate(); err != nil {
 		return nil, err
 	}
+	if cfg.timeSource == nil {
+		cfg.timeSource = NewMedianTime()
+	}
 
 	b := &Blockchain{
 		params:            cfg.params,
@@ -91,6 +106,8 @@ func NewBlockchain(opts ...Option) (*Blockchain, error) {
 		sigCache:          cfg.sigCache,
 		proofCache:        cfg.proofCache,
 		verifier:          cfg.verifier,
+		timeSource:        cfg.timeSource,
+		chainTips:         newChainTips(cfg.forkAlertDepth),
 		stateLock:         sync.RWMutex{},
 		notificationsLock: sync.RWMutex{},
 	}
@@ -118,16 +135,24 @@ func NewBlockchain(opts ...Option) (*Blockchain, error) {
 		if err := b.accumulatorDB.Init(b.index.Tip()); err != nil {
 			return nil, err
 		}
-
-		if b.indexManager != nil {
-			if err := b.indexManager.Init(b.index.Tip().Height(), b.GetBlockByHeight); err != nil {
-				return nil, err
-			}
-		}
 	}
 	if err := b.validatorSet.Init(b.index.Tip()); err != nil {
 		return nil, err
 	}
+	if initialized {
+		epochHeight, err := b.calcEpochHeight()
+		if err != nil {
+			return nil, err
+		}
+		b.epochHeight = epochHeight
+	}
+	b.publishSnapshot()
+
+	if initialized && b.indexManager != nil {
+		if err := b.indexManager.Init(b.index.Tip().Height(), b.GetBlockByHeight); err != nil {
+			return nil, err
+		}
+	}
 
 	node := b.index.Tip()
 	if b.prune {
@@ -331,10 +356,14 @@ func (b *Blockchain) connectBlock(dbtx datastore.Txn, blk *blocks.Block, flags B
 		}
 	} else {
 
+		// On regtest the first block is treated as following a genesis
+		// block made now so it doesn't skip ahead all the epochs since
+		// the genesis timestamp.
+		prevTimestamp := b.index.Tip().Timestamp()
 		if b.params.Name == params.RegestParams.Name && b.index.Tip().Height() == 0 {
-			b.index.tip.timestamp = time.Now().Unix()
+			prevTimestamp = time.Now().Unix()
 		}
-		prevEpoch := (b.index.Tip().Timestamp() - b.params.GenesisBlock.Header.Timestamp) / b.params.EpochLength
+		prevEpoch := (prevTimestamp - b.params.GenesisBlock.Header.Timestamp) / b.params.EpochLength
 		blkEpoch := (blk.Header.Timestamp - b.params.GenesisBlock.Header.Timestamp) / b.params.EpochLength
 		if blkEpoch > prevEpoch {
 			coinbase := calculateNextCoinbaseDistribution(b.params, blkEpoch)
@@ -349,6 +378,17 @@ func (b *Blockchain) connectBlock(dbtx datastore.Txn, blk *blocks.Block, flags B
 
 			validatorReward = coinbase - types.Amount(treasuryCredit)
 			newEpoch = true
+
+			// The block has not yet modified the validator set or the
+			// nullifier set so this captures the state at the end of the
+			// previous epoch.
+			snapshot, err := b.takeEpochSnapshot(dbtx, prevEpoch)
+			if err != nil {
+				return err
+			}
+			if err := dsPutEpochSnapshot(dbtx, snapshot); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -399,6 +439,9 @@ func (b *Blockchain) connectBlock(dbtx datastore.Txn, blk *blocks.Block, flags B
 	}
 
 	b.index.ExtendIndex(blk.Header)
+	if newEpoch {
+		b.epochHeight = blk.Header.Height
+	}
 
 	// The following commits the changes to memory atomically so we don't need to worry about
 	// rolling back the changes if the rest of this function errors. The only possible error is
@@ -416,6 +459,15 @@ func (b *Blockchain) connectBlock(dbtx datastore.Txn, blk *blocks.Block, flags B
 		log.WithCaller(true).Error("Commit Block: Error flushing accumulator", log.Args("error", err))
 	}
 
+	b.chainTips.remove(blk.ID())
+
+	// Blocks connected as part of a batch are not visible to readers
+	// until the batch is committed to disk. Whoever commits the batch
+	// publishes the snapshot.
+	if !flags.HasFlag(BFBatchCommit) {
+		b.publishSnapshot()
+	}
+
 	// Notify subscribers of new block.
 	if !flags.HasFlag(BFNoNotification) {
 		b.sendNotification(NTBlockConnected, blk)
@@ -445,6 +497,9 @@ func (b *Blockchain) ReindexChainState() error {
 	if err := dsDeleteAccumulatorCheckpoints(dbtx); err != nil {
 		return err
 	}
+	if err := dsDeleteEpochSnapshots(dbtx); err != nil {
+		return err
+	}
 	if err := dsDeleteNullifierSet(dbtx); err != nil {
 		return err
 	}
@@ -467,6 +522,7 @@ func (b *Blockchain) ReindexChainState() error {
 	b.accumulatorDB = NewAccumulatorDB(b.ds)
 	b.txoRootSet = NewTxoRootSet(b.ds, b.txoRootSet.maxEntries)
 	b.index = NewBlockIndex(b.ds)
+	b.epochHeight = 0
 
 	if err := dsInitCurrentSupply(b.ds); err != nil {
 		return err
@@ -514,6 +570,7 @@ func (b *Blockchain) ReindexChainState() error {
 			if err := dbtx.Commit(context.Background()); err != nil {
 				return err
 			}
+			b.publishSnapshot()
 			dbtx, err = b.ds.NewTransaction(context.Background(), false)
 			if err != nil {
 				return err
@@ -530,6 +587,7 @@ func (b *Blockchain) ReindexChainState() error {
 			if err := dbtx.Commit(context.Background()); err != nil {
 				return err
 			}
+			b.publishSnapshot()
 			return err
 		}
 		if err := b.connectBlock(dbtx, blk, flags); err != nil {
@@ -540,6 +598,7 @@ func (b *Blockchain) ReindexChainState() error {
 	if err := dbtx.Commit(context.Background()); err != nil {
 		return err
 	}
+	b.publishSnapshot()
 	log.Info("Finished reindex")
 	return nil
 }
@@ -554,88 +613,42 @@ func (b *Blockchain) WeightedRandomValidator() peer.ID {
 
 // BestBlock returns the ID, height, and timestamp of the block at the tip of the chain.
 func (b *Blockchain) BestBlock() (types.ID, uint32, time.Time) {
-	b.stateLock.RLock()
-	defer b.stateLock.RUnlock()
-
-	tip := b.index.Tip()
-	return tip.blockID, tip.height, time.Unix(tip.timestamp, 0)
+	return b.Snapshot().BestBlock()
 }
 
 // GetBlockByHeight returns the block at the given height. The block will be loaded from disk.
 func (b *Blockchain) GetBlockByHeight(height uint32) (*blocks.Block, error) {
-	b.stateLock.RLock()
-	defer b.stateLock.RUnlock()
-
-	node, err := b.index.GetNodeByHeight(height)
-	if err != nil {
-		return nil, err
-	}
-	return node.Block()
+	return b.Snapshot().GetBlockByHeight(height)
 }
 
 // GetBlockByID returns the block with the given ID. The block will be loaded from disk.
 func (b *Blockchain) GetBlockByID(blockID types.ID) (*blocks.Block, error) {
-	b.stateLock.RLock()
-	defer b.stateLock.RUnlock()
-
-	node, err := b.index.GetNodeByID(blockID)
-	if err != nil {
-		return nil, err
-	}
-	return node.Block()
+	return b.Snapshot().GetBlockByID(blockID)
 }
 
 // GetBlockIDByHeight returns the ID of the block at the given height.
 func (b *Blockchain) GetBlockIDByHeight(height uint32) (types.ID, error) {
-	b.stateLock.RLock()
-	defer b.stateLock.RUnlock()
-
-	node, err := b.index.GetNodeByHeight(height)
-	if err != nil {
-		return types.ID{}, err
-	}
-	return node.ID(), nil
+	return b.Snapshot().GetBlockIDByHeight(height)
 }
 
 // GetHeaderByHeight returns the header at the given height. The header will be loaded from disk.
 func (b *Blockchain) GetHeaderByHeight(height uint32) (*blocks.BlockHeader, error) {
-	b.stateLock.RLock()
-	defer b.stateLock.RUnlock()
-
-	node, err := b.index.GetNodeByHeight(height)
-	if err != nil {
-		return nil, err
-	}
-	return node.Header()
+	return b.Snapshot().GetHeaderByHeight(height)
 }
 
 // GetBlockHeight returns the height of the block with the given ID.
 func (b *Blockchain) GetBlockHeight(blkID types.ID) (uint32, error) {
-	b.stateLock.RLock()
-	defer b.stateLock.RUnlock()
-
-	node, err := b.index.GetNodeByID(blkID)
-	if err != nil {
-		return 0, err
-	}
-	return node.height, nil
+	return b.Snapshot().GetBlockHeight(blkID)
 }
 
 // HasBlock returns whether the block exists in the chain.
 func (b *Blockchain) HasBlock(blockID types.ID) bool {
-	b.stateLock.RLock()
-	defer b.stateLock.RUnlock()
-
-	_, err := b.index.GetNodeByID(blockID)
-	return err == nil
+	return b.Snapshot().HasBlock(blockID)
 }
 
 // TreasuryBalance returns the current balance of the treasury.
 func (b *Blockchain) TreasuryBalance() (types.Amount, error) {
-	b.stateLock.RLock()
-	defer b.stateLock.RUnlock()
-
-	return dsFetchTreasuryBalance(b.ds)
+	return b.Snapshot().TreasuryBalance(), nil
 }
 
 // TxoRootExists returns whether the given root exists in the txo root set.
@@ -656,24 +669,12 @@ func (b *Blockchain) NullifierExists(n types.Nullifier) (bool, error) {
 
 // GetValidator returns the validator for the given ID
 func (b *Blockchain) GetValidator(validatorID peer.ID) (*Validator, error) {
-	b.stateLock.RLock()
-	defer b.stateLock.RUnlock()
-
-	val, err := b.validatorSet.GetValidator(validatorID)
-	if err != nil {
-		return nil, err
-	}
-	ret := &Validator{}
-	copyValidator(ret, val)
-	return ret, nil
+	return b.Snapshot().GetValidator(validatorID)
 }
 
 // ValidatorExists returns whether the validator exists in the set.
 func (b *Blockchain) ValidatorExists(validatorID peer.ID) bool {
-	b.stateLock.RLock()
-	defer b.stateLock.RUnlock()
-
-	return b.validatorSet.ValidatorExists(validatorID)
+	return b.Snapshot().ValidatorExists(validatorID)
 }
 
 // GetAccumulatorCheckpointByTimestamp returns the accumulator checkpoint at or prior
@@ -724,14 +725,28 @@ func (b *Blockchain) getAccumulatorCheckpointByHeight(height uint32) (*Accumulat
 	return acc, priorHeight, nil
 }
 
-// GetInclusionProof returns an inclusion proof for the input if the blockchain scanner
-// had the encryption key *before* the commitment was processed in a block.
-func (b *Blockchain) GetInclusionProof(commitment types.ID) (*InclusionProof, types.ID, error) {
+// GetEpochSnapshot returns the chain state snapshot taken at the end of
+// the given epoch.
+func (b *Blockchain) GetEpochSnapshot(epoch int64) (*EpochSnapshot, error) {
+	b.stateLock.RLock()
+	defer b.stateLock.RUnlock()
+
+	return dsFetchEpochSnapshot(b.ds, epoch)
+}
+
+// LatestEpochSnapshot returns the chain state snapshot taken at the end
+// of the most recent epoch.
+func (b *Blockchain) LatestEpochSnapshot() (*EpochSnapshot, error) {
 	b.stateLock.RLock()
 	defer b.stateLock.RUnlock()
 
-	proof, err := b.accumulatorDB.Accumulator().GetProof(commitment.Bytes())
-	return proof, b.index.Tip().ID(), err
+	return dsFetchLatestEpochSnapshot(b.ds)
+}
+
+// GetInclusionProof returns an inclusion proof for the input if the blockchain scanner
+// had the encryption key *before* the commitment was processed in a block.
+func (b *Blockchain) GetInclusionProof(commitment types.ID) (*InclusionProof, types.ID, error) {
+	return b.Snapshot().GetInclusionProof(commitment)
 }
 
 // Params returns the current chain parameters use by the blockchain.
@@ -739,66 +754,36 @@ func (b *Blockchain) Params() *params.NetworkParams {
 	return b.params
 }
 
+// TimeSource returns the median time source used to compute the network
+// adjusted time.
+func (b *Blockchain) TimeSource() MedianTimeSource {
+	return b.timeSource
+}
+
 // CurrentSupply returns the current circulating supply of coins.
 func (b *Blockchain) CurrentSupply() (types.Amount, error) {
-	b.stateLock.RLock()
-	defer b.stateLock.RUnlock()
-
-	dbtx, err := b.ds.NewTransaction(context.Background(), true)
-	if err != nil {
-		return 0, err
-	}
-	defer dbtx.Discard(context.Background())
-
-	supply, err := dsFetchCurrentSupply(dbtx)
-	if err != nil {
-		return 0, err
-	}
-
-	err = dbtx.Commit(context.Background())
-	if err != nil {
-		return 0, err
-	}
-	return supply, nil
+	return b.Snapshot().CurrentSupply(), nil
 }
 
 // TotalStaked returns the total number of coins staked in the validator set.
 func (b *Blockchain) TotalStaked() types.Amount {
-	b.stateLock.RLock()
-	defer b.stateLock.RUnlock()
-
-	return b.validatorSet.totalStaked()
+	return b.Snapshot().TotalStaked()
 }
 
 // TotalStakeWeight returns the total number of coins staked in the validator set
 // weighted by time locks.
 func (b *Blockchain) TotalStakeWeight() types.Amount {
-	b.stateLock.RLock()
-	defer b.stateLock.RUnlock()
-
-	return b.validatorSet.totalWeightedStake()
+	return b.Snapshot().TotalStakeWeight()
 }
 
 // ValidatorSetSize returns the number of validators in the validator set.
 func (b *Blockchain) ValidatorSetSize() int {
-	b.stateLock.RLock()
-	defer b.stateLock.RUnlock()
-
-	return len(b.validatorSet.validators)
+	return b.Snapshot().ValidatorSetSize()
 }
 
 // Validators returns the full list of validators.
 func (b *Blockchain) Validators() []*Validator {
-	b.stateLock.RLock()
-	defer b.stateLock.RUnlock()
-
-	ret := make([]*Validator, 0, len(b.validatorSet.validators))
-	for _, val := range b.validatorSet.validators {
-		v := &Validator{}
-		copyValidator(v, val)
-		ret = append(ret, v)
-	}
-	return ret
+	return b.Snapshot().Validators()
 }
 
 // IsProducerUnderLimit returns whether the given validator is currently under the block production limit.
@@ -813,6 +798,67 @@ func (b *Blockchain) IsProducerUnderLimit(validatorID peer.ID) (bool, uint32, ui
 	return current < max, current, max, nil
 }
 
+// RewardsMatureAt returns the height of the first block which may include
+// a coinbase transaction claiming the rewards credited at the start of the
+// current epoch.
+func (b *Blockchain) RewardsMatureAt() uint32 {
+	return b.Snapshot().RewardsMatureAt()
+}
+
+// NextValidatorReward returns the coins which will be distributed to the
+// validators at the start of the next epoch along with the time at which
+// the next epoch begins.
+func (b *Blockchain) NextValidatorReward() (types.Amount, time.Time) {
+	_, _, timestamp := b.BestBlock()
+	genesis := b.params.GenesisBlock.Header.Timestamp
+	nextEpoch := (timestamp.Unix()-genesis)/b.params.EpochLength + 1
+	return calculateNextValidatorReward(b.params, nextEpoch), time.Unix(genesis+nextEpoch*b.params.EpochLength, 0)
+}
+
+func (b *Blockchain) rewardsMatureAt() uint32 {
+	if b.epochHeight < b.params.CoinbaseMaturityHeight {
+		return b.epochHeight
+	}
+	return b.epochHeight + b.params.CoinbaseMaturity
+}
+
+// calcEpochHeight returns the height of the first block in the tip's epoch
+// by searching the chain for the first block with a timestamp in the epoch.
+// Block timestamps always increase so the epochs are in height order.
+func (b *Blockchain) calcEpochHeight() (uint32, error) {
+	genesis := b.params.GenesisBlock.Header.Timestamp
+	epochAt := func(height uint32) (int64, error) {
+		node, err := b.index.GetNodeByHeight(height)
+		if err != nil {
+			return 0, err
+		}
+		header, err := node.Header()
+		if err != nil {
+			return 0, err
+		}
+		return (header.Timestamp - genesis) / b.params.EpochLength, nil
+	}
+
+	tipEpoch, err := epochAt(b.index.Tip().Height())
+	if err != nil {
+		return 0, err
+	}
+	low, high := uint32(0), b.index.Tip().Height()
+	for low < high {
+		mid := low + (high-low)/2
+		epoch, err := epochAt(mid)
+		if err != nil {
+			return 0, err
+		}
+		if epoch < tipEpoch {
+			low = mid + 1
+		} else {
+			high = mid
+		}
+	}
+	return low, nil
+}
+
 func (b *Blockchain) isInitialized() (bool, error) {
 	_, err := dsFetchBlockIDFromHeight(b.ds, 0)
 	if err == datastore.ErrNotFound {
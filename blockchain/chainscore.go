@@ -44,6 +44,9 @@ func (b *Blockchain) CalcChainScore(blks []*blocks.Block, flags ...BehaviorFlags
 		sigCache:          NewSigCache(DefaultSigCacheSize),
 		proofCache:        NewProofCache(DefaultProofCacheSize),
 		verifier:          b.verifier,
+		timeSource:        b.timeSource,
+		chainTips:         newChainTips(b.chainTips.alertDepth),
+		epochHeight:       b.epochHeight,
 		notificationsLock: sync.RWMutex{},
 		stateLock:         sync.RWMutex{},
 	}
@@ -58,11 +61,25 @@ func (b *Blockchain) CalcChainScore(blks []*blocks.Block, flags ...BehaviorFlags
 
 	tempChain.txoRootSet.maxEntries = b.txoRootSet.maxEntries + uint(len(blks))
 
-	tipHeader, err := b.index.Tip().Header()
-	if err != nil {
-		return 0, err
+	// The index is loaded with the last MedianTimeBlocks headers so that
+	// the median time past can be computed for the new blocks.
+	tip := b.index.Tip()
+	start := uint32(0)
+	if tip.Height() >= b.params.MedianTimeBlocks {
+		start = tip.Height() - b.params.MedianTimeBlocks + 1
+	}
+	var tipHeader *blocks.BlockHeader
+	for height := start; height <= tip.Height(); height++ {
+		node, err := b.index.GetNodeByHeight(height)
+		if err != nil {
+			return 0, err
+		}
+		tipHeader, err = node.Header()
+		if err != nil {
+			return 0, err
+		}
+		tempChain.index.ExtendIndex(tipHeader)
 	}
-	tempChain.index.ExtendIndex(tipHeader)
 
 	balance, err := dsFetchTreasuryBalance(b.ds)
 	if err != nil {
@@ -0,0 +1,179 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"fmt"
+	"github.com/project-illium/ilxd/types"
+	"sort"
+	"sync"
+	"time"
+)
+
+// maxTrackedChainTips is the maximum number of competing branches to
+// hold in memory. When full the least recently seen tip is evicted.
+const maxTrackedChainTips = 100
+
+// ChainTipStatus describes the finalization status of a chain tip.
+type ChainTipStatus int
+
+const (
+	// ChainTipActive is the tip of the finalized main chain.
+	ChainTipActive ChainTipStatus = iota
+	// ChainTipPending is a competing branch which has not yet been
+	// decided by consensus.
+	ChainTipPending
+	// ChainTipRejected is a competing branch which lost to the main chain.
+	ChainTipRejected
+)
+
+var chainTipStatusStrings = map[ChainTipStatus]string{
+	ChainTipActive:   "active",
+	ChainTipPending:  "pending",
+	ChainTipRejected: "rejected",
+}
+
+// String returns the ChainTipStatus in human-readable form.
+func (s ChainTipStatus) String() string {
+	if str, ok := chainTipStatusStrings[s]; ok {
+		return str
+	}
+	return fmt.Sprintf("Unknown ChainTipStatus (%d)", int(s))
+}
+
+// ChainTip is the tip of a branch of the chain seen by this node.
+type ChainTip struct {
+	// ID is the ID of the block at the tip of the branch.
+	ID types.ID
+	// Height is the height of the tip.
+	Height uint32
+	// ForkHeight is the height of the last block the branch has
+	// in common with the main chain.
+	ForkHeight uint32
+	// BranchLength is the number of blocks in the branch after
+	// the fork point.
+	BranchLength uint32
+	// Status is the finalization status of the branch.
+	Status ChainTipStatus
+	// LastSeen is the last time the tip was recorded.
+	LastSeen time.Time
+}
+
+// chainTips tracks recently seen competing branches.
+type chainTips struct {
+	tips       map[types.ID]*ChainTip
+	alertDepth uint32
+	mtx        sync.RWMutex
+}
+
+func newChainTips(alertDepth uint32) *chainTips {
+	return &chainTips{
+		tips:       make(map[types.ID]*ChainTip),
+		alertDepth: alertDepth,
+		mtx:        sync.RWMutex{},
+	}
+}
+
+// record adds or updates the tip and returns true if the branch has
+// just become deeper than the alert depth. A rejected tip is not moved
+// back to pending.
+func (ct *chainTips) record(tipID types.ID, height, forkHeight uint32, status ChainTipStatus) (*ChainTip, bool) {
+	ct.mtx.Lock()
+	defer ct.mtx.Unlock()
+
+	var branchLength uint32
+	if height > forkHeight {
+		branchLength = height - forkHeight
+	}
+
+	prev, ok := ct.tips[tipID]
+	if ok && prev.Status == ChainTipRejected && status == ChainTipPending {
+		status = ChainTipRejected
+	}
+	if !ok && len(ct.tips) >= maxTrackedChainTips {
+		var oldest *ChainTip
+		for _, tip := range ct.tips {
+			if oldest == nil || tip.LastSeen.Before(oldest.LastSeen) {
+				oldest = tip
+			}
+		}
+		delete(ct.tips, oldest.ID)
+	}
+
+	tip := &ChainTip{
+		ID:           tipID,
+		Height:       height,
+		ForkHeight:   forkHeight,
+		BranchLength: branchLength,
+		Status:       status,
+		LastSeen:     time.Now(),
+	}
+	ct.tips[tipID] = tip
+
+	alert := branchLength > ct.alertDepth && (!ok || prev.BranchLength <= ct.alertDepth)
+	cpy := *tip
+	return &cpy, alert
+}
+
+// remove deletes the tip from the tracker. This is used when a tracked
+// block is connected to the main chain.
+func (ct *chainTips) remove(tipID types.ID) {
+	ct.mtx.Lock()
+	defer ct.mtx.Unlock()
+
+	delete(ct.tips, tipID)
+}
+
+// list returns a copy of the tracked tips sorted by height descending.
+func (ct *chainTips) list() []ChainTip {
+	ct.mtx.RLock()
+	defer ct.mtx.RUnlock()
+
+	tips := make([]ChainTip, 0, len(ct.tips))
+	for _, tip := range ct.tips {
+		tips = append(tips, *tip)
+	}
+	sort.Slice(tips, func(i, j int) bool {
+		if tips[i].Height == tips[j].Height {
+			return tips[i].LastSeen.After(tips[j].LastSeen)
+		}
+		return tips[i].Height > tips[j].Height
+	})
+	return tips
+}
+
+// RecordChainTip records a competing branch whose tip is at the given height
+// and which forks from the main chain after forkHeight. If the branch is
+// deeper than the fork alert depth a warning is logged and an NTForkDetected
+// notification is sent.
+func (b *Blockchain) RecordChainTip(tipID types.ID, height, forkHeight uint32, status ChainTipStatus) {
+	tip, alert := b.chainTips.record(tipID, height, forkHeight, status)
+	if alert {
+		log.Warn("Detected chain fork deeper than alert depth", log.ArgsFromMap(map[string]any{
+			"tip":         tip.ID.String(),
+			"height":      tip.Height,
+			"fork height": tip.ForkHeight,
+			"length":      tip.BranchLength,
+			"status":      tip.Status.String(),
+		}))
+		b.sendNotification(NTForkDetected, tip)
+	}
+}
+
+// ChainTips returns the tip of the main chain followed by the recently seen
+// competing branches sorted by height.
+func (b *Blockchain) ChainTips() []ChainTip {
+	bestID, height, timestamp := b.BestBlock()
+	tips := []ChainTip{
+		{
+			ID:         bestID,
+			Height:     height,
+			ForkHeight: height,
+			Status:     ChainTipActive,
+			LastSeen:   timestamp,
+		},
+	}
+	return append(tips, b.chainTips.list()...)
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestChainTips(t *testing.T) {
+	ct := newChainTips(2)
+
+	a, b := randomID(), randomID()
+	_, alert := ct.record(a, 11, 10, ChainTipPending)
+	assert.False(t, alert)
+	_, alert = ct.record(b, 11, 10, ChainTipPending)
+	assert.False(t, alert)
+
+	// Once rejected a tip stays rejected.
+	ct.record(a, 11, 10, ChainTipRejected)
+	tip, _ := ct.record(a, 11, 10, ChainTipPending)
+	assert.Equal(t, ChainTipRejected, tip.Status)
+
+	// Alert only the first time the branch passes the alert depth.
+	tip, alert = ct.record(b, 13, 10, ChainTipRejected)
+	assert.True(t, alert)
+	assert.Equal(t, uint32(3), tip.BranchLength)
+	_, alert = ct.record(b, 14, 10, ChainTipRejected)
+	assert.False(t, alert)
+
+	tips := ct.list()
+	assert.Len(t, tips, 2)
+	assert.Equal(t, b, tips[0].ID)
+	assert.Equal(t, a, tips[1].ID)
+
+	ct.remove(a)
+	assert.Len(t, ct.list(), 1)
+
+	for i := 0; i < maxTrackedChainTips+10; i++ {
+		ct.record(randomID(), uint32(i), 0, ChainTipRejected)
+	}
+	assert.Len(t, ct.list(), maxTrackedChainTips)
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"github.com/project-illium/ilxd/params/hash"
+	"github.com/project-illium/ilxd/repo"
+	"github.com/project-illium/ilxd/types"
+	"golang.org/x/crypto/blake2s"
+	"sort"
+)
+
+// EpochSnapshot is a commitment to the chain state at the end of an
+// epoch. It is taken when the first block of the next epoch is
+// connected and reflects the state as of the last block of the epoch.
+//
+// Nodes that agree on the chain will compute the same snapshots so they
+// can be used as anchors by light clients and to cross-check nodes.
+type EpochSnapshot struct {
+	Epoch            int64
+	Height           uint32
+	BlockID          types.ID
+	AccumulatorRoot  types.ID
+	NullifierSetHash types.ID
+	ValidatorSetHash types.ID
+}
+
+// Commitment returns a single hash committing to every field in
+// the snapshot.
+func (s *EpochSnapshot) Commitment() types.ID {
+	b := make([]byte, 12, 12+hash.HashSize*4)
+	binary.BigEndian.PutUint64(b, uint64(s.Epoch))
+	binary.BigEndian.PutUint32(b[8:], s.Height)
+	b = append(b, s.BlockID[:]...)
+	b = append(b, s.AccumulatorRoot[:]...)
+	b = append(b, s.NullifierSetHash[:]...)
+	b = append(b, s.ValidatorSetHash[:]...)
+	return types.NewID(hash.HashFunc(b))
+}
+
+// Hash returns a hash of the validators in the set and their stake.
+// The validators are sorted by ID so every node computes the same hash.
+func (vs *ValidatorSet) Hash() (types.ID, error) {
+	vs.mtx.RLock()
+	defer vs.mtx.RUnlock()
+
+	validators := make([]*Validator, 0, len(vs.validators))
+	for _, v := range vs.validators {
+		validators = append(validators, v)
+	}
+	sort.Slice(validators, func(i, j int) bool {
+		return validators[i].PeerID < validators[j].PeerID
+	})
+
+	h, err := blake2s.New256(nil)
+	if err != nil {
+		return types.ID{}, err
+	}
+	b := make([]byte, 16)
+	for _, v := range validators {
+		idBytes, err := v.PeerID.Marshal()
+		if err != nil {
+			return types.ID{}, err
+		}
+		h.Write(idBytes)
+		binary.BigEndian.PutUint64(b, uint64(v.TotalStake))
+		binary.BigEndian.PutUint64(b[8:], uint64(v.WeightedStake))
+		h.Write(b)
+	}
+	sum := h.Sum(nil)
+	sum[0] &= 0b00011111
+	return types.NewID(sum), nil
+}
+
+// nullifierSetHash returns a hash of every nullifier in the nullifier
+// set in sorted order. The hash is streamed so the set does not need to
+// be loaded into memory.
+func nullifierSetHash(dbtx datastore.Txn) (types.ID, error) {
+	results, err := dbtx.Query(context.Background(), query.Query{
+		Prefix:   repo.NullifierKeyPrefix,
+		KeysOnly: true,
+		Orders:   []query.Order{query.OrderByKey{}},
+	})
+	if err != nil {
+		return types.ID{}, err
+	}
+	defer results.Close()
+
+	h, err := blake2s.New256(nil)
+	if err != nil {
+		return types.ID{}, err
+	}
+	for result, ok := results.NextSync(); ok; result, ok = results.NextSync() {
+		if result.Error != nil {
+			return types.ID{}, result.Error
+		}
+		n, err := hex.DecodeString(datastore.NewKey(result.Key).BaseNamespace())
+		if err != nil {
+			return types.ID{}, err
+		}
+		h.Write(n)
+	}
+	sum := h.Sum(nil)
+	sum[0] &= 0b00011111
+	return types.NewID(sum), nil
+}
+
+// takeEpochSnapshot computes the snapshot of the current chain state. It
+// must be called before the block starting the next epoch modifies
+// the state.
+func (b *Blockchain) takeEpochSnapshot(dbtx datastore.Txn, epoch int64) (*EpochSnapshot, error) {
+	tip := b.index.Tip()
+	nullifierHash, err := nullifierSetHash(dbtx)
+	if err != nil {
+		return nil, err
+	}
+	validatorHash, err := b.validatorSet.Hash()
+	if err != nil {
+		return nil, err
+	}
+	return &EpochSnapshot{
+		Epoch:            epoch,
+		Height:           tip.Height(),
+		BlockID:          tip.ID(),
+		AccumulatorRoot:  b.accumulatorDB.Accumulator().Root(),
+		NullifierSetHash: nullifierHash,
+		ValidatorSetHash: validatorHash,
+	}, nil
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"context"
+	"github.com/ipfs/go-datastore"
+	"github.com/project-illium/ilxd/params"
+	"github.com/project-illium/ilxd/repo/mock"
+	"github.com/project-illium/ilxd/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestNullifierSetHash(t *testing.T) {
+	nullifiers := make([]types.Nullifier, 10)
+	for i := range nullifiers {
+		nullifiers[i] = types.NewNullifier(randomID().Bytes())
+	}
+	reversed := make([]types.Nullifier, len(nullifiers))
+	for i := range nullifiers {
+		reversed[len(nullifiers)-1-i] = nullifiers[i]
+	}
+
+	hashSet := func(nullifiers []types.Nullifier) types.ID {
+		ds := mock.NewMapDatastore()
+		dbtx, err := ds.NewTransaction(context.Background(), false)
+		require.NoError(t, err)
+		require.NoError(t, dsPutNullifiers(dbtx, nullifiers))
+		require.NoError(t, dbtx.Commit(context.Background()))
+
+		dbtx, err = ds.NewTransaction(context.Background(), false)
+		require.NoError(t, err)
+		h, err := nullifierSetHash(dbtx)
+		require.NoError(t, err)
+		return h
+	}
+
+	// The insertion order does not matter.
+	assert.Equal(t, hashSet(nullifiers), hashSet(reversed))
+	assert.NotEqual(t, hashSet(nullifiers), hashSet(nullifiers[1:]))
+}
+
+func TestValidatorSet_Hash(t *testing.T) {
+	vs := NewValidatorSet(&params.RegestParams, mock.NewMapDatastore())
+	emptyHash, err := vs.Hash()
+	require.NoError(t, err)
+
+	for i := 0; i < 5; i++ {
+		id := randomPeerID()
+		vs.validators[id] = &Validator{
+			PeerID:        id,
+			TotalStake:    types.Amount(i * 1000),
+			WeightedStake: types.Amount(i * 1500),
+		}
+	}
+	h, err := vs.Hash()
+	require.NoError(t, err)
+	assert.NotEqual(t, emptyHash, h)
+
+	// Map iteration order is random but the hash is not.
+	for i := 0; i < 5; i++ {
+		h2, err := vs.Hash()
+		require.NoError(t, err)
+		assert.Equal(t, h, h2)
+	}
+
+	for _, v := range vs.validators {
+		v.WeightedStake++
+		break
+	}
+	h3, err := vs.Hash()
+	require.NoError(t, err)
+	assert.NotEqual(t, h, h3)
+}
+
+func TestEpochSnapshotStorage(t *testing.T) {
+	ds := mock.NewMapDatastore()
+
+	_, err := dsFetchLatestEpochSnapshot(ds)
+	assert.ErrorIs(t, err, datastore.ErrNotFound)
+
+	snapshots := []*EpochSnapshot{
+		{
+			Epoch:            1,
+			Height:           100,
+			BlockID:          randomID(),
+			AccumulatorRoot:  randomID(),
+			NullifierSetHash: randomID(),
+			ValidatorSetHash: randomID(),
+		},
+		{
+			Epoch:            2,
+			Height:           200,
+			BlockID:          randomID(),
+			AccumulatorRoot:  randomID(),
+			NullifierSetHash: randomID(),
+			ValidatorSetHash: randomID(),
+		},
+	}
+	assert.NotEqual(t, snapshots[0].Commitment(), snapshots[1].Commitment())
+
+	dbtx, err := ds.NewTransaction(context.Background(), false)
+	require.NoError(t, err)
+	for _, s := range snapshots {
+		require.NoError(t, dsPutEpochSnapshot(dbtx, s))
+	}
+	require.NoError(t, dbtx.Commit(context.Background()))
+
+	s, err := dsFetchEpochSnapshot(ds, 1)
+	require.NoError(t, err)
+	assert.Equal(t, snapshots[0], s)
+	assert.Equal(t, snapshots[0].Commitment(), s.Commitment())
+
+	s, err = dsFetchLatestEpochSnapshot(ds)
+	require.NoError(t, err)
+	assert.Equal(t, snapshots[1], s)
+
+	dbtx, err = ds.NewTransaction(context.Background(), false)
+	require.NoError(t, err)
+	require.NoError(t, dsDeleteEpochSnapshots(dbtx))
+	require.NoError(t, dbtx.Commit(context.Background()))
+
+	_, err = dsFetchEpochSnapshot(ds, 1)
+	assert.ErrorIs(t, err, datastore.ErrNotFound)
+}
@@ -23,7 +23,7 @@ type OrphanBlockError string
 // Error returns the assertion error as a human-readable string and satisfies
 // the error interface.
 func (e OrphanBlockError) Error() string {
-	return "orphan block"
+	return "orphan block: " + string(e)
 }
 
 // AssertError identifies an error that indicates an internal code consistency
@@ -58,6 +58,8 @@ const (
 	ErrInvalidCheckpoint
 	ErrNilHeader
 	ErrMaxBlockSize
+	ErrMedianTimePast
+	ErrImmatureCoinbase
 )
 
 // Map of ErrorCode values back to their constant names for pretty printing.
@@ -81,6 +83,8 @@ var errorCodeStrings = map[ErrorCode]string{
 	ErrInvalidCheckpoint:      "ErrInvalidCheckpoint",
 	ErrNilHeader:              "ErrNilHeader",
 	ErrMaxBlockSize:           "ErrMaxBlockSize",
+	ErrMedianTimePast:         "ErrMedianTimePast",
+	ErrImmatureCoinbase:       "ErrImmatureCoinbase",
 }
 
 // String returns the ErrorCode as a human-readable name.
@@ -0,0 +1,198 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package indexers
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	datastore "github.com/ipfs/go-datastore"
+	"github.com/project-illium/ilxd/repo"
+	"github.com/project-illium/ilxd/types/blocks"
+	"sync"
+	"time"
+)
+
+// externalIndexRetryInterval is how long to wait before retrying a block
+// that an external indexer failed to connect.
+const externalIndexRetryInterval = time.Second * 30
+
+// ExternalIndexer is implemented by indexes maintained outside the node's
+// database, such as by plugins.
+//
+// Unlike an Indexer, an ExternalIndexer does not take part in the block
+// connect transaction and cannot hold up the chain. Instead the manager
+// checkpoints the height of the last block each indexer connected and
+// feeds it blocks from there. A new indexer is backfilled from genesis
+// and an indexer which returns an error is retried from its checkpoint.
+//
+// Finalized blocks are never disconnected so there is no disconnect
+// callback.
+type ExternalIndexer interface {
+	// Key returns a unique key for the index. It is used to
+	// store the index checkpoint.
+	Key() string
+
+	// ConnectBlock is called with each block in height order. If an
+	// error is returned the block will be retried.
+	ConnectBlock(blk *blocks.Block) error
+}
+
+type externalIndex struct {
+	indexer ExternalIndexer
+	notify  chan struct{}
+}
+
+// ExternalIndexManager feeds blocks to the external indexers.
+type ExternalIndexManager struct {
+	ds        repo.Datastore
+	getBlock  func(height uint32) (*blocks.Block, error)
+	tipHeight func() uint32
+	indexes   []*externalIndex
+	quit      chan struct{}
+	wg        sync.WaitGroup
+	mtx       sync.Mutex
+}
+
+// NewExternalIndexManager returns a new ExternalIndexManager. The getBlock
+// and tipHeight functions are used to backfill the indexers.
+func NewExternalIndexManager(ds repo.Datastore, getBlock func(height uint32) (*blocks.Block, error), tipHeight func() uint32) *ExternalIndexManager {
+	return &ExternalIndexManager{
+		ds:        ds,
+		getBlock:  getBlock,
+		tipHeight: tipHeight,
+		mtx:       sync.Mutex{},
+	}
+}
+
+// Register adds an indexer. It will start receiving blocks when the
+// manager is started.
+func (em *ExternalIndexManager) Register(indexer ExternalIndexer) {
+	em.mtx.Lock()
+	defer em.mtx.Unlock()
+
+	em.indexes = append(em.indexes, &externalIndex{
+		indexer: indexer,
+		notify:  make(chan struct{}, 1),
+	})
+}
+
+// Start starts feeding blocks to the indexers, beginning with any blocks
+// they missed since their last checkpoint.
+func (em *ExternalIndexManager) Start() {
+	em.mtx.Lock()
+	defer em.mtx.Unlock()
+
+	if em.quit != nil {
+		return
+	}
+	em.quit = make(chan struct{})
+	for _, idx := range em.indexes {
+		em.wg.Add(1)
+		go em.indexHandler(idx, em.quit)
+	}
+}
+
+// Close stops feeding blocks to the indexers and blocks until any
+// in-progress ConnectBlock calls return.
+func (em *ExternalIndexManager) Close() {
+	em.mtx.Lock()
+	if em.quit == nil {
+		em.mtx.Unlock()
+		return
+	}
+	close(em.quit)
+	em.quit = nil
+	em.mtx.Unlock()
+
+	em.wg.Wait()
+}
+
+// BlockConnected notifies the indexers that the tip of the chain has
+// advanced. The blocks themselves are loaded by each indexer's handler.
+func (em *ExternalIndexManager) BlockConnected() {
+	em.mtx.Lock()
+	defer em.mtx.Unlock()
+
+	for _, idx := range em.indexes {
+		select {
+		case idx.notify <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Checkpoint returns the height of the last block connected by the
+// external indexer with the given key.
+func (em *ExternalIndexManager) Checkpoint(key string) (uint32, error) {
+	heightBytes, err := em.ds.Get(context.Background(), datastore.NewKey(repo.ExternalIndexerHeightKeyPrefix+key))
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint32(heightBytes), nil
+}
+
+func (em *ExternalIndexManager) putCheckpoint(key string, height uint32) error {
+	heightBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(heightBytes, height)
+	return em.ds.Put(context.Background(), datastore.NewKey(repo.ExternalIndexerHeightKeyPrefix+key), heightBytes)
+}
+
+func (em *ExternalIndexManager) indexHandler(idx *externalIndex, quit chan struct{}) {
+	defer em.wg.Done()
+	for {
+		err := em.catchUp(idx, quit)
+		if err != nil {
+			log.WithCaller(true).Error("External indexer error", log.ArgsFromMap(map[string]any{
+				"index": idx.indexer.Key(),
+				"error": err,
+			}))
+		}
+
+		var retry <-chan time.Time
+		if err != nil {
+			retry = time.After(externalIndexRetryInterval)
+		}
+		select {
+		case <-idx.notify:
+		case <-retry:
+		case <-quit:
+			return
+		}
+	}
+}
+
+// catchUp connects every block between the indexer's checkpoint and the
+// tip of the chain, updating the checkpoint after each one.
+func (em *ExternalIndexManager) catchUp(idx *externalIndex, quit chan struct{}) error {
+	key := idx.indexer.Key()
+	next := uint32(0)
+	height, err := em.Checkpoint(key)
+	if err == nil {
+		next = height + 1
+	} else if !errors.Is(err, datastore.ErrNotFound) {
+		return err
+	}
+
+	tip := em.tipHeight()
+	for ; next <= tip; next++ {
+		select {
+		case <-quit:
+			return nil
+		default:
+		}
+		blk, err := em.getBlock(next)
+		if err != nil {
+			return err
+		}
+		if err := idx.indexer.ConnectBlock(blk); err != nil {
+			return err
+		}
+		if err := em.putCheckpoint(key, next); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package indexers
+
+import (
+	"errors"
+	"github.com/project-illium/ilxd/repo/mock"
+	"github.com/project-illium/ilxd/types/blocks"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+type mockExternalIndexer struct {
+	heights []uint32
+	failAt  uint32
+	fail    bool
+	mtx     sync.Mutex
+}
+
+func (m *mockExternalIndexer) Key() string { return "mock" }
+
+func (m *mockExternalIndexer) ConnectBlock(blk *blocks.Block) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if m.fail && blk.Header.Height == m.failAt {
+		return errors.New("index error")
+	}
+	m.heights = append(m.heights, blk.Header.Height)
+	return nil
+}
+
+func (m *mockExternalIndexer) connected() []uint32 {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	return append([]uint32{}, m.heights...)
+}
+
+func TestExternalIndexManager(t *testing.T) {
+	ds := mock.NewMapDatastore()
+
+	var (
+		tip    = uint32(5)
+		tipMtx sync.Mutex
+	)
+	getBlock := func(height uint32) (*blocks.Block, error) {
+		return &blocks.Block{Header: &blocks.BlockHeader{Height: height}}, nil
+	}
+	tipHeight := func() uint32 {
+		tipMtx.Lock()
+		defer tipMtx.Unlock()
+		return tip
+	}
+
+	// A new indexer is backfilled from genesis but stops at
+	// the block it fails to connect.
+	indexer := &mockExternalIndexer{failAt: 3, fail: true}
+	em := NewExternalIndexManager(ds, getBlock, tipHeight)
+	em.Register(indexer)
+	em.Start()
+
+	assert.Eventually(t, func() bool {
+		return len(indexer.connected()) == 3
+	}, time.Second*5, time.Millisecond*10)
+	em.Close()
+	assert.Equal(t, []uint32{0, 1, 2}, indexer.connected())
+
+	height, err := em.Checkpoint("mock")
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(2), height)
+
+	// On restart the indexer resumes from its checkpoint.
+	indexer.mtx.Lock()
+	indexer.fail = false
+	indexer.mtx.Unlock()
+	em = NewExternalIndexManager(ds, getBlock, tipHeight)
+	em.Register(indexer)
+	em.Start()
+	defer em.Close()
+
+	assert.Eventually(t, func() bool {
+		return len(indexer.connected()) == 6
+	}, time.Second*5, time.Millisecond*10)
+
+	// New blocks are delivered as they are connected.
+	tipMtx.Lock()
+	tip = 6
+	tipMtx.Unlock()
+	em.BlockConnected()
+
+	assert.Eventually(t, func() bool {
+		return len(indexer.connected()) == 7
+	}, time.Second*5, time.Millisecond*10)
+	assert.Equal(t, []uint32{0, 1, 2, 3, 4, 5, 6}, indexer.connected())
+}
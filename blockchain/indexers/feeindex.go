@@ -0,0 +1,148 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package indexers
+
+import (
+	"errors"
+	"fmt"
+	datastore "github.com/ipfs/go-datastore"
+	"github.com/project-illium/ilxd/blockchain/indexers/pb"
+	"github.com/project-illium/ilxd/policy"
+	"github.com/project-illium/ilxd/repo"
+	"github.com/project-illium/ilxd/types/blocks"
+	"github.com/project-illium/ilxd/types/transactions"
+	"google.golang.org/protobuf/proto"
+	"sort"
+)
+
+var _ Indexer = (*FeeIndex)(nil)
+
+const FeeIndexName = "fee index"
+
+// FeeIndex is an implementation of the Indexer which records the
+// distribution of the fee rates paid in each block. Fee estimates
+// made from the mempool alone can swing as transactions come and
+// go. The history lets wallets smooth their estimates over a number
+// of blocks.
+type FeeIndex struct{}
+
+// NewFeeIndex returns a new FeeIndex.
+func NewFeeIndex() *FeeIndex {
+	return &FeeIndex{}
+}
+
+// Key returns the key of the index as a string.
+func (idx *FeeIndex) Key() string {
+	return repo.FeeIndexKey
+}
+
+// Name returns the human-readable name of the index.
+func (idx *FeeIndex) Name() string {
+	return FeeIndexName
+}
+
+// ConnectBlock is called when a block is connected to the chain.
+// The indexer can use this opportunity to parse it and store it in
+// the database. The database transaction must be respected.
+func (idx *FeeIndex) ConnectBlock(dbtx datastore.Txn, blk *blocks.Block) error {
+	stats, err := CalcFeeStats(blk)
+	if err != nil {
+		return err
+	}
+	ser, err := proto.Marshal(stats)
+	if err != nil {
+		return err
+	}
+	if err := dsPutIndexValue(dbtx, idx, feeIndexHeightKey(blk.Header.Height), ser); err != nil {
+		return err
+	}
+	return dsPutIndexerHeight(dbtx, idx, blk.Header.Height)
+}
+
+// GetFeeHistory returns the fee stats for up to n blocks ending at the
+// given height, newest first. Fewer are returned if the chain is not
+// that long.
+func (idx *FeeIndex) GetFeeHistory(ds repo.Datastore, height uint32, n int) ([]*pb.DBFeeStats, error) {
+	history := make([]*pb.DBFeeStats, 0, n)
+	for i := 0; i < n && uint32(i) <= height; i++ {
+		ser, err := dsFetchIndexValue(ds, idx, feeIndexHeightKey(height-uint32(i)))
+		if errors.Is(err, datastore.ErrNotFound) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		stats := new(pb.DBFeeStats)
+		if err := proto.Unmarshal(ser, stats); err != nil {
+			return nil, err
+		}
+		history = append(history, stats)
+	}
+	return history, nil
+}
+
+// Close is called when the index manager shuts down and gives the indexer
+// an opportunity to do some cleanup.
+func (idx *FeeIndex) Close(ds repo.Datastore) error {
+	return nil
+}
+
+// CalcFeeStats computes the distribution of the fee rates paid by the
+// transactions in the block. Transactions which don't pay a fee, such
+// as coinbase and stake transactions, are not included.
+func CalcFeeStats(blk *blocks.Block) (*pb.DBFeeStats, error) {
+	size, err := blk.SerializedSize()
+	if err != nil {
+		return nil, err
+	}
+	stats := &pb.DBFeeStats{
+		Height:    blk.Header.Height,
+		Timestamp: blk.Header.Timestamp,
+		BlockSize: uint32(size),
+	}
+	rates := make([]uint64, 0, len(blk.Transactions))
+	for _, tx := range blk.Transactions {
+		fpkb, isFeePayer, err := policy.CalcFeePerKilobyte(tx)
+		if err != nil {
+			return nil, err
+		}
+		if !isFeePayer {
+			continue
+		}
+		switch t := tx.GetTx().(type) {
+		case *transactions.Transaction_StandardTransaction:
+			stats.TotalFees += t.StandardTransaction.Fee
+		case *transactions.Transaction_MintTransaction:
+			stats.TotalFees += t.MintTransaction.Fee
+		}
+		rates = append(rates, uint64(fpkb))
+	}
+	if len(rates) == 0 {
+		return stats, nil
+	}
+	sort.Slice(rates, func(i, j int) bool { return rates[i] < rates[j] })
+
+	percentile := func(p float64) uint64 {
+		return rates[int(p*float64(len(rates)-1)+0.5)]
+	}
+	stats.FeePayers = uint32(len(rates))
+	stats.Min = rates[0]
+	stats.P10 = percentile(.10)
+	stats.P25 = percentile(.25)
+	stats.P50 = percentile(.50)
+	stats.P75 = percentile(.75)
+	stats.P90 = percentile(.90)
+	stats.Max = rates[len(rates)-1]
+	return stats, nil
+}
+
+// DropFeeIndex drops the fee index from the datastore
+func DropFeeIndex(ds repo.Datastore) error {
+	return dsDropIndex(ds, &FeeIndex{})
+}
+
+// feeIndexHeightKey zero pads the height so the keys sort by height.
+func feeIndexHeightKey(height uint32) string {
+	return fmt.Sprintf("%010d", height)
+}
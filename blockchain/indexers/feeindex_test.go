@@ -0,0 +1,83 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package indexers
+
+import (
+	"context"
+	"github.com/project-illium/ilxd/params"
+	"github.com/project-illium/ilxd/policy"
+	"github.com/project-illium/ilxd/repo/mock"
+	"github.com/project-illium/ilxd/types/blocks"
+	"github.com/project-illium/ilxd/types/transactions"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFeeIndex(t *testing.T) {
+	ds := mock.NewMapDatastore()
+	idx := NewFeeIndex()
+
+	dbtx, err := ds.NewTransaction(context.Background(), false)
+	assert.NoError(t, err)
+	assert.NoError(t, idx.ConnectBlock(dbtx, params.RegestParams.GenesisBlock))
+
+	makeTx := func(fee uint64) *transactions.Transaction {
+		return transactions.WrapTransaction(&transactions.StandardTransaction{
+			Nullifiers: [][]byte{make([]byte, 32)},
+			Fee:        fee,
+			Proof:      make([]byte, 1000),
+		})
+	}
+	var txs []*transactions.Transaction
+	for i := uint64(1); i <= 10; i++ {
+		txs = append(txs, makeTx(i*1000))
+	}
+	txs = append(txs, transactions.WrapTransaction(&transactions.StakeTransaction{}))
+
+	blk := &blocks.Block{
+		Header: &blocks.BlockHeader{
+			Height:    1,
+			Timestamp: 12345,
+		},
+		Transactions: txs,
+	}
+	assert.NoError(t, idx.ConnectBlock(dbtx, blk))
+	assert.NoError(t, dbtx.Commit(context.Background()))
+
+	history, err := idx.GetFeeHistory(ds, 1, 5)
+	assert.NoError(t, err)
+	assert.Len(t, history, 2)
+	assert.Equal(t, uint32(1), history[0].Height)
+	assert.Equal(t, uint32(0), history[1].Height)
+
+	stats := history[0]
+	assert.Equal(t, int64(12345), stats.Timestamp)
+	assert.Equal(t, uint32(10), stats.FeePayers)
+	assert.Equal(t, uint64(55000), stats.TotalFees)
+
+	rate := func(tx *transactions.Transaction) uint64 {
+		fpkb, _, err := policy.CalcFeePerKilobyte(tx)
+		assert.NoError(t, err)
+		return uint64(fpkb)
+	}
+	assert.Equal(t, rate(txs[0]), stats.Min)
+	assert.Equal(t, rate(txs[1]), stats.P10)
+	assert.Equal(t, rate(txs[5]), stats.P50)
+	assert.Equal(t, rate(txs[8]), stats.P90)
+	assert.Equal(t, rate(txs[9]), stats.Max)
+
+	// The genesis block has no fee paying transactions.
+	assert.Equal(t, uint32(0), history[1].FeePayers)
+	assert.Equal(t, uint64(0), history[1].P50)
+
+	history, err = idx.GetFeeHistory(ds, 1, 1)
+	assert.NoError(t, err)
+	assert.Len(t, history, 1)
+
+	assert.NoError(t, DropFeeIndex(ds))
+	history, err = idx.GetFeeHistory(ds, 1, 5)
+	assert.NoError(t, err)
+	assert.Len(t, history, 0)
+}
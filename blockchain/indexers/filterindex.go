@@ -0,0 +1,154 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package indexers
+
+import (
+	datastore "github.com/ipfs/go-datastore"
+	"github.com/project-illium/ilxd/blockchain/indexers/pb"
+	"github.com/project-illium/ilxd/gcs"
+	"github.com/project-illium/ilxd/params/hash"
+	"github.com/project-illium/ilxd/repo"
+	"github.com/project-illium/ilxd/types"
+	"github.com/project-illium/ilxd/types/blocks"
+	"google.golang.org/protobuf/proto"
+	"sync"
+)
+
+var _ Indexer = (*BlockFilterIndex)(nil)
+
+const BlockFilterIndexName = "block filter index"
+
+// BlockFilterIndex is an implementation of the Indexer which stores a
+// compact filter for each block. The filter matches the block's output
+// commitments and nullifiers, so a light wallet can download the filters
+// and only fetch the blocks which match its notes instead of downloading
+// every block.
+//
+// Each block also has a filter header which commits to the filter and
+// the previous block's filter header. A light wallet can compare the
+// headers served by different peers to detect a peer serving a bad filter.
+type BlockFilterIndex struct {
+	// The filter header of the last connected block is kept
+	// so the next header can be computed without reading the
+	// database.
+	lastID     types.ID
+	lastHeader types.ID
+	mtx        sync.Mutex
+}
+
+// NewBlockFilterIndex returns a new BlockFilterIndex.
+func NewBlockFilterIndex() *BlockFilterIndex {
+	return &BlockFilterIndex{}
+}
+
+// Key returns the key of the index as a string.
+func (idx *BlockFilterIndex) Key() string {
+	return repo.BlockFilterIndexKey
+}
+
+// Name returns the human-readable name of the index.
+func (idx *BlockFilterIndex) Name() string {
+	return BlockFilterIndexName
+}
+
+// ConnectBlock is called when a block is connected to the chain.
+// The indexer can use this opportunity to parse it and store it in
+// the database. The database transaction must be respected.
+func (idx *BlockFilterIndex) ConnectBlock(dbtx datastore.Txn, blk *blocks.Block) error {
+	idx.mtx.Lock()
+	defer idx.mtx.Unlock()
+
+	var prevHeader types.ID
+	if parent := types.NewID(blk.Header.Parent); blk.Header.Height > 0 && parent == idx.lastID {
+		prevHeader = idx.lastHeader
+	} else if blk.Header.Height > 0 {
+		ser, err := dsFetchIndexValueWithTx(dbtx, idx, parent.String())
+		if err != nil {
+			return err
+		}
+		var prev pb.DBBlockFilter
+		if err := proto.Unmarshal(ser, &prev); err != nil {
+			return err
+		}
+		prevHeader = types.NewID(prev.Header)
+	}
+
+	filter := BuildBlockFilter(blk).Bytes()
+	header := BlockFilterHeader(filter, prevHeader)
+	ser, err := proto.Marshal(&pb.DBBlockFilter{
+		Filter: filter,
+		Header: header[:],
+	})
+	if err != nil {
+		return err
+	}
+	blockID := blk.ID()
+	if err := dsPutIndexValue(dbtx, idx, blockID.String(), ser); err != nil {
+		return err
+	}
+	if err := dsPutIndexerHeight(dbtx, idx, blk.Header.Height); err != nil {
+		return err
+	}
+	idx.lastID = blockID
+	idx.lastHeader = header
+	return nil
+}
+
+// GetBlockFilter returns the serialized filter and the filter header for
+// the block.
+func (idx *BlockFilterIndex) GetBlockFilter(ds repo.Datastore, blockID types.ID) ([]byte, types.ID, error) {
+	ser, err := dsFetchIndexValue(ds, idx, blockID.String())
+	if err != nil {
+		return nil, types.ID{}, err
+	}
+	var dbFilter pb.DBBlockFilter
+	if err := proto.Unmarshal(ser, &dbFilter); err != nil {
+		return nil, types.ID{}, err
+	}
+	return dbFilter.Filter, types.NewID(dbFilter.Header), nil
+}
+
+// Close is called when the index manager shuts down and gives the indexer
+// an opportunity to do some cleanup.
+func (idx *BlockFilterIndex) Close(ds repo.Datastore) error {
+	return nil
+}
+
+// BuildBlockFilter builds the compact filter for the block. It contains
+// the commitment of each of the block's outputs and each of the nullifiers
+// spent in the block.
+func BuildBlockFilter(blk *blocks.Block) *gcs.Filter {
+	outputs := blk.Outputs()
+	nullifiers := blk.Nullifiers()
+	items := make([][]byte, 0, len(outputs)+len(nullifiers))
+	for _, out := range outputs {
+		items = append(items, out.Commitment)
+	}
+	for _, n := range nullifiers {
+		items = append(items, n.Bytes())
+	}
+	return gcs.BuildFilter(BlockFilterKey(blk.ID()), items)
+}
+
+// BlockFilterKey returns the key used to build and match the block's
+// filter. It's the first gcs.KeySize bytes of the block ID.
+func BlockFilterKey(blockID types.ID) [gcs.KeySize]byte {
+	var key [gcs.KeySize]byte
+	copy(key[:], blockID[:gcs.KeySize])
+	return key
+}
+
+// BlockFilterHeader returns the filter header for a block given the
+// serialized filter and the previous block's filter header. The previous
+// header of the genesis block is all zeros.
+func BlockFilterHeader(filter []byte, prevHeader types.ID) types.ID {
+	filterHash := hash.HashFunc(filter)
+	return types.NewID(hash.HashFunc(append(filterHash, prevHeader[:]...)))
+}
+
+// DropBlockFilterIndex drops the block filter index from the datastore
+func DropBlockFilterIndex(ds repo.Datastore) error {
+	return dsDropIndex(ds, &BlockFilterIndex{})
+}
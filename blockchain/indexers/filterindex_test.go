@@ -0,0 +1,73 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package indexers
+
+import (
+	"context"
+	"crypto/rand"
+	"github.com/project-illium/ilxd/gcs"
+	"github.com/project-illium/ilxd/params"
+	"github.com/project-illium/ilxd/repo/mock"
+	"github.com/project-illium/ilxd/types"
+	"github.com/project-illium/ilxd/types/blocks"
+	"github.com/project-illium/ilxd/types/transactions"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBlockFilterIndex(t *testing.T) {
+	ds := mock.NewMapDatastore()
+	idx := NewBlockFilterIndex()
+
+	genesis := params.RegestParams.GenesisBlock
+	dbtx, err := ds.NewTransaction(context.Background(), false)
+	assert.NoError(t, err)
+	assert.NoError(t, idx.ConnectBlock(dbtx, genesis))
+
+	randBytes := func() []byte {
+		b := make([]byte, 32)
+		rand.Read(b)
+		return b
+	}
+	commitment, nullifier := randBytes(), randBytes()
+	blk := &blocks.Block{
+		Header: &blocks.BlockHeader{
+			Height: 1,
+			Parent: genesis.ID().Bytes(),
+		},
+		Transactions: []*transactions.Transaction{
+			transactions.WrapTransaction(&transactions.StandardTransaction{
+				Outputs: []*transactions.Output{
+					{Commitment: commitment},
+				},
+				Nullifiers: [][]byte{nullifier},
+			}),
+		},
+	}
+	assert.NoError(t, idx.ConnectBlock(dbtx, blk))
+	assert.NoError(t, dbtx.Commit(context.Background()))
+
+	genesisFilter, genesisHeader, err := idx.GetBlockFilter(ds, genesis.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, BlockFilterHeader(genesisFilter, types.ID{}), genesisHeader)
+
+	ser, header, err := idx.GetBlockFilter(ds, blk.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, BlockFilterHeader(ser, genesisHeader), header)
+
+	filter, err := gcs.FromBytes(ser)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(2), filter.N())
+
+	key := BlockFilterKey(blk.ID())
+	for _, item := range [][]byte{commitment, nullifier} {
+		match, err := filter.Match(key, item)
+		assert.NoError(t, err)
+		assert.True(t, match)
+	}
+	match, err := filter.Match(key, randBytes())
+	assert.NoError(t, err)
+	assert.False(t, match)
+}
@@ -130,6 +130,362 @@ func (x *DBAddrAmount) GetAmount() uint64 {
 	return 0
 }
 
+// DBRescanCheckpoint is the saved progress of a wallet server
+// index rescan so that it can be resumed if interrupted.
+type DBRescanCheckpoint struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The height of the last block scanned
+	Height uint32 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	// The serialized accumulator as of height
+	Accumulator []byte `protobuf:"bytes,2,opt,name=accumulator,proto3" json:"accumulator,omitempty"`
+	// Unspent nullifiers found so far
+	Nullifiers []*DBRescanCheckpoint_Nullifier `protobuf:"bytes,3,rep,name=nullifiers,proto3" json:"nullifiers,omitempty"`
+}
+
+func (x *DBRescanCheckpoint) Reset() {
+	*x = DBRescanCheckpoint{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_indexer_models_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DBRescanCheckpoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DBRescanCheckpoint) ProtoMessage() {}
+
+func (x *DBRescanCheckpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_db_indexer_models_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DBRescanCheckpoint.ProtoReflect.Descriptor instead.
+func (*DBRescanCheckpoint) Descriptor() ([]byte, []int) {
+	return file_db_indexer_models_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DBRescanCheckpoint) GetHeight() uint32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *DBRescanCheckpoint) GetAccumulator() []byte {
+	if x != nil {
+		return x.Accumulator
+	}
+	return nil
+}
+
+func (x *DBRescanCheckpoint) GetNullifiers() []*DBRescanCheckpoint_Nullifier {
+	if x != nil {
+		return x.Nullifiers
+	}
+	return nil
+}
+
+// DBFeeStats holds the fee rates paid by the transactions in a block.
+type DBFeeStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The block height
+	Height uint32 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	// The block timestamp
+	Timestamp int64 `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// The number of transactions which paid a fee
+	FeePayers uint32 `protobuf:"varint,3,opt,name=fee_payers,json=feePayers,proto3" json:"fee_payers,omitempty"`
+	// The serialized size of the block in bytes
+	BlockSize uint32 `protobuf:"varint,4,opt,name=block_size,json=blockSize,proto3" json:"block_size,omitempty"`
+	// The total fees paid in nanoillium
+	TotalFees uint64 `protobuf:"varint,5,opt,name=total_fees,json=totalFees,proto3" json:"total_fees,omitempty"`
+	// The fee per kilobyte percentiles of the fee paying
+	// transactions. These are zero if there were none.
+	Min uint64 `protobuf:"varint,6,opt,name=min,proto3" json:"min,omitempty"`
+	P10 uint64 `protobuf:"varint,7,opt,name=p10,proto3" json:"p10,omitempty"`
+	P25 uint64 `protobuf:"varint,8,opt,name=p25,proto3" json:"p25,omitempty"`
+	P50 uint64 `protobuf:"varint,9,opt,name=p50,proto3" json:"p50,omitempty"`
+	P75 uint64 `protobuf:"varint,10,opt,name=p75,proto3" json:"p75,omitempty"`
+	P90 uint64 `protobuf:"varint,11,opt,name=p90,proto3" json:"p90,omitempty"`
+	Max uint64 `protobuf:"varint,12,opt,name=max,proto3" json:"max,omitempty"`
+}
+
+func (x *DBFeeStats) Reset() {
+	*x = DBFeeStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_indexer_models_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DBFeeStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DBFeeStats) ProtoMessage() {}
+
+func (x *DBFeeStats) ProtoReflect() protoreflect.Message {
+	mi := &file_db_indexer_models_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DBFeeStats.ProtoReflect.Descriptor instead.
+func (*DBFeeStats) Descriptor() ([]byte, []int) {
+	return file_db_indexer_models_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DBFeeStats) GetHeight() uint32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *DBFeeStats) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *DBFeeStats) GetFeePayers() uint32 {
+	if x != nil {
+		return x.FeePayers
+	}
+	return 0
+}
+
+func (x *DBFeeStats) GetBlockSize() uint32 {
+	if x != nil {
+		return x.BlockSize
+	}
+	return 0
+}
+
+func (x *DBFeeStats) GetTotalFees() uint64 {
+	if x != nil {
+		return x.TotalFees
+	}
+	return 0
+}
+
+func (x *DBFeeStats) GetMin() uint64 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *DBFeeStats) GetP10() uint64 {
+	if x != nil {
+		return x.P10
+	}
+	return 0
+}
+
+func (x *DBFeeStats) GetP25() uint64 {
+	if x != nil {
+		return x.P25
+	}
+	return 0
+}
+
+func (x *DBFeeStats) GetP50() uint64 {
+	if x != nil {
+		return x.P50
+	}
+	return 0
+}
+
+func (x *DBFeeStats) GetP75() uint64 {
+	if x != nil {
+		return x.P75
+	}
+	return 0
+}
+
+func (x *DBFeeStats) GetP90() uint64 {
+	if x != nil {
+		return x.P90
+	}
+	return 0
+}
+
+func (x *DBFeeStats) GetMax() uint64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+// DBBlockFilter holds the compact filter for a block.
+type DBBlockFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The serialized filter
+	Filter []byte `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	// The filter header commits to the filter and
+	// the filter headers of all the previous blocks.
+	Header []byte `protobuf:"bytes,2,opt,name=header,proto3" json:"header,omitempty"`
+}
+
+func (x *DBBlockFilter) Reset() {
+	*x = DBBlockFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_indexer_models_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DBBlockFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DBBlockFilter) ProtoMessage() {}
+
+func (x *DBBlockFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_db_indexer_models_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DBBlockFilter.ProtoReflect.Descriptor instead.
+func (*DBBlockFilter) Descriptor() ([]byte, []int) {
+	return file_db_indexer_models_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DBBlockFilter) GetFilter() []byte {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *DBBlockFilter) GetHeader() []byte {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+// DBScriptOutput is an output whose note was published
+// unencrypted.
+type DBScriptOutput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The output's commitment
+	Commitment []byte `protobuf:"bytes,1,opt,name=commitment,proto3" json:"commitment,omitempty"`
+	// The ID of the transaction which created the output
+	Txid []byte `protobuf:"bytes,2,opt,name=txid,proto3" json:"txid,omitempty"`
+	// The height of the block the transaction is in
+	Height uint32 `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	// The index of the commitment in the accumulator
+	OutputIndex uint64 `protobuf:"varint,4,opt,name=output_index,json=outputIndex,proto3" json:"output_index,omitempty"`
+	// The serialized note
+	Note []byte `protobuf:"bytes,5,opt,name=note,proto3" json:"note,omitempty"`
+}
+
+func (x *DBScriptOutput) Reset() {
+	*x = DBScriptOutput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_indexer_models_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DBScriptOutput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DBScriptOutput) ProtoMessage() {}
+
+func (x *DBScriptOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_db_indexer_models_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DBScriptOutput.ProtoReflect.Descriptor instead.
+func (*DBScriptOutput) Descriptor() ([]byte, []int) {
+	return file_db_indexer_models_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DBScriptOutput) GetCommitment() []byte {
+	if x != nil {
+		return x.Commitment
+	}
+	return nil
+}
+
+func (x *DBScriptOutput) GetTxid() []byte {
+	if x != nil {
+		return x.Txid
+	}
+	return nil
+}
+
+func (x *DBScriptOutput) GetHeight() uint32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *DBScriptOutput) GetOutputIndex() uint64 {
+	if x != nil {
+		return x.OutputIndex
+	}
+	return 0
+}
+
+func (x *DBScriptOutput) GetNote() []byte {
+	if x != nil {
+		return x.Note
+	}
+	return nil
+}
+
 type DBMetadata_IOMetadata struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -149,7 +505,7 @@ type DBMetadata_IOMetadata struct {
 func (x *DBMetadata_IOMetadata) Reset() {
 	*x = DBMetadata_IOMetadata{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_db_indexer_models_proto_msgTypes[2]
+		mi := &file_db_indexer_models_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -162,7 +518,7 @@ func (x *DBMetadata_IOMetadata) String() string {
 func (*DBMetadata_IOMetadata) ProtoMessage() {}
 
 func (x *DBMetadata_IOMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_db_indexer_models_proto_msgTypes[2]
+	mi := &file_db_indexer_models_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -229,7 +585,7 @@ type DBMetadata_IOMetadata_TxIO struct {
 func (x *DBMetadata_IOMetadata_TxIO) Reset() {
 	*x = DBMetadata_IOMetadata_TxIO{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_db_indexer_models_proto_msgTypes[3]
+		mi := &file_db_indexer_models_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -242,7 +598,7 @@ func (x *DBMetadata_IOMetadata_TxIO) String() string {
 func (*DBMetadata_IOMetadata_TxIO) ProtoMessage() {}
 
 func (x *DBMetadata_IOMetadata_TxIO) ProtoReflect() protoreflect.Message {
-	mi := &file_db_indexer_models_proto_msgTypes[3]
+	mi := &file_db_indexer_models_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -283,7 +639,7 @@ type DBMetadata_IOMetadata_Unknown struct {
 func (x *DBMetadata_IOMetadata_Unknown) Reset() {
 	*x = DBMetadata_IOMetadata_Unknown{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_db_indexer_models_proto_msgTypes[4]
+		mi := &file_db_indexer_models_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -296,7 +652,7 @@ func (x *DBMetadata_IOMetadata_Unknown) String() string {
 func (*DBMetadata_IOMetadata_Unknown) ProtoMessage() {}
 
 func (x *DBMetadata_IOMetadata_Unknown) ProtoReflect() protoreflect.Message {
-	mi := &file_db_indexer_models_proto_msgTypes[4]
+	mi := &file_db_indexer_models_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -312,6 +668,61 @@ func (*DBMetadata_IOMetadata_Unknown) Descriptor() ([]byte, []int) {
 	return file_db_indexer_models_proto_rawDescGZIP(), []int{0, 0, 1}
 }
 
+type DBRescanCheckpoint_Nullifier struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Nullifier  []byte `protobuf:"bytes,1,opt,name=nullifier,proto3" json:"nullifier,omitempty"`
+	Commitment []byte `protobuf:"bytes,2,opt,name=commitment,proto3" json:"commitment,omitempty"`
+}
+
+func (x *DBRescanCheckpoint_Nullifier) Reset() {
+	*x = DBRescanCheckpoint_Nullifier{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_indexer_models_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DBRescanCheckpoint_Nullifier) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DBRescanCheckpoint_Nullifier) ProtoMessage() {}
+
+func (x *DBRescanCheckpoint_Nullifier) ProtoReflect() protoreflect.Message {
+	mi := &file_db_indexer_models_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DBRescanCheckpoint_Nullifier.ProtoReflect.Descriptor instead.
+func (*DBRescanCheckpoint_Nullifier) Descriptor() ([]byte, []int) {
+	return file_db_indexer_models_proto_rawDescGZIP(), []int{2, 0}
+}
+
+func (x *DBRescanCheckpoint_Nullifier) GetNullifier() []byte {
+	if x != nil {
+		return x.Nullifier
+	}
+	return nil
+}
+
+func (x *DBRescanCheckpoint_Nullifier) GetCommitment() []byte {
+	if x != nil {
+		return x.Commitment
+	}
+	return nil
+}
+
 var File_db_indexer_models_proto protoreflect.FileDescriptor
 
 var file_db_indexer_models_proto_rawDesc = []byte{
@@ -340,8 +751,53 @@ var file_db_indexer_models_proto_rawDesc = []byte{
 	0x64, 0x64, 0x72, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64,
 	0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72,
 	0x65, 0x73, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x04, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x42, 0x07, 0x5a, 0x05, 0x2e,
-	0x2e, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x01, 0x28, 0x04, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xd8, 0x01, 0x0a, 0x12,
+	0x44, 0x42, 0x52, 0x65, 0x73, 0x63, 0x61, 0x6e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x63,
+	0x63, 0x75, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x0b, 0x61, 0x63, 0x63, 0x75, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x3d, 0x0a, 0x0a,
+	0x6e, 0x75, 0x6c, 0x6c, 0x69, 0x66, 0x69, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1d, 0x2e, 0x44, 0x42, 0x52, 0x65, 0x73, 0x63, 0x61, 0x6e, 0x43, 0x68, 0x65, 0x63, 0x6b,
+	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x2e, 0x4e, 0x75, 0x6c, 0x6c, 0x69, 0x66, 0x69, 0x65, 0x72, 0x52,
+	0x0a, 0x6e, 0x75, 0x6c, 0x6c, 0x69, 0x66, 0x69, 0x65, 0x72, 0x73, 0x1a, 0x49, 0x0a, 0x09, 0x4e,
+	0x75, 0x6c, 0x6c, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x75, 0x6c, 0x6c,
+	0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x6e, 0x75, 0x6c,
+	0x6c, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d,
+	0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x9d, 0x02, 0x0a, 0x0a, 0x44, 0x42, 0x46, 0x65, 0x65,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1c, 0x0a,
+	0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1d, 0x0a, 0x0a, 0x66,
+	0x65, 0x65, 0x5f, 0x70, 0x61, 0x79, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x09, 0x66, 0x65, 0x65, 0x50, 0x61, 0x79, 0x65, 0x72, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c,
+	0x6f, 0x63, 0x6b, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x5f, 0x66, 0x65, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x46, 0x65, 0x65, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x31,
+	0x30, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x70, 0x31, 0x30, 0x12, 0x10, 0x0a, 0x03,
+	0x70, 0x32, 0x35, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x70, 0x32, 0x35, 0x12, 0x10,
+	0x0a, 0x03, 0x70, 0x35, 0x30, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x70, 0x35, 0x30,
+	0x12, 0x10, 0x0a, 0x03, 0x70, 0x37, 0x35, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x70,
+	0x37, 0x35, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x39, 0x30, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x03, 0x70, 0x39, 0x30, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x0c, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x22, 0x3f, 0x0a, 0x0d, 0x44, 0x42, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12,
+	0x16, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x22, 0x93, 0x01, 0x0a, 0x0e, 0x44, 0x42, 0x53, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a,
+	0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x78,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x74, 0x78, 0x69, 0x64, 0x12, 0x16,
+	0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06,
+	0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74,
+	0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x6f, 0x75,
+	0x74, 0x70, 0x75, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x74,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x42, 0x07, 0x5a,
+	0x05, 0x2e, 0x2e, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -356,24 +812,30 @@ func file_db_indexer_models_proto_rawDescGZIP() []byte {
 	return file_db_indexer_models_proto_rawDescData
 }
 
-var file_db_indexer_models_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_db_indexer_models_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_db_indexer_models_proto_goTypes = []interface{}{
 	(*DBMetadata)(nil),                    // 0: DBMetadata
 	(*DBAddrAmount)(nil),                  // 1: DBAddrAmount
-	(*DBMetadata_IOMetadata)(nil),         // 2: DBMetadata.IOMetadata
-	(*DBMetadata_IOMetadata_TxIO)(nil),    // 3: DBMetadata.IOMetadata.TxIO
-	(*DBMetadata_IOMetadata_Unknown)(nil), // 4: DBMetadata.IOMetadata.Unknown
+	(*DBRescanCheckpoint)(nil),            // 2: DBRescanCheckpoint
+	(*DBFeeStats)(nil),                    // 3: DBFeeStats
+	(*DBBlockFilter)(nil),                 // 4: DBBlockFilter
+	(*DBScriptOutput)(nil),                // 5: DBScriptOutput
+	(*DBMetadata_IOMetadata)(nil),         // 6: DBMetadata.IOMetadata
+	(*DBMetadata_IOMetadata_TxIO)(nil),    // 7: DBMetadata.IOMetadata.TxIO
+	(*DBMetadata_IOMetadata_Unknown)(nil), // 8: DBMetadata.IOMetadata.Unknown
+	(*DBRescanCheckpoint_Nullifier)(nil),  // 9: DBRescanCheckpoint.Nullifier
 }
 var file_db_indexer_models_proto_depIdxs = []int32{
-	2, // 0: DBMetadata.inputs:type_name -> DBMetadata.IOMetadata
-	2, // 1: DBMetadata.outputs:type_name -> DBMetadata.IOMetadata
-	3, // 2: DBMetadata.IOMetadata.tx_io:type_name -> DBMetadata.IOMetadata.TxIO
-	4, // 3: DBMetadata.IOMetadata.unknown:type_name -> DBMetadata.IOMetadata.Unknown
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	6, // 0: DBMetadata.inputs:type_name -> DBMetadata.IOMetadata
+	6, // 1: DBMetadata.outputs:type_name -> DBMetadata.IOMetadata
+	9, // 2: DBRescanCheckpoint.nullifiers:type_name -> DBRescanCheckpoint.Nullifier
+	7, // 3: DBMetadata.IOMetadata.tx_io:type_name -> DBMetadata.IOMetadata.TxIO
+	8, // 4: DBMetadata.IOMetadata.unknown:type_name -> DBMetadata.IOMetadata.Unknown
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_db_indexer_models_proto_init() }
@@ -407,7 +869,7 @@ func file_db_indexer_models_proto_init() {
 			}
 		}
 		file_db_indexer_models_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DBMetadata_IOMetadata); i {
+			switch v := v.(*DBRescanCheckpoint); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -419,7 +881,7 @@ func file_db_indexer_models_proto_init() {
 			}
 		}
 		file_db_indexer_models_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DBMetadata_IOMetadata_TxIO); i {
+			switch v := v.(*DBFeeStats); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -431,6 +893,54 @@ func file_db_indexer_models_proto_init() {
 			}
 		}
 		file_db_indexer_models_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DBBlockFilter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_indexer_models_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DBScriptOutput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_indexer_models_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DBMetadata_IOMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_indexer_models_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DBMetadata_IOMetadata_TxIO); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_indexer_models_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DBMetadata_IOMetadata_Unknown); i {
 			case 0:
 				return &v.state
@@ -442,8 +952,20 @@ func file_db_indexer_models_proto_init() {
 				return nil
 			}
 		}
+		file_db_indexer_models_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DBRescanCheckpoint_Nullifier); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
-	file_db_indexer_models_proto_msgTypes[2].OneofWrappers = []interface{}{
+	file_db_indexer_models_proto_msgTypes[6].OneofWrappers = []interface{}{
 		(*DBMetadata_IOMetadata_TxIo)(nil),
 		(*DBMetadata_IOMetadata_Unknown_)(nil),
 	}
@@ -453,7 +975,7 @@ func file_db_indexer_models_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_db_indexer_models_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
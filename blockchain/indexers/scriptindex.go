@@ -0,0 +1,168 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package indexers
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	datastore "github.com/ipfs/go-datastore"
+	"github.com/project-illium/ilxd/blockchain/indexers/pb"
+	"github.com/project-illium/ilxd/repo"
+	"github.com/project-illium/ilxd/types"
+	"github.com/project-illium/ilxd/types/blocks"
+	"google.golang.org/protobuf/proto"
+	"sort"
+	"sync"
+)
+
+var _ Indexer = (*ScriptIndex)(nil)
+
+const ScriptIndexName = "script index"
+
+// ScriptIndex is an implementation of the Indexer which indexes the
+// outputs whose notes were published unencrypted, such as those sent
+// to public addresses, by the note's script hash. Applications built
+// on a shared script, like an escrow registry or a pool, can use it to
+// discover the notes locked with the script.
+//
+// A note is only indexed if its commitment matches the output's
+// commitment, so the index can't be filled with notes for a script
+// that were never created.
+type ScriptIndex struct {
+	// outputIndex is the number of outputs in the chain as of
+	// block lastID, which is the last block connected.
+	lastID      types.ID
+	outputIndex uint64
+	mtx         sync.Mutex
+}
+
+// NewScriptIndex returns a new ScriptIndex.
+func NewScriptIndex() *ScriptIndex {
+	return &ScriptIndex{}
+}
+
+// Key returns the key of the index as a string.
+func (idx *ScriptIndex) Key() string {
+	return repo.ScriptIndexKey
+}
+
+// Name returns the human-readable name of the index.
+func (idx *ScriptIndex) Name() string {
+	return ScriptIndexName
+}
+
+// ConnectBlock is called when a block is connected to the chain.
+// The indexer can use this opportunity to parse it and store it in
+// the database. The database transaction must be respected.
+func (idx *ScriptIndex) ConnectBlock(dbtx datastore.Txn, blk *blocks.Block) error {
+	idx.mtx.Lock()
+	defer idx.mtx.Unlock()
+
+	var outputIndex uint64
+	if parent := types.NewID(blk.Header.Parent); blk.Header.Height > 0 && parent == idx.lastID {
+		outputIndex = idx.outputIndex
+	} else if blk.Header.Height > 0 {
+		val, err := dsFetchIndexValueWithTx(dbtx, idx, repo.ScriptIndexOutputIndexKey)
+		if err != nil {
+			return err
+		}
+		outputIndex = binary.BigEndian.Uint64(val)
+	}
+
+	for _, tx := range blk.Transactions {
+		for _, out := range tx.Outputs() {
+			outputIndex++
+
+			note, ok := publicNote(out.Ciphertext, out.Commitment)
+			if !ok {
+				continue
+			}
+			ser, err := proto.Marshal(&pb.DBScriptOutput{
+				Commitment:  out.Commitment,
+				Txid:        tx.ID().Bytes(),
+				Height:      blk.Header.Height,
+				OutputIndex: outputIndex - 1,
+				Note:        out.Ciphertext,
+			})
+			if err != nil {
+				return err
+			}
+			dsKey := fmt.Sprintf("%s%s/%020d", repo.ScriptIndexScriptKeyPrefix, note.ScriptHash.String(), outputIndex-1)
+			if err := dsPutIndexValue(dbtx, idx, dsKey, ser); err != nil {
+				return err
+			}
+		}
+	}
+
+	outputsBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(outputsBytes, outputIndex)
+	if err := dsPutIndexValue(dbtx, idx, repo.ScriptIndexOutputIndexKey, outputsBytes); err != nil {
+		return err
+	}
+	if err := dsPutIndexerHeight(dbtx, idx, blk.Header.Height); err != nil {
+		return err
+	}
+	idx.lastID = blk.ID()
+	idx.outputIndex = outputIndex
+	return nil
+}
+
+// GetScriptOutputs returns the indexed outputs whose note has the script
+// hash in the order they were added to the chain.
+func (idx *ScriptIndex) GetScriptOutputs(ds repo.Datastore, scriptHash types.ID) ([]*pb.DBScriptOutput, error) {
+	dbtx, err := ds.NewTransaction(context.Background(), true)
+	if err != nil {
+		return nil, err
+	}
+	defer dbtx.Discard(context.Background())
+
+	query, err := dsPrefixQueryIndexValue(dbtx, idx, repo.ScriptIndexScriptKeyPrefix+scriptHash.String()+"/")
+	if err != nil {
+		return nil, err
+	}
+	defer query.Close()
+
+	var outputs []*pb.DBScriptOutput
+	for r := range query.Next() {
+		if r.Error != nil {
+			return nil, r.Error
+		}
+		output := new(pb.DBScriptOutput)
+		if err := proto.Unmarshal(r.Value, output); err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, output)
+	}
+	sort.Slice(outputs, func(i, j int) bool {
+		return outputs[i].OutputIndex < outputs[j].OutputIndex
+	})
+	return outputs, nil
+}
+
+// Close is called when the index manager shuts down and gives the indexer
+// an opportunity to do some cleanup.
+func (idx *ScriptIndex) Close(ds repo.Datastore) error {
+	return nil
+}
+
+// DropScriptIndex drops the script index from the datastore
+func DropScriptIndex(ds repo.Datastore) error {
+	return dsDropIndex(ds, &ScriptIndex{})
+}
+
+// publicNote returns the note if the ciphertext is an unencrypted note
+// with the commitment.
+func publicNote(ciphertext, commitment []byte) (*types.SpendNote, bool) {
+	note := new(types.SpendNote)
+	if err := note.Deserialize(ciphertext); err != nil {
+		return nil, false
+	}
+	c, err := note.Commitment()
+	if err != nil || c != types.NewID(commitment) {
+		return nil, false
+	}
+	return note, true
+}
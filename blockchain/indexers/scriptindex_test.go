@@ -0,0 +1,76 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package indexers
+
+import (
+	"context"
+	"crypto/rand"
+	"github.com/project-illium/ilxd/params"
+	"github.com/project-illium/ilxd/repo/mock"
+	"github.com/project-illium/ilxd/types"
+	"github.com/project-illium/ilxd/types/blocks"
+	"github.com/project-illium/ilxd/types/transactions"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestScriptIndex(t *testing.T) {
+	ds := mock.NewMapDatastore()
+	idx := NewScriptIndex()
+
+	genesis := params.RegestParams.GenesisBlock
+	dbtx, err := ds.NewTransaction(context.Background(), false)
+	assert.NoError(t, err)
+	assert.NoError(t, idx.ConnectBlock(dbtx, genesis))
+
+	randBytes := func(n int) []byte {
+		b := make([]byte, n)
+		rand.Read(b)
+		return b
+	}
+	scriptHash := types.NewID(randBytes(32))
+	note := &types.SpendNote{
+		ScriptHash: scriptHash,
+		Amount:     1000,
+		State:      types.State{randBytes(32)},
+	}
+	copy(note.Salt[:], randBytes(32))
+	commitment, err := note.Commitment()
+	assert.NoError(t, err)
+	ciphertext, err := note.ToPublicCiphertext()
+	assert.NoError(t, err)
+
+	tx := transactions.WrapTransaction(&transactions.StandardTransaction{
+		Outputs: []*transactions.Output{
+			// Encrypted
+			{Commitment: randBytes(32), Ciphertext: randBytes(160)},
+			{Commitment: commitment.Bytes(), Ciphertext: ciphertext},
+			// The note doesn't match the commitment
+			{Commitment: randBytes(32), Ciphertext: ciphertext},
+		},
+	})
+	blk := &blocks.Block{
+		Header: &blocks.BlockHeader{
+			Height: 1,
+			Parent: genesis.ID().Bytes(),
+		},
+		Transactions: []*transactions.Transaction{tx},
+	}
+	assert.NoError(t, idx.ConnectBlock(dbtx, blk))
+	assert.NoError(t, dbtx.Commit(context.Background()))
+
+	outputs, err := idx.GetScriptOutputs(ds, scriptHash)
+	assert.NoError(t, err)
+	assert.Len(t, outputs, 1)
+	assert.Equal(t, commitment.Bytes(), outputs[0].Commitment)
+	assert.Equal(t, tx.ID().Bytes(), outputs[0].Txid)
+	assert.Equal(t, uint32(1), outputs[0].Height)
+	assert.Equal(t, uint64(len(genesis.Outputs())+1), outputs[0].OutputIndex)
+	assert.Equal(t, ciphertext, outputs[0].Note)
+
+	outputs, err = idx.GetScriptOutputs(ds, types.NewID(randBytes(32)))
+	assert.NoError(t, err)
+	assert.Len(t, outputs, 0)
+}
@@ -12,13 +12,17 @@ import (
 	"github.com/ipfs/go-datastore"
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/project-illium/ilxd/blockchain"
+	"github.com/project-illium/ilxd/blockchain/indexers/pb"
 	icrypto "github.com/project-illium/ilxd/crypto"
 	"github.com/project-illium/ilxd/repo"
+	"github.com/project-illium/ilxd/tasks"
 	"github.com/project-illium/ilxd/types"
 	"github.com/project-illium/ilxd/types/blocks"
 	"github.com/project-illium/ilxd/types/transactions"
 	"github.com/project-illium/walletlib"
+	"google.golang.org/protobuf/proto"
 	"math/rand"
+	"runtime"
 	"strings"
 	"sync"
 	"time"
@@ -30,6 +34,10 @@ const (
 	staleUserThreshold      = time.Hour * 24 * 90
 	staleUserTickerInterval = time.Hour * 24
 	flushTickerInterval     = time.Hour * 10
+
+	// rescanCheckpointInterval is how often, in blocks, the
+	// progress of a rescan is saved to the database.
+	rescanCheckpointInterval = 1000
 )
 
 type UserTransaction struct {
@@ -439,6 +447,9 @@ func (idx *WalletServerIndex) RegistrationExists(ds repo.Datastore, viewKey cryp
 // RescanViewkey loads historical blocks from disk from the provided checkpoint (or genesis if checkpoint is nil)
 // and scans them looking for transactions for the provided viewKey. If transactions are found the internal state
 // is updated.
+//
+// Progress is saved periodically so that if the rescan is interrupted it can be picked
+// up again with ResumeRescans.
 func (idx *WalletServerIndex) RescanViewkey(ds repo.Datastore, viewKey crypto.PrivKey, accumulatorCheckpoint *blockchain.Accumulator, checkpointHeight uint32, getBlockFunc func(uint32) (*blocks.Block, error)) error {
 	if _, ok := viewKey.(*icrypto.Curve25519PrivateKey); !ok {
 		return errors.New("viewKey is not curve25519 private key")
@@ -461,6 +472,124 @@ func (idx *WalletServerIndex) RescanViewkey(ds repo.Datastore, viewKey crypto.Pr
 		return errors.New(errStr)
 	}
 
+	nullifiers := make(map[types.Nullifier]commitmentWithKey)
+	if err := idx.saveRescanCheckpoint(ds, viewKey, acc, checkpointHeight, nullifiers); err != nil {
+		log.WithCaller(true).Error("Wallet server index error rescanning chain", log.Args("error", err))
+		return err
+	}
+	return idx.rescan(ds, viewKey, acc, checkpointHeight, nullifiers, getBlockFunc)
+}
+
+// ResumeRescans restarts any rescans that were interrupted, such as by a
+// shutdown, from their last saved checkpoint. The rescans run in the background.
+func (idx *WalletServerIndex) ResumeRescans(ds repo.Datastore, getBlockFunc func(uint32) (*blocks.Block, error)) error {
+	checkpoints, err := idx.loadRescanCheckpoints(ds)
+	if err != nil {
+		return err
+	}
+	for _, c := range checkpoints {
+		log.Info("Resuming wallet server index rescan", log.Args("height", c.height))
+		go idx.rescan(ds, c.viewKey, c.acc, c.height, c.nullifiers, getBlockFunc) //nolint:errcheck
+	}
+	return nil
+}
+
+type rescanCheckpoint struct {
+	viewKey    crypto.PrivKey
+	acc        *blockchain.Accumulator
+	height     uint32
+	nullifiers map[types.Nullifier]commitmentWithKey
+}
+
+func (idx *WalletServerIndex) loadRescanCheckpoints(ds repo.Datastore) ([]*rescanCheckpoint, error) {
+	dbtx, err := ds.NewTransaction(context.Background(), true)
+	if err != nil {
+		return nil, err
+	}
+	defer dbtx.Discard(context.Background())
+
+	query, err := dsPrefixQueryIndexValue(dbtx, idx, repo.WalletServerRescanPrefix)
+	if err != nil {
+		return nil, err
+	}
+	defer query.Close()
+
+	var checkpoints []*rescanCheckpoint
+	for r := range query.Next() {
+		v := strings.Split(r.Key, "/")
+		keyBytes, err := hex.DecodeString(v[len(v)-1])
+		if err != nil {
+			return nil, err
+		}
+		viewKey, err := crypto.UnmarshalPrivateKey(keyBytes)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := viewKey.(*icrypto.Curve25519PrivateKey); !ok {
+			return nil, errors.New("viewkey is not curve25519 private key")
+		}
+		var dbCheckpoint pb.DBRescanCheckpoint
+		if err := proto.Unmarshal(r.Value, &dbCheckpoint); err != nil {
+			return nil, err
+		}
+		acc, err := blockchain.DeserializeAccumulator(dbCheckpoint.Accumulator)
+		if err != nil {
+			return nil, err
+		}
+		checkpoint := &rescanCheckpoint{
+			viewKey:    viewKey,
+			acc:        acc,
+			height:     dbCheckpoint.Height,
+			nullifiers: make(map[types.Nullifier]commitmentWithKey),
+		}
+		for _, n := range dbCheckpoint.Nullifiers {
+			checkpoint.nullifiers[types.NewNullifier(n.Nullifier)] = commitmentWithKey{
+				commitment: types.NewID(n.Commitment),
+				viewKey:    viewKey,
+			}
+		}
+		checkpoints = append(checkpoints, checkpoint)
+	}
+	return checkpoints, nil
+}
+
+// rescanBlock is a block that has been fetched and scanned by a rescan worker.
+type rescanBlock struct {
+	blk     *blocks.Block
+	matches map[types.ID]*walletlib.ScanMatch
+	err     error
+}
+
+// scanBlocks fetches and scans blocks starting at the provided height using
+// a pool of workers. The results are returned in height order. Only a limited
+// number of blocks are fetched ahead of the caller to keep memory bounded.
+// Blocks past the tip will return an error.
+func scanBlocks(scanner *walletlib.TransactionScanner, height uint32, getBlockFunc func(uint32) (*blocks.Block, error), done <-chan struct{}) <-chan chan *rescanBlock {
+	workers := runtime.NumCPU()
+	results := make(chan chan *rescanBlock, workers)
+	go func() {
+		defer close(results)
+		for ; ; height++ {
+			ch := make(chan *rescanBlock, 1)
+			select {
+			case results <- ch:
+			case <-done:
+				return
+			}
+			go func(h uint32) {
+				blk, err := getBlockFunc(h)
+				if err != nil {
+					ch <- &rescanBlock{err: err}
+					return
+				}
+				ch <- &rescanBlock{blk: blk, matches: scanner.ScanOutputs(blk)}
+			}(height)
+		}
+	}()
+	return results
+}
+
+func (idx *WalletServerIndex) rescan(ds repo.Datastore, viewKey crypto.PrivKey, acc *blockchain.Accumulator, checkpointHeight uint32, nullifiers map[types.Nullifier]commitmentWithKey, getBlockFunc func(uint32) (*blocks.Block, error)) error {
 	// Let's grab the current height of the chain to avoid repeated
 	// locks during the following loop.
 	idx.stateMtx.RLock()
@@ -469,24 +598,41 @@ func (idx *WalletServerIndex) RescanViewkey(ds repo.Datastore, viewKey crypto.Pr
 
 	scanner := walletlib.NewTransactionScanner(viewKey.(*icrypto.Curve25519PrivateKey))
 	height := checkpointHeight + 1
-	nullifiers := make(map[types.Nullifier]commitmentWithKey)
-	for {
-		blk, err := getBlockFunc(height)
-		if err != nil {
-			log.WithCaller(true).Error("Wallet server index error rescanning chain", log.Args("error", err))
-			return err
+
+	task := tasks.Start(tasks.KindRescan, "wallet server index rescan")
+	defer task.Done()
+
+	done := make(chan struct{})
+	defer close(done)
+	results := scanBlocks(scanner, height, getBlockFunc, done)
+
+	for ch := range results {
+		var rb *rescanBlock
+		select {
+		case rb = <-ch:
+		case <-idx.quit:
+			return idx.saveRescanCheckpoint(ds, viewKey, acc, height-1, nullifiers)
 		}
-		matches := scanner.ScanOutputs(blk)
-		for _, tx := range blk.Transactions {
+		if rb.err != nil {
+			// The block may not have been connected yet when
+			// the worker went to fetch it so try once more.
+			blk, err := getBlockFunc(height)
+			if err != nil {
+				log.WithCaller(true).Error("Wallet server index error rescanning chain", log.Args("error", err))
+				return err
+			}
+			rb = &rescanBlock{blk: blk, matches: scanner.ScanOutputs(blk)}
+		}
+		for _, tx := range rb.blk.Transactions {
 			for _, out := range tx.Outputs() {
-				match, ok := matches[types.NewID(out.Commitment)]
+				match, ok := rb.matches[types.NewID(out.Commitment)]
 				if ok {
 					dbtx, err := ds.NewTransaction(context.Background(), false)
 					if err != nil {
 						log.WithCaller(true).Error("Wallet server index error rescanning chain", log.Args("error", err))
 						return err
 					}
-					commitmentIndex := idx.acc.NumElements()
+					commitmentIndex := acc.NumElements()
 					viewKey, err := crypto.MarshalPrivateKey(match.Key)
 					if err != nil {
 						log.WithCaller(true).Error("Wallet server index error rescanning chain", log.Args("error", err))
@@ -580,23 +726,83 @@ func (idx *WalletServerIndex) RescanViewkey(ds repo.Datastore, viewKey crypto.Pr
 			}
 		}
 
+		task.SetState("scanned height %d of %d", rb.blk.Header.Height, bestHeight)
+
+		if rb.blk.Header.Height%rescanCheckpointInterval == 0 {
+			if err := idx.saveRescanCheckpoint(ds, viewKey, acc, rb.blk.Header.Height, nullifiers); err != nil {
+				log.WithCaller(true).Error("Wallet server index error rescanning chain", log.Args("error", err))
+				return err
+			}
+		}
+
 		// It's likely the chain has moved forward since we last checked
 		// the height so let's check again.
-		if blk.Header.Height >= bestHeight {
+		if rb.blk.Header.Height >= bestHeight {
 			idx.stateMtx.Lock()
-			if blk.Header.Height == idx.bestBlockHeight {
+			if rb.blk.Header.Height == idx.bestBlockHeight {
 				idx.acc.MergeProofs(acc)
 				for k, v := range nullifiers {
 					idx.nullifiers[k] = v
 				}
 				idx.stateMtx.Unlock()
-				return nil
+				return idx.deleteRescanCheckpoint(ds, viewKey)
 			}
 			bestHeight = idx.bestBlockHeight
 			idx.stateMtx.Unlock()
 		}
 		height++
 	}
+	return nil
+}
+
+func (idx *WalletServerIndex) saveRescanCheckpoint(ds repo.Datastore, viewKey crypto.PrivKey, acc *blockchain.Accumulator, height uint32, nullifiers map[types.Nullifier]commitmentWithKey) error {
+	keyBytes, err := crypto.MarshalPrivateKey(viewKey)
+	if err != nil {
+		return err
+	}
+	accBytes, err := blockchain.SerializeAccumulator(acc)
+	if err != nil {
+		return err
+	}
+	checkpoint := &pb.DBRescanCheckpoint{
+		Height:      height,
+		Accumulator: accBytes,
+		Nullifiers:  make([]*pb.DBRescanCheckpoint_Nullifier, 0, len(nullifiers)),
+	}
+	for n, cwk := range nullifiers {
+		checkpoint.Nullifiers = append(checkpoint.Nullifiers, &pb.DBRescanCheckpoint_Nullifier{
+			Nullifier:  n.Bytes(),
+			Commitment: cwk.commitment.Bytes(),
+		})
+	}
+	ser, err := proto.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+
+	dbtx, err := ds.NewTransaction(context.Background(), false)
+	if err != nil {
+		return err
+	}
+	if err := dsPutIndexValue(dbtx, idx, repo.WalletServerRescanPrefix+hex.EncodeToString(keyBytes), ser); err != nil {
+		return err
+	}
+	return dbtx.Commit(context.Background())
+}
+
+func (idx *WalletServerIndex) deleteRescanCheckpoint(ds repo.Datastore, viewKey crypto.PrivKey) error {
+	keyBytes, err := crypto.MarshalPrivateKey(viewKey)
+	if err != nil {
+		return err
+	}
+	dbtx, err := ds.NewTransaction(context.Background(), false)
+	if err != nil {
+		return err
+	}
+	if err := dsDeleteIndexValue(dbtx, idx, repo.WalletServerRescanPrefix+hex.EncodeToString(keyBytes)); err != nil {
+		return err
+	}
+	return dbtx.Commit(context.Background())
 }
 
 // Subscribe returns a subscription to the stream of user transactions.
@@ -8,6 +8,7 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"github.com/ipfs/go-datastore"
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/project-illium/ilxd/blockchain"
 	icrypto "github.com/project-illium/ilxd/crypto"
@@ -183,6 +184,39 @@ func TestWalletServerIndex(t *testing.T) {
 	txids, err = idx.GetTransactionsIDs(ds, viewKey)
 	assert.NoError(t, err)
 	assert.Len(t, txids, 2)
+
+	// The checkpoint should be removed once the rescan completes
+	_, err = dsFetchIndexValue(ds, idx, repo.WalletServerRescanPrefix+hex.EncodeToString(privKeyBytes))
+	assert.ErrorIs(t, err, datastore.ErrNotFound)
+
+	// Test resuming an interrupted rescan
+	ds = mock.NewMapDatastore()
+	idx, err = NewWalletServerIndex(ds)
+	assert.NoError(t, err)
+	idx.bestBlockHeight = 2
+
+	assert.NoError(t, idx.RegisterViewKey(ds, viewKey, ul.Serialize()))
+	assert.NoError(t, idx.saveRescanCheckpoint(ds, viewKey, blockchain.NewAccumulator(), 0, make(map[types.Nullifier]commitmentWithKey)))
+	checkpoints, err := idx.loadRescanCheckpoints(ds)
+	assert.NoError(t, err)
+	assert.Len(t, checkpoints, 1)
+	assert.Equal(t, uint32(0), checkpoints[0].height)
+	assert.True(t, checkpoints[0].viewKey.Equals(viewKey))
+
+	err = idx.rescan(ds, checkpoints[0].viewKey, checkpoints[0].acc, checkpoints[0].height, checkpoints[0].nullifiers, func(height uint32) (*blocks.Block, error) {
+		if height == 1 {
+			return blk, nil
+		}
+		return blk2, nil
+	})
+	assert.NoError(t, err)
+
+	_, err = dsFetchIndexValue(ds, idx, repo.WalletServerRescanPrefix+hex.EncodeToString(privKeyBytes))
+	assert.ErrorIs(t, err, datastore.ErrNotFound)
+
+	txids, err = idx.GetTransactionsIDs(ds, viewKey)
+	assert.NoError(t, err)
+	assert.Len(t, txids, 2)
 }
 
 func randSpendNote() types.SpendNote {
@@ -544,6 +544,83 @@ func dsDeleteAccumulatorCheckpoints(dbtx datastore.Txn) error {
 	return nil
 }
 
+func dsPutEpochSnapshot(dbtx datastore.Txn, snapshot *EpochSnapshot) error {
+	ser, err := proto.Marshal(&pb.DBEpochSnapshot{
+		Epoch:            snapshot.Epoch,
+		Height:           snapshot.Height,
+		Block_ID:         snapshot.BlockID.Bytes(),
+		AccumulatorRoot:  snapshot.AccumulatorRoot.Bytes(),
+		NullifierSetHash: snapshot.NullifierSetHash.Bytes(),
+		ValidatorSetHash: snapshot.ValidatorSetHash.Bytes(),
+	})
+	if err != nil {
+		return err
+	}
+	return dbtx.Put(context.Background(), datastore.NewKey(repo.EpochSnapshotKeyPrefix+fmt.Sprintf("%010d", snapshot.Epoch)), ser)
+}
+
+func deserializeEpochSnapshot(ser []byte) (*EpochSnapshot, error) {
+	var dbSnapshot pb.DBEpochSnapshot
+	if err := proto.Unmarshal(ser, &dbSnapshot); err != nil {
+		return nil, err
+	}
+	return &EpochSnapshot{
+		Epoch:            dbSnapshot.Epoch,
+		Height:           dbSnapshot.Height,
+		BlockID:          types.NewID(dbSnapshot.Block_ID),
+		AccumulatorRoot:  types.NewID(dbSnapshot.AccumulatorRoot),
+		NullifierSetHash: types.NewID(dbSnapshot.NullifierSetHash),
+		ValidatorSetHash: types.NewID(dbSnapshot.ValidatorSetHash),
+	}, nil
+}
+
+func dsFetchEpochSnapshot(ds repo.Datastore, epoch int64) (*EpochSnapshot, error) {
+	ser, err := ds.Get(context.Background(), datastore.NewKey(repo.EpochSnapshotKeyPrefix+fmt.Sprintf("%010d", epoch)))
+	if err != nil {
+		return nil, err
+	}
+	return deserializeEpochSnapshot(ser)
+}
+
+func dsFetchLatestEpochSnapshot(ds repo.Datastore) (*EpochSnapshot, error) {
+	results, err := ds.Query(context.Background(), query.Query{
+		Prefix: repo.EpochSnapshotKeyPrefix,
+		Orders: []query.Order{query.OrderByKeyDescending{}},
+		Limit:  1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer results.Close()
+
+	result, ok := results.NextSync()
+	if !ok {
+		return nil, datastore.ErrNotFound
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return deserializeEpochSnapshot(result.Value)
+}
+
+func dsDeleteEpochSnapshots(dbtx datastore.Txn) error {
+	q := query.Query{
+		Prefix: repo.EpochSnapshotKeyPrefix,
+	}
+
+	results, err := dbtx.Query(context.Background(), q)
+	if err != nil {
+		return err
+	}
+
+	for result, ok := results.NextSync(); ok; result, ok = results.NextSync() {
+		if err := dbtx.Delete(context.Background(), datastore.NewKey(result.Key)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func dsPutAccumulatorConsistencyStatus(ds repo.Datastore, status setConsistencyStatus) error {
 	b := make([]byte, 2)
 	binary.BigEndian.PutUint16(b, uint16(status))
@@ -594,7 +671,7 @@ func dsInitCurrentSupply(ds datastore.Datastore) error {
 	return ds.Put(context.Background(), datastore.NewKey(repo.CoinSupplyKey), zero)
 }
 
-func dsFetchCurrentSupply(dbtx datastore.Txn) (types.Amount, error) {
+func dsFetchCurrentSupply(dbtx datastore.Read) (types.Amount, error) {
 	b, err := dbtx.Get(context.Background(), datastore.NewKey(repo.CoinSupplyKey))
 	if err != nil {
 		return 0, err
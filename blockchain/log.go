@@ -7,10 +7,15 @@ package blockchain
 import (
 	"github.com/project-illium/logger"
 	"github.com/pterm/pterm"
+	"go.opentelemetry.io/otel"
 )
 
 var log = logger.DisabledLogger.WithLevel(pterm.LogLevelDisabled)
 
+// tracer is backed by the global tracer provider which is a no-op
+// unless tracing is enabled by the application.
+var tracer = otel.Tracer("github.com/project-illium/ilxd/blockchain")
+
 // UseLogger uses a specified Logger to output package logging info.
 func UseLogger(logger *logger.Logger) {
 	log = logger
@@ -0,0 +1,200 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// maxMedianTimeEntries is the maximum number of entries allowed in the
+	// median time data.
+	maxMedianTimeEntries = 200
+
+	// minMedianTimeEntries is the minimum number of samples required
+	// before the median offset is applied to the local clock.
+	minMedianTimeEntries = 5
+
+	// MaxAllowedOffset is the maximum offset the median time will be
+	// allowed to adjust the local clock by. If the median offset exceeds
+	// this it is ignored as it's more likely the peers are lying than the
+	// local clock being this far off.
+	MaxAllowedOffset = time.Minute * 10
+
+	// MaxClockSkew is the offset at which the local clock is considered
+	// to be skewed. A warning is logged if the median offset exceeds
+	// this as blocks produced or validated by this node may be rejected.
+	MaxClockSkew = MaxBlockFutureTime / 2
+)
+
+// MedianTimeSource provides a mechanism to add several time samples which are
+// used to determine a median time which is then used as an offset to the local
+// clock.
+type MedianTimeSource interface {
+	// AdjustedTime returns the current time adjusted by the median time
+	// offset as calculated from the time samples added by AddTimeSample.
+	AdjustedTime() time.Time
+
+	// AddTimeSample adds a time sample that is used when determining the
+	// median time of the added samples.
+	AddTimeSample(id string, timeVal time.Time)
+
+	// Offset returns the duration to adjust the local clock by based
+	// upon the median of the time samples added by AddTimeSample.
+	Offset() time.Duration
+
+	// SetMockTime overrides the clock with the provided time. The
+	// adjusted time will remain fixed at this value until it is changed
+	// again. Passing in the zero time restores the real clock.
+	//
+	// This is intended for regression testing only.
+	SetMockTime(t time.Time)
+
+	// AdvanceTime moves the mock time forward by the provided duration
+	// and returns the new time. If a mock time is not set, it starts
+	// from the current adjusted time.
+	//
+	// This is intended for regression testing only.
+	AdvanceTime(d time.Duration) time.Time
+}
+
+// medianTime provides an implementation of the MedianTimeSource interface.
+type medianTime struct {
+	mtx         sync.Mutex
+	knownIDs    map[string]struct{}
+	offsets     []time.Duration
+	offset      time.Duration
+	mockTime    time.Time
+	skewWarning bool
+}
+
+// Ensure the medianTime type implements the MedianTimeSource interface.
+var _ MedianTimeSource = (*medianTime)(nil)
+
+// NewMedianTime returns a new instance of concurrency-safe implementation of
+// the MedianTimeSource interface.
+func NewMedianTime() MedianTimeSource {
+	return &medianTime{
+		knownIDs: make(map[string]struct{}),
+	}
+}
+
+// AdjustedTime returns the current time adjusted by the median time offset as
+// calculated from the time samples added by AddTimeSample.
+//
+// This function is safe for concurrent access and is part of the
+// MedianTimeSource interface implementation.
+func (m *medianTime) AdjustedTime() time.Time {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if !m.mockTime.IsZero() {
+		return m.mockTime
+	}
+	return time.Now().Add(m.offset)
+}
+
+// AddTimeSample adds a time sample that is used when determining the median
+// time of the added samples. Only one sample per id is accepted so that a
+// single peer cannot skew the median by reconnecting.
+//
+// This function is safe for concurrent access and is part of the
+// MedianTimeSource interface implementation.
+func (m *medianTime) AddTimeSample(id string, timeVal time.Time) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if _, exists := m.knownIDs[id]; exists {
+		return
+	}
+	m.knownIDs[id] = struct{}{}
+
+	// Keep a sliding window of the most recent offsets.
+	offset := time.Until(timeVal)
+	if len(m.offsets) == maxMedianTimeEntries {
+		m.offsets = m.offsets[1:]
+	}
+	m.offsets = append(m.offsets, offset)
+
+	if len(m.offsets) < minMedianTimeEntries {
+		return
+	}
+
+	sorted := make([]time.Duration, len(m.offsets))
+	copy(sorted, m.offsets)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	median := sorted[len(sorted)/2]
+
+	if median.Abs() > MaxAllowedOffset {
+		m.offset = 0
+		log.Warn("Median peer time offset exceeds the maximum allowed offset. Ignoring.", log.ArgsFromMap(map[string]any{
+			"offset": median.String(),
+			"max":    MaxAllowedOffset.String(),
+		}))
+		return
+	}
+	m.offset = median
+
+	// Only warn once each time the clock goes out of bounds so we don't
+	// flood the log on every new connection.
+	if median.Abs() > MaxClockSkew {
+		if !m.skewWarning {
+			log.Warn("Local clock differs from the median peer time. Please check your clock.", log.ArgsFromMap(map[string]any{
+				"offset": median.String(),
+			}))
+		}
+		m.skewWarning = true
+	} else {
+		m.skewWarning = false
+	}
+
+	log.Debug("Added time sample", log.ArgsFromMap(map[string]any{
+		"sample":  offset.String(),
+		"offset":  m.offset.String(),
+		"samples": len(m.offsets),
+	}))
+}
+
+// Offset returns the duration to adjust the local clock by based upon the
+// median of the time samples added by AddTimeSample.
+//
+// This function is safe for concurrent access and is part of the
+// MedianTimeSource interface implementation.
+func (m *medianTime) Offset() time.Duration {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	return m.offset
+}
+
+// SetMockTime overrides the clock with the provided time. Passing in the
+// zero time restores the real clock.
+//
+// This function is safe for concurrent access and is part of the
+// MedianTimeSource interface implementation.
+func (m *medianTime) SetMockTime(t time.Time) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.mockTime = t
+}
+
+// AdvanceTime moves the mock time forward by the provided duration and
+// returns the new time.
+//
+// This function is safe for concurrent access and is part of the
+// MedianTimeSource interface implementation.
+func (m *medianTime) AdvanceTime(d time.Duration) time.Time {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if m.mockTime.IsZero() {
+		m.mockTime = time.Now().Add(m.offset)
+	}
+	m.mockTime = m.mockTime.Add(d)
+	return m.mockTime
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestMedianTime(t *testing.T) {
+	tests := []struct {
+		name    string
+		samples []time.Duration
+		want    time.Duration
+	}{
+		{
+			name:    "too few samples",
+			samples: []time.Duration{time.Second * 3, time.Second * 3, time.Second * 3, time.Second * 3},
+			want:    0,
+		},
+		{
+			name:    "median of samples",
+			samples: []time.Duration{time.Second, time.Second * 2, time.Second * 3, time.Second * 4, time.Second * 100},
+			want:    time.Second * 3,
+		},
+		{
+			name:    "negative offset",
+			samples: []time.Duration{-time.Second * 7, -time.Second * 7, -time.Second * 7, 0, 0},
+			want:    -time.Second * 7,
+		},
+		{
+			name:    "exceeds max allowed offset",
+			samples: []time.Duration{time.Hour, time.Hour, time.Hour, time.Hour, time.Hour},
+			want:    0,
+		},
+	}
+
+	for _, test := range tests {
+		mt := NewMedianTime()
+		for i, offset := range test.samples {
+			mt.AddTimeSample(fmt.Sprintf("peer%d", i), time.Now().Add(offset))
+		}
+		assert.InDelta(t, test.want, mt.Offset(), float64(time.Millisecond*100), test.name)
+	}
+
+	// Duplicate samples from the same peer are ignored.
+	mt := NewMedianTime()
+	for i := 0; i < minMedianTimeEntries; i++ {
+		mt.AddTimeSample("peer", time.Now().Add(time.Second*3))
+	}
+	assert.Equal(t, time.Duration(0), mt.Offset())
+}
+
+func TestMockTime(t *testing.T) {
+	mt := NewMedianTime()
+
+	mock := time.Unix(1700000000, 0)
+	mt.SetMockTime(mock)
+	assert.Equal(t, mock, mt.AdjustedTime())
+
+	assert.Equal(t, mock.Add(time.Hour), mt.AdvanceTime(time.Hour))
+	assert.Equal(t, mock.Add(time.Hour), mt.AdjustedTime())
+
+	mt.SetMockTime(time.Time{})
+	assert.WithinDuration(t, time.Now(), mt.AdjustedTime(), time.Second)
+
+	// Advancing without a mock time starts from the current time.
+	assert.WithinDuration(t, time.Now().Add(time.Hour*24), mt.AdvanceTime(time.Hour*24), time.Second)
+}
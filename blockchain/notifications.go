@@ -26,6 +26,9 @@ const (
 	NTRemoveValidator
 	NTValidatorSetUpdate
 	NTNewEpoch
+	// NTForkDetected indicates a competing branch deeper than the fork
+	// alert depth was seen.
+	NTForkDetected
 )
 
 // notificationTypeStrings is a map of notification types back to their constant
@@ -36,6 +39,7 @@ var notificationTypeStrings = map[NotificationType]string{
 	NTRemoveValidator:    "NTRemoveValidator",
 	NTValidatorSetUpdate: "NTValidatorSetUpdate",
 	NTNewEpoch:           "NTNewEpoch",
+	NTForkDetected:       "NTForkDetected",
 }
 
 // String returns the NotificationType in human-readable form.
@@ -50,6 +54,7 @@ func (n NotificationType) String() string {
 // function provided during the call to New and consists of a notification type
 // as well as associated data that depends on the type as follows:
 //   - NTBlockConnected:    *blocks.Block
+//   - NTForkDetected:      *ChainTip
 type Notification struct {
 	Type NotificationType
 	Data interface{}
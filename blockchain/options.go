@@ -16,6 +16,7 @@ const (
 	DefaultMaxNullifiers  = 100000
 	DefaultSigCacheSize   = 100000
 	DefaultProofCacheSize = 100000
+	DefaultForkAlertDepth = 1
 )
 
 // DefaultOptions returns a blockchain configure option that fills in
@@ -29,6 +30,8 @@ func DefaultOptions() Option {
 		cfg.proofCache = NewProofCache(DefaultProofCacheSize)
 		cfg.maxNullifiers = DefaultMaxNullifiers
 		cfg.maxTxoRoots = DefaultMaxTxoRoots
+		cfg.timeSource = NewMedianTime()
+		cfg.forkAlertDepth = DefaultForkAlertDepth
 		return nil
 	}
 }
@@ -128,17 +131,41 @@ func Prune() Option {
 	}
 }
 
+// TimeSource is the median time source used to compute the network
+// adjusted time when validating block timestamps. The caller is
+// responsible for adding peer time samples to it.
+//
+// If this is not provided a new instance will be used.
+func TimeSource(timeSource MedianTimeSource) Option {
+	return func(cfg *config) error {
+		cfg.timeSource = timeSource
+		return nil
+	}
+}
+
+// ForkAlertDepth is the number of blocks a competing branch may extend
+// past the fork point before the chain logs a warning and sends an
+// NTForkDetected notification.
+func ForkAlertDepth(depth uint32) Option {
+	return func(cfg *config) error {
+		cfg.forkAlertDepth = depth
+		return nil
+	}
+}
+
 // Config specifies the blockchain configuration.
 type config struct {
-	params        *params.NetworkParams
-	datastore     repo.Datastore
-	sigCache      *SigCache
-	proofCache    *ProofCache
-	indexManager  IndexManager
-	verifier      zk.Verifier
-	maxNullifiers uint
-	maxTxoRoots   uint
-	prune         bool
+	params         *params.NetworkParams
+	datastore      repo.Datastore
+	sigCache       *SigCache
+	proofCache     *ProofCache
+	indexManager   IndexManager
+	verifier       zk.Verifier
+	timeSource     MedianTimeSource
+	maxNullifiers  uint
+	maxTxoRoots    uint
+	forkAlertDepth uint32
+	prune          bool
 }
 
 func (cfg *config) validate() error {
@@ -322,6 +322,93 @@ func (x *DBAccumulator) GetLookupMap() []*DBAccumulator_LookupMap {
 	return nil
 }
 
+type DBEpochSnapshot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Epoch            int64  `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Height           uint32 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	Block_ID         []byte `protobuf:"bytes,3,opt,name=block_ID,json=blockID,proto3" json:"block_ID,omitempty"`
+	AccumulatorRoot  []byte `protobuf:"bytes,4,opt,name=accumulator_root,json=accumulatorRoot,proto3" json:"accumulator_root,omitempty"`
+	NullifierSetHash []byte `protobuf:"bytes,5,opt,name=nullifier_set_hash,json=nullifierSetHash,proto3" json:"nullifier_set_hash,omitempty"`
+	ValidatorSetHash []byte `protobuf:"bytes,6,opt,name=validator_set_hash,json=validatorSetHash,proto3" json:"validator_set_hash,omitempty"`
+}
+
+func (x *DBEpochSnapshot) Reset() {
+	*x = DBEpochSnapshot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_models_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DBEpochSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DBEpochSnapshot) ProtoMessage() {}
+
+func (x *DBEpochSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_db_models_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DBEpochSnapshot.ProtoReflect.Descriptor instead.
+func (*DBEpochSnapshot) Descriptor() ([]byte, []int) {
+	return file_db_models_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DBEpochSnapshot) GetEpoch() int64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *DBEpochSnapshot) GetHeight() uint32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *DBEpochSnapshot) GetBlock_ID() []byte {
+	if x != nil {
+		return x.Block_ID
+	}
+	return nil
+}
+
+func (x *DBEpochSnapshot) GetAccumulatorRoot() []byte {
+	if x != nil {
+		return x.AccumulatorRoot
+	}
+	return nil
+}
+
+func (x *DBEpochSnapshot) GetNullifierSetHash() []byte {
+	if x != nil {
+		return x.NullifierSetHash
+	}
+	return nil
+}
+
+func (x *DBEpochSnapshot) GetValidatorSetHash() []byte {
+	if x != nil {
+		return x.ValidatorSetHash
+	}
+	return nil
+}
+
 type DBValidator_Nullifier struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -337,7 +424,7 @@ type DBValidator_Nullifier struct {
 func (x *DBValidator_Nullifier) Reset() {
 	*x = DBValidator_Nullifier{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_db_models_proto_msgTypes[4]
+		mi := &file_db_models_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -350,7 +437,7 @@ func (x *DBValidator_Nullifier) String() string {
 func (*DBValidator_Nullifier) ProtoMessage() {}
 
 func (x *DBValidator_Nullifier) ProtoReflect() protoreflect.Message {
-	mi := &file_db_models_proto_msgTypes[4]
+	mi := &file_db_models_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -417,7 +504,7 @@ type DBAccumulator_InclusionProof struct {
 func (x *DBAccumulator_InclusionProof) Reset() {
 	*x = DBAccumulator_InclusionProof{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_db_models_proto_msgTypes[5]
+		mi := &file_db_models_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -430,7 +517,7 @@ func (x *DBAccumulator_InclusionProof) String() string {
 func (*DBAccumulator_InclusionProof) ProtoMessage() {}
 
 func (x *DBAccumulator_InclusionProof) ProtoReflect() protoreflect.Message {
-	mi := &file_db_models_proto_msgTypes[5]
+	mi := &file_db_models_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -500,7 +587,7 @@ type DBAccumulator_LookupMap struct {
 func (x *DBAccumulator_LookupMap) Reset() {
 	*x = DBAccumulator_LookupMap{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_db_models_proto_msgTypes[6]
+		mi := &file_db_models_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -513,7 +600,7 @@ func (x *DBAccumulator_LookupMap) String() string {
 func (*DBAccumulator_LookupMap) ProtoMessage() {}
 
 func (x *DBAccumulator_LookupMap) ProtoReflect() protoreflect.Message {
-	mi := &file_db_models_proto_msgTypes[6]
+	mi := &file_db_models_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -623,8 +710,23 @@ var file_db_models_proto_rawDesc = []byte{
 	0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x6c, 0x61, 0x73, 0x74, 0x1a, 0x33, 0x0a, 0x09, 0x4c, 0x6f,
 	0x6f, 0x6b, 0x75, 0x70, 0x4d, 0x61, 0x70, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64,
-	0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x42,
-	0x07, 0x5a, 0x05, 0x2e, 0x2e, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x22,
+	0xe1, 0x01, 0x0a, 0x0f, 0x44, 0x42, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x53, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x49, 0x44, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x12, 0x29, 0x0a, 0x10,
+	0x61, 0x63, 0x63, 0x75, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x5f, 0x72, 0x6f, 0x6f, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x61, 0x63, 0x63, 0x75, 0x6d, 0x75, 0x6c, 0x61,
+	0x74, 0x6f, 0x72, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x2c, 0x0a, 0x12, 0x6e, 0x75, 0x6c, 0x6c, 0x69,
+	0x66, 0x69, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x10, 0x6e, 0x75, 0x6c, 0x6c, 0x69, 0x66, 0x69, 0x65, 0x72, 0x53, 0x65,
+	0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x2c, 0x0a, 0x12, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
+	0x6f, 0x72, 0x5f, 0x73, 0x65, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x10, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x65, 0x74, 0x48,
+	0x61, 0x73, 0x68, 0x42, 0x07, 0x5a, 0x05, 0x2e, 0x2e, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -639,26 +741,27 @@ func file_db_models_proto_rawDescGZIP() []byte {
 	return file_db_models_proto_rawDescData
 }
 
-var file_db_models_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_db_models_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_db_models_proto_goTypes = []interface{}{
 	(*DBValidator)(nil),                  // 0: DBValidator
 	(*DBTxs)(nil),                        // 1: DBTxs
 	(*DBBlockNode)(nil),                  // 2: DBBlockNode
 	(*DBAccumulator)(nil),                // 3: DBAccumulator
-	(*DBValidator_Nullifier)(nil),        // 4: DBValidator.Nullifier
-	(*DBAccumulator_InclusionProof)(nil), // 5: DBAccumulator.InclusionProof
-	(*DBAccumulator_LookupMap)(nil),      // 6: DBAccumulator.LookupMap
-	(*timestamppb.Timestamp)(nil),        // 7: google.protobuf.Timestamp
-	(*transactions.Transaction)(nil),     // 8: Transaction
+	(*DBEpochSnapshot)(nil),              // 4: DBEpochSnapshot
+	(*DBValidator_Nullifier)(nil),        // 5: DBValidator.Nullifier
+	(*DBAccumulator_InclusionProof)(nil), // 6: DBAccumulator.InclusionProof
+	(*DBAccumulator_LookupMap)(nil),      // 7: DBAccumulator.LookupMap
+	(*timestamppb.Timestamp)(nil),        // 8: google.protobuf.Timestamp
+	(*transactions.Transaction)(nil),     // 9: Transaction
 }
 var file_db_models_proto_depIdxs = []int32{
-	4, // 0: DBValidator.nullifiers:type_name -> DBValidator.Nullifier
-	7, // 1: DBValidator.validator_since:type_name -> google.protobuf.Timestamp
-	8, // 2: DBTxs.transactions:type_name -> Transaction
-	5, // 3: DBAccumulator.proofs:type_name -> DBAccumulator.InclusionProof
-	6, // 4: DBAccumulator.lookupMap:type_name -> DBAccumulator.LookupMap
-	7, // 5: DBValidator.Nullifier.locktime:type_name -> google.protobuf.Timestamp
-	7, // 6: DBValidator.Nullifier.blockstamp:type_name -> google.protobuf.Timestamp
+	5, // 0: DBValidator.nullifiers:type_name -> DBValidator.Nullifier
+	8, // 1: DBValidator.validator_since:type_name -> google.protobuf.Timestamp
+	9, // 2: DBTxs.transactions:type_name -> Transaction
+	6, // 3: DBAccumulator.proofs:type_name -> DBAccumulator.InclusionProof
+	7, // 4: DBAccumulator.lookupMap:type_name -> DBAccumulator.LookupMap
+	8, // 5: DBValidator.Nullifier.locktime:type_name -> google.protobuf.Timestamp
+	8, // 6: DBValidator.Nullifier.blockstamp:type_name -> google.protobuf.Timestamp
 	7, // [7:7] is the sub-list for method output_type
 	7, // [7:7] is the sub-list for method input_type
 	7, // [7:7] is the sub-list for extension type_name
@@ -721,7 +824,7 @@ func file_db_models_proto_init() {
 			}
 		}
 		file_db_models_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DBValidator_Nullifier); i {
+			switch v := v.(*DBEpochSnapshot); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -733,7 +836,7 @@ func file_db_models_proto_init() {
 			}
 		}
 		file_db_models_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DBAccumulator_InclusionProof); i {
+			switch v := v.(*DBValidator_Nullifier); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -745,6 +848,18 @@ func file_db_models_proto_init() {
 			}
 		}
 		file_db_models_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DBAccumulator_InclusionProof); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_models_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DBAccumulator_LookupMap); i {
 			case 0:
 				return &v.state
@@ -763,7 +878,7 @@ func file_db_models_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_db_models_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -9,7 +9,6 @@ import (
 	"github.com/project-illium/ilxd/types"
 	"github.com/project-illium/ilxd/types/transactions"
 	"github.com/project-illium/ilxd/zk"
-	"runtime"
 )
 
 // ValidateTransactionProof validates the zero knowledge proof for a single transaction.
@@ -30,9 +29,6 @@ func ValidateTransactionProof(tx *transactions.Transaction, proofCache *ProofCac
 type proofValidator struct {
 	proofCache *ProofCache
 	verifier   zk.Verifier
-	workChan   chan *transactions.Transaction
-	resultChan chan error
-	done       chan struct{}
 }
 
 // NewProofValidator returns a new ProofValidator.
@@ -41,174 +37,76 @@ func NewProofValidator(proofCache *ProofCache, verifier zk.Verifier) *proofValid
 	return &proofValidator{
 		proofCache: proofCache,
 		verifier:   verifier,
-		workChan:   make(chan *transactions.Transaction),
-		resultChan: make(chan error),
-		done:       make(chan struct{}),
 	}
 }
 
+// cachedProof holds what is needed to add a verified proof to the cache.
+type cachedProof struct {
+	proofHash types.ID
+	proof     []byte
+	txid      types.ID
+}
+
 // Validate validates the transactions proofs in parallel for fast validation.
 // If a proof already exists in the proofCache, the validation will be skipped.
 // If a proof is valid and does not exist in the cache, it will be added to the
 // cache.
 func (p *proofValidator) Validate(txs []*transactions.Transaction) error {
-	defer close(p.done)
-
-	if len(txs) == 0 {
-		return nil
-	}
-
-	maxGoRoutines := runtime.NumCPU() * 3
-	if maxGoRoutines <= 0 {
-		maxGoRoutines = 1
-	}
-	if maxGoRoutines > len(txs) {
-		maxGoRoutines = len(txs)
-	}
-
-	for i := 0; i < maxGoRoutines; i++ {
-		go p.validateHandler()
-	}
-
-	go func() {
-		for _, tx := range txs {
-			p.workChan <- tx
+	var (
+		reqs    = make([]zk.VerifyRequest, 0, len(txs))
+		toCache = make([]cachedProof, 0, len(txs))
+	)
+	for _, t := range txs {
+		var (
+			program  string
+			proof    []byte
+			txid     types.ID
+			toParams func() (zk.Parameters, error)
+		)
+		switch tx := t.GetTx().(type) {
+		case *transactions.Transaction_StandardTransaction:
+			program, proof, txid = zk.StandardValidationProgram(), tx.StandardTransaction.Proof, tx.StandardTransaction.ID()
+			toParams = tx.StandardTransaction.ToCircuitParams
+		case *transactions.Transaction_CoinbaseTransaction:
+			program, proof, txid = zk.CoinbaseValidationProgram(), tx.CoinbaseTransaction.Proof, tx.CoinbaseTransaction.ID()
+			toParams = tx.CoinbaseTransaction.ToCircuitParams
+		case *transactions.Transaction_TreasuryTransaction:
+			program, proof, txid = zk.TreasuryValidationProgram(), tx.TreasuryTransaction.Proof, tx.TreasuryTransaction.ID()
+			toParams = tx.TreasuryTransaction.ToCircuitParams
+		case *transactions.Transaction_MintTransaction:
+			program, proof, txid = zk.MintValidationProgram(), tx.MintTransaction.Proof, tx.MintTransaction.ID()
+			toParams = tx.MintTransaction.ToCircuitParams
+		case *transactions.Transaction_StakeTransaction:
+			program, proof, txid = zk.StakeValidationProgram(), tx.StakeTransaction.Proof, tx.StakeTransaction.ID()
+			toParams = tx.StakeTransaction.ToCircuitParams
+		default:
+			continue
 		}
-		close(p.workChan)
-	}()
-
-	for i := 0; i < len(txs); i++ {
-		err := <-p.resultChan
+		proofHash := types.NewIDFromData(proof)
+		if p.proofCache.Exists(proofHash, proof, txid) {
+			continue
+		}
+		params, err := toParams()
 		if err != nil {
 			return ruleError(ErrInvalidTx, fmt.Sprintf("proof validation error: %s", err.Error()))
 		}
+		reqs = append(reqs, zk.VerifyRequest{
+			Program:      program,
+			PublicParams: params,
+			Proof:        proof,
+		})
+		toCache = append(toCache, cachedProof{
+			proofHash: proofHash,
+			proof:     proof,
+			txid:      txid,
+		})
 	}
-	return nil
-}
 
-func (p *proofValidator) validateHandler() {
-	for {
-		select {
-		case t := <-p.workChan:
-			switch tx := t.GetTx().(type) {
-			case *transactions.Transaction_StandardTransaction:
-				proofHash := types.NewIDFromData(tx.StandardTransaction.Proof)
-				exists := p.proofCache.Exists(proofHash, tx.StandardTransaction.Proof, tx.StandardTransaction.ID())
-				if exists {
-					p.resultChan <- nil
-					break
-				}
-				params, err := tx.StandardTransaction.ToCircuitParams()
-				if err != nil {
-					p.resultChan <- err
-					break
-				}
-				valid, err := p.verifier.Verify(zk.StandardValidationProgram(), params, tx.StandardTransaction.Proof)
-				if err != nil {
-					p.resultChan <- err
-					break
-				}
-				if !valid {
-					p.resultChan <- ruleError(ErrInvalidTx, "invalid proof")
-					break
-				}
-				p.proofCache.Add(proofHash, tx.StandardTransaction.Proof, tx.StandardTransaction.ID())
-				p.resultChan <- nil
-			case *transactions.Transaction_CoinbaseTransaction:
-				proofHash := types.NewIDFromData(tx.CoinbaseTransaction.Proof)
-				exists := p.proofCache.Exists(proofHash, tx.CoinbaseTransaction.Proof, tx.CoinbaseTransaction.ID())
-				if exists {
-					p.resultChan <- nil
-					break
-				}
-				params, err := tx.CoinbaseTransaction.ToCircuitParams()
-				if err != nil {
-					p.resultChan <- err
-					break
-				}
-				valid, err := p.verifier.Verify(zk.CoinbaseValidationProgram(), params, tx.CoinbaseTransaction.Proof)
-				if err != nil {
-					p.resultChan <- err
-					break
-				}
-				if !valid {
-					p.resultChan <- ruleError(ErrInvalidTx, "invalid proof")
-					break
-				}
-				p.proofCache.Add(proofHash, tx.CoinbaseTransaction.Proof, tx.CoinbaseTransaction.ID())
-				p.resultChan <- nil
-			case *transactions.Transaction_TreasuryTransaction:
-				proofHash := types.NewIDFromData(tx.TreasuryTransaction.Proof)
-				exists := p.proofCache.Exists(proofHash, tx.TreasuryTransaction.Proof, tx.TreasuryTransaction.ID())
-				if exists {
-					p.resultChan <- nil
-					break
-				}
-				params, err := tx.TreasuryTransaction.ToCircuitParams()
-				if err != nil {
-					p.resultChan <- err
-					break
-				}
-				valid, err := p.verifier.Verify(zk.TreasuryValidationProgram(), params, tx.TreasuryTransaction.Proof)
-				if err != nil {
-					p.resultChan <- err
-					break
-				}
-				if !valid {
-					p.resultChan <- ruleError(ErrInvalidTx, "invalid proof")
-					break
-				}
-				p.proofCache.Add(proofHash, tx.TreasuryTransaction.Proof, tx.TreasuryTransaction.ID())
-				p.resultChan <- nil
-			case *transactions.Transaction_MintTransaction:
-				proofHash := types.NewIDFromData(tx.MintTransaction.Proof)
-				exists := p.proofCache.Exists(proofHash, tx.MintTransaction.Proof, tx.MintTransaction.ID())
-				if exists {
-					p.resultChan <- nil
-					break
-				}
-				params, err := tx.MintTransaction.ToCircuitParams()
-				if err != nil {
-					p.resultChan <- err
-					break
-				}
-				valid, err := p.verifier.Verify(zk.MintValidationProgram(), params, tx.MintTransaction.Proof)
-				if err != nil {
-					p.resultChan <- err
-					break
-				}
-				if !valid {
-					p.resultChan <- ruleError(ErrInvalidTx, "invalid proof")
-					break
-				}
-				p.proofCache.Add(proofHash, tx.MintTransaction.Proof, tx.MintTransaction.ID())
-				p.resultChan <- nil
-			case *transactions.Transaction_StakeTransaction:
-				proofHash := types.NewIDFromData(tx.StakeTransaction.Proof)
-				exists := p.proofCache.Exists(proofHash, tx.StakeTransaction.Proof, tx.StakeTransaction.ID())
-				if exists {
-					p.resultChan <- nil
-					break
-				}
-				params, err := tx.StakeTransaction.ToCircuitParams()
-				if err != nil {
-					p.resultChan <- err
-					break
-				}
-				valid, err := p.verifier.Verify(zk.StakeValidationProgram(), params, tx.StakeTransaction.Proof)
-				if err != nil {
-					p.resultChan <- err
-					break
-				}
-				if !valid {
-					p.resultChan <- ruleError(ErrInvalidTx, "invalid proof")
-					break
-				}
-				p.proofCache.Add(proofHash, tx.StakeTransaction.Proof, tx.StakeTransaction.ID())
-				p.resultChan <- nil
-			}
-		case <-p.done:
-			return
-		}
+	if err := zk.VerifyBatch(p.verifier, reqs); err != nil {
+		return ruleError(ErrInvalidTx, fmt.Sprintf("proof validation error: %s", err.Error()))
 	}
+	for _, c := range toCache {
+		p.proofCache.Add(c.proofHash, c.proof, c.txid)
+	}
+	return nil
 }
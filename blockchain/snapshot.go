@@ -0,0 +1,274 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"errors"
+	"github.com/ipfs/go-datastore"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/project-illium/ilxd/repo"
+	"github.com/project-illium/ilxd/types"
+	"github.com/project-illium/ilxd/types/blocks"
+	"time"
+)
+
+// ChainSnapshot is an immutable view of the chain state as of a single
+// block. A new snapshot is published each time the chain state changes
+// so reads served from a snapshot never block on, or observe part of, a
+// block being connected.
+//
+// A snapshot holds no references to the live chain state. Everything
+// except blocks and headers is copied when it is built. Blocks and
+// headers are loaded from the datastore, which is safe because a
+// snapshot is only published once its blocks are committed to disk and
+// finality means the blocks at or below its tip never change. Blocks
+// connected after the snapshot was taken are filtered out by height.
+//
+// Callers which make several reads and need them to agree with each
+// other, such as an RPC reporting the tip along with the supply, should
+// take one snapshot and make all the reads against it.
+type ChainSnapshot struct {
+	blockID   types.ID
+	height    uint32
+	timestamp time.Time
+
+	ds            repo.Datastore
+	accumulator   *Accumulator
+	validators    map[peer.ID]*Validator
+	totalStaked   types.Amount
+	totalWeighted types.Amount
+	treasury      types.Amount
+	supply        types.Amount
+	matureAt      uint32
+}
+
+// newChainSnapshot builds a snapshot of the current chain state. The
+// state must not change while this is running. Normally this means the
+// stateLock is held for writing.
+func (b *Blockchain) newChainSnapshot() (*ChainSnapshot, error) {
+	treasury, err := dsFetchTreasuryBalance(b.ds)
+	if err != nil {
+		return nil, err
+	}
+	supply, err := dsFetchCurrentSupply(b.ds)
+	if err != nil {
+		return nil, err
+	}
+
+	tip := b.index.Tip()
+	snap := &ChainSnapshot{
+		blockID:     tip.blockID,
+		height:      tip.height,
+		timestamp:   time.Unix(tip.timestamp, 0),
+		ds:          b.ds,
+		accumulator: b.accumulatorDB.committed(),
+		treasury:    treasury,
+		supply:      supply,
+		matureAt:    b.rewardsMatureAt(),
+	}
+
+	b.validatorSet.mtx.RLock()
+	defer b.validatorSet.mtx.RUnlock()
+
+	snap.validators = make(map[peer.ID]*Validator, len(b.validatorSet.validators))
+	for id, val := range b.validatorSet.validators {
+		v := &Validator{}
+		copyValidator(v, val)
+		snap.validators[id] = v
+		snap.totalStaked += v.TotalStake
+		snap.totalWeighted += v.WeightedStake
+	}
+	return snap, nil
+}
+
+// publishSnapshot replaces the current snapshot with one of the current
+// chain state. If the snapshot cannot be built the error is logged and
+// readers continue to see the prior state.
+func (b *Blockchain) publishSnapshot() {
+	snap, err := b.newChainSnapshot()
+	if err != nil {
+		log.WithCaller(true).Error("Error publishing chain snapshot", log.Args("error", err))
+		return
+	}
+	b.snapshotLock.Lock()
+	b.snapshot = snap
+	b.snapshotLock.Unlock()
+}
+
+// Snapshot returns the most recent snapshot of the chain state.
+//
+// This does not wait for a block that is currently being connected.
+func (b *Blockchain) Snapshot() *ChainSnapshot {
+	b.snapshotLock.RLock()
+	defer b.snapshotLock.RUnlock()
+
+	return b.snapshot
+}
+
+// BestBlock returns the ID, height, and timestamp of the block at the tip
+// of the snapshot.
+func (s *ChainSnapshot) BestBlock() (types.ID, uint32, time.Time) {
+	return s.blockID, s.height, s.timestamp
+}
+
+// GetBlockByHeight returns the block at the given height. The block will be loaded from disk.
+func (s *ChainSnapshot) GetBlockByHeight(height uint32) (*blocks.Block, error) {
+	blockID, err := s.blockIDByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	return dsFetchBlock(s.ds, blockID)
+}
+
+// GetBlockByID returns the block with the given ID. The block will be loaded from disk.
+func (s *ChainSnapshot) GetBlockByID(blockID types.ID) (*blocks.Block, error) {
+	blk, err := dsFetchBlock(s.ds, blockID)
+	if err != nil {
+		return nil, err
+	}
+	if blk.Header.Height > s.height {
+		return nil, datastore.ErrNotFound
+	}
+	return blk, nil
+}
+
+// GetBlockIDByHeight returns the ID of the block at the given height.
+func (s *ChainSnapshot) GetBlockIDByHeight(height uint32) (types.ID, error) {
+	return s.blockIDByHeight(height)
+}
+
+// GetHeaderByHeight returns the header at the given height. The header will be loaded from disk.
+func (s *ChainSnapshot) GetHeaderByHeight(height uint32) (*blocks.BlockHeader, error) {
+	blockID, err := s.blockIDByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	return dsFetchHeader(s.ds, blockID)
+}
+
+// GetBlockHeight returns the height of the block with the given ID.
+func (s *ChainSnapshot) GetBlockHeight(blkID types.ID) (uint32, error) {
+	header, err := s.headerByID(blkID)
+	if err != nil {
+		return 0, err
+	}
+	return header.Height, nil
+}
+
+// HasBlock returns whether the block exists in the snapshot.
+func (s *ChainSnapshot) HasBlock(blockID types.ID) bool {
+	_, err := s.headerByID(blockID)
+	return err == nil
+}
+
+// TreasuryBalance returns the balance of the treasury.
+func (s *ChainSnapshot) TreasuryBalance() types.Amount {
+	return s.treasury
+}
+
+// CurrentSupply returns the circulating supply of coins.
+func (s *ChainSnapshot) CurrentSupply() types.Amount {
+	return s.supply
+}
+
+// GetValidator returns the validator for the given ID
+func (s *ChainSnapshot) GetValidator(validatorID peer.ID) (*Validator, error) {
+	val, ok := s.validators[validatorID]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	ret := &Validator{}
+	copyValidator(ret, val)
+	return ret, nil
+}
+
+// ValidatorExists returns whether the validator exists in the set.
+func (s *ChainSnapshot) ValidatorExists(validatorID peer.ID) bool {
+	_, ok := s.validators[validatorID]
+	return ok
+}
+
+// Validators returns the full list of validators.
+func (s *ChainSnapshot) Validators() []*Validator {
+	ret := make([]*Validator, 0, len(s.validators))
+	for _, val := range s.validators {
+		v := &Validator{}
+		copyValidator(v, val)
+		ret = append(ret, v)
+	}
+	return ret
+}
+
+// IsStaked returns whether the nullifier is staked with any of the
+// validators in the set.
+func (s *ChainSnapshot) IsStaked(nullifier types.Nullifier) bool {
+	for _, val := range s.validators {
+		if _, ok := val.Nullifiers[nullifier]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidatorSetSize returns the number of validators in the validator set.
+func (s *ChainSnapshot) ValidatorSetSize() int {
+	return len(s.validators)
+}
+
+// TotalStaked returns the total number of coins staked in the validator set.
+func (s *ChainSnapshot) TotalStaked() types.Amount {
+	return s.totalStaked
+}
+
+// TotalStakeWeight returns the total number of coins staked in the validator set
+// weighted by time locks.
+func (s *ChainSnapshot) TotalStakeWeight() types.Amount {
+	return s.totalWeighted
+}
+
+// RewardsMatureAt returns the height of the first block which may include
+// a coinbase transaction claiming the current epoch's rewards.
+func (s *ChainSnapshot) RewardsMatureAt() uint32 {
+	return s.matureAt
+}
+
+// TxoRoot returns the root of the accumulator as of the snapshot's tip.
+// Transactions built against this state use it as their txo root.
+func (s *ChainSnapshot) TxoRoot() types.ID {
+	return s.accumulator.Root()
+}
+
+// GetInclusionProof returns an inclusion proof for the input if the blockchain scanner
+// had the encryption key *before* the commitment was processed in a block.
+func (s *ChainSnapshot) GetInclusionProof(commitment types.ID) (*InclusionProof, types.ID, error) {
+	proof, err := s.accumulator.GetProof(commitment.Bytes())
+	return proof, s.blockID, err
+}
+
+// blockIDByHeight returns the ID of the block at the height if it is
+// at or below the snapshot's tip.
+func (s *ChainSnapshot) blockIDByHeight(height uint32) (types.ID, error) {
+	if height > s.height {
+		return types.ID{}, errors.New("height beyond chain tip")
+	}
+	if height == s.height {
+		return s.blockID, nil
+	}
+	return dsFetchBlockIDFromHeight(s.ds, height)
+}
+
+// headerByID returns the header for the ID if the block is at or below
+// the snapshot's tip. Blocks connected after the snapshot was taken may
+// already be on disk, so they are filtered out by height.
+func (s *ChainSnapshot) headerByID(blockID types.ID) (*blocks.BlockHeader, error) {
+	header, err := dsFetchHeader(s.ds, blockID)
+	if err != nil {
+		return nil, err
+	}
+	if header.Height > s.height {
+		return nil, datastore.ErrNotFound
+	}
+	return header, nil
+}
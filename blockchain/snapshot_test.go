@@ -0,0 +1,199 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package blockchain_test
+
+import (
+	"github.com/project-illium/ilxd/blockchain"
+	"github.com/project-illium/ilxd/blockchain/harness"
+	"github.com/project-illium/ilxd/repo/mock"
+	"github.com/project-illium/ilxd/types/blocks"
+	"github.com/project-illium/ilxd/zk"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestChainSnapshot(t *testing.T) {
+	testHarness, err := harness.NewTestHarness(harness.DefaultOptions())
+	assert.NoError(t, err)
+
+	assert.NoError(t, testHarness.GenerateBlocks(5))
+
+	verifier := &zk.MockVerifier{}
+	verifier.SetValid(true)
+	chain, err := blockchain.NewBlockchain(blockchain.DefaultOptions(), blockchain.Params(testHarness.Blockchain().Params()), blockchain.Verifier(verifier))
+	assert.NoError(t, err)
+
+	for i := uint32(1); i < 4; i++ {
+		blk, err := testHarness.Blockchain().GetBlockByHeight(i)
+		assert.NoError(t, err)
+		assert.NoError(t, chain.ConnectBlock(blk, blockchain.BFNone))
+	}
+
+	snap := chain.Snapshot()
+	id, height, _ := snap.BestBlock()
+	assert.Equal(t, uint32(3), height)
+	supply := snap.CurrentSupply()
+	treasury := snap.TreasuryBalance()
+
+	blk, err := testHarness.Blockchain().GetBlockByHeight(4)
+	assert.NoError(t, err)
+	assert.NoError(t, chain.ConnectBlock(blk, blockchain.BFNone))
+
+	// The old snapshot does not see the new block.
+	id2, height2, _ := snap.BestBlock()
+	assert.Equal(t, id, id2)
+	assert.Equal(t, uint32(3), height2)
+	assert.Equal(t, supply, snap.CurrentSupply())
+	assert.Equal(t, treasury, snap.TreasuryBalance())
+	assert.False(t, snap.HasBlock(blk.ID()))
+	_, err = snap.GetBlockByHeight(4)
+	assert.Error(t, err)
+	_, err = snap.GetBlockHeight(blk.ID())
+	assert.Error(t, err)
+
+	// The chain serves the new snapshot.
+	id3, height3, _ := chain.BestBlock()
+	assert.Equal(t, blk.ID(), id3)
+	assert.Equal(t, uint32(4), height3)
+	assert.True(t, chain.HasBlock(blk.ID()))
+	assert.True(t, chain.Snapshot().HasBlock(blk.ID()))
+}
+
+func TestRewardsMatureAtAfterRestart(t *testing.T) {
+	f, err := harness.BlocksData.Open("blocks/blocks.dat")
+	assert.NoError(t, err)
+	testHarness, err := harness.NewTestHarness(harness.DefaultOptions(), harness.LoadBlocks(f, 200))
+	assert.NoError(t, err)
+
+	chainParams := *testHarness.Blockchain().Params()
+	chainParams.CoinbaseMaturity = 5
+
+	verifier := &zk.MockVerifier{}
+	verifier.SetValid(true)
+	ds := mock.NewMapDatastore()
+	chain, err := blockchain.NewBlockchain(blockchain.DefaultOptions(), blockchain.Datastore(ds), blockchain.Params(&chainParams), blockchain.Verifier(verifier))
+	assert.NoError(t, err)
+
+	for i := uint32(1); i < 200; i++ {
+		blk, err := testHarness.Blockchain().GetBlockByHeight(i)
+		assert.NoError(t, err)
+		assert.NoError(t, chain.ConnectBlock(blk, blockchain.BFFastAdd))
+	}
+	matureAt := chain.RewardsMatureAt()
+	assert.Greater(t, matureAt, chainParams.CoinbaseMaturity)
+
+	// The epoch height is derived from the chain when the node restarts.
+	chain2, err := blockchain.NewBlockchain(blockchain.DefaultOptions(), blockchain.Datastore(ds), blockchain.Params(&chainParams), blockchain.Verifier(verifier))
+	assert.NoError(t, err)
+	assert.Equal(t, matureAt, chain2.RewardsMatureAt())
+}
+
+func TestChainSnapshotDuringBatch(t *testing.T) {
+	f, err := harness.BlocksData.Open("blocks/blocks.dat")
+	assert.NoError(t, err)
+	testHarness, err := harness.NewTestHarness(harness.DefaultOptions(), harness.LoadBlocks(f, 20))
+	assert.NoError(t, err)
+
+	verifier := &zk.MockVerifier{}
+	verifier.SetValid(true)
+	chain, err := blockchain.NewBlockchain(blockchain.DefaultOptions(), blockchain.Params(testHarness.Blockchain().Params()), blockchain.Verifier(verifier))
+	assert.NoError(t, err)
+
+	for i := uint32(1); i < 5; i++ {
+		blk, err := testHarness.Blockchain().GetBlockByHeight(i)
+		assert.NoError(t, err)
+		assert.NoError(t, chain.ConnectBlock(blk, blockchain.BFFastAdd))
+	}
+	snap := chain.Snapshot()
+	id, height, _ := snap.BestBlock()
+	assert.Equal(t, uint32(4), height)
+
+	connected := make(chan uint32, 20)
+	chain.Subscribe(func(n *blockchain.Notification) {
+		if n.Type == blockchain.NTBlockConnected {
+			connected <- n.Data.(*blocks.Block).Header.Height
+		}
+	})
+
+	batch, err := chain.BlockBatch()
+	assert.NoError(t, err)
+	var last *blocks.Block
+	for i := uint32(5); i < 10; i++ {
+		last, err = testHarness.Blockchain().GetBlockByHeight(i)
+		assert.NoError(t, err)
+		assert.NoError(t, batch.AddBlock(last, blockchain.BFFastAdd))
+	}
+	for h := range connected {
+		if h == last.Header.Height {
+			break
+		}
+	}
+
+	// Every block in the batch is connected but none are committed so
+	// readers still see the pre-batch state.
+	id2, height2, _ := chain.BestBlock()
+	assert.Equal(t, id, id2)
+	assert.Equal(t, uint32(4), height2)
+	assert.False(t, chain.HasBlock(last.ID()))
+	_, err = chain.GetBlockByHeight(5)
+	assert.Error(t, err)
+	blk, err := chain.GetBlockByHeight(4)
+	assert.NoError(t, err)
+	assert.Equal(t, id, blk.ID())
+
+	assert.NoError(t, batch.Commit())
+
+	id3, height3, _ := chain.BestBlock()
+	assert.Equal(t, last.ID(), id3)
+	assert.Equal(t, uint32(9), height3)
+	assert.True(t, chain.HasBlock(last.ID()))
+	blk, err = chain.GetBlockByHeight(9)
+	assert.NoError(t, err)
+	assert.Equal(t, last.ID(), blk.ID())
+
+	// The old snapshot is unchanged by the commit.
+	_, height4, _ := snap.BestBlock()
+	assert.Equal(t, uint32(4), height4)
+	assert.False(t, snap.HasBlock(last.ID()))
+	_, err = snap.GetBlockByHeight(9)
+	assert.Error(t, err)
+	_, err = snap.GetBlockByID(last.ID())
+	assert.Error(t, err)
+}
+
+func TestChainSnapshotBatchError(t *testing.T) {
+	f, err := harness.BlocksData.Open("blocks/blocks.dat")
+	assert.NoError(t, err)
+	testHarness, err := harness.NewTestHarness(harness.DefaultOptions(), harness.LoadBlocks(f, 20))
+	assert.NoError(t, err)
+
+	verifier := &zk.MockVerifier{}
+	verifier.SetValid(true)
+	chain, err := blockchain.NewBlockchain(blockchain.DefaultOptions(), blockchain.Params(testHarness.Blockchain().Params()), blockchain.Verifier(verifier))
+	assert.NoError(t, err)
+
+	batch, err := chain.BlockBatch()
+	assert.NoError(t, err)
+	for i := uint32(1); i < 5; i++ {
+		blk, err := testHarness.Blockchain().GetBlockByHeight(i)
+		assert.NoError(t, err)
+		assert.NoError(t, batch.AddBlock(blk, blockchain.BFFastAdd))
+	}
+	// Skipping a height makes the rest of the batch fail validation.
+	for i := uint32(6); i < 8; i++ {
+		blk, err := testHarness.Blockchain().GetBlockByHeight(i)
+		assert.NoError(t, err)
+		assert.NoError(t, batch.AddBlock(blk, blockchain.BFFastAdd))
+	}
+	assert.Error(t, batch.Commit())
+
+	// The blocks before the invalid one are committed and visible.
+	tip, err := testHarness.Blockchain().GetBlockByHeight(4)
+	assert.NoError(t, err)
+	id, height, _ := chain.BestBlock()
+	assert.Equal(t, tip.ID(), id)
+	assert.Equal(t, uint32(4), height)
+	assert.True(t, chain.HasBlock(tip.ID()))
+}
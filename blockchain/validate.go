@@ -6,12 +6,19 @@ package blockchain
 
 import (
 	"bytes"
+	"context"
+	"fmt"
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/project-illium/ilxd/params"
 	"github.com/project-illium/ilxd/params/hash"
 	"github.com/project-illium/ilxd/types"
 	"github.com/project-illium/ilxd/types/blocks"
 	"github.com/project-illium/ilxd/types/transactions"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"sort"
 	"time"
 )
 
@@ -24,8 +31,9 @@ const (
 
 	MaxDocumentHashLen = 68
 
-	MaxTransactionSize = 1000000
-
+	// MaxBlockFutureTime is the maximum drift allowed between a block
+	// timestamp and the network adjusted time if the network params
+	// do not set MaxBlockFutureDrift.
 	MaxBlockFutureTime = time.Second * 10
 
 	RestakePeriod = time.Hour * 24 * 7
@@ -93,12 +101,24 @@ func (b *Blockchain) checkBlockContext(header *blocks.BlockHeader) error {
 	}
 
 	if header.Timestamp <= tip.Timestamp() {
-		return ruleError(ErrInvalidTimestamp, "timestamp is too early")
+		return ruleError(ErrInvalidTimestamp, fmt.Sprintf("block timestamp %d is not after parent timestamp %d", header.Timestamp, tip.Timestamp()))
+	}
+	if header.Height >= b.params.MedianTimePastHeight {
+		medianTime, err := b.medianTimePast()
+		if err != nil {
+			return err
+		}
+		if header.Timestamp <= medianTime {
+			return ruleError(ErrMedianTimePast, fmt.Sprintf("block timestamp %d is not after median time past %d", header.Timestamp, medianTime))
+		}
 	}
-	// The block timestamp is not allowed to be too far ahead of our local clock.
-	// Because this block *may* still become valid as our clock advances we will
-	// mark it as an orphan which will allow us to process it again later.
-	if time.Unix(header.Timestamp, 0).After(time.Now().Add(MaxBlockFutureTime)) {
+	// The block timestamp is not allowed to be too far ahead of the network
+	// adjusted time. Because this block *may* still become valid as our clock
+	// advances we will mark it as an orphan which will allow us to process it
+	// again later.
+	maxDrift := b.maxBlockFutureDrift()
+	adjustedTime := b.timeSource.AdjustedTime()
+	if time.Unix(header.Timestamp, 0).After(adjustedTime.Add(maxDrift)) {
 		return OrphanBlockError("block timestamp is too far in the future")
 	}
 
@@ -120,6 +140,48 @@ func (b *Blockchain) checkBlockContext(header *blocks.BlockHeader) error {
 	return nil
 }
 
+// medianTimePast returns the median timestamp of the last MedianTimeBlocks
+// blocks ending at the tip of the chain. If the chain is shorter than
+// MedianTimeBlocks the median of all blocks is returned.
+func (b *Blockchain) medianTimePast() (int64, error) {
+	n := b.params.MedianTimeBlocks
+	if n == 0 {
+		return 0, nil
+	}
+	tip := b.index.Tip()
+	timestamps := make([]int64, 0, n)
+	for height := tip.Height(); uint32(len(timestamps)) < n; height-- {
+		node, err := b.index.GetNodeByHeight(height)
+		if err != nil {
+			return 0, err
+		}
+		timestamp := node.Timestamp()
+		if timestamp == 0 {
+			// Nodes loaded from the db only contain the ID and height.
+			header, err := node.Header()
+			if err != nil {
+				return 0, err
+			}
+			timestamp = header.Timestamp
+		}
+		timestamps = append(timestamps, timestamp)
+		if height == 0 {
+			break
+		}
+	}
+	sort.Slice(timestamps, func(i, j int) bool { return timestamps[i] < timestamps[j] })
+	return timestamps[len(timestamps)/2], nil
+}
+
+// maxBlockFutureDrift returns the maximum amount of time a block timestamp
+// may be ahead of the network adjusted time.
+func (b *Blockchain) maxBlockFutureDrift() time.Duration {
+	if b.params.MaxBlockFutureDrift > 0 {
+		return b.params.MaxBlockFutureDrift
+	}
+	return MaxBlockFutureTime
+}
+
 // validateHeader validates the transaction header. No blockchain context is needed for this validation.
 func (b *Blockchain) validateHeader(header *blocks.BlockHeader, flags BehaviorFlags) error {
 	if header == nil {
@@ -159,15 +221,53 @@ func (b *Blockchain) validateHeader(header *blocks.BlockHeader, flags BehaviorFl
 //
 // Returns the index of the transaction that was invalid or -1 if none were determined
 // to be invalid.
-func (b *Blockchain) validateBlock(blk *blocks.Block, flags BehaviorFlags) (int, error) {
+func (b *Blockchain) validateBlock(blk *blocks.Block, flags BehaviorFlags) (invalidIdx int, err error) {
+	ctx, span := tracer.Start(context.Background(), "blockchain.validateBlock", trace.WithAttributes(
+		attribute.String("illium.block_id", blk.ID().String()),
+		attribute.Int64("illium.height", int64(blk.Header.Height)),
+		attribute.Int("illium.tx_count", len(blk.Transactions)),
+	))
+	// Each validation stage gets its own child span. Starting a new
+	// stage ends the previous one.
+	var stage trace.Span
+	startStage := func(name string) {
+		if stage != nil {
+			stage.End()
+		}
+		_, stage = tracer.Start(ctx, name)
+	}
+	defer func() {
+		if err != nil {
+			stage.RecordError(err)
+			stage.SetStatus(codes.Error, err.Error())
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		stage.End()
+		span.End()
+	}()
+
+	startStage("blockchain.validateHeader")
 	if err := b.validateHeader(blk.Header, flags); err != nil {
 		return -1, err
 	}
 
+	startStage("blockchain.validateTransactions")
+
 	if len(blk.Transactions) == 0 {
 		return -1, ruleError(ErrEmptyBlock, "block contains zero transactions")
 	}
 
+	if blk.Header.Height >= b.params.MaxBlockSizeHeight {
+		size, err := blk.SerializedSize()
+		if err != nil {
+			return -1, err
+		}
+		if uint32(size) > b.params.MaxBlockSize {
+			return -1, ruleError(ErrMaxBlockSize, "block exceeds max block size")
+		}
+	}
+
 	calculatedTxRoot := TransactionsMerkleRoot(blk.Transactions)
 
 	if !bytes.Equal(calculatedTxRoot[:], blk.Header.TxRoot) {
@@ -190,7 +290,7 @@ func (b *Blockchain) validateBlock(blk *blocks.Block, flags BehaviorFlags) (int,
 			}
 			lastTxid = t.ID()
 		}
-		if err := CheckTransactionSanity(t, time.Unix(blk.Header.Timestamp, 0)); err != nil {
+		if err := CheckTransactionSanity(t, b.params, time.Unix(blk.Header.Timestamp, 0)); err != nil {
 			return i, err
 		}
 		switch tx := t.Tx.(type) {
@@ -210,6 +310,9 @@ func (b *Blockchain) validateBlock(blk *blocks.Block, flags BehaviorFlags) (int,
 				if types.Amount(tx.CoinbaseTransaction.NewCoins) != validator.UnclaimedCoins || tx.CoinbaseTransaction.NewCoins == 0 {
 					return i, ruleError(ErrInvalidTx, "coinbase transaction creates invalid number of coins")
 				}
+				if blk.Header.Height < b.rewardsMatureAt() {
+					return i, ruleError(ErrImmatureCoinbase, "coinbase claims rewards which have not matured")
+				}
 				blockCoinbases[validatorID] = true
 			}
 		case *transactions.Transaction_StakeTransaction:
@@ -350,10 +453,12 @@ func (b *Blockchain) validateBlock(blk *blocks.Block, flags BehaviorFlags) (int,
 	}
 
 	if !flags.HasFlag(BFFastAdd) {
+		startStage("blockchain.validateProofs")
 		proofValidator := NewProofValidator(b.proofCache, b.verifier)
 		if err := proofValidator.Validate(blk.Transactions); err != nil {
 			return -1, err
 		}
+		startStage("blockchain.validateSignatures")
 		sigValidator := NewSigValidator(b.sigCache)
 		if err := sigValidator.Validate(blk.Transactions); err != nil {
 			return -1, err
@@ -365,7 +470,7 @@ func (b *Blockchain) validateBlock(blk *blocks.Block, flags BehaviorFlags) (int,
 
 // CheckTransactionSanity performs a sanity check on the transaction. No blockchain context
 // is considered by this function.
-func CheckTransactionSanity(t *transactions.Transaction, blockTime time.Time) error {
+func CheckTransactionSanity(t *transactions.Transaction, params *params.NetworkParams, blockTime time.Time) error {
 	if t.Tx == nil {
 		return ruleError(ErrInvalidTx, "missing inner protobuf transaction")
 	}
@@ -452,7 +557,7 @@ func CheckTransactionSanity(t *transactions.Transaction, blockTime time.Time) er
 	if err != nil {
 		return err
 	}
-	if size > MaxTransactionSize {
+	if uint32(size) > params.MaxTransactionSize {
 		return ruleError(ErrInvalidTx, "transaction too large")
 	}
 	return nil
@@ -476,8 +581,41 @@ func ValidateLocktime(blocktime time.Time, locktime *transactions.Locktime) bool
 	return false
 }
 
+// NewExpiringLocktime returns a locktime whose window opens no later than
+// start and closes at expiry. A transaction using this locktime cannot be
+// included in a block timestamped at or after expiry, so once the chain
+// passes that time the transaction's inputs may safely be spent again.
+func NewExpiringLocktime(start, expiry time.Time) *transactions.Locktime {
+	precision := (expiry.Unix() - start.Unix() + 1) / 2
+	return &transactions.Locktime{
+		Timestamp: expiry.Unix() - precision,
+		Precision: precision,
+	}
+}
+
+// TransactionExpiry returns the time at which the transaction's locktime
+// window closes. After this time the transaction can never be included in
+// a block. False is returned if the transaction does not expire.
+func TransactionExpiry(tx *transactions.Transaction) (time.Time, bool) {
+	var locktime *transactions.Locktime
+	switch t := tx.GetTx().(type) {
+	case *transactions.Transaction_StandardTransaction:
+		locktime = t.StandardTransaction.Locktime
+	case *transactions.Transaction_MintTransaction:
+		locktime = t.MintTransaction.Locktime
+	}
+	if locktime == nil || locktime.Timestamp <= 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(locktime.Timestamp, 0).Add(time.Duration(locktime.Precision) * time.Second), true
+}
+
 // validateOutputs makes sure the output fields do not exceed a certain length. Protobuf
 // does not enforce size restrictions so we have to do it here.
+//
+// Every transaction type that spends inputs, other than stake transactions,
+// must have at least one output. The delegated stake script relies on this
+// to only allow the delegate key to stake the coins.
 func validateOutputs(outputs []*transactions.Output) error {
 	if len(outputs) == 0 {
 		return ruleError(ErrInvalidTx, "transaction has no outputs")
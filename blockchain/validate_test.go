@@ -120,6 +120,7 @@ func TestCheckBlockContext(t *testing.T) {
 		index:        index,
 		validatorSet: vs,
 		params:       &params.RegestParams,
+		timeSource:   NewMedianTime(),
 	}
 
 	prev, err := index.Tip().Header()
@@ -192,7 +193,7 @@ func TestCheckBlockContext(t *testing.T) {
 				Version:     1,
 				Height:      prev.Height + 1,
 				Parent:      prevID[:],
-				Timestamp:   time.Now().Add(MaxBlockFutureTime + time.Second*1).Unix(),
+				Timestamp:   time.Now().Add(params.RegestParams.MaxBlockFutureDrift + time.Second*1).Unix(),
 				Producer_ID: valBytes,
 			},
 			expectedErr: OrphanBlockError("block timestamp is too far in the future"),
@@ -233,11 +234,67 @@ func TestCheckBlockContext(t *testing.T) {
 	}
 }
 
+func TestMedianTimePast(t *testing.T) {
+	ds := mock.NewMapDatastore()
+	err := populateDatabase(ds, 100)
+	assert.NoError(t, err)
+
+	index := NewBlockIndex(ds)
+	err = index.Init()
+	assert.NoError(t, err)
+
+	vs := NewValidatorSet(&params.RegestParams, ds)
+	validatorID := randomPeerID()
+	valBytes, err := validatorID.Marshal()
+	assert.NoError(t, err)
+	vs.validators[validatorID] = &Validator{
+		PeerID: validatorID,
+	}
+
+	b := Blockchain{
+		index:        index,
+		validatorSet: vs,
+		params:       &params.RegestParams,
+		timeSource:   NewMedianTime(),
+	}
+
+	// Block timestamps increase by one second so the median of the
+	// last 11 blocks is five seconds before the tip.
+	tip := index.Tip()
+	mtp, err := b.medianTimePast()
+	assert.NoError(t, err)
+	assert.Equal(t, tip.Timestamp()-5, mtp)
+
+	// Move the tip timestamp back so the only rule the header violates
+	// is the median time past.
+	tip.timestamp = mtp - 2
+	tipID := tip.ID()
+	header := &blocks.BlockHeader{
+		Version:     1,
+		Height:      tip.Height() + 1,
+		Parent:      tipID[:],
+		Timestamp:   mtp,
+		Producer_ID: valBytes,
+	}
+	err = b.checkBlockContext(header)
+	assert.Error(t, err)
+	assert.Equal(t, ErrorCode(ErrMedianTimePast), err.(RuleError).ErrorCode)
+
+	// The rule doesn't apply before its activation height.
+	chainParams := params.RegestParams
+	chainParams.MedianTimePastHeight = header.Height + 1
+	b.params = &chainParams
+	assert.NoError(t, b.checkBlockContext(header))
+}
+
 func TestValidateBlock(t *testing.T) {
 	ds := mock.NewMapDatastore()
 	verifier := &zk.MockVerifier{}
 	verifier.SetValid(true)
+	chainParams := params.RegestParams
+	chainParams.CoinbaseMaturity = 1
 	b := Blockchain{
+		params:       &chainParams,
 		ds:           ds,
 		proofCache:   NewProofCache(30),
 		txoRootSet:   NewTxoRootSet(ds, 10),
@@ -353,6 +410,24 @@ func TestValidateBlock(t *testing.T) {
 			flags:       BFNone,
 			expectedErr: ruleError(ErrEmptyBlock, ""),
 		},
+		{
+			name: "block exceeds max size",
+			block: func(blk *blocks.Block) (*blocks.Block, error) {
+				blk.Transactions = []*transactions.Transaction{
+					transactions.WrapTransaction(&transactions.StandardTransaction{
+						Proof: make([]byte, chainParams.MaxBlockSize),
+					}),
+				}
+				header, err := signHeader(proto.Clone(header).(*blocks.BlockHeader))
+				if err != nil {
+					return nil, err
+				}
+				blk.Header = header
+				return blk, nil
+			},
+			flags:       BFNone,
+			expectedErr: ruleError(ErrMaxBlockSize, ""),
+		},
 		{
 			name: "invalid transaction root",
 			block: func(blk *blocks.Block) (*blocks.Block, error) {
@@ -1345,6 +1420,44 @@ func TestValidateBlock(t *testing.T) {
 			}
 		}
 	}
+
+	// Before the max block size rule activates the oversized block
+	// fails on its transaction root instead.
+	for _, test := range tests {
+		if test.name != "block exceeds max size" {
+			continue
+		}
+		blk, err := test.block(proto.Clone(block).(*blocks.Block))
+		assert.NoError(t, err)
+		chainParams.MaxBlockSizeHeight = blk.Header.Height + 1
+		_, err = b.validateBlock(blk, test.flags)
+		chainParams.MaxBlockSizeHeight = 0
+		assert.Error(t, err)
+		if ruleErr, ok := err.(RuleError); ok {
+			assert.Equal(t, ErrorCode(ErrInvalidTxRoot), ruleErr.ErrorCode)
+		}
+	}
+
+	// Rewards credited by a block at height 1 are not claimable until height 2.
+	for _, test := range tests {
+		if test.name != "coinbase transaction valid" {
+			continue
+		}
+		b.epochHeight = 1
+		blk, err := test.block(proto.Clone(block).(*blocks.Block))
+		assert.NoError(t, err)
+		_, err = b.validateBlock(blk, test.flags)
+		assert.Error(t, err)
+		if ruleErr, ok := err.(RuleError); ok {
+			assert.Equal(t, ErrorCode(ErrImmatureCoinbase), ruleErr.ErrorCode)
+		}
+
+		// Before the maturity rule activates the rewards are claimable right away.
+		chainParams.CoinbaseMaturityHeight = 2
+		_, err = b.validateBlock(blk, test.flags)
+		assert.NoError(t, err)
+		chainParams.CoinbaseMaturityHeight = 0
+	}
 }
 
 func TestCheckTransactionSanity(t *testing.T) {
@@ -1815,7 +1928,7 @@ func TestCheckTransactionSanity(t *testing.T) {
 		},
 	}
 	for _, test := range tests {
-		err := CheckTransactionSanity(test.tx, test.timestamp)
+		err := CheckTransactionSanity(test.tx, &params.RegestParams, test.timestamp)
 		if test.expectedErr == nil {
 			assert.NoErrorf(t, err, "tx sanity test: %s failure", test.name)
 		} else {
@@ -1843,6 +1956,26 @@ func TestValidateLocktime(t *testing.T) {
 	assert.False(t, ValidateLocktime(time.Now().Add(time.Second), locktime))
 }
 
+func TestExpiringLocktime(t *testing.T) {
+	start := time.Now()
+	expiry := start.Add(time.Hour)
+	locktime := NewExpiringLocktime(start, expiry)
+
+	assert.True(t, ValidateLocktime(start, locktime))
+	assert.True(t, ValidateLocktime(expiry.Add(-time.Second), locktime))
+	assert.False(t, ValidateLocktime(expiry, locktime))
+
+	tx := transactions.WrapTransaction(&transactions.StandardTransaction{
+		Locktime: locktime,
+	})
+	txExpiry, ok := TransactionExpiry(tx)
+	assert.True(t, ok)
+	assert.Equal(t, expiry.Unix(), txExpiry.Unix())
+
+	_, ok = TransactionExpiry(transactions.WrapTransaction(&transactions.StandardTransaction{}))
+	assert.False(t, ok)
+}
+
 func TestConnectGenesisBlocks(t *testing.T) {
 	tests := []*params.NetworkParams{
 		&params.RegestParams,
@@ -0,0 +1,231 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/project-illium/ilxd/rpc/pb"
+	"github.com/project-illium/ilxd/types"
+	"time"
+)
+
+type InitiateSwap struct {
+	CounterpartyKey string `short:"k" long:"counterpartykey" description:"The counterparty's spend public key. They can claim the coins with the secret. Serialized as hex string."`
+	Amount          string `short:"a" long:"amount" description:"The amount of ILX to lock in the contract"`
+	RefundAfter     int64  `short:"r" long:"refundafter" description:"The unix time after which the coins can be refunded. If zero the default of 48 hours from now is used."`
+	FeePerKB        string `short:"f" long:"feeperkb" description:"The fee per kilobyte to pay for the transaction. If zero the wallet will use its default fee."`
+	opts            *options
+}
+
+func (x *InitiateSwap) Execute(args []string) error {
+	client, err := makeWalletClient(x.opts)
+	if err != nil {
+		return err
+	}
+	key, err := hex.DecodeString(x.CounterpartyKey)
+	if err != nil {
+		return err
+	}
+	amount, err := types.AmountFromILX(x.Amount)
+	if err != nil {
+		return err
+	}
+	fpkb, err := types.AmountFromILX(x.FeePerKB)
+	if err != nil {
+		return err
+	}
+	resp, err := client.InitiateSwap(makeContext(x.opts.AuthToken), &pb.InitiateSwapRequest{
+		CounterpartyPublicKey: key,
+		Amount:                uint64(amount),
+		RefundAfter:           x.RefundAfter,
+		FeePerKilobyte:        uint64(fpkb),
+	})
+	if err != nil {
+		return err
+	}
+	return printAtomicSwap(resp.Swap)
+}
+
+type ParticipateSwap struct {
+	CounterpartyKey string `short:"k" long:"counterpartykey" description:"The counterparty's spend public key. They can claim the coins with the secret. Serialized as hex string."`
+	SecretHash      string `short:"s" long:"secrethash" description:"The sha256 hash of the secret from the initiator's contract. Serialized as hex string."`
+	Amount          string `short:"a" long:"amount" description:"The amount of ILX to lock in the contract"`
+	RefundAfter     int64  `short:"r" long:"refundafter" description:"The unix time after which the coins can be refunded. This must be well before the refund time of the initiator's contract. If zero the default of 24 hours from now is used."`
+	FeePerKB        string `short:"f" long:"feeperkb" description:"The fee per kilobyte to pay for the transaction. If zero the wallet will use its default fee."`
+	opts            *options
+}
+
+func (x *ParticipateSwap) Execute(args []string) error {
+	client, err := makeWalletClient(x.opts)
+	if err != nil {
+		return err
+	}
+	key, err := hex.DecodeString(x.CounterpartyKey)
+	if err != nil {
+		return err
+	}
+	secretHash, err := hex.DecodeString(x.SecretHash)
+	if err != nil {
+		return err
+	}
+	amount, err := types.AmountFromILX(x.Amount)
+	if err != nil {
+		return err
+	}
+	fpkb, err := types.AmountFromILX(x.FeePerKB)
+	if err != nil {
+		return err
+	}
+	resp, err := client.ParticipateSwap(makeContext(x.opts.AuthToken), &pb.ParticipateSwapRequest{
+		CounterpartyPublicKey: key,
+		SecretHash:            secretHash,
+		Amount:                uint64(amount),
+		RefundAfter:           x.RefundAfter,
+		FeePerKilobyte:        uint64(fpkb),
+	})
+	if err != nil {
+		return err
+	}
+	return printAtomicSwap(resp.Swap)
+}
+
+type RedeemSwap struct {
+	Address       string `short:"a" long:"addr" description:"The address of the counterparty's contract"`
+	LockingScript string `short:"l" long:"lockingscript" description:"The contract's locking script. Serialized as hex string."`
+	ViewKey       string `short:"k" long:"viewkey" description:"The contract's view private key. Serialized as hex string."`
+	Secret        string `short:"s" long:"secret" description:"The secret. Serialized as hex string."`
+	RescanHeight  uint32 `short:"r" long:"rescanheight" description:"The block height to rescan from if the contract has not been imported yet"`
+	FeePerKB      string `short:"f" long:"feeperkb" description:"The fee per kilobyte to pay for the transaction. If zero the wallet will use its default fee."`
+	opts          *options
+}
+
+func (x *RedeemSwap) Execute(args []string) error {
+	client, err := makeWalletClient(x.opts)
+	if err != nil {
+		return err
+	}
+	lockingScript, err := hex.DecodeString(x.LockingScript)
+	if err != nil {
+		return err
+	}
+	viewKey, err := hex.DecodeString(x.ViewKey)
+	if err != nil {
+		return err
+	}
+	secret, err := hex.DecodeString(x.Secret)
+	if err != nil {
+		return err
+	}
+	fpkb, err := types.AmountFromILX(x.FeePerKB)
+	if err != nil {
+		return err
+	}
+	resp, err := client.RedeemSwap(makeContext(x.opts.AuthToken), &pb.RedeemSwapRequest{
+		ContractAddress: x.Address,
+		LockingScript:   lockingScript,
+		ViewPrivateKey:  viewKey,
+		Secret:          secret,
+		RescanHeight:    x.RescanHeight,
+		FeePerKilobyte:  uint64(fpkb),
+	})
+	if err != nil {
+		return err
+	}
+	return printAtomicSwap(resp.Swap)
+}
+
+type RefundSwap struct {
+	SwapID   string `short:"i" long:"id" description:"The ID of the swap to refund"`
+	FeePerKB string `short:"f" long:"feeperkb" description:"The fee per kilobyte to pay for the transaction. If zero the wallet will use its default fee."`
+	opts     *options
+}
+
+func (x *RefundSwap) Execute(args []string) error {
+	client, err := makeWalletClient(x.opts)
+	if err != nil {
+		return err
+	}
+	id, err := hex.DecodeString(x.SwapID)
+	if err != nil {
+		return err
+	}
+	fpkb, err := types.AmountFromILX(x.FeePerKB)
+	if err != nil {
+		return err
+	}
+	resp, err := client.RefundSwap(makeContext(x.opts.AuthToken), &pb.RefundSwapRequest{
+		Swap_ID:        id,
+		FeePerKilobyte: uint64(fpkb),
+	})
+	if err != nil {
+		return err
+	}
+	return printAtomicSwap(resp.Swap)
+}
+
+type GetSwaps struct {
+	opts *options
+}
+
+func (x *GetSwaps) Execute(args []string) error {
+	client, err := makeWalletClient(x.opts)
+	if err != nil {
+		return err
+	}
+	resp, err := client.GetSwaps(makeContext(x.opts.AuthToken), &pb.GetSwapsRequest{})
+	if err != nil {
+		return err
+	}
+	for _, swap := range resp.Swaps {
+		if err := printAtomicSwap(swap); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func printAtomicSwap(swap *pb.AtomicSwap) error {
+	s := struct {
+		SwapID          types.HexEncodable `json:"swapID"`
+		Role            string             `json:"role"`
+		Status          string             `json:"status"`
+		SecretHash      types.HexEncodable `json:"secretHash"`
+		Secret          types.HexEncodable `json:"secret,omitempty"`
+		Amount          types.Amount       `json:"amount"`
+		RefundAfter     time.Time          `json:"refundAfter"`
+		ContractAddress string             `json:"contractAddress"`
+		LockingScript   types.HexEncodable `json:"lockingScript"`
+		ViewPrivateKey  types.HexEncodable `json:"viewPrivateKey"`
+		WalletAddress   string             `json:"walletAddress"`
+		FundingTxid     types.HexEncodable `json:"fundingTxid,omitempty"`
+		RedeemTxid      types.HexEncodable `json:"redeemTxid,omitempty"`
+		RefundTxid      types.HexEncodable `json:"refundTxid,omitempty"`
+		Created         time.Time          `json:"created"`
+	}{
+		SwapID:          swap.Swap_ID,
+		Role:            swap.Role.String(),
+		Status:          swap.Status.String(),
+		SecretHash:      swap.SecretHash,
+		Secret:          swap.Secret,
+		Amount:          types.Amount(swap.Amount),
+		RefundAfter:     time.Unix(swap.RefundAfter, 0),
+		ContractAddress: swap.ContractAddress,
+		LockingScript:   swap.LockingScript,
+		ViewPrivateKey:  swap.ViewPrivateKey,
+		WalletAddress:   swap.WalletAddress,
+		FundingTxid:     swap.FundingTransaction_ID,
+		RedeemTxid:      swap.RedeemTransaction_ID,
+		RefundTxid:      swap.RefundTransaction_ID,
+		Created:         time.Unix(swap.Created, 0),
+	}
+	out, err := json.MarshalIndent(&s, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
@@ -7,6 +7,7 @@ package main
 import (
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/project-illium/ilxd/rpc/pb"
@@ -16,7 +17,9 @@ import (
 	"github.com/tidwall/sjson"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
+	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -74,6 +77,240 @@ func (x *GetMempool) Execute(args []string) error {
 	return nil
 }
 
+type GetFeeEstimate struct {
+	ConfTarget uint32 `short:"t" long:"conf-target" description:"The number of blocks the transaction should be included within" default:"1"`
+	opts       *options
+}
+
+func (x *GetFeeEstimate) Execute(args []string) error {
+	client, err := makeBlockchainClient(x.opts)
+	if err != nil {
+		return err
+	}
+	resp, err := client.GetFeeEstimate(makeContext(x.opts.AuthToken), &pb.GetFeeEstimateRequest{
+		ConfTarget: x.ConfTarget,
+	})
+	if err != nil {
+		return err
+	}
+
+	s := struct {
+		FeePerKilobyte types.Amount `json:"feePerKilobyte"`
+		ConfTarget     uint32       `json:"confTarget"`
+	}{
+		FeePerKilobyte: types.Amount(resp.FeePerKilobyte),
+		ConfTarget:     resp.ConfTarget,
+	}
+	out, err := json.MarshalIndent(&s, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+type GetFeeHistory struct {
+	NBlocks uint32 `short:"n" long:"nblocks" description:"The number of recent blocks to return" default:"10"`
+	opts    *options
+}
+
+func (x *GetFeeHistory) Execute(args []string) error {
+	client, err := makeBlockchainClient(x.opts)
+	if err != nil {
+		return err
+	}
+	resp, err := client.GetFeeHistory(makeContext(x.opts.AuthToken), &pb.GetFeeHistoryRequest{
+		NBlocks: x.NBlocks,
+	})
+	if err != nil {
+		return err
+	}
+
+	type blockFees struct {
+		Height    uint32       `json:"height"`
+		Timestamp time.Time    `json:"timestamp"`
+		FeePayers uint32       `json:"feePayers"`
+		BlockSize uint32       `json:"blockSize"`
+		TotalFees types.Amount `json:"totalFees"`
+		Min       types.Amount `json:"min"`
+		P10       types.Amount `json:"p10"`
+		P25       types.Amount `json:"p25"`
+		P50       types.Amount `json:"p50"`
+		P75       types.Amount `json:"p75"`
+		P90       types.Amount `json:"p90"`
+		Max       types.Amount `json:"max"`
+	}
+	s := struct {
+		MedianFeePerKilobyte types.Amount `json:"medianFeePerKilobyte"`
+		Blocks               []blockFees  `json:"blocks"`
+	}{
+		MedianFeePerKilobyte: types.Amount(resp.MedianFeePerKilobyte),
+		Blocks:               make([]blockFees, 0, len(resp.Blocks)),
+	}
+	for _, b := range resp.Blocks {
+		s.Blocks = append(s.Blocks, blockFees{
+			Height:    b.Height,
+			Timestamp: time.Unix(b.Timestamp, 0),
+			FeePayers: b.FeePayers,
+			BlockSize: b.BlockSize,
+			TotalFees: types.Amount(b.TotalFees),
+			Min:       types.Amount(b.Min),
+			P10:       types.Amount(b.P10),
+			P25:       types.Amount(b.P25),
+			P50:       types.Amount(b.P50),
+			P75:       types.Amount(b.P75),
+			P90:       types.Amount(b.P90),
+			Max:       types.Amount(b.Max),
+		})
+	}
+	out, err := json.MarshalIndent(&s, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+type GetMempoolEntry struct {
+	Txid string `short:"i" long:"id" description:"Txid to look up"`
+	opts *options
+}
+
+func (x *GetMempoolEntry) Execute(args []string) error {
+	client, err := makeBlockchainClient(x.opts)
+	if err != nil {
+		return err
+	}
+
+	txid, err := hex.DecodeString(x.Txid)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.GetMempoolEntry(makeContext(x.opts.AuthToken), &pb.GetMempoolEntryRequest{
+		Transaction_ID: txid,
+	})
+	if err != nil {
+		return err
+	}
+
+	s := struct {
+		Transaction    *transactions.Transaction `json:"transaction"`
+		Size           uint32                    `json:"size"`
+		Fee            types.Amount              `json:"fee"`
+		FeePerKilobyte types.Amount              `json:"feePerKilobyte"`
+	}{
+		Transaction:    resp.Transaction,
+		Size:           resp.Size,
+		Fee:            types.Amount(resp.Fee),
+		FeePerKilobyte: types.Amount(resp.FeePerKilobyte),
+	}
+	out, err := json.MarshalIndent(&s, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+type VerifyReserves struct {
+	Proof string `short:"p" long:"proof" description:"The reserves proof JSON created by provereserves. Use this or file."`
+	File  string `short:"f" long:"file" description:"A path to a file containing the reserves proof JSON. Use this or proof."`
+	opts  *options
+}
+
+func (x *VerifyReserves) Execute(args []string) error {
+	proofJSON := []byte(x.Proof)
+	if x.File != "" {
+		var err error
+		proofJSON, err = os.ReadFile(x.File)
+		if err != nil {
+			return err
+		}
+	}
+	var proof pb.ReservesProof
+	if err := protojson.Unmarshal(proofJSON, &proof); err != nil {
+		return err
+	}
+
+	client, err := makeBlockchainClient(x.opts)
+	if err != nil {
+		return err
+	}
+	resp, err := client.VerifyReserves(makeContext(x.opts.AuthToken), &pb.VerifyReservesRequest{
+		Proof: &proof,
+	})
+	if err != nil {
+		return err
+	}
+
+	s := struct {
+		Total   types.Amount `json:"total"`
+		Message string       `json:"message"`
+	}{
+		Total:   types.Amount(resp.Total),
+		Message: resp.Message,
+	}
+	out, err := json.MarshalIndent(&s, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+type VerifyPayment struct {
+	Proof string `short:"p" long:"proof" description:"The payment proof JSON created by provepayment. Use this or file."`
+	File  string `short:"f" long:"file" description:"A path to a file containing the payment proof JSON. Use this or proof."`
+	opts  *options
+}
+
+func (x *VerifyPayment) Execute(args []string) error {
+	proofJSON := []byte(x.Proof)
+	if x.File != "" {
+		var err error
+		proofJSON, err = os.ReadFile(x.File)
+		if err != nil {
+			return err
+		}
+	}
+	var proof pb.PaymentProof
+	if err := protojson.Unmarshal(proofJSON, &proof); err != nil {
+		return err
+	}
+
+	client, err := makeBlockchainClient(x.opts)
+	if err != nil {
+		return err
+	}
+	resp, err := client.VerifyPayment(makeContext(x.opts.AuthToken), &pb.VerifyPaymentRequest{
+		Proof: &proof,
+	})
+	if err != nil {
+		return err
+	}
+
+	s := struct {
+		Address string             `json:"address"`
+		Amount  types.Amount       `json:"amount"`
+		AssetID types.HexEncodable `json:"assetID"`
+		BlockID types.HexEncodable `json:"blockID"`
+		Height  uint32             `json:"height"`
+	}{
+		Address: resp.Address,
+		Amount:  types.Amount(resp.Amount),
+		AssetID: resp.Asset_ID,
+		BlockID: resp.Block_ID,
+		Height:  resp.Height,
+	}
+	out, err := json.MarshalIndent(&s, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
 type GetBlockchainInfo struct {
 	opts *options
 }
@@ -99,6 +336,7 @@ func (x *GetBlockchainInfo) Execute(args []string) error {
 		TreasuryBalance   types.Amount       `json:"treasuryBalance"`
 		BlockchainSize    uint64             `json:"blockchainSize"`
 		Epoch             uint32             `json:"epoch"`
+		TxoRoot           types.HexEncodable `json:"txoRoot"`
 	}{
 		Network:           resp.Network.String(),
 		BestHeight:        resp.BestHeight,
@@ -110,6 +348,134 @@ func (x *GetBlockchainInfo) Execute(args []string) error {
 		TreasuryBalance:   types.Amount(resp.TreasuryBalance),
 		BlockchainSize:    resp.BlockchainSize,
 		Epoch:             resp.Epoch,
+		TxoRoot:           resp.TxoRoot,
+	}
+
+	out, err := json.MarshalIndent(&s, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(out))
+	return nil
+}
+
+type GetChainStats struct {
+	NBlocks uint32 `short:"n" long:"nblocks" description:"The number of blocks, ending at the tip, to compute the utilization over. Defaults to 100."`
+	opts    *options
+}
+
+func (x *GetChainStats) Execute(args []string) error {
+	client, err := makeBlockchainClient(x.opts)
+	if err != nil {
+		return err
+	}
+	resp, err := client.GetChainStats(makeContext(x.opts.AuthToken), &pb.GetChainStatsRequest{
+		NBlocks: x.NBlocks,
+	})
+	if err != nil {
+		return err
+	}
+
+	s := struct {
+		MaxBlockSize        uint32  `json:"maxBlockSize"`
+		MaxTransactionSize  uint32  `json:"maxTransactionSize"`
+		BlockSizeSoftLimit  uint32  `json:"blockSizeSoftLimit"`
+		NBlocks             uint32  `json:"nBlocks"`
+		TipBlockSize        uint32  `json:"tipBlockSize"`
+		TipUtilization      float64 `json:"tipUtilization"`
+		AverageBlockSize    uint32  `json:"averageBlockSize"`
+		LargestBlockSize    uint32  `json:"largestBlockSize"`
+		AverageUtilization  float64 `json:"averageUtilization"`
+		AverageTransactions float64 `json:"averageTransactions"`
+	}{
+		MaxBlockSize:        resp.MaxBlockSize,
+		MaxTransactionSize:  resp.MaxTransactionSize,
+		BlockSizeSoftLimit:  resp.BlockSizeSoftLimit,
+		NBlocks:             resp.NBlocks,
+		TipBlockSize:        resp.TipBlockSize,
+		TipUtilization:      resp.TipUtilization,
+		AverageBlockSize:    resp.AverageBlockSize,
+		LargestBlockSize:    resp.LargestBlockSize,
+		AverageUtilization:  resp.AverageUtilization,
+		AverageTransactions: resp.AverageTransactions,
+	}
+
+	out, err := json.MarshalIndent(&s, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(out))
+	return nil
+}
+
+type GetNetworkParams struct {
+	opts *options
+}
+
+func (x *GetNetworkParams) Execute(args []string) error {
+	client, err := makeBlockchainClient(x.opts)
+	if err != nil {
+		return err
+	}
+	resp, err := client.GetNetworkParams(makeContext(x.opts.AuthToken), &pb.GetNetworkParamsRequest{})
+	if err != nil {
+		return err
+	}
+
+	s := struct {
+		Name                       string             `json:"name"`
+		ProtocolPrefix             string             `json:"protocolPrefix"`
+		AddressPrefix              string             `json:"addressPrefix"`
+		GenesisBlockID             types.HexEncodable `json:"genesisBlockID"`
+		GenesisTimestamp           time.Time          `json:"genesisTimestamp"`
+		GenesisCoinbase            types.Amount       `json:"genesisCoinbase"`
+		EpochLength                string             `json:"epochLength"`
+		TargetDistribution         types.Amount       `json:"targetDistribution"`
+		InitialDistributionPeriods int64              `json:"initialDistributionPeriods"`
+		AValue                     float64            `json:"aValue"`
+		TreasuryPercentage         float64            `json:"treasuryPercentage"`
+		LongTermInflationRate      float64            `json:"longTermInflationRate"`
+		ValidatorExpiration        string             `json:"validatorExpiration"`
+		RestakePeriod              string             `json:"restakePeriod"`
+		MedianTimeBlocks           uint32             `json:"medianTimeBlocks"`
+		MaxBlockFutureDrift        string             `json:"maxBlockFutureDrift"`
+		MinFeePerKilobyte          types.Amount       `json:"minFeePerKilobyte"`
+		MinStake                   types.Amount       `json:"minStake"`
+		AllowMockProofs            bool               `json:"allowMockProofs"`
+		AvalancheFinalizationScore uint32             `json:"avalancheFinalizationScore"`
+		AvalancheMaxInflightPoll   uint32             `json:"avalancheMaxInflightPoll"`
+		AvalancheRequestTimeout    string             `json:"avalancheRequestTimeout"`
+		AvalanchePollInterval      string             `json:"avalanchePollInterval"`
+		MaxBlockSize               uint32             `json:"maxBlockSize"`
+		MaxTransactionSize         uint32             `json:"maxTransactionSize"`
+	}{
+		Name:                       resp.Name,
+		ProtocolPrefix:             resp.ProtocolPrefix,
+		AddressPrefix:              resp.AddressPrefix,
+		GenesisBlockID:             resp.GenesisBlock_ID,
+		GenesisTimestamp:           time.Unix(resp.GenesisTimestamp, 0),
+		GenesisCoinbase:            types.Amount(resp.GenesisCoinbase),
+		EpochLength:                (time.Duration(resp.EpochLength) * time.Second).String(),
+		TargetDistribution:         types.Amount(resp.TargetDistribution),
+		InitialDistributionPeriods: resp.InitialDistributionPeriods,
+		AValue:                     resp.AValue,
+		TreasuryPercentage:         resp.TreasuryPercentage,
+		LongTermInflationRate:      resp.LongTermInflationRate,
+		ValidatorExpiration:        (time.Duration(resp.ValidatorExpiration) * time.Second).String(),
+		RestakePeriod:              (time.Duration(resp.RestakePeriod) * time.Second).String(),
+		MedianTimeBlocks:           resp.MedianTimeBlocks,
+		MaxBlockFutureDrift:        (time.Duration(resp.MaxBlockFutureDrift) * time.Second).String(),
+		MinFeePerKilobyte:          types.Amount(resp.MinFeePerKilobyte),
+		MinStake:                   types.Amount(resp.MinStake),
+		AllowMockProofs:            resp.AllowMockProofs,
+		AvalancheFinalizationScore: resp.AvalancheFinalizationScore,
+		AvalancheMaxInflightPoll:   resp.AvalancheMaxInflightPoll,
+		AvalancheRequestTimeout:    (time.Duration(resp.AvalancheRequestTimeout) * time.Millisecond).String(),
+		AvalanchePollInterval:      (time.Duration(resp.AvalanchePollInterval) * time.Millisecond).String(),
+		MaxBlockSize:               resp.MaxBlockSize,
+		MaxTransactionSize:         resp.MaxTransactionSize,
 	}
 
 	out, err := json.MarshalIndent(&s, "", "    ")
@@ -270,6 +636,113 @@ func (x *GetCompressedBlock) Execute(args []string) error {
 	return nil
 }
 
+type GetBlockFilter struct {
+	opts    *options
+	BlockID string `short:"i" long:"id" description:"Block ID to look up. Either use this or the height."`
+	Height  int    `short:"t" long:"height" description:"Block height. Either use this or the ID"`
+}
+
+func (x *GetBlockFilter) Execute(args []string) error {
+	client, err := makeBlockchainClient(x.opts)
+	if err != nil {
+		return err
+	}
+	var req *pb.GetBlockFilterRequest
+	if x.BlockID != "" {
+		b, err := hex.DecodeString(x.BlockID)
+		if err != nil {
+			return err
+		}
+		req = &pb.GetBlockFilterRequest{
+			IdOrHeight: &pb.GetBlockFilterRequest_Block_ID{Block_ID: b},
+		}
+	} else {
+		req = &pb.GetBlockFilterRequest{
+			IdOrHeight: &pb.GetBlockFilterRequest_Height{Height: uint32(x.Height)},
+		}
+	}
+	resp, err := client.GetBlockFilter(makeContext(x.opts.AuthToken), req)
+	if err != nil {
+		return err
+	}
+
+	f := struct {
+		BlockID      types.HexEncodable `json:"blockID"`
+		Height       uint32             `json:"height"`
+		Filter       types.HexEncodable `json:"filter"`
+		FilterHeader types.HexEncodable `json:"filterHeader"`
+	}{
+		BlockID:      resp.Block_ID,
+		Height:       resp.Height,
+		Filter:       resp.Filter,
+		FilterHeader: resp.FilterHeader,
+	}
+
+	out, err := json.MarshalIndent(&f, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+type GetCommitmentsByScriptHash struct {
+	opts       *options
+	ScriptHash string `short:"s" long:"scripthash" description:"The hash of the locking script in hex"`
+}
+
+func (x *GetCommitmentsByScriptHash) Execute(args []string) error {
+	client, err := makeBlockchainClient(x.opts)
+	if err != nil {
+		return err
+	}
+	scriptHash, err := hex.DecodeString(x.ScriptHash)
+	if err != nil {
+		return err
+	}
+	resp, err := client.GetCommitmentsByScriptHash(makeContext(x.opts.AuthToken), &pb.GetCommitmentsByScriptHashRequest{
+		ScriptHash: scriptHash,
+	})
+	if err != nil {
+		return err
+	}
+
+	type scriptCommitment struct {
+		Commitment      types.HexEncodable   `json:"commitment"`
+		TransactionID   types.HexEncodable   `json:"transactionID"`
+		Height          uint32               `json:"height"`
+		CommitmentIndex uint64               `json:"commitmentIndex"`
+		Amount          types.Amount         `json:"amount"`
+		AssetID         types.HexEncodable   `json:"assetID"`
+		Salt            types.HexEncodable   `json:"salt"`
+		State           []types.HexEncodable `json:"state"`
+	}
+	commitments := make([]scriptCommitment, 0, len(resp.Commitments))
+	for _, c := range resp.Commitments {
+		state := make([]types.HexEncodable, 0, len(c.State))
+		for _, s := range c.State {
+			state = append(state, s)
+		}
+		commitments = append(commitments, scriptCommitment{
+			Commitment:      c.Commitment,
+			TransactionID:   c.Transaction_ID,
+			Height:          c.Height,
+			CommitmentIndex: c.CommitmentIndex,
+			Amount:          types.Amount(c.Amount),
+			AssetID:         c.Asset_ID,
+			Salt:            c.Salt,
+			State:           state,
+		})
+	}
+
+	out, err := json.MarshalIndent(commitments, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
 type GetTransaction struct {
 	Txid    string `short:"i" long:"id" description:"Txid to look up"`
 	Concise bool   `short:"c" long:"concise" description:"Return the transaction without the proof"`
@@ -547,6 +1020,123 @@ func (x *GetValidatorSet) Execute(args []string) error {
 	return nil
 }
 
+type GetStakeDistribution struct {
+	Probability float64 `short:"p" long:"probability" description:"The target probability, between 0 and 1, of producing at least one block within the number of blocks. Defaults to 0.5."`
+	Blocks      uint32  `short:"b" long:"blocks" description:"The number of blocks over which to compute the probability. Defaults to 1000."`
+	opts        *options
+}
+
+func (x *GetStakeDistribution) Execute(args []string) error {
+	client, err := makeBlockchainClient(x.opts)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.GetStakeDistribution(makeContext(x.opts.AuthToken), &pb.GetStakeDistributionRequest{
+		TargetProbability: x.Probability,
+		Blocks:            x.Blocks,
+	})
+	if err != nil {
+		return err
+	}
+
+	type bucket struct {
+		MinWeight   types.Amount `json:"minWeight"`
+		MaxWeight   types.Amount `json:"maxWeight"`
+		Count       uint32       `json:"count"`
+		TotalWeight types.Amount `json:"totalWeight"`
+	}
+	histogram := make([]*bucket, 0, len(resp.Histogram))
+	for _, b := range resp.Histogram {
+		histogram = append(histogram, &bucket{
+			MinWeight:   types.Amount(b.MinWeight),
+			MaxWeight:   types.Amount(b.MaxWeight),
+			Count:       b.Count,
+			TotalWeight: types.Amount(b.TotalWeight),
+		})
+	}
+
+	d := struct {
+		NumValidators       uint32       `json:"numValidators"`
+		StakeWeight         types.Amount `json:"stakeWeight"`
+		MedianStakeWeight   types.Amount `json:"medianStakeWeight"`
+		Gini                float64      `json:"gini"`
+		NakamotoCoefficient uint32       `json:"nakamotoCoefficient"`
+		Histogram           []*bucket    `json:"histogram"`
+		MinimumStake        types.Amount `json:"minimumStake"`
+	}{
+		NumValidators:       resp.NumValidators,
+		StakeWeight:         types.Amount(resp.StakeWeight),
+		MedianStakeWeight:   types.Amount(resp.MedianStakeWeight),
+		Gini:                resp.Gini,
+		NakamotoCoefficient: resp.NakamotoCoefficient,
+		Histogram:           histogram,
+		MinimumStake:        types.Amount(resp.MinimumStake),
+	}
+
+	out, err := json.MarshalIndent(&d, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+type GetEquivocationEvidence struct {
+	ValID string `short:"i" long:"id" description:"Optional validator ID to return the evidence for. If omitted the evidence for all validators is returned."`
+	opts  *options
+}
+
+func (x *GetEquivocationEvidence) Execute(args []string) error {
+	client, err := makeBlockchainClient(x.opts)
+	if err != nil {
+		return err
+	}
+
+	req := &pb.GetEquivocationEvidenceRequest{}
+	if x.ValID != "" {
+		pid, err := peer.Decode(x.ValID)
+		if err != nil {
+			return err
+		}
+		req.Validator_ID, err = pid.Marshal()
+		if err != nil {
+			return err
+		}
+	}
+	resp, err := client.GetEquivocationEvidence(makeContext(x.opts.AuthToken), req)
+	if err != nil {
+		return err
+	}
+
+	type evidence struct {
+		ValidatorID string              `json:"validatorID"`
+		Height      uint32              `json:"height"`
+		First       *blocks.BlockHeader `json:"first"`
+		Second      *blocks.BlockHeader `json:"second"`
+	}
+	evs := make([]*evidence, 0, len(resp.Evidence))
+	for _, ev := range resp.Evidence {
+		pid, err := peer.IDFromBytes(ev.First.Producer_ID)
+		if err != nil {
+			return err
+		}
+		evs = append(evs, &evidence{
+			ValidatorID: pid.String(),
+			Height:      ev.First.Height,
+			First:       ev.First,
+			Second:      ev.Second,
+		})
+	}
+
+	out, err := json.MarshalIndent(evs, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
 type GetAccumulatorCheckpoint struct {
 	opts      *options
 	Timestamp int64  `short:"s" long:"timestamp" description:"A timestamp either at or after the desired checkpoint. Use either this or height."`
@@ -601,6 +1191,95 @@ func (x *GetAccumulatorCheckpoint) Execute(args []string) error {
 	return nil
 }
 
+type GetEpochSnapshot struct {
+	opts   *options
+	Epoch  int64 `short:"e" long:"epoch" description:"The epoch to return the snapshot for"`
+	Latest bool  `short:"l" long:"latest" description:"Return the most recent snapshot. The epoch is ignored if this is set."`
+}
+
+func (x *GetEpochSnapshot) Execute(args []string) error {
+	client, err := makeBlockchainClient(x.opts)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.GetEpochSnapshot(makeContext(x.opts.AuthToken), &pb.GetEpochSnapshotRequest{
+		Epoch:  x.Epoch,
+		Latest: x.Latest,
+	})
+	if err != nil {
+		return err
+	}
+
+	r := struct {
+		Epoch            int64              `json:"epoch"`
+		Height           uint32             `json:"height"`
+		BlockID          types.HexEncodable `json:"blockID"`
+		AccumulatorRoot  types.HexEncodable `json:"accumulatorRoot"`
+		NullifierSetHash types.HexEncodable `json:"nullifierSetHash"`
+		ValidatorSetHash types.HexEncodable `json:"validatorSetHash"`
+		Commitment       types.HexEncodable `json:"commitment"`
+	}{
+		Epoch:            resp.Epoch,
+		Height:           resp.Height,
+		BlockID:          resp.Block_ID,
+		AccumulatorRoot:  resp.AccumulatorRoot,
+		NullifierSetHash: resp.NullifierSetHash,
+		ValidatorSetHash: resp.ValidatorSetHash,
+		Commitment:       resp.Commitment,
+	}
+
+	out, err := json.MarshalIndent(&r, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+type GetChainTips struct {
+	opts *options
+}
+
+func (x *GetChainTips) Execute(args []string) error {
+	client, err := makeBlockchainClient(x.opts)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.GetChainTips(makeContext(x.opts.AuthToken), &pb.GetChainTipsRequest{})
+	if err != nil {
+		return err
+	}
+
+	type tip struct {
+		BlockID      types.HexEncodable `json:"blockID"`
+		Height       uint32             `json:"height"`
+		ForkHeight   uint32             `json:"forkHeight"`
+		BranchLength uint32             `json:"branchLength"`
+		Status       string             `json:"status"`
+		LastSeen     int64              `json:"lastSeen"`
+	}
+	tips := make([]tip, 0, len(resp.Tips))
+	for _, t := range resp.Tips {
+		tips = append(tips, tip{
+			BlockID:      t.Block_ID,
+			Height:       t.Height,
+			ForkHeight:   t.ForkHeight,
+			BranchLength: t.BranchLength,
+			Status:       strings.ToLower(t.Status.String()),
+			LastSeen:     t.LastSeen,
+		})
+	}
+
+	out, err := json.MarshalIndent(tips, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
 type SubmitTransaction struct {
 	opts *options
 	Tx   string `short:"t" long:"tx" description:"The transaction to submit. Serialized as hex string or JSON."`
@@ -619,6 +1298,10 @@ func (x *SubmitTransaction) Execute(args []string) error {
 		}
 	}
 
+	if err := verifyNetwork(x.opts); err != nil {
+		return err
+	}
+
 	client, err := makeBlockchainClient(x.opts)
 	if err != nil {
 		return err
@@ -634,3 +1317,50 @@ func (x *SubmitTransaction) Execute(args []string) error {
 	fmt.Println(hex.EncodeToString(resp.Transaction_ID))
 	return nil
 }
+
+type SubmitPackage struct {
+	opts *options
+	Txs  []string `short:"t" long:"tx" description:"A transaction in the package. Serialized as hex string or JSON. Use this option once for each transaction."`
+}
+
+func (x *SubmitPackage) Execute(args []string) error {
+	if len(x.Txs) == 0 {
+		return errors.New("no transactions provided")
+	}
+	txs := make([]*transactions.Transaction, 0, len(x.Txs))
+	for _, s := range x.Txs {
+		var tx transactions.Transaction
+		txBytes, err := hex.DecodeString(s)
+		if err == nil {
+			if err := proto.Unmarshal(txBytes, &tx); err != nil {
+				return err
+			}
+		} else {
+			if err := json.Unmarshal([]byte(s), &tx); err != nil {
+				return err
+			}
+		}
+		txs = append(txs, &tx)
+	}
+
+	if err := verifyNetwork(x.opts); err != nil {
+		return err
+	}
+
+	client, err := makeBlockchainClient(x.opts)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.SubmitPackage(makeContext(x.opts.AuthToken), &pb.SubmitPackageRequest{
+		Transactions: txs,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, txid := range resp.Transaction_IDs {
+		fmt.Println(hex.EncodeToString(txid))
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+//go:build windows || plan9
+// +build windows plan9
+
+package main
+
+// captureStdout runs fn. Redirecting the stdout file descriptor isn't
+// supported on this platform so anything fn writes goes straight to
+// stdout and nothing is captured.
+func captureStdout(fn func()) (string, error) {
+	fn()
+	return "", nil
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+//go:build !windows && !plan9
+// +build !windows,!plan9
+
+package main
+
+import (
+	"bytes"
+	"golang.org/x/sys/unix"
+	"io"
+	"os"
+)
+
+// captureStdout runs fn and returns everything written to the process's
+// stdout while it ran. The file descriptor itself is redirected, rather
+// than os.Stdout, so output written by the rust library is captured too.
+func captureStdout(fn func()) (string, error) {
+	saved, err := unix.Dup(unix.Stdout)
+	if err != nil {
+		return "", err
+	}
+	defer unix.Close(saved)
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+
+	if err := unix.Dup2(int(w.Fd()), unix.Stdout); err != nil {
+		w.Close()
+		return "", err
+	}
+
+	// Read concurrently so a long trace doesn't fill the pipe and
+	// block the writer.
+	var (
+		buf  bytes.Buffer
+		done = make(chan struct{})
+	)
+	go func() {
+		io.Copy(&buf, r)
+		close(done)
+	}()
+
+	fn()
+
+	restoreErr := unix.Dup2(saved, unix.Stdout)
+	w.Close()
+	<-done
+	return buf.String(), restoreErr
+}
@@ -0,0 +1,209 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/jessevdk/go-flags"
+	"github.com/project-illium/ilxd/rpc/pb"
+	"github.com/project-illium/ilxd/types"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	// completionEnv is the environment variable go-flags checks to
+	// decide whether to complete the command line instead of running it.
+	completionEnv = "GO_FLAGS_COMPLETION"
+
+	// completionTimeout bounds how long completers wait on the node so
+	// that an unreachable node does not hang the shell.
+	completionTimeout = time.Second * 3
+)
+
+// completionOpts are the connection options used by the completers to
+// query the node. They are populated by loadCompletionOptions.
+var completionOpts *options
+
+// The completion scripts call back into ilxcli with GO_FLAGS_COMPLETION
+// set so the completions always match the registered commands and options.
+const bashCompletion = `# bash completion for ilxcli
+_ilxcli() {
+    local args=("${COMP_WORDS[@]:1:$COMP_CWORD}")
+    local IFS=$'\n'
+    COMPREPLY=($(GO_FLAGS_COMPLETION=1 "${COMP_WORDS[0]}" "${args[@]}" 2>/dev/null))
+    return 0
+}
+complete -o default -F _ilxcli ilxcli
+`
+
+const zshCompletion = `#compdef ilxcli
+# zsh completion for ilxcli
+_ilxcli() {
+    local -a completions
+    local line item desc
+    for line in "${(@f)$(GO_FLAGS_COMPLETION=verbose "${words[1]}" "${(@)words[2,CURRENT]}" 2>/dev/null)}"; do
+        [[ -z $line ]] && continue
+        item=${line%% *}
+        desc=""
+        [[ $line == *"  # "* ]] && desc=${line#*  \# }
+        completions+=("${item//:/\\:}:$desc")
+    done
+    _describe -V ilxcli completions
+}
+compdef _ilxcli ilxcli
+`
+
+const fishCompletion = `# fish completion for ilxcli
+function __ilxcli_complete
+    set -l tokens (commandline -opc)
+    set -l current (commandline -ct)
+    set -lx GO_FLAGS_COMPLETION verbose
+    $tokens[1] $tokens[2..-1] "$current" 2>/dev/null | string replace -r '^(\S+)\s+# ' '$1\t'
+end
+complete -c ilxcli -f -a '(__ilxcli_complete)'
+`
+
+type Completion struct {
+	opts *options
+}
+
+func (x *Completion) Execute(args []string) error {
+	if len(args) != 1 {
+		return errors.New("specify one shell: [bash, zsh, fish]")
+	}
+	switch strings.ToLower(args[0]) {
+	case "bash":
+		fmt.Print(bashCompletion)
+	case "zsh":
+		fmt.Print(zshCompletion)
+	case "fish":
+		fmt.Print(fishCompletion)
+	default:
+		return fmt.Errorf("unsupported shell %s: [bash, zsh, fish]", args[0])
+	}
+	return nil
+}
+
+// loadCompletionOptions parses the connection options on the command line
+// being completed so that the completers connect to the same node the
+// command would.
+func loadCompletionOptions(parser *flags.Parser, args []string, opts *options) {
+	completionOpts = opts
+
+	i := commandIndex(parser, args)
+	if i < 0 {
+		// Don't parse the argument being completed.
+		i = len(args) - 1
+	}
+	if i < 0 {
+		i = 0
+	}
+
+	compval := os.Getenv(completionEnv)
+	os.Unsetenv(completionEnv)
+	defer os.Setenv(completionEnv, compval)
+
+	parser.SubcommandsOptional = true
+	defer func() { parser.SubcommandsOptional = false }()
+
+	// Errors are ignored as the command line is usually incomplete.
+	parser.ParseArgs(args[:i])
+}
+
+// walletAddress is an option holding one of the wallet's addresses. It
+// completes from the addresses in the wallet.
+type walletAddress string
+
+func (walletAddress) Complete(match string) []flags.Completion {
+	if completionOpts == nil {
+		return nil
+	}
+	client, err := makeWalletClient(completionOpts)
+	if err != nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(makeContext(completionOpts.AuthToken), completionTimeout)
+	defer cancel()
+
+	resp, err := client.GetAddresses(ctx, &pb.GetAddressesRequest{})
+	if err != nil {
+		return nil
+	}
+	var ret []flags.Completion
+	for _, addr := range resp.Addresses {
+		if strings.HasPrefix(addr, match) {
+			ret = append(ret, flags.Completion{Item: addr})
+		}
+	}
+	return ret
+}
+
+// contactName is an option holding the name of an address book contact.
+// It completes from the contacts in the wallet's address book.
+type contactName string
+
+func (contactName) Complete(match string) []flags.Completion {
+	if completionOpts == nil {
+		return nil
+	}
+	client, err := makeWalletClient(completionOpts)
+	if err != nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(makeContext(completionOpts.AuthToken), completionTimeout)
+	defer cancel()
+
+	resp, err := client.ListContacts(ctx, &pb.ListContactsRequest{})
+	if err != nil {
+		return nil
+	}
+	var ret []flags.Completion
+	for _, contact := range resp.Contacts {
+		if strings.HasPrefix(strings.ToLower(contact.Name), strings.ToLower(match)) {
+			ret = append(ret, flags.Completion{
+				Item:        contact.Name,
+				Description: contact.Address,
+			})
+		}
+	}
+	return ret
+}
+
+// commitmentHex is an option holding the hex encoded commitment of one
+// of the wallet's utxos. It completes from the utxos in the wallet.
+type commitmentHex string
+
+func (commitmentHex) Complete(match string) []flags.Completion {
+	if completionOpts == nil {
+		return nil
+	}
+	client, err := makeWalletClient(completionOpts)
+	if err != nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(makeContext(completionOpts.AuthToken), completionTimeout)
+	defer cancel()
+
+	resp, err := client.GetUtxos(ctx, &pb.GetUtxosRequest{})
+	if err != nil {
+		return nil
+	}
+	var ret []flags.Completion
+	for _, utxo := range resp.Utxos {
+		commitment := hex.EncodeToString(utxo.Commitment)
+		if strings.HasPrefix(commitment, match) {
+			ret = append(ret, flags.Completion{
+				Item:        commitment,
+				Description: fmt.Sprintf("%f ILX", types.Amount(utxo.Amount).ToILX()),
+			})
+		}
+	}
+	return ret
+}
@@ -0,0 +1,255 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"github.com/jessevdk/go-flags"
+	"os"
+	"strings"
+	"unicode"
+)
+
+const (
+	// envPrefix is the prefix of the environment variables which
+	// override option defaults.
+	envPrefix = "ILXCLI_"
+
+	aliasSection = "aliases"
+)
+
+// cliConfig is the parsed config file.
+//
+// The file uses ini syntax. Options outside any section set the connection
+// options for every command. A section named after a command sets default
+// options for that command, and may also override the connection options
+// when that command is run. The [aliases] section maps new command names
+// to a command and its arguments:
+//
+//	serveraddr=/ip4/127.0.0.1/tcp/5001
+//
+//	[spend]
+//	feeperkb=0.0001
+//
+//	[aliases]
+//	pay=spend --feeperkb=0.0002
+//
+// The value of any option may also be set with an environment variable.
+// Connection options use ILXCLI_<OPTION> and command options use
+// ILXCLI_<COMMAND>_<OPTION>, for example ILXCLI_SPEND_FEEPERKB.
+// Flags on the command line take precedence over environment variables,
+// which take precedence over the config file.
+type cliConfig struct {
+	global   map[string]string
+	commands map[string]map[string]string
+	aliases  map[string]string
+}
+
+// loadCLIConfig parses the config file at the given path. A missing file
+// is treated as empty.
+func loadCLIConfig(path string) (*cliConfig, error) {
+	cfg := &cliConfig{
+		global:   make(map[string]string),
+		commands: make(map[string]map[string]string),
+		aliases:  make(map[string]string),
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	section := cfg.global
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == ';' || line[0] == '#' {
+			continue
+		}
+		if line[0] == '[' {
+			if line[len(line)-1] != ']' {
+				return nil, fmt.Errorf("%s:%d: malformed section header", path, n)
+			}
+			name := strings.ToLower(strings.TrimSpace(line[1 : len(line)-1]))
+			switch name {
+			case "application options", "connection options":
+				// Accepted for compatibility with older config files.
+				section = cfg.global
+			case aliasSection:
+				section = cfg.aliases
+			default:
+				if _, ok := cfg.commands[name]; !ok {
+					cfg.commands[name] = make(map[string]string)
+				}
+				section = cfg.commands[name]
+			}
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: expected key=value", path, n)
+		}
+		section[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return cfg, scanner.Err()
+}
+
+// apply expands any alias in the arguments and sets the option defaults
+// from the config file and the environment. It returns the arguments
+// that should be passed to the parser.
+func (cfg *cliConfig) apply(parser *flags.Parser, args []string) ([]string, error) {
+	for name := range cfg.aliases {
+		if parser.Find(name) != nil {
+			return nil, fmt.Errorf("alias %s shadows an existing command", name)
+		}
+	}
+	for key, value := range cfg.global {
+		if err := setDefault(parser.FindOptionByLongName(key), key, value); err != nil {
+			return nil, err
+		}
+	}
+
+	i := commandIndex(parser, args)
+	if i >= 0 {
+		if alias, ok := cfg.aliases[args[i]]; ok {
+			expanded, err := splitArgs(alias)
+			if err != nil {
+				return nil, fmt.Errorf("alias %s: %w", args[i], err)
+			}
+			if len(expanded) == 0 {
+				return nil, fmt.Errorf("alias %s is empty", args[i])
+			}
+			args = append(append(append([]string{}, args[:i]...), expanded...), args[i+1:]...)
+		}
+		if cmd := parser.Find(args[i]); cmd != nil {
+			for key, value := range cfg.commands[cmd.Name] {
+				if err := setDefault(cmd.FindOptionByLongName(key), key, value); err != nil {
+					return nil, fmt.Errorf("[%s] %w", cmd.Name, err)
+				}
+			}
+		}
+	}
+
+	for _, g := range parser.Groups() {
+		setEnvKeys(g.Options(), envPrefix)
+	}
+	for _, cmd := range parser.Commands() {
+		setEnvKeys(cmd.Options(), envPrefix+envName(cmd.Name)+"_")
+	}
+	return args, nil
+}
+
+func setDefault(opt *flags.Option, key, value string) error {
+	if opt == nil {
+		return fmt.Errorf("unknown option %s", key)
+	}
+	opt.Default = []string{value}
+	return nil
+}
+
+// setEnvKeys lets each option be set from an environment variable. Options
+// which already name an environment variable are left alone.
+func setEnvKeys(opts []*flags.Option, prefix string) {
+	for _, opt := range opts {
+		if opt.EnvDefaultKey == "" && opt.LongName != "" && opt.LongName != "help" {
+			opt.EnvDefaultKey = prefix + envName(opt.LongName)
+		}
+	}
+}
+
+func envName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, name)
+}
+
+// commandIndex returns the index of the command in the arguments, skipping
+// over any connection options and their values. It returns -1 if there is
+// no command.
+func commandIndex(parser *flags.Parser, args []string) int {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			if i+1 < len(args) {
+				return i + 1
+			}
+			return -1
+		}
+		if !strings.HasPrefix(arg, "-") {
+			return i
+		}
+		if strings.Contains(arg, "=") {
+			continue
+		}
+		var opt *flags.Option
+		if strings.HasPrefix(arg, "--") {
+			opt = parser.FindOptionByLongName(arg[2:])
+		} else if len(arg) == 2 {
+			opt = parser.FindOptionByShortName(rune(arg[1]))
+		}
+		if opt != nil && opt.Value() != nil {
+			if _, isBool := opt.Value().(bool); !isBool {
+				// The next argument is the option's value.
+				i++
+			}
+		}
+	}
+	return -1
+}
+
+// splitArgs splits a string into arguments the way a shell would for
+// simple cases. Single and double quotes group words and a backslash
+// escapes the next character.
+func splitArgs(s string) ([]string, error) {
+	var (
+		args    []string
+		current strings.Builder
+		inArg   bool
+		quote   rune
+		escaped bool
+	)
+	for _, r := range s {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inArg = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case unicode.IsSpace(r):
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 || escaped {
+		return nil, errors.New("unterminated quote or escape")
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
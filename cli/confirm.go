@@ -0,0 +1,83 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/project-illium/ilxd/rpc/pb"
+	"github.com/project-illium/ilxd/types"
+	"os"
+	"strings"
+	"time"
+)
+
+// errAborted is returned when the user declines a confirmation prompt.
+var errAborted = errors.New("aborted")
+
+// confirm prints the prompt and waits for the user to answer yes. If stdin
+// is closed without an answer it is treated as no, so scripts must pass
+// --yes to run commands which ask for confirmation.
+func confirm(prompt string) error {
+	fmt.Printf("%s %s: ", prompt, tr("[y/N]"))
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && answer == "" {
+		fmt.Println()
+		return errAborted
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	if answer == "y" || answer == "yes" || answer == strings.ToLower(tr("y")) || answer == strings.ToLower(tr("yes")) {
+		return nil
+	}
+	return errAborted
+}
+
+func printTransactionPlan(plan *pb.TransactionPlan) error {
+	type input struct {
+		Commitment types.HexEncodable `json:"commitment"`
+		Amount     types.Amount       `json:"amount"`
+	}
+	type output struct {
+		Address string       `json:"address"`
+		Amount  types.Amount `json:"amount"`
+		Change  bool         `json:"change,omitempty"`
+	}
+	s := struct {
+		Inputs               []input      `json:"inputs"`
+		Outputs              []output     `json:"outputs"`
+		Fee                  types.Amount `json:"fee"`
+		Proofs               uint32       `json:"proofs,omitempty"`
+		EstimatedProvingTime string       `json:"estimatedProvingTime,omitempty"`
+	}{
+		Inputs:  make([]input, 0, len(plan.Inputs)),
+		Outputs: make([]output, 0, len(plan.Outputs)),
+		Fee:     types.Amount(plan.Fee),
+		Proofs:  plan.Proofs,
+	}
+	if plan.EstimatedProvingTime > 0 {
+		s.EstimatedProvingTime = (time.Duration(plan.EstimatedProvingTime) * time.Millisecond).String()
+	}
+	for _, in := range plan.Inputs {
+		s.Inputs = append(s.Inputs, input{
+			Commitment: in.Commitment,
+			Amount:     types.Amount(in.Amount),
+		})
+	}
+	for _, out := range plan.Outputs {
+		s.Outputs = append(s.Outputs, output{
+			Address: out.Address,
+			Amount:  types.Amount(out.Amount),
+			Change:  out.Change,
+		})
+	}
+	out, err := json.MarshalIndent(&s, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/project-illium/ilxd/rpc/pb"
+	"time"
+)
+
+type AddContact struct {
+	Name    string `short:"n" long:"name" description:"The name to save the address under. Names are case insensitive and must be unique."`
+	Address string `short:"a" long:"addr" description:"The contact's address"`
+	opts    *options
+}
+
+func (x *AddContact) Execute(args []string) error {
+	client, err := makeWalletClient(x.opts)
+	if err != nil {
+		return err
+	}
+	resp, err := client.AddContact(makeContext(x.opts.AuthToken), &pb.AddContactRequest{
+		Name:    x.Name,
+		Address: x.Address,
+	})
+	if err != nil {
+		return err
+	}
+	return printContacts([]*pb.Contact{resp.Contact})
+}
+
+type ListContacts struct {
+	opts *options
+}
+
+func (x *ListContacts) Execute(args []string) error {
+	client, err := makeWalletClient(x.opts)
+	if err != nil {
+		return err
+	}
+	resp, err := client.ListContacts(makeContext(x.opts.AuthToken), &pb.ListContactsRequest{})
+	if err != nil {
+		return err
+	}
+	return printContacts(resp.Contacts)
+}
+
+type RemoveContact struct {
+	Name contactName `short:"n" long:"name" description:"The name of the contact to remove"`
+	opts *options
+}
+
+func (x *RemoveContact) Execute(args []string) error {
+	client, err := makeWalletClient(x.opts)
+	if err != nil {
+		return err
+	}
+	_, err = client.RemoveContact(makeContext(x.opts.AuthToken), &pb.RemoveContactRequest{
+		Name: string(x.Name),
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Println("success")
+	return nil
+}
+
+func printContacts(contacts []*pb.Contact) error {
+	type contact struct {
+		Name    string    `json:"name"`
+		Address string    `json:"address"`
+		Created time.Time `json:"created"`
+	}
+	out := make([]contact, 0, len(contacts))
+	for _, c := range contacts {
+		out = append(out, contact{
+			Name:    c.Name,
+			Address: c.Address,
+			Created: time.Unix(c.Created, 0),
+		})
+	}
+	ser, err := json.MarshalIndent(out, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(ser))
+	return nil
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/project-illium/ilxd/repo"
+	"github.com/project-illium/ilxd/zk"
+	"github.com/project-illium/ilxd/zk/lurk/macros"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// maxFrameFieldLen is the length at which the expressions and
+// environments in the printed frames are truncated unless --full is set.
+const maxFrameFieldLen = 200
+
+type DebugScript struct {
+	Script     string `short:"s" long:"script" description:"The lurk program, or the path to a file containing it. The program must be a (lambda (priv pub) ...) and may use the macros from the standard library."`
+	PrivParams string `long:"priv" description:"The private params expression" default:"nil"`
+	PubParams  string `long:"pub" description:"The public params expression" default:"nil"`
+	Frames     int    `short:"n" long:"frames" description:"The number of frames to print before the result or failure. Zero prints all the frames." default:"10"`
+	Env        bool   `long:"env" description:"Print the environment in each frame"`
+	Full       bool   `long:"full" description:"Don't truncate long expressions"`
+	opts       *options
+}
+
+func (x *DebugScript) Execute(args []string) error {
+	if x.Script == "" {
+		return errors.New("script is required")
+	}
+	script := x.Script
+	if b, err := os.ReadFile(repo.CleanAndExpandPath(script)); err == nil {
+		script = string(b)
+	}
+
+	mp, err := macros.NewMacroPreprocessor(macros.WithStandardLib(), macros.RemoveComments())
+	if err != nil {
+		return err
+	}
+	program, err := mp.Preprocess(script)
+	if err != nil {
+		return err
+	}
+	priv, err := mp.Preprocess(x.PrivParams)
+	if err != nil {
+		return err
+	}
+	pub, err := mp.Preprocess(x.PubParams)
+	if err != nil {
+		return err
+	}
+
+	var (
+		tag        zk.Tag
+		val        []byte
+		iterations int
+		evalErr    error
+	)
+	trace, err := captureStdout(func() {
+		tag, val, iterations, evalErr = zk.Eval(strings.TrimSpace(program), zk.Expr(strings.TrimSpace(priv)), zk.Expr(strings.TrimSpace(pub)), true)
+	})
+	if err != nil {
+		return err
+	}
+	if evalErr != nil {
+		return evalErr
+	}
+
+	x.printDebugResult(os.Stdout, parseLurkFrames(trace), tag, val, iterations)
+	return nil
+}
+
+// lurkFrame is a single evaluation step from the lurk debug trace.
+type lurkFrame struct {
+	index int
+	expr  string
+	env   string
+	cont  string
+}
+
+// failed returns whether lurk hit an error evaluating the frame.
+func (f lurkFrame) failed() bool {
+	return strings.HasPrefix(f.cont, "Error")
+}
+
+// parseLurkFrames parses the frames the rust library prints when
+// evaluating in debug mode.
+func parseLurkFrames(trace string) []lurkFrame {
+	var (
+		frames []lurkFrame
+		cur    *lurkFrame
+	)
+	for _, line := range strings.Split(trace, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "Frame:"):
+			n, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "Frame:")))
+			if err != nil {
+				cur = nil
+				continue
+			}
+			frames = append(frames, lurkFrame{index: n})
+			cur = &frames[len(frames)-1]
+		case cur == nil:
+		case strings.HasPrefix(line, "Expr:"):
+			cur.expr = strings.TrimSpace(strings.TrimPrefix(line, "Expr:"))
+		case strings.HasPrefix(line, "Env:"):
+			cur.env = strings.TrimSpace(strings.TrimPrefix(line, "Env:"))
+		case strings.HasPrefix(line, "Cont:"):
+			cur.cont = strings.TrimSpace(strings.TrimPrefix(line, "Cont:"))
+		}
+	}
+	return frames
+}
+
+// printDebugResult prints the trailing frames of the evaluation, the
+// result, and where the script failed if it didn't return t. If lurk
+// hit an error the failure is the frame where the error occurred,
+// otherwise the script ran to completion and returned something other
+// than t, in which case the last frames show how it got there.
+func (x *DebugScript) printDebugResult(out io.Writer, frames []lurkFrame, tag zk.Tag, val []byte, iterations int) {
+	failedAt := -1
+	for i, f := range frames {
+		if f.failed() {
+			failedAt = i
+			break
+		}
+	}
+	end := len(frames)
+	if failedAt >= 0 {
+		end = failedAt + 1
+	}
+	start := 0
+	if x.Frames > 0 && end-x.Frames > 0 {
+		start = end - x.Frames
+	}
+	if start > 0 {
+		fmt.Fprintf(out, "... %d earlier frames omitted\n\n", start)
+	}
+	for _, f := range frames[start:end] {
+		fmt.Fprintf(out, "Frame %d\n", f.index)
+		fmt.Fprintf(out, "  Expr: %s\n", x.truncate(f.expr))
+		if x.Env {
+			fmt.Fprintf(out, "  Env:  %s\n", x.truncate(f.env))
+		}
+		fmt.Fprintf(out, "  Cont: %s\n\n", x.truncate(f.cont))
+	}
+
+	fmt.Fprintf(out, "Iterations: %d\n", iterations)
+	fmt.Fprintf(out, "Output:     %s\n", formatLurkOutput(tag, val))
+	fmt.Fprintf(out, "Tag:        %s\n", tag)
+	fmt.Fprintf(out, "Value:      0x%x\n", val)
+
+	switch {
+	case failedAt >= 0:
+		fmt.Fprintf(out, "Result:     error at frame %d\n", frames[failedAt].index)
+		fmt.Fprintf(out, "            %s\n", x.truncate(frames[failedAt].expr))
+	case tag == zk.TagSym && bytes.Equal(val, zk.OutputTrue):
+		fmt.Fprintln(out, "Result:     the script returned t and would unlock")
+	default:
+		fmt.Fprintln(out, "Result:     the script did not return t and would not unlock")
+	}
+}
+
+func (x *DebugScript) truncate(s string) string {
+	if x.Full || len(s) <= maxFrameFieldLen {
+		return s
+	}
+	return s[:maxFrameFieldLen] + "..."
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/project-illium/ilxd/rpc/pb"
+	"github.com/project-illium/ilxd/types"
+)
+
+type DelegateStake struct {
+	DelegateKey string `short:"k" long:"delegatekey" description:"The delegate's spend public key. The delegate can stake the coins but not spend them. Serialized as hex string."`
+	Amount      string `short:"a" long:"amount" description:"The amount of ILX to delegate"`
+	FeePerKB    string `short:"f" long:"feeperkb" description:"The fee per kilobyte to pay for the transaction. If zero the wallet will use its default fee."`
+	opts        *options
+}
+
+func (x *DelegateStake) Execute(args []string) error {
+	client, err := makeWalletClient(x.opts)
+	if err != nil {
+		return err
+	}
+	key, err := hex.DecodeString(x.DelegateKey)
+	if err != nil {
+		return err
+	}
+	amount, err := types.AmountFromILX(x.Amount)
+	if err != nil {
+		return err
+	}
+	fpkb, err := types.AmountFromILX(x.FeePerKB)
+	if err != nil {
+		return err
+	}
+	resp, err := client.DelegateStake(makeContext(x.opts.AuthToken), &pb.DelegateStakeRequest{
+		DelegatePublicKey: key,
+		Amount:            uint64(amount),
+		FeePerKilobyte:    uint64(fpkb),
+	})
+	if err != nil {
+		return err
+	}
+	out, err := json.MarshalIndent(struct {
+		Address        string             `json:"address"`
+		LockingScript  types.HexEncodable `json:"lockingScript"`
+		ViewPrivateKey types.HexEncodable `json:"viewPrivateKey"`
+		Txid           types.HexEncodable `json:"txid"`
+	}{
+		Address:        resp.Address,
+		LockingScript:  resp.LockingScript,
+		ViewPrivateKey: resp.ViewPrivateKey,
+		Txid:           resp.Transaction_ID,
+	}, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+type RevokeDelegation struct {
+	Address  string `short:"a" long:"addr" description:"The cold staking address"`
+	FeePerKB string `short:"f" long:"feeperkb" description:"The fee per kilobyte to pay for the transaction. If zero the wallet will use its default fee."`
+	opts     *options
+}
+
+func (x *RevokeDelegation) Execute(args []string) error {
+	client, err := makeWalletClient(x.opts)
+	if err != nil {
+		return err
+	}
+	fpkb, err := types.AmountFromILX(x.FeePerKB)
+	if err != nil {
+		return err
+	}
+	resp, err := client.RevokeDelegation(makeContext(x.opts.AuthToken), &pb.RevokeDelegationRequest{
+		Address:        x.Address,
+		FeePerKilobyte: uint64(fpkb),
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Println(hex.EncodeToString(resp.Transaction_ID))
+	return nil
+}
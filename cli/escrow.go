@@ -0,0 +1,627 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	icrypto "github.com/project-illium/ilxd/crypto"
+	"github.com/project-illium/ilxd/params"
+	"github.com/project-illium/ilxd/repo"
+	"github.com/project-illium/ilxd/rpc/pb"
+	"github.com/project-illium/ilxd/types"
+	"github.com/project-illium/ilxd/zk"
+	"github.com/project-illium/walletlib"
+	"github.com/pterm/pterm"
+	"google.golang.org/protobuf/proto"
+	"os"
+	"strings"
+)
+
+// escrowThreshold is the number of the three escrow keys needed to
+// release the funds. Either the buyer and seller agree, or the
+// arbiter sides with one of them.
+const escrowThreshold = 2
+
+// escrowParty is one of the three participants in an escrow.
+type escrowParty struct {
+	PublicKey     types.HexEncodable `json:"publicKey"`
+	PayoutAddress string             `json:"payoutAddress,omitempty"`
+}
+
+// escrowTemplate describes a 2-of-3 escrow. It is created by createescrow
+// and shared between the buyer, seller, and arbiter. Every other escrow
+// command reads it so that the parties never need to handle the locking
+// script or keys by hand.
+//
+// The template contains the escrow's view private key. This lets each
+// party detect the funding transaction but does not allow anyone to
+// spend from the escrow.
+type escrowTemplate struct {
+	Address        string             `json:"address"`
+	LockingScript  types.HexEncodable `json:"lockingScript"`
+	ViewPrivateKey types.HexEncodable `json:"viewPrivateKey"`
+	Amount         types.Amount       `json:"amount"`
+	Buyer          escrowParty        `json:"buyer"`
+	Seller         escrowParty        `json:"seller"`
+	Arbiter        escrowParty        `json:"arbiter"`
+}
+
+func loadEscrowTemplate(filename string) (*escrowTemplate, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var template escrowTemplate
+	if err := json.Unmarshal(data, &template); err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// roles returns the escrow parties in the order their keys appear in
+// the locking script.
+func (t *escrowTemplate) roles() ([]string, []escrowParty) {
+	return []string{"buyer", "seller", "arbiter"}, []escrowParty{t.Buyer, t.Seller, t.Arbiter}
+}
+
+// payoutAddress returns the payout address for the buyer or seller.
+func (t *escrowTemplate) payoutAddress(role string, chainParams *params.NetworkParams) (walletlib.Address, error) {
+	var addr string
+	switch strings.ToLower(role) {
+	case "buyer":
+		addr = t.Buyer.PayoutAddress
+	case "seller":
+		addr = t.Seller.PayoutAddress
+	default:
+		return nil, errors.New("funds can only be released to the buyer or seller")
+	}
+	if addr == "" {
+		return nil, fmt.Errorf("escrow has no payout address for the %s", role)
+	}
+	return walletlib.DecodeAddress(addr, chainParams)
+}
+
+// checkRelease validates that a raw transaction spends only from the
+// escrow and that its private outputs match the transaction. It returns
+// the transaction's sighash. Signers rely on this check to know exactly
+// where the funds they are releasing will go.
+func (t *escrowTemplate) checkRelease(rawTx *pb.RawTransaction) ([]byte, error) {
+	if rawTx.Tx == nil || rawTx.Tx.GetStandardTransaction() == nil {
+		return nil, errors.New("release must be a standard transaction")
+	}
+	standardTx := rawTx.Tx.GetStandardTransaction()
+	if len(rawTx.Inputs) == 0 || len(rawTx.Inputs) != len(standardTx.Nullifiers) {
+		return nil, errors.New("release inputs do not match transaction")
+	}
+	if len(rawTx.Outputs) != len(standardTx.Outputs) {
+		return nil, errors.New("release outputs do not match transaction")
+	}
+
+	var lockingScript types.LockingScript
+	if err := lockingScript.Deserialize(t.LockingScript); err != nil {
+		return nil, err
+	}
+	for i, in := range rawTx.Inputs {
+		if in.Script != zk.MultisigScript() || len(in.LockingParams) != len(lockingScript.LockingParams) {
+			return nil, fmt.Errorf("input %d is not locked by the escrow", i)
+		}
+		for j := range in.LockingParams {
+			if !bytes.Equal(in.LockingParams[j], lockingScript.LockingParams[j]) {
+				return nil, fmt.Errorf("input %d is not locked by the escrow", i)
+			}
+		}
+	}
+	if err := checkPrivateOutputs(rawTx); err != nil {
+		return nil, err
+	}
+	return standardTx.SigHash()
+}
+
+// checkPrivateOutputs validates that the private outputs of a raw standard
+// transaction match the output commitments in the transaction.
+func checkPrivateOutputs(rawTx *pb.RawTransaction) error {
+	standardTx := rawTx.Tx.GetStandardTransaction()
+	for i, out := range rawTx.Outputs {
+		state := new(types.State)
+		if err := state.Deserialize(out.State); err != nil {
+			return err
+		}
+		note := types.SpendNote{
+			ScriptHash: types.NewID(out.ScriptHash),
+			Amount:     types.Amount(out.Amount),
+			AssetID:    types.NewID(out.Asset_ID),
+			State:      *state,
+		}
+		copy(note.Salt[:], out.Salt)
+		commitment, err := note.Commitment()
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(commitment[:], standardTx.Outputs[i].Commitment) {
+			return fmt.Errorf("output %d does not match transaction", i)
+		}
+	}
+	return nil
+}
+
+func decodeRawTransaction(s string) (*pb.RawTransaction, error) {
+	txBytes, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	var rawTx pb.RawTransaction
+	if err := proto.Unmarshal(txBytes, &rawTx); err != nil {
+		return nil, err
+	}
+	return &rawTx, nil
+}
+
+type CreateEscrow struct {
+	BuyerPubKey   string `short:"b" long:"buyer" description:"The buyer's spend public key. Serialized as hex string."`
+	SellerPubKey  string `short:"s" long:"seller" description:"The seller's spend public key. Serialized as hex string."`
+	ArbiterPubKey string `short:"r" long:"arbiter" description:"The arbiter's spend public key. Serialized as hex string."`
+	BuyerAddress  string `long:"buyeraddr" description:"The address the buyer is refunded to if the escrow is cancelled"`
+	SellerAddress string `long:"selleraddr" description:"The address the seller is paid to when the escrow is released"`
+	Amount        string `short:"t" long:"amount" description:"The amount the buyer is expected to fund the escrow with"`
+	Output        string `short:"o" long:"output" description:"The file to write the escrow template to. Share this file with the other parties." default:"escrow.json"`
+	opts          *options
+}
+
+func (x *CreateEscrow) Execute(args []string) error {
+	chainParams, err := networkParams(x.opts)
+	if err != nil {
+		return err
+	}
+	amount, err := types.AmountFromILX(x.Amount)
+	if err != nil {
+		return err
+	}
+	for _, addr := range []string{x.BuyerAddress, x.SellerAddress} {
+		if addr == "" {
+			continue
+		}
+		if _, err := walletlib.DecodeAddress(addr, chainParams); err != nil {
+			return err
+		}
+	}
+
+	threshold := make([]byte, 4)
+	binary.BigEndian.PutUint32(threshold, escrowThreshold)
+
+	scriptCommitment, err := zk.LurkCommit(zk.MultisigScript())
+	if err != nil {
+		return err
+	}
+	lockingScript := types.LockingScript{
+		ScriptCommitment: types.NewID(scriptCommitment),
+		LockingParams:    [][]byte{threshold},
+	}
+
+	pubkeys := make([][]byte, 0, 3)
+	for _, p := range []string{x.BuyerPubKey, x.SellerPubKey, x.ArbiterPubKey} {
+		keyBytes, err := hex.DecodeString(p)
+		if err != nil {
+			return err
+		}
+		pubkey, err := crypto.UnmarshalPublicKey(keyBytes)
+		if err != nil {
+			return err
+		}
+		novaKey, ok := pubkey.(*icrypto.NovaPublicKey)
+		if !ok {
+			return errors.New("pubkey is not type Nova public key")
+		}
+		pubX, pubY := novaKey.ToXY()
+		lockingScript.LockingParams = append(lockingScript.LockingParams, pubX, pubY)
+		pubkeys = append(pubkeys, keyBytes)
+	}
+
+	viewKey, _, err := icrypto.GenerateCurve25519Key(rand.Reader)
+	if err != nil {
+		return err
+	}
+	viewKeyBytes, err := crypto.MarshalPrivateKey(viewKey)
+	if err != nil {
+		return err
+	}
+
+	addr, err := walletlib.NewBasicAddress(lockingScript, viewKey.GetPublic(), chainParams)
+	if err != nil {
+		return err
+	}
+
+	template := escrowTemplate{
+		Address:        addr.String(),
+		LockingScript:  lockingScript.Serialize(),
+		ViewPrivateKey: viewKeyBytes,
+		Amount:         amount,
+		Buyer:          escrowParty{PublicKey: pubkeys[0], PayoutAddress: x.BuyerAddress},
+		Seller:         escrowParty{PublicKey: pubkeys[1], PayoutAddress: x.SellerAddress},
+		Arbiter:        escrowParty{PublicKey: pubkeys[2]},
+	}
+	out, err := json.MarshalIndent(&template, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(x.Output, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(out); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	fmt.Println(string(out))
+	return nil
+}
+
+type ImportEscrow struct {
+	File             string `short:"f" long:"file" description:"The escrow template created by createescrow" default:"escrow.json"`
+	Rescan           bool   `short:"r" long:"rescan" description:"Whether or not to rescan the blockchain to try to detect the funding transaction."`
+	RescanFromHeight uint32 `short:"t" long:"rescanheight" description:"The height of the chain to rescan from."`
+	opts             *options
+}
+
+func (x *ImportEscrow) Execute(args []string) error {
+	template, err := loadEscrowTemplate(x.File)
+	if err != nil {
+		return err
+	}
+	client, err := makeWalletClient(x.opts)
+	if err != nil {
+		return err
+	}
+	_, err = client.ImportAddress(makeContext(x.opts.AuthToken), &pb.ImportAddressRequest{
+		Address:          template.Address,
+		LockingScript:    template.LockingScript,
+		ViewPrivateKey:   template.ViewPrivateKey,
+		Rescan:           x.Rescan,
+		RescanFromHeight: x.RescanFromHeight,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("success")
+	return nil
+}
+
+type GetEscrowStatus struct {
+	File string `short:"f" long:"file" description:"The escrow template created by createescrow" default:"escrow.json"`
+	opts *options
+}
+
+func (x *GetEscrowStatus) Execute(args []string) error {
+	template, err := loadEscrowTemplate(x.File)
+	if err != nil {
+		return err
+	}
+	client, err := makeWalletClient(x.opts)
+	if err != nil {
+		return err
+	}
+	resp, err := client.GetUtxos(makeContext(x.opts.AuthToken), &pb.GetUtxosRequest{})
+	if err != nil {
+		return err
+	}
+
+	type utxo struct {
+		Commitment types.HexEncodable `json:"commitment"`
+		Amount     types.Amount       `json:"amount"`
+	}
+	s := struct {
+		Address  string       `json:"address"`
+		Expected types.Amount `json:"expected"`
+		Balance  types.Amount `json:"balance"`
+		Funded   bool         `json:"funded"`
+		Utxos    []utxo       `json:"utxos"`
+	}{
+		Address:  template.Address,
+		Expected: template.Amount,
+		Utxos:    []utxo{},
+	}
+	for _, ut := range resp.Utxos {
+		if ut.Address != template.Address {
+			continue
+		}
+		s.Balance += types.Amount(ut.Amount)
+		s.Utxos = append(s.Utxos, utxo{
+			Commitment: ut.Commitment,
+			Amount:     types.Amount(ut.Amount),
+		})
+	}
+	s.Funded = s.Balance > 0 && s.Balance >= template.Amount
+
+	out, err := json.MarshalIndent(&s, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+type CreateEscrowRelease struct {
+	File     string `short:"f" long:"file" description:"The escrow template created by createescrow" default:"escrow.json"`
+	To       string `long:"to" description:"Who to release the escrow to: [seller, buyer]. Releasing to the buyer refunds the escrow." default:"seller"`
+	FeePerKB string `long:"feeperkb" description:"The fee per kilobyte to pay for this transaction. If zero the default fee will be used."`
+	opts     *options
+}
+
+func (x *CreateEscrowRelease) Execute(args []string) error {
+	template, err := loadEscrowTemplate(x.File)
+	if err != nil {
+		return err
+	}
+	chainParams, err := networkParams(x.opts)
+	if err != nil {
+		return err
+	}
+	payTo, err := template.payoutAddress(x.To, chainParams)
+	if err != nil {
+		return err
+	}
+	fpkb, err := types.AmountFromILX(x.FeePerKB)
+	if err != nil {
+		return err
+	}
+	if fpkb == 0 {
+		fpkb = repo.DefaultFeePerKilobyte
+	}
+
+	client, err := makeWalletClient(x.opts)
+	if err != nil {
+		return err
+	}
+	resp, err := client.GetUtxos(makeContext(x.opts.AuthToken), &pb.GetUtxosRequest{})
+	if err != nil {
+		return err
+	}
+	var (
+		inputs []*pb.CreateRawTransactionRequest_Input
+		total  types.Amount
+	)
+	for _, ut := range resp.Utxos {
+		if ut.Address != template.Address {
+			continue
+		}
+		inputs = append(inputs, &pb.CreateRawTransactionRequest_Input{
+			CommitmentOrPrivateInput: &pb.CreateRawTransactionRequest_Input_Commitment{
+				Commitment: ut.Commitment,
+			},
+		})
+		total += types.Amount(ut.Amount)
+	}
+	if len(inputs) == 0 {
+		return errors.New("escrow is not funded, use importescrow to detect the funding transaction")
+	}
+
+	// The wallet sizes the fee as if there were a change output. There is
+	// no change here so the whole balance, less that fee, goes to the payee.
+	fee := walletlib.ComputeFee(len(inputs), 2, fpkb)
+	if total <= fee {
+		return errors.New("escrow balance does not cover the fee")
+	}
+
+	rawResp, err := client.CreateRawTransaction(makeContext(x.opts.AuthToken), &pb.CreateRawTransactionRequest{
+		Inputs: inputs,
+		Outputs: []*pb.CreateRawTransactionRequest_Output{
+			{
+				Address: payTo.String(),
+				Amount:  uint64(total - fee),
+			},
+		},
+		AppendChangeOutput: false,
+		FeePerKilobyte:     uint64(fpkb),
+	})
+	if err != nil {
+		return err
+	}
+	ser, err := proto.Marshal(rawResp.RawTx)
+	if err != nil {
+		return err
+	}
+	fmt.Println(hex.EncodeToString(ser))
+	return nil
+}
+
+type SignEscrow struct {
+	File       string `short:"f" long:"file" description:"The escrow template created by createescrow" default:"escrow.json"`
+	Tx         string `short:"t" long:"tx" description:"The release transaction created by createescrowrelease. Serialized as hex string."`
+	PrivateKey string `short:"k" long:"privkey" description:"Your spend private key for the escrow. Serialized as hex string. If not set the signer command is used."`
+	opts       *options
+}
+
+func (x *SignEscrow) Execute(args []string) error {
+	template, err := loadEscrowTemplate(x.File)
+	if err != nil {
+		return err
+	}
+	chainParams, err := networkParams(x.opts)
+	if err != nil {
+		return err
+	}
+	rawTx, err := decodeRawTransaction(x.Tx)
+	if err != nil {
+		return err
+	}
+	sigHash, err := template.checkRelease(rawTx)
+	if err != nil {
+		return err
+	}
+
+	names, parties := template.roles()
+	candidates := make([]crypto.PubKey, 0, len(parties))
+	for _, party := range parties {
+		pubkey, err := crypto.UnmarshalPublicKey(party.PublicKey)
+		if err != nil {
+			return err
+		}
+		candidates = append(candidates, pubkey)
+	}
+	privKey, err := signingKey(x.opts, x.PrivateKey, candidates)
+	if err != nil {
+		return err
+	}
+	pubKeyBytes, err := crypto.MarshalPublicKey(privKey.GetPublic())
+	if err != nil {
+		return err
+	}
+	role := ""
+	for i, party := range parties {
+		if bytes.Equal(party.PublicKey, pubKeyBytes) {
+			role = names[i]
+			break
+		}
+	}
+	if role == "" {
+		return errors.New("private key is not one of the escrow keys")
+	}
+
+	// Show the signer where the funds go so the arbiter, in particular,
+	// can confirm the release matches their ruling before signing.
+	type payment struct {
+		PayTo  string       `json:"payTo"`
+		Amount types.Amount `json:"amount"`
+	}
+	payments := make([]payment, 0, len(rawTx.Outputs))
+	for _, out := range rawTx.Outputs {
+		payTo := "unknown"
+		for _, r := range []string{"buyer", "seller"} {
+			addr, err := template.payoutAddress(r, chainParams)
+			if err != nil {
+				continue
+			}
+			scriptHash := addr.ScriptHash()
+			if bytes.Equal(out.ScriptHash, scriptHash[:]) {
+				payTo = r
+			}
+		}
+		payments = append(payments, payment{
+			PayTo:  payTo,
+			Amount: types.Amount(out.Amount),
+		})
+	}
+
+	sig, err := privKey.Sign(sigHash)
+	if err != nil {
+		return err
+	}
+
+	s := struct {
+		Role      string             `json:"role"`
+		Payments  []payment          `json:"payments"`
+		Fee       types.Amount       `json:"fee"`
+		Signature types.HexEncodable `json:"signature"`
+	}{
+		Role:      role,
+		Payments:  payments,
+		Fee:       types.Amount(rawTx.Tx.GetStandardTransaction().Fee),
+		Signature: sig,
+	}
+	out, err := json.MarshalIndent(&s, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+type FinalizeEscrow struct {
+	File       string   `short:"f" long:"file" description:"The escrow template created by createescrow" default:"escrow.json"`
+	Tx         string   `short:"t" long:"tx" description:"The release transaction created by createescrowrelease. Serialized as hex string."`
+	Signatures []string `short:"i" long:"sig" description:"A signature created by signescrow. Use this option twice, once for each signing party."`
+	opts       *options
+}
+
+func (x *FinalizeEscrow) Execute(args []string) error {
+	template, err := loadEscrowTemplate(x.File)
+	if err != nil {
+		return err
+	}
+	rawTx, err := decodeRawTransaction(x.Tx)
+	if err != nil {
+		return err
+	}
+	sigHash, err := template.checkRelease(rawTx)
+	if err != nil {
+		return err
+	}
+
+	sigs := make([][]byte, 0, len(x.Signatures))
+	for _, s := range x.Signatures {
+		sig, err := hex.DecodeString(s)
+		if err != nil {
+			return err
+		}
+		sigs = append(sigs, sig)
+	}
+
+	// The multisig script expects the signatures in the same order as
+	// the keys in the locking script.
+	ordered := make([][]byte, 0, escrowThreshold)
+	_, parties := template.roles()
+	for _, party := range parties {
+		pubkey, err := crypto.UnmarshalPublicKey(party.PublicKey)
+		if err != nil {
+			return err
+		}
+		for _, sig := range sigs {
+			valid, err := pubkey.Verify(sigHash, sig)
+			if err == nil && valid {
+				ordered = append(ordered, sig)
+				break
+			}
+		}
+		if len(ordered) == escrowThreshold {
+			break
+		}
+	}
+	if len(ordered) < escrowThreshold {
+		return fmt.Errorf("%d valid signatures from different parties are required", escrowThreshold)
+	}
+
+	walletClient, err := makeWalletClient(x.opts)
+	if err != nil {
+		return err
+	}
+	blockchainClient, err := makeBlockchainClient(x.opts)
+	if err != nil {
+		return err
+	}
+
+	spinner, err := pterm.DefaultSpinner.Start(provingPhrase())
+	if err != nil {
+		return err
+	}
+	proveResp, err := walletClient.ProveMultisig(makeContext(x.opts.AuthToken), &pb.ProveMultisigRequest{
+		RawTx: rawTx,
+		Sigs:  ordered,
+	})
+	if err != nil {
+		spinner.Fail(trf("Error proving transaction: %s", err.Error()))
+		return nil
+	}
+	submitResp, err := blockchainClient.SubmitTransaction(makeContext(x.opts.AuthToken), &pb.SubmitTransactionRequest{
+		Transaction: proveResp.ProvedTx,
+	})
+	if err != nil {
+		spinner.Fail(trf("Error submitting transaction: %s", err.Error()))
+		return nil
+	}
+	spinner.Success(hex.EncodeToString(submitResp.Transaction_ID))
+	return nil
+}
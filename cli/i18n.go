@@ -0,0 +1,145 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/project-illium/ilxd/repo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	mrand "math/rand"
+	"os"
+	"strings"
+)
+
+// Messages meant for people, such as prompts, progress, and errors, are
+// translated. Output meant for other programs, such as JSON responses,
+// transaction IDs, and "success", is not so scripts work in any locale.
+//
+// The English text of a message is its key in the catalogs. A message
+// missing from the catalog for the locale is printed in English.
+
+// catalogs maps a language code to its translations.
+var catalogs = map[string]map[string]string{
+	"es": catalogES,
+}
+
+// translations is the catalog for the selected locale. It's nil for English.
+var translations map[string]string
+
+// setLocale selects the catalog used to translate messages. The language
+// is taken from the lang option, or if that is not set, from the LC_ALL,
+// LC_MESSAGES, or LANG environment variables. If a file is given it is
+// loaded as a JSON object mapping English messages to their translations
+// and takes precedence over the built-in catalog.
+func setLocale(lang, file string) error {
+	if lang == "" {
+		for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+			if v := os.Getenv(env); v != "" {
+				lang = v
+				break
+			}
+		}
+	}
+	lang = normalizeLang(lang)
+
+	translations = nil
+	if catalog, ok := catalogs[lang]; ok {
+		translations = make(map[string]string, len(catalog))
+		for k, v := range catalog {
+			translations[k] = v
+		}
+	}
+	if file == "" {
+		return nil
+	}
+	b, err := os.ReadFile(repo.CleanAndExpandPath(file))
+	if err != nil {
+		return err
+	}
+	var custom map[string]string
+	if err := json.Unmarshal(b, &custom); err != nil {
+		return fmt.Errorf("invalid language file: %s", err)
+	}
+	if translations == nil {
+		translations = make(map[string]string, len(custom))
+	}
+	for k, v := range custom {
+		translations[k] = v
+	}
+	return nil
+}
+
+// normalizeLang reduces a locale such as es_ES.UTF-8 to its language code.
+func normalizeLang(lang string) string {
+	lang = strings.ToLower(lang)
+	if i := strings.IndexAny(lang, "_-.@"); i >= 0 {
+		lang = lang[:i]
+	}
+	switch lang {
+	case "c", "posix":
+		return "en"
+	}
+	return lang
+}
+
+// tr returns the translation of the message for the selected locale.
+func tr(msg string) string {
+	if t, ok := translations[msg]; ok {
+		return t
+	}
+	return msg
+}
+
+// trf translates the format string and then formats it with the args.
+func trf(format string, args ...any) string {
+	return fmt.Sprintf(tr(format), args...)
+}
+
+// provingPhrases are shown while a transaction is being proved.
+var provingPhrases = []string{
+	"Hang tight! We're doing moon math.",
+	"Patience, we're bending the laws of math for you.",
+	"Hang in there, we're bending the fabric of the cosmos.",
+	"Just a sec, bending the blockchain to our will.",
+	"Hang tight, your transaction is in the oven.",
+	"Sit tight, charging warp coils.",
+	"Be patient, traveling through hyperspace ain't like dusting crops.",
+}
+
+// provingPhrase returns a random, translated phrase to show while a
+// transaction is being proved.
+func provingPhrase() string {
+	return tr(provingPhrases[mrand.Intn(len(provingPhrases))])
+}
+
+// localizeError translates errors which originate in the CLI. Errors from
+// the node are returned as is.
+func localizeError(err error) error {
+	if errors.Is(err, errAborted) {
+		return errors.New(tr(errAborted.Error()))
+	}
+	return err
+}
+
+// errorHint returns a translated suggestion for how to fix the error, or
+// an empty string if there is none.
+func errorHint(err error) string {
+	st, ok := status.FromError(err)
+	if !ok {
+		return ""
+	}
+	switch st.Code() {
+	case codes.Unavailable:
+		return tr("Hint: if the node could not be reached check that ilxd is running and that --serveraddr and --rpccert are correct.")
+	case codes.Unauthenticated:
+		return tr("Hint: the node requires an authentication token. Set it with --authtoken.")
+	case codes.PermissionDenied:
+		return tr("Hint: the wallet may be locked. Unlock it with walletunlock.")
+	}
+	return ""
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package main
+
+// catalogES holds the Spanish translations.
+var catalogES = map[string]string{
+	// Prompts
+	"[y/N]": "[s/N]",
+	"y":     "s",
+	"yes":   "sí",
+	"This permanently deletes the wallet's private keys. Make sure the seed is backed up. Continue?": "Esto elimina de forma permanente las claves privadas de la billetera. Asegúrese de tener una copia de la semilla. ¿Continuar?",
+	"Stake %d utxo(s)?":                    "¿Hacer stake de %d utxo(s)?",
+	"Execute spend template %s?":           "¿Ejecutar la plantilla de gasto %s?",
+	"Send all funds to %s?":                "¿Enviar todos los fondos a %s?",
+	"Send %s ILX to %s?":                   "¿Enviar %s ILX a %s?",
+	"Send %f ILX to %s?":                   "¿Enviar %f ILX a %s?",
+	"Lock %s ILX until %s?":                "¿Bloquear %s ILX hasta %s?",
+	"Spendable balance: %f ILX":            "Saldo disponible: %f ILX",
+	"Recipient address":                    "Dirección del destinatario",
+	"Amount (ILX)":                         "Monto (ILX)",
+	"Invalid amount: %s":                   "Monto no válido: %s",
+	"Amount exceeds the spendable balance": "El monto supera el saldo disponible",
+	"Coin selection":                       "Selección de monedas",
+	"Coin selection strategy":              "Estrategia de selección de monedas",
+	"Let the wallet choose":                "Dejar que la billetera elija",
+	"Choose the coins to spend":            "Elegir las monedas a gastar",
+	"Coins to spend":                       "Monedas a gastar",
+	"Selected coins total %f ILX which does not cover the amount": "Las monedas seleccionadas suman %f ILX, lo que no cubre el monto",
+	"Review":                    "Revisión",
+	"Fee":                       "Comisión",
+	"Fast":                      "Rápida",
+	"Normal":                    "Normal",
+	"Economy":                   "Económica",
+	"%s (%d blocks): %f ILX/kB": "%s (%d bloques): %f ILX/kB",
+	"Wallet default":            "Predeterminada de la billetera",
+	"Custom fee per kilobyte":   "Comisión personalizada por kilobyte",
+	"Fee per kilobyte (ILX)":    "Comisión por kilobyte (ILX)",
+	"Invalid fee: %s":           "Comisión no válida: %s",
+
+	// Progress
+	"Hang tight! We're doing moon math.":                                 "¡Espere! Estamos haciendo matemáticas lunares.",
+	"Patience, we're bending the laws of math for you.":                  "Paciencia, estamos doblando las leyes de las matemáticas por usted.",
+	"Hang in there, we're bending the fabric of the cosmos.":             "Aguante, estamos doblando el tejido del cosmos.",
+	"Just a sec, bending the blockchain to our will.":                    "Un segundo, doblegando la blockchain a nuestra voluntad.",
+	"Hang tight, your transaction is in the oven.":                       "Espere, su transacción está en el horno.",
+	"Sit tight, charging warp coils.":                                    "Espere, cargando las bobinas warp.",
+	"Be patient, traveling through hyperspace ain't like dusting crops.": "Paciencia, viajar por el hiperespacio no es como fumigar cultivos.",
+	"%s (evaluating)":                              "%s (evaluando)",
+	"%s (proving %d steps)":                        "%s (probando %d pasos)",
+	"%s (proving %d/%d steps, about %s remaining)": "%s (probando %d/%d pasos, quedan unos %s)",
+	"%s (compressing)":                             "%s (comprimiendo)",
+	"Stake transaction broadcast successfully":     "Transacción de stake difundida correctamente",
+
+	// Errors
+	"aborted":                                                   "cancelado",
+	"wallet has no spendable coins":                             "la billetera no tiene monedas disponibles",
+	"Error proving transaction: %s":                             "Error al probar la transacción: %s",
+	"Error serializing transaction: %s":                         "Error al serializar la transacción: %s",
+	"Error submitting transaction: %s":                          "Error al enviar la transacción: %s",
+	"node is running on %s but the client is configured for %s": "el nodo se ejecuta en %s pero el cliente está configurado para %s",
+	"Hint: if the node could not be reached check that ilxd is running and that --serveraddr and --rpccert are correct.": "Sugerencia: si no se pudo conectar con el nodo, compruebe que ilxd esté en ejecución y que --serveraddr y --rpccert sean correctos.",
+	"Hint: the node requires an authentication token. Set it with --authtoken.":                                          "Sugerencia: el nodo requiere un token de autenticación. Indíquelo con --authtoken.",
+	"Hint: the wallet may be locked. Unlock it with walletunlock.":                                                       "Sugerencia: es posible que la billetera esté bloqueada. Desbloquéela con walletunlock.",
+}
@@ -6,19 +6,23 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/jessevdk/go-flags"
 	"github.com/multiformats/go-multiaddr"
 	manet "github.com/multiformats/go-multiaddr/net"
+	"github.com/project-illium/ilxd/params"
 	"github.com/project-illium/ilxd/repo"
 	"github.com/project-illium/ilxd/rpc/pb"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/encoding/gzip"
 	"google.golang.org/grpc/metadata"
 	"log"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 const (
@@ -27,11 +31,18 @@ const (
 )
 
 type options struct {
-	ShowVersion bool   `short:"v" long:"version" description:"Display version information and exit"`
-	ConfigFile  string `short:"C" long:"configfile" description:"Path to configuration file"`
-	AuthToken   string `short:"t" long:"authtoken" description:"The ilxd node gRPC authentican token if needed"`
-	ServerAddr  string `short:"a" long:"serveraddr" description:"The address of the ilxd gRPC server (in multiaddr format)" default:"/ip4/127.0.0.1/tcp/5001"`
-	RPCCert     string `long:"rpccert" description:"A path to the SSL certificate to use with gRPC (this is only need if using a self-signed cert)" default:"~/.ilxd/rpc.cert"`
+	ShowVersion bool          `short:"v" long:"version" description:"Display version information and exit"`
+	ConfigFile  string        `short:"C" long:"configfile" description:"Path to configuration file"`
+	AuthToken   string        `short:"t" long:"authtoken" description:"The ilxd node gRPC authentican token if needed"`
+	ServerAddr  string        `short:"a" long:"serveraddr" description:"The address of the ilxd gRPC server (in multiaddr format)" default:"/ip4/127.0.0.1/tcp/5001"`
+	RPCCert     string        `long:"rpccert" description:"A path to the SSL certificate to use with gRPC (this is only need if using a self-signed cert)" default:"~/.ilxd/rpc.cert"`
+	MaxRecvSize int           `long:"maxrecvmsgsize" description:"The maximum size in bytes of a response the client will accept from the server" default:"16777216"`
+	Compress    bool          `long:"compress" description:"Ask the server to gzip compress its responses. This is useful for large responses over slow connections."`
+	Timeout     time.Duration `long:"timeout" env:"ILXCLI_TIMEOUT" description:"How long to wait for each response from the node, for example 30s or 10m. Defaults to one minute for queries and thirty minutes for commands which create a zk proof. A negative value waits indefinitely."`
+	Net         string        `long:"net" env:"ILXCLI_NET" description:"The network the node is expected to be running on: [mainnet, testnet, alphanet, regtest]. If set, commands that spend coins first verify the node is on this network. Default: mainnet"`
+	Lang        string        `long:"lang" env:"ILXCLI_LANG" description:"The language for prompts, progress, and error messages, for example es. Defaults to the LC_ALL, LC_MESSAGES, or LANG environment variable. Machine readable output is not translated."`
+	LangFile    string        `long:"langfile" env:"ILXCLI_LANGFILE" description:"A JSON file mapping English messages to their translations. Entries override the built-in translations for the selected language."`
+	SignerCmd   string        `long:"signer-cmd" env:"ILXCLI_SIGNER_CMD" description:"An external signer command, such as a hardware wallet bridge, to sign with instead of passing spend private keys. It is run once per request with a JSON request on stdin and must write a JSON response to stdout."`
 }
 
 func main() {
@@ -48,49 +59,66 @@ func main() {
 		configFile = filepath.Join(repo.DefaultHomeDir, defaultConfigFilename)
 	}
 
-	var opts options
-	parser := flags.NewParser(&opts, flags.Default)
-	err := flags.NewIniParser(parser).ParseFile(configFile)
+	cfg, err := loadCLIConfig(configFile)
 	if err != nil {
-		if _, ok := err.(*os.PathError); !ok {
-			fmt.Fprintf(os.Stderr, "Error parsing config "+
-				"file: %v\n", err)
-			usageMessage := "Use ilxcli -h to show usage"
-			fmt.Fprintln(os.Stderr, usageMessage)
-			log.Fatal(err)
-		}
+		fmt.Fprintf(os.Stderr, "Error parsing config "+
+			"file: %v\n", err)
+		usageMessage := "Use ilxcli -h to show usage"
+		fmt.Fprintln(os.Stderr, usageMessage)
+		log.Fatal(err)
 	}
 	if len(os.Args) == 2 && os.Args[1] == "-v" {
 		fmt.Println(repo.VersionString())
 		return
 	}
 
-	parser = flags.NewNamedParser("ilxcli", flags.HelpFlag)
+	var opts options
+	parser := flags.NewNamedParser("ilxcli", flags.HelpFlag)
 	parser.AddGroup("Connection options", "Configuration options for connecting to ilxd", &opts)
 
 	// Blockchain service
 	parser.AddCommand("getmempoolinfo", "Returns the state of the current mempool", "Returns the state of the current mempool", &GetMempoolInfo{&opts})
 	parser.AddCommand("getmempool", "Returns all the transactions in the mempool", "Returns all the transactions in the mempool", &GetMempool{&opts})
+	parser.AddCommand("getmempoolentry", "Returns a transaction from the mempool along with its fee data", "Returns a transaction from the mempool along with its size and fee data", &GetMempoolEntry{opts: &opts})
+	parser.AddCommand("getfeeestimate", "Returns the fee needed to confirm within a number of blocks", "Returns the fee per kilobyte a transaction should pay to be included in a block within the target number of blocks. The estimate is based on the fees of the transactions in the mempool and in the recent blocks.", &GetFeeEstimate{opts: &opts})
+	parser.AddCommand("getfeehistory", "Returns the fee rates paid in recent blocks", "Returns the distribution of the fee rates paid in each of the most recent blocks along with the median of the blocks' median fee rates. This can be used to smooth a fee estimate over a number of blocks. Fees are per kilobyte.", &GetFeeHistory{opts: &opts})
+	parser.AddCommand("verifyreserves", "Verify a reserves proof", "Verify a reserves proof created by provereserves against the current chain state and print the proven total", &VerifyReserves{opts: &opts})
+	parser.AddCommand("verifypayment", "Verify a payment proof", "Verify a payment proof created by provepayment and print the address, amount, and asset that were paid", &VerifyPayment{opts: &opts})
 	parser.AddCommand("getblockchaininfo", "Returns data about the blockchain", "Returns data about the blockchain including the most recent block hash and height", &GetBlockchainInfo{&opts})
+	parser.AddCommand("getchainstats", "Returns block size limits and utilization", "Returns the network's maximum block and transaction sizes, this node's block size soft limit, and how much of the block size limit recent blocks have used.", &GetChainStats{opts: &opts})
+	parser.AddCommand("getnetworkparams", "Returns the parameters of the network", "Returns the parameters of the network the node is running on. This includes the coin emission schedule, epoch length, protocol prefix, fee minimums, and the genesis block ID.", &GetNetworkParams{&opts})
 	parser.AddCommand("getblockinfo", "Returns a block header plus some extra metadata", "Returns a block header plus some extra metadata", &GetBlockInfo{opts: &opts})
 	parser.AddCommand("getblock", "Returns the detailed data for a block", "Returns the detailed data for a block", &GetBlock{opts: &opts})
 	parser.AddCommand("getcompressedblock", "Returns a block in compressed format", "Returns a block that is stripped down to just the outputs. It is the bare minimum information a client side wallet needs to compute its internal state.", &GetCompressedBlock{opts: &opts})
+	parser.AddCommand("getblockfilter", "Returns the compact filter for a block", "Returns the compact filter of the output commitments and nullifiers in a block. Light wallets can match the filter against their notes and only download the blocks which match. Requires BlockFilterIndex.", &GetBlockFilter{opts: &opts})
+	parser.AddCommand("getcommitmentsbyscripthash", "Returns the notes with a script hash", "Returns the commitments of all the notes in the chain with the given script hash. Only notes which were published unencrypted, such as those sent to public addresses or to a shared script, can be found. Requires ScriptIndex.", &GetCommitmentsByScriptHash{opts: &opts})
 	parser.AddCommand("gettransaction", "Returns the transaction for the given transaction ID", "Returns the transaction for the given transaction ID. Requires TxIndex.", &GetTransaction{opts: &opts})
 	parser.AddCommand("getmerkleproof", "Returns a Merkle (SPV) proof for a specific transaction in the provided block", "Returns a Merkle (SPV) proof for a specific transaction in the provided block. Requires TxIndex.", &GetMerkleProof{opts: &opts})
 	parser.AddCommand("getvalidator", "Returns all the information about the given validator", "Returns all the information about the given validator including the number of staked coins.", &GetValidator{opts: &opts})
 	parser.AddCommand("getvalidatorsetinfo", "Returns information about the validator set", "Returns information about the validator set.", &GetValidatorSetInfo{opts: &opts})
 	parser.AddCommand("getvalidatorset", "Returns all the validators in the current validator set", "Returns all the validators in the current validator set.", &GetValidatorSet{opts: &opts})
+	parser.AddCommand("getstakedistribution", "Returns statistics about the distribution of stake", "Returns a histogram and concentration metrics of the validator stake weights along with the minimum stake needed to produce a block within the requested number of blocks with the target probability.", &GetStakeDistribution{opts: &opts})
+	parser.AddCommand("getequivocationevidence", "Returns the equivocation evidence recorded by the node", "Returns the equivocation evidence recorded by the node. Evidence is two signed blocks at the same height from the same validator, either seen by this node or relayed by peers.", &GetEquivocationEvidence{opts: &opts})
 	parser.AddCommand("getaccumulatorcheckpoint", "Returns the accumulator at the requested height", "Returns the accumulator at the requested height. If there is no checkpoint at that height, the *prior* checkpoint found in the chain will be returned. If there is no prior checkpoint (as is prior to the first), an error will be returned.", &GetAccumulatorCheckpoint{opts: &opts})
+	parser.AddCommand("getepochsnapshot", "Returns the chain state commitment taken at the end of an epoch", "Returns the chain state commitment taken at the end of an epoch. The snapshot commits to the accumulator root, a hash of the nullifier set, and a hash of the validator set as of the last block of the epoch.", &GetEpochSnapshot{opts: &opts})
+	parser.AddCommand("getchaintips", "Returns the tips of the main chain and recently seen competing branches", "Returns the tip of the main chain along with the tips of recently seen competing branches, the height at which they fork from the main chain, and whether they are still pending in consensus or were rejected.", &GetChainTips{opts: &opts})
 	parser.AddCommand("submittransaction", "Validates a transaction and submits it to the network", "Validates a transaction and submits it to the network. An error will be returned if it fails validation.", &SubmitTransaction{opts: &opts})
+	parser.AddCommand("submitpackage", "Validates a group of transactions and submits them to the network together", "Validates a group of transactions and submits them to the network only if all of them are valid. If any transaction fails validation none are accepted and an error identifying it is returned.", &SubmitPackage{opts: &opts})
 
 	// Node service
 	parser.AddCommand("gethostinfo", "Returns info about the libp2p host", "Returns info about the libp2p host", &GetHostInfo{opts: &opts})
+	parser.AddCommand("validatorinfo", "Returns this node's staking status", "Returns this node's validator ID, staked coins and their expiration times, unclaimed rewards, the reward it can expect at the start of the next epoch given its current stake weight, and whether autostaking is enabled.", &GetValidatorInfo{opts: &opts})
 	parser.AddCommand("getnetworkkey", "Returns node's network private key", "Returns node's network private key", &GetNetworkKey{opts: &opts})
 	parser.AddCommand("getpeers", "Returns a list of peers that this node is connected to", "Returns a list of peers that this node is connected to", &GetPeers{opts: &opts})
 	parser.AddCommand("getpeerinfo", "Returns info about the peer", "Returns info about the peer if it is connected", &GetPeerInfo{opts: &opts})
 	parser.AddCommand("addpeer", "Attempts to connect to the provided peer", "Attempts to connect to the provided peer", &AddPeer{opts: &opts})
 	parser.AddCommand("blockpeer", "Blocks the given peer for the provided time period", "Blocks the given peer for the provided time period", &BlockPeer{opts: &opts})
 	parser.AddCommand("unblockpeer", "Removes a peer from the block list", "Removes a peer from the block list", &UnblockPeer{opts: &opts})
+	parser.AddCommand("getaccesslists", "Returns the connection allow and deny lists", "Returns the peer IDs and IP ranges in the connection allow and deny lists. If the allow list is not empty only inbound connections from a listed peer ID or IP range are accepted. Connections to or from anything in the deny list are always refused.", &GetAccessLists{opts: &opts})
+	parser.AddCommand("updateaccesslist", "Adds or removes rules from the connection allow or deny list", "Adds or removes peer IDs, IP addresses, or IP ranges in CIDR notation from the connection allow or deny list. This change is committed to the datastore and will persist between sessions. Existing connections which are no longer permitted are closed.", &UpdateAccessList{opts: &opts})
+	parser.AddCommand("getconsensuspeerscores", "Returns the consensus engine's validator reliability scores", "Returns the reliability scores the consensus engine keeps for the validators it polls. Validators are polled in proportion to their stake weight multiplied by their score and are temporarily banned from polling if the score falls too low.", &GetConsensusPeerScores{opts: &opts})
+	parser.AddCommand("getconsensusstate", "Returns the consensus engine's vote records and finalization latency", "Returns the blocks the consensus engine is voting on with their status and confidence, the number of polls in flight, the engine's queues, and a histogram of how long blocks have taken to finalize. Use this to see whether avalanche is stalled.", &GetConsensusState{opts: &opts})
+	parser.AddCommand("gettasks", "Returns the node's running background tasks", "Returns the node's running background tasks, such as chain sync, wallet and wallet server rescans, proofs, and consensus polls, along with when they started and their current state, plus the number of goroutines in the node. Use this to see what the node is doing when it appears to be stuck.", &GetTasks{opts: &opts})
 	parser.AddCommand("setloglevel", "Changes the logging level of the node", "Changes the logging level of the node", &SetLogLevel{opts: &opts})
 	parser.AddCommand("getminfeeperkilobyte", "Returns the node's current minimum transaction fee", "Returns the node's current minimum transaction fee needed to relay transactions and admit them into the mempool. Validators will also set their initial preference for blocks containing transactions with fees below this threshold to not-preferred.", &GetMinFeePerKilobyte{opts: &opts})
 	parser.AddCommand("setminfeeperkilobyte", "Sets the node's fee policy", "Sets the node's fee policy", &SetMinFeePerKilobyte{opts: &opts})
@@ -100,10 +128,23 @@ func main() {
 	parser.AddCommand("setblocksizesoftlimit", "Sets the node's blocksize soft limit policy", "Sets the node's blocksize soft limit policy.", &SetBlockSizeSoftLimit{opts: &opts})
 	parser.AddCommand("gettreasurywhitelist", "Returns the current treasury whitelist for the node", "Returns the current treasury whitelist for the node. Blocks containing TreasuryTransactions not found in this list will have their initial preference set to not-preferred.", &GetTreasuryWhitelist{opts: &opts})
 	parser.AddCommand("updatetreasurywhitelist", "Adds or removes a transaction from the treasury whitelist", "Adds or removes a transaction from the treasury whitelist. This change is committed to the datastore and will persist between sessions.", &UpdateTreasuryWhitelist{opts: &opts})
+	parser.AddCommand("createtreasuryproposal", "Drafts a treasury withdrawal for review by the validators", "Drafts a treasury withdrawal paying the provided outputs and creates its zk proof. The proposal is saved and printed along with a serialized copy, as hex, which can be handed to validators for review. The transaction is not broadcast until submittreasuryproposal is called.", &CreateTreasuryProposal{opts: &opts})
+	parser.AddCommand("importtreasuryproposal", "Imports a treasury proposal for review", "Decodes a serialized treasury proposal and checks that the outputs pay the listed addresses the listed amounts and that the treasury can cover the withdrawal. If the checks pass the proposal is saved. If --approve is used the transaction is also added to the treasury whitelist.", &ImportTreasuryProposal{opts: &opts})
+	parser.AddCommand("collecttreasuryapprovals", "Queries the validators for their approval of a treasury proposal", "Queries each validator for its treasury whitelist and records the validators, and the fraction of the weighted stake, which have approved the proposal.", &CollectTreasuryApprovals{opts: &opts})
+	parser.AddCommand("submittreasuryproposal", "Broadcasts a treasury proposal's transaction", "Broadcasts the proposal's treasury transaction. The transaction must be in this node's treasury whitelist.", &SubmitTreasuryProposal{opts: &opts})
+	parser.AddCommand("listtreasuryproposals", "Lists the saved treasury proposals", "Lists the saved treasury proposals which have not yet been confirmed in a block.", &ListTreasuryProposals{opts: &opts})
 	parser.AddCommand("reconsiderblock", "Tries to reprocess the given block", "Tries to reprocess the given block", &ReconsiderBlock{opts: &opts})
 	parser.AddCommand("recomputechainstate", "Rebuilds the entire chain state from genesis", "Deletes the accumulator, validator set, and nullifier set and rebuilds them by loading and re-processing all blocks from genesis.", &RecomputeChainState{opts: &opts})
-	parser.AddCommand("signmessage", "Sign a message with the network key", "Sign a message with the nework key", &SignMessage{opts: &opts})
+	parser.AddCommand("captureprofile", "Records a runtime profile of the node", "Records a cpu, heap, goroutine, block, mutex, allocs, or trace profile of the node for the given duration and writes it to the profiles directory inside the node's data directory. The file path is returned.", &CaptureProfile{opts: &opts})
+	parser.AddCommand("setmocktime", "Overrides the node's clock (regtest only)", "Overrides the node's clock with the provided unix timestamp. The node's notion of time remains fixed at this value until it is changed again. Setting the timestamp to zero restores the real clock. This command is only available in regtest mode.", &SetMockTime{opts: &opts})
+	parser.AddCommand("advancetime", "Moves the node's mock time forward (regtest only)", "Moves the node's mock time forward by the provided number of seconds. If a mock time is not set, it starts from the current time. The new timestamp is returned. This command is only available in regtest mode.", &AdvanceTime{opts: &opts})
+	parser.AddCommand("signmessage", "Sign a message with the network key or a wallet address", "Sign a message with the network key. If an address is provided the message is signed with the spend key of the wallet address instead.", &SignMessage{opts: &opts})
 	parser.AddCommand("verifymessage", "Verify a signed message", "Verify a signed message", &VerifyMessage{opts: &opts})
+	parser.AddCommand("replayconsensus", "Replay recorded consensus traffic", "Feed the avalanche traffic recorded by ilxd --recordconsensus into an offline consensus engine and print the blocks that were finalized or rejected, in order. This runs locally and does not connect to the node. The avalanche settings of the network selected with --net are used.", &ReplayConsensus{opts: &opts})
+
+	lurk, _ := parser.AddCommand("lurk", "Lurk development tools", "Tools for developing lurk locking scripts.", &struct{}{})
+	lurk.AddCommand("repl", "Interactive lurk evaluation loop", "Starts an interactive lurk evaluation loop. Names can be bound to expressions and scripts and live chain data, such as the current txo root or a transaction's sighash, nullifiers, and output commitments, can be pulled from the node into the environment. Type :help in the repl for the commands.", &LurkRepl{opts: &opts})
+	parser.AddCommand("debugscript", "Evaluate a lurk script with debugging", "Evaluates a lurk locking script with the private and public params in debug mode and prints the final frames of the evaluation, the number of iterations, the output tag and value, and the frame where the script failed if it did not return t. The script may be a file or an expression.", &DebugScript{opts: &opts})
 
 	// Wallet service
 	parser.AddCommand("getbalance", "Returns the combined balance of all addresses in the wallet", "Returns the combined balance of all addresses in the wallet", &GetBalance{opts: &opts})
@@ -113,50 +154,189 @@ func main() {
 	parser.AddCommand("getpublicaddress", "Returns the most recent public address of the wallet", "Returns a public address built from the wallet's most recent private key.", &GetPublicAddress{opts: &opts})
 	parser.AddCommand("getaddresses", "Returns all the addresses created by this wallet", "Returns all the addresses created by this wallet", &GetAddresses{opts: &opts})
 	parser.AddCommand("getaddrinfo", "Returns info about the given address", "Returns info about the given address", &GetAddrInfo{opts: &opts})
+	parser.AddCommand("getaddrinfobatch", "Returns info about multiple addresses", "Returns info about the given addresses, or all the wallet's addresses with --all, keyed by address", &GetAddrInfoBatch{opts: &opts})
+	parser.AddCommand("setcustomerid", "Associate a customer identifier with an address", "Associate an opaque customer identifier with one of the wallet's addresses. The identifier is included with the address in getaddrinfo, gettransactions, and wallet transaction notifications.", &SetCustomerID{opts: &opts})
+	parser.AddCommand("setaddresslabel", "Attach a label to an address", "Attach a label to one of the wallet's addresses. The label is included with the address in getaddrinfo, getutxos, gettransactions, and wallet transaction notifications.", &SetAddressLabel{opts: &opts})
+	parser.AddCommand("getaddressesbylabel", "Get the addresses with a label", "Returns the wallet's addresses with the given label.", &GetAddressesByLabel{opts: &opts})
+	parser.AddCommand("convertaddresses", "Re-encodes addresses into the current address format", "Re-encodes addresses into the current address format. Addresses using one of the network's legacy prefixes are accepted. If no addresses are provided all the wallet's addresses are converted.", &ConvertAddresses{opts: &opts})
 	parser.AddCommand("getnewaddress", "Generates a new address and returns it", "Generates a new address and returns it. Both a new spend key and view key will be derived from the mnemonic seed.", &GetNewAddress{opts: &opts})
 	parser.AddCommand("gettransactions", "Returns the list of transactions for the wallet", "Returns the list of transactions for the wallet", &GetTransactions{opts: &opts})
+	parser.AddCommand("notifytransactions", "Streams wallet transactions as they finalize", "Prints each transaction paying to or spending from the wallet as it finalizes, marked as incoming or outgoing, followed by a confirmation event for each new block until the transaction reaches --confirmations. Runs until interrupted.", &NotifyTransactions{opts: &opts})
 	parser.AddCommand("getutxos", "Returns a list of the wallet's current unspent transaction outputs (UTXOs)", "Returns a list of the wallet's current unspent transaction outputs (UTXOs)", &GetUtxos{opts: &opts})
 	parser.AddCommand("getprivatekey", "Returns the serialized spend and view keys for the given address", "Returns the serialized spend and view keys for the given address", &GetPrivateKey{opts: &opts})
+	parser.AddCommand("getprivatekeybatch", "Returns the serialized keys for multiple addresses", "Returns the serialized spend and view keys for the given addresses, or all the wallet's addresses with --all, keyed by address", &GetPrivateKeyBatch{opts: &opts})
+	parser.AddCommand("provereserves", "Create a proof of the wallet's reserves", "Create a proof that the wallet controls unspent coins totaling at least the given amount. The proof reveals the notes it contains, so only as many of the largest notes as are needed are included.", &ProveReserves{opts: &opts})
+	parser.AddCommand("provepayment", "Create a proof of a payment", "Create a proof that a transaction output paid a specific amount to a specific address. The proof discloses only that output's note. If the wallet cannot decrypt the output, such as when proving a payment you sent, the private output returned by createrawtransaction must be provided.", &ProvePayment{opts: &opts})
+	parser.AddCommand("requestinvoice", "Request a one-time address from a payee", "Request a fresh one-time address and payment terms from the node serving invoices for the payee's address. The terms are authenticated against the payee's address before they are returned.", &RequestInvoice{opts: &opts})
+	parser.AddCommand("createinvoice", "Create a merchant invoice", "Create a merchant invoice paid to a fresh wallet address. Payments are tracked as they are accepted into the mempool (PAID), included in a block being voted on (CONFIRMED), and finalized (FINALIZED). If a webhook URL is provided the JSON encoded invoice is POSTed to it each time its status changes. The invoice includes an ilx: payment URI which the payer can pass to spend --uri.", &CreateInvoice{opts: &opts})
+	parser.AddCommand("getinvoicestatus", "Get the status of a merchant invoice", "Get a merchant invoice along with the payments made to it and its current status", &GetInvoiceStatus{opts: &opts})
 	parser.AddCommand("importaddress", "Imports a watch address into the wallet", "Imports a watch address into the wallet", &ImportAddress{opts: &opts})
+	parser.AddCommand("importaddresses", "Imports several watch addresses into the wallet", "Imports a batch of watch addresses from a JSON file. If a rescan is requested it is run once for the whole batch rather than once per address.", &ImportAddresses{opts: &opts})
+	parser.AddCommand("getrescanstatus", "Get the progress of the wallet's rescan", "Returns the progress of the wallet's current or most recent rescan, such as one started by importaddress --rescan, along with an estimate of how long it has left.", &GetRescanStatus{opts: &opts})
+	parser.AddCommand("exportwatchonly", "Export the wallet's addresses and view keys", "Export the addresses, locking scripts, and view keys needed to watch this wallet from another node. The output can be imported with importaddresses, for example to set up a wallet read replica running with walletreadonly. The view keys reveal the wallet's transaction history so keep the file private.", &ExportWatchOnly{opts: &opts})
+	parser.AddCommand("exportdescriptor", "Export an account descriptor for a watch-only node", "Export the wallet's view keys, locking scripts, and a change address, but none of the spend keys, as a single account descriptor string. Import it on an online node with importdescriptor to track the balance and build unsigned transactions that are then signed by this wallet. The descriptor reveals the wallet's transaction history so keep it private.", &ExportDescriptor{opts: &opts})
+	parser.AddCommand("importdescriptor", "Import an account descriptor", "Import an account descriptor created by exportdescriptor into this wallet as watch-only. Spend the account's coins with createrawtransaction, passing the input commitments and the descriptor's change address with changeaddr.", &ImportDescriptor{opts: &opts})
 	parser.AddCommand("createmultisigspendkeypair", "Generates a spend keypair for use in a multisig address", "Generates a spend keypair for use in a multisig address", &CreateMultisigSpendKeypair{opts: &opts})
 	parser.AddCommand("createmultisigviewkeypair", "Generates a view keypair for use in a multisig address", "Generates a view keypair for use in a multisig address", &CreateMultisigViewKeypair{opts: &opts})
 	parser.AddCommand("createmultisigaddress", "Generates a new multisig address using the provided public keys", "Generates a new multisig address using the provided public keys", &CreateMultisigAddress{opts: &opts})
+	parser.AddCommand("createscriptaddress", "Generates an address for a locking script template", "Generates an address for one of the built in locking script templates: hashlock (HTLC), vesting, twofactor (2FA with timeout recovery), and oracle. The template parameters are passed as name=value pairs. The locking script, script commitment, and lurk script are returned along with the address for the network selected with --net. Use --list to see the templates and their parameters.", &CreateScriptAddress{opts: &opts})
 	parser.AddCommand("createmultisignature", "Generates and returns a signature for use when proving a multisig transaction", "Generates and returns a signature for use when proving a multisig transaction", &CreateMultiSignature{opts: &opts})
 	parser.AddCommand("provemultisig", "Creates a proof for a transaction with a multisig input", "Creates a proof for a transaction with a multisig input", &ProveMultisig{opts: &opts})
+	parser.AddCommand("createpst", "Create a partially signed multisig transaction", "Wrap a raw transaction spending from a multisig address in a partially signed transaction (PST). The PST carries the transaction and the signatures collected so far and is passed between the co-signers, who each add their signature with signpst, until it can be proven with finalizepst.", &CreatePST{opts: &opts})
+	parser.AddCommand("signpst", "Sign a partially signed transaction", "Check that a partially signed transaction only spends from a single multisig address and add your signature to it. The updated PST is printed so it can be passed to the next signer.", &SignPST{opts: &opts})
+	parser.AddCommand("combinepst", "Combine partially signed transactions", "Merge the signatures from several copies of the same partially signed transaction, such as when co-signers sign in parallel", &CombinePST{opts: &opts})
+	parser.AddCommand("finalizepst", "Prove a partially signed transaction", "Prove a partially signed transaction once it holds enough signatures and either print it or submit it to the network", &FinalizePST{opts: &opts})
+	parser.AddCommand("createescrow", "Create a 2-of-3 buyer, seller, and arbiter escrow", "Create a 2-of-3 escrow address from the buyer, seller, and arbiter spend public keys and write an escrow template file to share with the other parties. The buyer funds the escrow by sending coins to its address. Funds are released when any two parties sign: the buyer and seller cooperatively, or the arbiter together with one of them to resolve a dispute.", &CreateEscrow{opts: &opts})
+	parser.AddCommand("importescrow", "Import an escrow into the wallet", "Import the escrow address from an escrow template as a watch address so the wallet can detect the funding transaction", &ImportEscrow{opts: &opts})
+	parser.AddCommand("getescrowstatus", "Show whether an escrow has been funded", "Show the escrow's balance, unspent notes, and whether it has been funded with the expected amount. The escrow must first be imported with importescrow.", &GetEscrowStatus{opts: &opts})
+	parser.AddCommand("createescrowrelease", "Create a transaction releasing an escrow", "Create a transaction paying the escrow balance, less the fee, to the seller or refunding it to the buyer. Any party may create it. It must then be signed by two parties with signescrow.", &CreateEscrowRelease{opts: &opts})
+	parser.AddCommand("signescrow", "Sign an escrow release transaction", "Check that a release transaction only spends from the escrow, show where the funds are going, and sign it with your escrow key. In a dispute the arbiter signs the release or refund matching their ruling.", &SignEscrow{opts: &opts})
+	parser.AddCommand("finalizeescrow", "Prove and broadcast an escrow release transaction", "Combine two signatures from signescrow, prove the release transaction, and submit it to the network", &FinalizeEscrow{opts: &opts})
 	parser.AddCommand("walletlock", "Encrypts the wallet's private keys", "Encrypts the wallet's private keys", &WalletLock{opts: &opts})
 	parser.AddCommand("walletunlock", "Decrypts the wallet seed and holds it in memory for the specified period of time", "Decrypts the wallet seed and holds it in memory for the specified period of time", &WalletUnlock{opts: &opts})
+	parser.AddCommand("walletviewunlock", "Unlocks the wallet with the view passphrase", "Unlocks the wallet with the view passphrase set by setwalletviewpassphrase for the specified period of time. This permits balance and history queries and new addresses, but spending still requires walletunlock.", &WalletViewUnlock{opts: &opts})
+	parser.AddCommand("createwalletunlockfile", "Create a file the node can unlock the wallet with on startup", "Encrypt the wallet passphrase with the key printed by the key command and write it to a file. Set walletunlockfile and walletunlockkeycmd in the node's config to unlock the wallet on startup without storing the passphrase in plaintext. The key should be kept in the OS keyring or a KMS rather than on disk next to the file.", &CreateWalletUnlockFile{opts: &opts})
+	parser.AddCommand("setwalletviewpassphrase", "Sets a view only passphrase for the wallet", "Sets a second, lighter, passphrase which only permits balance and history queries and address generation, so that dashboards can run against a mostly locked wallet. Once it is set those methods are only served while the wallet is unlocked with either passphrase, and every other wallet method requires the full passphrase. An empty view passphrase permits them without unlocking. Addresses are generated ahead of time for getnewaddress to hand out and the pool is refilled whenever the wallet is unlocked with walletunlock. The wallet must be unlocked.", &SetWalletViewPassphrase{opts: &opts})
 	parser.AddCommand("setwalletpassphrase", "Encrypts the wallet for the first time", "Encrypts the wallet for the first time", &SetWalletPassphrase{opts: &opts})
 	parser.AddCommand("changewalletpassphrase", "Changes the passphrase used to encrypt the wallet private keys", "Changes the passphrase used to encrypt the wallet private keys", &ChangeWalletPassphrase{opts: &opts})
-	parser.AddCommand("deleteprivatekeys", "Deletes the wallet's private keys and seed from disk", "Deletes the wallet's private keys and seed from disk essentially turning the wallet into a watch-only wallet. It will still record incoming transactions but cannot spend them.", &DeletePrivateKeys{opts: &opts})
-	parser.AddCommand("createrawtransaction", "Creates a new, unsigned (unproven) transaction using the given parameters", "Creates a new, unsigned (unproven) transaction using the given parameters", &CreateRawTransaction{opts: &opts})
+	parser.AddCommand("deleteprivatekeys", "Deletes the wallet's private keys and seed from disk", "Deletes the wallet's private keys and seed from disk essentially turning the wallet into a watch-only wallet. It will still record incoming transactions but cannot spend them. The node first writes the keys to a backup file encrypted with the backup passphrase.", &DeletePrivateKeys{opts: &opts})
+	parser.AddCommand("decryptkeybackup", "Decrypt a private key backup", "Decrypt a private key backup written by the node before deleteprivatekeys deleted the keys and print the seed and keys. This runs locally and does not connect to the node.", &DecryptKeyBackup{opts: &opts})
+	parser.AddCommand("walletrepair", "Check the wallet's utxos against the chain and fix any problems", "Verifies each wallet utxo against the chain state. Utxos whose commitment is not in the accumulator or whose nullifier has already been spent are removed, and missing metadata is derived again from the wallet's addresses. The actions taken are printed. This is useful after a crash during a rescan.", &WalletRepair{opts: &opts})
+	parser.AddCommand("createrawtransaction", "Creates a new, unsigned (unproven) transaction using the given parameters", "Creates a new, unsigned (unproven) transaction using the given parameters. Alternatively pass a JSON recipe listing the outputs by address and amount in ILX, with optional state fields, input commitments, fee, change address, and locktime. The wallet selects the inputs if none are given and resolves the salts, inclusion proofs, and change.", &CreateRawTransaction{opts: &opts})
 	parser.AddCommand("createrawstaketransaction", "Creates a new, unsigned (unproven) stake transaction using the given parameters", "Creates a new, unsigned (unproven) stake transaction using the given parameters", &CreateRawStakeTransaction{opts: &opts})
 	parser.AddCommand("decodetransaction", "Decode a serialized transaction", "Decodes a serialized transaction in hex format and prints out the JSON", &DecodeTransaction{opts: &opts})
 	parser.AddCommand("decoderawtransaction", "Decode a raw transaction", "Decodes a raw transaction in hex format and prints out the JSON", &DecodeRawTransaction{opts: &opts})
 	parser.AddCommand("proverawtransaction", "Creates the zk-proof for the transaction", "Creates the zk-proof for the transaction. Assuming there are no errors, this transaction should be ready for broadcast.", &ProveRawTransaction{opts: &opts})
 	parser.AddCommand("stake", "Stakes the selected wallet UTXOs and turns the node into a validator", "Stakes the selected wallet UTXOs and turns the node into a validator", &Stake{opts: &opts})
+	parser.AddCommand("delegatestake", "Delegate staking rights to a validator", "Send coins to a cold staking address. The delegate key, held by an online validator, can stake the coins but cannot spend them. This wallet's key can spend the coins at any time. Give the validator the address, locking script, and view key so they can import the address and stake the coins.", &DelegateStake{opts: &opts})
+	parser.AddCommand("revokedelegation", "Revoke a stake delegation", "Spend the coins at a cold staking address back to the wallet, removing the stake from the delegate's validator", &RevokeDelegation{opts: &opts})
 	parser.AddCommand("setautostakerewards", "Automatically stakes validator rewards", "Automatically stakes validator rewards", &SetAutoStakeRewards{opts: &opts})
 	parser.AddCommand("spend", "Sends coins from the wallet", "Sends coins from the wallet according to the provided parameters", &Spend{opts: &opts})
+	parser.AddCommand("startsweepjob", "Sweep a large wallet in the background", "Sweep the wallet's coins to an address in the background. The inputs are split across as many transactions as needed to keep each under the maximum number of inputs, since proving time grows with the number of inputs. The transactions are proven and broadcast one after another and the job resumes if the node restarts. Check its progress with getsweepjobs.", &StartSweepJob{opts: &opts})
+	parser.AddCommand("getsweepjobs", "List the wallet's sweep jobs", "List the wallet's sweep jobs along with their status and the transactions broadcast so far", &GetSweepJobs{opts: &opts})
+	parser.AddCommand("cancelsweepjob", "Cancel a sweep job", "Stop a running sweep job once the transaction it is proving, if any, is finished. Transactions already broadcast are not affected.", &CancelSweepJob{opts: &opts})
+	parser.AddCommand("resumesweepjob", "Resume a sweep job", "Restart a sweep job which failed or was canceled. Inputs which have already been swept are skipped.", &ResumeSweepJob{opts: &opts})
+	parser.AddCommand("initiateswap", "Initiate a cross chain atomic swap", "Start an atomic swap by locking coins in a hash time locked contract with a new secret. The counterparty can claim the coins by publishing the secret, or the coins can be refunded after the refund time. Give the counterparty the secret hash so they can lock their coins on the other chain, and the contract address, locking script, and view key so they can redeem this contract.", &InitiateSwap{opts: &opts})
+	parser.AddCommand("participateswap", "Participate in a cross chain atomic swap", "Lock coins in a hash time locked contract using the secret hash from a swap the counterparty initiated on another chain. The wallet watches for the counterparty to redeem the contract and saves the secret they publish so the other side of the swap can be redeemed. Use getswaps to see the secret.", &ParticipateSwap{opts: &opts})
+	parser.AddCommand("redeemswap", "Redeem an atomic swap contract", "Claim the coins in a contract funded by the counterparty using the secret. The secret is published in the transaction. The contract's claim key must be the spend key of one of the wallet's addresses, which can be found with signmessage. If the contract hasn't been imported it is imported and the wallet rescans for it. Run the command again once the rescan finishes.", &RedeemSwap{opts: &opts})
+	parser.AddCommand("refundswap", "Refund an atomic swap contract", "Return the coins in a contract funded by this wallet after the refund time has passed", &RefundSwap{opts: &opts})
+	parser.AddCommand("getswaps", "List the wallet's atomic swaps", "List the wallet's atomic swaps along with their status and secrets", &GetSwaps{opts: &opts})
+	parser.AddCommand("createpaperwallet", "Generate a paper wallet offline", "Generate a new keypair and address without connecting to a node. QR codes for the address and private key are written to the output directory. The private key can optionally be encrypted with a passphrase.", &CreatePaperWallet{opts: &opts})
+	parser.AddCommand("decryptpaperwallet", "Decrypt a paper wallet private key", "Decrypt a passphrase encrypted private key created by createpaperwallet", &DecryptPaperWallet{opts: &opts})
+	parser.AddCommand("savespendtemplate", "Save a reusable spend template", "Save a named spend template with a list of recipients, a fee, and optional inputs. Execute it with spend --template=<name>.", &SaveSpendTemplate{opts: &opts})
+	parser.AddCommand("getspendtemplates", "List the saved spend templates", "List the saved spend templates", &GetSpendTemplates{opts: &opts})
+	parser.AddCommand("deletespendtemplate", "Delete a saved spend template", "Delete a saved spend template", &DeleteSpendTemplate{opts: &opts})
+	parser.AddCommand("addcontact", "Add an address to the address book", "Save an address in the wallet's address book under a name. Send to it with spend --to-contact=<name>.", &AddContact{opts: &opts})
+	parser.AddCommand("listcontacts", "List the address book", "List the contacts in the wallet's address book", &ListContacts{opts: &opts})
+	parser.AddCommand("removecontact", "Remove an address book contact", "Remove a contact from the wallet's address book", &RemoveContact{opts: &opts})
 	parser.AddCommand("timelockcoins", "Lock coins in a timelocked address", "Send coins into a timelocked address, from which the wallet may spend from after the timelock expires. This is primarily used for adding weight to stake.", &TimelockCoins{opts: &opts})
 
-	if _, err := parser.Parse(); err != nil {
+	// Completion
+	parser.AddCommand("completion", "Generate a shell completion script", "Prints a completion script for the given shell: [bash, zsh, fish]. Addresses and commitments are completed from the wallet if the node is reachable. To enable it for the current bash session run: source <(ilxcli completion bash)", &Completion{opts: &opts})
+
+	args, err := cfg.apply(parser, os.Args[1:])
+	if err != nil {
+		log.Fatal(err)
+	}
+	if os.Getenv(completionEnv) != "" {
+		loadCompletionOptions(parser, args, &opts)
+	}
+	parser.CommandHandler = func(command flags.Commander, args []string) error {
+		if err := setLocale(opts.Lang, opts.LangFile); err != nil {
+			return err
+		}
+		if command == nil {
+			return nil
+		}
+		active := parser.Active
+		for active.Active != nil {
+			active = active.Active
+		}
+		setCallTimeout(&opts, active.Name)
+		defer releaseCallContexts()
+		return command.Execute(args)
+	}
+	if _, err := parser.ParseArgs(args); err != nil {
 		if e, ok := err.(*flags.Error); ok && e.Type == flags.ErrHelp {
 			fmt.Println(err)
 			os.Exit(0)
 		}
-		log.Fatal(err)
+		if hint := errorHint(err); hint != "" {
+			fmt.Fprintln(os.Stderr, hint)
+		}
+		log.Fatal(localizeError(err))
 	}
 
 }
 
+// makeContext returns the context for a call to the node. It carries the
+// auth token, if any, and the deadline for the command being run.
 func makeContext(authToken string) context.Context {
 	ctx := context.Background()
 	if authToken != "" {
 		md := metadata.Pairs(authenticationTokenKey, authToken)
 		ctx = metadata.NewOutgoingContext(context.Background(), md)
 	}
+	if callTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, callTimeout)
+		callCancels = append(callCancels, cancel)
+	}
 	return ctx
 }
 
+// networkParams returns the network params for the --net option.
+func networkParams(opts *options) (*params.NetworkParams, error) {
+	switch strings.ToLower(opts.Net) {
+	case "mainnet", "":
+		return &params.MainnetParams, nil
+	case "testnet":
+		return &params.Testnet1Params, nil
+	case "regtest":
+		return &params.RegestParams, nil
+	case "alphanet":
+		return &params.AlphanetParams, nil
+	default:
+		return nil, errors.New("invalid net")
+	}
+}
+
+// verifyNetwork returns an error if the --net option is set and the node
+// is running on a different network. This is used before spending coins
+// so that a misconfigured client doesn't send transactions to the wrong
+// network.
+func verifyNetwork(opts *options) error {
+	if opts.Net == "" {
+		return nil
+	}
+	chainParams, err := networkParams(opts)
+	if err != nil {
+		return err
+	}
+	client, err := makeBlockchainClient(opts)
+	if err != nil {
+		return err
+	}
+	resp, err := client.GetNetworkParams(makeContext(opts.AuthToken), &pb.GetNetworkParamsRequest{})
+	if err != nil {
+		return err
+	}
+	if resp.Name != chainParams.Name {
+		return errors.New(trf("node is running on %s but the client is configured for %s", resp.Name, chainParams.Name))
+	}
+	return nil
+}
+
+// callOptions returns the default call options for the connection
+// to the server.
+func callOptions(opts *options) grpc.DialOption {
+	callOpts := []grpc.CallOption{grpc.MaxCallRecvMsgSize(opts.MaxRecvSize)}
+	if opts.Compress {
+		callOpts = append(callOpts, grpc.UseCompressor(gzip.Name))
+	}
+	return grpc.WithDefaultCallOptions(callOpts...)
+}
+
 func makeBlockchainClient(opts *options) (pb.BlockchainServiceClient, error) {
 	certFile := repo.CleanAndExpandPath(opts.RPCCert)
 
@@ -181,7 +361,7 @@ func makeBlockchainClient(opts *options) (pb.BlockchainServiceClient, error) {
 	if err != nil {
 		return nil, err
 	}
-	conn, err := grpc.Dial(netAddr.String(), grpc.WithTransportCredentials(creds), grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(1000000)))
+	conn, err := grpc.Dial(netAddr.String(), grpc.WithTransportCredentials(creds), callOptions(opts), keepaliveParams)
 	if err != nil {
 		return nil, err
 	}
@@ -212,7 +392,7 @@ func makeNodeClient(opts *options) (pb.NodeServiceClient, error) {
 	if err != nil {
 		return nil, err
 	}
-	conn, err := grpc.Dial(netAddr.String(), grpc.WithTransportCredentials(creds), grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(1000000)))
+	conn, err := grpc.Dial(netAddr.String(), grpc.WithTransportCredentials(creds), callOptions(opts), keepaliveParams)
 	if err != nil {
 		return nil, err
 	}
@@ -243,7 +423,7 @@ func makeWalletClient(opts *options) (pb.WalletServiceClient, error) {
 	if err != nil {
 		return nil, err
 	}
-	conn, err := grpc.Dial(netAddr.String(), grpc.WithTransportCredentials(creds), grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(1000000)))
+	conn, err := grpc.Dial(netAddr.String(), grpc.WithTransportCredentials(creds), callOptions(opts), keepaliveParams)
 	if err != nil {
 		return nil, err
 	}
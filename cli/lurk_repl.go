@@ -0,0 +1,378 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/project-illium/ilxd/repo"
+	"github.com/project-illium/ilxd/rpc/pb"
+	"github.com/project-illium/ilxd/types/transactions"
+	"github.com/project-illium/ilxd/zk"
+	"github.com/project-illium/ilxd/zk/lurk/macros"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+)
+
+const lurkReplHelp = `Enter a lurk expression to evaluate it. Expressions may span more than
+one line and may use the macros from the standard library. The expression
+is evaluated inside (lambda (priv pub) ...) so it can refer to the private
+and public params.
+
+Commands:
+  :let <name> <expr>   Bind a name to an expression
+  :load <name> <file>  Bind a name to the script in a file
+  :unset <name>        Remove a binding
+  :env                 Show the bindings and params
+  :priv <expr>         Set the private params
+  :pub <expr>          Set the public params
+  :commit <expr>       Print the lurk commitment (hash) of an expression
+  :txoroot             Bind txo-root to the node's current txo root
+  :tx <txid>           Bind sighash, txo-root, nullifiers, and commitments
+                       from a transaction on the chain
+  :help                Show this help
+  :quit                Exit the repl`
+
+type LurkRepl struct {
+	opts *options
+}
+
+func (x *LurkRepl) Execute(args []string) error {
+	mp, err := macros.NewMacroPreprocessor(macros.WithStandardLib(), macros.RemoveComments())
+	if err != nil {
+		return err
+	}
+	r := &lurkRepl{
+		opts: x.opts,
+		mp:   mp,
+		priv: "nil",
+		pub:  "nil",
+	}
+	fmt.Println("Lurk repl. Type :help for the commands.")
+	return r.run(os.Stdin, os.Stdout)
+}
+
+// lurkBinding is a name bound to an expression in the repl.
+type lurkBinding struct {
+	name string
+	expr string
+}
+
+// lurkRepl holds the state of the repl. The bindings are kept in the
+// order they were made and wrapped around each expression in a letrec
+// so later bindings may refer to earlier ones.
+type lurkRepl struct {
+	opts     *options
+	mp       *macros.MacroPreprocessor
+	bindings []lurkBinding
+	priv     string
+	pub      string
+}
+
+func (r *lurkRepl) run(in io.Reader, out io.Writer) error {
+	var (
+		scanner = bufio.NewScanner(in)
+		input   string
+	)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	fmt.Fprint(out, "lurk> ")
+	for scanner.Scan() {
+		line := scanner.Text()
+		if input == "" && strings.HasPrefix(strings.TrimSpace(line), ":") {
+			quit, err := r.command(strings.TrimSpace(line), out)
+			if err != nil {
+				fmt.Fprintln(out, "error:", err)
+			}
+			if quit {
+				return nil
+			}
+			fmt.Fprint(out, "lurk> ")
+			continue
+		}
+
+		input += line + "\n"
+		if parenDepth(input) > 0 {
+			fmt.Fprint(out, "...   ")
+			continue
+		}
+		if expr := strings.TrimSpace(input); expr != "" {
+			if err := r.eval(expr, out); err != nil {
+				fmt.Fprintln(out, "error:", err)
+			}
+		}
+		input = ""
+		fmt.Fprint(out, "lurk> ")
+	}
+	fmt.Fprintln(out)
+	return scanner.Err()
+}
+
+// command runs a repl command. It returns true if the repl should exit.
+func (r *lurkRepl) command(line string, out io.Writer) (bool, error) {
+	cmd, rest, _ := strings.Cut(line, " ")
+	rest = strings.TrimSpace(rest)
+	switch cmd {
+	case ":quit", ":q", ":exit":
+		return true, nil
+	case ":help":
+		fmt.Fprintln(out, lurkReplHelp)
+	case ":let":
+		name, expr, ok := strings.Cut(rest, " ")
+		if !ok || strings.TrimSpace(expr) == "" {
+			return false, errors.New("usage: :let <name> <expr>")
+		}
+		return false, r.bind(name, expr)
+	case ":load":
+		name, path, ok := strings.Cut(rest, " ")
+		if !ok {
+			return false, errors.New("usage: :load <name> <file>")
+		}
+		script, err := os.ReadFile(repo.CleanAndExpandPath(strings.TrimSpace(path)))
+		if err != nil {
+			return false, err
+		}
+		return false, r.bind(name, string(script))
+	case ":unset":
+		r.unbind(rest)
+	case ":env":
+		for _, b := range r.bindings {
+			fmt.Fprintf(out, "%s = %s\n", b.name, b.expr)
+		}
+		fmt.Fprintf(out, "priv = %s\n", r.priv)
+		fmt.Fprintf(out, "pub = %s\n", r.pub)
+	case ":priv", ":pub":
+		expr, err := r.mp.Preprocess(rest)
+		if err != nil {
+			return false, err
+		}
+		if expr = strings.TrimSpace(expr); expr == "" {
+			expr = "nil"
+		}
+		if cmd == ":priv" {
+			r.priv = expr
+		} else {
+			r.pub = expr
+		}
+	case ":commit":
+		expr, err := r.mp.Preprocess(rest)
+		if err != nil {
+			return false, err
+		}
+		h, err := zk.LurkCommit(strings.TrimSpace(expr))
+		if err != nil {
+			return false, err
+		}
+		fmt.Fprintf(out, "0x%x\n", h)
+	case ":txoroot":
+		return false, r.bindTxoRoot(out)
+	case ":tx":
+		return false, r.bindTransaction(rest, out)
+	default:
+		return false, fmt.Errorf("unknown command %s. Type :help for the commands", cmd)
+	}
+	return false, nil
+}
+
+// bind preprocesses the expression and binds it to the name, replacing
+// any existing binding with the same name.
+func (r *lurkRepl) bind(name, expr string) error {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.ContainsAny(name, "() \t\"") {
+		return fmt.Errorf("invalid name %q", name)
+	}
+	expr, err := r.mp.Preprocess(expr)
+	if err != nil {
+		return err
+	}
+	expr = strings.TrimSpace(expr)
+	for i, b := range r.bindings {
+		if b.name == name {
+			r.bindings[i].expr = expr
+			return nil
+		}
+	}
+	r.bindings = append(r.bindings, lurkBinding{name: name, expr: expr})
+	return nil
+}
+
+func (r *lurkRepl) unbind(name string) {
+	for i, b := range r.bindings {
+		if b.name == name {
+			r.bindings = append(r.bindings[:i], r.bindings[i+1:]...)
+			return
+		}
+	}
+}
+
+// eval evaluates the expression with the bindings and params and prints
+// the result.
+func (r *lurkRepl) eval(expr string, out io.Writer) error {
+	expr, err := r.mp.Preprocess(expr)
+	if err != nil {
+		return err
+	}
+	body := strings.TrimSpace(expr)
+	if len(r.bindings) > 0 {
+		var sb strings.Builder
+		for _, b := range r.bindings {
+			sb.WriteString(fmt.Sprintf("(%s %s)", b.name, b.expr))
+		}
+		body = fmt.Sprintf("(letrec (%s) %s)", sb.String(), body)
+	}
+	program := fmt.Sprintf("(lambda (priv pub) %s)", body)
+
+	tag, val, iterations, err := zk.Eval(program, zk.Expr(r.priv), zk.Expr(r.pub))
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "%s  (%d iterations)\n", formatLurkOutput(tag, val), iterations)
+	return nil
+}
+
+// bindTxoRoot binds txo-root to the txo root of the node's best block.
+func (r *lurkRepl) bindTxoRoot(out io.Writer) error {
+	client, err := makeBlockchainClient(r.opts)
+	if err != nil {
+		return err
+	}
+	resp, err := client.GetBlockchainInfo(makeContext(r.opts.AuthToken), &pb.GetBlockchainInfoRequest{})
+	if err != nil {
+		return err
+	}
+	if len(resp.TxoRoot) == 0 {
+		return errors.New("the node did not return a txo root")
+	}
+	expr := fmt.Sprintf("0x%x", resp.TxoRoot)
+	fmt.Fprintf(out, "txo-root = %s (height %d)\n", expr, resp.BestHeight)
+	return r.bind("txo-root", expr)
+}
+
+// bindTransaction fetches the transaction and binds its sighash, and its
+// txo root, nullifiers, and output commitments if it has them.
+func (r *lurkRepl) bindTransaction(txidStr string, out io.Writer) error {
+	txid, err := hex.DecodeString(txidStr)
+	if err != nil {
+		return err
+	}
+	client, err := makeBlockchainClient(r.opts)
+	if err != nil {
+		return err
+	}
+	resp, err := client.GetTransaction(makeContext(r.opts.AuthToken), &pb.GetTransactionRequest{
+		Transaction_ID: txid,
+	})
+	if err != nil {
+		return err
+	}
+
+	var (
+		sigHash []byte
+		txoRoot []byte
+	)
+	switch tx := resp.Tx.GetTx().(type) {
+	case *transactions.Transaction_StandardTransaction:
+		sigHash, err = tx.StandardTransaction.SigHash()
+		txoRoot = tx.StandardTransaction.TxoRoot
+	case *transactions.Transaction_MintTransaction:
+		sigHash, err = tx.MintTransaction.SigHash()
+		txoRoot = tx.MintTransaction.TxoRoot
+	case *transactions.Transaction_StakeTransaction:
+		sigHash, err = tx.StakeTransaction.SigHash()
+		txoRoot = tx.StakeTransaction.TxoRoot
+	case *transactions.Transaction_CoinbaseTransaction:
+		sigHash, err = tx.CoinbaseTransaction.SigHash()
+	case *transactions.Transaction_TreasuryTransaction:
+		sigHash, err = tx.TreasuryTransaction.SigHash()
+	default:
+		return errors.New("unknown transaction type")
+	}
+	if err != nil {
+		return err
+	}
+
+	nullifiers := make([]any, 0, len(resp.Tx.Nullifiers()))
+	for _, n := range resp.Tx.Nullifiers() {
+		nullifiers = append(nullifiers, n.Bytes())
+	}
+	commitments := make([]any, 0, len(resp.Tx.Outputs()))
+	for _, o := range resp.Tx.Outputs() {
+		commitments = append(commitments, o.Commitment)
+	}
+
+	bindings := []lurkBinding{
+		{"sighash", fmt.Sprintf("0x%x", sigHash)},
+		{"nullifiers", string(zk.List(nullifiers...))},
+		{"commitments", string(zk.List(commitments...))},
+	}
+	if len(txoRoot) > 0 {
+		bindings = append(bindings, lurkBinding{"txo-root", fmt.Sprintf("0x%x", txoRoot)})
+	}
+	for _, b := range bindings {
+		if err := r.bind(b.name, b.expr); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "%s = %s\n", b.name, b.expr)
+	}
+	return nil
+}
+
+// formatLurkOutput formats the result of an evaluation. Lurk only returns
+// the tag and the value's field element, which for compound values is a
+// hash, so numbers and the t and nil symbols are shown as values and
+// everything else as its tag and field element.
+func formatLurkOutput(tag zk.Tag, val []byte) string {
+	switch {
+	case tag == zk.TagNil:
+		return "nil"
+	case tag == zk.TagSym && bytes.Equal(val, zk.OutputTrue):
+		return "t"
+	case tag == zk.TagNum:
+		return fmt.Sprintf("%s (0x%x)", new(big.Int).SetBytes(val).String(), val)
+	case tag == zk.TagU64:
+		return fmt.Sprintf("%su64", new(big.Int).SetBytes(val).String())
+	}
+	return fmt.Sprintf("<%s 0x%x>", tag, val)
+}
+
+// parenDepth returns the number of unclosed parentheses in the input.
+// Parentheses in strings and comments are ignored.
+func parenDepth(input string) int {
+	var (
+		depth    int
+		inString bool
+		escaped  bool
+		comment  bool
+	)
+	for _, c := range input {
+		switch {
+		case comment:
+			if c == '\n' {
+				comment = false
+			}
+		case inString:
+			if escaped {
+				escaped = false
+			} else if c == '\\' {
+				escaped = true
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == ';':
+			comment = true
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		}
+	}
+	return depth
+}
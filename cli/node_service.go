@@ -5,6 +5,7 @@
 package main
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/hex"
 	"encoding/json"
@@ -12,11 +13,15 @@ import (
 	"fmt"
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/project-illium/ilxd/consensus"
+	"github.com/project-illium/ilxd/repo"
 	"github.com/project-illium/ilxd/rpc/pb"
 	"github.com/project-illium/ilxd/types"
 	"golang.org/x/crypto/openpgp/armor" // nolint:staticcheck
 	"google.golang.org/protobuf/encoding/protojson"
+	"os"
 	"strings"
+	"time"
 )
 
 type GetHostInfo struct {
@@ -44,6 +49,84 @@ func (x *GetHostInfo) Execute(args []string) error {
 	return nil
 }
 
+type GetValidatorInfo struct {
+	opts *options
+}
+
+func (x *GetValidatorInfo) Execute(args []string) error {
+	client, err := makeNodeClient(x.opts)
+	if err != nil {
+		return err
+	}
+	resp, err := client.GetValidatorInfo(makeContext(x.opts.AuthToken), &pb.GetValidatorInfoRequest{})
+	if err != nil {
+		return err
+	}
+
+	pid, err := peer.IDFromBytes(resp.Validator_ID)
+	if err != nil {
+		return err
+	}
+
+	type stake struct {
+		Nullifier          types.HexEncodable `json:"nullifier"`
+		Amount             types.Amount       `json:"amount"`
+		TimelockedUntil    *time.Time         `json:"timelockedUntil,omitempty"`
+		Expiration         time.Time          `json:"expiration"`
+		RestakeEligibility time.Time          `json:"restakeEligibility"`
+	}
+
+	stk := make([]*stake, 0, len(resp.Stake))
+	for _, s := range resp.Stake {
+		a := &stake{
+			Nullifier:          s.Nullifier,
+			Amount:             types.Amount(s.Amount),
+			Expiration:         time.Unix(s.Expiration, 0),
+			RestakeEligibility: time.Unix(s.RestakeEligibility, 0),
+		}
+		if s.TimelockedUntil > 0 {
+			ts := time.Unix(s.TimelockedUntil, 0)
+			a.TimelockedUntil = &ts
+		}
+		stk = append(stk, a)
+	}
+
+	v := struct {
+		ValidatorID         string       `json:"validatorID"`
+		IsValidator         bool         `json:"isValidator"`
+		TotalStake          types.Amount `json:"totalStake"`
+		StakeWeight         types.Amount `json:"stakeWeight"`
+		UnclaimedCoins      types.Amount `json:"unclaimedCoins"`
+		ExpectedEpochReward types.Amount `json:"expectedEpochReward"`
+		NextEpoch           time.Time    `json:"nextEpoch"`
+		EpochBlocks         uint32       `json:"epochBlocks"`
+		ValidatorSince      *time.Time   `json:"validatorSince,omitempty"`
+		Stake               []*stake     `json:"stake"`
+		Autostake           bool         `json:"autostake"`
+	}{
+		ValidatorID:         pid.String(),
+		IsValidator:         resp.IsValidator,
+		TotalStake:          types.Amount(resp.TotalStake),
+		StakeWeight:         types.Amount(resp.StakeWeight),
+		UnclaimedCoins:      types.Amount(resp.UnclaimedCoins),
+		ExpectedEpochReward: types.Amount(resp.ExpectedEpochReward),
+		NextEpoch:           time.Unix(resp.NextEpoch, 0),
+		EpochBlocks:         resp.EpochBlocks,
+		Stake:               stk,
+		Autostake:           resp.Autostake,
+	}
+	if resp.IsValidator {
+		ts := time.Unix(resp.ValidatorSince, 0)
+		v.ValidatorSince = &ts
+	}
+	out, err := json.MarshalIndent(&v, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
 type GetNetworkKey struct {
 	opts *options
 }
@@ -197,6 +280,258 @@ func (x *UnblockPeer) Execute(args []string) error {
 	return nil
 }
 
+type GetAccessLists struct {
+	opts *options
+}
+
+func (x *GetAccessLists) Execute(args []string) error {
+	client, err := makeNodeClient(x.opts)
+	if err != nil {
+		return err
+	}
+	resp, err := client.GetAccessLists(makeContext(x.opts.AuthToken), &pb.GetAccessListsRequest{})
+	if err != nil {
+		return err
+	}
+	lists := struct {
+		Allow []string `json:"allow"`
+		Deny  []string `json:"deny"`
+	}{
+		Allow: resp.Allow,
+		Deny:  resp.Deny,
+	}
+	out, err := json.MarshalIndent(&lists, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+type UpdateAccessList struct {
+	Deny     bool     `short:"d" long:"deny" description:"Update the deny list instead of the allow list"`
+	ToAdd    []string `short:"a" long:"toadd" description:"A peer ID, IP address, or IP range in CIDR notation to add to the list"`
+	ToRemove []string `short:"r" long:"toremove" description:"A peer ID, IP address, or IP range in CIDR notation to remove from the list"`
+	opts     *options
+}
+
+func (x *UpdateAccessList) Execute(args []string) error {
+	client, err := makeNodeClient(x.opts)
+	if err != nil {
+		return err
+	}
+	list := pb.UpdateAccessListRequest_ALLOW
+	if x.Deny {
+		list = pb.UpdateAccessListRequest_DENY
+	}
+	_, err = client.UpdateAccessList(makeContext(x.opts.AuthToken), &pb.UpdateAccessListRequest{
+		List:   list,
+		Add:    x.ToAdd,
+		Remove: x.ToRemove,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("success")
+	return nil
+}
+
+type GetConsensusPeerScores struct {
+	opts *options
+}
+
+func (x *GetConsensusPeerScores) Execute(args []string) error {
+	client, err := makeNodeClient(x.opts)
+	if err != nil {
+		return err
+	}
+	resp, err := client.GetConsensusPeerScores(makeContext(x.opts.AuthToken), &pb.GetConsensusPeerScoresRequest{})
+	if err != nil {
+		return err
+	}
+
+	type score struct {
+		PeerID      string     `json:"peerID"`
+		Score       float64    `json:"score"`
+		Responses   uint64     `json:"responses"`
+		Timeouts    uint64     `json:"timeouts"`
+		FlipFlops   uint64     `json:"flipFlops"`
+		AvgLatency  int64      `json:"avgLatencyMs"`
+		BannedUntil *time.Time `json:"bannedUntil,omitempty"`
+	}
+	scores := make([]score, 0, len(resp.Scores))
+	for _, s := range resp.Scores {
+		sc := score{
+			PeerID:     s.Peer_ID,
+			Score:      s.Score,
+			Responses:  s.Responses,
+			Timeouts:   s.Timeouts,
+			FlipFlops:  s.FlipFlops,
+			AvgLatency: s.AvgLatency,
+		}
+		if s.BannedUntil != 0 {
+			bannedUntil := time.Unix(s.BannedUntil, 0)
+			sc.BannedUntil = &bannedUntil
+		}
+		scores = append(scores, sc)
+	}
+	out, err := json.MarshalIndent(scores, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+type GetConsensusState struct {
+	opts *options
+}
+
+func (x *GetConsensusState) Execute(args []string) error {
+	client, err := makeNodeClient(x.opts)
+	if err != nil {
+		return err
+	}
+	resp, err := client.GetConsensusState(makeContext(x.opts.AuthToken), &pb.GetConsensusStateRequest{})
+	if err != nil {
+		return err
+	}
+
+	type block struct {
+		BlockID    types.HexEncodable `json:"blockID"`
+		Status     string             `json:"status"`
+		Acceptable bool               `json:"acceptable"`
+		Confidence uint32             `json:"confidence"`
+	}
+	type height struct {
+		Height           uint32  `json:"height"`
+		Blocks           []block `json:"blocks"`
+		ActiveBit        uint32  `json:"activeBit"`
+		BitConfidence    uint32  `json:"bitConfidence"`
+		InflightRequests uint32  `json:"inflightRequests"`
+		TotalVotes       uint64  `json:"totalVotes"`
+		Age              string  `json:"age"`
+	}
+	type bucket struct {
+		LessOrEqual string `json:"le"`
+		Count       uint64 `json:"count"`
+	}
+	type state struct {
+		FinalizationScore   uint32   `json:"finalizationScore"`
+		InflightQueries     uint32   `json:"inflightQueries"`
+		QueuedResponses     uint32   `json:"queuedResponses"`
+		QueuedHousekeeping  uint32   `json:"queuedHousekeeping"`
+		Heights             []height `json:"heights"`
+		Finalized           uint64   `json:"finalized"`
+		AvgFinalizationTime string   `json:"avgFinalizationTime"`
+		FinalizationLatency []bucket `json:"finalizationLatency"`
+	}
+	s := state{
+		FinalizationScore:  resp.FinalizationScore,
+		InflightQueries:    resp.InflightQueries,
+		QueuedResponses:    resp.QueuedResponses,
+		QueuedHousekeeping: resp.QueuedHousekeeping,
+		Heights:            make([]height, 0, len(resp.Heights)),
+	}
+	for _, h := range resp.Heights {
+		ht := height{
+			Height:           h.Height,
+			Blocks:           make([]block, 0, len(h.Blocks)),
+			ActiveBit:        h.ActiveBit,
+			BitConfidence:    h.BitConfidence,
+			InflightRequests: h.InflightRequests,
+			TotalVotes:       h.TotalVotes,
+			Age:              (time.Duration(h.Age) * time.Millisecond).String(),
+		}
+		for _, b := range h.Blocks {
+			ht.Blocks = append(ht.Blocks, block{
+				BlockID:    b.Block_ID,
+				Status:     b.Status,
+				Acceptable: b.Acceptable,
+				Confidence: b.Confidence,
+			})
+		}
+		s.Heights = append(s.Heights, ht)
+	}
+	if latency := resp.FinalizationLatency; latency != nil {
+		s.Finalized = latency.Count
+		if latency.Count > 0 {
+			s.AvgFinalizationTime = (time.Duration(latency.Sum/int64(latency.Count)) * time.Millisecond).String()
+		}
+		var counted uint64
+		for i, bound := range latency.Buckets {
+			if i >= len(latency.Counts) {
+				break
+			}
+			s.FinalizationLatency = append(s.FinalizationLatency, bucket{
+				LessOrEqual: (time.Duration(bound) * time.Millisecond).String(),
+				Count:       latency.Counts[i],
+			})
+			counted += latency.Counts[i]
+		}
+		s.FinalizationLatency = append(s.FinalizationLatency, bucket{
+			LessOrEqual: "+Inf",
+			Count:       latency.Count - counted,
+		})
+	}
+	out, err := json.MarshalIndent(s, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+type GetTasks struct {
+	opts *options
+}
+
+func (x *GetTasks) Execute(args []string) error {
+	client, err := makeNodeClient(x.opts)
+	if err != nil {
+		return err
+	}
+	resp, err := client.GetTasks(makeContext(x.opts.AuthToken), &pb.GetTasksRequest{})
+	if err != nil {
+		return err
+	}
+	type task struct {
+		Kind    string    `json:"kind"`
+		Name    string    `json:"name"`
+		State   string    `json:"state"`
+		Started time.Time `json:"started"`
+		Running string    `json:"running"`
+		Updated time.Time `json:"updated,omitempty"`
+	}
+	s := struct {
+		Goroutines uint32 `json:"goroutines"`
+		Tasks      []task `json:"tasks"`
+	}{
+		Goroutines: resp.Goroutines,
+		Tasks:      make([]task, 0, len(resp.Tasks)),
+	}
+	for _, t := range resp.Tasks {
+		tk := task{
+			Kind:    t.Kind,
+			Name:    t.Name,
+			State:   t.State,
+			Started: time.Unix(t.Started, 0),
+			Running: time.Since(time.Unix(t.Started, 0)).Round(time.Second).String(),
+		}
+		if t.Updated != 0 {
+			tk.Updated = time.Unix(t.Updated, 0)
+		}
+		s.Tasks = append(s.Tasks, tk)
+	}
+	out, err := json.MarshalIndent(s, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
 type SetLogLevel struct {
 	Level string `short:"l" long:"level" description:"The log level: [DEBUG, INFO, WARNING, ERROR, CRITICAL, ALERT, EMERGENCY]"`
 	opts  *options
@@ -423,6 +758,205 @@ func (x *UpdateTreasuryWhitelist) Execute(args []string) error {
 	return nil
 }
 
+type CreateTreasuryProposal struct {
+	Outputs      []string `short:"o" long:"output" description:"An output paid by the treasury in the form address:amount where the amount is in ILX. This option can be used more than once."`
+	ProposalHash string   `short:"p" long:"proposalhash" description:"The hash of the proposal document. Serialized as hex string."`
+	Description  string   `short:"d" long:"description" description:"A description of the proposal"`
+	opts         *options
+}
+
+func (x *CreateTreasuryProposal) Execute(args []string) error {
+	client, err := makeNodeClient(x.opts)
+	if err != nil {
+		return err
+	}
+	outputs := make([]*pb.CreateTreasuryProposalRequest_Output, 0, len(x.Outputs))
+	for _, o := range x.Outputs {
+		i := strings.LastIndex(o, ":")
+		if i < 0 {
+			return errors.New("output must be in the form address:amount")
+		}
+		amount, err := types.AmountFromILX(o[i+1:])
+		if err != nil {
+			return err
+		}
+		outputs = append(outputs, &pb.CreateTreasuryProposalRequest_Output{
+			Address: o[:i],
+			Amount:  uint64(amount),
+		})
+	}
+	proposalHash, err := hex.DecodeString(x.ProposalHash)
+	if err != nil {
+		return err
+	}
+	resp, err := client.CreateTreasuryProposal(makeContext(x.opts.AuthToken), &pb.CreateTreasuryProposalRequest{
+		Outputs:      outputs,
+		ProposalHash: proposalHash,
+		Description:  x.Description,
+	})
+	if err != nil {
+		return err
+	}
+	if err := printTreasuryProposal(resp.Proposal); err != nil {
+		return err
+	}
+	fmt.Println(hex.EncodeToString(resp.SerializedProposal))
+	return nil
+}
+
+type ImportTreasuryProposal struct {
+	Proposal string `short:"p" long:"proposal" description:"The serialized proposal. Serialized as hex string."`
+	Approve  bool   `short:"a" long:"approve" description:"Add the proposal's transaction to the treasury whitelist if it passes the checks"`
+	opts     *options
+}
+
+func (x *ImportTreasuryProposal) Execute(args []string) error {
+	client, err := makeNodeClient(x.opts)
+	if err != nil {
+		return err
+	}
+	ser, err := hex.DecodeString(x.Proposal)
+	if err != nil {
+		return err
+	}
+	resp, err := client.ImportTreasuryProposal(makeContext(x.opts.AuthToken), &pb.ImportTreasuryProposalRequest{
+		SerializedProposal: ser,
+		Approve:            x.Approve,
+	})
+	if err != nil {
+		return err
+	}
+	return printTreasuryProposal(resp.Proposal)
+}
+
+type CollectTreasuryApprovals struct {
+	ProposalID string `short:"i" long:"id" description:"The ID of the proposal"`
+	opts       *options
+}
+
+func (x *CollectTreasuryApprovals) Execute(args []string) error {
+	client, err := makeNodeClient(x.opts)
+	if err != nil {
+		return err
+	}
+	id, err := hex.DecodeString(x.ProposalID)
+	if err != nil {
+		return err
+	}
+	resp, err := client.CollectTreasuryApprovals(makeContext(x.opts.AuthToken), &pb.CollectTreasuryApprovalsRequest{
+		Proposal_ID: id,
+	})
+	if err != nil {
+		return err
+	}
+	return printTreasuryProposal(resp.Proposal)
+}
+
+type SubmitTreasuryProposal struct {
+	ProposalID string `short:"i" long:"id" description:"The ID of the proposal"`
+	opts       *options
+}
+
+func (x *SubmitTreasuryProposal) Execute(args []string) error {
+	client, err := makeNodeClient(x.opts)
+	if err != nil {
+		return err
+	}
+	id, err := hex.DecodeString(x.ProposalID)
+	if err != nil {
+		return err
+	}
+	resp, err := client.SubmitTreasuryProposal(makeContext(x.opts.AuthToken), &pb.SubmitTreasuryProposalRequest{
+		Proposal_ID: id,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Println(hex.EncodeToString(resp.Transaction_ID))
+	return nil
+}
+
+type ListTreasuryProposals struct {
+	IncludeConfirmed bool `short:"c" long:"includeconfirmed" description:"Include proposals which have been confirmed"`
+	opts             *options
+}
+
+func (x *ListTreasuryProposals) Execute(args []string) error {
+	client, err := makeNodeClient(x.opts)
+	if err != nil {
+		return err
+	}
+	resp, err := client.ListTreasuryProposals(makeContext(x.opts.AuthToken), &pb.ListTreasuryProposalsRequest{
+		IncludeConfirmed: x.IncludeConfirmed,
+	})
+	if err != nil {
+		return err
+	}
+	for _, proposal := range resp.Proposals {
+		if err := printTreasuryProposal(proposal); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func printTreasuryProposal(proposal *pb.TreasuryProposal) error {
+	type output struct {
+		Address string       `json:"address"`
+		Amount  types.Amount `json:"amount"`
+	}
+	type approvals struct {
+		Validators    []string     `json:"validators"`
+		ApprovedStake types.Amount `json:"approvedStake"`
+		TotalStake    types.Amount `json:"totalStake"`
+		Unreachable   uint32       `json:"unreachable"`
+		Collected     time.Time    `json:"collected"`
+	}
+	p := struct {
+		ProposalID   types.HexEncodable `json:"proposalID"`
+		Description  string             `json:"description"`
+		ProposalHash types.HexEncodable `json:"proposalHash"`
+		Amount       types.Amount       `json:"amount"`
+		Outputs      []output           `json:"outputs"`
+		Status       string             `json:"status"`
+		Approvals    *approvals         `json:"approvals,omitempty"`
+		Created      time.Time          `json:"created"`
+	}{
+		ProposalID:  proposal.Proposal_ID,
+		Description: proposal.Description,
+		Status:      proposal.Status.String(),
+		Created:     time.Unix(proposal.Created, 0),
+	}
+	if proposal.RawTx != nil {
+		if tx := proposal.RawTx.Tx.GetTreasuryTransaction(); tx != nil {
+			p.ProposalHash = tx.ProposalHash
+			p.Amount = types.Amount(tx.Amount)
+		}
+		for i, out := range proposal.RawTx.Outputs {
+			o := output{Amount: types.Amount(out.Amount)}
+			if i < len(proposal.OutputAddresses) {
+				o.Address = proposal.OutputAddresses[i]
+			}
+			p.Outputs = append(p.Outputs, o)
+		}
+	}
+	if proposal.Approvals != nil {
+		p.Approvals = &approvals{
+			Validators:    proposal.Approvals.Validator_IDs,
+			ApprovedStake: types.Amount(proposal.Approvals.ApprovedStake),
+			TotalStake:    types.Amount(proposal.Approvals.TotalStake),
+			Unreachable:   proposal.Approvals.Unreachable,
+			Collected:     time.Unix(proposal.Approvals.Collected, 0),
+		}
+	}
+	out, err := json.MarshalIndent(&p, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
 type ReconsiderBlock struct {
 	opts    *options
 	BlockID string `short:"i" long:"id" description:"Block ID of the block to reconsider"`
@@ -480,12 +1014,87 @@ func (x *RecomputeChainState) Execute(args []string) error {
 	return nil
 }
 
+type CaptureProfile struct {
+	Type     string `short:"t" long:"type" description:"The profile type: [cpu, heap, goroutine, block, mutex, allocs, trace]" default:"cpu"`
+	Duration uint32 `short:"d" long:"duration" description:"The number of seconds to record the profile for" default:"30"`
+	opts     *options
+}
+
+func (x *CaptureProfile) Execute(args []string) error {
+	client, err := makeNodeClient(x.opts)
+	if err != nil {
+		return err
+	}
+	profileType, ok := pb.CaptureProfileRequest_ProfileType_value[strings.ToUpper(x.Type)]
+	if !ok {
+		return errors.New("unknown profile type")
+	}
+	resp, err := client.CaptureProfile(makeContext(x.opts.AuthToken), &pb.CaptureProfileRequest{
+		ProfileType:     pb.CaptureProfileRequest_ProfileType(profileType),
+		DurationSeconds: x.Duration,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(resp.FilePath)
+	return nil
+}
+
+type SetMockTime struct {
+	Timestamp int64 `short:"t" long:"timestamp" description:"The unix timestamp to set the clock to. Zero restores the real clock."`
+	opts      *options
+}
+
+func (x *SetMockTime) Execute(args []string) error {
+	client, err := makeNodeClient(x.opts)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.SetMockTime(makeContext(x.opts.AuthToken), &pb.SetMockTimeRequest{
+		Timestamp: x.Timestamp,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("success")
+	return nil
+}
+
+type AdvanceTime struct {
+	Seconds uint64 `short:"s" long:"seconds" description:"The number of seconds to advance the clock by"`
+	opts    *options
+}
+
+func (x *AdvanceTime) Execute(args []string) error {
+	client, err := makeNodeClient(x.opts)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.AdvanceTime(makeContext(x.opts.AuthToken), &pb.AdvanceTimeRequest{
+		Seconds: x.Seconds,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(resp.Timestamp)
+	return nil
+}
+
 type SignMessage struct {
-	Message string `short:"m" long:"message" description:"A message to sign"`
+	Message string        `short:"m" long:"message" description:"A message to sign"`
+	Address walletAddress `short:"a" long:"addr" description:"Sign with the spend key of this wallet address instead of the network key. This proves ownership of the address without moving funds."`
 	opts    *options
 }
 
 func (x *SignMessage) Execute(args []string) error {
+	if x.Address != "" {
+		return x.signWithAddress()
+	}
 	client, err := makeNodeClient(x.opts)
 	if err != nil {
 		return err
@@ -534,6 +1143,44 @@ func (x *SignMessage) Execute(args []string) error {
 	return nil
 }
 
+func (x *SignMessage) signWithAddress() error {
+	client, err := makeWalletClient(x.opts)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.SignMessage(makeContext(x.opts.AuthToken), &pb.SignMessageRequest{
+		Address: string(x.Address),
+		Message: x.Message,
+	})
+	if err != nil {
+		return err
+	}
+
+	var b []byte
+	buff := bytes.NewBuffer(b)
+
+	w, err := armor.Encode(buff, "ILLIUM SIGNATURE", map[string]string{
+		"Address":   string(x.Address),
+		"PublicKey": hex.EncodeToString(resp.PublicKey),
+		"Message":   x.Message,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(resp.Signature)
+	if err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	fmt.Println(buff.String())
+
+	return nil
+}
+
 type VerifyMessage struct {
 	SigBlock string `short:"s" long:"sig" description:"A signature block to verify"`
 	opts     *options
@@ -545,6 +1192,10 @@ func (x *VerifyMessage) Execute(args []string) error {
 		return err
 	}
 
+	if _, ok := block.Header["Address"]; ok {
+		return x.verifyAddressSignature(block)
+	}
+
 	pidStr, ok := block.Header["PeerID"]
 	if !ok {
 		return errors.New("PeerID not found in signature block")
@@ -588,3 +1239,95 @@ func (x *VerifyMessage) Execute(args []string) error {
 
 	return nil
 }
+
+// verifyAddressSignature verifies a signature block created with the
+// spend key of a wallet address. The address only commits to the hash
+// of the spend key so the check is done by the node.
+func (x *VerifyMessage) verifyAddressSignature(block *armor.Block) error {
+	if block.Type != "ILLIUM SIGNATURE" {
+		return errors.New("not illium signature block")
+	}
+	message, ok := block.Header["Message"]
+	if !ok {
+		return errors.New("Message not found in signature block")
+	}
+	pubkeyStr, ok := block.Header["PublicKey"]
+	if !ok {
+		return errors.New("PublicKey not found in signature block")
+	}
+	pubkey, err := hex.DecodeString(pubkeyStr)
+	if err != nil {
+		return err
+	}
+
+	reader := new(bytes.Buffer)
+	_, err = reader.ReadFrom(block.Body)
+	if err != nil {
+		return err
+	}
+
+	client, err := makeWalletClient(x.opts)
+	if err != nil {
+		return err
+	}
+	resp, err := client.VerifyMessage(makeContext(x.opts.AuthToken), &pb.VerifyMessageRequest{
+		Address:   block.Header["Address"],
+		Message:   message,
+		Signature: reader.Bytes(),
+		PublicKey: pubkey,
+	})
+	if err != nil {
+		return err
+	}
+	if resp.Valid {
+		fmt.Println("valid signature")
+	} else {
+		fmt.Println("invalid signature")
+	}
+
+	return nil
+}
+
+type ReplayConsensus struct {
+	File string `short:"f" long:"file" description:"The file written by ilxd --recordconsensus"`
+	opts *options
+}
+
+func (x *ReplayConsensus) Execute(args []string) error {
+	f, err := os.Open(repo.CleanAndExpandPath(x.File))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	chainParams, err := networkParams(x.opts)
+	if err != nil {
+		return err
+	}
+	events, err := consensus.ReplayTraffic(bufio.NewReader(f), chainParams)
+	if err != nil {
+		return err
+	}
+
+	type event struct {
+		Timestamp time.Time `json:"timestamp"`
+		Height    uint32    `json:"height"`
+		BlockID   string    `json:"blockID"`
+		Status    string    `json:"status"`
+	}
+	out := make([]event, 0, len(events))
+	for _, e := range events {
+		out = append(out, event{
+			Timestamp: e.Timestamp,
+			Height:    e.Height,
+			BlockID:   e.BlockID.String(),
+			Status:    e.Status.String(),
+		})
+	}
+	ser, err := json.MarshalIndent(out, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(ser))
+	return nil
+}
@@ -0,0 +1,314 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/btcsuite/btcd/btcutil/bech32"
+	icrypto "github.com/project-illium/ilxd/crypto"
+	"github.com/project-illium/ilxd/params"
+	"github.com/project-illium/ilxd/types"
+	"github.com/project-illium/ilxd/zk"
+	"github.com/project-illium/walletlib"
+	"github.com/skip2/go-qrcode"
+	"golang.org/x/crypto/scrypt"
+	"os"
+	"path"
+	"strings"
+)
+
+const (
+	// encryptedKeyPrefix is the bech32 prefix for passphrase encrypted
+	// private keys.
+	encryptedKeyPrefix = "privenc"
+
+	// The scrypt parameters are the same as those used by BIP-38.
+	scryptN = 16384
+	scryptR = 8
+	scryptP = 8
+
+	qrCodeSize = 512
+)
+
+type CreatePaperWallet struct {
+	OutputDir  string `short:"o" long:"outdir" description:"The directory to write the QR code images to" default:"."`
+	Format     string `short:"f" long:"format" description:"The image format for the QR codes: [png, svg]" default:"png"`
+	Passphrase string `short:"p" long:"passphrase" description:"Optionally encrypt the private key with this passphrase. The passphrase will be needed to decrypt the key before it can be used."`
+	opts       *options
+}
+
+func (x *CreatePaperWallet) Execute(args []string) error {
+	format := strings.ToLower(x.Format)
+	if format != "png" && format != "svg" {
+		return errors.New("format must be png or svg")
+	}
+	chainParams, err := networkParams(x.opts)
+	if err != nil {
+		return err
+	}
+
+	spendKey, _, err := icrypto.GenerateNovaKey(rand.Reader)
+	if err != nil {
+		return err
+	}
+	viewKey, _, err := icrypto.GenerateCurve25519Key(rand.Reader)
+	if err != nil {
+		return err
+	}
+	rawSpend, err := spendKey.Raw()
+	if err != nil {
+		return err
+	}
+	rawView, err := viewKey.Raw()
+	if err != nil {
+		return err
+	}
+	keyBytes := make([]byte, 0, 64)
+	keyBytes = append(keyBytes, rawSpend[:32]...)
+	keyBytes = append(keyBytes, rawView[:32]...)
+	key, err := walletlib.UnmarshalWalletPrivateKey(keyBytes)
+	if err != nil {
+		return err
+	}
+	walletKey := key.(*walletlib.WalletPrivateKey)
+
+	addr, err := paperWalletAddress(walletKey, chainParams)
+	if err != nil {
+		return err
+	}
+
+	encodedKey := walletlib.EncodePrivateKey(walletKey)
+	if x.Passphrase != "" {
+		encodedKey, err = encryptPrivateKey(keyBytes, addr.String(), x.Passphrase)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := os.MkdirAll(x.OutputDir, 0700); err != nil {
+		return err
+	}
+	addrFile := path.Join(x.OutputDir, "paperwallet-address."+format)
+	keyFile := path.Join(x.OutputDir, "paperwallet-privkey."+format)
+	if err := writeQRCode(addrFile, addr.String(), format); err != nil {
+		return err
+	}
+	if err := writeQRCode(keyFile, encodedKey, format); err != nil {
+		return err
+	}
+
+	s := struct {
+		Address     string `json:"address"`
+		PrivateKey  string `json:"privateKey"`
+		Encrypted   bool   `json:"encrypted"`
+		AddressFile string `json:"addressFile"`
+		KeyFile     string `json:"keyFile"`
+	}{
+		Address:     addr.String(),
+		PrivateKey:  encodedKey,
+		Encrypted:   x.Passphrase != "",
+		AddressFile: addrFile,
+		KeyFile:     keyFile,
+	}
+	out, err := json.MarshalIndent(&s, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+type DecryptPaperWallet struct {
+	Key        string `short:"k" long:"key" description:"The encrypted private key"`
+	Passphrase string `short:"p" long:"passphrase" description:"The passphrase used to encrypt the key"`
+	opts       *options
+}
+
+func (x *DecryptPaperWallet) Execute(args []string) error {
+	chainParams, err := networkParams(x.opts)
+	if err != nil {
+		return err
+	}
+	walletKey, addr, err := decryptPrivateKey(x.Key, x.Passphrase, chainParams)
+	if err != nil {
+		return err
+	}
+
+	s := struct {
+		Address    string `json:"address"`
+		PrivateKey string `json:"privateKey"`
+	}{
+		Address:    addr.String(),
+		PrivateKey: walletlib.EncodePrivateKey(walletKey),
+	}
+	out, err := json.MarshalIndent(&s, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+// paperWalletAddress returns the basic transfer address for the key.
+func paperWalletAddress(key *walletlib.WalletPrivateKey, chainParams *params.NetworkParams) (walletlib.Address, error) {
+	novaKey, ok := key.SpendKey().GetPublic().(*icrypto.NovaPublicKey)
+	if !ok {
+		return nil, errors.New("spend key is not type Nova public key")
+	}
+	pubX, pubY := novaKey.ToXY()
+
+	scriptCommitment, err := zk.LurkCommit(zk.BasicTransferScript())
+	if err != nil {
+		return nil, err
+	}
+	lockingScript := types.LockingScript{
+		ScriptCommitment: types.NewID(scriptCommitment),
+		LockingParams:    [][]byte{pubX, pubY},
+	}
+	return walletlib.NewBasicAddress(lockingScript, key.ViewKey().GetPublic(), chainParams)
+}
+
+// encryptPrivateKey encrypts the raw wallet key following the BIP-38
+// construction. The first four bytes of the double sha256 of the address
+// are used as the scrypt salt and are prepended to the ciphertext so that
+// a wrong passphrase can be detected on decryption.
+func encryptPrivateKey(keyBytes []byte, addr, passphrase string) (string, error) {
+	salt := addressHash(addr)
+	derived, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, len(keyBytes)+32)
+	if err != nil {
+		return "", err
+	}
+	block, err := aes.NewCipher(derived[len(keyBytes):])
+	if err != nil {
+		return "", err
+	}
+
+	payload := make([]byte, len(salt)+len(keyBytes))
+	copy(payload, salt)
+	xored := make([]byte, len(keyBytes))
+	for i := range keyBytes {
+		xored[i] = keyBytes[i] ^ derived[i]
+	}
+	for i := 0; i < len(xored); i += aes.BlockSize {
+		block.Encrypt(payload[len(salt)+i:], xored[i:i+aes.BlockSize])
+	}
+
+	converted, err := bech32.ConvertBits(payload, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+	return bech32.EncodeM(encryptedKeyPrefix, converted)
+}
+
+// decryptPrivateKey reverses encryptPrivateKey and returns the key along
+// with its address.
+func decryptPrivateKey(encoded, passphrase string, chainParams *params.NetworkParams) (*walletlib.WalletPrivateKey, walletlib.Address, error) {
+	hrp, data, err := bech32.DecodeNoLimit(encoded)
+	if err != nil {
+		return nil, nil, err
+	}
+	if hrp != encryptedKeyPrefix {
+		return nil, nil, errors.New("key is not an encrypted private key")
+	}
+	payload, err := bech32.ConvertBits(data, 5, 8, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(payload) != 4+64 {
+		return nil, nil, errors.New("invalid encrypted key length")
+	}
+	salt, ciphertext := payload[:4], payload[4:]
+
+	derived, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, len(ciphertext)+32)
+	if err != nil {
+		return nil, nil, err
+	}
+	block, err := aes.NewCipher(derived[len(ciphertext):])
+	if err != nil {
+		return nil, nil, err
+	}
+	keyBytes := make([]byte, len(ciphertext))
+	for i := 0; i < len(ciphertext); i += aes.BlockSize {
+		block.Decrypt(keyBytes[i:], ciphertext[i:i+aes.BlockSize])
+	}
+	for i := range keyBytes {
+		keyBytes[i] ^= derived[i]
+	}
+
+	key, err := walletlib.UnmarshalWalletPrivateKey(keyBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	walletKey := key.(*walletlib.WalletPrivateKey)
+	addr, err := paperWalletAddress(walletKey, chainParams)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !bytes.Equal(addressHash(addr.String()), salt) {
+		return nil, nil, errors.New("incorrect passphrase")
+	}
+	return walletKey, addr, nil
+}
+
+func addressHash(addr string) []byte {
+	h := sha256.Sum256([]byte(addr))
+	h = sha256.Sum256(h[:])
+	return h[:4]
+}
+
+// writeQRCode encodes the content as a QR code and writes it to the file.
+// Existing files are never overwritten so that a previously generated
+// key cannot be lost.
+func writeQRCode(filename, content, format string) error {
+	qr, err := qrcode.New(content, qrcode.Medium)
+	if err != nil {
+		return err
+	}
+
+	var img []byte
+	if format == "svg" {
+		img = qrCodeSVG(qr.Bitmap())
+	} else {
+		img, err = qr.PNG(qrCodeSize)
+		if err != nil {
+			return err
+		}
+	}
+
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(img); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// qrCodeSVG renders the QR code bitmap as an SVG image with one unit
+// square per module.
+func qrCodeSVG(bitmap [][]bool) []byte {
+	var buf bytes.Buffer
+	size := len(bitmap)
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" width="%d" height="%d" shape-rendering="crispEdges">`, size, size, qrCodeSize, qrCodeSize)
+	fmt.Fprintf(&buf, `<rect width="%d" height="%d" fill="#ffffff"/>`, size, size)
+	buf.WriteString(`<path fill="#000000" d="`)
+	for y, row := range bitmap {
+		for x, set := range row {
+			if set {
+				fmt.Fprintf(&buf, "M%d %dh1v1h-1z", x, y)
+			}
+		}
+	}
+	buf.WriteString(`"/></svg>`)
+	return buf.Bytes()
+}
@@ -0,0 +1,393 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	icrypto "github.com/project-illium/ilxd/crypto"
+	"github.com/project-illium/ilxd/repo"
+	"github.com/project-illium/ilxd/rpc/pb"
+	"github.com/project-illium/ilxd/types"
+	"github.com/project-illium/ilxd/zk"
+	"github.com/pterm/pterm"
+	"google.golang.org/protobuf/proto"
+	"os"
+	"strings"
+)
+
+// pstPrefix is prepended to encoded partially signed transactions so they
+// can be told apart from raw transactions and so the format can be versioned.
+const pstPrefix = "ilxpst1:"
+
+// pstSignature is a signature over the transaction's sighash by one of
+// the multisig keys.
+type pstSignature struct {
+	PublicKey types.HexEncodable `json:"publicKey"`
+	Signature types.HexEncodable `json:"signature"`
+}
+
+// partiallySignedTx (PST) is a raw multisig transaction along with the
+// signatures collected so far. It is created by createpst and passed between
+// the co-signers, each adding their signature with signpst, until it holds
+// enough signatures to be proven with finalizepst. Co-signers can also sign
+// in parallel and merge their copies with combinepst.
+//
+// Every input must be locked by the same multisig locking script so a single
+// set of signatures unlocks the whole transaction.
+type partiallySignedTx struct {
+	RawTx      types.HexEncodable   `json:"rawTx"`
+	Threshold  uint32               `json:"threshold"`
+	PublicKeys []types.HexEncodable `json:"publicKeys"`
+	Signatures []pstSignature       `json:"signatures"`
+}
+
+// newPST validates a raw transaction and returns a PST for it with
+// no signatures.
+func newPST(rawTx *pb.RawTransaction) (*partiallySignedTx, error) {
+	threshold, pubkeys, err := checkMultisigTx(rawTx)
+	if err != nil {
+		return nil, err
+	}
+	ser, err := proto.Marshal(rawTx)
+	if err != nil {
+		return nil, err
+	}
+	pst := &partiallySignedTx{
+		RawTx:      ser,
+		Threshold:  threshold,
+		PublicKeys: make([]types.HexEncodable, 0, len(pubkeys)),
+		Signatures: []pstSignature{},
+	}
+	for _, pubkey := range pubkeys {
+		keyBytes, err := crypto.MarshalPublicKey(pubkey)
+		if err != nil {
+			return nil, err
+		}
+		pst.PublicKeys = append(pst.PublicKeys, keyBytes)
+	}
+	return pst, nil
+}
+
+// checkMultisigTx validates that a raw transaction is a standard transaction
+// spending only from a single multisig locking script and that its private
+// outputs match the transaction. It returns the threshold and the keys in
+// the order they appear in the locking script.
+func checkMultisigTx(rawTx *pb.RawTransaction) (uint32, []crypto.PubKey, error) {
+	if rawTx.Tx == nil || rawTx.Tx.GetStandardTransaction() == nil {
+		return 0, nil, errors.New("transaction must be a standard transaction")
+	}
+	standardTx := rawTx.Tx.GetStandardTransaction()
+	if len(rawTx.Inputs) == 0 || len(rawTx.Inputs) != len(standardTx.Nullifiers) {
+		return 0, nil, errors.New("raw transaction inputs do not match transaction")
+	}
+	if len(rawTx.Outputs) != len(standardTx.Outputs) {
+		return 0, nil, errors.New("raw transaction outputs do not match transaction")
+	}
+
+	lockingParams := rawTx.Inputs[0].LockingParams
+	for i, in := range rawTx.Inputs {
+		if in.Script != zk.MultisigScript() {
+			return 0, nil, fmt.Errorf("input %d is not a multisig input", i)
+		}
+		if len(in.LockingParams) != len(lockingParams) {
+			return 0, nil, fmt.Errorf("input %d is locked by a different multisig script", i)
+		}
+		for j := range in.LockingParams {
+			if !bytes.Equal(in.LockingParams[j], lockingParams[j]) {
+				return 0, nil, fmt.Errorf("input %d is locked by a different multisig script", i)
+			}
+		}
+	}
+	if err := checkPrivateOutputs(rawTx); err != nil {
+		return 0, nil, err
+	}
+
+	if len(lockingParams) < 3 || len(lockingParams)%2 != 1 || len(lockingParams[0]) > 4 {
+		return 0, nil, errors.New("invalid multisig locking params")
+	}
+	var threshold uint32
+	for _, b := range lockingParams[0] {
+		threshold = threshold<<8 | uint32(b)
+	}
+	pubkeys := make([]crypto.PubKey, 0, len(lockingParams)/2)
+	for i := 1; i < len(lockingParams); i += 2 {
+		pubkey, err := icrypto.PublicKeyFromXY(lockingParams[i], lockingParams[i+1])
+		if err != nil {
+			return 0, nil, err
+		}
+		pubkeys = append(pubkeys, pubkey)
+	}
+	if threshold == 0 || int(threshold) > len(pubkeys) {
+		return 0, nil, errors.New("invalid multisig threshold")
+	}
+	return threshold, pubkeys, nil
+}
+
+// encode serializes the PST as the prefix followed by base64 encoded JSON.
+func (pst *partiallySignedTx) encode() (string, error) {
+	ser, err := json.Marshal(pst)
+	if err != nil {
+		return "", err
+	}
+	return pstPrefix + base64.StdEncoding.EncodeToString(ser), nil
+}
+
+// decodePST parses an encoded PST, either as a string or from a file, and
+// checks it against its raw transaction.
+func decodePST(s string) (*partiallySignedTx, *pb.RawTransaction, error) {
+	if !strings.HasPrefix(s, pstPrefix) {
+		data, err := os.ReadFile(repo.CleanAndExpandPath(s))
+		if err != nil {
+			return nil, nil, errors.New("unknown partially signed transaction format")
+		}
+		s = strings.TrimSpace(string(data))
+		if !strings.HasPrefix(s, pstPrefix) {
+			return nil, nil, errors.New("unknown partially signed transaction format")
+		}
+	}
+	ser, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(s, pstPrefix))
+	if err != nil {
+		return nil, nil, err
+	}
+	pst := new(partiallySignedTx)
+	if err := json.Unmarshal(ser, pst); err != nil {
+		return nil, nil, err
+	}
+	rawTx := new(pb.RawTransaction)
+	if err := proto.Unmarshal(pst.RawTx, rawTx); err != nil {
+		return nil, nil, err
+	}
+
+	// Rebuild the PST from the transaction so the threshold and keys
+	// can't disagree with the locking script, then check each
+	// of the signatures against it.
+	checked, err := newPST(rawTx)
+	if err != nil {
+		return nil, nil, err
+	}
+	sigHash, err := rawTx.Tx.GetStandardTransaction().SigHash()
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, sig := range pst.Signatures {
+		if err := checked.addSignature(sigHash, sig); err != nil {
+			return nil, nil, err
+		}
+	}
+	return checked, rawTx, nil
+}
+
+// addSignature adds the signature to the PST replacing any previous
+// signature by the same key. An error is returned if the key is not one
+// of the multisig keys or the signature is invalid.
+func (pst *partiallySignedTx) addSignature(sigHash []byte, sig pstSignature) error {
+	found := false
+	for _, key := range pst.PublicKeys {
+		if bytes.Equal(key, sig.PublicKey) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return errors.New("signature is not from one of the multisig keys")
+	}
+	pubkey, err := crypto.UnmarshalPublicKey(sig.PublicKey)
+	if err != nil {
+		return err
+	}
+	valid, err := pubkey.Verify(sigHash, sig.Signature)
+	if err != nil || !valid {
+		return errors.New("invalid signature")
+	}
+
+	for i, s := range pst.Signatures {
+		if bytes.Equal(s.PublicKey, sig.PublicKey) {
+			pst.Signatures[i] = sig
+			return nil
+		}
+	}
+	pst.Signatures = append(pst.Signatures, sig)
+	return nil
+}
+
+// orderedSignatures returns threshold signatures in the order of the keys
+// in the locking script as expected by the multisig script.
+func (pst *partiallySignedTx) orderedSignatures() ([][]byte, error) {
+	ordered := make([][]byte, 0, pst.Threshold)
+	for _, key := range pst.PublicKeys {
+		for _, sig := range pst.Signatures {
+			if bytes.Equal(key, sig.PublicKey) {
+				ordered = append(ordered, sig.Signature)
+				break
+			}
+		}
+		if len(ordered) == int(pst.Threshold) {
+			return ordered, nil
+		}
+	}
+	return nil, fmt.Errorf("%d of %d signatures collected", len(ordered), pst.Threshold)
+}
+
+// printPST writes a summary of the PST to stderr and the encoded PST to
+// stdout so the output can be passed straight to the next command.
+func printPST(pst *partiallySignedTx) error {
+	encoded, err := pst.encode()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "%d of %d signatures collected\n", len(pst.Signatures), pst.Threshold)
+	fmt.Println(encoded)
+	return nil
+}
+
+type CreatePST struct {
+	Tx   string `short:"t" long:"tx" description:"A raw transaction spending from a multisig address, as created by createrawtransaction. Serialized as hex string."`
+	opts *options
+}
+
+func (x *CreatePST) Execute(args []string) error {
+	rawTx, err := decodeRawTransaction(x.Tx)
+	if err != nil {
+		return err
+	}
+	pst, err := newPST(rawTx)
+	if err != nil {
+		return err
+	}
+	return printPST(pst)
+}
+
+type SignPST struct {
+	PST        string `short:"p" long:"pst" description:"The partially signed transaction or a file containing it"`
+	PrivateKey string `short:"k" long:"privkey" description:"Your spend private key for the multisig address. Serialized as hex string. If not set the signer command is used."`
+	opts       *options
+}
+
+func (x *SignPST) Execute(args []string) error {
+	pst, rawTx, err := decodePST(x.PST)
+	if err != nil {
+		return err
+	}
+	privKey, err := signingKey(x.opts, x.PrivateKey, inputPublicKeys(rawTx))
+	if err != nil {
+		return err
+	}
+	pubKeyBytes, err := crypto.MarshalPublicKey(privKey.GetPublic())
+	if err != nil {
+		return err
+	}
+
+	sigHash, err := rawTx.Tx.GetStandardTransaction().SigHash()
+	if err != nil {
+		return err
+	}
+	sig, err := privKey.Sign(sigHash)
+	if err != nil {
+		return err
+	}
+	if err := pst.addSignature(sigHash, pstSignature{PublicKey: pubKeyBytes, Signature: sig}); err != nil {
+		return err
+	}
+	return printPST(pst)
+}
+
+type CombinePST struct {
+	PSTs []string `short:"p" long:"pst" description:"A partially signed transaction or a file containing it. Use this option more than once to combine several copies of the same transaction."`
+	opts *options
+}
+
+func (x *CombinePST) Execute(args []string) error {
+	if len(x.PSTs) == 0 {
+		return errors.New("at least one pst is required")
+	}
+	combined, rawTx, err := decodePST(x.PSTs[0])
+	if err != nil {
+		return err
+	}
+	sigHash, err := rawTx.Tx.GetStandardTransaction().SigHash()
+	if err != nil {
+		return err
+	}
+	for _, s := range x.PSTs[1:] {
+		pst, _, err := decodePST(s)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(pst.RawTx, combined.RawTx) {
+			return errors.New("partially signed transactions are for different transactions")
+		}
+		for _, sig := range pst.Signatures {
+			if err := combined.addSignature(sigHash, sig); err != nil {
+				return err
+			}
+		}
+	}
+	return printPST(combined)
+}
+
+type FinalizePST struct {
+	PST       string `short:"p" long:"pst" description:"The partially signed transaction or a file containing it"`
+	Broadcast bool   `short:"b" long:"broadcast" description:"Submit the proven transaction to the network. If false the transaction is printed."`
+	opts      *options
+}
+
+func (x *FinalizePST) Execute(args []string) error {
+	pst, rawTx, err := decodePST(x.PST)
+	if err != nil {
+		return err
+	}
+	sigs, err := pst.orderedSignatures()
+	if err != nil {
+		return err
+	}
+
+	walletClient, err := makeWalletClient(x.opts)
+	if err != nil {
+		return err
+	}
+
+	spinner, err := pterm.DefaultSpinner.Start(provingPhrase())
+	if err != nil {
+		return err
+	}
+	proveResp, err := walletClient.ProveMultisig(makeContext(x.opts.AuthToken), &pb.ProveMultisigRequest{
+		RawTx: rawTx,
+		Sigs:  sigs,
+	})
+	if err != nil {
+		spinner.Fail(trf("Error proving transaction: %s", err.Error()))
+		return nil
+	}
+	if !x.Broadcast {
+		ser, err := proto.Marshal(proveResp.ProvedTx)
+		if err != nil {
+			spinner.Fail(trf("Error serializing transaction: %s", err.Error()))
+			return nil
+		}
+		spinner.Success(hex.EncodeToString(ser))
+		return nil
+	}
+
+	blockchainClient, err := makeBlockchainClient(x.opts)
+	if err != nil {
+		spinner.Fail(err.Error())
+		return nil
+	}
+	submitResp, err := blockchainClient.SubmitTransaction(makeContext(x.opts.AuthToken), &pb.SubmitTransactionRequest{
+		Transaction: proveResp.ProvedTx,
+	})
+	if err != nil {
+		spinner.Fail(trf("Error submitting transaction: %s", err.Error()))
+		return nil
+	}
+	spinner.Success(hex.EncodeToString(submitResp.Transaction_ID))
+	return nil
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/project-illium/ilxd/types"
+	"github.com/project-illium/ilxd/zk"
+	"github.com/project-illium/walletlib"
+	"strings"
+)
+
+type CreateScriptAddress struct {
+	Template   string   `short:"t" long:"template" description:"The script template to use. Use --list to see the templates."`
+	Params     []string `short:"p" long:"param" description:"A template parameter formatted as <name>=<value>. Use this option once for each of the template's parameters."`
+	ViewPubKey string   `short:"k" long:"viewpubkey" description:"The view public key for the address. Serialized as hex string."`
+	List       bool     `short:"l" long:"list" description:"List the script templates and their parameters"`
+	opts       *options
+}
+
+func (x *CreateScriptAddress) Execute(args []string) error {
+	if x.List {
+		return listScriptTemplates()
+	}
+	if x.Template == "" {
+		return errors.New("template is required")
+	}
+	tmpl, err := zk.GetScriptTemplate(x.Template)
+	if err != nil {
+		return err
+	}
+
+	templateArgs := make(map[string]string)
+	for _, p := range x.Params {
+		name, value, ok := strings.Cut(p, "=")
+		if !ok {
+			return fmt.Errorf("invalid param %s. Params must be formatted as <name>=<value>", p)
+		}
+		templateArgs[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	lockingParams, err := tmpl.LockingParams(templateArgs)
+	if err != nil {
+		return err
+	}
+
+	viewKeyBytes, err := hex.DecodeString(x.ViewPubKey)
+	if err != nil {
+		return err
+	}
+	viewKey, err := crypto.UnmarshalPublicKey(viewKeyBytes)
+	if err != nil {
+		return err
+	}
+
+	scriptCommitment := tmpl.ScriptCommitment()
+	if len(scriptCommitment) == 0 {
+		return zk.ErrProverUnavailable
+	}
+	lockingScript := types.LockingScript{
+		ScriptCommitment: types.NewID(scriptCommitment),
+		LockingParams:    lockingParams,
+	}
+
+	chainParams, err := networkParams(x.opts)
+	if err != nil {
+		return err
+	}
+	addr, err := walletlib.NewBasicAddress(lockingScript, viewKey, chainParams)
+	if err != nil {
+		return err
+	}
+
+	resp := struct {
+		Addr             string             `json:"address"`
+		LockingScript    types.HexEncodable `json:"lockingScript"`
+		ScriptCommitment types.HexEncodable `json:"scriptCommitment"`
+		Script           string             `json:"script"`
+	}{
+		Addr:             addr.String(),
+		LockingScript:    lockingScript.Serialize(),
+		ScriptCommitment: scriptCommitment,
+		Script:           tmpl.Script(),
+	}
+	out, err := json.MarshalIndent(&resp, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+func listScriptTemplates() error {
+	type param struct {
+		Name        string `json:"name"`
+		Type        string `json:"type"`
+		Description string `json:"description"`
+	}
+	type template struct {
+		Name        string  `json:"name"`
+		Description string  `json:"description"`
+		Params      []param `json:"params"`
+	}
+	templates := make([]template, 0, len(zk.ScriptTemplates()))
+	for _, t := range zk.ScriptTemplates() {
+		tmpl := template{
+			Name:        t.Name,
+			Description: t.Description,
+		}
+		for _, p := range t.Params {
+			tmpl.Params = append(tmpl.Params, param{
+				Name:        p.Name,
+				Type:        p.Type.String(),
+				Description: p.Description,
+			})
+		}
+		templates = append(templates, tmpl)
+	}
+	out, err := json.MarshalIndent(templates, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
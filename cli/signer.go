@@ -0,0 +1,222 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/crypto/pb"
+	icrypto "github.com/project-illium/ilxd/crypto"
+	rpcpb "github.com/project-illium/ilxd/rpc/pb"
+	"github.com/project-illium/ilxd/types"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// signerRequest is the JSON written to the signer command's stdin.
+//
+// The getpublickeys method asks the signer for the Nova spend public keys
+// it holds. The sign method asks it to sign the sighash with the private
+// key for the public key. The signer may ask the user to confirm on the
+// device before responding.
+type signerRequest struct {
+	Method    string             `json:"method"`
+	PublicKey types.HexEncodable `json:"publicKey,omitempty"`
+	SigHash   types.HexEncodable `json:"sigHash,omitempty"`
+}
+
+// signerResponse is the JSON read from the signer command's stdout.
+type signerResponse struct {
+	PublicKeys []types.HexEncodable `json:"publicKeys,omitempty"`
+	Signature  types.HexEncodable   `json:"signature,omitempty"`
+	Error      string               `json:"error,omitempty"`
+}
+
+// externalSigner delegates signing to an external program, such as a bridge
+// to a hardware wallet, so the spend private keys never need to be passed
+// to the CLI. The program is run once per request with the request on
+// stdin and must write the response to stdout.
+type externalSigner struct {
+	cmd []string
+}
+
+func newExternalSigner(cmd string) (*externalSigner, error) {
+	fields := strings.Fields(cmd)
+	if len(fields) == 0 {
+		return nil, errors.New("signer command is empty")
+	}
+	return &externalSigner{cmd: fields}, nil
+}
+
+func (s *externalSigner) call(req *signerRequest) (*signerResponse, error) {
+	in, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	cmd := exec.Command(s.cmd[0], s.cmd[1:]...)
+	cmd.Stdin = bytes.NewReader(in)
+	// The signer may prompt the user on stderr.
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("signer command failed: %s", err)
+	}
+	resp := new(signerResponse)
+	if err := json.Unmarshal(out, resp); err != nil {
+		return nil, fmt.Errorf("invalid signer response: %s", err)
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("signer error: %s", resp.Error)
+	}
+	return resp, nil
+}
+
+// keys returns a key for each of the public keys held by the signer.
+func (s *externalSigner) keys() ([]crypto.PrivKey, error) {
+	resp, err := s.call(&signerRequest{Method: "getpublickeys"})
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]crypto.PrivKey, 0, len(resp.PublicKeys))
+	for _, keyBytes := range resp.PublicKeys {
+		pub, err := crypto.UnmarshalPublicKey(keyBytes)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := pub.(*icrypto.NovaPublicKey); !ok {
+			return nil, errors.New("signer public key is not type Nova")
+		}
+		keys = append(keys, &signerKey{signer: s, pub: pub, pubBytes: keyBytes})
+	}
+	return keys, nil
+}
+
+// signerKey is a private key held by an external signer. It satisfies
+// crypto.PrivKey so it can be used anywhere a Nova private key is, but
+// the raw key cannot be read.
+type signerKey struct {
+	signer   *externalSigner
+	pub      crypto.PubKey
+	pubBytes []byte
+}
+
+func (k *signerKey) Sign(sigHash []byte) ([]byte, error) {
+	resp, err := k.signer.call(&signerRequest{
+		Method:    "sign",
+		PublicKey: k.pubBytes,
+		SigHash:   sigHash,
+	})
+	if err != nil {
+		return nil, err
+	}
+	valid, err := k.pub.Verify(sigHash, resp.Signature)
+	if err != nil || !valid {
+		return nil, errors.New("signer returned an invalid signature")
+	}
+	return resp.Signature, nil
+}
+
+func (k *signerKey) GetPublic() crypto.PubKey {
+	return k.pub
+}
+
+func (k *signerKey) Equals(o crypto.Key) bool {
+	other, ok := o.(*signerKey)
+	return ok && k.pub.Equals(other.pub)
+}
+
+func (k *signerKey) Raw() ([]byte, error) {
+	return nil, errors.New("private key is held by the external signer")
+}
+
+func (k *signerKey) Type() pb.KeyType {
+	return icrypto.Libp2pKeyTypeNova
+}
+
+// signingKeys returns the private keys passed on the command line as hex
+// strings. If none were passed and a signer command is configured the
+// signer's keys are returned instead.
+func signingKeys(opts *options, privKeys ...string) ([]crypto.PrivKey, error) {
+	keys := make([]crypto.PrivKey, 0, len(privKeys))
+	for _, k := range privKeys {
+		if k == "" {
+			continue
+		}
+		privKeyBytes, err := hex.DecodeString(k)
+		if err != nil {
+			return nil, err
+		}
+		privKey, err := crypto.UnmarshalPrivateKey(privKeyBytes)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, privKey)
+	}
+	if len(keys) > 0 || opts.SignerCmd == "" {
+		return keys, nil
+	}
+	signer, err := newExternalSigner(opts.SignerCmd)
+	if err != nil {
+		return nil, err
+	}
+	return signer.keys()
+}
+
+// signingKey returns the private key passed on the command line or, if none
+// was passed, the signer key for one of the candidate public keys. If there
+// are no candidates the signer must hold exactly one key.
+func signingKey(opts *options, privKey string, candidates []crypto.PubKey) (crypto.PrivKey, error) {
+	if privKey == "" && opts.SignerCmd == "" {
+		return nil, errors.New("a private key or signer command is required")
+	}
+	keys, err := signingKeys(opts, privKey)
+	if err != nil {
+		return nil, err
+	}
+	if privKey != "" {
+		return keys[0], nil
+	}
+	if len(candidates) == 0 {
+		if len(keys) != 1 {
+			return nil, fmt.Errorf("signer holds %d keys, unable to choose which to sign with", len(keys))
+		}
+		return keys[0], nil
+	}
+	for _, key := range keys {
+		for _, candidate := range candidates {
+			if key.GetPublic().Equals(candidate) {
+				return key, nil
+			}
+		}
+	}
+	return nil, errors.New("signer does not hold any of the keys needed to sign")
+}
+
+// inputPublicKeys returns the spend public keys found in the locking
+// params of the raw transaction's basic and multisig inputs.
+func inputPublicKeys(rawTx *rpcpb.RawTransaction) []crypto.PubKey {
+	var keys []crypto.PubKey
+	for _, in := range rawTx.Inputs {
+		// Basic inputs hold a single key. Multisig inputs hold the
+		// threshold followed by the keys.
+		start := 1
+		if len(in.LockingParams) == 2 {
+			start = 0
+		}
+		for i := start; i+1 < len(in.LockingParams); i += 2 {
+			pub, err := icrypto.PublicKeyFromXY(in.LockingParams[i], in.LockingParams[i+1])
+			if err != nil {
+				continue
+			}
+			keys = append(keys, pub)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,246 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/project-illium/ilxd/rpc/pb"
+	"github.com/project-illium/ilxd/types"
+	"github.com/pterm/pterm"
+	"strings"
+)
+
+// wizardConfTargets are the confirmation targets offered in the fee step
+// of the spend wizard.
+var wizardConfTargets = []struct {
+	name   string
+	target uint32
+}{
+	{"Fast", 1},
+	{"Normal", 6},
+	{"Economy", 24},
+}
+
+const (
+	wizardAutoSelect     = "Let the wallet choose"
+	wizardManualSelect   = "Choose the coins to spend"
+	wizardWalletFee      = "Wallet default"
+	wizardCustomFee      = "Custom fee per kilobyte"
+	wizardMaxListedUtxos = 15
+)
+
+// spendInteractive walks the user through building a spend one step at a
+// time: the recipient, the amount, the coins to spend, and the fee. The
+// transaction is built as a dry run and shown for review before anything
+// is proved or broadcast.
+func (x *Spend) spendInteractive(client pb.WalletServiceClient) error {
+	balance, err := client.GetBalance(makeContext(x.opts.AuthToken), &pb.GetBalanceRequest{Breakdown: true})
+	if err != nil {
+		return err
+	}
+	pterm.Info.Println(trf("Spendable balance: %f ILX", types.Amount(balance.Spendable).ToILX()))
+
+	// Recipient
+	var toAddr string
+	for toAddr == "" {
+		toAddr, err = pterm.DefaultInteractiveTextInput.Show(tr("Recipient address"))
+		if err != nil {
+			return err
+		}
+		toAddr = strings.TrimSpace(toAddr)
+	}
+
+	// Amount
+	var amt types.Amount
+	for amt == 0 {
+		amtStr, err := pterm.DefaultInteractiveTextInput.Show(tr("Amount (ILX)"))
+		if err != nil {
+			return err
+		}
+		amt, err = types.AmountFromILX(amtStr)
+		if err != nil {
+			pterm.Warning.Println(trf("Invalid amount: %s", err))
+			continue
+		}
+		if amt > types.Amount(balance.Spendable) {
+			pterm.Warning.Println(tr("Amount exceeds the spendable balance"))
+			amt = 0
+		}
+	}
+
+	// Coin selection
+	req := &pb.SpendRequest{
+		ToAddress: toAddr,
+		Amount:    uint64(amt),
+	}
+	mode, err := pterm.DefaultInteractiveSelect.
+		WithOptions([]string{tr(wizardAutoSelect), tr(wizardManualSelect)}).
+		Show(tr("Coin selection"))
+	if err != nil {
+		return err
+	}
+	if mode == tr(wizardAutoSelect) {
+		strategies := make([]string, 0, len(pb.SpendRequest_CoinSelection_name))
+		for i := 0; i < len(pb.SpendRequest_CoinSelection_name); i++ {
+			strategies = append(strategies, strings.ToLower(strings.ReplaceAll(pb.SpendRequest_CoinSelection_name[int32(i)], "_", "-")))
+		}
+		strategy, err := pterm.DefaultInteractiveSelect.
+			WithOptions(strategies).
+			Show(tr("Coin selection strategy"))
+		if err != nil {
+			return err
+		}
+		req.CoinSelection = pb.SpendRequest_CoinSelection(pb.SpendRequest_CoinSelection_value[strings.ToUpper(strings.ReplaceAll(strategy, "-", "_"))])
+	} else {
+		commitments, err := selectUtxos(client, x.opts, amt)
+		if err != nil {
+			return err
+		}
+		req.InputCommitments = commitments
+	}
+
+	// Fee
+	fpkb, err := selectFee(x.opts)
+	if err != nil {
+		return err
+	}
+	req.FeePerKilobyte = uint64(fpkb)
+
+	// Review
+	req.DryRun = true
+	resp, err := client.Spend(makeContext(x.opts.AuthToken), req)
+	if err != nil {
+		return err
+	}
+	pterm.DefaultSection.Println(tr("Review"))
+	if err := printTransactionPlan(resp.Plan); err != nil {
+		return err
+	}
+	ok, err := pterm.DefaultInteractiveConfirm.Show(trf("Send %f ILX to %s?", amt.ToILX(), toAddr))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errAborted
+	}
+
+	req.DryRun = false
+	spinner, err := pterm.DefaultSpinner.Start(provingPhrase())
+	if err != nil {
+		return err
+	}
+	resp, err = client.Spend(makeContext(x.opts.AuthToken), req)
+	if err != nil {
+		spinner.Fail(trf("Error proving transaction: %s", err.Error()))
+		return nil
+	}
+	spinner.Success(hex.EncodeToString(resp.Transaction_ID))
+	return nil
+}
+
+// selectUtxos lists the wallet's spendable utxos with checkboxes and returns
+// the commitments of the ones selected. The user is asked again if the
+// selected coins don't cover the amount.
+func selectUtxos(client pb.WalletServiceClient, opts *options, amt types.Amount) ([][]byte, error) {
+	resp, err := client.GetUtxos(makeContext(opts.AuthToken), &pb.GetUtxosRequest{})
+	if err != nil {
+		return nil, err
+	}
+	var (
+		choices = make([]string, 0, len(resp.Utxos))
+		utxos   = make(map[string]*pb.Utxo, len(resp.Utxos))
+	)
+	for _, ut := range resp.Utxos {
+		if ut.Staked || ut.WatchOnly {
+			continue
+		}
+		option := fmt.Sprintf("%f ILX  %s", types.Amount(ut.Amount).ToILX(), hex.EncodeToString(ut.Commitment))
+		if ut.Label != "" {
+			option += "  (" + ut.Label + ")"
+		}
+		choices = append(choices, option)
+		utxos[option] = ut
+	}
+	if len(choices) == 0 {
+		return nil, errors.New(tr("wallet has no spendable coins"))
+	}
+
+	for {
+		selected, err := pterm.DefaultInteractiveMultiselect.
+			WithOptions(choices).
+			WithMaxHeight(wizardMaxListedUtxos).
+			Show(tr("Coins to spend"))
+		if err != nil {
+			return nil, err
+		}
+		var (
+			total       types.Amount
+			commitments = make([][]byte, 0, len(selected))
+		)
+		for _, option := range selected {
+			total += types.Amount(utxos[option].Amount)
+			commitments = append(commitments, utxos[option].Commitment)
+		}
+		if total < amt {
+			pterm.Warning.Println(trf("Selected coins total %f ILX which does not cover the amount", total.ToILX()))
+			continue
+		}
+		return commitments, nil
+	}
+}
+
+// selectFee offers the node's fee estimates for a few confirmation targets
+// along with the wallet default or a custom fee and returns the fee per
+// kilobyte chosen. Zero means the wallet default.
+func selectFee(opts *options) (types.Amount, error) {
+	client, err := makeBlockchainClient(opts)
+	if err != nil {
+		return 0, err
+	}
+	var (
+		choices = make([]string, 0, len(wizardConfTargets)+2)
+		fees    = make(map[string]types.Amount)
+	)
+	for _, t := range wizardConfTargets {
+		resp, err := client.GetFeeEstimate(makeContext(opts.AuthToken), &pb.GetFeeEstimateRequest{
+			ConfTarget: t.target,
+		})
+		if err != nil {
+			return 0, err
+		}
+		option := trf("%s (%d blocks): %f ILX/kB", tr(t.name), t.target, types.Amount(resp.FeePerKilobyte).ToILX())
+		choices = append(choices, option)
+		fees[option] = types.Amount(resp.FeePerKilobyte)
+	}
+	choices = append(choices, tr(wizardWalletFee), tr(wizardCustomFee))
+
+	choice, err := pterm.DefaultInteractiveSelect.
+		WithOptions(choices).
+		Show(tr("Fee"))
+	if err != nil {
+		return 0, err
+	}
+	switch choice {
+	case tr(wizardWalletFee):
+		return 0, nil
+	case tr(wizardCustomFee):
+		for {
+			feeStr, err := pterm.DefaultInteractiveTextInput.Show(tr("Fee per kilobyte (ILX)"))
+			if err != nil {
+				return 0, err
+			}
+			fpkb, err := types.AmountFromILX(feeStr)
+			if err != nil {
+				pterm.Warning.Println(trf("Invalid fee: %s", err))
+				continue
+			}
+			return fpkb, nil
+		}
+	default:
+		return fees[choice], nil
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+	"time"
+)
+
+const (
+	// defaultQueryTimeout is how long a call to the node may take
+	// for commands which only read or update the node's state.
+	defaultQueryTimeout = time.Minute
+
+	// defaultProvingTimeout is how long a call to the node may take
+	// for commands which create a zk proof. Proving can take several
+	// minutes on slow hardware.
+	defaultProvingTimeout = time.Minute * 30
+)
+
+// commandTimeouts holds the default call timeout for the commands which
+// don't use defaultQueryTimeout. A zero timeout means the calls have no
+// deadline.
+var commandTimeouts = map[string]time.Duration{
+	"createtreasuryproposal": defaultProvingTimeout,
+	"delegatestake":          defaultProvingTimeout,
+	"finalizeescrow":         defaultProvingTimeout,
+	"finalizepst":            defaultProvingTimeout,
+	"initiateswap":           defaultProvingTimeout,
+	"participateswap":        defaultProvingTimeout,
+	"provemultisig":          defaultProvingTimeout,
+	"provepayment":           defaultProvingTimeout,
+	"proverawtransaction":    defaultProvingTimeout,
+	"provereserves":          defaultProvingTimeout,
+	"redeemswap":             defaultProvingTimeout,
+	"refundswap":             defaultProvingTimeout,
+	"revokedelegation":       defaultProvingTimeout,
+	"spend":                  defaultProvingTimeout,
+	"stake":                  defaultProvingTimeout,
+	"timelockcoins":          defaultProvingTimeout,
+	"walletrepair":           defaultProvingTimeout,
+
+	// These run for as long as the user asks.
+	"captureprofile":      0,
+	"notifytransactions":  0,
+	"recomputechainstate": 0,
+}
+
+// keepaliveParams has the client ping the node while a call is in flight
+// so that proxies between the two don't drop a long running call, such as
+// a spend waiting on the prover, for being idle.
+var keepaliveParams = grpc.WithKeepaliveParams(keepalive.ClientParameters{
+	Time:    time.Second * 30,
+	Timeout: time.Second * 20,
+})
+
+var (
+	// callTimeout is the deadline makeContext sets on each call.
+	callTimeout time.Duration
+
+	callCancels []context.CancelFunc
+)
+
+// setCallTimeout sets the call timeout for the command. The timeout option,
+// if set, takes precedence over the command's default. A negative timeout
+// option disables the deadline.
+func setCallTimeout(opts *options, command string) {
+	switch {
+	case opts.Timeout < 0:
+		callTimeout = 0
+	case opts.Timeout > 0:
+		callTimeout = opts.Timeout
+	default:
+		timeout, ok := commandTimeouts[command]
+		if !ok {
+			timeout = defaultQueryTimeout
+		}
+		callTimeout = timeout
+	}
+}
+
+// releaseCallContexts releases the resources held by the contexts
+// returned by makeContext. It's called once the command finishes.
+func releaseCallContexts() {
+	for _, cancel := range callCancels {
+		cancel()
+	}
+	callCancels = nil
+}
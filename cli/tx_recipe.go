@@ -0,0 +1,203 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/project-illium/ilxd/repo"
+	"github.com/project-illium/ilxd/rpc/pb"
+	"github.com/project-illium/ilxd/types"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// txRecipe is a human-readable description of a transaction. It's
+// resolved into a CreateRawTransactionRequest so users don't have to
+// hand-craft the private input and output JSON.
+//
+// Example:
+//
+//	{
+//	    "inputs": ["5c6f1e..."],
+//	    "outputs": [
+//	        {"address": "ilx1...", "amount": "1.5"},
+//	        {"address": "ilx1...", "amount": 2, "state": [true, 1700000000, "0x0a0b"]}
+//	    ],
+//	    "feePerKilobyte": "0.0001",
+//	    "changeAddress": "ilx1...",
+//	    "locktime": "2024-06-01T00:00:00Z"
+//	}
+//
+// If no inputs are provided the wallet selects the coins to spend.
+// The wallet always appends a change output and looks up the salts
+// and inclusion proofs for the inputs.
+type txRecipe struct {
+	Inputs         []string         `json:"inputs"`
+	Outputs        []txRecipeOutput `json:"outputs"`
+	FeePerKilobyte json.Number      `json:"feePerKilobyte"`
+	ChangeAddress  string           `json:"changeAddress"`
+	// Locktime is a unix timestamp or an RFC3339 string after which
+	// the transaction can no longer be included in a block.
+	Locktime json.RawMessage `json:"locktime"`
+}
+
+type txRecipeOutput struct {
+	Address string      `json:"address"`
+	Amount  json.Number `json:"amount"`
+	// State holds the lurk-script state fields of the output. Booleans
+	// are encoded as a single byte, integers as a uint64, and strings
+	// must be hex encoded with a 0x prefix. A 32 byte hex string is
+	// treated as a hash by the script.
+	State []interface{} `json:"state"`
+}
+
+// loadTxRecipe reads a recipe from a JSON file and builds the
+// CreateRawTransactionRequest it describes.
+func loadTxRecipe(path string) (*pb.CreateRawTransactionRequest, error) {
+	ser, err := os.ReadFile(repo.CleanAndExpandPath(path))
+	if err != nil {
+		return nil, err
+	}
+	var recipe txRecipe
+	dec := json.NewDecoder(bytes.NewReader(ser))
+	dec.UseNumber()
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&recipe); err != nil {
+		return nil, fmt.Errorf("invalid recipe: %w", err)
+	}
+	return recipe.request()
+}
+
+func (r *txRecipe) request() (*pb.CreateRawTransactionRequest, error) {
+	if len(r.Outputs) == 0 {
+		return nil, errors.New("recipe has no outputs")
+	}
+	fpkb, err := types.AmountFromILX(r.FeePerKilobyte.String())
+	if err != nil {
+		return nil, fmt.Errorf("invalid feePerKilobyte: %w", err)
+	}
+	expiry, err := parseRecipeLocktime(r.Locktime)
+	if err != nil {
+		return nil, err
+	}
+	req := &pb.CreateRawTransactionRequest{
+		AppendChangeOutput: true,
+		FeePerKilobyte:     uint64(fpkb),
+		Expiry:             expiry,
+		ChangeAddress:      r.ChangeAddress,
+	}
+	if r.ChangeAddress != "" && len(r.Inputs) == 0 {
+		return nil, errors.New("changeAddress requires inputs")
+	}
+	for i, in := range r.Inputs {
+		commitment, err := hex.DecodeString(in)
+		if err != nil {
+			return nil, fmt.Errorf("input %d: invalid commitment: %w", i, err)
+		}
+		req.Inputs = append(req.Inputs, &pb.CreateRawTransactionRequest_Input{
+			CommitmentOrPrivateInput: &pb.CreateRawTransactionRequest_Input_Commitment{
+				Commitment: commitment,
+			},
+		})
+	}
+	for i, out := range r.Outputs {
+		if out.Address == "" {
+			return nil, fmt.Errorf("output %d: missing address", i)
+		}
+		amount, err := types.AmountFromILX(out.Amount.String())
+		if err != nil {
+			return nil, fmt.Errorf("output %d: invalid amount: %w", i, err)
+		}
+		if amount == 0 {
+			return nil, fmt.Errorf("output %d: amount must be greater than zero", i)
+		}
+		var state []byte
+		if len(out.State) > 0 {
+			s, err := encodeRecipeState(out.State)
+			if err != nil {
+				return nil, fmt.Errorf("output %d: %w", i, err)
+			}
+			state, err = s.Serialize(false)
+			if err != nil {
+				return nil, err
+			}
+		}
+		req.Outputs = append(req.Outputs, &pb.CreateRawTransactionRequest_Output{
+			Address: out.Address,
+			Amount:  uint64(amount),
+			State:   state,
+		})
+	}
+	return req, nil
+}
+
+// encodeRecipeState converts the JSON state fields into the byte
+// elements of a types.State using the lengths the lurk script
+// expects for each type.
+func encodeRecipeState(fields []interface{}) (types.State, error) {
+	state := make(types.State, 0, len(fields))
+	for i, field := range fields {
+		switch v := field.(type) {
+		case bool:
+			b := []byte{0x00}
+			if v {
+				b[0] = 0x01
+			}
+			state = append(state, b)
+		case json.Number:
+			n, err := strconv.ParseUint(v.String(), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("state field %d: must be an unsigned integer", i)
+			}
+			b := make([]byte, 8)
+			binary.BigEndian.PutUint64(b, n)
+			state = append(state, b)
+		case string:
+			if !strings.HasPrefix(v, "0x") {
+				return nil, fmt.Errorf("state field %d: strings must be hex encoded with a 0x prefix", i)
+			}
+			b, err := hex.DecodeString(strings.TrimPrefix(v, "0x"))
+			if err != nil {
+				return nil, fmt.Errorf("state field %d: %w", i, err)
+			}
+			if len(b) == 0 || len(b) > 32 {
+				return nil, fmt.Errorf("state field %d: must be between 1 and 32 bytes", i)
+			}
+			state = append(state, b)
+		default:
+			return nil, fmt.Errorf("state field %d: unsupported type", i)
+		}
+	}
+	return state, nil
+}
+
+// parseRecipeLocktime accepts either a unix timestamp or an RFC3339
+// string. A missing locktime returns zero so the wallet's default is
+// used.
+func parseRecipeLocktime(raw json.RawMessage) (int64, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return 0, nil
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return 0, fmt.Errorf("invalid locktime: %w", err)
+		}
+		return t.Unix(), nil
+	}
+	n, err := strconv.ParseInt(string(raw), 10, 64)
+	if err != nil {
+		return 0, errors.New("locktime must be a unix timestamp or an RFC3339 string")
+	}
+	return n, nil
+}
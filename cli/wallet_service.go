@@ -14,7 +14,7 @@ import (
 	"fmt"
 	"github.com/libp2p/go-libp2p/core/crypto"
 	icrypto "github.com/project-illium/ilxd/crypto"
-	"github.com/project-illium/ilxd/params"
+	"github.com/project-illium/ilxd/repo"
 	"github.com/project-illium/ilxd/rpc/pb"
 	"github.com/project-illium/ilxd/types"
 	"github.com/project-illium/ilxd/types/transactions"
@@ -22,13 +22,18 @@ import (
 	"github.com/project-illium/ilxd/zk/circparams"
 	"github.com/project-illium/walletlib"
 	"github.com/pterm/pterm"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
-	mrand "math/rand"
+	"io"
+	"os"
+	"strconv"
 	"strings"
+	"time"
 )
 
 type GetBalance struct {
-	opts *options
+	Breakdown bool `short:"b" long:"breakdown" description:"Break the balance down into spendable, timelocked, staked, and watch-only funds"`
+	opts      *options
 }
 
 func (x *GetBalance) Execute(args []string) error {
@@ -36,11 +41,35 @@ func (x *GetBalance) Execute(args []string) error {
 	if err != nil {
 		return err
 	}
-	resp, err := client.GetBalance(makeContext(x.opts.AuthToken), &pb.GetBalanceRequest{})
+	resp, err := client.GetBalance(makeContext(x.opts.AuthToken), &pb.GetBalanceRequest{
+		Breakdown: x.Breakdown,
+	})
 	if err != nil {
 		return err
 	}
-	fmt.Println(types.Amount(resp.Balance).ToILX())
+	if !x.Breakdown {
+		fmt.Println(types.Amount(resp.Balance).ToILX())
+		return nil
+	}
+
+	s := struct {
+		Balance    types.Amount `json:"balance"`
+		Spendable  types.Amount `json:"spendable"`
+		Timelocked types.Amount `json:"timelocked"`
+		Staked     types.Amount `json:"staked"`
+		WatchOnly  types.Amount `json:"watchOnly"`
+	}{
+		Balance:    types.Amount(resp.Balance),
+		Spendable:  types.Amount(resp.Spendable),
+		Timelocked: types.Amount(resp.Timelocked),
+		Staked:     types.Amount(resp.Staked),
+		WatchOnly:  types.Amount(resp.WatchOnly),
+	}
+	out, err := json.MarshalIndent(&s, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
 	return nil
 }
 
@@ -135,8 +164,48 @@ func (x *GetAddresses) Execute(args []string) error {
 	return nil
 }
 
+type ConvertAddresses struct {
+	Addresses []string `short:"a" long:"addr" description:"An address to convert. Use this option more than once to convert more than one address. If omitted all the wallet's addresses are converted."`
+	Prefix    string   `short:"p" long:"prefix" description:"Optionally encode the addresses using this prefix instead of the network's current prefix"`
+	opts      *options
+}
+
+func (x *ConvertAddresses) Execute(args []string) error {
+	client, err := makeWalletClient(x.opts)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.ConvertAddresses(makeContext(x.opts.AuthToken), &pb.ConvertAddressesRequest{
+		Addresses:     x.Addresses,
+		AddressPrefix: x.Prefix,
+	})
+	if err != nil {
+		return err
+	}
+
+	type convertedAddress struct {
+		Original  string `json:"original"`
+		Converted string `json:"converted"`
+	}
+	addrs := make([]convertedAddress, 0, len(resp.Addresses))
+	for _, addr := range resp.Addresses {
+		addrs = append(addrs, convertedAddress{
+			Original:  addr.Original,
+			Converted: addr.Converted,
+		})
+	}
+	out, err := json.MarshalIndent(addrs, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
 type GetNewAddress struct {
-	opts *options
+	CustomerID string `short:"c" long:"customerid" description:"Optionally associate a customer identifier with the new address"`
+	opts       *options
 }
 
 func (x *GetNewAddress) Execute(args []string) error {
@@ -145,84 +214,768 @@ func (x *GetNewAddress) Execute(args []string) error {
 		return err
 	}
 
-	resp, err := client.GetNewAddress(makeContext(x.opts.AuthToken), &pb.GetNewAddressRequest{})
+	resp, err := client.GetNewAddress(makeContext(x.opts.AuthToken), &pb.GetNewAddressRequest{
+		Customer_ID: x.CustomerID,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Println(resp.Address)
+	return nil
+}
+
+type GetAddrInfo struct {
+	Address walletAddress `short:"a" long:"addr" description:"The address to get the info for"`
+	opts    *options
+}
+
+func (x *GetAddrInfo) Execute(args []string) error {
+	client, err := makeWalletClient(x.opts)
+	if err != nil {
+		return err
+	}
+	resp, err := client.GetAddressInfo(makeContext(x.opts.AuthToken), &pb.GetAddressInfoRequest{
+		Address: string(x.Address),
+	})
+	if err != nil {
+		return err
+	}
+
+	kp := newAddressInfo(resp)
+	out, err := json.MarshalIndent(&kp, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(out))
+	return nil
+}
+
+type GetAddrInfoBatch struct {
+	Addresses []string `short:"a" long:"addr" description:"An address to get the info for. Use this option more than once to get the info for more than one address."`
+	All       bool     `long:"all" description:"Get the info for all the wallet's addresses"`
+	opts      *options
+}
+
+func (x *GetAddrInfoBatch) Execute(args []string) error {
+	client, err := makeWalletClient(x.opts)
+	if err != nil {
+		return err
+	}
+	resp, err := client.GetAddressInfoBatch(makeContext(x.opts.AuthToken), &pb.GetAddressInfoBatchRequest{
+		Addresses: x.Addresses,
+		All:       x.All,
+	})
+	if err != nil {
+		return err
+	}
+
+	infos := make(map[string]addressInfo, len(resp.Infos))
+	for addr, info := range resp.Infos {
+		infos[addr] = newAddressInfo(info)
+	}
+	out, err := json.MarshalIndent(infos, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(out))
+	return nil
+}
+
+type addressInfo struct {
+	Addr           string             `json:"address"`
+	LockingScript  types.HexEncodable `json:"lockingScript"`
+	ViewPrivateKey types.HexEncodable `json:"viewPrivateKey"`
+	WatchOnly      bool               `json:"watchOnly"`
+	CustomerID     string             `json:"customerID,omitempty"`
+	Label          string             `json:"label,omitempty"`
+}
+
+func newAddressInfo(resp *pb.GetAddressInfoResponse) addressInfo {
+	return addressInfo{
+		Addr:           resp.Address,
+		LockingScript:  resp.LockingScript,
+		ViewPrivateKey: resp.ViewPrivateKey,
+		WatchOnly:      resp.WatchOnly,
+		CustomerID:     resp.Customer_ID,
+		Label:          resp.Label,
+	}
+}
+
+type SetCustomerID struct {
+	Address    walletAddress `short:"a" long:"addr" description:"The wallet address"`
+	CustomerID string        `short:"c" long:"customerid" description:"The customer identifier. If empty the existing association is removed."`
+	opts       *options
+}
+
+func (x *SetCustomerID) Execute(args []string) error {
+	client, err := makeWalletClient(x.opts)
+	if err != nil {
+		return err
+	}
+	_, err = client.SetCustomerID(makeContext(x.opts.AuthToken), &pb.SetCustomerIDRequest{
+		Address:     string(x.Address),
+		Customer_ID: x.CustomerID,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Println("success")
+	return nil
+}
+
+type SetAddressLabel struct {
+	Address walletAddress `short:"a" long:"addr" description:"The wallet address"`
+	Label   string        `short:"l" long:"label" description:"The label. If empty the existing label is removed."`
+	opts    *options
+}
+
+func (x *SetAddressLabel) Execute(args []string) error {
+	client, err := makeWalletClient(x.opts)
+	if err != nil {
+		return err
+	}
+	_, err = client.SetAddressLabel(makeContext(x.opts.AuthToken), &pb.SetAddressLabelRequest{
+		Address: string(x.Address),
+		Label:   x.Label,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Println("success")
+	return nil
+}
+
+type GetAddressesByLabel struct {
+	Label string `short:"l" long:"label" description:"The label to look up"`
+	opts  *options
+}
+
+func (x *GetAddressesByLabel) Execute(args []string) error {
+	client, err := makeWalletClient(x.opts)
+	if err != nil {
+		return err
+	}
+	resp, err := client.GetAddressesByLabel(makeContext(x.opts.AuthToken), &pb.GetAddressesByLabelRequest{
+		Label: x.Label,
+	})
+	if err != nil {
+		return err
+	}
+	addrs := resp.Addresses
+	if addrs == nil {
+		addrs = []string{}
+	}
+	out, err := json.MarshalIndent(addrs, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+type GetTransactions struct {
+	opts *options
+}
+
+func (x *GetTransactions) Execute(args []string) error {
+	client, err := makeWalletClient(x.opts)
+	if err != nil {
+		return err
+	}
+	resp, err := client.GetTransactions(makeContext(x.opts.AuthToken), &pb.GetTransactionsRequest{})
+	if err != nil {
+		return err
+	}
+	type tx struct {
+		Txid     types.HexEncodable `json:"txid"`
+		NetCoins float64            `json:"netCoins"`
+		Inputs   []interface{}      `json:"inputs"`
+		Outputs  []interface{}      `json:"outputs"`
+	}
+	txs := make([]tx, 0, len(resp.Txs))
+	for _, rtx := range resp.Txs {
+		amt := types.Amount(rtx.NetCoins).ToILX()
+		if rtx.NetCoins < 0 {
+			amt = types.Amount(rtx.NetCoins*-1).ToILX() * -1
+		}
+		txs = append(txs, tx{
+			Txid:     rtx.Transaction_ID,
+			NetCoins: amt,
+			Inputs:   pbIOtoIO(rtx.Inputs),
+			Outputs:  pbIOtoIO(rtx.Outputs),
+		})
+	}
+	out, err := json.MarshalIndent(txs, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+type NotifyTransactions struct {
+	Confirmations uint32 `short:"c" long:"confirmations" description:"Print a confirmation event for each new block until the transaction has this many confirmations. The block containing the transaction is the first confirmation."`
+	opts          *options
+}
+
+func (x *NotifyTransactions) Execute(args []string) error {
+	client, err := makeWalletClient(x.opts)
+	if err != nil {
+		return err
+	}
+	stream, err := client.SubscribeTransactionEvents(makeContext(x.opts.AuthToken), &pb.SubscribeTransactionEventsRequest{
+		Confirmations: x.Confirmations,
+	})
+	if err != nil {
+		return err
+	}
+	type event struct {
+		Type          string             `json:"type"`
+		Txid          types.HexEncodable `json:"txid"`
+		NetCoins      float64            `json:"netCoins"`
+		Inputs        []interface{}      `json:"inputs"`
+		Outputs       []interface{}      `json:"outputs"`
+		BlockID       types.HexEncodable `json:"blockID"`
+		BlockHeight   uint32             `json:"blockHeight"`
+		Confirmations uint32             `json:"confirmations"`
+	}
+	type stakeEvent struct {
+		Type        string             `json:"type"`
+		Address     string             `json:"address"`
+		Commitment  types.HexEncodable `json:"commitment"`
+		Amount      types.Amount       `json:"amount"`
+		Label       string             `json:"label,omitempty"`
+		BlockID     types.HexEncodable `json:"blockID"`
+		BlockHeight uint32             `json:"blockHeight"`
+	}
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if resp.Utxo != nil {
+			out, err := json.MarshalIndent(stakeEvent{
+				Type:        strings.ToLower(resp.Type.String()),
+				Address:     resp.Utxo.Address,
+				Commitment:  resp.Utxo.Commitment,
+				Amount:      types.Amount(resp.Utxo.Amount),
+				Label:       resp.Utxo.Label,
+				BlockID:     resp.Block_ID,
+				BlockHeight: resp.BlockHeight,
+			}, "", "    ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(out))
+			continue
+		}
+		amt := types.Amount(resp.Transaction.NetCoins).ToILX()
+		if resp.Transaction.NetCoins < 0 {
+			amt = types.Amount(resp.Transaction.NetCoins*-1).ToILX() * -1
+		}
+		out, err := json.MarshalIndent(event{
+			Type:          strings.ToLower(resp.Type.String()),
+			Txid:          resp.Transaction.Transaction_ID,
+			NetCoins:      amt,
+			Inputs:        pbIOtoIO(resp.Transaction.Inputs),
+			Outputs:       pbIOtoIO(resp.Transaction.Outputs),
+			BlockID:       resp.Block_ID,
+			BlockHeight:   resp.BlockHeight,
+			Confirmations: resp.Confirmations,
+		}, "", "    ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+	}
+}
+
+type GetUtxos struct {
+	opts *options
+}
+
+func (x *GetUtxos) Execute(args []string) error {
+	client, err := makeWalletClient(x.opts)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.GetUtxos(makeContext(x.opts.AuthToken), &pb.GetUtxosRequest{})
+	if err != nil {
+		return err
+	}
+	type utxo struct {
+		Address     string             `json:"address"`
+		Commitment  types.HexEncodable `json:"commitment"`
+		Amount      types.Amount       `json:"amount"`
+		WatchOnly   bool               `json:"watchOnly"`
+		Staked      bool               `json:"staked"`
+		LockedUntil int64              `json:"lockedUntil"`
+		Label       string             `json:"label,omitempty"`
+	}
+	utxos := make([]utxo, 0, len(resp.Utxos))
+	for _, ut := range resp.Utxos {
+		utxos = append(utxos, utxo{
+			Address:     ut.Address,
+			Commitment:  ut.Commitment,
+			Amount:      types.Amount(ut.Amount),
+			WatchOnly:   ut.WatchOnly,
+			Staked:      ut.Staked,
+			LockedUntil: ut.LockedUntill,
+			Label:       ut.Label,
+		})
+	}
+	out, err := json.MarshalIndent(utxos, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+type GetPrivateKey struct {
+	Address walletAddress `short:"a" long:"addr" description:"The address to get the private key for"`
+	opts    *options
+}
+
+func (x *GetPrivateKey) Execute(args []string) error {
+	client, err := makeWalletClient(x.opts)
+	if err != nil {
+		return err
+	}
+	resp, err := client.GetPrivateKey(makeContext(x.opts.AuthToken), &pb.GetPrivateKeyRequest{
+		Address: string(x.Address),
+	})
+	if err != nil {
+		return err
+	}
+
+	key, err := crypto.UnmarshalPrivateKey(resp.SerializedKeys)
+	if err != nil {
+		return err
+	}
+	walletKey, ok := key.(*walletlib.WalletPrivateKey)
+	if !ok {
+		return errors.New("error decoding key")
+	}
+
+	fmt.Println(walletlib.EncodePrivateKey(walletKey))
+	return nil
+}
+
+type GetPrivateKeyBatch struct {
+	Addresses []string `short:"a" long:"addr" description:"An address to get the private key for. Use this option more than once to get the keys for more than one address."`
+	All       bool     `long:"all" description:"Get the private keys for all the wallet's addresses"`
+	opts      *options
+}
+
+func (x *GetPrivateKeyBatch) Execute(args []string) error {
+	client, err := makeWalletClient(x.opts)
+	if err != nil {
+		return err
+	}
+	resp, err := client.GetPrivateKeyBatch(makeContext(x.opts.AuthToken), &pb.GetPrivateKeyBatchRequest{
+		Addresses: x.Addresses,
+		All:       x.All,
+	})
+	if err != nil {
+		return err
+	}
+
+	keys := make(map[string]string, len(resp.SerializedKeys))
+	for addr, ser := range resp.SerializedKeys {
+		key, err := crypto.UnmarshalPrivateKey(ser)
+		if err != nil {
+			return err
+		}
+		walletKey, ok := key.(*walletlib.WalletPrivateKey)
+		if !ok {
+			return errors.New("error decoding key")
+		}
+		keys[addr] = walletlib.EncodePrivateKey(walletKey)
+	}
+	out, err := json.MarshalIndent(keys, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(out))
+	return nil
+}
+
+type ProveReserves struct {
+	Amount  string `short:"t" long:"amount" description:"The minimum total to prove. If zero all eligible notes will be included."`
+	Message string `short:"m" long:"message" description:"The challenge message chosen by the verifier"`
+	opts    *options
+}
+
+func (x *ProveReserves) Execute(args []string) error {
+	client, err := makeWalletClient(x.opts)
+	if err != nil {
+		return err
+	}
+	amt, err := types.AmountFromILX(x.Amount)
+	if err != nil {
+		return err
+	}
+	resp, err := client.ProveReserves(makeContext(x.opts.AuthToken), &pb.ProveReservesRequest{
+		Amount:  uint64(amt),
+		Message: x.Message,
+	})
+	if err != nil {
+		return err
+	}
+
+	m := protojson.MarshalOptions{
+		Indent: "    ",
+	}
+	out, err := m.Marshal(resp.Proof)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+type ProvePayment struct {
+	Txid          string `short:"i" long:"id" description:"The ID of the transaction containing the payment"`
+	OutputIndex   uint32 `short:"x" long:"index" description:"The index of the payment output in the transaction"`
+	Address       string `short:"a" long:"addr" description:"The address that was paid. Only needed if the address does not belong to the wallet."`
+	PrivateOutput string `short:"o" long:"output" description:"The private output data as a JSON string, as returned by createrawtransaction. Only needed if the wallet cannot decrypt the output, for example when proving a payment you sent."`
+	opts          *options
+}
+
+func (x *ProvePayment) Execute(args []string) error {
+	txid, err := hex.DecodeString(x.Txid)
+	if err != nil {
+		return err
+	}
+	req := &pb.ProvePaymentRequest{
+		Transaction_ID: txid,
+		OutputIndex:    x.OutputIndex,
+		Address:        x.Address,
+	}
+	if x.PrivateOutput != "" {
+		var output pb.PrivateOutput
+		if err := json.Unmarshal([]byte(x.PrivateOutput), &output); err != nil {
+			return err
+		}
+		req.PrivateOutput = &output
+	}
+
+	client, err := makeWalletClient(x.opts)
+	if err != nil {
+		return err
+	}
+	resp, err := client.ProvePayment(makeContext(x.opts.AuthToken), req)
+	if err != nil {
+		return err
+	}
+
+	m := protojson.MarshalOptions{
+		Indent: "    ",
+	}
+	out, err := m.Marshal(resp.Proof)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+type RequestInvoice struct {
+	Address string `short:"a" long:"addr" description:"The payee's address"`
+	PeerID  string `short:"p" long:"peer" description:"Optional. The peer ID of the payee's node. If this is not provided the network will be searched for it."`
+	Amount  string `short:"t" long:"amount" description:"The amount to pay"`
+	Memo    string `short:"m" long:"memo" description:"An optional memo describing the payment"`
+	opts    *options
+}
+
+func (x *RequestInvoice) Execute(args []string) error {
+	client, err := makeWalletClient(x.opts)
+	if err != nil {
+		return err
+	}
+	amt, err := types.AmountFromILX(x.Amount)
+	if err != nil {
+		return err
+	}
+	resp, err := client.RequestInvoice(makeContext(x.opts.AuthToken), &pb.RequestInvoiceRequest{
+		PayeeAddress: x.Address,
+		Peer_ID:      x.PeerID,
+		Amount:       uint64(amt),
+		Memo:         x.Memo,
+	})
+	if err != nil {
+		return err
+	}
+
+	s := struct {
+		Address string       `json:"address"`
+		Amount  types.Amount `json:"amount"`
+		Memo    string       `json:"memo"`
+		Expires time.Time    `json:"expires"`
+	}{
+		Address: resp.Address,
+		Amount:  types.Amount(resp.Amount),
+		Memo:    resp.Memo,
+		Expires: time.Unix(resp.Expires, 0),
+	}
+	out, err := json.MarshalIndent(&s, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+type CreateInvoice struct {
+	Amount     string `short:"t" long:"amount" description:"The amount to pay"`
+	Memo       string `short:"m" long:"memo" description:"An optional memo describing the payment"`
+	Expiry     int64  `short:"e" long:"expiry" description:"Optional. The number of seconds the invoice may go unpaid before it expires. The default is one hour."`
+	WebhookURL string `short:"w" long:"webhook" description:"Optional. An http(s) URL to POST the invoice to each time its status changes"`
+	opts       *options
+}
+
+func (x *CreateInvoice) Execute(args []string) error {
+	client, err := makeWalletClient(x.opts)
+	if err != nil {
+		return err
+	}
+	amt, err := types.AmountFromILX(x.Amount)
+	if err != nil {
+		return err
+	}
+	resp, err := client.CreateInvoice(makeContext(x.opts.AuthToken), &pb.CreateInvoiceRequest{
+		Amount:      uint64(amt),
+		Memo:        x.Memo,
+		Expiry:      x.Expiry,
+		Webhook_URL: x.WebhookURL,
+	})
+	if err != nil {
+		return err
+	}
+	return printInvoice(resp.Invoice)
+}
+
+type GetInvoiceStatus struct {
+	InvoiceID string `short:"i" long:"id" description:"The invoice ID"`
+	opts      *options
+}
+
+func (x *GetInvoiceStatus) Execute(args []string) error {
+	client, err := makeWalletClient(x.opts)
+	if err != nil {
+		return err
+	}
+	id, err := hex.DecodeString(x.InvoiceID)
+	if err != nil {
+		return err
+	}
+	resp, err := client.GetInvoiceStatus(makeContext(x.opts.AuthToken), &pb.GetInvoiceStatusRequest{
+		Invoice_ID: id,
+	})
+	if err != nil {
+		return err
+	}
+	return printInvoice(resp.Invoice)
+}
+
+func printInvoice(inv *pb.Invoice) error {
+	type payment struct {
+		Txid    types.HexEncodable `json:"txid"`
+		Amount  types.Amount       `json:"amount"`
+		Status  string             `json:"status"`
+		BlockID types.HexEncodable `json:"blockID,omitempty"`
+		Height  uint32             `json:"height,omitempty"`
+	}
+	s := struct {
+		InvoiceID  types.HexEncodable `json:"invoiceID"`
+		Address    string             `json:"address"`
+		Amount     types.Amount       `json:"amount"`
+		AmountPaid types.Amount       `json:"amountPaid"`
+		Memo       string             `json:"memo"`
+		Created    time.Time          `json:"created"`
+		Expires    time.Time          `json:"expires"`
+		Status     string             `json:"status"`
+		Payments   []payment          `json:"payments"`
+		WebhookURL string             `json:"webhookURL,omitempty"`
+		PaymentURI string             `json:"paymentURI,omitempty"`
+	}{
+		InvoiceID:  inv.Invoice_ID,
+		Address:    inv.Address,
+		Amount:     types.Amount(inv.Amount),
+		AmountPaid: types.Amount(inv.AmountPaid),
+		Memo:       inv.Memo,
+		Created:    time.Unix(inv.Created, 0),
+		Expires:    time.Unix(inv.Expires, 0),
+		Status:     inv.Status.String(),
+		Payments:   make([]payment, 0, len(inv.Payments)),
+		WebhookURL: inv.Webhook_URL,
+		PaymentURI: inv.Payment_URI,
+	}
+	for _, p := range inv.Payments {
+		s.Payments = append(s.Payments, payment{
+			Txid:    p.Transaction_ID,
+			Amount:  types.Amount(p.Amount),
+			Status:  p.Status.String(),
+			BlockID: p.Block_ID,
+			Height:  p.Height,
+		})
+	}
+	out, err := json.MarshalIndent(&s, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+type ImportAddress struct {
+	Address          string `short:"a" long:"addr" description:"The address to import"`
+	LockingScript    string `short:"l" long:"lockingscript" description:"The locking script for the address. Serialized as hex string"`
+	ViewPrivateKey   string `short:"k" long:"viewkey" description:"The view private key for the address. Serialized as hex string."`
+	Rescan           bool   `short:"r" long:"rescan" description:"Whether or not to rescan the blockchain to try to detect transactions for this address."`
+	RescanFromHeight uint32 `short:"t" long:"rescanheight" description:"The height of the chain to rescan from. Selecting a height close to the address birthday saves resources."`
+	opts             *options
+}
+
+func (x *ImportAddress) Execute(args []string) error {
+	client, err := makeWalletClient(x.opts)
+	if err != nil {
+		return err
+	}
+
+	lockingScriptBytes, err := hex.DecodeString(x.LockingScript)
+	if err != nil {
+		return err
+	}
+	privKeyBytes, err := hex.DecodeString(x.ViewPrivateKey)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.ImportAddress(makeContext(x.opts.AuthToken), &pb.ImportAddressRequest{
+		Address:          x.Address,
+		LockingScript:    lockingScriptBytes,
+		ViewPrivateKey:   privKeyBytes,
+		Rescan:           x.Rescan,
+		RescanFromHeight: x.RescanFromHeight,
+	})
 	if err != nil {
 		return err
 	}
-	fmt.Println(resp.Address)
+
+	fmt.Println("success")
 	return nil
 }
 
-type GetAddrInfo struct {
-	Address string `short:"a" long:"addr" description:"The address to get the info for"`
-	opts    *options
+// watchAddress is the JSON format of the file written by exportwatchonly
+// and read by importaddresses.
+type watchAddress struct {
+	Address       string `json:"address"`
+	LockingScript string `json:"lockingScript"`
+	ViewKey       string `json:"viewKey"`
 }
 
-func (x *GetAddrInfo) Execute(args []string) error {
+type ImportAddresses struct {
+	File             string `short:"f" long:"file" description:"A JSON file containing a list of addresses to import. Each entry has an address, lockingScript and viewKey with the script and key serialized as hex strings."`
+	Rescan           bool   `short:"r" long:"rescan" description:"Whether or not to rescan the blockchain to try to detect transactions for these addresses. Only one rescan is run for the whole batch."`
+	RescanFromHeight uint32 `short:"t" long:"rescanheight" description:"The height of the chain to rescan from. This should be the lowest birthday of the addresses."`
+	opts             *options
+}
+
+func (x *ImportAddresses) Execute(args []string) error {
+	if x.File == "" {
+		return errors.New("file is required")
+	}
 	client, err := makeWalletClient(x.opts)
 	if err != nil {
 		return err
 	}
-	resp, err := client.GetAddressInfo(makeContext(x.opts.AuthToken), &pb.GetAddressInfoRequest{
-		Address: x.Address,
-	})
+
+	b, err := os.ReadFile(repo.CleanAndExpandPath(x.File))
 	if err != nil {
 		return err
 	}
+	var entries []watchAddress
+	if err := json.Unmarshal(b, &entries); err != nil {
+		return err
+	}
 
-	kp := struct {
-		Addr           string             `json:"address"`
-		LockingScript  types.HexEncodable `json:"lockingScript"`
-		ViewPrivateKey types.HexEncodable `json:"viewPrivateKey"`
-		WatchOnly      bool               `json:"watchOnly"`
-	}{
-		Addr:           resp.Address,
-		LockingScript:  resp.LockingScript,
-		ViewPrivateKey: resp.ViewPrivateKey,
-		WatchOnly:      resp.WatchOnly,
+	req := &pb.ImportAddressesRequest{
+		Addresses:        make([]*pb.ImportAddressesRequest_Address, 0, len(entries)),
+		Rescan:           x.Rescan,
+		RescanFromHeight: x.RescanFromHeight,
 	}
-	out, err := json.MarshalIndent(&kp, "", "    ")
+	for _, e := range entries {
+		lockingScriptBytes, err := hex.DecodeString(e.LockingScript)
+		if err != nil {
+			return err
+		}
+		privKeyBytes, err := hex.DecodeString(e.ViewKey)
+		if err != nil {
+			return err
+		}
+		req.Addresses = append(req.Addresses, &pb.ImportAddressesRequest_Address{
+			Address:        e.Address,
+			LockingScript:  lockingScriptBytes,
+			ViewPrivateKey: privKeyBytes,
+		})
+	}
+
+	_, err = client.ImportAddresses(makeContext(x.opts.AuthToken), req)
 	if err != nil {
 		return err
 	}
 
-	fmt.Println(string(out))
+	fmt.Println("success")
 	return nil
 }
 
-type GetTransactions struct {
+type GetRescanStatus struct {
 	opts *options
 }
 
-func (x *GetTransactions) Execute(args []string) error {
+func (x *GetRescanStatus) Execute(args []string) error {
 	client, err := makeWalletClient(x.opts)
 	if err != nil {
 		return err
 	}
-	resp, err := client.GetTransactions(makeContext(x.opts.AuthToken), &pb.GetTransactionsRequest{})
+	resp, err := client.GetRescanStatus(makeContext(x.opts.AuthToken), &pb.GetRescanStatusRequest{})
 	if err != nil {
 		return err
 	}
-	type tx struct {
-		Txid     types.HexEncodable `json:"txid"`
-		NetCoins float64            `json:"netCoins"`
-		Inputs   []interface{}      `json:"inputs"`
-		Outputs  []interface{}      `json:"outputs"`
+	s := struct {
+		Rescanning    bool       `json:"rescanning"`
+		StartHeight   uint32     `json:"startHeight"`
+		ScannedHeight uint32     `json:"scannedHeight"`
+		BestHeight    uint32     `json:"bestHeight"`
+		Started       *time.Time `json:"started,omitempty"`
+		Finished      *time.Time `json:"finished,omitempty"`
+		Remaining     string     `json:"remaining,omitempty"`
+	}{
+		Rescanning:    resp.Rescanning,
+		StartHeight:   resp.StartHeight,
+		ScannedHeight: resp.ScannedHeight,
+		BestHeight:    resp.BestHeight,
 	}
-	txs := make([]tx, 0, len(resp.Txs))
-	for _, rtx := range resp.Txs {
-		amt := types.Amount(rtx.NetCoins).ToILX()
-		if rtx.NetCoins < 0 {
-			amt = types.Amount(rtx.NetCoins*-1).ToILX() * -1
-		}
-		txs = append(txs, tx{
-			Txid:     rtx.Transaction_ID,
-			NetCoins: amt,
-			Inputs:   pbIOtoIO(rtx.Inputs),
-			Outputs:  pbIOtoIO(rtx.Outputs),
-		})
+	if resp.Started != 0 {
+		started := time.Unix(resp.Started, 0)
+		s.Started = &started
 	}
-	out, err := json.MarshalIndent(txs, "", "    ")
+	if resp.Finished != 0 {
+		finished := time.Unix(resp.Finished, 0)
+		s.Finished = &finished
+	}
+	if resp.RemainingSeconds > 0 {
+		s.Remaining = (time.Duration(resp.RemainingSeconds) * time.Second).String()
+	}
+	out, err := json.MarshalIndent(s, "", "    ")
 	if err != nil {
 		return err
 	}
@@ -230,113 +983,113 @@ func (x *GetTransactions) Execute(args []string) error {
 	return nil
 }
 
-type GetUtxos struct {
+type ExportWatchOnly struct {
+	File string `short:"f" long:"file" description:"The file to write the addresses to. If not set they are printed."`
 	opts *options
 }
 
-func (x *GetUtxos) Execute(args []string) error {
+func (x *ExportWatchOnly) Execute(args []string) error {
 	client, err := makeWalletClient(x.opts)
 	if err != nil {
 		return err
 	}
 
-	resp, err := client.GetUtxos(makeContext(x.opts.AuthToken), &pb.GetUtxosRequest{})
+	resp, err := client.ExportWatchOnly(makeContext(x.opts.AuthToken), &pb.ExportWatchOnlyRequest{})
 	if err != nil {
 		return err
 	}
-	type utxo struct {
-		Address     string             `json:"address"`
-		Commitment  types.HexEncodable `json:"commitment"`
-		Amount      types.Amount       `json:"amount"`
-		WatchOnly   bool               `json:"watchOnly"`
-		Staked      bool               `json:"staked"`
-		LockedUntil int64              `json:"lockedUntil"`
-	}
-	utxos := make([]utxo, 0, len(resp.Utxos))
-	for _, ut := range resp.Utxos {
-		utxos = append(utxos, utxo{
-			Address:     ut.Address,
-			Commitment:  ut.Commitment,
-			Amount:      types.Amount(ut.Amount),
-			WatchOnly:   ut.WatchOnly,
-			Staked:      ut.Staked,
-			LockedUntil: ut.LockedUntill,
+
+	entries := make([]watchAddress, 0, len(resp.Addresses))
+	for _, a := range resp.Addresses {
+		entries = append(entries, watchAddress{
+			Address:       a.Address,
+			LockingScript: hex.EncodeToString(a.LockingScript),
+			ViewKey:       hex.EncodeToString(a.ViewPrivateKey),
 		})
 	}
-	out, err := json.MarshalIndent(utxos, "", "    ")
+	out, err := json.MarshalIndent(entries, "", "    ")
 	if err != nil {
 		return err
 	}
-	fmt.Println(string(out))
-	return nil
+	if x.File == "" {
+		fmt.Println(string(out))
+		return nil
+	}
+	// The view keys reveal the wallet's transaction history.
+	return os.WriteFile(repo.CleanAndExpandPath(x.File), out, 0600)
 }
 
-type GetPrivateKey struct {
-	Address string `short:"a" long:"addr" description:"The address to get the private key for"`
-	opts    *options
+type ExportDescriptor struct {
+	BirthdayHeight uint32 `short:"b" long:"birthday" description:"The height to start rescanning from when the descriptor is imported. If zero the importing node rescans the entire chain."`
+	File           string `short:"f" long:"file" description:"The file to write the descriptor to. If not set it is printed."`
+	opts           *options
 }
 
-func (x *GetPrivateKey) Execute(args []string) error {
+func (x *ExportDescriptor) Execute(args []string) error {
 	client, err := makeWalletClient(x.opts)
 	if err != nil {
 		return err
 	}
-	resp, err := client.GetPrivateKey(makeContext(x.opts.AuthToken), &pb.GetPrivateKeyRequest{
-		Address: x.Address,
-	})
-	if err != nil {
-		return err
-	}
 
-	key, err := crypto.UnmarshalPrivateKey(resp.SerializedKeys)
+	resp, err := client.ExportDescriptor(makeContext(x.opts.AuthToken), &pb.ExportDescriptorRequest{
+		BirthdayHeight: x.BirthdayHeight,
+	})
 	if err != nil {
 		return err
 	}
-	walletKey, ok := key.(*walletlib.WalletPrivateKey)
-	if !ok {
-		return errors.New("error decoding key")
+	if x.File == "" {
+		fmt.Println(resp.Descriptor_)
+		return nil
 	}
-
-	fmt.Println(walletlib.EncodePrivateKey(walletKey))
-	return nil
+	// The view keys reveal the wallet's transaction history.
+	return os.WriteFile(repo.CleanAndExpandPath(x.File), []byte(resp.Descriptor_+"\n"), 0600)
 }
 
-type ImportAddress struct {
-	Address          string `short:"a" long:"addr" description:"The address to import"`
-	LockingScript    string `short:"l" long:"lockingscript" description:"The locking script for the address. Serialized as hex string"`
-	ViewPrivateKey   string `short:"k" long:"viewkey" description:"The view private key for the address. Serialized as hex string."`
-	Rescan           bool   `short:"r" long:"rescan" description:"Whether or not to rescan the blockchain to try to detect transactions for this address."`
-	RescanFromHeight uint32 `short:"t" long:"rescanheight" description:"The height of the chain to rescan from. Selecting a height close to the address birthday saves resources."`
-	opts             *options
+type ImportDescriptor struct {
+	Descriptor string `short:"d" long:"descriptor" description:"The account descriptor to import. Use this or file."`
+	File       string `short:"f" long:"file" description:"A file containing the account descriptor to import. Use this or descriptor."`
+	Rescan     bool   `short:"r" long:"rescan" description:"Whether or not to rescan the blockchain from the descriptor's birthday to try to detect the account's transactions."`
+	opts       *options
 }
 
-func (x *ImportAddress) Execute(args []string) error {
+func (x *ImportDescriptor) Execute(args []string) error {
+	descriptor := x.Descriptor
+	if x.File != "" {
+		b, err := os.ReadFile(repo.CleanAndExpandPath(x.File))
+		if err != nil {
+			return err
+		}
+		descriptor = strings.TrimSpace(string(b))
+	}
+	if descriptor == "" {
+		return errors.New("descriptor or file is required")
+	}
 	client, err := makeWalletClient(x.opts)
 	if err != nil {
 		return err
 	}
 
-	lockingScriptBytes, err := hex.DecodeString(x.LockingScript)
-	if err != nil {
-		return err
-	}
-	privKeyBytes, err := hex.DecodeString(x.ViewPrivateKey)
+	resp, err := client.ImportDescriptor(makeContext(x.opts.AuthToken), &pb.ImportDescriptorRequest{
+		Descriptor_: descriptor,
+		Rescan:      x.Rescan,
+	})
 	if err != nil {
 		return err
 	}
 
-	_, err = client.ImportAddress(makeContext(x.opts.AuthToken), &pb.ImportAddressRequest{
-		Address:          x.Address,
-		LockingScript:    lockingScriptBytes,
-		ViewPrivateKey:   privKeyBytes,
-		Rescan:           x.Rescan,
-		RescanFromHeight: x.RescanFromHeight,
-	})
+	out, err := json.MarshalIndent(struct {
+		NumAddresses   uint32 `json:"numAddresses"`
+		ChangeAddress  string `json:"changeAddress"`
+		BirthdayHeight uint32 `json:"birthdayHeight"`
+	}{
+		NumAddresses:   resp.NumAddresses,
+		ChangeAddress:  resp.ChangeAddress,
+		BirthdayHeight: resp.BirthdayHeight,
+	}, "", "    ")
 	if err != nil {
 		return err
 	}
-
-	fmt.Println("success")
+	fmt.Println(string(out))
 	return nil
 }
 
@@ -412,7 +1165,6 @@ type CreateMultisigAddress struct {
 	ViewPubKey string   `short:"k" long:"viewpubkey" description:"The view public key for the address. Serialized as hex string."`
 	Pubkeys    []string `short:"p" long:"pubkey" description:"One or more public keys to use with the address. Serialized as a hex string. Use this option more than once for more than one key."`
 	Threshold  uint32   `short:"t" long:"threshold" description:"The number of keys needing to sign to the spend from this address."`
-	Net        string   `short:"n" long:"net" description:"Which network the address is for: [mainnet, testnet, regtest] Default: mainnet"`
 	opts       *options
 }
 
@@ -460,18 +1212,9 @@ func (x *CreateMultisigAddress) Execute(args []string) error {
 	}
 	lockingScript.LockingParams = append(lockingScript.LockingParams, pubkeys...)
 
-	var chainParams *params.NetworkParams
-	switch strings.ToLower(x.Net) {
-	case "mainnet", "":
-		chainParams = &params.MainnetParams
-	case "testnet":
-		chainParams = &params.Testnet1Params
-	case "regtest":
-		chainParams = &params.RegestParams
-	case "alphanet":
-		chainParams = &params.AlphanetParams
-	default:
-		return errors.New("invalid net")
+	chainParams, err := networkParams(x.opts)
+	if err != nil {
+		return err
 	}
 
 	addr, err := walletlib.NewBasicAddress(lockingScript, viewKey, chainParams)
@@ -498,21 +1241,16 @@ func (x *CreateMultisigAddress) Execute(args []string) error {
 type CreateMultiSignature struct {
 	Tx         string `short:"t" long:"tx" description:"A transaction to sign (either Transaction or RawTransaction). Serialized as hex string. Use this or sighash."`
 	SigHash    string `short:"h" long:"sighash" description:"A sighash to sign. Serialized as hex string. Use this or tx."`
-	PrivateKey string `short:"k" long:"privkey" description:"A spend private key. Serialized as hex string."`
+	PrivateKey string `short:"k" long:"privkey" description:"A spend private key. Serialized as hex string. If not set the signer command is used."`
 	opts       *options
 }
 
 func (x *CreateMultiSignature) Execute(args []string) error {
-	privKeyBytes, err := hex.DecodeString(x.PrivateKey)
-	if err != nil {
-		return err
-	}
-	privKey, err := crypto.UnmarshalPrivateKey(privKeyBytes)
-	if err != nil {
-		return err
-	}
-
-	var sigHash []byte
+	var (
+		sigHash    []byte
+		candidates []crypto.PubKey
+		err        error
+	)
 	if x.Tx != "" {
 		txBytes, err := hex.DecodeString(x.Tx)
 		if err != nil {
@@ -525,6 +1263,7 @@ func (x *CreateMultiSignature) Execute(args []string) error {
 				return err
 			}
 			tx = raw.Tx
+			candidates = inputPublicKeys(&raw)
 		}
 		if tx.GetStandardTransaction() != nil {
 			sigHash, err = tx.GetStandardTransaction().SigHash()
@@ -552,6 +1291,10 @@ func (x *CreateMultiSignature) Execute(args []string) error {
 		return errors.New("tx or sighash required")
 	}
 
+	privKey, err := signingKey(x.opts, x.PrivateKey, candidates)
+	if err != nil {
+		return err
+	}
 	sig, err := privKey.Sign(sigHash)
 	if err != nil {
 		return err
@@ -673,13 +1416,14 @@ func (x *ProveMultisig) Execute(args []string) error {
 		prover = &zk.MockProver{}
 	}
 
-	spinner, err := pterm.DefaultSpinner.Start(provingPhrases[mrand.Intn(len(provingPhrases))])
+	spinner, err := pterm.DefaultSpinner.Start(provingPhrase())
 	if err != nil {
 		return err
 	}
+	prover = &spinnerProver{Prover: prover, spinner: spinner}
 	proof, err := prover.Prove(zk.StandardValidationProgram(), privateParams, publicParams)
 	if err != nil {
-		spinner.Fail(fmt.Sprintf("Error proving transaction: %s", err.Error()))
+		spinner.Fail(trf("Error proving transaction: %s", err.Error()))
 		return nil
 	}
 
@@ -689,14 +1433,14 @@ func (x *ProveMultisig) Execute(args []string) error {
 	if x.Serialize {
 		ser, err := proto.Marshal(tx)
 		if err != nil {
-			spinner.Fail(fmt.Sprintf("Error serializing transaction: %s", err.Error()))
+			spinner.Fail(trf("Error serializing transaction: %s", err.Error()))
 			return nil
 		}
 		spinner.Success(hex.EncodeToString(ser))
 	} else {
 		out, err := json.MarshalIndent(tx, "", "    ")
 		if err != nil {
-			spinner.Fail(fmt.Sprintf("Error serializing transaction: %s", err.Error()))
+			spinner.Fail(trf("Error serializing transaction: %s", err.Error()))
 			return nil
 		}
 		spinner.Success(string(out))
@@ -747,6 +1491,48 @@ func (x *WalletUnlock) Execute(args []string) error {
 	return nil
 }
 
+type WalletViewUnlock struct {
+	Passphrase string `short:"p" long:"passphrase" description:"The wallet's view passphrase"`
+	Duration   uint32 `short:"d" long:"duration" description:"The number of seconds to unlock the wallet for"`
+	opts       *options
+}
+
+func (x *WalletViewUnlock) Execute(args []string) error {
+	client, err := makeWalletClient(x.opts)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.WalletViewUnlock(makeContext(x.opts.AuthToken), &pb.WalletViewUnlockRequest{
+		Passphrase: x.Passphrase,
+		Duration:   x.Duration,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("success")
+	return nil
+}
+
+type CreateWalletUnlockFile struct {
+	Passphrase string `short:"p" long:"passphrase" description:"The wallet passphrase"`
+	KeyCmd     string `short:"k" long:"keycmd" description:"A command which prints the key to encrypt the passphrase with, such as a lookup in the OS keyring. The node must be configured with the same walletunlockkeycmd."`
+	File       string `short:"f" long:"file" description:"The file to write the encrypted passphrase to"`
+	opts       *options
+}
+
+func (x *CreateWalletUnlockFile) Execute(args []string) error {
+	if x.File == "" {
+		return errors.New("file is required")
+	}
+	if err := repo.CreateWalletUnlockFile(x.File, x.Passphrase, x.KeyCmd); err != nil {
+		return err
+	}
+	fmt.Println("success")
+	return nil
+}
+
 type SetWalletPassphrase struct {
 	Passphrase string `short:"p" long:"passphrase" description:"The passphrase to set"`
 	opts       *options
@@ -769,6 +1555,30 @@ func (x *SetWalletPassphrase) Execute(args []string) error {
 	return nil
 }
 
+type SetWalletViewPassphrase struct {
+	Passphrase string `short:"p" long:"passphrase" description:"The view passphrase to set. May be empty to permit the view methods without unlocking."`
+	Remove     bool   `long:"remove" description:"Remove the view passphrase"`
+	opts       *options
+}
+
+func (x *SetWalletViewPassphrase) Execute(args []string) error {
+	client, err := makeWalletClient(x.opts)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.SetWalletViewPassphrase(makeContext(x.opts.AuthToken), &pb.SetWalletViewPassphraseRequest{
+		ViewPassphrase: x.Passphrase,
+		Remove:         x.Remove,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("success")
+	return nil
+}
+
 type ChangeWalletPassphrase struct {
 	Passphrase    string `short:"p" long:"passphrase" description:"The wallet's current passphrase"`
 	NewPassphrase string `short:"n" long:"newpassphrase" description:"The passphrase to change it to"`
@@ -794,31 +1604,118 @@ func (x *ChangeWalletPassphrase) Execute(args []string) error {
 }
 
 type DeletePrivateKeys struct {
-	opts *options
+	BackupPassphrase string `short:"p" long:"backuppassphrase" description:"The passphrase to encrypt the key backup with. Before the keys are deleted the node writes them to an encrypted backup file in its key backup directory."`
+	Yes              bool   `short:"y" long:"yes" description:"Don't ask for confirmation before deleting the keys."`
+	opts             *options
 }
 
 func (x *DeletePrivateKeys) Execute(args []string) error {
+	if x.BackupPassphrase == "" {
+		return errors.New("backup passphrase is required")
+	}
 	client, err := makeWalletClient(x.opts)
 	if err != nil {
 		return err
 	}
+	if !x.Yes {
+		if err := confirm(tr("This permanently deletes the wallet's private keys. Make sure the seed is backed up. Continue?")); err != nil {
+			return err
+		}
+	}
 
-	_, err = client.DeletePrivateKeys(makeContext(x.opts.AuthToken), &pb.DeletePrivateKeysRequest{})
+	nonceResp, err := client.GetDeletePrivateKeysNonce(makeContext(x.opts.AuthToken), &pb.GetDeletePrivateKeysNonceRequest{})
+	if err != nil {
+		return err
+	}
+	resp, err := client.DeletePrivateKeys(makeContext(x.opts.AuthToken), &pb.DeletePrivateKeysRequest{
+		Nonce:            nonceResp.Nonce,
+		BackupPassphrase: x.BackupPassphrase,
+	})
 	if err != nil {
 		return err
 	}
 
-	fmt.Println("success")
+	fmt.Printf("Private keys deleted. Backup written to %s\n", resp.BackupFile)
+	return nil
+}
+
+type WalletRepair struct {
+	DryRun bool `long:"dry-run" description:"Report the problems found without fixing them"`
+	opts   *options
+}
+
+func (x *WalletRepair) Execute(args []string) error {
+	client, err := makeWalletClient(x.opts)
+	if err != nil {
+		return err
+	}
+	resp, err := client.RepairWallet(makeContext(x.opts.AuthToken), &pb.RepairWalletRequest{
+		DryRun: x.DryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	type action struct {
+		Commitment types.HexEncodable `json:"commitment"`
+		Action     string             `json:"action"`
+		Reason     string             `json:"reason"`
+	}
+	s := struct {
+		Checked uint32   `json:"checked"`
+		Actions []action `json:"actions"`
+	}{
+		Checked: resp.Checked,
+		Actions: make([]action, 0, len(resp.Actions)),
+	}
+	for _, a := range resp.Actions {
+		s.Actions = append(s.Actions, action{
+			Commitment: a.Commitment,
+			Action:     strings.ToLower(a.Type.String()),
+			Reason:     a.Reason,
+		})
+	}
+	out, err := json.MarshalIndent(&s, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+type DecryptKeyBackup struct {
+	File       string `short:"f" long:"file" description:"The key backup file written by deleteprivatekeys"`
+	Passphrase string `short:"p" long:"passphrase" description:"The passphrase the backup was encrypted with"`
+	opts       *options
+}
+
+func (x *DecryptKeyBackup) Execute(args []string) error {
+	ciphertext, err := os.ReadFile(repo.CleanAndExpandPath(x.File))
+	if err != nil {
+		return err
+	}
+	plaintext, err := icrypto.DecryptWithPassphrase(ciphertext, x.Passphrase)
+	if err != nil {
+		return err
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, plaintext, "", "    "); err != nil {
+		return err
+	}
+	fmt.Println(out.String())
 	return nil
 }
 
 type CreateRawTransaction struct {
-	InputCommitments   []string `short:"t" long:"commitment" description:"A commitment to spend as an input. Serialized as a hex string. If using this the wallet will look up the private input data. Use this or input."`
-	PrivateInputs      []string `short:"i" long:"input" description:"Private input data as a JSON string. To include more than one input use this option more than once. Use this or commitment."`
-	PrivateOutputs     []string `short:"o" long:"output" description:"Private output data as a JSON string. To include more than one output use this option more than once."`
-	AppendChangeOutput bool     `short:"c" long:"appendchange" description:"Append a change output to the transaction. If false you'll have to manually include the change out. If true the wallet will use its most recent address for change.'"`
-	FeePerKB           string   `short:"f" long:"feeperkb" description:"The fee per kilobyte to pay for this transaction. If zero the wallet will use its default fee."`
-	Serialize          bool     `short:"s" long:"serialize" description:"Serialize the output as a hex string. If false it will be JSON."`
+	InputCommitments   []commitmentHex `short:"t" long:"commitment" description:"A commitment to spend as an input. Serialized as a hex string. If using this the wallet will look up the private input data. Use this or input."`
+	PrivateInputs      []string        `short:"i" long:"input" description:"Private input data as a JSON string. To include more than one input use this option more than once. Use this or commitment."`
+	PrivateOutputs     []string        `short:"o" long:"output" description:"Private output data as a JSON string. To include more than one output use this option more than once."`
+	AppendChangeOutput bool            `short:"c" long:"appendchange" description:"Append a change output to the transaction. If false you'll have to manually include the change out. If true the wallet will use its most recent address for change.'"`
+	FeePerKB           string          `short:"f" long:"feeperkb" description:"The fee per kilobyte to pay for this transaction. If zero the wallet will use its default fee."`
+	Serialize          bool            `short:"s" long:"serialize" description:"Serialize the output as a hex string. If false it will be JSON."`
+	Expiry             int64           `long:"expiry" description:"A unix timestamp after which the transaction can no longer be included in a block. If zero the wallet's default locktime is used."`
+	ChangeAddress      string          `long:"changeaddr" description:"The address to send the change to when appendchange is set. Use this on a watch-only node to return the change to the imported account. Requires commitment inputs."`
+	Recipe             string          `long:"recipe" description:"Path to a JSON file describing the transaction's outputs in ILX, and optionally the input commitments, fee, change address, and locktime. Use this instead of the other options."`
 	opts               *options
 }
 
@@ -827,6 +1724,16 @@ func (x *CreateRawTransaction) Execute(args []string) error {
 	if err != nil {
 		return err
 	}
+	if x.Recipe != "" {
+		if len(x.InputCommitments) > 0 || len(x.PrivateInputs) > 0 || len(x.PrivateOutputs) > 0 {
+			return errors.New("recipe cannot be used with input, commitment, or output")
+		}
+		req, err := loadTxRecipe(x.Recipe)
+		if err != nil {
+			return err
+		}
+		return x.createRawTransaction(client, req)
+	}
 	fpkb, err := types.AmountFromILX(x.FeePerKB)
 	if err != nil {
 		return err
@@ -836,6 +1743,8 @@ func (x *CreateRawTransaction) Execute(args []string) error {
 		Outputs:            nil,
 		AppendChangeOutput: x.AppendChangeOutput,
 		FeePerKilobyte:     uint64(fpkb),
+		Expiry:             x.Expiry,
+		ChangeAddress:      x.ChangeAddress,
 	}
 
 	if len(x.PrivateInputs) > 0 {
@@ -852,7 +1761,7 @@ func (x *CreateRawTransaction) Execute(args []string) error {
 		}
 	} else if len(x.InputCommitments) > 0 {
 		for _, commitment := range x.InputCommitments {
-			commitmentBytes, err := hex.DecodeString(commitment)
+			commitmentBytes, err := hex.DecodeString(string(commitment))
 			if err != nil {
 				return err
 			}
@@ -888,7 +1797,10 @@ func (x *CreateRawTransaction) Execute(args []string) error {
 			State:   state,
 		})
 	}
+	return x.createRawTransaction(client, req)
+}
 
+func (x *CreateRawTransaction) createRawTransaction(client pb.WalletServiceClient, req *pb.CreateRawTransactionRequest) error {
 	resp, err := client.CreateRawTransaction(makeContext(x.opts.AuthToken), req)
 	if err != nil {
 		return err
@@ -911,9 +1823,9 @@ func (x *CreateRawTransaction) Execute(args []string) error {
 }
 
 type CreateRawStakeTransaction struct {
-	InputCommitment string `short:"t" long:"commitment" description:"A commitment to stake as an input. Serialized as a hex string. If using this the wallet will look up the private input data. Use this or input."`
-	PrivateInput    string `short:"i" long:"input" description:"Private input data as a JSON string. Use this or commitment."`
-	Serialize       bool   `short:"s" long:"serialize" description:"Serialize the output as a hex string. If false it will be JSON."`
+	InputCommitment commitmentHex `short:"t" long:"commitment" description:"A commitment to stake as an input. Serialized as a hex string. If using this the wallet will look up the private input data. Use this or input."`
+	PrivateInput    string        `short:"i" long:"input" description:"Private input data as a JSON string. Use this or commitment."`
+	Serialize       bool          `short:"s" long:"serialize" description:"Serialize the output as a hex string. If false it will be JSON."`
 	opts            *options
 }
 
@@ -937,7 +1849,7 @@ func (x *CreateRawStakeTransaction) Execute(args []string) error {
 			},
 		}
 	} else if len(x.InputCommitment) > 0 {
-		commitmentBytes, err := hex.DecodeString(x.InputCommitment)
+		commitmentBytes, err := hex.DecodeString(string(x.InputCommitment))
 		if err != nil {
 			return err
 		}
@@ -1043,23 +1955,15 @@ func (x *DecodeRawTransaction) Execute(args []string) error {
 type ProveRawTransaction struct {
 	Tx          string   `short:"t" long:"rawtx" description:"The transaction to prove. Serialized as hex string or JSON."`
 	Serialize   bool     `short:"s" long:"serialize" description:"Serialize the output as a hex string. If false it will be JSON."`
-	PrivateKeys []string `short:"k" long:"privkey" description:"An optional spend private to sign the inputs. If one is not provided this CLI will connect to the wallet and look for the key. Serialized as hex string."`
+	PrivateKeys []string `short:"k" long:"privkey" description:"An optional spend private to sign the inputs. If one is not provided the signer command is used if set, otherwise this CLI will connect to the wallet and look for the key. Serialized as hex string."`
 	Mock        bool     `short:"m" long:"mock" description:"Create a mock proof instead of a real zk-snark. The inputs will still be validated."`
 	opts        *options
 }
 
 func (x *ProveRawTransaction) Execute(args []string) error {
-	var privKeys []crypto.PrivKey
-	for _, k := range x.PrivateKeys {
-		privKeyBytes, err := hex.DecodeString(k)
-		if err != nil {
-			return err
-		}
-		privKey, err := crypto.UnmarshalPrivateKey(privKeyBytes)
-		if err != nil {
-			return err
-		}
-		privKeys = append(privKeys, privKey)
+	privKeys, err := signingKeys(x.opts, x.PrivateKeys...)
+	if err != nil {
+		return err
 	}
 
 	var rawTx pb.RawTransaction
@@ -1087,21 +1991,21 @@ func (x *ProveRawTransaction) Execute(args []string) error {
 		prover = &zk.MockProver{}
 	}
 
-	spinner, err := pterm.DefaultSpinner.Start(provingPhrases[mrand.Intn(len(provingPhrases))])
+	spinner, err := pterm.DefaultSpinner.Start(provingPhrase())
 	if err != nil {
 		return err
 	}
 	var tx *transactions.Transaction
-	if privKeys != nil || hasUnlockingParams || rawTx.Tx.GetTreasuryTransaction() != nil {
-		tx, err = proveRawTransactionLocally(&rawTx, privKeys, prover)
+	if len(privKeys) > 0 || hasUnlockingParams || rawTx.Tx.GetTreasuryTransaction() != nil {
+		tx, err = proveRawTransactionLocally(&rawTx, privKeys, &spinnerProver{Prover: prover, spinner: spinner})
 		if err != nil {
-			spinner.Fail(fmt.Sprintf("Error proving transaction: %s", err.Error()))
+			spinner.Fail(trf("Error proving transaction: %s", err.Error()))
 			return nil
 		}
 	} else {
 		client, err := makeWalletClient(x.opts)
 		if err != nil {
-			spinner.Fail(fmt.Sprintf("Error proving transaction: %s", err.Error()))
+			spinner.Fail(trf("Error proving transaction: %s", err.Error()))
 			return nil
 		}
 
@@ -1109,7 +2013,7 @@ func (x *ProveRawTransaction) Execute(args []string) error {
 			RawTx: &rawTx,
 		})
 		if err != nil {
-			spinner.Fail(fmt.Sprintf("Error proving transaction: %s", err.Error()))
+			spinner.Fail(trf("Error proving transaction: %s", err.Error()))
 			return nil
 		}
 		tx = resp.ProvedTx
@@ -1118,14 +2022,14 @@ func (x *ProveRawTransaction) Execute(args []string) error {
 	if x.Serialize {
 		ser, err := proto.Marshal(tx)
 		if err != nil {
-			spinner.Fail(fmt.Sprintf("Error serializing transaction: %s", err.Error()))
+			spinner.Fail(trf("Error serializing transaction: %s", err.Error()))
 			return nil
 		}
 		spinner.Success(hex.EncodeToString(ser))
 	} else {
 		out, err := json.MarshalIndent(tx, "", "    ")
 		if err != nil {
-			spinner.Fail(fmt.Sprintf("Error serializing transaction: %s", err.Error()))
+			spinner.Fail(trf("Error serializing transaction: %s", err.Error()))
 			return nil
 		}
 		spinner.Success(string(out))
@@ -1134,11 +2038,16 @@ func (x *ProveRawTransaction) Execute(args []string) error {
 }
 
 type Stake struct {
-	Commitments []string `short:"c" long:"commitment" description:"A utxo commitment to stake. Encoded as a hex string. You can stake more than one. To do so just use this option more than once."`
+	Commitments []commitmentHex `short:"c" long:"commitment" description:"A utxo commitment to stake. Encoded as a hex string. You can stake more than one. To do so just use this option more than once."`
+	DryRun      bool            `long:"dry-run" description:"Print the utxos that would be staked without proving or broadcasting the transactions."`
+	Yes         bool            `short:"y" long:"yes" description:"Don't ask for confirmation before staking."`
 	opts        *options
 }
 
 func (x *Stake) Execute(args []string) error {
+	if err := verifyNetwork(x.opts); err != nil {
+		return err
+	}
 	client, err := makeWalletClient(x.opts)
 	if err != nil {
 		return err
@@ -1146,7 +2055,7 @@ func (x *Stake) Execute(args []string) error {
 
 	commitments := make([][]byte, 0, len(x.Commitments))
 	for _, c := range x.Commitments {
-		cBytes, err := hex.DecodeString(c)
+		cBytes, err := hex.DecodeString(string(c))
 		if err != nil {
 			return err
 		}
@@ -1155,8 +2064,23 @@ func (x *Stake) Execute(args []string) error {
 	if len(commitments) == 0 {
 		return errors.New("commitment to stake must be specified")
 	}
+	if x.DryRun {
+		resp, err := client.Stake(makeContext(x.opts.AuthToken), &pb.StakeRequest{
+			Commitments: commitments,
+			DryRun:      true,
+		})
+		if err != nil {
+			return err
+		}
+		return printTransactionPlan(resp.Plan)
+	}
+	if !x.Yes {
+		if err := confirm(trf("Stake %d utxo(s)?", len(commitments))); err != nil {
+			return err
+		}
+	}
 
-	spinner, err := pterm.DefaultSpinner.Start(provingPhrases[mrand.Intn(len(provingPhrases))])
+	spinner, err := pterm.DefaultSpinner.Start(provingPhrase())
 	if err != nil {
 		return err
 	}
@@ -1164,11 +2088,11 @@ func (x *Stake) Execute(args []string) error {
 		Commitments: commitments,
 	})
 	if err != nil {
-		spinner.Fail(fmt.Sprintf("Error proving transaction: %s", err.Error()))
+		spinner.Fail(trf("Error proving transaction: %s", err.Error()))
 		return nil
 	}
 
-	spinner.Success("Stake transaction broadcast successfully")
+	spinner.Success(tr("Stake transaction broadcast successfully"))
 	return nil
 }
 
@@ -1195,45 +2119,105 @@ func (x *SetAutoStakeRewards) Execute(args []string) error {
 }
 
 type Spend struct {
-	Address     string   `short:"a" long:"addr" description:"An address to send coins to"`
-	Amount      string   `short:"t" long:"amount" description:"The amount to send"`
-	FeePerKB    string   `short:"f" long:"feeperkb" description:"The fee per kilobyte to pay for this transaction. If zero the wallet will use its default fee."`
-	Commitments []string `short:"c" long:"commitment" description:"Optionally specify which input commitment(s) to spend. If this field is omitted the wallet will automatically select (only non-staked) inputs commitments. Serialized as hex strings. Use this option more than once to add more than one input commitment."`
-	SpendAll    bool     `long:"all" description:"If true the amount option will be ignored and all the funds will be swept from the wallet to the provided address, minus the transaction fee."`
+	Address     string          `short:"a" long:"addr" description:"An address to send coins to"`
+	ToContact   contactName     `long:"to-contact" description:"The name of an address book contact to send coins to. Use this or addr."`
+	URI         string          `long:"uri" description:"An ilx: payment URI to pay. The coins are sent to the URI's address and, unless amount is set, the URI's amount is sent. Use this or addr."`
+	Amount      string          `short:"t" long:"amount" description:"The amount to send"`
+	FeePerKB    string          `short:"f" long:"feeperkb" description:"The fee per kilobyte to pay for this transaction. If zero the wallet will use its default fee."`
+	ConfTarget  uint32          `long:"conf-target" description:"Pay the fee the node estimates is needed for the transaction to be included within this many blocks. Cannot be used with feeperkb."`
+	Commitments []commitmentHex `short:"c" long:"commitment" description:"Optionally specify which input commitment(s) to spend. If this field is omitted the wallet will automatically select (only non-staked) inputs commitments. Serialized as hex strings. Use this option more than once to add more than one input commitment."`
+	SpendAll    bool            `long:"all" description:"If true the amount option will be ignored and all the funds will be swept from the wallet to the provided address, minus the transaction fee."`
+	Template    string          `long:"template" description:"Execute the named spend template. If set all other options are ignored."`
+	DryRun      bool            `long:"dry-run" description:"Print the inputs, outputs, and fee the transaction would have without proving or broadcasting it."`
+	CoinSelect  string          `long:"coinselect" description:"The strategy used to select the inputs if no commitments are specified: [default, largest-first, smallest-first, branch-and-bound, privacy-randomized]. Staked coins are never selected." default:"default"`
+	Yes         bool            `short:"y" long:"yes" description:"Don't ask for confirmation before sending."`
+	Interactive bool            `short:"i" long:"interactive" description:"Build the transaction step by step: recipient, amount, coin selection, and fee, followed by a review before sending. All other options are ignored."`
 	opts        *options
 }
 
 func (x *Spend) Execute(args []string) error {
+	if err := verifyNetwork(x.opts); err != nil {
+		return err
+	}
 	client, err := makeWalletClient(x.opts)
 	if err != nil {
 		return err
 	}
+	if x.Interactive {
+		return x.spendInteractive(client)
+	}
 
 	commitments := make([][]byte, 0, len(x.Commitments))
 	for _, c := range x.Commitments {
-		cBytes, err := hex.DecodeString(c)
+		cBytes, err := hex.DecodeString(string(c))
 		if err != nil {
 			return err
 		}
 		commitments = append(commitments, cBytes)
 	}
-
-	spinner, err := pterm.DefaultSpinner.Start(provingPhrases[mrand.Intn(len(provingPhrases))])
+	fpkb, err := feePerKilobyte(x.opts, x.FeePerKB, x.ConfTarget)
 	if err != nil {
 		return err
 	}
-	if x.SpendAll {
-		fpkb, err := types.AmountFromILX(x.FeePerKB)
+	coinSelection, ok := pb.SpendRequest_CoinSelection_value[strings.ToUpper(strings.ReplaceAll(x.CoinSelect, "-", "_"))]
+	if !ok {
+		return errors.New("unknown coin selection strategy")
+	}
+
+	if x.Template != "" {
+		if x.DryRun {
+			return errors.New("dry run is not supported for templates")
+		}
+		if !x.Yes {
+			if err := confirm(trf("Execute spend template %s?", x.Template)); err != nil {
+				return err
+			}
+		}
+		spinner, err := pterm.DefaultSpinner.Start(provingPhrase())
 		if err != nil {
 			return err
 		}
-		resp, err := client.SweepWallet(makeContext(x.opts.AuthToken), &pb.SweepWalletRequest{
+		resp, err := client.SpendFromTemplate(makeContext(x.opts.AuthToken), &pb.SpendFromTemplateRequest{
+			Name: x.Template,
+		})
+		if err != nil {
+			spinner.Fail(trf("Error proving transaction: %s", err.Error()))
+			return nil
+		}
+
+		spinner.Success(hex.EncodeToString(resp.Transaction_ID))
+	} else if x.SpendAll {
+		if x.ToContact != "" {
+			return errors.New("to-contact cannot be used with all")
+		}
+		if x.URI != "" {
+			return errors.New("uri cannot be used with all")
+		}
+		req := &pb.SweepWalletRequest{
 			ToAddress:        x.Address,
 			FeePerKilobyte:   uint64(fpkb),
 			InputCommitments: commitments,
-		})
+		}
+		if x.DryRun {
+			req.DryRun = true
+			resp, err := client.SweepWallet(makeContext(x.opts.AuthToken), req)
+			if err != nil {
+				return err
+			}
+			return printTransactionPlan(resp.Plan)
+		}
+		if !x.Yes {
+			if err := confirm(trf("Send all funds to %s?", x.Address)); err != nil {
+				return err
+			}
+		}
+		spinner, err := pterm.DefaultSpinner.Start(provingPhrase())
+		if err != nil {
+			return err
+		}
+		resp, err := client.SweepWallet(makeContext(x.opts.AuthToken), req)
 		if err != nil {
-			spinner.Fail(fmt.Sprintf("Error proving transaction: %s", err.Error()))
+			spinner.Fail(trf("Error proving transaction: %s", err.Error()))
 			return nil
 		}
 
@@ -1243,18 +2227,52 @@ func (x *Spend) Execute(args []string) error {
 		if err != nil {
 			return err
 		}
-		fpkb, err := types.AmountFromILX(x.FeePerKB)
-		if err != nil {
-			return err
-		}
-		resp, err := client.Spend(makeContext(x.opts.AuthToken), &pb.SpendRequest{
+		req := &pb.SpendRequest{
 			ToAddress:        x.Address,
+			ToContact:        string(x.ToContact),
+			Payment_URI:      x.URI,
 			Amount:           uint64(amt),
 			FeePerKilobyte:   uint64(fpkb),
 			InputCommitments: commitments,
-		})
+			CoinSelection:    pb.SpendRequest_CoinSelection(coinSelection),
+		}
+		recipient, amtStr := x.Address, x.Amount
+		if x.ToContact != "" {
+			recipient = string(x.ToContact)
+		}
+		if x.URI != "" {
+			uri, err := types.ParsePaymentURI(x.URI)
+			if err != nil {
+				return err
+			}
+			recipient = uri.Address
+			if uri.Memo != "" {
+				recipient = fmt.Sprintf("%s (%s)", uri.Address, uri.Memo)
+			}
+			if amt == 0 {
+				amtStr = strconv.FormatFloat(uri.Amount.ToILX(), 'f', -1, 64)
+			}
+		}
+		if x.DryRun {
+			req.DryRun = true
+			resp, err := client.Spend(makeContext(x.opts.AuthToken), req)
+			if err != nil {
+				return err
+			}
+			return printTransactionPlan(resp.Plan)
+		}
+		if !x.Yes {
+			if err := confirm(trf("Send %s ILX to %s?", amtStr, recipient)); err != nil {
+				return err
+			}
+		}
+		spinner, err := pterm.DefaultSpinner.Start(provingPhrase())
+		if err != nil {
+			return err
+		}
+		resp, err := client.Spend(makeContext(x.opts.AuthToken), req)
 		if err != nil {
-			spinner.Fail(fmt.Sprintf("Error proving transaction: %s", err.Error()))
+			spinner.Fail(trf("Error proving transaction: %s", err.Error()))
 			return nil
 		}
 
@@ -1264,50 +2282,364 @@ func (x *Spend) Execute(args []string) error {
 	return nil
 }
 
-type TimelockCoins struct {
-	LockUntil   int64    `short:"l" long:"lockuntil" description:"A unix timestamp to lock the coins until (in seconds)."`
-	Amount      string   `short:"t" long:"amount" description:"The amount to lockup"`
-	FeePerKB    string   `short:"f" long:"feeperkb" description:"The fee per kilobyte to pay for this transaction. If zero the wallet will use its default fee."`
-	Commitments []string `short:"c" long:"commitment" description:"Optionally specify which input commitment(s) to lock. If this field is omitted the wallet will automatically select (only non-staked) inputs commitments. Serialized as hex strings. Use this option more than once to add more than one input commitment."`
+type StartSweepJob struct {
+	Address     string          `short:"a" long:"addr" description:"The address to sweep the coins to"`
+	FeePerKB    string          `short:"f" long:"feeperkb" description:"The fee per kilobyte to pay for the transactions. If zero the wallet will use its default fee."`
+	Commitments []commitmentHex `short:"c" long:"commitment" description:"Optionally specify which input commitment(s) to sweep. If this field is omitted all the spendable coins in the wallet are swept. Use this option more than once to add more than one input commitment."`
+	MaxInputs   uint32          `short:"m" long:"maxinputs" description:"The maximum number of inputs in each transaction. If zero the node's default is used."`
 	opts        *options
 }
 
-func (x *TimelockCoins) Execute(args []string) error {
+func (x *StartSweepJob) Execute(args []string) error {
 	client, err := makeWalletClient(x.opts)
 	if err != nil {
 		return err
 	}
-
+	fpkb, err := types.AmountFromILX(x.FeePerKB)
+	if err != nil {
+		return err
+	}
 	commitments := make([][]byte, 0, len(x.Commitments))
 	for _, c := range x.Commitments {
-		cBytes, err := hex.DecodeString(c)
+		cBytes, err := hex.DecodeString(string(c))
 		if err != nil {
 			return err
 		}
 		commitments = append(commitments, cBytes)
 	}
+	resp, err := client.StartSweepJob(makeContext(x.opts.AuthToken), &pb.StartSweepJobRequest{
+		ToAddress:        x.Address,
+		FeePerKilobyte:   uint64(fpkb),
+		InputCommitments: commitments,
+		MaxInputsPerTx:   x.MaxInputs,
+	})
+	if err != nil {
+		return err
+	}
+	return printSweepJob(resp.Job)
+}
+
+type GetSweepJobs struct {
+	opts *options
+}
+
+func (x *GetSweepJobs) Execute(args []string) error {
+	client, err := makeWalletClient(x.opts)
+	if err != nil {
+		return err
+	}
+	resp, err := client.GetSweepJobs(makeContext(x.opts.AuthToken), &pb.GetSweepJobsRequest{})
+	if err != nil {
+		return err
+	}
+	for _, job := range resp.Jobs {
+		if err := printSweepJob(job); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-	spinner, err := pterm.DefaultSpinner.Start(provingPhrases[mrand.Intn(len(provingPhrases))])
+type CancelSweepJob struct {
+	JobID string `short:"j" long:"job" description:"The ID of the sweep job"`
+	opts  *options
+}
+
+func (x *CancelSweepJob) Execute(args []string) error {
+	client, err := makeWalletClient(x.opts)
 	if err != nil {
 		return err
 	}
-	amt, err := types.AmountFromILX(x.Amount)
+	id, err := hex.DecodeString(x.JobID)
+	if err != nil {
+		return err
+	}
+	_, err = client.CancelSweepJob(makeContext(x.opts.AuthToken), &pb.CancelSweepJobRequest{
+		Job_ID: id,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Println("success")
+	return nil
+}
+
+type ResumeSweepJob struct {
+	JobID string `short:"j" long:"job" description:"The ID of the sweep job"`
+	opts  *options
+}
+
+func (x *ResumeSweepJob) Execute(args []string) error {
+	client, err := makeWalletClient(x.opts)
+	if err != nil {
+		return err
+	}
+	id, err := hex.DecodeString(x.JobID)
+	if err != nil {
+		return err
+	}
+	resp, err := client.ResumeSweepJob(makeContext(x.opts.AuthToken), &pb.ResumeSweepJobRequest{
+		Job_ID: id,
+	})
+	if err != nil {
+		return err
+	}
+	return printSweepJob(resp.Job)
+}
+
+func printSweepJob(job *pb.SweepJob) error {
+	s := struct {
+		JobID          types.HexEncodable   `json:"jobID"`
+		Address        string               `json:"address"`
+		Status         string               `json:"status"`
+		Error          string               `json:"error,omitempty"`
+		TotalInputs    uint32               `json:"totalInputs"`
+		PendingInputs  int                  `json:"pendingInputs"`
+		MaxInputsPerTx uint32               `json:"maxInputsPerTx"`
+		FeePerKB       types.Amount         `json:"feePerKilobyte"`
+		Created        time.Time            `json:"created"`
+		Txids          []types.HexEncodable `json:"txids"`
+	}{
+		JobID:          job.Job_ID,
+		Address:        job.ToAddress,
+		Status:         job.Status.String(),
+		Error:          job.Error,
+		TotalInputs:    job.TotalInputs,
+		PendingInputs:  len(job.PendingCommitments),
+		MaxInputsPerTx: job.MaxInputsPerTx,
+		FeePerKB:       types.Amount(job.FeePerKilobyte),
+		Created:        time.Unix(job.Created, 0),
+		Txids:          make([]types.HexEncodable, 0, len(job.Transaction_IDs)),
+	}
+	for _, txid := range job.Transaction_IDs {
+		s.Txids = append(s.Txids, txid)
+	}
+	out, err := json.MarshalIndent(&s, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+// feePerKilobyte returns the fee per kilobyte set with the feeperkb option
+// or, if a confirmation target is set instead, the node's fee estimate for it.
+func feePerKilobyte(opts *options, feePerKB string, confTarget uint32) (types.Amount, error) {
+	fpkb, err := types.AmountFromILX(feePerKB)
+	if err != nil {
+		return 0, err
+	}
+	if confTarget == 0 {
+		return fpkb, nil
+	}
+	if fpkb > 0 {
+		return 0, errors.New("feeperkb and conf-target cannot be used together")
+	}
+	client, err := makeBlockchainClient(opts)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := client.GetFeeEstimate(makeContext(opts.AuthToken), &pb.GetFeeEstimateRequest{
+		ConfTarget: confTarget,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return types.Amount(resp.FeePerKilobyte), nil
+}
+
+type SaveSpendTemplate struct {
+	Name        string          `short:"n" long:"name" description:"The name of the template"`
+	Recipients  []string        `short:"r" long:"recipient" description:"A recipient as a JSON string with an address and either an amount or a percent. A percent pays that share of the spendable balance when the template is executed. Use this option more than once to add more than one recipient."`
+	FeePerKB    string          `short:"f" long:"feeperkb" description:"The fee per kilobyte to pay for the transaction. If zero the wallet will use its default fee."`
+	Commitments []commitmentHex `short:"c" long:"commitment" description:"Optionally specify which input commitment(s) to spend. If this field is omitted the wallet will automatically select (only non-staked) inputs commitments. Serialized as hex strings. Use this option more than once to add more than one input commitment."`
+	opts        *options
+}
+
+func (x *SaveSpendTemplate) Execute(args []string) error {
+	client, err := makeWalletClient(x.opts)
 	if err != nil {
 		return err
 	}
+
 	fpkb, err := types.AmountFromILX(x.FeePerKB)
 	if err != nil {
 		return err
 	}
+	template := &pb.SpendTemplate{
+		Name:           x.Name,
+		FeePerKilobyte: uint64(fpkb),
+	}
+	for _, r := range x.Recipients {
+		recipient := struct {
+			Address string       `json:"address"`
+			Amount  types.Amount `json:"amount"`
+			Percent float64      `json:"percent"`
+		}{}
+		if err := json.Unmarshal([]byte(r), &recipient); err != nil {
+			return err
+		}
+		template.Recipients = append(template.Recipients, &pb.SpendTemplate_Recipient{
+			Address:        recipient.Address,
+			Amount:         uint64(recipient.Amount),
+			BalancePercent: recipient.Percent,
+		})
+	}
+	for _, c := range x.Commitments {
+		cBytes, err := hex.DecodeString(string(c))
+		if err != nil {
+			return err
+		}
+		template.InputCommitments = append(template.InputCommitments, cBytes)
+	}
+
+	_, err = client.SaveSpendTemplate(makeContext(x.opts.AuthToken), &pb.SaveSpendTemplateRequest{
+		Template: template,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Println("success")
+	return nil
+}
+
+type GetSpendTemplates struct {
+	opts *options
+}
+
+func (x *GetSpendTemplates) Execute(args []string) error {
+	client, err := makeWalletClient(x.opts)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.GetSpendTemplates(makeContext(x.opts.AuthToken), &pb.GetSpendTemplatesRequest{})
+	if err != nil {
+		return err
+	}
+
+	type recipient struct {
+		Address string       `json:"address"`
+		Amount  types.Amount `json:"amount,omitempty"`
+		Percent float64      `json:"percent,omitempty"`
+	}
+	type template struct {
+		Name        string               `json:"name"`
+		Recipients  []recipient          `json:"recipients"`
+		FeePerKB    types.Amount         `json:"feePerKB"`
+		Commitments []types.HexEncodable `json:"commitments,omitempty"`
+	}
+	templates := make([]template, 0, len(resp.Templates))
+	for _, t := range resp.Templates {
+		tmpl := template{
+			Name:     t.Name,
+			FeePerKB: types.Amount(t.FeePerKilobyte),
+		}
+		for _, r := range t.Recipients {
+			tmpl.Recipients = append(tmpl.Recipients, recipient{
+				Address: r.Address,
+				Amount:  types.Amount(r.Amount),
+				Percent: r.BalancePercent,
+			})
+		}
+		for _, c := range t.InputCommitments {
+			tmpl.Commitments = append(tmpl.Commitments, c)
+		}
+		templates = append(templates, tmpl)
+	}
+	out, err := json.MarshalIndent(templates, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+type DeleteSpendTemplate struct {
+	Name string `short:"n" long:"name" description:"The name of the template to delete"`
+	opts *options
+}
+
+func (x *DeleteSpendTemplate) Execute(args []string) error {
+	client, err := makeWalletClient(x.opts)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.DeleteSpendTemplate(makeContext(x.opts.AuthToken), &pb.DeleteSpendTemplateRequest{
+		Name: x.Name,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Println("success")
+	return nil
+}
+
+type TimelockCoins struct {
+	LockUntil   int64           `short:"l" long:"lockuntil" description:"A unix timestamp to lock the coins until (in seconds)."`
+	Amount      string          `short:"t" long:"amount" description:"The amount to lockup"`
+	FeePerKB    string          `short:"f" long:"feeperkb" description:"The fee per kilobyte to pay for this transaction. If zero the wallet will use its default fee."`
+	ConfTarget  uint32          `long:"conf-target" description:"Pay the fee the node estimates is needed for the transaction to be included within this many blocks. Cannot be used with feeperkb."`
+	Commitments []commitmentHex `short:"c" long:"commitment" description:"Optionally specify which input commitment(s) to lock. If this field is omitted the wallet will automatically select (only non-staked) inputs commitments. Serialized as hex strings. Use this option more than once to add more than one input commitment."`
+	DryRun      bool            `long:"dry-run" description:"Print the inputs, outputs, and fee the transaction would have without proving or broadcasting it."`
+	Yes         bool            `short:"y" long:"yes" description:"Don't ask for confirmation before locking the coins."`
+	opts        *options
+}
+
+func (x *TimelockCoins) Execute(args []string) error {
+	if err := verifyNetwork(x.opts); err != nil {
+		return err
+	}
+	client, err := makeWalletClient(x.opts)
+	if err != nil {
+		return err
+	}
+
+	commitments := make([][]byte, 0, len(x.Commitments))
+	for _, c := range x.Commitments {
+		cBytes, err := hex.DecodeString(string(c))
+		if err != nil {
+			return err
+		}
+		commitments = append(commitments, cBytes)
+	}
 
-	resp, err := client.TimelockCoins(makeContext(x.opts.AuthToken), &pb.TimelockCoinsRequest{
+	amt, err := types.AmountFromILX(x.Amount)
+	if err != nil {
+		return err
+	}
+	fpkb, err := feePerKilobyte(x.opts, x.FeePerKB, x.ConfTarget)
+	if err != nil {
+		return err
+	}
+	req := &pb.TimelockCoinsRequest{
 		LockUntil:        x.LockUntil,
 		Amount:           uint64(amt),
 		FeePerKilobyte:   uint64(fpkb),
 		InputCommitments: commitments,
-	})
+	}
+	if x.DryRun {
+		req.DryRun = true
+		resp, err := client.TimelockCoins(makeContext(x.opts.AuthToken), req)
+		if err != nil {
+			return err
+		}
+		return printTransactionPlan(resp.Plan)
+	}
+	if !x.Yes {
+		if err := confirm(trf("Lock %s ILX until %s?", x.Amount, time.Unix(x.LockUntil, 0).Format(time.RFC1123))); err != nil {
+			return err
+		}
+	}
+
+	spinner, err := pterm.DefaultSpinner.Start(provingPhrase())
+	if err != nil {
+		return err
+	}
+	resp, err := client.TimelockCoins(makeContext(x.opts.AuthToken), req)
 	if err != nil {
-		spinner.Fail(fmt.Sprintf("Error proving transaction: %s", err.Error()))
+		spinner.Fail(trf("Error proving transaction: %s", err.Error()))
 		return nil
 	}
 
@@ -1523,14 +2855,18 @@ func proveRawTransactionLocally(rawTx *pb.RawTransaction, privKeys []crypto.Priv
 func pbIOtoIO(ios []*pb.IOMetadata) []interface{} {
 	ret := make([]interface{}, 0, len(ios))
 	type txIO struct {
-		Address string       `json:"address"`
-		Amount  types.Amount `json:"amount"`
+		Address    string       `json:"address"`
+		Amount     types.Amount `json:"amount"`
+		CustomerID string       `json:"customerID,omitempty"`
+		Label      string       `json:"label,omitempty"`
 	}
 	for _, io := range ios {
 		if io.GetTxIo() != nil {
 			ret = append(ret, &txIO{
-				Address: io.GetTxIo().Address,
-				Amount:  types.Amount(io.GetTxIo().Amount),
+				Address:    io.GetTxIo().Address,
+				Amount:     types.Amount(io.GetTxIo().Amount),
+				CustomerID: io.GetTxIo().Customer_ID,
+				Label:      io.GetTxIo().Label,
 			})
 		}
 		if io.GetUnknown() != nil {
@@ -1540,12 +2876,32 @@ func pbIOtoIO(ios []*pb.IOMetadata) []interface{} {
 	return ret
 }
 
-var provingPhrases = []string{
-	"Hang tight! We're doing moon math.",
-	"Patience, we're bending the laws of math for you.",
-	"Hang in there, we're bending the fabric of the cosmos.",
-	"Just a sec, bending the blockchain to our will.",
-	"Hang tight, your transaction is in the oven.",
-	"Sit tight, charging warp coils.",
-	"Be patient, traveling through hyperspace ain't like dusting crops.",
+// spinnerProver wraps a prover and shows the progress of
+// its proofs on the spinner.
+type spinnerProver struct {
+	zk.Prover
+	spinner *pterm.SpinnerPrinter
+}
+
+func (p *spinnerProver) Prove(program string, privateParams zk.Parameters, publicParams zk.Parameters, maxSteps ...uint64) ([]byte, error) {
+	pp, ok := p.Prover.(zk.ProgressProver)
+	if !ok {
+		return p.Prover.Prove(program, privateParams, publicParams, maxSteps...)
+	}
+	phrase := p.spinner.Text
+	return pp.ProveWithProgress(program, privateParams, publicParams, func(progress zk.ProofProgress) {
+		switch progress.Phase {
+		case zk.PhaseEvaluating:
+			p.spinner.UpdateText(trf("%s (evaluating)", phrase))
+		case zk.PhaseProving:
+			if progress.EstimatedRemaining == 0 {
+				p.spinner.UpdateText(trf("%s (proving %d steps)", phrase, progress.TotalSteps))
+				return
+			}
+			p.spinner.UpdateText(trf("%s (proving %d/%d steps, about %s remaining)", phrase,
+				progress.StepsCompleted, progress.TotalSteps, progress.EstimatedRemaining.Round(time.Second)))
+		case zk.PhaseCompressing:
+			p.spinner.UpdateText(trf("%s (compressing)", phrase))
+		}
+	}, maxSteps...)
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/cenkalti/backoff/v4"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/project-illium/ilxd/blockchain"
+	"sync"
 	"time"
 )
 
@@ -22,6 +23,7 @@ type BackoffChooser struct {
 	peerMap map[peer.ID]*backoffTime
 	chooser blockchain.WeightedChooser
 	valconn ValidatorSetConnection
+	mtx     sync.Mutex
 }
 
 // NewBackoffChooser returns a new initialized BackoffChooser
@@ -38,6 +40,10 @@ func NewBackoffChooser(chooser blockchain.WeightedChooser, valconn ValidatorSetC
 // then "" will be returned.
 func (b *BackoffChooser) WeightedRandomValidator() peer.ID {
 	peer := b.chooser.WeightedRandomValidator()
+
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
 	if bot, ok := b.peerMap[peer]; ok {
 		if time.Now().After(bot.backoffUntil) {
 			return peer
@@ -55,6 +61,10 @@ func (b *BackoffChooser) WeightedRandomValidator() peer.ID {
 // the given peer.
 func (b *BackoffChooser) RegisterDialFailure(p peer.ID) {
 	b.valconn.RegisterDialFailure(p)
+
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
 	bot, ok := b.peerMap[p]
 	if ok {
 		t := bot.eb.NextBackOff()
@@ -82,9 +92,21 @@ func (b *BackoffChooser) RegisterDialFailure(p peer.ID) {
 // given peer.
 func (b *BackoffChooser) RegisterDialSuccess(p peer.ID) {
 	b.valconn.RegisterDialSuccess(p)
+
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
 	_, ok := b.peerMap[p]
 	if ok {
 		log.WithCaller(true).Trace("Removing dial backoff", log.Args("peer", p))
 		delete(b.peerMap, p)
 	}
 }
+
+// ClearBackoffs deletes the exponential backoffs for all peers.
+func (b *BackoffChooser) ClearBackoffs() {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	b.peerMap = make(map[peer.ID]*backoffTime)
+}
@@ -14,9 +14,13 @@ import (
 	"github.com/project-illium/ilxd/net"
 	"github.com/project-illium/ilxd/params"
 	"github.com/project-illium/ilxd/params/hash"
+	"github.com/project-illium/ilxd/recovery"
 	"github.com/project-illium/ilxd/types"
 	"github.com/project-illium/ilxd/types/blocks"
 	"github.com/project-illium/ilxd/types/wire"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/protobuf/proto"
 	"io"
 	"math/rand"
@@ -25,20 +29,6 @@ import (
 )
 
 const (
-	// RequestTimeout is the amount of time to wait for a response to a
-	// query
-	RequestTimeout = 1 * time.Minute
-
-	// FinalizationScore is the confidence score we consider to be final
-	FinalizationScore = 160
-
-	// TimeStep is the amount of time to wait between event ticks
-	TimeStep = time.Millisecond
-
-	// MaxInflightPoll is the max outstanding requests that we can have
-	// for any inventory item.
-	MaxInflightPoll = FinalizationScore
-
 	// DeleteInventoryAfter is the maximum time we'll keep a block in memory
 	// if it hasn't been finalized by avalanche.
 	DeleteInventoryAfter = time.Hour * 6
@@ -46,12 +36,17 @@ const (
 	// ConsensusProtocol is the libp2p network protocol ID
 	ConsensusProtocol = "/consensus/"
 
-	// ConsensusProtocolVersion is the version of the ConsensusProtocol
-	ConsensusProtocolVersion = "1.0.0"
+	// ConsensusProtocolVersion is the version of the ConsensusProtocol.
+	// The net.LegacyProtocolVersion is also served until all nodes upgrade.
+	ConsensusProtocolVersion = "2.0.0"
 
 	// MinConnectedStakeThreshold is the minimum percentage of the weighted stake
 	// set we must be connected to in order to finalize blocks.
 	MinConnectedStakeThreshold = .5
+
+	// defaultShards is the number of shards the vote records are split
+	// across if not set in the config.
+	defaultShards = 4
 )
 
 // requestExpirationMsg signifies a request has expired and
@@ -75,6 +70,13 @@ type newBlockMessage struct {
 	callback     chan<- Status
 }
 
+// expandPollingMsg signifies a request to poll more validators
+// on each tick.
+type expandPollingMsg struct {
+	sampleSize int
+	until      time.Time
+}
+
 // registerVotesMsg signifies a response to a query from another peer.
 type registerVotesMsg struct {
 	p    peer.ID
@@ -93,15 +95,20 @@ type GetBlockFunc func(blockID types.ID) (*blocks.Block, error)
 type GetBlockIDFunc func(height uint32) (types.ID, error)
 
 // ConsensusEngine implements a form of the avalanche consensus protocol.
-// It primarily consists of an event loop that polls the weighted list of
-// validators for any unfinalized blocks and records the responses. Blocks
+// It primarily consists of event loops that poll the weighted list of
+// validators for any unfinalized blocks and record the responses. Blocks
 // finalize when the confidence level exceeds the threshold.
+//
+// The blocks are split across shards by height, each with its own event
+// loop. Queries from other peers are queued separately and answered from
+// snapshots of the shards' preferences.
 type ConsensusEngine struct {
 	ctx          context.Context
 	network      *net.Network
 	params       *params.NetworkParams
 	chooser      *BackoffChooser
-	ms           net.MessageSender
+	scores       *peerScorer
+	ms           net.MultiplexedMessageSender
 	valConn      ValidatorSetConnection
 	self         peer.ID
 	wg           sync.WaitGroup
@@ -109,12 +116,12 @@ type ConsensusEngine struct {
 	getBlock     GetBlockFunc
 	getBlockID   GetBlockIDFunc
 	quit         chan struct{}
-	msgChan      chan interface{}
 	print        bool
+	recorder     *TrafficRecorder
+	latency      *latencyHistogram
 
-	blocks    map[uint32]*BlockChoice
-	queries   map[string]RequestRecord
-	callbacks map[types.ID]chan<- Status
+	shards     []*engineShard
+	queryQueue *msgQueue
 }
 
 // NewConsensusEngine returns a new ConsensusEngine
@@ -130,27 +137,44 @@ func NewConsensusEngine(ctx context.Context, opts ...Option) (*ConsensusEngine,
 		return nil, err
 	}
 
+	ms := net.NewMultiplexedMessageSender(cfg.network.Host(), func() net.ResponseMessage {
+		return new(wire.MsgPollResponse)
+	}, cfg.params.ProtocolPrefix+ConsensusProtocol+ConsensusProtocolVersion,
+		cfg.params.ProtocolPrefix+ConsensusProtocol+net.LegacyProtocolVersion)
+
 	eng := &ConsensusEngine{
 		ctx:          ctx,
 		network:      cfg.network,
 		valConn:      cfg.valConn,
 		chooser:      NewBackoffChooser(cfg.chooser, cfg.valConn),
+		scores:       newPeerScorer(),
 		params:       cfg.params,
 		self:         cfg.self,
-		ms:           net.NewMessageSender(cfg.network.Host(), cfg.params.ProtocolPrefix+ConsensusProtocol+ConsensusProtocolVersion),
+		ms:           ms,
 		wg:           sync.WaitGroup{},
 		requestBlock: cfg.requestBlockFunc,
 		getBlock:     cfg.getBlockFunc,
 		getBlockID:   cfg.getBlockIDFunc,
 		quit:         make(chan struct{}),
-		msgChan:      make(chan interface{}),
-		blocks:       make(map[uint32]*BlockChoice),
-		queries:      make(map[string]RequestRecord),
-		callbacks:    make(map[types.ID]chan<- Status),
+		queryQueue:   newMsgQueue(queryQueueSize),
+		recorder:     cfg.recorder,
+		latency:      newLatencyHistogram(),
+	}
+	numShards := cfg.shards
+	if numShards < 1 {
+		numShards = defaultShards
+	}
+	for i := 0; i < numShards; i++ {
+		eng.shards = append(eng.shards, newEngineShard(eng))
 	}
 	eng.network.Host().SetStreamHandler(eng.params.ProtocolPrefix+ConsensusProtocol+ConsensusProtocolVersion, eng.HandleNewStream)
+	eng.network.Host().SetStreamHandler(eng.params.ProtocolPrefix+ConsensusProtocol+net.LegacyProtocolVersion, eng.HandleNewStream)
 	eng.wg.Add(1)
-	go eng.handler()
+	go eng.queryHandler()
+	for _, sh := range eng.shards {
+		eng.wg.Add(1)
+		go sh.handler()
+	}
 	return eng, nil
 }
 
@@ -160,30 +184,44 @@ func (eng *ConsensusEngine) Close() {
 	eng.wg.Wait()
 }
 
-func (eng *ConsensusEngine) handler() {
-	eventLoopTicker := time.NewTicker(TimeStep)
-out:
+// queryHandler answers queries from other peers. It runs apart from the
+// shards' event loops so queries are never held up behind vote processing.
+func (eng *ConsensusEngine) queryHandler() {
+	defer eng.wg.Done()
 	for {
 		select {
-		case m := <-eng.msgChan:
-			switch msg := m.(type) {
-			case *requestExpirationMsg:
-				eng.handleRequestExpiration(msg.key, msg.p)
-			case *queryMsg:
-				eng.handleQuery(msg.request, msg.remotePeer, msg.respChan)
-			case *newBlockMessage:
-				eng.handleNewBlock(msg.header, msg.isAcceptable, msg.callback)
-			case *registerVotesMsg:
-				eng.handleRegisterVotes(msg.p, msg.resp)
-			}
-		case <-eventLoopTicker.C:
-			eng.pollLoop()
+		case m := <-eng.queryQueue.ch:
+			msg := m.(*queryMsg)
+			msg.respChan <- eng.handleQuery(msg.request, msg.remotePeer)
 		case <-eng.quit:
-			break out
+			return
 		}
 	}
-	eventLoopTicker.Stop()
-	eng.wg.Done()
+}
+
+// shardFor returns the shard which owns the height. Blocks are sharded by
+// height rather than by ID so that conflicting blocks at the same height
+// share a shard, and so that poll requests, which are keyed by height,
+// can be routed without knowing which block the peer prefers.
+func (eng *ConsensusEngine) shardFor(height uint32) *engineShard {
+	return eng.shards[height%uint32(len(eng.shards))]
+}
+
+// QueueStats returns the number of messages queued and blocked for each
+// of the engine's message queues, summed across the shards.
+func (eng *ConsensusEngine) QueueStats() QueueStats {
+	stats := QueueStats{Queries: eng.queryQueue.stats()}
+	for _, sh := range eng.shards {
+		stats.Responses.add(sh.respQueue.stats())
+		stats.Housekeeping.add(sh.housekeepingQueue.stats())
+	}
+	return stats
+}
+
+// PeerScores returns the reliability scores of the validators the engine
+// has polled, sorted by peer ID.
+func (eng *ConsensusEngine) PeerScores() []PeerScore {
+	return eng.scores.scores()
 }
 
 // NewBlock is used to pass new work in the engine. The callback channel will return the final
@@ -197,20 +235,21 @@ func (eng *ConsensusEngine) NewBlock(header *blocks.BlockHeader, isAcceptable bo
 		"acceptable": isAcceptable,
 	}))
 	headerCpy := proto.Clone(header).(*blocks.BlockHeader)
-	eng.msgChan <- &newBlockMessage{
+	eng.recorder.recordNewBlock(headerCpy, isAcceptable)
+	eng.shardFor(header.Height).housekeepingQueue.push(&newBlockMessage{
 		header:       headerCpy,
 		isAcceptable: isAcceptable,
 		callback:     callback,
-	}
+	}, eng.quit)
 }
 
-func (eng *ConsensusEngine) handleNewBlock(header *blocks.BlockHeader, isAcceptable bool, callback chan<- Status) {
+func (sh *engineShard) handleNewBlock(header *blocks.BlockHeader, isAcceptable bool, callback chan<- Status) {
 	blockID := header.ID().Clone()
 
-	bc, ok := eng.blocks[header.Height]
+	bc, ok := sh.blocks[header.Height]
 	if !ok {
-		bc = NewBlockChoice(header.Height)
-		eng.blocks[header.Height] = bc
+		bc = NewBlockChoice(header.Height, sh.eng.params)
+		sh.blocks[header.Height] = bc
 	}
 
 	if bc.HasBlock(blockID) {
@@ -218,6 +257,22 @@ func (eng *ConsensusEngine) handleNewBlock(header *blocks.BlockHeader, isAccepta
 	}
 
 	bc.AddNewBlock(blockID, isAcceptable)
+	sh.dirty = true
+
+	// A block which conflicts with one that has already finalized
+	// is rejected without being voted on.
+	if bc.blockVotes[blockID].Status() == StatusRejected {
+		log.Debug("Block conflicts with a finalized block", log.ArgsFromMap(map[string]any{
+			"id":     header.ID().String(),
+			"height": header.Height,
+		}))
+		if callback != nil {
+			go func() {
+				callback <- StatusRejected
+			}()
+		}
+		return
+	}
 
 	if len(bc.blockVotes) > 1 {
 		log.Debug("Conflicting block received by consensus engine", log.ArgsFromMap(map[string]any{
@@ -227,7 +282,51 @@ func (eng *ConsensusEngine) handleNewBlock(header *blocks.BlockHeader, isAccepta
 		}))
 	}
 
-	eng.callbacks[blockID] = callback
+	sh.callbacks[blockID] = callback
+
+	_, span := tracer.Start(sh.eng.ctx, "consensus.avalanche", trace.WithAttributes(
+		attribute.String("illium.block_id", blockID.String()),
+		attribute.Int64("illium.height", int64(header.Height)),
+		attribute.Bool("illium.acceptable", isAcceptable),
+		attribute.Int("illium.conflicts", len(bc.blockVotes)-1),
+	))
+	sh.spans[blockID] = span
+}
+
+// endBlockSpan ends the avalanche span for the block, if there is one,
+// and records the final status.
+func (sh *engineShard) endBlockSpan(blockID types.ID, status string) {
+	span, ok := sh.spans[blockID]
+	if !ok {
+		return
+	}
+	delete(sh.spans, blockID)
+	span.SetAttributes(attribute.String("illium.status", status))
+	span.End()
+}
+
+// ExpandPolling makes the engine poll sampleSize validators on each tick,
+// rather than one, for the given duration. Any dial backoffs are cleared
+// so validators which previously failed are retried. This is used to help
+// recover when blocks are not finalizing.
+func (eng *ConsensusEngine) ExpandPolling(sampleSize int, duration time.Duration) {
+	until := time.Now().Add(duration)
+	log.Debug("Expanding consensus poll sample size", log.ArgsFromMap(map[string]any{
+		"sample size": sampleSize,
+		"until":       until,
+	}))
+	eng.chooser.ClearBackoffs()
+	for _, sh := range eng.shards {
+		sh.housekeepingQueue.push(&expandPollingMsg{
+			sampleSize: sampleSize,
+			until:      until,
+		}, eng.quit)
+	}
+}
+
+func (sh *engineShard) handleExpandPolling(sampleSize int, until time.Time) {
+	sh.pollSampleSize = sampleSize
+	sh.pollExpireTime = until
 }
 
 // HandleNewStream handles incoming streams from peers. We use one stream for
@@ -237,6 +336,7 @@ func (eng *ConsensusEngine) HandleNewStream(s inet.Stream) {
 }
 
 func (eng *ConsensusEngine) handleNewMessage(s inet.Stream) {
+	defer recovery.HandlePanic("consensus-stream")
 	defer s.Close()
 	contextReader := ctxio.NewReader(eng.ctx, s)
 	reader := msgio.NewVarintReaderSize(contextReader, inet.MessageSizeMax)
@@ -268,7 +368,7 @@ func (eng *ConsensusEngine) handleNewMessage(s inet.Stream) {
 			s.Reset()
 			return
 		}
-		if err := proto.Unmarshal(msgBytes, req); err != nil {
+		if err := net.UnmarshalStreamMsg(s, msgBytes, req); err != nil {
 			reader.ReleaseMsg(msgBytes)
 			log.WithCaller(true).Error("Error unmarshalling avalanche message", log.ArgsFromMap(map[string]any{
 				"peer":  remotePeer,
@@ -281,15 +381,35 @@ func (eng *ConsensusEngine) handleNewMessage(s inet.Stream) {
 
 		switch msg := req.Msg.(type) {
 		case *wire.MsgConsensusRequest_PollRequest:
-			respCh := make(chan *wire.MsgPollResponse)
-			eng.msgChan <- &queryMsg{
+			eng.recorder.recordReceivedPoll(remotePeer, msg.PollRequest)
+			respCh := make(chan *wire.MsgPollResponse, 1)
+			queued := eng.queryQueue.tryPush(&queryMsg{
 				request:    msg.PollRequest,
 				respChan:   respCh,
 				remotePeer: remotePeer,
+			})
+			if !queued {
+				// The engine is too far behind to answer. The peer's
+				// request will time out and it will poll someone else.
+				log.WithCaller(true).Trace("Consensus query queue full, dropping query", log.ArgsFromMap(map[string]any{
+					"peer": remotePeer,
+				}))
+				break
 			}
 
-			respMsg := <-respCh
-			err = net.WriteMsg(s, respMsg)
+			var respMsg *wire.MsgPollResponse
+			select {
+			case respMsg = <-respCh:
+			case <-eng.quit:
+				s.Reset()
+				return
+			}
+			if respMsg == nil {
+				s.Reset()
+				return
+			}
+			eng.recorder.recordSentPollResponse(remotePeer, respMsg)
+			err = net.WriteStreamMsg(s, respMsg)
 			if err != nil {
 				log.WithCaller(true).Trace("Error writing poll response to avalanche stream", log.ArgsFromMap(map[string]any{
 					"peer":  remotePeer,
@@ -312,7 +432,7 @@ func (eng *ConsensusEngine) handleNewMessage(s inet.Stream) {
 			} else {
 				respMsg.Block = blk
 			}
-			err = net.WriteMsg(s, respMsg)
+			err = net.WriteStreamMsg(s, respMsg)
 			if err != nil {
 				log.WithCaller(true).Trace("Error writing blk response to avalanche stream", log.ArgsFromMap(map[string]any{
 					"peer":  remotePeer,
@@ -325,7 +445,7 @@ func (eng *ConsensusEngine) handleNewMessage(s inet.Stream) {
 				"peer": remotePeer,
 			}))
 			s.Reset()
-			eng.network.IncreaseBanscore(remotePeer, 30, 0, "sent unknown consensus message")
+			eng.increaseBanscore(remotePeer, 30, 0, "sent unknown consensus message")
 			return
 		}
 
@@ -333,11 +453,13 @@ func (eng *ConsensusEngine) handleNewMessage(s inet.Stream) {
 	}
 }
 
-func (eng *ConsensusEngine) handleQuery(req *wire.MsgPollRequest, remotePeer peer.ID, respChan chan *wire.MsgPollResponse) {
+// handleQuery answers a poll request from the shards' preference snapshots.
+// It returns nil if the request is invalid.
+func (eng *ConsensusEngine) handleQuery(req *wire.MsgPollRequest, remotePeer peer.ID) *wire.MsgPollResponse {
 	if len(req.Heights) == 0 {
 		log.WithCaller(true).Trace("Received empty poll request", log.Args("peer", remotePeer))
-		eng.network.IncreaseBanscore(remotePeer, 30, 0, "sent empty poll request")
-		return
+		eng.increaseBanscore(remotePeer, 30, 0, "sent empty poll request")
+		return nil
 	}
 	resp := &wire.MsgPollResponse{
 		Request_ID: req.Request_ID,
@@ -345,105 +467,130 @@ func (eng *ConsensusEngine) handleQuery(req *wire.MsgPollRequest, remotePeer pee
 	}
 
 	for _, height := range req.Heights {
-		preference := types.ID{}
-		record, ok := eng.blocks[height]
+		preference, ok := eng.shardFor(height).preference(height)
 		if !ok {
 			blockID, err := eng.getBlockID(height)
 			if err == nil {
 				preference = blockID
 			}
-		} else {
-			preference = record.GetPreference()
 		}
 
 		resp.Votes = append(resp.Votes, preference.Bytes())
 	}
 
-	respChan <- resp
+	return resp
 }
 
-func (eng *ConsensusEngine) handleRequestExpiration(key string, p peer.ID) {
-	eng.chooser.RegisterDialFailure(p)
-	r, ok := eng.queries[key]
+func (sh *engineShard) handleRequestExpiration(key string, p peer.ID) {
+	sh.eng.chooser.RegisterDialFailure(p)
+	r, ok := sh.queries[key]
 	if !ok {
 		return
 	}
-	delete(eng.queries, key)
+	delete(sh.queries, key)
 	heights := r.GetHeights()
 	for _, height := range heights {
-		bc, ok := eng.blocks[height]
+		bc, ok := sh.blocks[height]
 		if ok {
 			bc.inflightRequests--
 		}
 	}
 }
 
-func (eng *ConsensusEngine) queueMessageToPeer(pollReq *wire.MsgPollRequest, peer peer.ID) {
+func (sh *engineShard) queueMessageToPeer(pollReq *wire.MsgPollRequest, peer peer.ID) {
+	eng := sh.eng
 	var (
 		key  = queryKey(pollReq.Request_ID, peer.String())
 		resp = new(wire.MsgPollResponse)
 	)
 
+	ctx, span := tracer.Start(eng.ctx, "consensus.poll", trace.WithAttributes(
+		attribute.String("illium.peer", peer.String()),
+		attribute.Int("illium.heights", len(pollReq.Heights)),
+	))
+	defer span.End()
+
 	req := &wire.MsgConsensusRequest{
 		Msg: &wire.MsgConsensusRequest_PollRequest{
 			PollRequest: pollReq,
 		},
 	}
 
+	eng.recorder.recordSentPoll(peer, pollReq)
 	if peer != eng.self {
-		err := eng.ms.SendRequest(eng.ctx, peer, req, resp)
+		// Polls are pipelined over a single stream per peer and the
+		// responses matched back up by request ID.
+		start := time.Now()
+		err := eng.ms.SendMultiplexedRequest(ctx, peer, pollReq.Request_ID, req, resp)
 		if err != nil {
-			eng.msgChan <- &requestExpirationMsg{key, peer}
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			eng.recorder.recordPollExpired(peer, pollReq.Request_ID)
+			eng.scores.recordTimeout(peer)
+			sh.respQueue.push(&requestExpirationMsg{key, peer}, eng.quit)
 			return
 		}
+		eng.scores.recordResponse(peer, time.Since(start))
 	} else {
 		// Sleep here to not artificially advantage our own node.
 		time.Sleep(time.Millisecond * 20)
 
-		respCh := make(chan *wire.MsgPollResponse)
-		eng.msgChan <- &queryMsg{
+		respCh := make(chan *wire.MsgPollResponse, 1)
+		queued := eng.queryQueue.push(&queryMsg{
 			request:    pollReq,
 			remotePeer: peer,
 			respChan:   respCh,
+		}, eng.quit)
+		if !queued {
+			return
+		}
+		select {
+		case resp = <-respCh:
+		case <-eng.quit:
+			return
+		}
+		if resp == nil {
+			return
 		}
-		resp = <-respCh
 	}
+	eng.recorder.recordReceivedPollResponse(peer, resp)
 
-	eng.msgChan <- &registerVotesMsg{
+	sh.respQueue.push(&registerVotesMsg{
 		p:    peer,
 		resp: resp,
-	}
+	}, eng.quit)
 }
 
-func (eng *ConsensusEngine) handleRegisterVotes(p peer.ID, resp *wire.MsgPollResponse) {
+func (sh *engineShard) handleRegisterVotes(p peer.ID, resp *wire.MsgPollResponse) {
+	eng := sh.eng
 	eng.chooser.RegisterDialSuccess(p)
 	key := queryKey(resp.Request_ID, p.String())
 
-	r, ok := eng.queries[key]
+	r, ok := sh.queries[key]
 	if !ok {
 		log.Debug("Received poll response with an unknown request ID", log.Args("peer", p))
-		eng.network.IncreaseBanscore(p, 30, 0, "sent poll response with unknown request ID")
+		eng.increaseBanscore(p, 30, 0, "sent poll response with unknown request ID")
 		return
 	}
 
 	// Always delete the key if it's present
-	delete(eng.queries, key)
+	delete(sh.queries, key)
 
-	if r.IsExpired() {
+	if r.IsExpired(eng.params.AvalancheRequestTimeout) {
 		log.Debug("Received poll response with an expired request", log.Args("peer", p))
-		eng.network.IncreaseBanscore(p, 0, 20, "sent poll response for expired request")
+		eng.increaseBanscore(p, 0, 20, "sent poll response for expired request")
 		return
 	}
 
 	heights := r.GetHeights()
 	if len(resp.Votes) != len(heights) {
 		log.Debug("Received poll response with an incorrect number of votes", log.Args("peer", p))
-		eng.network.IncreaseBanscore(p, 30, 0, "sent poll response with incorrect number of votes")
+		eng.increaseBanscore(p, 30, 0, "sent poll response with incorrect number of votes")
 		return
 	}
 
 	for i, height := range heights {
-		bc, ok := eng.blocks[height]
+		bc, ok := sh.blocks[height]
 		if !ok {
 			// We are not voting on this anymore
 			continue
@@ -455,11 +602,14 @@ func (eng *ConsensusEngine) handleRegisterVotes(p peer.ID, resp *wire.MsgPollRes
 
 		if len(resp.Votes[i]) != hash.HashSize {
 			log.Debug("Received poll response with an incorrect hash length", log.Args("peer", p))
-			eng.network.IncreaseBanscore(p, 30, 0, "sent poll response with incorrect hash length")
+			eng.increaseBanscore(p, 30, 0, "sent poll response with incorrect hash length")
 			continue
 		}
 
 		voteID := types.NewID(resp.Votes[i])
+		if p != eng.self {
+			eng.scores.recordVote(p, height, voteID)
+		}
 
 		_, ok = bc.blockVotes[voteID]
 		if !ok && voteID.Compare(types.ID{}) != 0 {
@@ -469,23 +619,31 @@ func (eng *ConsensusEngine) handleRegisterVotes(p peer.ID, resp *wire.MsgPollRes
 			voteID = types.ID{}
 		}
 
+		finalizedID, finalized := bc.RecordVote(voteID)
+		sh.dirty = true
+
 		// Block finalized, fire callbacks
-		if finalizedID, ok := bc.RecordVote(voteID); ok {
-			callback, ok := eng.callbacks[finalizedID]
+		if finalized {
+			eng.latency.observe(time.Since(bc.timestamp))
+			sh.endBlockSpan(finalizedID, StatusFinalized.String())
+			callback, ok := sh.callbacks[finalizedID]
 			if ok && callback != nil {
-				delete(eng.callbacks, finalizedID)
+				delete(sh.callbacks, finalizedID)
 				go func(cb chan<- Status) {
 					cb <- StatusFinalized
 				}(callback)
 			}
 
-			for id := range bc.blockVotes {
-				if id.Compare(finalizedID) != 0 {
-					callback, ok := eng.callbacks[id]
+			// The conflicting blocks were rejected when the
+			// block finalized.
+			for id, record := range bc.blockVotes {
+				if record.Status() == StatusRejected {
+					sh.endBlockSpan(id, StatusRejected.String())
+					callback, ok := sh.callbacks[id]
 					if ok && callback != nil {
-						delete(eng.callbacks, id)
+						delete(sh.callbacks, id)
 						go func(cb chan<- Status) {
-							callback <- StatusRejected
+							cb <- StatusRejected
 						}(callback)
 					}
 				}
@@ -515,19 +673,43 @@ func (eng *ConsensusEngine) GetBlockFromPeer(p peer.ID, blkID types.ID) (*blocks
 	return resp.Block, nil
 }
 
-func (eng *ConsensusEngine) pollLoop() {
-	if eng.valConn.ConnectedStakePercentage() < MinConnectedStakeThreshold {
+func (sh *engineShard) pollLoop() {
+	if sh.eng.valConn.ConnectedStakePercentage() < MinConnectedStakeThreshold {
 		return
 	}
-	p := eng.chooser.WeightedRandomValidator()
+	sampleSize := 1
+	if time.Now().Before(sh.pollExpireTime) {
+		sampleSize = sh.pollSampleSize
+	}
+	for i := 0; i < sampleSize; i++ {
+		sh.pollValidator()
+	}
+}
+
+func (sh *engineShard) pollValidator() {
+	// Nothing to poll for. Skip choosing a validator as a validator
+	// in backoff is counted as a dial failure.
+	if len(sh.blocks) == 0 {
+		return
+	}
+	p := sh.eng.chooser.WeightedRandomValidator()
 	if p == "" {
 		return
 	}
+	// Unreliable validators are polled less often, or not at all
+	// while banned.
+	if p != sh.eng.self && !sh.eng.scores.shouldPoll(p) {
+		return
+	}
 
 	var heights []uint32
-	for height, record := range eng.blocks {
+	for height, record := range sh.blocks {
 		if time.Since(record.timestamp) > DeleteInventoryAfter {
-			delete(eng.blocks, height)
+			for id := range record.blockVotes {
+				sh.endBlockSpan(id, "expired")
+			}
+			delete(sh.blocks, height)
+			sh.dirty = true
 			continue
 		}
 
@@ -549,14 +731,23 @@ func (eng *ConsensusEngine) pollLoop() {
 	requestID := rand.Uint32()
 
 	key := queryKey(requestID, p.String())
-	eng.queries[key] = NewRequestRecord(time.Now().Unix(), heights)
+	sh.queries[key] = NewRequestRecord(time.Now().Unix(), heights)
 
 	req := &wire.MsgPollRequest{
 		Request_ID: requestID,
 		Heights:    heights,
 	}
 
-	go eng.queueMessageToPeer(req, p)
+	go sh.queueMessageToPeer(req, p)
+}
+
+// increaseBanscore increases the peer's banscore. There is no network
+// when replaying recorded traffic so this does nothing then.
+func (eng *ConsensusEngine) increaseBanscore(p peer.ID, persistent, transient uint32, reason string) {
+	if eng.network == nil {
+		return
+	}
+	eng.network.IncreaseBanscore(p, persistent, transient, reason)
 }
 
 func queryKey(requestID uint32, peerID string) string {
@@ -51,7 +51,7 @@ type mockNode struct {
 	engine *ConsensusEngine
 }
 
-func newMockNode(mn mocknet.Mocknet) (*mockNode, error) {
+func newMockNode(mn mocknet.Mocknet, opts ...Option) (*mockNode, error) {
 	host, err := mn.GenPeer()
 	if err != nil {
 		return nil, err
@@ -71,7 +71,7 @@ func newMockNode(mn mocknet.Mocknet) (*mockNode, error) {
 	if err != nil {
 		return nil, err
 	}
-	engine, err := NewConsensusEngine(context.Background(),
+	engine, err := NewConsensusEngine(context.Background(), append([]Option{
 		Params(&params.RegestParams),
 		Network(network),
 		ValidatorConnector(&MockValConn{}),
@@ -80,26 +80,26 @@ func newMockNode(mn mocknet.Mocknet) (*mockNode, error) {
 		GetBlockID(func(height uint32) (types.ID, error) { return types.ID{}, errors.New("not found") }),
 		RequestBlock(func(id types.ID, id2 peer.ID) {}),
 		PeerID(network.Host().ID()),
-	)
+	}, opts...)...)
 	if err != nil {
 		return nil, err
 	}
 	return &mockNode{engine: engine}, nil
 }
 
-func setup() ([]*mockNode, *mockNode, func(), error) {
+func setup(opts ...Option) ([]*mockNode, *mockNode, func(), error) {
 	mn := mocknet.New()
 	numNodes := 100
 	nodes := make([]*mockNode, 0, numNodes)
 	for i := 0; i < numNodes; i++ {
-		node, err := newMockNode(mn)
+		node, err := newMockNode(mn, opts...)
 		if err != nil {
 			return nil, nil, nil, err
 		}
 		nodes = append(nodes, node)
 	}
 
-	testNode, err := newMockNode(mn)
+	testNode, err := newMockNode(mn, opts...)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -157,7 +157,7 @@ func TestConsensusEngine(t *testing.T) {
 		case <-cb:
 			t.Fatal("Callback should not have been called block 2")
 		case <-time.After(time.Second * 5):
-			assert.Equal(t, StatusNotPreferred, testNode.engine.blocks[blk2.Header.Height].blockVotes[blk2.ID()].Status())
+			assert.Equal(t, StatusNotPreferred, testNode.engine.shardFor(blk2.Header.Height).blocks[blk2.Header.Height].blockVotes[blk2.ID()].Status())
 		}
 	})
 	t.Run("Test block finalization of all nodes with initial preference yes", func(t *testing.T) {
@@ -208,9 +208,9 @@ func TestConsensusEngine(t *testing.T) {
 		case <-cb:
 			t.Fatal("Callback should not have been called for block 4")
 		case <-ticker.C:
-			assert.Equal(t, StatusNotPreferred, testNode.engine.blocks[blk4.Header.Height].blockVotes[blk4.ID()].Status())
+			assert.Equal(t, StatusNotPreferred, testNode.engine.shardFor(blk4.Header.Height).blocks[blk4.Header.Height].blockVotes[blk4.ID()].Status())
 			for _, n := range nodes {
-				assert.Equal(t, StatusNotPreferred, n.engine.blocks[blk4.Header.Height].blockVotes[blk4.ID()].Status())
+				assert.Equal(t, StatusNotPreferred, n.engine.shardFor(blk4.Header.Height).blocks[blk4.Header.Height].blockVotes[blk4.ID()].Status())
 			}
 		}
 	})
@@ -236,7 +236,7 @@ func TestConsensusEngine(t *testing.T) {
 			assert.Equal(t, status, StatusFinalized)
 			yes = true
 		case <-ticker.C:
-			assert.Equal(t, StatusNotPreferred, testNode.engine.blocks[blk5.Header.Height].blockVotes[blk5.ID()].Status())
+			assert.Equal(t, StatusNotPreferred, testNode.engine.shardFor(blk5.Header.Height).blocks[blk5.Header.Height].blockVotes[blk5.ID()].Status())
 			yes = false
 		}
 
@@ -257,7 +257,7 @@ func TestConsensusEngine(t *testing.T) {
 			case <-ticker.C:
 				if !yes {
 					for _, n := range nodes {
-						assert.Equal(t, StatusNotPreferred, n.engine.blocks[blk5.Header.Height].blockVotes[blk5.ID()].Status())
+						assert.Equal(t, StatusNotPreferred, n.engine.shardFor(blk5.Header.Height).blocks[blk5.Header.Height].blockVotes[blk5.ID()].Status())
 					}
 					break loop
 				} else {
@@ -293,12 +293,42 @@ func TestConsensusEngine(t *testing.T) {
 		select {
 		case status := <-cbb2:
 			assert.Equal(t, status, StatusRejected)
-			assert.Equal(t, StatusNotPreferred, testNode.engine.blocks[blk6b.Header.Height].blockVotes[blk6b.ID()].Status())
+			assert.Equal(t, StatusRejected, testNode.engine.shardFor(blk6b.Header.Height).blocks[blk6b.Header.Height].blockVotes[blk6b.ID()].Status())
 		case <-ticker.C:
 			t.Errorf("Failed to reject block 6b for test node")
 		}
 	})
 
+	t.Run("Test block conflicting with a finalized block is rejected", func(t *testing.T) {
+		nodes, testNode, teardown, err := setup()
+		assert.NoError(t, err)
+		defer teardown()
+
+		blk7a := &blocks.Block{Header: &blocks.BlockHeader{Version: 0, Height: 7}}
+		blk7b := &blocks.Block{Header: &blocks.BlockHeader{Version: 1, Height: 7}}
+		for _, node := range nodes {
+			node.engine.NewBlock(blk7a.Header, true, nil)
+		}
+
+		cba := make(chan Status)
+		testNode.engine.NewBlock(blk7a.Header, true, cba)
+		select {
+		case status := <-cba:
+			assert.Equal(t, StatusFinalized, status)
+		case <-time.After(time.Second * 30):
+			t.Fatal("Failed to finalize block 7a")
+		}
+
+		cbb := make(chan Status)
+		testNode.engine.NewBlock(blk7b.Header, true, cbb)
+		select {
+		case status := <-cbb:
+			assert.Equal(t, StatusRejected, status)
+		case <-time.After(time.Second * 5):
+			t.Fatal("Failed to reject block 7b")
+		}
+	})
+
 	t.Run("Test block finalization of all nodes with conflicting blocks", func(t *testing.T) {
 		nodes, testNode, teardown, err := setup()
 		assert.NoError(t, err)
@@ -364,11 +394,11 @@ func TestConsensusEngine(t *testing.T) {
 		assert.Equal(t, 100, finalized)
 		assert.Equal(t, 100, rejected)
 
-		blockAStatus := testNode.engine.blocks[blk6a.Header.Height].blockVotes[blk6a.ID()].Status()
-		blockBStatus := testNode.engine.blocks[blk6b.Header.Height].blockVotes[blk6b.ID()].Status()
+		blockAStatus := testNode.engine.shardFor(blk6a.Header.Height).blocks[blk6a.Header.Height].blockVotes[blk6a.ID()].Status()
+		blockBStatus := testNode.engine.shardFor(blk6b.Header.Height).blocks[blk6b.Header.Height].blockVotes[blk6b.ID()].Status()
 		for _, n := range nodes {
-			assert.Equal(t, blockAStatus, n.engine.blocks[blk6a.Header.Height].blockVotes[blk6a.ID()].Status())
-			assert.Equal(t, blockBStatus, n.engine.blocks[blk6b.Header.Height].blockVotes[blk6b.ID()].Status())
+			assert.Equal(t, blockAStatus, n.engine.shardFor(blk6a.Header.Height).blocks[blk6a.Header.Height].blockVotes[blk6a.ID()].Status())
+			assert.Equal(t, blockBStatus, n.engine.shardFor(blk6b.Header.Height).blocks[blk6b.Header.Height].blockVotes[blk6b.ID()].Status())
 		}
 	})
 	t.Run("Test block finalization of all nodes with many blocks", func(t *testing.T) {
@@ -430,10 +460,10 @@ func TestConsensusEngine(t *testing.T) {
 				fmt.Printf("%08b\n", blk6e.ID()[0])
 				fmt.Printf("%08b\n", blk6f.ID()[0])
 				fmt.Println("*****")
-				fmt.Printf("%d\n", testNode.engine.blocks[6].bitRecord.activeBit)
-				fmt.Printf("%08b\n", testNode.engine.blocks[6].bitRecord.finalizedBits[0])
+				fmt.Printf("%d\n", testNode.engine.shardFor(6).blocks[6].bitRecord.activeBit)
+				fmt.Printf("%08b\n", testNode.engine.shardFor(6).blocks[6].bitRecord.finalizedBits[0])
 				t.Errorf("Failed to finalize or reject block 6")
-				for _, rec := range testNode.engine.blocks[6].blockVotes {
+				for _, rec := range testNode.engine.shardFor(6).blocks[6].blockVotes {
 					fmt.Printf("%08b\n", rec.votes)
 					fmt.Printf("%08b\n", rec.consider)
 					fmt.Printf("%08b\n", rec.confidence)
@@ -467,20 +497,49 @@ func TestConsensusEngine(t *testing.T) {
 		assert.Equal(t, finalized, 100)
 		assert.Equal(t, rejected, 500)
 
-		blkAStatus := testNode.engine.blocks[blk6a.Header.Height].blockVotes[blk6a.ID()].Status()
-		blkBStatus := testNode.engine.blocks[blk6b.Header.Height].blockVotes[blk6b.ID()].Status()
-		blkCStatus := testNode.engine.blocks[blk6c.Header.Height].blockVotes[blk6c.ID()].Status()
-		blkDStatus := testNode.engine.blocks[blk6d.Header.Height].blockVotes[blk6d.ID()].Status()
-		blkEStatus := testNode.engine.blocks[blk6e.Header.Height].blockVotes[blk6e.ID()].Status()
-		blkFStatus := testNode.engine.blocks[blk6f.Header.Height].blockVotes[blk6f.ID()].Status()
+		blkAStatus := testNode.engine.shardFor(blk6a.Header.Height).blocks[blk6a.Header.Height].blockVotes[blk6a.ID()].Status()
+		blkBStatus := testNode.engine.shardFor(blk6b.Header.Height).blocks[blk6b.Header.Height].blockVotes[blk6b.ID()].Status()
+		blkCStatus := testNode.engine.shardFor(blk6c.Header.Height).blocks[blk6c.Header.Height].blockVotes[blk6c.ID()].Status()
+		blkDStatus := testNode.engine.shardFor(blk6d.Header.Height).blocks[blk6d.Header.Height].blockVotes[blk6d.ID()].Status()
+		blkEStatus := testNode.engine.shardFor(blk6e.Header.Height).blocks[blk6e.Header.Height].blockVotes[blk6e.ID()].Status()
+		blkFStatus := testNode.engine.shardFor(blk6f.Header.Height).blocks[blk6f.Header.Height].blockVotes[blk6f.ID()].Status()
 
 		for _, n := range nodes {
-			assert.Equal(t, blkAStatus, n.engine.blocks[blk6a.Header.Height].blockVotes[blk6a.ID()].Status())
-			assert.Equal(t, blkBStatus, n.engine.blocks[blk6b.Header.Height].blockVotes[blk6b.ID()].Status())
-			assert.Equal(t, blkCStatus, n.engine.blocks[blk6c.Header.Height].blockVotes[blk6c.ID()].Status())
-			assert.Equal(t, blkDStatus, n.engine.blocks[blk6d.Header.Height].blockVotes[blk6d.ID()].Status())
-			assert.Equal(t, blkEStatus, n.engine.blocks[blk6e.Header.Height].blockVotes[blk6e.ID()].Status())
-			assert.Equal(t, blkFStatus, n.engine.blocks[blk6f.Header.Height].blockVotes[blk6f.ID()].Status())
+			assert.Equal(t, blkAStatus, n.engine.shardFor(blk6a.Header.Height).blocks[blk6a.Header.Height].blockVotes[blk6a.ID()].Status())
+			assert.Equal(t, blkBStatus, n.engine.shardFor(blk6b.Header.Height).blocks[blk6b.Header.Height].blockVotes[blk6b.ID()].Status())
+			assert.Equal(t, blkCStatus, n.engine.shardFor(blk6c.Header.Height).blocks[blk6c.Header.Height].blockVotes[blk6c.ID()].Status())
+			assert.Equal(t, blkDStatus, n.engine.shardFor(blk6d.Header.Height).blocks[blk6d.Header.Height].blockVotes[blk6d.ID()].Status())
+			assert.Equal(t, blkEStatus, n.engine.shardFor(blk6e.Header.Height).blocks[blk6e.Header.Height].blockVotes[blk6e.ID()].Status())
+			assert.Equal(t, blkFStatus, n.engine.shardFor(blk6f.Header.Height).blocks[blk6f.Header.Height].blockVotes[blk6f.ID()].Status())
+		}
+	})
+
+	t.Run("Test block finalization of blocks across shards", func(t *testing.T) {
+		nodes, testNode, teardown, err := setup(Shards(4))
+		assert.NoError(t, err)
+		defer teardown()
+
+		var headers []*blocks.BlockHeader
+		for height := uint32(10); height < 18; height++ {
+			headers = append(headers, &blocks.BlockHeader{Height: height})
+		}
+		for _, node := range nodes {
+			for _, header := range headers {
+				node.engine.NewBlock(header, true, nil)
+			}
+		}
+
+		cb := make(chan Status)
+		for _, header := range headers {
+			testNode.engine.NewBlock(header, true, cb)
+		}
+		for range headers {
+			select {
+			case status := <-cb:
+				assert.Equal(t, StatusFinalized, status)
+			case <-time.After(time.Second * 30):
+				t.Fatal("Failed to finalize blocks")
+			}
 		}
 	})
 }
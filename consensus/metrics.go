@@ -0,0 +1,117 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package consensus
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"time"
+)
+
+var (
+	metricActiveHeights = prometheus.NewDesc(
+		"ilxd_consensus_active_heights",
+		"The number of heights with a block which has not finalized.",
+		nil, nil)
+	metricActiveBlocks = prometheus.NewDesc(
+		"ilxd_consensus_active_blocks",
+		"The number of blocks being voted on which have not finalized or been rejected.",
+		nil, nil)
+	metricOldestActive = prometheus.NewDesc(
+		"ilxd_consensus_oldest_active_height_seconds",
+		"How long the engine has been voting on the oldest height which has not finalized.",
+		nil, nil)
+	metricMinConfidence = prometheus.NewDesc(
+		"ilxd_consensus_min_preferred_confidence",
+		"The lowest confidence of the preferred blocks which have not finalized.",
+		nil, nil)
+	metricInflightQueries = prometheus.NewDesc(
+		"ilxd_consensus_inflight_queries",
+		"The number of polls sent to validators which have not been answered or expired.",
+		nil, nil)
+	metricQueuedMessages = prometheus.NewDesc(
+		"ilxd_consensus_queued_messages",
+		"The number of messages waiting in the engine's queues.",
+		[]string{"queue"}, nil)
+	metricFinalizationLatency = prometheus.NewDesc(
+		"ilxd_consensus_finalization_latency_seconds",
+		"How long blocks took to finalize after being added to the engine.",
+		nil, nil)
+)
+
+// metricsCollector exports the engine's state to prometheus. The state
+// is read from the shards when the metrics are scraped.
+type metricsCollector struct {
+	eng *ConsensusEngine
+}
+
+// MetricsCollector returns a prometheus collector for the engine's state.
+func (eng *ConsensusEngine) MetricsCollector() prometheus.Collector {
+	return &metricsCollector{eng: eng}
+}
+
+func (c *metricsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- metricActiveHeights
+	ch <- metricActiveBlocks
+	ch <- metricOldestActive
+	ch <- metricMinConfidence
+	ch <- metricInflightQueries
+	ch <- metricQueuedMessages
+	ch <- metricFinalizationLatency
+}
+
+func (c *metricsCollector) Collect(ch chan<- prometheus.Metric) {
+	state := c.eng.State()
+
+	var (
+		activeHeights int
+		activeBlocks  int
+		oldest        time.Duration
+		minConfidence = -1
+	)
+	for _, hs := range state.Heights {
+		active := false
+		for _, b := range hs.Blocks {
+			switch b.Status {
+			case StatusPreferred:
+				if minConfidence < 0 || int(b.Confidence) < minConfidence {
+					minConfidence = int(b.Confidence)
+				}
+				fallthrough
+			case StatusNotPreferred:
+				active = true
+				activeBlocks++
+			}
+		}
+		if active {
+			activeHeights++
+			if hs.Age > oldest {
+				oldest = hs.Age
+			}
+		}
+	}
+	if minConfidence < 0 {
+		minConfidence = 0
+	}
+
+	ch <- prometheus.MustNewConstMetric(metricActiveHeights, prometheus.GaugeValue, float64(activeHeights))
+	ch <- prometheus.MustNewConstMetric(metricActiveBlocks, prometheus.GaugeValue, float64(activeBlocks))
+	ch <- prometheus.MustNewConstMetric(metricOldestActive, prometheus.GaugeValue, oldest.Seconds())
+	ch <- prometheus.MustNewConstMetric(metricMinConfidence, prometheus.GaugeValue, float64(minConfidence))
+	ch <- prometheus.MustNewConstMetric(metricInflightQueries, prometheus.GaugeValue, float64(state.InflightQueries))
+	ch <- prometheus.MustNewConstMetric(metricQueuedMessages, prometheus.GaugeValue, float64(state.Queues.Responses.Queued), "responses")
+	ch <- prometheus.MustNewConstMetric(metricQueuedMessages, prometheus.GaugeValue, float64(state.Queues.Housekeeping.Queued), "housekeeping")
+
+	// Prometheus buckets are cumulative.
+	var (
+		latency    = state.FinalizationLatency
+		buckets    = make(map[float64]uint64, len(latency.Buckets))
+		cumulative uint64
+	)
+	for i, bound := range latency.Buckets {
+		cumulative += latency.Counts[i]
+		buckets[bound.Seconds()] = cumulative
+	}
+	ch <- prometheus.MustNewConstHistogram(metricFinalizationLatency, latency.Count, latency.Sum.Seconds(), buckets)
+}
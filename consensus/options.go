@@ -110,6 +110,28 @@ func PeerID(self peer.ID) Option {
 	}
 }
 
+// Shards is the number of shards the vote records are split across.
+// Each shard is handled by its own goroutine.
+//
+// This option is optional. The default is 4.
+func Shards(n int) Option {
+	return func(cfg *config) error {
+		cfg.shards = n
+		return nil
+	}
+}
+
+// Recorder records the engine's avalanche traffic so that it can
+// be replayed offline with ReplayTraffic.
+//
+// This option is optional.
+func Recorder(r *TrafficRecorder) Option {
+	return func(cfg *config) error {
+		cfg.recorder = r
+		return nil
+	}
+}
+
 // Config specifies the blockchain configuration.
 type config struct {
 	params           *params.NetworkParams
@@ -120,6 +142,8 @@ type config struct {
 	requestBlockFunc RequestBlockFunc
 	getBlockFunc     GetBlockFunc
 	getBlockIDFunc   GetBlockIDFunc
+	shards           int
+	recorder         *TrafficRecorder
 }
 
 func (cfg *config) validate() error {
@@ -0,0 +1,242 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package consensus
+
+import (
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/project-illium/ilxd/types"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// PeerScoreBanThreshold is the score below which a validator is
+	// temporarily banned from being polled.
+	PeerScoreBanThreshold = 0.2
+
+	// PeerScoreBanDuration is how long a validator with a score below
+	// the threshold is excluded from polling.
+	PeerScoreBanDuration = 10 * time.Minute
+
+	// peerScoreMinSamples is the number of polls which must have been
+	// answered or failed before a validator's score is used. Until then
+	// the validator is polled at its full weight.
+	peerScoreMinSamples = 20
+
+	// peerScoreDecay is the weight given to each new sample in the moving
+	// averages. Old behavior is forgotten over roughly 1/peerScoreDecay
+	// samples so a validator which recovers regains its weight.
+	peerScoreDecay = 0.05
+
+	// peerScoreLatencyTarget is the average response latency at or below
+	// which a validator is not penalized.
+	peerScoreLatencyTarget = time.Second
+
+	// maxTrackedVoteHeights is the number of heights for which each
+	// validator's last vote is remembered when looking for flip-flops.
+	maxTrackedVoteHeights = 64
+)
+
+// PeerScore is a snapshot of the reliability of a validator as seen by
+// the consensus engine.
+type PeerScore struct {
+	Peer peer.ID
+	// Score is between zero and one. The validator is polled with its
+	// stake weight multiplied by the score.
+	Score float64
+	// Responses is the number of polls the validator has answered.
+	Responses uint64
+	// Timeouts is the number of polls which failed or timed out.
+	Timeouts uint64
+	// FlipFlops is the number of times the validator changed its vote
+	// from one block to another at the same height.
+	FlipFlops uint64
+	// AvgLatency is the moving average of the response latency.
+	AvgLatency time.Duration
+	// BannedUntil is the time until which the validator is excluded from
+	// polling. It's zero if the validator is not banned.
+	BannedUntil time.Time
+}
+
+type peerRecord struct {
+	responses   uint64
+	timeouts    uint64
+	flips       uint64
+	timeoutRate float64
+	flipRate    float64
+	latency     time.Duration
+	votes       map[uint32]types.ID
+	bannedUntil time.Time
+}
+
+// score returns the validator's score. It's computed from the moving
+// averages of the timeout rate, flip-flop rate, and latency.
+func (r *peerRecord) score() float64 {
+	if r.responses+r.timeouts < peerScoreMinSamples {
+		return 1
+	}
+	s := (1 - r.timeoutRate) * (1 - r.flipRate)
+	if r.latency > peerScoreLatencyTarget {
+		s *= float64(peerScoreLatencyTarget) / float64(r.latency)
+	}
+	return s
+}
+
+// peerScorer tracks the reliability of the validators the engine polls.
+// It's shared by the shards so access is guarded by a mutex.
+type peerScorer struct {
+	peers map[peer.ID]*peerRecord
+	mtx   sync.Mutex
+}
+
+func newPeerScorer() *peerScorer {
+	return &peerScorer{
+		peers: make(map[peer.ID]*peerRecord),
+	}
+}
+
+func (ps *peerScorer) record(p peer.ID) *peerRecord {
+	r, ok := ps.peers[p]
+	if !ok {
+		r = &peerRecord{
+			votes: make(map[uint32]types.ID),
+		}
+		ps.peers[p] = r
+	}
+	return r
+}
+
+// recordResponse records a poll response received after the given latency.
+func (ps *peerScorer) recordResponse(p peer.ID, latency time.Duration) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	r := ps.record(p)
+	r.responses++
+	r.timeoutRate *= 1 - peerScoreDecay
+	if r.responses == 1 {
+		r.latency = latency
+	} else {
+		r.latency += time.Duration(peerScoreDecay * float64(latency-r.latency))
+	}
+	ps.checkBan(p, r)
+}
+
+// recordTimeout records a poll which failed or timed out.
+func (ps *peerScorer) recordTimeout(p peer.ID) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	r := ps.record(p)
+	r.timeouts++
+	r.timeoutRate = r.timeoutRate*(1-peerScoreDecay) + peerScoreDecay
+	ps.checkBan(p, r)
+}
+
+// recordVote records the validator's vote at the height. Changing the vote
+// from one block to another counts as a flip-flop. Votes for unknown blocks
+// are recorded as the zero ID and don't count.
+func (ps *peerScorer) recordVote(p peer.ID, height uint32, voteID types.ID) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	r := ps.record(p)
+	prev, ok := r.votes[height]
+	flipped := ok && prev != voteID && prev != (types.ID{}) && voteID != (types.ID{})
+	if flipped {
+		r.flips++
+		r.flipRate = r.flipRate*(1-peerScoreDecay) + peerScoreDecay
+	} else {
+		r.flipRate *= 1 - peerScoreDecay
+	}
+	if !ok && len(r.votes) >= maxTrackedVoteHeights {
+		lowest := height
+		for h := range r.votes {
+			if h < lowest {
+				lowest = h
+			}
+		}
+		if lowest == height {
+			return
+		}
+		delete(r.votes, lowest)
+	}
+	r.votes[height] = voteID
+	ps.checkBan(p, r)
+}
+
+// checkBan bans the validator if its score has fallen below the
+// threshold. The moving averages are reset so the validator starts
+// over once the ban expires.
+//
+// The mutex must be held when calling this.
+func (ps *peerScorer) checkBan(p peer.ID, r *peerRecord) {
+	if r.score() >= PeerScoreBanThreshold {
+		return
+	}
+	log.Debug("Banning unreliable validator from consensus polling", log.ArgsFromMap(map[string]any{
+		"peer":         p,
+		"timeout rate": r.timeoutRate,
+		"flip rate":    r.flipRate,
+		"latency":      r.latency,
+		"duration":     PeerScoreBanDuration,
+	}))
+	r.bannedUntil = time.Now().Add(PeerScoreBanDuration)
+	r.timeoutRate = 0
+	r.flipRate = 0
+	r.latency = 0
+	r.responses = 0
+	r.timeouts = 0
+	r.flips = 0
+}
+
+// shouldPoll returns whether a validator chosen by stake weight should
+// be polled. Validators with a score below one are skipped with a
+// probability of one minus the score, which amounts to choosing them by
+// their stake weight multiplied by their score. Banned validators are
+// always skipped.
+func (ps *peerScorer) shouldPoll(p peer.ID) bool {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	r, ok := ps.peers[p]
+	if !ok {
+		return true
+	}
+	if time.Now().Before(r.bannedUntil) {
+		return false
+	}
+	score := r.score()
+	return score >= 1 || rand.Float64() < score
+}
+
+// scores returns the scores of all the tracked validators sorted by
+// peer ID.
+func (ps *peerScorer) scores() []PeerScore {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	ret := make([]PeerScore, 0, len(ps.peers))
+	for p, r := range ps.peers {
+		s := PeerScore{
+			Peer:       p,
+			Score:      r.score(),
+			Responses:  r.responses,
+			Timeouts:   r.timeouts,
+			FlipFlops:  r.flips,
+			AvgLatency: r.latency,
+		}
+		if time.Now().Before(r.bannedUntil) {
+			s.BannedUntil = r.bannedUntil
+		}
+		ret = append(ret, s)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Peer < ret[j].Peer
+	})
+	return ret
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package consensus
+
+import (
+	"crypto/rand"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/project-illium/ilxd/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestPeerScorer(t *testing.T) {
+	peers := make([]peer.ID, 3)
+	for i := range peers {
+		priv, _, err := crypto.GenerateEd25519Key(rand.Reader)
+		assert.NoError(t, err)
+		pid, err := peer.IDFromPrivateKey(priv)
+		assert.NoError(t, err)
+		peers[i] = pid
+	}
+	good, flaky, flipper := peers[0], peers[1], peers[2]
+
+	ps := newPeerScorer()
+	for i := 0; i < peerScoreMinSamples; i++ {
+		ps.recordResponse(good, time.Millisecond*100)
+		ps.recordVote(good, uint32(i), types.ID{0x01})
+		ps.recordResponse(flipper, time.Millisecond*100)
+	}
+	assert.True(t, ps.shouldPoll(good))
+	assert.True(t, ps.shouldPoll(flipper))
+
+	// A validator which stops answering is banned.
+	for i := 0; i < peerScoreMinSamples; i++ {
+		ps.recordResponse(flaky, time.Millisecond*100)
+	}
+	banned := false
+	for i := 0; i < 100 && !banned; i++ {
+		ps.recordTimeout(flaky)
+		banned = !ps.scores()[indexOf(ps.scores(), flaky)].BannedUntil.IsZero()
+	}
+	assert.True(t, banned)
+	assert.False(t, ps.shouldPoll(flaky))
+
+	// So is one which keeps changing its vote.
+	banned = false
+	for i := 0; i < 100 && !banned; i++ {
+		ps.recordVote(flipper, 1, types.ID{byte(i%2 + 1)})
+		banned = !ps.scores()[indexOf(ps.scores(), flipper)].BannedUntil.IsZero()
+	}
+	assert.True(t, banned)
+	assert.False(t, ps.shouldPoll(flipper))
+
+	// Votes for unknown blocks are not flip-flops.
+	for i := 0; i < 10; i++ {
+		ps.recordVote(good, 1, types.ID{})
+		ps.recordVote(good, 1, types.ID{0x01})
+	}
+	scores := ps.scores()
+	s := scores[indexOf(scores, good)]
+	assert.Equal(t, uint64(0), s.FlipFlops)
+	assert.Equal(t, float64(1), s.Score)
+	assert.True(t, s.BannedUntil.IsZero())
+	assert.Equal(t, time.Millisecond*100, s.AvgLatency)
+
+	// Slow validators are down-weighted but not banned.
+	slow := newPeerScorer()
+	for i := 0; i < peerScoreMinSamples*10; i++ {
+		slow.recordResponse(good, peerScoreLatencyTarget*2)
+	}
+	s = slow.scores()[0]
+	assert.InDelta(t, 0.5, s.Score, 0.01)
+	assert.True(t, s.BannedUntil.IsZero())
+}
+
+func indexOf(scores []PeerScore, p peer.ID) int {
+	for i, s := range scores {
+		if s.Peer == p {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package consensus
+
+import (
+	"sync/atomic"
+)
+
+const (
+	// responseQueueSize is the number of poll responses and request
+	// expirations which can be queued for each shard.
+	responseQueueSize = 1000
+
+	// queryQueueSize is the number of queries from other peers which
+	// can be queued for the engine. Queries beyond this are dropped and
+	// the peer's request will time out.
+	queryQueueSize = 500
+
+	// housekeepingQueueSize is the number of new blocks and other
+	// housekeeping messages which can be queued for each shard.
+	housekeepingQueueSize = 100
+)
+
+// MessageQueueStats reports the state of one of the engine's
+// message queues.
+type MessageQueueStats struct {
+	// Queued is the number of messages waiting to be handled.
+	Queued int
+	// Dropped is the number of messages dropped because the
+	// queue was full.
+	Dropped uint64
+	// Blocked is the number of times a sender had to wait
+	// because the queue was full.
+	Blocked uint64
+}
+
+func (s *MessageQueueStats) add(o MessageQueueStats) {
+	s.Queued += o.Queued
+	s.Dropped += o.Dropped
+	s.Blocked += o.Blocked
+}
+
+// QueueStats reports the state of the engine's message queues. Queries
+// from other peers are answered from a single queue while each shard
+// handles its poll responses before housekeeping such as new blocks.
+type QueueStats struct {
+	Responses    MessageQueueStats
+	Queries      MessageQueueStats
+	Housekeeping MessageQueueStats
+}
+
+// msgQueue is a bounded queue of messages for the engine or a shard.
+type msgQueue struct {
+	ch      chan interface{}
+	dropped uint64
+	blocked uint64
+}
+
+func newMsgQueue(size int) *msgQueue {
+	return &msgQueue{ch: make(chan interface{}, size)}
+}
+
+// push adds the message to the queue, waiting for room if the queue is
+// full. It returns false if quit is closed before the message is queued.
+func (q *msgQueue) push(m interface{}, quit <-chan struct{}) bool {
+	select {
+	case q.ch <- m:
+		return true
+	default:
+	}
+	atomic.AddUint64(&q.blocked, 1)
+	select {
+	case q.ch <- m:
+		return true
+	case <-quit:
+		return false
+	}
+}
+
+// tryPush adds the message to the queue if there is room, otherwise it
+// drops the message and returns false.
+func (q *msgQueue) tryPush(m interface{}) bool {
+	select {
+	case q.ch <- m:
+		return true
+	default:
+		atomic.AddUint64(&q.dropped, 1)
+		return false
+	}
+}
+
+func (q *msgQueue) stats() MessageQueueStats {
+	if q == nil {
+		return MessageQueueStats{}
+	}
+	return MessageQueueStats{
+		Queued:  len(q.ch),
+		Dropped: atomic.LoadUint64(&q.dropped),
+		Blocked: atomic.LoadUint64(&q.blocked),
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package consensus
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestMsgQueue(t *testing.T) {
+	q := newMsgQueue(2)
+	quit := make(chan struct{})
+
+	assert.True(t, q.tryPush(1))
+	assert.True(t, q.push(2, quit))
+	assert.False(t, q.tryPush(3))
+	assert.Equal(t, MessageQueueStats{Queued: 2, Dropped: 1}, q.stats())
+
+	// A push to a full queue waits until there is room.
+	pushed := make(chan bool)
+	go func() {
+		pushed <- q.push(4, quit)
+	}()
+	select {
+	case <-pushed:
+		t.Fatal("push did not wait for room in the queue")
+	case <-time.After(time.Millisecond * 50):
+	}
+	assert.Equal(t, 1, <-q.ch)
+	assert.True(t, <-pushed)
+	assert.Equal(t, MessageQueueStats{Queued: 2, Dropped: 1, Blocked: 1}, q.stats())
+
+	// And gives up if quit is closed.
+	go func() {
+		pushed <- q.push(5, quit)
+	}()
+	close(quit)
+	assert.False(t, <-pushed)
+	assert.Equal(t, 2, <-q.ch)
+	assert.Equal(t, 4, <-q.ch)
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package consensus
+
+import (
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/project-illium/ilxd/net"
+	"github.com/project-illium/ilxd/types/blocks"
+	"github.com/project-illium/ilxd/types/wire"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// TrafficRecorder writes the avalanche polls and responses sent and
+// received by the engine, along with the blocks passed into it, to a
+// file so that they can be fed back into an engine offline with
+// ReplayTraffic.
+//
+// The records are written as length delimited ConsensusRecord messages
+// wrapped in a wire.MsgEnvelope.
+type TrafficRecorder struct {
+	w      io.Writer
+	closer io.Closer
+	failed bool
+	mtx    sync.Mutex
+}
+
+// NewTrafficRecorder returns a TrafficRecorder which writes to w.
+func NewTrafficRecorder(w io.Writer) *TrafficRecorder {
+	return &TrafficRecorder{w: w}
+}
+
+// OpenTrafficRecorder returns a TrafficRecorder which appends to the
+// file at the path, creating it if it doesn't exist.
+func OpenTrafficRecorder(path string) (*TrafficRecorder, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &TrafficRecorder{w: f, closer: f}, nil
+}
+
+// Close closes the underlying file if the recorder opened it.
+func (r *TrafficRecorder) Close() error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if r.closer == nil {
+		return nil
+	}
+	return r.closer.Close()
+}
+
+func (r *TrafficRecorder) recordNewBlock(header *blocks.BlockHeader, isAcceptable bool) {
+	r.record("", &wire.ConsensusRecord{
+		Event: &wire.ConsensusRecord_NewBlock{
+			NewBlock: &wire.ConsensusRecordNewBlock{
+				Header:     header,
+				Acceptable: isAcceptable,
+			},
+		},
+	})
+}
+
+func (r *TrafficRecorder) recordSentPoll(p peer.ID, req *wire.MsgPollRequest) {
+	r.record(p, &wire.ConsensusRecord{
+		Event: &wire.ConsensusRecord_SentPoll{SentPoll: req},
+	})
+}
+
+func (r *TrafficRecorder) recordReceivedPollResponse(p peer.ID, resp *wire.MsgPollResponse) {
+	r.record(p, &wire.ConsensusRecord{
+		Event: &wire.ConsensusRecord_ReceivedPollResponse{ReceivedPollResponse: resp},
+	})
+}
+
+func (r *TrafficRecorder) recordPollExpired(p peer.ID, requestID uint32) {
+	r.record(p, &wire.ConsensusRecord{
+		Event: &wire.ConsensusRecord_PollExpired{PollExpired: requestID},
+	})
+}
+
+func (r *TrafficRecorder) recordReceivedPoll(p peer.ID, req *wire.MsgPollRequest) {
+	r.record(p, &wire.ConsensusRecord{
+		Event: &wire.ConsensusRecord_ReceivedPoll{ReceivedPoll: req},
+	})
+}
+
+func (r *TrafficRecorder) recordSentPollResponse(p peer.ID, resp *wire.MsgPollResponse) {
+	r.record(p, &wire.ConsensusRecord{
+		Event: &wire.ConsensusRecord_SentPollResponse{SentPollResponse: resp},
+	})
+}
+
+// record timestamps and writes the record. The recorder may be nil in
+// which case nothing is recorded. Recording stops after the first write
+// error so a full disk doesn't flood the log.
+func (r *TrafficRecorder) record(p peer.ID, rec *wire.ConsensusRecord) {
+	if r == nil {
+		return
+	}
+	rec.Timestamp = time.Now().UnixNano()
+	if p != "" {
+		rec.Peer_ID = []byte(p)
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if r.failed {
+		return
+	}
+	if err := net.WriteMsg(r.w, rec); err != nil {
+		log.Error("Error recording consensus traffic", log.Args("error", err))
+		r.failed = true
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package consensus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	inet "github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-msgio"
+	"github.com/project-illium/ilxd/params"
+	"github.com/project-illium/ilxd/types"
+	"github.com/project-illium/ilxd/types/wire"
+	"io"
+	"time"
+)
+
+// ReplayEvent is a block being finalized or rejected while
+// replaying recorded traffic.
+type ReplayEvent struct {
+	Timestamp time.Time
+	Height    uint32
+	BlockID   types.ID
+	Status    Status
+}
+
+// ReplayTraffic feeds the traffic recorded by a TrafficRecorder into an
+// offline ConsensusEngine and returns the blocks that were finalized or
+// rejected in the order they were decided. This can be used to find out
+// why a block finalized, or failed to, after the fact.
+//
+// The blocks and poll responses are handled in the order they were
+// recorded, using a single shard, and the timestamps of the records
+// are used to decide which requests had expired. The polls received
+// from other peers are skipped as they don't affect the engine's state.
+//
+// The params must have the same avalanche settings as the node which
+// recorded the traffic.
+func ReplayTraffic(r io.Reader, params *params.NetworkParams) ([]ReplayEvent, error) {
+	eng := &ConsensusEngine{
+		ctx:          context.Background(),
+		params:       params,
+		chooser:      NewBackoffChooser(nil, offlineValConn{}),
+		scores:       newPeerScorer(),
+		requestBlock: func(types.ID, peer.ID) {},
+		quit:         make(chan struct{}),
+		queryQueue:   newMsgQueue(queryQueueSize),
+		latency:      newLatencyHistogram(),
+	}
+	sh := newEngineShard(eng)
+	eng.shards = []*engineShard{sh}
+
+	var (
+		reader  = msgio.NewVarintReaderSize(r, inet.MessageSizeMax)
+		sentAt  = make(map[string]time.Time)
+		decided = make(map[uint32]bool)
+		events  []ReplayEvent
+	)
+	for {
+		msgBytes, err := reader.ReadMsg()
+		if errors.Is(err, io.EOF) {
+			return events, nil
+		} else if err != nil {
+			return nil, err
+		}
+		rec := new(wire.ConsensusRecord)
+		err = wire.UnmarshalEnvelope(msgBytes, rec)
+		reader.ReleaseMsg(msgBytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid consensus record: %w", err)
+		}
+
+		var (
+			p  = peer.ID(rec.Peer_ID)
+			ts = time.Unix(0, rec.Timestamp)
+		)
+		switch event := rec.Event.(type) {
+		case *wire.ConsensusRecord_NewBlock:
+			if event.NewBlock.Header == nil {
+				return nil, errors.New("invalid consensus record: new block header is nil")
+			}
+			sh.handleNewBlock(event.NewBlock.Header, event.NewBlock.Acceptable, nil)
+		case *wire.ConsensusRecord_SentPoll:
+			key := queryKey(event.SentPoll.Request_ID, p.String())
+			sh.queries[key] = NewRequestRecord(ts.Unix(), event.SentPoll.Heights)
+			sentAt[key] = ts
+			for _, height := range event.SentPoll.Heights {
+				if bc, ok := sh.blocks[height]; ok {
+					bc.inflightRequests++
+				}
+			}
+		case *wire.ConsensusRecord_ReceivedPollResponse:
+			key := queryKey(event.ReceivedPollResponse.Request_ID, p.String())
+			req, ok := sh.queries[key]
+			if !ok {
+				continue
+			}
+			// The engine checks for expiration against the current
+			// time so the request's timestamp is adjusted to give
+			// the same result as it did when it was recorded.
+			timestamp := time.Now().Unix()
+			if ts.Sub(sentAt[key]) > sh.eng.params.AvalancheRequestTimeout {
+				timestamp = 0
+			}
+			sh.queries[key] = NewRequestRecord(timestamp, req.GetHeights())
+			delete(sentAt, key)
+
+			sh.handleRegisterVotes(p, event.ReceivedPollResponse)
+
+			for _, height := range req.GetHeights() {
+				bc, ok := sh.blocks[height]
+				if !ok || decided[height] || !bc.HasFinalized() {
+					continue
+				}
+				decided[height] = true
+				events = append(events, decidedEvents(bc, ts)...)
+			}
+		case *wire.ConsensusRecord_PollExpired:
+			key := queryKey(event.PollExpired, p.String())
+			delete(sentAt, key)
+			sh.handleRequestExpiration(key, p)
+		}
+	}
+}
+
+// decidedEvents returns the events for a height which has finalized with
+// the finalized block first followed by the rejected blocks.
+func decidedEvents(bc *BlockChoice, ts time.Time) []ReplayEvent {
+	events := make([]ReplayEvent, 1, len(bc.blockVotes))
+	for id, vr := range bc.blockVotes {
+		event := ReplayEvent{
+			Timestamp: ts,
+			Height:    bc.height,
+			BlockID:   id,
+			Status:    StatusRejected,
+		}
+		if vr.Status() == StatusFinalized {
+			event.Status = StatusFinalized
+			events[0] = event
+			continue
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+// offlineValConn is the ValidatorSetConnection used when replaying
+// recorded traffic.
+type offlineValConn struct{}
+
+func (offlineValConn) ConnectedStakePercentage() float64 { return 1 }
+func (offlineValConn) RegisterDialSuccess(p peer.ID)     {}
+func (offlineValConn) RegisterDialFailure(p peer.ID)     {}
@@ -0,0 +1,57 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package consensus
+
+import (
+	"bytes"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/project-illium/ilxd/net"
+	"github.com/project-illium/ilxd/params"
+	"github.com/project-illium/ilxd/types/blocks"
+	"github.com/project-illium/ilxd/types/wire"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestReplayTraffic(t *testing.T) {
+	var (
+		buf      bytes.Buffer
+		recorder = NewTrafficRecorder(&buf)
+		p        = peer.ID("validator")
+		blk1     = &blocks.BlockHeader{Height: 5, Timestamp: 1}
+		blk2     = &blocks.BlockHeader{Height: 5, Timestamp: 2}
+	)
+
+	recorder.recordNewBlock(blk1, true)
+	recorder.recordNewBlock(blk2, true)
+
+	// A response which arrives after the request expired is ignored.
+	now := time.Now()
+	assert.NoError(t, net.WriteMsg(&buf, &wire.ConsensusRecord{
+		Timestamp: now.UnixNano(),
+		Peer_ID:   []byte(p),
+		Event:     &wire.ConsensusRecord_SentPoll{SentPoll: &wire.MsgPollRequest{Request_ID: 1, Heights: []uint32{5}}},
+	}))
+	assert.NoError(t, net.WriteMsg(&buf, &wire.ConsensusRecord{
+		Timestamp: now.Add(params.RegestParams.AvalancheRequestTimeout * 2).UnixNano(),
+		Peer_ID:   []byte(p),
+		Event:     &wire.ConsensusRecord_ReceivedPollResponse{ReceivedPollResponse: &wire.MsgPollResponse{Request_ID: 1, Votes: [][]byte{blk2.ID().Bytes()}}},
+	}))
+
+	for i := uint32(2); i < 500; i++ {
+		recorder.recordSentPoll(p, &wire.MsgPollRequest{Request_ID: i, Heights: []uint32{5}})
+		recorder.recordReceivedPollResponse(p, &wire.MsgPollResponse{Request_ID: i, Votes: [][]byte{blk2.ID().Bytes()}})
+	}
+
+	events, err := ReplayTraffic(&buf, &params.RegestParams)
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, blk2.ID(), events[0].BlockID)
+	assert.Equal(t, StatusFinalized, events[0].Status)
+	assert.Equal(t, uint32(5), events[0].Height)
+	assert.Equal(t, blk1.ID(), events[1].BlockID)
+	assert.Equal(t, StatusRejected, events[1].Status)
+}
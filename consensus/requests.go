@@ -29,7 +29,7 @@ func (r RequestRecord) GetHeights() []uint32 {
 	return r.heights
 }
 
-// IsExpired returns true if the request has expired
-func (r RequestRecord) IsExpired() bool {
-	return time.Unix(r.timestamp, 0).Add(RequestTimeout).Before(time.Now())
+// IsExpired returns true if the request is older than the timeout
+func (r RequestRecord) IsExpired(timeout time.Duration) bool {
+	return time.Unix(r.timestamp, 0).Add(timeout).Before(time.Now())
 }
@@ -0,0 +1,136 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package consensus
+
+import (
+	"github.com/project-illium/ilxd/recovery"
+	"github.com/project-illium/ilxd/types"
+	"go.opentelemetry.io/otel/trace"
+	"sync/atomic"
+	"time"
+)
+
+// engineShard owns the vote records for a subset of the heights being
+// voted on. Each shard runs its own event loop and polls validators for
+// its own heights so that an engine tracking many unfinalized blocks does
+// not serialize all of the work through a single goroutine.
+//
+// Heights rather than block IDs are used to assign blocks to shards as
+// conflicting blocks at the same height must share a BlockChoice.
+type engineShard struct {
+	eng *ConsensusEngine
+
+	pollSampleSize int
+	pollExpireTime time.Time
+
+	blocks    map[uint32]*BlockChoice
+	queries   map[string]RequestRecord
+	callbacks map[types.ID]chan<- Status
+	spans     map[types.ID]trace.Span
+
+	// preferences is a snapshot of the preferred block at each of the
+	// shard's heights. It is replaced rather than modified so that the
+	// query handler can read it without going through the event loop.
+	preferences atomic.Pointer[map[uint32]types.ID]
+	dirty       bool
+
+	respQueue         *msgQueue
+	housekeepingQueue *msgQueue
+}
+
+func newEngineShard(eng *ConsensusEngine) *engineShard {
+	sh := &engineShard{
+		eng:               eng,
+		blocks:            make(map[uint32]*BlockChoice),
+		queries:           make(map[string]RequestRecord),
+		callbacks:         make(map[types.ID]chan<- Status),
+		spans:             make(map[types.ID]trace.Span),
+		respQueue:         newMsgQueue(responseQueueSize),
+		housekeepingQueue: newMsgQueue(housekeepingQueueSize),
+	}
+	sh.preferences.Store(&map[uint32]types.ID{})
+	return sh
+}
+
+func (sh *engineShard) handler() {
+	defer sh.eng.wg.Done()
+	defer recovery.HandlePanic("consensus", recovery.State(sh.stateSummary), recovery.Restart(func() {
+		sh.eng.wg.Add(1)
+		go sh.handler()
+	}))
+
+	eventLoopTicker := time.NewTicker(sh.eng.params.AvalanchePollInterval)
+	defer eventLoopTicker.Stop()
+out:
+	for {
+		// Poll responses are handled first so that votes are not
+		// held up behind a burst of new blocks.
+		select {
+		case m := <-sh.respQueue.ch:
+			sh.handleMessage(m)
+		case <-sh.eng.quit:
+			break out
+		default:
+			select {
+			case m := <-sh.respQueue.ch:
+				sh.handleMessage(m)
+			case m := <-sh.housekeepingQueue.ch:
+				sh.handleMessage(m)
+			case <-eventLoopTicker.C:
+				sh.pollLoop()
+			case <-sh.eng.quit:
+				break out
+			}
+		}
+		if sh.dirty {
+			sh.publishPreferences()
+		}
+	}
+}
+
+func (sh *engineShard) handleMessage(m interface{}) {
+	switch msg := m.(type) {
+	case *requestExpirationMsg:
+		sh.handleRequestExpiration(msg.key, msg.p)
+	case *newBlockMessage:
+		sh.handleNewBlock(msg.header, msg.isAcceptable, msg.callback)
+	case *registerVotesMsg:
+		sh.handleRegisterVotes(msg.p, msg.resp)
+	case *expandPollingMsg:
+		sh.handleExpandPolling(msg.sampleSize, msg.until)
+	case *stateRequestMsg:
+		sh.handleStateRequest(msg.resp)
+	}
+}
+
+// publishPreferences replaces the snapshot of the shard's preferences
+// with the current ones.
+func (sh *engineShard) publishPreferences() {
+	prefs := make(map[uint32]types.ID, len(sh.blocks))
+	for height, bc := range sh.blocks {
+		prefs[height] = bc.GetPreference()
+	}
+	sh.preferences.Store(&prefs)
+	sh.dirty = false
+}
+
+// preference returns the preferred block at the height from the latest
+// snapshot. It returns false if the shard is not voting on the height.
+// It is safe to call from any goroutine.
+func (sh *engineShard) preference(height uint32) (types.ID, bool) {
+	prefs := *sh.preferences.Load()
+	id, ok := prefs[height]
+	return id, ok
+}
+
+// stateSummary is included in crash reports if the handler panics. It
+// runs after the handler has exited so it's safe to read the maps.
+func (sh *engineShard) stateSummary() map[string]any {
+	return map[string]any{
+		"blocks":    len(sh.blocks),
+		"queries":   len(sh.queries),
+		"callbacks": len(sh.callbacks),
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package consensus
+
+import (
+	"github.com/project-illium/ilxd/types"
+	"sort"
+	"sync"
+	"time"
+)
+
+// FinalizationLatencyBuckets are the upper bounds of the buckets in the
+// finalization latency histogram.
+var FinalizationLatencyBuckets = []time.Duration{
+	time.Millisecond * 250,
+	time.Millisecond * 500,
+	time.Second,
+	time.Second * 2,
+	time.Second * 5,
+	time.Second * 10,
+	time.Second * 30,
+	time.Minute,
+	time.Minute * 5,
+	time.Minute * 30,
+}
+
+// BlockVoteState is a snapshot of the vote record for a block.
+type BlockVoteState struct {
+	ID         types.ID
+	Status     Status
+	Acceptable bool
+	// Confidence is the number of consecutive conclusive polls which
+	// agreed with the current preference.
+	Confidence uint16
+}
+
+// HeightState is a snapshot of the voting on the blocks at a height.
+type HeightState struct {
+	Height uint32
+	Blocks []BlockVoteState
+	// ActiveBit is the bit of the block ID currently being voted on when
+	// choosing between conflicting blocks. BitConfidence is its confidence.
+	ActiveBit     uint8
+	BitConfidence uint16
+	// InflightRequests is the number of polls for the height which have
+	// not been answered or expired.
+	InflightRequests int
+	TotalVotes       int
+	// Age is how long the engine has been voting on the height.
+	Age time.Duration
+}
+
+// LatencyHistogram counts how long blocks took to finalize.
+type LatencyHistogram struct {
+	// Buckets are the upper bounds of the buckets. Counts holds the number
+	// of blocks which finalized within each bound but not the previous one.
+	// Blocks slower than the last bound are only included in Count.
+	Buckets []time.Duration
+	Counts  []uint64
+	Count   uint64
+	Sum     time.Duration
+}
+
+// EngineState is a snapshot of the state of the consensus engine.
+type EngineState struct {
+	FinalizationScore uint16
+	// Heights are the heights being voted on, sorted by height. Heights
+	// which have finalized remain until the engine stops tracking them.
+	Heights []HeightState
+	// InflightQueries is the number of polls sent to validators which
+	// have not been answered or expired.
+	InflightQueries     int
+	Queues              QueueStats
+	FinalizationLatency LatencyHistogram
+}
+
+// stateRequestMsg asks a shard for a snapshot of its state.
+type stateRequestMsg struct {
+	resp chan<- shardState
+}
+
+type shardState struct {
+	heights []HeightState
+	queries int
+}
+
+// State returns a snapshot of the engine's vote records, inflight polls,
+// and the finalization latency histogram. It's meant to help diagnose
+// blocks which are not finalizing.
+func (eng *ConsensusEngine) State() EngineState {
+	state := EngineState{
+		FinalizationScore:   eng.params.AvalancheFinalizationScore,
+		Queues:              eng.QueueStats(),
+		FinalizationLatency: eng.latency.snapshot(),
+	}
+	for _, sh := range eng.shards {
+		ch := make(chan shardState, 1)
+		if !sh.housekeepingQueue.push(&stateRequestMsg{resp: ch}, eng.quit) {
+			return state
+		}
+		select {
+		case ss := <-ch:
+			state.Heights = append(state.Heights, ss.heights...)
+			state.InflightQueries += ss.queries
+		case <-eng.quit:
+			return state
+		}
+	}
+	sort.Slice(state.Heights, func(i, j int) bool {
+		return state.Heights[i].Height < state.Heights[j].Height
+	})
+	return state
+}
+
+func (sh *engineShard) handleStateRequest(resp chan<- shardState) {
+	ss := shardState{
+		heights: make([]HeightState, 0, len(sh.blocks)),
+		queries: len(sh.queries),
+	}
+	for height, bc := range sh.blocks {
+		hs := HeightState{
+			Height:           height,
+			Blocks:           make([]BlockVoteState, 0, len(bc.blockVotes)),
+			ActiveBit:        bc.bitRecord.activeBit,
+			BitConfidence:    bc.bitRecord.getConfidence(),
+			InflightRequests: bc.inflightRequests,
+			TotalVotes:       bc.totalVotes,
+			Age:              time.Since(bc.timestamp),
+		}
+		for id, rec := range bc.blockVotes {
+			hs.Blocks = append(hs.Blocks, BlockVoteState{
+				ID:         id.Clone(),
+				Status:     rec.Status(),
+				Acceptable: rec.acceptable,
+				Confidence: rec.getConfidence(),
+			})
+		}
+		sort.Slice(hs.Blocks, func(i, j int) bool {
+			return hs.Blocks[i].ID.Compare(hs.Blocks[j].ID) < 0
+		})
+		ss.heights = append(ss.heights, hs)
+	}
+	resp <- ss
+}
+
+// latencyHistogram records how long blocks take to finalize. It's
+// shared by the shards.
+type latencyHistogram struct {
+	mtx    sync.Mutex
+	counts []uint64
+	count  uint64
+	sum    time.Duration
+}
+
+func newLatencyHistogram() *latencyHistogram {
+	return &latencyHistogram{counts: make([]uint64, len(FinalizationLatencyBuckets))}
+}
+
+func (h *latencyHistogram) observe(d time.Duration) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	for i, bound := range FinalizationLatencyBuckets {
+		if d <= bound {
+			h.counts[i]++
+			break
+		}
+	}
+	h.count++
+	h.sum += d
+}
+
+func (h *latencyHistogram) snapshot() LatencyHistogram {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	counts := make([]uint64, len(h.counts))
+	copy(counts, h.counts)
+	return LatencyHistogram{
+		Buckets: FinalizationLatencyBuckets,
+		Counts:  counts,
+		Count:   h.count,
+		Sum:     h.sum,
+	}
+}
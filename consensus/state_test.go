@@ -0,0 +1,90 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package consensus
+
+import (
+	"github.com/project-illium/ilxd/params"
+	"github.com/project-illium/ilxd/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestLatencyHistogram(t *testing.T) {
+	h := newLatencyHistogram()
+	h.observe(time.Millisecond * 100)
+	h.observe(time.Millisecond * 250)
+	h.observe(time.Second * 3)
+	h.observe(time.Hour)
+
+	snap := h.snapshot()
+	assert.Equal(t, FinalizationLatencyBuckets, snap.Buckets)
+	assert.Equal(t, uint64(4), snap.Count)
+	assert.Equal(t, time.Hour+time.Second*3+time.Millisecond*350, snap.Sum)
+
+	expected := make([]uint64, len(FinalizationLatencyBuckets))
+	expected[0] = 2
+	expected[4] = 1
+	assert.Equal(t, expected, snap.Counts)
+
+	// The snapshot is a copy.
+	h.observe(time.Millisecond)
+	assert.Equal(t, uint64(2), snap.Counts[0])
+}
+
+func TestEngineState(t *testing.T) {
+	eng := newTestEngine(&params.RegestParams)
+	sh := eng.shards[0]
+
+	id1, id2 := randomBlockID(), randomBlockID()
+	bc := NewBlockChoice(5, eng.params)
+	bc.AddNewBlock(id1, true)
+	bc.AddNewBlock(id2, false)
+	bc.inflightRequests = 3
+	sh.blocks[5] = bc
+	sh.blocks[2] = NewBlockChoice(2, eng.params)
+	sh.queries["1|peer"] = NewRequestRecord(time.Now().Unix(), []uint32{5})
+
+	// Answer the state request in place of the shard's handler.
+	go func() {
+		m := <-sh.housekeepingQueue.ch
+		sh.handleMessage(m)
+	}()
+	state := eng.State()
+
+	assert.Equal(t, eng.params.AvalancheFinalizationScore, state.FinalizationScore)
+	assert.Equal(t, 1, state.InflightQueries)
+	assert.Len(t, state.Heights, 2)
+	assert.Equal(t, uint32(2), state.Heights[0].Height)
+	assert.Equal(t, uint32(5), state.Heights[1].Height)
+
+	hs := state.Heights[1]
+	assert.Equal(t, 3, hs.InflightRequests)
+	assert.Len(t, hs.Blocks, 2)
+	statuses := make(map[types.ID]Status)
+	for _, b := range hs.Blocks {
+		statuses[b.ID] = b.Status
+	}
+	assert.Equal(t, StatusPreferred, statuses[id1])
+	assert.Equal(t, StatusNotPreferred, statuses[id2])
+}
+
+func TestQueueStatsNilQueues(t *testing.T) {
+	eng := &ConsensusEngine{}
+	assert.Equal(t, QueueStats{}, eng.QueueStats())
+}
+
+// newTestEngine returns an engine with a single shard and all of its
+// queues created but none of its handlers running.
+func newTestEngine(params *params.NetworkParams) *ConsensusEngine {
+	eng := &ConsensusEngine{
+		params:     params,
+		quit:       make(chan struct{}),
+		queryQueue: newMsgQueue(queryQueueSize),
+		latency:    newLatencyHistogram(),
+	}
+	eng.shards = []*engineShard{newEngineShard(eng)}
+	return eng
+}
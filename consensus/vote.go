@@ -5,6 +5,7 @@
 package consensus
 
 import (
+	"github.com/project-illium/ilxd/params"
 	"github.com/project-illium/ilxd/types"
 	"time"
 )
@@ -67,21 +68,27 @@ const (
 // object tracks them all and makes a selection based on the recorded
 // votes.
 type BlockChoice struct {
-	height           uint32
-	bitRecord        *BitVoteRecord
-	blockVotes       map[types.ID]*BlockVoteRecord
-	inflightRequests int
-	timestamp        time.Time
-	totalVotes       int
+	height            uint32
+	bitRecord         *BitVoteRecord
+	blockVotes        map[types.ID]*BlockVoteRecord
+	inflightRequests  int
+	timestamp         time.Time
+	totalVotes        int
+	finalizationScore uint16
+	maxInflightPoll   int
 }
 
-// NewBlockChoice returns a new BlockChoice for this height
-func NewBlockChoice(height uint32) *BlockChoice {
+// NewBlockChoice returns a new BlockChoice for this height. The
+// finalization score and max inflight polls are taken from the
+// network params.
+func NewBlockChoice(height uint32, params *params.NetworkParams) *BlockChoice {
 	return &BlockChoice{
-		height:     height,
-		bitRecord:  &BitVoteRecord{},
-		blockVotes: make(map[types.ID]*BlockVoteRecord),
-		timestamp:  time.Now(),
+		height:            height,
+		bitRecord:         &BitVoteRecord{finalizationScore: params.AvalancheFinalizationScore},
+		blockVotes:        make(map[types.ID]*BlockVoteRecord),
+		timestamp:         time.Now(),
+		finalizationScore: params.AvalancheFinalizationScore,
+		maxInflightPoll:   params.AvalancheMaxInflightPoll,
 	}
 }
 
@@ -111,11 +118,11 @@ func (bc *BlockChoice) HasFinalized() bool {
 // votes may ultimately be needed but this can be used to throttle
 // inflight requests.
 func (bc *BlockChoice) VotesNeededToFinalize() int {
-	max := MaxInflightPoll
+	max := bc.maxInflightPoll
 	for _, rec := range bc.blockVotes {
 		confidence := rec.getConfidence()
-		if MaxInflightPoll-int(confidence) < max {
-			max = FinalizationScore - int(confidence)
+		if bc.maxInflightPoll-int(confidence) < max {
+			max = int(bc.finalizationScore) - int(confidence)
 		}
 	}
 	return max
@@ -130,7 +137,17 @@ func (bc *BlockChoice) HasBlock(id types.ID) bool {
 // AddNewBlock adds a new block at this height. If there currently is no
 // preference, and if this block is acceptable, it will be selected as
 // the new preference.
+//
+// If a block at this height has already finalized the new block conflicts
+// with it and is added as rejected.
 func (bc *BlockChoice) AddNewBlock(blockID types.ID, isAcceptable bool) {
+	if bc.HasFinalized() {
+		record := &BlockVoteRecord{acceptable: isAcceptable, finalizationScore: bc.finalizationScore}
+		record.Reject()
+		bc.blockVotes[blockID] = record
+		return
+	}
+
 	havePreferred := false
 	for _, record := range bc.blockVotes {
 		if record.isPreferred() {
@@ -148,8 +165,9 @@ func (bc *BlockChoice) AddNewBlock(blockID types.ID, isAcceptable bool) {
 	}
 
 	bc.blockVotes[blockID] = &BlockVoteRecord{
-		acceptable: isAcceptable,
-		confidence: boolToUint16(preferred),
+		acceptable:        isAcceptable,
+		confidence:        boolToUint16(preferred),
+		finalizationScore: bc.finalizationScore,
 	}
 }
 
@@ -157,6 +175,8 @@ func (bc *BlockChoice) AddNewBlock(blockID types.ID, isAcceptable bool) {
 // then a YES will be recorded for that block and a NO for all other
 // conflicting blocks. If it is a ZERO ID then neither YES nor NO will
 // be recorded.
+//
+// When a block finalizes all the conflicting blocks are rejected.
 func (bc *BlockChoice) RecordVote(voteID types.ID) (types.ID, bool) {
 	bc.totalVotes++
 
@@ -170,6 +190,7 @@ func (bc *BlockChoice) RecordVote(voteID types.ID) (types.ID, bool) {
 	record, ok := bc.blockVotes[voteID]
 	if ok {
 		if record.RecordVote(v1) == ResultFinalized {
+			bc.rejectConflicts(voteID)
 			return voteID, true
 		}
 	}
@@ -182,6 +203,7 @@ func (bc *BlockChoice) RecordVote(voteID types.ID) (types.ID, bool) {
 	for id, record := range bc.blockVotes {
 		if id != voteID {
 			if record.RecordVote(v2) == ResultFinalized {
+				bc.rejectConflicts(id)
 				return id, true
 			}
 		}
@@ -270,6 +292,16 @@ func (bc *BlockChoice) RecordVote(voteID types.ID) (types.ID, bool) {
 	return types.ID{}, false
 }
 
+// rejectConflicts rejects all the blocks at this height other than the
+// finalized block.
+func (bc *BlockChoice) rejectConflicts(finalizedID types.ID) {
+	for id, record := range bc.blockVotes {
+		if id != finalizedID {
+			record.Reject()
+		}
+	}
+}
+
 // BitVoteRecord is responsible for tracking and finalizing bits.
 // We start with the most significant bit (MSB) and attempt to finalize
 // a 0 or 1 based on the MSB of the block ID votes. Since we're only
@@ -282,6 +314,8 @@ type BitVoteRecord struct {
 	votes      uint16
 	consider   uint16
 	confidence uint16
+
+	finalizationScore uint16
 }
 
 // RecordVote records a vote for active bit. If the bit finalizes
@@ -309,7 +343,7 @@ func (vr *BitVoteRecord) RecordVote(voteID types.ID) Result {
 	// Vote is conclusive and agrees with our current state
 	if vr.isOnePreferred() == one {
 		vr.confidence += 2
-		if vr.getConfidence() >= FinalizationScore {
+		if vr.getConfidence() >= vr.finalizationScore {
 			setBit(&vr.finalizedBits, vr.activeBit, vr.isOnePreferred())
 			vr.activeBit++
 			vr.votes = 0
@@ -355,6 +389,8 @@ type BlockVoteRecord struct {
 	votes      uint16
 	consider   uint16
 	confidence uint16
+
+	finalizationScore uint16
 }
 
 // RecordVote records the votes for a block ID and computes whether
@@ -379,7 +415,7 @@ func (vr *BlockVoteRecord) RecordVote(vote byte) Result {
 	if vr.isPreferred() == yes {
 		if vr.isPreferred() {
 			vr.confidence += 2
-			if vr.getConfidence() >= FinalizationScore {
+			if vr.getConfidence() >= vr.finalizationScore {
 				return ResultFinalized
 			}
 		}
@@ -399,6 +435,14 @@ func (vr *BlockVoteRecord) Reset(yes bool) {
 	vr.consider = 0
 }
 
+// Reject marks the block as rejected. This is done when a conflicting
+// block at the same height finalizes.
+func (vr *BlockVoteRecord) Reject() {
+	vr.confidence = vr.finalizationScore << 1
+	vr.votes = 0
+	vr.consider = 0
+}
+
 // Status returns the current status of the block ID
 func (vr *BlockVoteRecord) Status() (status Status) {
 	finalized := vr.hasFinalized()
@@ -421,7 +465,7 @@ func (vr *BlockVoteRecord) isPreferred() bool {
 }
 
 func (vr *BlockVoteRecord) hasFinalized() bool {
-	return vr.getConfidence() >= FinalizationScore
+	return vr.getConfidence() >= vr.finalizationScore
 }
 
 func (vr *BlockVoteRecord) getConfidence() uint16 {
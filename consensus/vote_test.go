@@ -6,6 +6,7 @@ package consensus
 
 import (
 	"crypto/rand"
+	"github.com/project-illium/ilxd/params"
 	"github.com/project-illium/ilxd/types"
 	"github.com/stretchr/testify/assert"
 	"math"
@@ -19,7 +20,7 @@ func randomBlockID() types.ID {
 }
 
 func TestBlockChoice(t *testing.T) {
-	bc := NewBlockChoice(1)
+	bc := NewBlockChoice(1, &params.RegestParams)
 
 	// blk1
 	blk1 := randomBlockID()
@@ -39,18 +40,25 @@ func TestBlockChoice(t *testing.T) {
 	assert.Len(t, bc.blockVotes, 2)
 	assert.Equal(t, bc.bitRecord.isOnePreferred(), getBit(blk1, 0) == 1)
 
-	for i := 0; i < FinalizationScore+11; i++ {
+	for i := 0; i < int(params.RegestParams.AvalancheFinalizationScore)+11; i++ {
 		_, ok := bc.RecordVote(blk1)
 		assert.False(t, ok)
 	}
 	_, ok := bc.RecordVote(blk1)
 	assert.True(t, ok)
 	assert.True(t, bc.blockVotes[blk1].Status() == StatusFinalized)
-	assert.True(t, bc.blockVotes[blk2].Status() == StatusNotPreferred)
+	assert.True(t, bc.blockVotes[blk2].Status() == StatusRejected)
+
+	// A block which arrives after the height has finalized is rejected.
+	blk3 := randomBlockID()
+	bc.AddNewBlock(blk3, true)
+	assert.Equal(t, StatusRejected, bc.blockVotes[blk3].Status())
+	assert.Equal(t, blk1, bc.GetPreference())
+	assert.True(t, bc.blockVotes[blk1].Status() == StatusFinalized)
 }
 
 func TestFlipping(t *testing.T) {
-	bc := NewBlockChoice(1)
+	bc := NewBlockChoice(1, &params.RegestParams)
 
 	blk1 := randomBlockID()
 	blk2 := randomBlockID()
@@ -8,7 +8,10 @@ import (
 	"crypto/rand"
 	"errors"
 	"github.com/libp2p/go-libp2p/core/crypto"
+	"golang.org/x/crypto/blake2b"
 	"golang.org/x/crypto/nacl/box"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
 )
 
 const (
@@ -17,11 +20,23 @@ const (
 
 	// Length of nacl ephemeral public key
 	EphemeralPublicKeyBytes = 32
+
+	// Length of the scrypt salt used by EncryptWithPassphrase
+	PassphraseSaltBytes = 16
+
+	// The scrypt parameters used to derive a key from a passphrase.
+	scryptN = 32768
+	scryptR = 8
+	scryptP = 1
 )
 
 // ErrBoxDecryption Nacl box decryption failed
 var ErrBoxDecryption = errors.New("failed to decrypt curve25519")
 
+// ErrPassphraseDecryption is returned when the passphrase is wrong or the
+// ciphertext has been modified.
+var ErrPassphraseDecryption = errors.New("failed to decrypt with passphrase")
+
 // Encrypt encrypts an output with the public key.
 func Encrypt(pubKey crypto.PubKey, plaintext []byte) ([]byte, error) {
 	curve25519PubKey, ok := pubKey.(*Curve25519PublicKey)
@@ -65,3 +80,109 @@ func Decrypt(privKey crypto.PrivKey, ciphertext []byte) ([]byte, error) {
 	}
 	return plaintext, nil
 }
+
+// TrialDecryption is the result of trial decrypting a ciphertext with
+// TrialDecrypt.
+type TrialDecryption struct {
+	// Key is the index of the key which decrypted the ciphertext or
+	// -1 if none of the keys could.
+	Key       int
+	Plaintext []byte
+}
+
+// TrialDecrypt attempts to decrypt each of the ciphertexts with each of
+// the keys. The keys are unpacked once for the batch, so this is cheaper
+// than calling Decrypt for every pair.
+func TrialDecrypt(keys []*Curve25519PrivateKey, ciphertexts [][]byte) []TrialDecryption {
+	var (
+		privs = make([][32]byte, len(keys))
+		pubs  = make([][32]byte, len(keys))
+		ret   = make([]TrialDecryption, len(ciphertexts))
+	)
+	for i, k := range keys {
+		copy(privs[i][:], k.k[:Curve25519PrivateKeySize])
+		copy(pubs[i][:], k.pubKeyBytes())
+	}
+
+	var (
+		ephemeralPub [32]byte
+		sharedKey    [32]byte
+		nonce        [NonceBytes]byte
+	)
+	for i, ciphertext := range ciphertexts {
+		ret[i].Key = -1
+		if len(ciphertext) < EphemeralPublicKeyBytes+box.Overhead {
+			continue
+		}
+		copy(ephemeralPub[:], ciphertext[:EphemeralPublicKeyBytes])
+		for j := range keys {
+			box.Precompute(&sharedKey, &ephemeralPub, &privs[j])
+			h, err := blake2b.New(NonceBytes, nil)
+			if err != nil {
+				continue
+			}
+			h.Write(ephemeralPub[:])
+			h.Write(pubs[j][:])
+			h.Sum(nonce[:0])
+
+			plaintext, ok := box.OpenAfterPrecomputation(nil, ciphertext[EphemeralPublicKeyBytes:], &nonce, &sharedKey)
+			if ok {
+				ret[i] = TrialDecryption{Key: j, Plaintext: plaintext}
+				break
+			}
+		}
+	}
+	return ret
+}
+
+// EncryptWithPassphrase encrypts the plaintext with a key derived from the
+// passphrase using scrypt. The salt and nonce are prepended to the returned
+// ciphertext.
+func EncryptWithPassphrase(plaintext []byte, passphrase string) ([]byte, error) {
+	salt := make([]byte, PassphraseSaltBytes)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	var nonce [NonceBytes]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return nil, err
+	}
+	key, err := passphraseKey(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext := make([]byte, 0, PassphraseSaltBytes+NonceBytes+len(plaintext)+secretbox.Overhead)
+	ciphertext = append(ciphertext, salt...)
+	ciphertext = append(ciphertext, nonce[:]...)
+	return secretbox.Seal(ciphertext, plaintext, &nonce, key), nil
+}
+
+// DecryptWithPassphrase decrypts a ciphertext created by EncryptWithPassphrase.
+func DecryptWithPassphrase(ciphertext []byte, passphrase string) ([]byte, error) {
+	if len(ciphertext) < PassphraseSaltBytes+NonceBytes+secretbox.Overhead {
+		return nil, ErrPassphraseDecryption
+	}
+	key, err := passphraseKey(passphrase, ciphertext[:PassphraseSaltBytes])
+	if err != nil {
+		return nil, err
+	}
+	var nonce [NonceBytes]byte
+	copy(nonce[:], ciphertext[PassphraseSaltBytes:PassphraseSaltBytes+NonceBytes])
+
+	plaintext, ok := secretbox.Open(nil, ciphertext[PassphraseSaltBytes+NonceBytes:], &nonce, key)
+	if !ok {
+		return nil, ErrPassphraseDecryption
+	}
+	return plaintext, nil
+}
+
+func passphraseKey(passphrase string, salt []byte) (*[32]byte, error) {
+	derived, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, 32)
+	if err != nil {
+		return nil, err
+	}
+	var key [32]byte
+	copy(key[:], derived)
+	return &key, nil
+}
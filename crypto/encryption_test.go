@@ -25,3 +25,46 @@ func TestEncryption(t *testing.T) {
 
 	assert.Equal(t, message, plainText)
 }
+
+func TestEncryptWithPassphrase(t *testing.T) {
+	message := []byte("message")
+
+	cipherText, err := EncryptWithPassphrase(message, "letmein")
+	assert.NoError(t, err)
+
+	plainText, err := DecryptWithPassphrase(cipherText, "letmein")
+	assert.NoError(t, err)
+	assert.Equal(t, message, plainText)
+
+	_, err = DecryptWithPassphrase(cipherText, "wrong")
+	assert.ErrorIs(t, err, ErrPassphraseDecryption)
+
+	cipherText[len(cipherText)-1] ^= 0x01
+	_, err = DecryptWithPassphrase(cipherText, "letmein")
+	assert.ErrorIs(t, err, ErrPassphraseDecryption)
+}
+
+func TestTrialDecrypt(t *testing.T) {
+	priv1, pub1, err := GenerateCurve25519Key(rand.Reader)
+	assert.NoError(t, err)
+	priv2, pub2, err := GenerateCurve25519Key(rand.Reader)
+	assert.NoError(t, err)
+	_, pub3, err := GenerateCurve25519Key(rand.Reader)
+	assert.NoError(t, err)
+
+	ct1, err := Encrypt(pub1, []byte("one"))
+	assert.NoError(t, err)
+	ct2, err := Encrypt(pub2, []byte("two"))
+	assert.NoError(t, err)
+	ct3, err := Encrypt(pub3, []byte("three"))
+	assert.NoError(t, err)
+
+	keys := []*Curve25519PrivateKey{priv1.(*Curve25519PrivateKey), priv2.(*Curve25519PrivateKey)}
+	results := TrialDecrypt(keys, [][]byte{ct1, ct2, ct3, {0x01}})
+	assert.Len(t, results, 4)
+	assert.Equal(t, TrialDecryption{Key: 0, Plaintext: []byte("one")}, results[0])
+	assert.Equal(t, TrialDecryption{Key: 1, Plaintext: []byte("two")}, results[1])
+	assert.Equal(t, -1, results[2].Key)
+	assert.Nil(t, results[2].Plaintext)
+	assert.Equal(t, -1, results[3].Key)
+}
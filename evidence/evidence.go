@@ -0,0 +1,180 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package evidence
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/project-illium/ilxd/gen"
+	"github.com/project-illium/ilxd/repo"
+	"github.com/project-illium/ilxd/types/blocks"
+	"time"
+)
+
+// MaxEvidencePerProducer is the maximum number of pieces of evidence
+// recorded for a single producer. One is enough to prove the producer
+// equivocated so anything beyond a few is not stored or relayed.
+const MaxEvidencePerProducer = 3
+
+var (
+	// ErrInvalidEvidence is returned when the evidence does not prove
+	// the producer equivocated.
+	ErrInvalidEvidence = errors.New("invalid equivocation evidence")
+
+	// ErrKnownEvidence is returned when the evidence has already been
+	// recorded.
+	ErrKnownEvidence = errors.New("equivocation evidence already recorded")
+
+	// ErrEvidenceLimit is returned when MaxEvidencePerProducer pieces
+	// of evidence have already been recorded for the producer.
+	ErrEvidenceLimit = errors.New("equivocation evidence limit reached for producer")
+)
+
+// Validate checks that the evidence is two different, validly signed
+// block headers at the same height from the same producer. Producers are
+// allowed to build a new block at the same height after waiting
+// gen.MinAllowableTimeBetweenDupBlocks so the timestamps must be closer
+// together than that.
+func Validate(ev *blocks.EquivocationEvidence) error {
+	if ev.First == nil || ev.Second == nil {
+		return fmt.Errorf("%w: missing header", ErrInvalidEvidence)
+	}
+	if ev.First.Height != ev.Second.Height {
+		return fmt.Errorf("%w: headers are at different heights", ErrInvalidEvidence)
+	}
+	if !bytes.Equal(ev.First.Producer_ID, ev.Second.Producer_ID) {
+		return fmt.Errorf("%w: headers are from different producers", ErrInvalidEvidence)
+	}
+	if ev.First.ID() == ev.Second.ID() {
+		return fmt.Errorf("%w: headers are the same block", ErrInvalidEvidence)
+	}
+	delta := time.Unix(ev.First.Timestamp, 0).Sub(time.Unix(ev.Second.Timestamp, 0))
+	if delta < 0 {
+		delta = -delta
+	}
+	if delta >= gen.MinAllowableTimeBetweenDupBlocks {
+		return fmt.Errorf("%w: headers are too far apart in time", ErrInvalidEvidence)
+	}
+
+	producerID, err := peer.IDFromBytes(ev.First.Producer_ID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidEvidence, err)
+	}
+	producerPubkey, err := producerID.ExtractPublicKey()
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidEvidence, err)
+	}
+	for _, header := range []*blocks.BlockHeader{ev.First, ev.Second} {
+		sigHash, err := header.SigHash()
+		if err != nil {
+			return err
+		}
+		valid, err := producerPubkey.Verify(sigHash, header.Signature)
+		if !valid || err != nil {
+			return fmt.Errorf("%w: invalid header signature", ErrInvalidEvidence)
+		}
+	}
+	return nil
+}
+
+// Store records equivocation evidence in the database indexed by
+// producer.
+type Store struct {
+	ds repo.Datastore
+}
+
+// NewStore returns a new Store using the provided datastore.
+func NewStore(ds repo.Datastore) *Store {
+	return &Store{ds: ds}
+}
+
+// Put validates and records the evidence. ErrKnownEvidence is returned
+// if it has already been recorded and ErrEvidenceLimit if the producer
+// already has MaxEvidencePerProducer pieces of evidence recorded.
+func (s *Store) Put(ev *blocks.EquivocationEvidence) error {
+	if err := Validate(ev); err != nil {
+		return err
+	}
+	producerID, err := peer.IDFromBytes(ev.First.Producer_ID)
+	if err != nil {
+		return err
+	}
+	prefix := repo.EvidenceDatastoreKeyPrefix + producerID.String() + "/"
+	key := datastore.NewKey(prefix + ev.ID().String())
+	exists, err := s.ds.Has(context.Background(), key)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrKnownEvidence
+	}
+	n, err := s.count(prefix)
+	if err != nil {
+		return err
+	}
+	if n >= MaxEvidencePerProducer {
+		return ErrEvidenceLimit
+	}
+	ser, err := ev.Serialize()
+	if err != nil {
+		return err
+	}
+	return s.ds.Put(context.Background(), key, ser)
+}
+
+// Evidence returns the recorded evidence for the producer. If the
+// producer ID is empty the evidence for all producers is returned.
+func (s *Store) Evidence(producerID peer.ID) ([]*blocks.EquivocationEvidence, error) {
+	prefix := repo.EvidenceDatastoreKeyPrefix
+	if producerID != "" {
+		prefix += producerID.String() + "/"
+	}
+	results, err := s.ds.Query(context.Background(), query.Query{
+		Prefix: prefix,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer results.Close()
+
+	var evidence []*blocks.EquivocationEvidence
+	for result, ok := results.NextSync(); ok; result, ok = results.NextSync() {
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		ev := new(blocks.EquivocationEvidence)
+		if err := ev.Deserialize(result.Value); err != nil {
+			return nil, err
+		}
+		evidence = append(evidence, ev)
+	}
+	return evidence, nil
+}
+
+// count returns the number of pieces of evidence recorded under the prefix.
+func (s *Store) count(prefix string) (int, error) {
+	results, err := s.ds.Query(context.Background(), query.Query{
+		Prefix:   prefix,
+		KeysOnly: true,
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer results.Close()
+
+	n := 0
+	for result, ok := results.NextSync(); ok; result, ok = results.NextSync() {
+		if result.Error != nil {
+			return 0, result.Error
+		}
+		n++
+	}
+	return n, nil
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package evidence
+
+import (
+	"crypto/rand"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/project-illium/ilxd/gen"
+	"github.com/project-illium/ilxd/repo/mock"
+	"github.com/project-illium/ilxd/types/blocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func makeHeader(t *testing.T, sk crypto.PrivKey, height uint32, timestamp time.Time, txRoot byte) *blocks.BlockHeader {
+	pid, err := peer.IDFromPrivateKey(sk)
+	require.NoError(t, err)
+	producerID, err := pid.Marshal()
+	require.NoError(t, err)
+
+	header := &blocks.BlockHeader{
+		Version:     1,
+		Height:      height,
+		Parent:      make([]byte, 32),
+		Timestamp:   timestamp.Unix(),
+		TxRoot:      []byte{txRoot},
+		Producer_ID: producerID,
+	}
+	sigHash, err := header.SigHash()
+	require.NoError(t, err)
+	header.Signature, err = sk.Sign(sigHash)
+	require.NoError(t, err)
+	return header
+}
+
+func TestValidate(t *testing.T) {
+	sk, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+	sk2, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+
+	now := time.Now()
+	tests := []struct {
+		name  string
+		ev    *blocks.EquivocationEvidence
+		valid bool
+	}{
+		{
+			name: "valid",
+			ev: &blocks.EquivocationEvidence{
+				First:  makeHeader(t, sk, 5, now, 0x01),
+				Second: makeHeader(t, sk, 5, now.Add(time.Second), 0x02),
+			},
+			valid: true,
+		},
+		{
+			name: "missing header",
+			ev: &blocks.EquivocationEvidence{
+				First: makeHeader(t, sk, 5, now, 0x01),
+			},
+			valid: false,
+		},
+		{
+			name: "same block",
+			ev: &blocks.EquivocationEvidence{
+				First:  makeHeader(t, sk, 5, now, 0x01),
+				Second: makeHeader(t, sk, 5, now, 0x01),
+			},
+			valid: false,
+		},
+		{
+			name: "different heights",
+			ev: &blocks.EquivocationEvidence{
+				First:  makeHeader(t, sk, 5, now, 0x01),
+				Second: makeHeader(t, sk, 6, now, 0x02),
+			},
+			valid: false,
+		},
+		{
+			name: "different producers",
+			ev: &blocks.EquivocationEvidence{
+				First:  makeHeader(t, sk, 5, now, 0x01),
+				Second: makeHeader(t, sk2, 5, now, 0x02),
+			},
+			valid: false,
+		},
+		{
+			name: "too far apart",
+			ev: &blocks.EquivocationEvidence{
+				First:  makeHeader(t, sk, 5, now.Add(gen.MinAllowableTimeBetweenDupBlocks), 0x01),
+				Second: makeHeader(t, sk, 5, now, 0x02),
+			},
+			valid: false,
+		},
+		{
+			name: "invalid signature",
+			ev: func() *blocks.EquivocationEvidence {
+				second := makeHeader(t, sk, 5, now, 0x02)
+				second.Signature[0] ^= 0xff
+				return &blocks.EquivocationEvidence{
+					First:  makeHeader(t, sk, 5, now, 0x01),
+					Second: second,
+				}
+			}(),
+			valid: false,
+		},
+	}
+
+	for _, test := range tests {
+		err := Validate(test.ev)
+		if test.valid {
+			assert.NoError(t, err, test.name)
+		} else {
+			assert.ErrorIs(t, err, ErrInvalidEvidence, test.name)
+		}
+	}
+}
+
+func TestStore(t *testing.T) {
+	sk, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+	sk2, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+	pid, err := peer.IDFromPrivateKey(sk)
+	require.NoError(t, err)
+
+	now := time.Now()
+	ev := &blocks.EquivocationEvidence{
+		First:  makeHeader(t, sk, 5, now, 0x01),
+		Second: makeHeader(t, sk, 5, now, 0x02),
+	}
+	ev2 := &blocks.EquivocationEvidence{
+		First:  makeHeader(t, sk2, 7, now, 0x01),
+		Second: makeHeader(t, sk2, 7, now, 0x02),
+	}
+
+	store := NewStore(mock.NewMapDatastore())
+	assert.NoError(t, store.Put(ev))
+	assert.NoError(t, store.Put(ev2))
+
+	// The same evidence with the headers swapped is already known.
+	assert.ErrorIs(t, store.Put(&blocks.EquivocationEvidence{
+		First:  ev.Second,
+		Second: ev.First,
+	}), ErrKnownEvidence)
+
+	assert.ErrorIs(t, store.Put(&blocks.EquivocationEvidence{
+		First: ev.First,
+	}), ErrInvalidEvidence)
+
+	evidence, err := store.Evidence(pid)
+	require.NoError(t, err)
+	require.Len(t, evidence, 1)
+	assert.Equal(t, ev.ID(), evidence[0].ID())
+
+	evidence, err = store.Evidence("")
+	require.NoError(t, err)
+	assert.Len(t, evidence, 2)
+
+	// Only MaxEvidencePerProducer pieces of evidence are kept per producer.
+	for i := 1; i < MaxEvidencePerProducer; i++ {
+		assert.NoError(t, store.Put(&blocks.EquivocationEvidence{
+			First:  makeHeader(t, sk, 5+uint32(i), now, 0x01),
+			Second: makeHeader(t, sk, 5+uint32(i), now, 0x02),
+		}))
+	}
+	assert.ErrorIs(t, store.Put(&blocks.EquivocationEvidence{
+		First:  makeHeader(t, sk, 100, now, 0x01),
+		Second: makeHeader(t, sk, 100, now, 0x02),
+	}), ErrEvidenceLimit)
+
+	evidence, err = store.Evidence(pid)
+	require.NoError(t, err)
+	assert.Len(t, evidence, MaxEvidencePerProducer)
+}
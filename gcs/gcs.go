@@ -0,0 +1,215 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+// Package gcs implements the Golomb-coded sets used for compact block
+// filters. The construction follows BIP158: each item is hashed with
+// SipHash-2-4, keyed by the first bytes of the block ID, into the range
+// [0, N*M), and the sorted values are delta encoded with Golomb-Rice
+// coding.
+package gcs
+
+import (
+	"encoding/binary"
+	"errors"
+	"github.com/dchest/siphash"
+	"math/bits"
+	"sort"
+)
+
+const (
+	// P is the Golomb-Rice coding parameter. The remainder of each
+	// delta is written using P bits.
+	P = 19
+
+	// M is the inverse of the false positive rate of a match.
+	M = 784931
+
+	// KeySize is the size of the SipHash key.
+	KeySize = 16
+)
+
+// ErrInvalidFilter is returned when a serialized filter is malformed.
+var ErrInvalidFilter = errors.New("invalid filter encoding")
+
+// Filter is a Golomb-coded set.
+type Filter struct {
+	n    uint32
+	data []byte
+}
+
+// BuildFilter builds a filter over the items using the key. Duplicate
+// items are only added once.
+func BuildFilter(key [KeySize]byte, items [][]byte) *Filter {
+	seen := make(map[string]struct{}, len(items))
+	unique := make([][]byte, 0, len(items))
+	for _, item := range items {
+		if _, ok := seen[string(item)]; ok {
+			continue
+		}
+		seen[string(item)] = struct{}{}
+		unique = append(unique, item)
+	}
+
+	f := &Filter{n: uint32(len(unique))}
+	if f.n == 0 {
+		return f
+	}
+
+	values := hashItems(key, unique, uint64(f.n)*M)
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+
+	w := &bitWriter{}
+	var last uint64
+	for _, v := range values {
+		delta := v - last
+		last = v
+
+		for q := delta >> P; q > 0; q-- {
+			w.writeBit(true)
+		}
+		w.writeBit(false)
+		w.writeBits(delta, P)
+	}
+	f.data = w.bytes
+	return f
+}
+
+// FromBytes deserializes a filter serialized with Bytes.
+func FromBytes(b []byte) (*Filter, error) {
+	n, i := binary.Uvarint(b)
+	if i <= 0 || n > uint64(^uint32(0)) {
+		return nil, ErrInvalidFilter
+	}
+	return &Filter{
+		n:    uint32(n),
+		data: b[i:],
+	}, nil
+}
+
+// Bytes returns the serialized filter. It is the number of items as a
+// uvarint followed by the Golomb-Rice coded values.
+func (f *Filter) Bytes() []byte {
+	ret := binary.AppendUvarint(nil, uint64(f.n))
+	return append(ret, f.data...)
+}
+
+// N returns the number of items in the filter.
+func (f *Filter) N() uint32 {
+	return f.n
+}
+
+// Match returns whether the item is likely in the filter. False
+// positives occur at a rate of 1/M.
+func (f *Filter) Match(key [KeySize]byte, item []byte) (bool, error) {
+	return f.MatchAny(key, [][]byte{item})
+}
+
+// MatchAny returns whether any of the items are likely in the filter.
+func (f *Filter) MatchAny(key [KeySize]byte, items [][]byte) (bool, error) {
+	if f.n == 0 || len(items) == 0 {
+		return false, nil
+	}
+
+	targets := hashItems(key, items, uint64(f.n)*M)
+	sort.Slice(targets, func(i, j int) bool { return targets[i] < targets[j] })
+
+	r := &bitReader{data: f.data}
+	var (
+		value uint64
+		t     int
+	)
+	for i := uint32(0); i < f.n; i++ {
+		delta, err := r.readValue()
+		if err != nil {
+			return false, err
+		}
+		value += delta
+
+		for targets[t] < value {
+			t++
+			if t == len(targets) {
+				return false, nil
+			}
+		}
+		if targets[t] == value {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// hashItems maps each item uniformly to [0, f).
+func hashItems(key [KeySize]byte, items [][]byte, f uint64) []uint64 {
+	k0 := binary.LittleEndian.Uint64(key[:8])
+	k1 := binary.LittleEndian.Uint64(key[8:])
+
+	values := make([]uint64, 0, len(items))
+	for _, item := range items {
+		hi, _ := bits.Mul64(siphash.Hash(k0, k1, item), f)
+		values = append(values, hi)
+	}
+	return values
+}
+
+type bitWriter struct {
+	bytes []byte
+	nbits uint8
+}
+
+func (w *bitWriter) writeBit(bit bool) {
+	if w.nbits == 0 {
+		w.bytes = append(w.bytes, 0)
+		w.nbits = 8
+	}
+	w.nbits--
+	if bit {
+		w.bytes[len(w.bytes)-1] |= 1 << w.nbits
+	}
+}
+
+func (w *bitWriter) writeBits(v uint64, n int) {
+	for i := n - 1; i >= 0; i-- {
+		w.writeBit(v&(1<<i) != 0)
+	}
+}
+
+type bitReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *bitReader) readBit() (bool, error) {
+	if r.pos >= len(r.data)*8 {
+		return false, ErrInvalidFilter
+	}
+	bit := r.data[r.pos/8]&(1<<(7-r.pos%8)) != 0
+	r.pos++
+	return bit, nil
+}
+
+// readValue reads a Golomb-Rice coded value.
+func (r *bitReader) readValue() (uint64, error) {
+	var q uint64
+	for {
+		bit, err := r.readBit()
+		if err != nil {
+			return 0, err
+		}
+		if !bit {
+			break
+		}
+		q++
+	}
+	v := q << P
+	for i := P - 1; i >= 0; i-- {
+		bit, err := r.readBit()
+		if err != nil {
+			return 0, err
+		}
+		if bit {
+			v |= 1 << i
+		}
+	}
+	return v, nil
+}
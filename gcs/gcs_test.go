@@ -0,0 +1,68 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package gcs
+
+import (
+	"crypto/rand"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFilter(t *testing.T) {
+	var key [KeySize]byte
+	rand.Read(key[:])
+
+	items := make([][]byte, 1000)
+	for i := range items {
+		items[i] = make([]byte, 32)
+		rand.Read(items[i])
+	}
+	// Duplicates are only added once.
+	f := BuildFilter(key, append(items, items[0]))
+	assert.Equal(t, uint32(len(items)), f.N())
+
+	f2, err := FromBytes(f.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, f.N(), f2.N())
+
+	for _, item := range items {
+		match, err := f2.Match(key, item)
+		assert.NoError(t, err)
+		assert.True(t, match)
+	}
+
+	misses := make([][]byte, 100)
+	for i := range misses {
+		misses[i] = make([]byte, 32)
+		rand.Read(misses[i])
+	}
+	match, err := f2.MatchAny(key, misses)
+	assert.NoError(t, err)
+	assert.False(t, match)
+
+	match, err = f2.MatchAny(key, append(misses, items[500]))
+	assert.NoError(t, err)
+	assert.True(t, match)
+
+	// A different key doesn't match.
+	var key2 [KeySize]byte
+	rand.Read(key2[:])
+	match, err = f2.MatchAny(key2, items[:10])
+	assert.NoError(t, err)
+	assert.False(t, match)
+
+	empty := BuildFilter(key, nil)
+	assert.Equal(t, []byte{0}, empty.Bytes())
+	match, err = empty.Match(key, items[0])
+	assert.NoError(t, err)
+	assert.False(t, match)
+
+	_, err = FromBytes(nil)
+	assert.ErrorIs(t, err, ErrInvalidFilter)
+	truncated, err := FromBytes(f.Bytes()[:2])
+	assert.NoError(t, err)
+	_, err = truncated.Match(key, items[0])
+	assert.ErrorIs(t, err, ErrInvalidFilter)
+}
@@ -197,7 +197,7 @@ func (g *BlockGenerator) generateBlock() error {
 		return err
 	}
 
-	now := time.Now()
+	now := g.chain.TimeSource().AdjustedTime()
 	bestID, height, timestamp := g.chain.BestBlock()
 	lastHeight := g.lastHeight
 	g.lastHeight = height
@@ -220,7 +220,11 @@ func (g *BlockGenerator) generateBlock() error {
 		blockTime = timestamp.Unix() + 1
 	}
 	// Don't generate a block if the timestamp would be too far into the future.
-	if blockTime > timestamp.Unix()+int64(blockchain.MaxBlockFutureTime) {
+	maxDrift := g.chain.Params().MaxBlockFutureDrift
+	if maxDrift == 0 {
+		maxDrift = blockchain.MaxBlockFutureTime
+	}
+	if time.Unix(blockTime, 0).After(now.Add(maxDrift)) {
 		return nil
 	}
 
@@ -276,6 +280,10 @@ func (g *BlockGenerator) generateBlock() error {
 	if len(txs) == 0 {
 		return nil
 	}
+	sizeLimit := g.policy.GetBlocksizeSoftLimit()
+	if maxSize := g.chain.Params().MaxBlockSize; sizeLimit > maxSize {
+		sizeLimit = maxSize
+	}
 	blk.Transactions = make([]*transactions.Transaction, 0, len(txs))
 	for _, tx := range txs {
 		txSize, err := tx.SerializedSize()
@@ -284,7 +292,7 @@ func (g *BlockGenerator) generateBlock() error {
 		}
 		blockSize += txSize + txProtobufExtraBytes
 
-		if uint32(blockSize) > g.policy.GetBlocksizeSoftLimit() {
+		if uint32(blockSize) > sizeLimit {
 			break
 		}
 		blk.Transactions = append(blk.Transactions, tx)
@@ -292,18 +300,32 @@ func (g *BlockGenerator) generateBlock() error {
 
 	sort.Sort(mempool.TxSorter(blk.Transactions))
 
-	merkleRoot := blockchain.TransactionsMerkleRoot(blk.Transactions)
-	blk.Header.TxRoot = merkleRoot[:]
+	// The size used above is an estimate. Drop transactions until the
+	// signed block is within the consensus limit so that we never produce
+	// a block which we, and everyone else, would reject.
+	for {
+		merkleRoot := blockchain.TransactionsMerkleRoot(blk.Transactions)
+		blk.Header.TxRoot = merkleRoot[:]
 
-	sigHash, err := blk.Header.SigHash()
-	if err != nil {
-		return err
-	}
-	sig, err := g.privKey.Sign(sigHash)
-	if err != nil {
-		return err
+		sigHash, err := blk.Header.SigHash()
+		if err != nil {
+			return err
+		}
+		sig, err := g.privKey.Sign(sigHash)
+		if err != nil {
+			return err
+		}
+		blk.Header.Signature = sig
+
+		size, err := blk.SerializedSize()
+		if err != nil {
+			return err
+		}
+		if uint32(size) <= g.chain.Params().MaxBlockSize || len(blk.Transactions) <= 1 {
+			break
+		}
+		blk.Transactions = blk.Transactions[:len(blk.Transactions)-1]
 	}
-	blk.Header.Signature = sig
 
 	if invalidIdx, err := g.chain.CheckConnectBlock(blk); err != nil {
 		if invalidIdx >= 0 && invalidIdx < len(blk.Transactions) {
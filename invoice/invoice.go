@@ -0,0 +1,441 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package invoice
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/ipfs/go-cid"
+	ctxio "github.com/jbenet/go-context/io"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	inet "github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-msgio"
+	mh "github.com/multiformats/go-multihash"
+	icrypto "github.com/project-illium/ilxd/crypto"
+	"github.com/project-illium/ilxd/net"
+	"github.com/project-illium/ilxd/params"
+	"github.com/project-illium/ilxd/params/hash"
+	"github.com/project-illium/ilxd/types"
+	"github.com/project-illium/ilxd/types/wire"
+	"github.com/project-illium/ilxd/zk"
+	"github.com/project-illium/walletlib"
+	"io"
+	"time"
+)
+
+const (
+	InvoiceProtocol        = "/invoice/"
+	InvoiceProtocolVersion = "2.0.0"
+
+	// InvoiceExpiry is how long the payee expects payment to a
+	// one-time address after handing it out.
+	InvoiceExpiry = time.Hour
+
+	// invoiceTermsPrefix domain separates the invoice signatures from
+	// signed messages and transaction sighashes.
+	invoiceTermsPrefix = "Illium Invoice:\n"
+
+	// reprovideInterval is how often the served addresses are
+	// re-advertised in the DHT. Provider records expire after a day.
+	reprovideInterval = time.Hour * 12
+
+	maxMemoLen      = 256
+	nonceLen        = 32
+	maxProviders    = 5
+	findPeerTimeout = time.Second * 30
+)
+
+// ErrNoPayee is returned when no peer serving invoices for the
+// address can be found.
+var ErrNoPayee = errors.New("no peer found serving invoices for address")
+
+// Payee is the wallet that receives payments. It hands out the
+// one-time addresses and holds the keys used to sign the terms.
+type Payee interface {
+	NewAddress() (walletlib.Address, error)
+	PrivateKeys() (map[walletlib.WalletPrivateKey]walletlib.Address, error)
+}
+
+// Invoice holds the terms returned by the payee.
+type Invoice struct {
+	PayeeAddress walletlib.Address
+	Address      walletlib.Address
+	Amount       types.Amount
+	Memo         string
+	Expires      time.Time
+}
+
+// InvoiceService is a libp2p network protocol that lets a payer request
+// a fresh one-time address and payment terms directly from the payee's
+// node.
+//
+// The payee advertises each address it serves invoices for in the DHT
+// so the payer only needs to know the payee's address. The response is
+// signed with the spend key of that address which authenticates the
+// terms regardless of which peer delivered them. Note that advertising
+// an address links it to this node's peer ID.
+type InvoiceService struct {
+	ctx     context.Context
+	network *net.Network
+	params  *params.NetworkParams
+	payee   Payee
+	served  map[types.ID]walletlib.Address
+	ms      net.MessageSender
+}
+
+// NewInvoiceService returns a new InvoiceService. If any addresses are
+// provided the stream handler is started and the addresses are
+// advertised in the DHT. Otherwise the service can only be used to
+// request invoices from other peers.
+func NewInvoiceService(ctx context.Context, network *net.Network, params *params.NetworkParams, payee Payee, addrs []walletlib.Address) *InvoiceService {
+	is := &InvoiceService{
+		ctx:     ctx,
+		network: network,
+		params:  params,
+		payee:   payee,
+		served:  make(map[types.ID]walletlib.Address),
+		ms:      net.NewMessageSender(network.Host(), params.ProtocolPrefix+InvoiceProtocol+InvoiceProtocolVersion),
+	}
+	for _, addr := range addrs {
+		is.served[addr.ScriptHash()] = addr
+	}
+
+	if len(is.served) > 0 {
+		is.network.Host().SetStreamHandler(params.ProtocolPrefix+InvoiceProtocol+InvoiceProtocolVersion, is.HandleNewStream)
+		go is.provideAddresses()
+	}
+	return is
+}
+
+// invoiceKey returns the DHT key under which the peers serving invoices
+// for the address are advertised.
+func invoiceKey(addr walletlib.Address) (cid.Cid, error) {
+	scriptHash := addr.ScriptHash()
+	h, err := mh.Sum(append([]byte(InvoiceProtocol), scriptHash[:]...), mh.SHA2_256, -1)
+	if err != nil {
+		return cid.Cid{}, err
+	}
+	return cid.NewCidV1(cid.Raw, h), nil
+}
+
+func (is *InvoiceService) provideAddresses() {
+	ticker := time.NewTicker(reprovideInterval)
+	defer ticker.Stop()
+	for {
+		for _, addr := range is.served {
+			key, err := invoiceKey(addr)
+			if err != nil {
+				continue
+			}
+			if err := is.network.Dht().Provide(is.ctx, key, true); err != nil {
+				log.Debug("Error advertising invoice address", log.ArgsFromMap(map[string]any{
+					"address": addr.String(),
+					"error":   err,
+				}))
+			}
+		}
+		select {
+		case <-is.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (is *InvoiceService) HandleNewStream(s inet.Stream) {
+	go is.handleNewMessage(s)
+}
+
+func (is *InvoiceService) handleNewMessage(s inet.Stream) {
+	defer s.Close()
+	contextReader := ctxio.NewReader(is.ctx, s)
+	reader := msgio.NewVarintReaderSize(contextReader, 1<<23)
+	remotePeer := s.Conn().RemotePeer()
+	defer reader.Close()
+	ticker := time.NewTicker(time.Minute)
+
+	for {
+		select {
+		case <-is.ctx.Done():
+			return
+		case <-ticker.C:
+			return
+		default:
+		}
+
+		msgBytes, err := reader.ReadMsg()
+		if err != nil {
+			reader.ReleaseMsg(msgBytes)
+			if err == io.EOF || err == inet.ErrReset {
+				s.Close()
+				return
+			}
+			log.Debug("Error reading from invoice service stream", log.ArgsFromMap(map[string]any{
+				"peer":  remotePeer,
+				"error": err,
+			}))
+			s.Reset()
+			return
+		}
+		req := new(wire.MsgInvoiceRequest)
+		if err := wire.UnmarshalEnvelope(msgBytes, req); err != nil {
+			reader.ReleaseMsg(msgBytes)
+			log.Debug("Error unmarshalling invoice service message", log.ArgsFromMap(map[string]any{
+				"peer":  remotePeer,
+				"error": err,
+			}))
+			s.Reset()
+			return
+		}
+		reader.ReleaseMsg(msgBytes)
+
+		// Each request derives a new wallet address so peers are
+		// rate limited to keep them from exhausting the keychain.
+		is.network.IncreaseBanscore(remotePeer, 0, 20, "rate limiting invoice protocol")
+
+		resp, err := is.handleInvoiceRequest(req)
+		if err != nil {
+			log.WithCaller(true).Error("Error handling invoice service message", log.ArgsFromMap(map[string]any{
+				"peer":  remotePeer,
+				"error": err,
+			}))
+			resp = &wire.MsgInvoiceResp{Error: wire.ErrorResponse_Unavailable}
+		}
+
+		if err := net.WriteMsg(s, resp); err != nil {
+			log.WithCaller(true).Error("Error writing invoice service response", log.ArgsFromMap(map[string]any{
+				"peer":  remotePeer,
+				"error": err,
+			}))
+			s.Reset()
+			return
+		}
+		ticker.Reset(time.Minute)
+	}
+}
+
+func (is *InvoiceService) handleInvoiceRequest(req *wire.MsgInvoiceRequest) (*wire.MsgInvoiceResp, error) {
+	if len(req.Nonce) != nonceLen || len(req.Memo) > maxMemoLen {
+		return &wire.MsgInvoiceResp{Error: wire.ErrorResponse_BadRequest}, nil
+	}
+	payeeAddr, err := walletlib.DecodeAddress(req.PayeeAddress, is.params)
+	if err != nil {
+		return &wire.MsgInvoiceResp{Error: wire.ErrorResponse_BadRequest}, nil
+	}
+	if _, ok := is.served[payeeAddr.ScriptHash()]; !ok {
+		return &wire.MsgInvoiceResp{Error: wire.ErrorResponse_NotFound}, nil
+	}
+
+	keys, err := is.payee.PrivateKeys()
+	if err != nil {
+		return nil, err
+	}
+	var spendKey crypto.PrivKey
+	for key, addr := range keys {
+		if addr.ScriptHash() == payeeAddr.ScriptHash() {
+			spendKey = key.SpendKey()
+			break
+		}
+	}
+	if spendKey == nil {
+		return &wire.MsgInvoiceResp{Error: wire.ErrorResponse_NotFound}, nil
+	}
+	pubkey, err := crypto.MarshalPublicKey(spendKey.GetPublic())
+	if err != nil {
+		return nil, err
+	}
+
+	addr, err := is.payee.NewAddress()
+	if err != nil {
+		return nil, err
+	}
+	invoice := &Invoice{
+		PayeeAddress: payeeAddr,
+		Address:      addr,
+		Amount:       types.Amount(req.Amount),
+		Memo:         req.Memo,
+		Expires:      time.Now().Add(InvoiceExpiry),
+	}
+	sig, err := spendKey.Sign(invoiceDigest(invoice, req.Nonce))
+	if err != nil {
+		return nil, err
+	}
+	return &wire.MsgInvoiceResp{
+		Address:   addr.String(),
+		Amount:    uint64(invoice.Amount),
+		Memo:      invoice.Memo,
+		Expires:   invoice.Expires.Unix(),
+		PublicKey: pubkey,
+		Signature: sig,
+	}, nil
+}
+
+// RequestInvoice requests a one-time address and payment terms for the
+// payee address. If the peer ID is empty the DHT is searched for peers
+// serving invoices for the address.
+//
+// The returned invoice has been authenticated against the payee address
+// so it is safe to pay regardless of which peer provided it.
+func (is *InvoiceService) RequestInvoice(payeeAddr walletlib.Address, p peer.ID, amount types.Amount, memo string) (*Invoice, error) {
+	if len(memo) > maxMemoLen {
+		return nil, fmt.Errorf("memo exceeds max length of %d", maxMemoLen)
+	}
+	peers := []peer.ID{p}
+	if p == "" {
+		var err error
+		peers, err = is.findPayeePeers(payeeAddr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var err error
+	for _, pid := range peers {
+		var invoice *Invoice
+		invoice, err = is.requestInvoice(pid, payeeAddr, amount, memo)
+		if err == nil {
+			return invoice, nil
+		}
+		log.Debug("Error requesting invoice", log.ArgsFromMap(map[string]any{
+			"peer":  pid,
+			"error": err,
+		}))
+	}
+	return nil, err
+}
+
+func (is *InvoiceService) findPayeePeers(payeeAddr walletlib.Address) ([]peer.ID, error) {
+	key, err := invoiceKey(payeeAddr)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(is.ctx, findPeerTimeout)
+	defer cancel()
+
+	var peers []peer.ID
+	for pi := range is.network.Dht().FindProvidersAsync(ctx, key, maxProviders) {
+		if pi.ID == is.network.Host().ID() {
+			continue
+		}
+		if len(pi.Addrs) > 0 {
+			is.network.Host().Peerstore().AddAddrs(pi.ID, pi.Addrs, time.Hour)
+		}
+		peers = append(peers, pi.ID)
+	}
+	if len(peers) == 0 {
+		return nil, ErrNoPayee
+	}
+	return peers, nil
+}
+
+func (is *InvoiceService) requestInvoice(p peer.ID, payeeAddr walletlib.Address, amount types.Amount, memo string) (*Invoice, error) {
+	nonce := make([]byte, nonceLen)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	var (
+		req = &wire.MsgInvoiceRequest{
+			PayeeAddress: payeeAddr.String(),
+			Amount:       uint64(amount),
+			Memo:         memo,
+			Nonce:        nonce,
+		}
+		resp = new(wire.MsgInvoiceResp)
+	)
+	err := is.ms.SendRequest(is.ctx, p, req, resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != wire.ErrorResponse_None {
+		return nil, fmt.Errorf("error response from peer: %s", resp.GetError().String())
+	}
+
+	invoice, err := verifyInvoice(payeeAddr, req, resp, is.params)
+	if err != nil {
+		is.network.IncreaseBanscore(p, 50, 0, "sent invalid invoice")
+		return nil, err
+	}
+	return invoice, nil
+}
+
+// verifyInvoice checks that the response echoes the requested terms and
+// is signed by the spend key of the payee address.
+func verifyInvoice(payeeAddr walletlib.Address, req *wire.MsgInvoiceRequest, resp *wire.MsgInvoiceResp, params *params.NetworkParams) (*Invoice, error) {
+	if resp.Amount != req.Amount || resp.Memo != req.Memo {
+		return nil, errors.New("invoice terms do not match request")
+	}
+	expires := time.Unix(resp.Expires, 0)
+	if !expires.After(time.Now()) {
+		return nil, errors.New("invoice is expired")
+	}
+	addr, err := walletlib.DecodeAddress(resp.Address, params)
+	if err != nil {
+		return nil, err
+	}
+
+	pub, err := crypto.UnmarshalPublicKey(resp.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	novaKey, ok := pub.(*icrypto.NovaPublicKey)
+	if !ok {
+		return nil, errors.New("public key is not type Nova public key")
+	}
+	pubX, pubY := novaKey.ToXY()
+	scriptCommitment, err := zk.LurkCommit(zk.BasicTransferScript())
+	if err != nil {
+		return nil, err
+	}
+	lockingScript := types.LockingScript{
+		ScriptCommitment: types.NewID(scriptCommitment),
+		LockingParams:    [][]byte{pubX, pubY},
+	}
+	scriptHash, err := lockingScript.Hash()
+	if err != nil {
+		return nil, err
+	}
+	if scriptHash != payeeAddr.ScriptHash() {
+		return nil, errors.New("public key does not match payee address")
+	}
+
+	invoice := &Invoice{
+		PayeeAddress: payeeAddr,
+		Address:      addr,
+		Amount:       types.Amount(resp.Amount),
+		Memo:         resp.Memo,
+		Expires:      expires,
+	}
+	valid, err := novaKey.Verify(invoiceDigest(invoice, req.Nonce), resp.Signature)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return nil, errors.New("invalid invoice signature")
+	}
+	return invoice, nil
+}
+
+// invoiceDigest returns the digest that is signed by the payee. The
+// full one-time address is covered, not just its script hash, so the
+// view key cannot be swapped by whoever relays the response.
+func invoiceDigest(invoice *Invoice, nonce []byte) []byte {
+	payeeScriptHash := invoice.PayeeAddress.ScriptHash()
+	addr := invoice.Address.String()
+
+	preimage := make([]byte, 0, len(invoiceTermsPrefix)+32+4+len(addr)+8+8+len(nonce)+len(invoice.Memo))
+	preimage = append(preimage, invoiceTermsPrefix...)
+	preimage = append(preimage, payeeScriptHash[:]...)
+	preimage = binary.BigEndian.AppendUint32(preimage, uint32(len(addr)))
+	preimage = append(preimage, addr...)
+	preimage = binary.BigEndian.AppendUint64(preimage, uint64(invoice.Amount))
+	preimage = binary.BigEndian.AppendUint64(preimage, uint64(invoice.Expires.Unix()))
+	preimage = append(preimage, nonce...)
+	preimage = append(preimage, invoice.Memo...)
+	return hash.HashFunc(preimage)
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package invoice_test
+
+import (
+	"context"
+	"crypto/rand"
+	"github.com/libp2p/go-libp2p/core/peer"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	icrypto "github.com/project-illium/ilxd/crypto"
+	"github.com/project-illium/ilxd/invoice"
+	"github.com/project-illium/ilxd/net"
+	"github.com/project-illium/ilxd/params"
+	"github.com/project-illium/ilxd/repo"
+	"github.com/project-illium/ilxd/repo/mock"
+	"github.com/project-illium/ilxd/types"
+	"github.com/project-illium/ilxd/types/blocks"
+	"github.com/project-illium/ilxd/types/transactions"
+	"github.com/project-illium/ilxd/zk"
+	"github.com/project-illium/walletlib"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type mockPayee struct {
+	keys map[walletlib.WalletPrivateKey]walletlib.Address
+}
+
+func (m *mockPayee) NewAddress() (walletlib.Address, error) {
+	_, addr, err := randomKeyAndAddress()
+	return addr, err
+}
+
+func (m *mockPayee) PrivateKeys() (map[walletlib.WalletPrivateKey]walletlib.Address, error) {
+	return m.keys, nil
+}
+
+func randomKeyAndAddress() (*walletlib.WalletPrivateKey, walletlib.Address, error) {
+	spendKey, _, err := icrypto.GenerateNovaKey(rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	viewKey, _, err := icrypto.GenerateCurve25519Key(rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	rawSpend, err := spendKey.Raw()
+	if err != nil {
+		return nil, nil, err
+	}
+	rawView, err := viewKey.Raw()
+	if err != nil {
+		return nil, nil, err
+	}
+	keyBytes := make([]byte, 0, 64)
+	keyBytes = append(keyBytes, rawSpend[:32]...)
+	keyBytes = append(keyBytes, rawView[:32]...)
+	key, err := walletlib.UnmarshalWalletPrivateKey(keyBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pubX, pubY := spendKey.GetPublic().(*icrypto.NovaPublicKey).ToXY()
+	scriptCommitment, err := zk.LurkCommit(zk.BasicTransferScript())
+	if err != nil {
+		return nil, nil, err
+	}
+	lockingScript := types.LockingScript{
+		ScriptCommitment: types.NewID(scriptCommitment),
+		LockingParams:    [][]byte{pubX, pubY},
+	}
+	addr, err := walletlib.NewBasicAddress(lockingScript, viewKey.GetPublic(), &params.RegestParams)
+	if err != nil {
+		return nil, nil, err
+	}
+	return key.(*walletlib.WalletPrivateKey), addr, nil
+}
+
+func makeNetwork(t *testing.T, mn mocknet.Mocknet) *net.Network {
+	host, err := mn.GenPeer()
+	assert.NoError(t, err)
+	network, err := net.NewNetwork(context.Background(), []net.Option{
+		net.WithHost(host),
+		net.Params(&params.RegestParams),
+		net.BlockValidator(func(*blocks.XThinnerBlock, peer.ID) error {
+			return nil
+		}),
+		net.MempoolValidator(func(transaction *transactions.Transaction) error {
+			return nil
+		}),
+		net.Datastore(mock.NewMapDatastore()),
+		net.MaxMessageSize(repo.DefaultMaxMessageSize),
+	}...)
+	assert.NoError(t, err)
+	return network
+}
+
+func TestInvoiceProtocol(t *testing.T) {
+	mn := mocknet.New()
+	network1 := makeNetwork(t, mn)
+	network2 := makeNetwork(t, mn)
+	assert.NoError(t, mn.LinkAll())
+	assert.NoError(t, mn.ConnectAllButSelf())
+
+	key, payeeAddr, err := randomKeyAndAddress()
+	assert.NoError(t, err)
+	_, otherAddr, err := randomKeyAndAddress()
+	assert.NoError(t, err)
+
+	payee := &mockPayee{
+		keys: map[walletlib.WalletPrivateKey]walletlib.Address{*key: payeeAddr},
+	}
+	invoice.NewInvoiceService(context.Background(), network1, &params.RegestParams, payee, []walletlib.Address{payeeAddr})
+	s2 := invoice.NewInvoiceService(context.Background(), network2, &params.RegestParams, nil, nil)
+
+	inv, err := s2.RequestInvoice(payeeAddr, network1.Host().ID(), 12345, "order #1")
+	assert.NoError(t, err)
+	assert.Equal(t, payeeAddr.String(), inv.PayeeAddress.String())
+	assert.NotEqual(t, payeeAddr.String(), inv.Address.String())
+	assert.Equal(t, types.Amount(12345), inv.Amount)
+	assert.Equal(t, "order #1", inv.Memo)
+	assert.True(t, inv.Expires.After(time.Now()))
+
+	// Each request is given a fresh address.
+	inv2, err := s2.RequestInvoice(payeeAddr, network1.Host().ID(), 12345, "order #1")
+	assert.NoError(t, err)
+	assert.NotEqual(t, inv.Address.String(), inv2.Address.String())
+
+	// Addresses that are not served are rejected.
+	_, err = s2.RequestInvoice(otherAddr, network1.Host().ID(), 12345, "")
+	assert.Error(t, err)
+}
@@ -10,9 +10,12 @@ import (
 	"github.com/project-illium/ilxd/blockchain/indexers"
 	"github.com/project-illium/ilxd/consensus"
 	"github.com/project-illium/ilxd/gen"
+	"github.com/project-illium/ilxd/invoice"
 	"github.com/project-illium/ilxd/mempool"
+	"github.com/project-illium/ilxd/plugins"
 	"github.com/project-illium/ilxd/policy"
 	"github.com/project-illium/ilxd/policy/protocol"
+	"github.com/project-illium/ilxd/recovery"
 	"github.com/project-illium/ilxd/sync"
 	"github.com/project-illium/walletlib"
 	"github.com/pterm/pterm"
@@ -65,6 +68,9 @@ func setupLogging(logDir, level string, testnet bool) error {
 	indexers.UseLogger(log)
 	policy.UseLogger(log)
 	protocol.UseLogger(log)
+	invoice.UseLogger(log)
+	plugins.UseLogger(log)
+	recovery.UseLogger(log)
 	return nil
 }
 
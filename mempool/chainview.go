@@ -8,6 +8,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/project-illium/ilxd/blockchain"
 	"github.com/project-illium/ilxd/types"
+	"time"
 )
 
 // ChainView is an interface of methods that provide the blockchain
@@ -26,4 +27,12 @@ type ChainView interface {
 
 	// GetValidator returns the validator for the given ID
 	GetValidator(validatorID peer.ID) (*blockchain.Validator, error)
+
+	// BestBlock returns the ID, height, and timestamp of the block
+	// at the tip of the chain.
+	BestBlock() (types.ID, uint32, time.Time)
+
+	// RewardsMatureAt returns the height of the first block which
+	// may include a coinbase claiming the current epoch's rewards.
+	RewardsMatureAt() uint32
 }
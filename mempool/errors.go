@@ -8,6 +8,8 @@ import (
 	"errors"
 	"fmt"
 	"github.com/project-illium/ilxd/blockchain"
+	"github.com/project-illium/ilxd/types"
+	"github.com/project-illium/ilxd/types/transactions"
 )
 
 // AssertError identifies an error that indicates an internal code consistency
@@ -75,3 +77,26 @@ func (e PolicyError) Error() string {
 func policyError(c ErrorCode, desc string) PolicyError {
 	return PolicyError{ErrorCode: c, Description: desc}
 }
+
+// PackageError is returned when a transaction in a package fails
+// validation. None of the package's transactions are accepted.
+type PackageError struct {
+	// Index is the position of the transaction in the package.
+	Index int
+	Txid  types.ID
+	Err   error
+}
+
+// Error satisfies the error interface and prints human-readable errors.
+func (e PackageError) Error() string {
+	return fmt.Sprintf("package transaction %d (%s): %s", e.Index, e.Txid, e.Err)
+}
+
+// Unwrap returns the error the transaction failed with.
+func (e PackageError) Unwrap() error {
+	return e.Err
+}
+
+func packageError(index int, tx *transactions.Transaction, err error) PackageError {
+	return PackageError{Index: index, Txid: tx.ID(), Err: err}
+}
@@ -5,22 +5,37 @@
 package mempool
 
 import (
+	"context"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/project-illium/ilxd/blockchain"
 	"github.com/project-illium/ilxd/policy"
 	"github.com/project-illium/ilxd/types"
 	"github.com/project-illium/ilxd/types/blocks"
 	"github.com/project-illium/ilxd/types/transactions"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/protobuf/proto"
+	"sync"
 	"time"
 )
 
-const minTimeAfterRejection = time.Minute
+const (
+	minTimeAfterRejection = time.Minute
+
+	// MaxPackageSize is the maximum number of transactions which may
+	// be submitted together with ProcessPackage.
+	MaxPackageSize = 25
+)
 
 type processTxReq struct {
 	tx         *transactions.Transaction
 	resultChan chan error
 }
+type processPackageReq struct {
+	txs        []*transactions.Transaction
+	resultChan chan error
+}
 type validateTxReq struct {
 	tx         *transactions.Transaction
 	resultChan chan error
@@ -56,6 +71,9 @@ type Mempool struct {
 	cfg            *config
 	msgChan        chan interface{}
 	quit           chan struct{}
+
+	notifications     []NotificationCallback
+	notificationsLock sync.RWMutex
 }
 
 // NewMempool returns a new mempool with the configuration options.
@@ -100,6 +118,8 @@ func (m *Mempool) validationHandler() {
 			switch req := msg.(type) {
 			case *processTxReq:
 				req.resultChan <- m.processTransaction(req.tx)
+			case *processPackageReq:
+				req.resultChan <- m.processPackage(req.txs)
 			case *removeBlockTxsReq:
 				m.removeBlockTransactions(req.txs)
 			case *getTransactionReq:
@@ -113,9 +133,16 @@ func (m *Mempool) validationHandler() {
 			}
 		case <-ticker.C:
 			toDelete := make([]*transactions.Transaction, 0)
+			now := m.cfg.timeSource.AdjustedTime()
 			for _, tx := range m.pool {
 				if time.Now().After(tx.firstSeen.Add(m.cfg.transactionTTL)) {
 					toDelete = append(toDelete, tx.tx)
+					continue
+				}
+				// Transactions past their locktime expiry can never
+				// be included in a block.
+				if expiry, ok := blockchain.TransactionExpiry(tx.tx); ok && !now.Before(expiry) {
+					toDelete = append(toDelete, tx.tx)
 				}
 			}
 			if len(toDelete) > 0 {
@@ -134,8 +161,18 @@ func (m *Mempool) validationHandler() {
 // validation, such as the expensive signature and proof checks, without locking.
 // The rest of validation, such as nullifier checks, duplicate mempool checks, etc.
 // are done in a single threaded channel.
-func (m *Mempool) ProcessTransaction(tx *transactions.Transaction) error {
-	if err := blockchain.CheckTransactionSanity(tx, time.Now()); err != nil {
+func (m *Mempool) ProcessTransaction(tx *transactions.Transaction) (err error) {
+	_, span := tracer.Start(context.Background(), "mempool.ProcessTransaction",
+		trace.WithAttributes(attribute.String("illium.txid", tx.ID().String())))
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if err := blockchain.CheckTransactionSanity(tx, m.cfg.params, m.cfg.timeSource.AdjustedTime()); err != nil {
 		return err
 	}
 
@@ -158,6 +195,7 @@ func (m *Mempool) ProcessTransaction(tx *transactions.Transaction) error {
 	if err != nil {
 		return err
 	}
+	span.AddEvent("proofs and signatures validated")
 
 	resultChan := make(chan error)
 	defer close(resultChan)
@@ -169,6 +207,108 @@ func (m *Mempool) ProcessTransaction(tx *transactions.Transaction) error {
 	return err
 }
 
+// ProcessPackage evaluates a group of transactions and accepts all of them
+// into the mempool if every one passes validation. If any transaction fails
+// none are added and a PackageError identifying it is returned.
+//
+// Transactions may not spend outputs which are not yet in the chain so the
+// transactions in a package can't depend on each other's outputs. They may
+// not spend the same nullifiers. Only standard, mint, and stake transactions
+// may be included.
+//
+// This method is safe for concurrent access. Like ProcessTransaction the
+// proof and signature checks are done without locking.
+func (m *Mempool) ProcessPackage(txs []*transactions.Transaction) (err error) {
+	_, span := tracer.Start(context.Background(), "mempool.ProcessPackage",
+		trace.WithAttributes(attribute.Int("illium.package_size", len(txs))))
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if len(txs) == 0 {
+		return ruleError(blockchain.ErrInvalidTx, "package is empty")
+	}
+	if len(txs) > MaxPackageSize {
+		return ruleError(blockchain.ErrInvalidTx, "package contains too many transactions")
+	}
+
+	var (
+		txids      = make(map[types.ID]bool)
+		nullifiers = make(map[types.Nullifier]bool)
+	)
+	for i, tx := range txs {
+		switch tx.GetTx().(type) {
+		case *transactions.Transaction_StandardTransaction,
+			*transactions.Transaction_MintTransaction,
+			*transactions.Transaction_StakeTransaction:
+		default:
+			return packageError(i, tx, ruleError(blockchain.ErrInvalidTx, "package may only contain standard, mint, and stake transactions"))
+		}
+		if txids[tx.ID()] {
+			return packageError(i, tx, ErrDuplicateTx)
+		}
+		txids[tx.ID()] = true
+		for _, n := range tx.Nullifiers() {
+			if nullifiers[n] {
+				return packageError(i, tx, ruleError(blockchain.ErrDoubleSpend, "nullifier spent by another transaction in the package"))
+			}
+			nullifiers[n] = true
+		}
+
+		if err := blockchain.CheckTransactionSanity(tx, m.cfg.params, m.cfg.timeSource.AdjustedTime()); err != nil {
+			return packageError(i, tx, err)
+		}
+		fpkb, isFeePayer, err := policy.CalcFeePerKilobyte(tx)
+		if err != nil {
+			return packageError(i, tx, err)
+		}
+		if isFeePayer && fpkb < m.cfg.policy.GetMinFeePerKilobyte() {
+			return packageError(i, tx, policyError(ErrFeeTooLow, "transaction fee is below policy minimum"))
+		}
+	}
+
+	// Validate the proofs and signatures of all the transactions
+	// at once.
+	var (
+		proofChans = make([]<-chan error, len(txs))
+		sigChans   = make([]<-chan error, len(txs))
+	)
+	for i, tx := range txs {
+		proofChans[i] = blockchain.ValidateTransactionProof(tx, m.cfg.proofCache, m.cfg.verifier)
+		sigChans[i] = blockchain.ValidateTransactionSig(tx, m.cfg.sigCache)
+	}
+	var validationErr error
+	for i, tx := range txs {
+		if err := <-proofChans[i]; err != nil && validationErr == nil {
+			validationErr = packageError(i, tx, err)
+		}
+		if err := <-sigChans[i]; err != nil && validationErr == nil {
+			validationErr = packageError(i, tx, err)
+		}
+	}
+	if validationErr != nil {
+		return validationErr
+	}
+	span.AddEvent("proofs and signatures validated")
+
+	cpy := make([]*transactions.Transaction, 0, len(txs))
+	for _, tx := range txs {
+		cpy = append(cpy, proto.Clone(tx).(*transactions.Transaction))
+	}
+	resultChan := make(chan error)
+	defer close(resultChan)
+	m.msgChan <- &processPackageReq{
+		txs:        cpy,
+		resultChan: resultChan,
+	}
+	err = <-resultChan
+	return err
+}
+
 // GetTransaction returns a transaction given the ID if it exists in the pool.
 //
 // This method is safe for concurrent access.
@@ -313,7 +453,7 @@ func (m *Mempool) handleRejectedBlock(blk *blocks.Block) {
 //
 // This function is safe for concurrent access.
 func (m *Mempool) ValidateTransaction(tx *transactions.Transaction, validatedProof bool) error {
-	if err := blockchain.CheckTransactionSanity(tx, time.Now()); err != nil {
+	if err := blockchain.CheckTransactionSanity(tx, m.cfg.params, m.cfg.timeSource.AdjustedTime()); err != nil {
 		return err
 	}
 
@@ -366,6 +506,9 @@ func (m *Mempool) handleValidateTransaction(tx *transactions.Transaction) error
 		if types.Amount(t.CoinbaseTransaction.NewCoins) != validator.UnclaimedCoins || t.CoinbaseTransaction.NewCoins == 0 {
 			return ruleError(blockchain.ErrInvalidTx, "coinbase transaction creates invalid number of coins")
 		}
+		if _, height, _ := m.cfg.chainView.BestBlock(); height+1 < m.cfg.chainView.RewardsMatureAt() {
+			return ruleError(blockchain.ErrImmatureCoinbase, "coinbase claims rewards which have not matured")
+		}
 
 		if !m.cfg.policy.GetValidatorAcceptableCoinbase(validatorID) {
 			return policyError(ErrPoorValidatorUptime, "coinbase for peer with poor uptime")
@@ -426,7 +569,7 @@ func (m *Mempool) handleValidateTransaction(tx *transactions.Transaction) error
 		if err == nil {
 			stake, exists := validator.Nullifiers[types.NewNullifier(t.StakeTransaction.Nullifier)]
 			if exists {
-				if stake.Blockstamp.Add(blockchain.ValidatorExpiration - blockchain.RestakePeriod).After(time.Now()) {
+				if stake.Blockstamp.Add(blockchain.ValidatorExpiration - blockchain.RestakePeriod).After(m.cfg.timeSource.AdjustedTime()) {
 					return ruleError(blockchain.ErrRestakeTooEarly, "restake transaction too early")
 				}
 			}
@@ -477,6 +620,32 @@ func (m *Mempool) handleValidateTransaction(tx *transactions.Transaction) error
 	return nil
 }
 
+// processPackage is the implementation for ProcessPackage. All the
+// transactions are validated against the pool and the chain before
+// any are added.
+//
+// This method is NOT safe for concurrent access.
+func (m *Mempool) processPackage(txs []*transactions.Transaction) error {
+	for i, tx := range txs {
+		if _, ok := m.pool[tx.ID()]; ok {
+			return packageError(i, tx, ErrDuplicateTx)
+		}
+		if err := m.handleValidateTransaction(tx); err != nil {
+			return packageError(i, tx, err)
+		}
+	}
+	for i, tx := range txs {
+		if err := m.processTransaction(tx); err != nil {
+			// The transactions were validated above so this should
+			// not happen. Remove the ones added so the package is
+			// not partially accepted.
+			m.removeBlockTransactions(txs[:i])
+			return packageError(i, tx, err)
+		}
+	}
+	return nil
+}
+
 // processTransaction is the implementation for ProcessTransaction.
 //
 // This method is NOT safe for concurrent access.
@@ -498,6 +667,9 @@ func (m *Mempool) processTransaction(tx *transactions.Transaction) error {
 		if types.Amount(t.CoinbaseTransaction.NewCoins) != validator.UnclaimedCoins || t.CoinbaseTransaction.NewCoins == 0 {
 			return ruleError(blockchain.ErrInvalidTx, "coinbase transaction creates invalid number of coins")
 		}
+		if _, height, _ := m.cfg.chainView.BestBlock(); height+1 < m.cfg.chainView.RewardsMatureAt() {
+			return ruleError(blockchain.ErrImmatureCoinbase, "coinbase claims rewards which have not matured")
+		}
 
 		if !m.cfg.policy.GetValidatorAcceptableCoinbase(validatorID) {
 			return policyError(ErrPoorValidatorUptime, "coinbase for peer with poor uptime")
@@ -522,6 +694,7 @@ func (m *Mempool) processTransaction(tx *transactions.Transaction) error {
 	case *transactions.Transaction_StandardTransaction:
 		for _, n := range t.StandardTransaction.Nullifiers {
 			if _, ok := m.nullifiers[types.NewNullifier(n)]; ok {
+				m.alertDoubleSpend(tx.ID(), types.NewNullifier(n))
 				return ruleError(blockchain.ErrDoubleSpend, "nullifier already in mempool")
 			}
 			exists, err := m.cfg.chainView.NullifierExists(types.NewNullifier(n))
@@ -545,6 +718,7 @@ func (m *Mempool) processTransaction(tx *transactions.Transaction) error {
 	case *transactions.Transaction_MintTransaction:
 		for _, n := range t.MintTransaction.Nullifiers {
 			if _, ok := m.nullifiers[types.NewNullifier(n)]; ok {
+				m.alertDoubleSpend(tx.ID(), types.NewNullifier(n))
 				return ruleError(blockchain.ErrDoubleSpend, "nullifier already in mempool")
 			}
 			exists, err := m.cfg.chainView.NullifierExists(types.NewNullifier(n))
@@ -570,6 +744,7 @@ func (m *Mempool) processTransaction(tx *transactions.Transaction) error {
 			return policyError(ErrMinStake, "stake amount below policy minimum")
 		}
 		if _, ok := m.nullifiers[types.NewNullifier(t.StakeTransaction.Nullifier)]; ok {
+			m.alertDoubleSpend(tx.ID(), types.NewNullifier(t.StakeTransaction.Nullifier))
 			return ruleError(blockchain.ErrDoubleSpend, "stake nullifier already in mempool")
 		}
 		valID, err := peer.IDFromBytes(t.StakeTransaction.Validator_ID)
@@ -580,7 +755,7 @@ func (m *Mempool) processTransaction(tx *transactions.Transaction) error {
 		if err == nil {
 			stake, exists := validator.Nullifiers[types.NewNullifier(t.StakeTransaction.Nullifier)]
 			if exists {
-				if stake.Blockstamp.Add(blockchain.ValidatorExpiration - blockchain.RestakePeriod).After(time.Now()) {
+				if stake.Blockstamp.Add(blockchain.ValidatorExpiration - blockchain.RestakePeriod).After(m.cfg.timeSource.AdjustedTime()) {
 					return ruleError(blockchain.ErrRestakeTooEarly, "restake transaction too early")
 				}
 			}
@@ -638,5 +813,6 @@ func (m *Mempool) processTransaction(tx *transactions.Transaction) error {
 		"txid": tx.ID().String(),
 		"type": tx.Type(),
 	}))
+	m.sendNotification(NTTransactionAccepted, tx)
 	return nil
 }
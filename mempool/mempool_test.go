@@ -404,6 +404,36 @@ func TestMempool(t *testing.T) {
 			},
 			expectedErr: ruleError(blockchain.ErrInvalidTx, ""),
 		},
+		{
+			name: "coinbase tx immature",
+			tx: transactions.WrapTransaction(&transactions.CoinbaseTransaction{
+				Validator_ID: valBytes,
+				NewCoins:     10000,
+				Outputs: []*transactions.Output{
+					{
+						Commitment: make([]byte, types.CommitmentLen),
+						Ciphertext: make([]byte, blockchain.CiphertextLen),
+					},
+				},
+				Signature: nil,
+				Proof:     make([]byte, 1000),
+			}),
+			signFunc: func(tx *transactions.Transaction) error {
+				view.height = 5
+				view.matureAt = 7
+				h, err := tx.GetCoinbaseTransaction().SigHash()
+				if err != nil {
+					return err
+				}
+				sig, err := sk.Sign(h)
+				if err != nil {
+					return err
+				}
+				tx.GetCoinbaseTransaction().Signature = sig
+				return nil
+			},
+			expectedErr: ruleError(blockchain.ErrImmatureCoinbase, ""),
+		},
 		{
 			name: "valid coinbase tx",
 			tx: transactions.WrapTransaction(&transactions.CoinbaseTransaction{
@@ -419,6 +449,7 @@ func TestMempool(t *testing.T) {
 				Proof:     make([]byte, 1000),
 			}),
 			signFunc: func(tx *transactions.Transaction) error {
+				view.height = 6
 				h, err := tx.GetCoinbaseTransaction().SigHash()
 				if err != nil {
 					return err
@@ -752,6 +783,196 @@ func newMockBlockchainView() *mockBlockchainView {
 	}
 }
 
+func TestDoubleSpendAlert(t *testing.T) {
+	view := newMockBlockchainView()
+	verifier := &zk.MockVerifier{}
+	verifier.SetValid(true)
+	m, err := NewMempool(
+		DefaultOptions(),
+		BlockchainView(view),
+		Verifier(verifier),
+	)
+	assert.NoError(t, err)
+	defer m.Close()
+
+	alerts := make(chan *DoubleSpendAlert, 1)
+	m.Subscribe(func(n *Notification) {
+		if n.Type == NTDoubleSpend {
+			alerts <- n.Data.(*DoubleSpendAlert)
+		}
+	})
+
+	txoRoot := randomID()
+	view.txoRoots[txoRoot] = true
+	nullifier := randomID()
+
+	makeTx := func(fee uint64) *transactions.Transaction {
+		return transactions.WrapTransaction(&transactions.StandardTransaction{
+			Outputs: []*transactions.Output{
+				{
+					Commitment: make([]byte, types.CommitmentLen),
+					Ciphertext: make([]byte, blockchain.CiphertextLen),
+				},
+			},
+			Nullifiers: [][]byte{nullifier[:]},
+			TxoRoot:    txoRoot[:],
+			Fee:        fee,
+			Proof:      make([]byte, 1000),
+		})
+	}
+
+	tx1 := makeTx(20000)
+	assert.NoError(t, m.ProcessTransaction(tx1))
+
+	tx2 := makeTx(30000)
+	err = m.ProcessTransaction(tx2)
+	assert.Equal(t, blockchain.ErrorCode(blockchain.ErrDoubleSpend), err.(blockchain.RuleError).ErrorCode)
+
+	select {
+	case alert := <-alerts:
+		assert.Equal(t, tx2.ID(), alert.Txid)
+		assert.Equal(t, tx1.ID(), alert.ConflictingTxid)
+		assert.Equal(t, types.NewNullifier(nullifier[:]), alert.Nullifier)
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for double spend alert")
+	}
+
+	// The first transaction remains in the pool.
+	_, err = m.GetTransaction(tx1.ID())
+	assert.NoError(t, err)
+	_, err = m.GetTransaction(tx2.ID())
+	assert.Error(t, err)
+}
+
+func TestTransactionAcceptedNotification(t *testing.T) {
+	view := newMockBlockchainView()
+	verifier := &zk.MockVerifier{}
+	verifier.SetValid(true)
+	m, err := NewMempool(
+		DefaultOptions(),
+		BlockchainView(view),
+		Verifier(verifier),
+	)
+	assert.NoError(t, err)
+	defer m.Close()
+
+	accepted := make(chan *transactions.Transaction, 1)
+	m.Subscribe(func(n *Notification) {
+		if n.Type == NTTransactionAccepted {
+			accepted <- n.Data.(*transactions.Transaction)
+		}
+	})
+
+	txoRoot := randomID()
+	view.txoRoots[txoRoot] = true
+	nullifier := randomID()
+
+	tx := transactions.WrapTransaction(&transactions.StandardTransaction{
+		Outputs: []*transactions.Output{
+			{
+				Commitment: make([]byte, types.CommitmentLen),
+				Ciphertext: make([]byte, blockchain.CiphertextLen),
+			},
+		},
+		Nullifiers: [][]byte{nullifier[:]},
+		TxoRoot:    txoRoot[:],
+		Fee:        20000,
+		Proof:      make([]byte, 1000),
+	})
+	assert.NoError(t, m.ProcessTransaction(tx))
+
+	select {
+	case n := <-accepted:
+		assert.Equal(t, tx.ID(), n.ID())
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for accepted notification")
+	}
+}
+
+func TestProcessPackage(t *testing.T) {
+	view := newMockBlockchainView()
+	verifier := &zk.MockVerifier{}
+	verifier.SetValid(true)
+	m, err := NewMempool(
+		DefaultOptions(),
+		BlockchainView(view),
+		Verifier(verifier),
+	)
+	assert.NoError(t, err)
+	defer m.Close()
+
+	txoRoot := randomID()
+	view.txoRoots[txoRoot] = true
+
+	makeTx := func(nullifier types.ID, txoRoot types.ID) *transactions.Transaction {
+		return transactions.WrapTransaction(&transactions.StandardTransaction{
+			Outputs: []*transactions.Output{
+				{
+					Commitment: make([]byte, types.CommitmentLen),
+					Ciphertext: make([]byte, blockchain.CiphertextLen),
+				},
+			},
+			Nullifiers: [][]byte{nullifier[:]},
+			TxoRoot:    txoRoot[:],
+			Fee:        20000,
+			Proof:      make([]byte, 1000),
+		})
+	}
+
+	assertNotInPool := func(txs ...*transactions.Transaction) {
+		for _, tx := range txs {
+			_, err := m.GetTransaction(tx.ID())
+			assert.ErrorIs(t, err, ErrNotFound)
+		}
+	}
+
+	// The second transaction has an unknown txo root so
+	// neither is accepted.
+	tx1 := makeTx(randomID(), txoRoot)
+	tx2 := makeTx(randomID(), randomID())
+	err = m.ProcessPackage([]*transactions.Transaction{tx1, tx2})
+	var pkgErr PackageError
+	assert.ErrorAs(t, err, &pkgErr)
+	assert.Equal(t, 1, pkgErr.Index)
+	assert.Equal(t, tx2.ID(), pkgErr.Txid)
+	assertNotInPool(tx1, tx2)
+
+	// Transactions in a package may not spend the same nullifier.
+	nullifier := randomID()
+	tx3 := makeTx(nullifier, txoRoot)
+	tx4 := makeTx(nullifier, txoRoot)
+	tx4.GetStandardTransaction().Fee = 30000
+	err = m.ProcessPackage([]*transactions.Transaction{tx3, tx4})
+	assert.ErrorAs(t, err, &pkgErr)
+	assert.Equal(t, blockchain.ErrorCode(blockchain.ErrDoubleSpend), pkgErr.Err.(blockchain.RuleError).ErrorCode)
+	assertNotInPool(tx3, tx4)
+
+	// Or ones in the pool.
+	tx5 := makeTx(randomID(), txoRoot)
+	assert.NoError(t, m.ProcessTransaction(tx5))
+	tx6 := makeTx(randomID(), txoRoot)
+	tx7 := makeTx(types.NewID(tx5.Nullifiers()[0].Bytes()), txoRoot)
+	tx7.GetStandardTransaction().Fee = 30000
+	err = m.ProcessPackage([]*transactions.Transaction{tx6, tx7})
+	assert.ErrorAs(t, err, &pkgErr)
+	assert.Equal(t, 1, pkgErr.Index)
+	assertNotInPool(tx6, tx7)
+
+	// A valid package is accepted.
+	err = m.ProcessPackage([]*transactions.Transaction{tx1, tx3, tx6})
+	assert.NoError(t, err)
+	for _, tx := range []*transactions.Transaction{tx1, tx3, tx6} {
+		_, err := m.GetTransaction(tx.ID())
+		assert.NoError(t, err)
+	}
+
+	// Submitting it again fails.
+	err = m.ProcessPackage([]*transactions.Transaction{tx1})
+	assert.ErrorIs(t, err, ErrDuplicateTx)
+
+	assert.Error(t, m.ProcessPackage(nil))
+}
+
 func randomID() types.ID {
 	r := make([]byte, 32)
 	rand.Read(r)
@@ -763,6 +984,8 @@ type mockBlockchainView struct {
 	txoRoots        map[types.ID]bool
 	nullifiers      map[types.Nullifier]bool
 	validators      map[peer.ID]*blockchain.Validator
+	height          uint32
+	matureAt        uint32
 }
 
 func (m *mockBlockchainView) TreasuryBalance() (types.Amount, error) {
@@ -784,3 +1007,11 @@ func (m *mockBlockchainView) GetValidator(validatorID peer.ID) (*blockchain.Vali
 	}
 	return val, nil
 }
+
+func (m *mockBlockchainView) BestBlock() (types.ID, uint32, time.Time) {
+	return types.ID{}, m.height, time.Time{}
+}
+
+func (m *mockBlockchainView) RewardsMatureAt() uint32 {
+	return m.matureAt
+}
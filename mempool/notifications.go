@@ -0,0 +1,106 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package mempool
+
+import (
+	"fmt"
+	"github.com/project-illium/ilxd/types"
+)
+
+// NotificationType represents the type of a notification message.
+type NotificationType int
+
+// NotificationCallback is used for a caller to provide a callback for
+// notifications about various mempool events.
+type NotificationCallback func(*Notification)
+
+// Constants for the type of notification message
+const (
+	// NTDoubleSpend indicates a transaction was rejected because it
+	// spends a nullifier already spent by a transaction in the pool.
+	NTDoubleSpend NotificationType = iota
+
+	// NTTransactionAccepted indicates a transaction was validated
+	// and added to the pool.
+	NTTransactionAccepted
+)
+
+// notificationTypeStrings is a map of notification types back to their constant
+// names for pretty printing.
+var notificationTypeStrings = map[NotificationType]string{
+	NTDoubleSpend:         "NTDoubleSpend",
+	NTTransactionAccepted: "NTTransactionAccepted",
+}
+
+// String returns the NotificationType in human-readable form.
+func (n NotificationType) String() string {
+	if s, ok := notificationTypeStrings[n]; ok {
+		return s
+	}
+	return fmt.Sprintf("Unknown Notification Type (%d)", int(n))
+}
+
+// DoubleSpendAlert is the data sent with an NTDoubleSpend notification.
+//
+// The first transaction seen remains in the pool and the conflicting
+// transaction is dropped. Merchants accepting unconfirmed transactions
+// can use this to detect an attempt to double spend a payment.
+type DoubleSpendAlert struct {
+	// Txid is the ID of the rejected transaction.
+	Txid types.ID
+	// ConflictingTxid is the ID of the transaction in the pool
+	// which spends the same nullifier.
+	ConflictingTxid types.ID
+	// Nullifier is the nullifier spent by both transactions.
+	Nullifier types.Nullifier
+}
+
+// Notification defines notification that is sent to the caller via the callback
+// function provided during the call to Subscribe and consists of a notification
+// type as well as associated data that depends on the type as follows:
+//   - NTDoubleSpend:         *DoubleSpendAlert
+//   - NTTransactionAccepted: *transactions.Transaction
+type Notification struct {
+	Type NotificationType
+	Data interface{}
+}
+
+// Subscribe to mempool notifications. Registers a callback to be executed
+// when various events take place. See the documentation on Notification and
+// NotificationType for details on the types and contents of notifications.
+func (m *Mempool) Subscribe(callback NotificationCallback) {
+	m.notificationsLock.Lock()
+	m.notifications = append(m.notifications, callback)
+	m.notificationsLock.Unlock()
+}
+
+// sendNotification sends a notification with the passed type and data to
+// all subscribers.
+func (m *Mempool) sendNotification(typ NotificationType, data interface{}) {
+	n := Notification{Type: typ, Data: data}
+	m.notificationsLock.RLock()
+	for _, callback := range m.notifications {
+		go callback(&n)
+	}
+	m.notificationsLock.RUnlock()
+}
+
+// alertDoubleSpend logs and sends a notification about a transaction which
+// conflicts with one already in the pool.
+//
+// This method is NOT safe for concurrent access.
+func (m *Mempool) alertDoubleSpend(txid types.ID, n types.Nullifier) {
+	alert := &DoubleSpendAlert{
+		Txid:            txid,
+		ConflictingTxid: m.nullifiers[n],
+		Nullifier:       n,
+	}
+	log.Debug("Rejected double spend", log.ArgsFromMap(map[string]any{
+		"txid":        alert.Txid.String(),
+		"conflicting": alert.ConflictingTxid.String(),
+		"nullifier":   alert.Nullifier.String(),
+	}))
+	m.sendNotification(NTDoubleSpend, alert)
+}
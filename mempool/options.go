@@ -29,6 +29,7 @@ func DefaultOptions() Option {
 		cfg.sigCache = blockchain.NewSigCache(defaultSigCacheSize)
 		cfg.proofCache = blockchain.NewProofCache(defaultProofCacheSize)
 		cfg.transactionTTL = defaultTransactionTTL
+		cfg.timeSource = blockchain.NewMedianTime()
 		return nil
 	}
 }
@@ -108,6 +109,18 @@ func ProofCache(proofCache *blockchain.ProofCache) Option {
 	}
 }
 
+// TimeSource is the source used to determine the current time when
+// validating timelocks and restake periods. This should be the same
+// time source used by the blockchain.
+//
+// If this is not provided a new instance will be used.
+func TimeSource(timeSource blockchain.MedianTimeSource) Option {
+	return func(cfg *config) error {
+		cfg.timeSource = timeSource
+		return nil
+	}
+}
+
 // Config specifies the blockchain configuration.
 type config struct {
 	params         *params.NetworkParams
@@ -117,6 +130,7 @@ type config struct {
 	proofCache     *blockchain.ProofCache
 	verifier       zk.Verifier
 	transactionTTL time.Duration
+	timeSource     blockchain.MedianTimeSource
 }
 
 func (cfg *config) validate() error {
@@ -141,5 +155,8 @@ func (cfg *config) validate() error {
 	if cfg.verifier == nil {
 		return AssertError("NewMempool: verifier cannot be nil")
 	}
+	if cfg.timeSource == nil {
+		return AssertError("NewMempool: time source cannot be nil")
+	}
 	return nil
 }
@@ -0,0 +1,243 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package net
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+	"github.com/project-illium/ilxd/repo"
+	"net"
+	"path"
+	"sort"
+	"strings"
+)
+
+// AccessList selects the allow list or the deny list.
+type AccessList int
+
+const (
+	// AllowList is the list of peer IDs and IP ranges which may make
+	// inbound connections. If it's empty all inbound connections are
+	// allowed, otherwise only connections from a listed peer ID or from
+	// an address in a listed IP range are accepted.
+	AllowList AccessList = iota
+	// DenyList is the list of peer IDs and IP ranges which we will neither
+	// accept connections from nor dial. It takes precedence over the
+	// allow list.
+	DenyList
+)
+
+func (l AccessList) String() string {
+	switch l {
+	case AllowList:
+		return "allow"
+	case DenyList:
+		return "deny"
+	default:
+		return "unknown"
+	}
+}
+
+func (l AccessList) key() string {
+	return repo.ConnGaterKeyPrefix + l.String() + "/"
+}
+
+// ErrInvalidAccessRule is returned when an access list rule is neither
+// a peer ID nor an IP address or range.
+var ErrInvalidAccessRule = errors.New("access rule must be a peer ID, IP address, or IP range in CIDR notation")
+
+type accessList struct {
+	peers map[peer.ID]struct{}
+	nets  map[string]*net.IPNet
+}
+
+func newAccessList() *accessList {
+	return &accessList{
+		peers: make(map[peer.ID]struct{}),
+		nets:  make(map[string]*net.IPNet),
+	}
+}
+
+func (al *accessList) empty() bool {
+	return len(al.peers) == 0 && len(al.nets) == 0
+}
+
+func (al *accessList) containsPeer(p peer.ID) bool {
+	_, ok := al.peers[p]
+	return ok
+}
+
+func (al *accessList) containsIP(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	for _, n := range al.nets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseAccessRule parses a peer ID, IP address, or IP range in CIDR
+// notation and returns the rule in its canonical form. A single IP
+// address is returned as a /32 or /128 range.
+func ParseAccessRule(rule string) (string, error) {
+	rule = strings.TrimSpace(rule)
+	if _, n, err := net.ParseCIDR(rule); err == nil {
+		return n.String(), nil
+	}
+	if ip := net.ParseIP(rule); ip != nil {
+		bits := 128
+		if ip.To4() != nil {
+			ip = ip.To4()
+			bits = 32
+		}
+		return (&net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}).String(), nil
+	}
+	if p, err := peer.Decode(rule); err == nil {
+		return p.String(), nil
+	}
+	return "", ErrInvalidAccessRule
+}
+
+// AddAccessRule adds a peer ID or IP range to the access list. The rule is
+// saved to the datastore. Existing connections are not closed.
+func (cg *ConnectionGater) AddAccessRule(list AccessList, rule string) error {
+	return cg.addAccessRule(list, rule, true)
+}
+
+func (cg *ConnectionGater) addAccessRule(list AccessList, rule string, persist bool) error {
+	rule, err := ParseAccessRule(rule)
+	if err != nil {
+		return err
+	}
+	if persist && cg.ds != nil {
+		err := cg.ds.Put(context.Background(), datastore.NewKey(list.key()+hex.EncodeToString([]byte(rule))), []byte{})
+		if err != nil {
+			log.WithCaller(true).Error("Error writing access rule to datastore", log.Args("error", err))
+			return err
+		}
+	}
+
+	cg.Lock()
+	defer cg.Unlock()
+
+	al := cg.accessList(list)
+	if _, n, err := net.ParseCIDR(rule); err == nil {
+		al.nets[rule] = n
+	} else {
+		p, _ := peer.Decode(rule)
+		al.peers[p] = struct{}{}
+	}
+	return nil
+}
+
+// RemoveAccessRule removes a peer ID or IP range from the access list.
+func (cg *ConnectionGater) RemoveAccessRule(list AccessList, rule string) error {
+	rule, err := ParseAccessRule(rule)
+	if err != nil {
+		return err
+	}
+	if cg.ds != nil {
+		err := cg.ds.Delete(context.Background(), datastore.NewKey(list.key()+hex.EncodeToString([]byte(rule))))
+		if err != nil {
+			log.WithCaller(true).Error("Error deleting access rule from datastore", log.Args("error", err))
+			return err
+		}
+	}
+
+	cg.Lock()
+	defer cg.Unlock()
+
+	al := cg.accessList(list)
+	delete(al.nets, rule)
+	if p, err := peer.Decode(rule); err == nil {
+		delete(al.peers, p)
+	}
+	return nil
+}
+
+// AccessRules returns the peer IDs and IP ranges in the access list.
+func (cg *ConnectionGater) AccessRules(list AccessList) []string {
+	cg.RLock()
+	defer cg.RUnlock()
+
+	al := cg.accessList(list)
+	rules := make([]string, 0, len(al.peers)+len(al.nets))
+	for p := range al.peers {
+		rules = append(rules, p.String())
+	}
+	for rule := range al.nets {
+		rules = append(rules, rule)
+	}
+	sort.Strings(rules)
+	return rules
+}
+
+// ConnAllowed returns whether the connection is permitted by the access
+// lists. This can be used to find existing connections which should be
+// closed after the lists change.
+func (cg *ConnectionGater) ConnAllowed(conn network.Conn) bool {
+	cg.RLock()
+	defer cg.RUnlock()
+
+	ip := remoteIP(conn.RemoteMultiaddr())
+	if cg.denyList.containsPeer(conn.RemotePeer()) || cg.denyList.containsIP(ip) {
+		return false
+	}
+	if conn.Stat().Direction != network.DirInbound || cg.allowList.empty() {
+		return true
+	}
+	return cg.allowList.containsPeer(conn.RemotePeer()) || cg.allowList.containsIP(ip)
+}
+
+func (cg *ConnectionGater) accessList(list AccessList) *accessList {
+	if list == DenyList {
+		return cg.denyList
+	}
+	return cg.allowList
+}
+
+func (cg *ConnectionGater) loadAccessRules(ctx context.Context) error {
+	for _, list := range []AccessList{AllowList, DenyList} {
+		res, err := cg.ds.Query(ctx, query.Query{Prefix: list.key(), KeysOnly: true})
+		if err != nil {
+			return err
+		}
+		for r := range res.Next() {
+			if r.Error != nil {
+				return r.Error
+			}
+			rule, err := hex.DecodeString(path.Base(r.Key))
+			if err != nil {
+				return err
+			}
+			if err := cg.addAccessRule(list, string(rule), false); err != nil {
+				return fmt.Errorf("%w: %s", err, string(rule))
+			}
+		}
+	}
+	return nil
+}
+
+func remoteIP(addr ma.Multiaddr) net.IP {
+	if addr == nil {
+		return nil
+	}
+	ip, err := manet.ToIP(addr)
+	if err != nil {
+		return nil
+	}
+	return ip
+}
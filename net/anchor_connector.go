@@ -0,0 +1,198 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package net
+
+import (
+	"context"
+	"github.com/libp2p/go-libp2p/core/host"
+	inet "github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"sync"
+	"time"
+)
+
+const (
+	// anchorCheckInterval is how often the connector checks for
+	// anchor peers that need to be dialed.
+	anchorCheckInterval = time.Second * 5
+
+	// anchorDialTimeout is the timeout for a single dial attempt.
+	anchorDialTimeout = time.Second * 30
+
+	// anchorBaseBackoff is the delay after the first failed dial. It
+	// doubles after each consecutive failure up to anchorMaxBackoff.
+	anchorBaseBackoff = time.Second * 5
+	anchorMaxBackoff  = time.Minute * 10
+
+	// anchorAlertThreshold is the number of consecutive failed dials
+	// after which an anchor is reported as unreachable.
+	anchorAlertThreshold = 5
+)
+
+type anchorPeer struct {
+	addrInfo    peer.AddrInfo
+	failures    int
+	nextAttempt time.Time
+	dialing     bool
+	alerted     bool
+}
+
+// AnchorConnector maintains outbound connections to a set of operator
+// configured anchor peers. The connections are protected from the
+// connection manager's pruning and are redialed with an exponential
+// backoff whenever they drop. If an anchor cannot be reached after
+// several attempts a warning is logged so the operator can investigate.
+type AnchorConnector struct {
+	host    host.Host
+	anchors map[peer.ID]*anchorPeer
+	quit    chan struct{}
+
+	mtx sync.RWMutex
+}
+
+// NewAnchorConnector returns a new AnchorConnector and starts dialing
+// the anchor peers.
+func NewAnchorConnector(host host.Host, anchors []peer.AddrInfo) *AnchorConnector {
+	ac := &AnchorConnector{
+		host:    host,
+		anchors: make(map[peer.ID]*anchorPeer),
+		quit:    make(chan struct{}),
+		mtx:     sync.RWMutex{},
+	}
+	for _, ai := range anchors {
+		ac.anchors[ai.ID] = &anchorPeer{addrInfo: ai}
+		host.Peerstore().AddAddrs(ai.ID, ai.Addrs, peerstore.PermanentAddrTTL)
+		host.ConnManager().Protect(ai.ID, AnchorProtectionFlag)
+	}
+
+	host.Network().Notify(&inet.NotifyBundle{
+		ConnectedF:    ac.handlePeerConnected,
+		DisconnectedF: ac.handlePeerDisconnected,
+	})
+
+	go ac.run()
+	return ac
+}
+
+// IsAnchor returns whether the peer is one of the anchor peers.
+func (ac *AnchorConnector) IsAnchor(p peer.ID) bool {
+	ac.mtx.RLock()
+	defer ac.mtx.RUnlock()
+
+	_, ok := ac.anchors[p]
+	return ok
+}
+
+// Close stops the connector from dialing the anchor peers.
+func (ac *AnchorConnector) Close() {
+	close(ac.quit)
+}
+
+func (ac *AnchorConnector) run() {
+	ticker := time.NewTicker(anchorCheckInterval)
+	defer ticker.Stop()
+	for {
+		ac.update()
+		select {
+		case <-ac.quit:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (ac *AnchorConnector) update() {
+	ac.mtx.Lock()
+	defer ac.mtx.Unlock()
+
+	now := time.Now()
+	for p, anchor := range ac.anchors {
+		if anchor.dialing || now.Before(anchor.nextAttempt) {
+			continue
+		}
+		if ac.host.Network().Connectedness(p) == inet.Connected {
+			continue
+		}
+		anchor.dialing = true
+		go ac.dial(anchor.addrInfo)
+	}
+}
+
+func (ac *AnchorConnector) dial(ai peer.AddrInfo) {
+	ctx, cancel := context.WithTimeout(context.Background(), anchorDialTimeout)
+	defer cancel()
+	err := ac.host.Connect(ctx, ai)
+
+	ac.mtx.Lock()
+	defer ac.mtx.Unlock()
+
+	anchor, ok := ac.anchors[ai.ID]
+	if !ok {
+		return
+	}
+	anchor.dialing = false
+	if err == nil {
+		ac.resetBackoff(anchor)
+		return
+	}
+	anchor.failures++
+	anchor.nextAttempt = time.Now().Add(anchorBackoff(anchor.failures))
+	log.Debug("Failed to dial anchor peer", log.ArgsFromMap(map[string]any{
+		"peer":     ai.ID,
+		"attempts": anchor.failures,
+		"error":    err,
+	}))
+	if anchor.failures >= anchorAlertThreshold && !anchor.alerted {
+		anchor.alerted = true
+		log.Warn("Anchor peer is unreachable", log.ArgsFromMap(map[string]any{
+			"peer":     ai.ID,
+			"attempts": anchor.failures,
+			"error":    err,
+		}))
+	}
+}
+
+func (ac *AnchorConnector) resetBackoff(anchor *anchorPeer) {
+	if anchor.alerted {
+		log.Info("Reconnected to anchor peer", log.Args("peer", anchor.addrInfo.ID))
+	}
+	anchor.failures = 0
+	anchor.alerted = false
+	anchor.nextAttempt = time.Time{}
+}
+
+func (ac *AnchorConnector) handlePeerConnected(_ inet.Network, conn inet.Conn) {
+	ac.mtx.Lock()
+	defer ac.mtx.Unlock()
+
+	if anchor, ok := ac.anchors[conn.RemotePeer()]; ok {
+		ac.resetBackoff(anchor)
+	}
+}
+
+func (ac *AnchorConnector) handlePeerDisconnected(_ inet.Network, conn inet.Conn) {
+	if !ac.IsAnchor(conn.RemotePeer()) {
+		return
+	}
+	log.Debug("Disconnected from anchor peer", log.Args("peer", conn.RemotePeer()))
+	go ac.update()
+}
+
+// anchorBackoff returns the delay before the next dial after the
+// given number of consecutive failures.
+func anchorBackoff(failures int) time.Duration {
+	if failures <= 0 {
+		return 0
+	}
+	backoff := anchorBaseBackoff
+	for i := 1; i < failures; i++ {
+		backoff *= 2
+		if backoff >= anchorMaxBackoff {
+			return anchorMaxBackoff
+		}
+	}
+	return backoff
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package net
+
+import (
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestAnchorBackoff(t *testing.T) {
+	assert.Equal(t, time.Duration(0), anchorBackoff(0))
+	assert.Equal(t, anchorBaseBackoff, anchorBackoff(1))
+	assert.Equal(t, anchorBaseBackoff*2, anchorBackoff(2))
+	assert.Equal(t, anchorBaseBackoff*8, anchorBackoff(4))
+	assert.Equal(t, anchorMaxBackoff, anchorBackoff(10))
+	assert.Equal(t, anchorMaxBackoff, anchorBackoff(1000))
+}
+
+func TestAnchorConnector(t *testing.T) {
+	mn := mocknet.New()
+	t.Cleanup(func() { mn.Close() })
+
+	h1, err := mn.GenPeer()
+	require.NoError(t, err)
+	h2, err := mn.GenPeer()
+	require.NoError(t, err)
+	h3, err := mn.GenPeer()
+	require.NoError(t, err)
+	require.NoError(t, mn.LinkAll())
+
+	ac := NewAnchorConnector(h1, []peer.AddrInfo{{ID: h2.ID(), Addrs: h2.Addrs()}})
+	defer ac.Close()
+
+	assert.True(t, ac.IsAnchor(h2.ID()))
+	assert.False(t, ac.IsAnchor(h3.ID()))
+
+	connected := func() bool {
+		return h1.Network().Connectedness(h2.ID()) == network.Connected
+	}
+	assert.Eventually(t, connected, time.Second*5, time.Millisecond*10)
+
+	// The connector should redial the anchor after the connection drops.
+	require.NoError(t, h1.Network().ClosePeer(h2.ID()))
+	assert.Eventually(t, connected, time.Second*5, time.Millisecond*10)
+
+	assert.NotEqual(t, network.Connected, h1.Network().Connectedness(h3.ID()))
+}
@@ -37,6 +37,9 @@ type ConnectionGater struct {
 	blockedPeers map[peer.ID]time.Time
 	blockedAddrs map[string]time.Time
 
+	allowList *accessList
+	denyList  *accessList
+
 	banDuration time.Duration
 	maxBanscore uint32
 
@@ -55,6 +58,8 @@ func NewConnectionGater(ds repo.Datastore, addrBook peerstore.AddrBook, banDurat
 		blockedPeers: make(map[peer.ID]time.Time),
 		blockedAddrs: make(map[string]time.Time),
 		scores:       make(map[peer.ID]*DynamicBanScore),
+		allowList:    newAccessList(),
+		denyList:     newAccessList(),
 		banDuration:  banDuration,
 		maxBanscore:  maxBanscore,
 		addrBook:     addrBook,
@@ -122,7 +127,7 @@ func (cg *ConnectionGater) loadRules(ctx context.Context) error {
 		})
 	}
 
-	return nil
+	return cg.loadAccessRules(ctx)
 }
 
 // IncreaseBanscore increases the ban score for a peer. If the score goes over maxBanscore then
@@ -330,7 +335,7 @@ func (cg *ConnectionGater) InterceptPeerDial(p peer.ID) (allow bool) {
 	defer cg.RUnlock()
 
 	_, block := cg.blockedPeers[p]
-	return !block
+	return !block && !cg.denyList.containsPeer(p)
 }
 
 func (cg *ConnectionGater) InterceptAddrDial(p peer.ID, a ma.Multiaddr) (allow bool) {
@@ -345,7 +350,7 @@ func (cg *ConnectionGater) InterceptAddrDial(p peer.ID, a ma.Multiaddr) (allow b
 	}
 
 	_, block := cg.blockedAddrs[ip.String()]
-	return !block
+	return !block && !cg.denyList.containsIP(ip)
 }
 
 func (cg *ConnectionGater) InterceptAccept(cma network.ConnMultiaddrs) (allow bool) {
@@ -361,7 +366,16 @@ func (cg *ConnectionGater) InterceptAccept(cma network.ConnMultiaddrs) (allow bo
 	}
 
 	_, block := cg.blockedAddrs[ip.String()]
-	return !block
+	if block || cg.denyList.containsIP(ip) {
+		return false
+	}
+
+	// If the allow list only has IP ranges we can reject the connection
+	// now. Otherwise we need to wait for the peer ID in InterceptSecured.
+	if len(cg.allowList.nets) > 0 && len(cg.allowList.peers) == 0 {
+		return cg.allowList.containsIP(ip)
+	}
+	return true
 }
 
 func (cg *ConnectionGater) InterceptSecured(dir network.Direction, p peer.ID, cma network.ConnMultiaddrs) (allow bool) {
@@ -375,7 +389,13 @@ func (cg *ConnectionGater) InterceptSecured(dir network.Direction, p peer.ID, cm
 	defer cg.RUnlock()
 
 	_, block := cg.blockedPeers[p]
-	return !block
+	if block || cg.denyList.containsPeer(p) {
+		return false
+	}
+	if cg.allowList.empty() {
+		return true
+	}
+	return cg.allowList.containsPeer(p) || cg.allowList.containsIP(remoteIP(cma.RemoteMultiaddr()))
 }
 
 func (cg *ConnectionGater) InterceptUpgraded(network.Conn) (allow bool, reason control.DisconnectReason) {
@@ -231,6 +231,79 @@ func TestBanscore(t *testing.T) {
 	assert.Equal(t, "1.2.3.4", blockedAddrs[0].String())
 }
 
+func TestConnectionGater_AccessLists(t *testing.T) {
+	ds := mock.NewMapDatastore()
+	pstore, err := pstoremem.NewPeerstore()
+	assert.NoError(t, err)
+
+	peerA, _ := peer.Decode("12D3KooWSE3nPEMZEXGpDRjZesMEVquvs3YjYPJdiC4ve66rVuu5")
+	peerB, _ := peer.Decode("12D3KooWARnj9CFGko6iX3PV8sYfMG94SSbMtTm7XPjtiiKjV7Fs")
+
+	inside := &mockConnMultiaddrs{remote: ma.StringCast("/ip4/10.1.2.3/tcp/1234")}
+	outside := &mockConnMultiaddrs{remote: ma.StringCast("/ip4/1.2.3.4/tcp/1234")}
+
+	cg, err := NewConnectionGater(ds, pstore, time.Minute, 100)
+	assert.NoError(t, err)
+
+	_, err = ParseAccessRule("not a rule")
+	assert.ErrorIs(t, err, ErrInvalidAccessRule)
+	assert.Error(t, cg.AddAccessRule(AllowList, "not a rule"))
+
+	// An empty allow list accepts everything
+	assert.True(t, cg.InterceptAccept(outside))
+	assert.True(t, cg.InterceptSecured(network.DirInbound, peerB, outside))
+
+	// With only an IP range in the allow list connections
+	// from outside the range are rejected on accept.
+	assert.NoError(t, cg.AddAccessRule(AllowList, "10.0.0.0/8"))
+	assert.True(t, cg.InterceptAccept(inside))
+	assert.False(t, cg.InterceptAccept(outside))
+	assert.True(t, cg.InterceptSecured(network.DirInbound, peerB, inside))
+	assert.False(t, cg.InterceptSecured(network.DirInbound, peerB, outside))
+
+	// Outbound connections are not restricted by the allow list
+	assert.True(t, cg.InterceptPeerDial(peerB))
+	assert.True(t, cg.InterceptAddrDial(peerB, outside.remote))
+	assert.True(t, cg.InterceptSecured(network.DirOutbound, peerB, outside))
+
+	// Once a peer ID is allowed we have to wait for the peer ID to decide.
+	assert.NoError(t, cg.AddAccessRule(AllowList, peerA.String()))
+	assert.True(t, cg.InterceptAccept(outside))
+	assert.True(t, cg.InterceptSecured(network.DirInbound, peerA, outside))
+	assert.False(t, cg.InterceptSecured(network.DirInbound, peerB, outside))
+	assert.True(t, cg.InterceptSecured(network.DirInbound, peerB, inside))
+
+	// The deny list takes precedence and applies in both directions.
+	assert.NoError(t, cg.AddAccessRule(DenyList, "10.1.2.3"))
+	assert.NoError(t, cg.AddAccessRule(DenyList, peerA.String()))
+	assert.False(t, cg.InterceptAccept(inside))
+	assert.False(t, cg.InterceptAddrDial(peerB, inside.remote))
+	assert.False(t, cg.InterceptPeerDial(peerA))
+	assert.False(t, cg.InterceptSecured(network.DirInbound, peerA, outside))
+
+	assert.Equal(t, []string{"10.0.0.0/8", peerA.String()}, cg.AccessRules(AllowList))
+	assert.Equal(t, []string{"10.1.2.3/32", peerA.String()}, cg.AccessRules(DenyList))
+
+	// make a new gater reusing the datastore to test persistence
+	cg, err = NewConnectionGater(ds, pstore, time.Minute, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.0/8", peerA.String()}, cg.AccessRules(AllowList))
+	assert.Equal(t, []string{"10.1.2.3/32", peerA.String()}, cg.AccessRules(DenyList))
+
+	assert.NoError(t, cg.RemoveAccessRule(DenyList, "10.1.2.3/32"))
+	assert.NoError(t, cg.RemoveAccessRule(DenyList, peerA.String()))
+	assert.NoError(t, cg.RemoveAccessRule(AllowList, "10.0.0.0/8"))
+	assert.NoError(t, cg.RemoveAccessRule(AllowList, peerA.String()))
+	assert.True(t, cg.InterceptPeerDial(peerA))
+	assert.True(t, cg.InterceptAccept(outside))
+	assert.True(t, cg.InterceptSecured(network.DirInbound, peerB, outside))
+
+	cg, err = NewConnectionGater(ds, pstore, time.Minute, 100)
+	assert.NoError(t, err)
+	assert.Empty(t, cg.AccessRules(AllowList))
+	assert.Empty(t, cg.AccessRules(DenyList))
+}
+
 type mockConnMultiaddrs struct {
 	local, remote ma.Multiaddr
 }
@@ -0,0 +1,356 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package net
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	mrand "math/rand"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// minReorderDelay is the least amount of time a reordered message is held
+// back so that the messages written after it have a chance to overtake it.
+const minReorderDelay = time.Millisecond * 10
+
+var errFlakyStreamClosed = errors.New("stream closed")
+
+// LinkConditions describes the adverse network conditions applied to the
+// messages sent to a peer.
+type LinkConditions struct {
+	// Latency is the base delay added to every message.
+	Latency time.Duration
+	// Jitter is the maximum random amount added to or subtracted from
+	// the latency of each message.
+	Jitter time.Duration
+	// DropRate is the probability, between zero and one, that a
+	// message is silently dropped.
+	DropRate float64
+	// ReorderRate is the probability, between zero and one, that a
+	// message is held back and delivered after later messages.
+	ReorderRate float64
+}
+
+// FlakyStats counts the messages handled by a FlakyHost.
+type FlakyStats struct {
+	Delivered uint64
+	Dropped   uint64
+	Reordered uint64
+}
+
+// FlakyHost wraps a host and applies link conditions to the messages written
+// to the streams it opens or accepts. It is used in tests to measure how
+// consensus and sync behave on a poor network and can be passed to the
+// Network with the WithHost option.
+//
+// All of the protocols on top of the host write varint length-prefixed
+// messages so the conditions are applied per message rather than per write.
+// Streams for protocols registered directly on the wrapped host, such as
+// identify and ping, are not affected.
+type FlakyHost struct {
+	host.Host
+
+	defaults   LinkConditions
+	conditions map[peer.ID]LinkConditions
+	rand       *mrand.Rand
+	mtx        sync.Mutex
+
+	delivered uint64
+	dropped   uint64
+	reordered uint64
+}
+
+// NewFlakyHost returns a FlakyHost which applies the default conditions
+// to every peer. The seed makes the random decisions reproducible.
+func NewFlakyHost(h host.Host, defaults LinkConditions, seed int64) *FlakyHost {
+	return &FlakyHost{
+		Host:       h,
+		defaults:   defaults,
+		conditions: make(map[peer.ID]LinkConditions),
+		rand:       mrand.New(mrand.NewSource(seed)),
+		mtx:        sync.Mutex{},
+	}
+}
+
+// SetLinkConditions overrides the default conditions for messages sent
+// to the given peer.
+func (fh *FlakyHost) SetLinkConditions(p peer.ID, conditions LinkConditions) {
+	fh.mtx.Lock()
+	defer fh.mtx.Unlock()
+
+	fh.conditions[p] = conditions
+}
+
+// Stats returns the number of messages delivered, dropped, and reordered.
+func (fh *FlakyHost) Stats() FlakyStats {
+	return FlakyStats{
+		Delivered: atomic.LoadUint64(&fh.delivered),
+		Dropped:   atomic.LoadUint64(&fh.dropped),
+		Reordered: atomic.LoadUint64(&fh.reordered),
+	}
+}
+
+// NewStream opens a new stream to the peer which is subject to the
+// link conditions.
+func (fh *FlakyHost) NewStream(ctx context.Context, p peer.ID, pids ...protocol.ID) (network.Stream, error) {
+	s, err := fh.Host.NewStream(ctx, p, pids...)
+	if err != nil {
+		return nil, err
+	}
+	return fh.wrapStream(s), nil
+}
+
+// SetStreamHandler sets the handler for the protocol. The handler is passed
+// streams which are subject to the link conditions.
+func (fh *FlakyHost) SetStreamHandler(pid protocol.ID, handler network.StreamHandler) {
+	fh.Host.SetStreamHandler(pid, func(s network.Stream) {
+		handler(fh.wrapStream(s))
+	})
+}
+
+// SetStreamHandlerMatch sets the handler for protocols matched by the
+// match function. The handler is passed streams which are subject to the
+// link conditions.
+func (fh *FlakyHost) SetStreamHandlerMatch(pid protocol.ID, match func(protocol.ID) bool, handler network.StreamHandler) {
+	fh.Host.SetStreamHandlerMatch(pid, match, func(s network.Stream) {
+		handler(fh.wrapStream(s))
+	})
+}
+
+func (fh *FlakyHost) wrapStream(s network.Stream) network.Stream {
+	return &flakyStream{
+		Stream: s,
+		fh:     fh,
+		wake:   make(chan struct{}, 1),
+		reset:  make(chan struct{}),
+	}
+}
+
+// schedule decides what happens to a message sent to the peer. It returns
+// false if the message is dropped, otherwise the delay before the message
+// is delivered and whether it may be overtaken by later messages.
+func (fh *FlakyHost) schedule(p peer.ID) (time.Duration, bool, bool) {
+	fh.mtx.Lock()
+	defer fh.mtx.Unlock()
+
+	conditions, ok := fh.conditions[p]
+	if !ok {
+		conditions = fh.defaults
+	}
+
+	if fh.rand.Float64() < conditions.DropRate {
+		atomic.AddUint64(&fh.dropped, 1)
+		return 0, false, false
+	}
+
+	delay := conditions.Latency
+	if conditions.Jitter > 0 {
+		delay += time.Duration(fh.rand.Int63n(int64(conditions.Jitter)*2+1)) - conditions.Jitter
+	}
+	if delay < 0 {
+		delay = 0
+	}
+
+	reorder := fh.rand.Float64() < conditions.ReorderRate
+	if reorder {
+		extra := conditions.Latency + conditions.Jitter
+		if extra < minReorderDelay {
+			extra = minReorderDelay
+		}
+		delay += extra
+		atomic.AddUint64(&fh.reordered, 1)
+	}
+	return delay, reorder, true
+}
+
+// flakyFrame is a message, or a close of the stream, waiting to be delivered.
+type flakyFrame struct {
+	data      []byte
+	closeFunc func() error
+	deliverAt time.Time
+}
+
+// flakyStream buffers the messages written to the stream and delivers them
+// to the underlying stream after the delay chosen for each one. Messages
+// which are not reordered are delivered in the order they were written.
+type flakyStream struct {
+	network.Stream
+	fh *FlakyHost
+
+	buf          []byte
+	pending      []*flakyFrame
+	lastInOrder  time.Time
+	lastDelivery time.Time
+	running      bool
+	closed       bool
+	err          error
+	mtx          sync.Mutex
+
+	wake      chan struct{}
+	reset     chan struct{}
+	resetOnce sync.Once
+}
+
+// Write splits the data into messages and schedules each one for delivery.
+// It returns as soon as the data has been buffered.
+func (s *flakyStream) Write(p []byte) (int, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.err != nil {
+		return 0, s.err
+	}
+	if s.closed {
+		return 0, errFlakyStreamClosed
+	}
+
+	s.buf = append(s.buf, p...)
+	for {
+		l, n := binary.Uvarint(s.buf)
+		if n < 0 {
+			return 0, errors.New("invalid message length prefix")
+		}
+		if n == 0 || uint64(len(s.buf)-n) < l {
+			break
+		}
+		msgLen := n + int(l)
+		msg := make([]byte, msgLen)
+		copy(msg, s.buf[:msgLen])
+		s.buf = s.buf[msgLen:]
+
+		delay, reorder, ok := s.fh.schedule(s.Conn().RemotePeer())
+		if !ok {
+			continue
+		}
+		deliverAt := time.Now().Add(delay)
+		if !reorder {
+			if deliverAt.Before(s.lastInOrder) {
+				deliverAt = s.lastInOrder
+			}
+			s.lastInOrder = deliverAt
+		}
+		s.enqueue(&flakyFrame{data: msg, deliverAt: deliverAt})
+	}
+	return len(p), nil
+}
+
+// Close closes the stream after all of the pending messages have
+// been delivered.
+func (s *flakyStream) Close() error {
+	return s.closeAfterPending(s.Stream.Close)
+}
+
+// CloseWrite closes the stream for writing after all of the pending
+// messages have been delivered.
+func (s *flakyStream) CloseWrite() error {
+	return s.closeAfterPending(s.Stream.CloseWrite)
+}
+
+// Reset resets the stream immediately discarding any pending messages.
+func (s *flakyStream) Reset() error {
+	s.resetOnce.Do(func() {
+		close(s.reset)
+	})
+	return s.Stream.Reset()
+}
+
+func (s *flakyStream) closeAfterPending(closeFunc func() error) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	deliverAt := time.Now()
+	if deliverAt.Before(s.lastDelivery) {
+		deliverAt = s.lastDelivery
+	}
+	s.enqueue(&flakyFrame{closeFunc: closeFunc, deliverAt: deliverAt})
+	return nil
+}
+
+// enqueue inserts the frame into the pending frames sorted by delivery time
+// and starts the delivery goroutine if it is not running. The mutex must
+// be held.
+func (s *flakyStream) enqueue(f *flakyFrame) {
+	i := sort.Search(len(s.pending), func(i int) bool {
+		return s.pending[i].deliverAt.After(f.deliverAt)
+	})
+	s.pending = append(s.pending, nil)
+	copy(s.pending[i+1:], s.pending[i:])
+	s.pending[i] = f
+
+	if f.deliverAt.After(s.lastDelivery) {
+		s.lastDelivery = f.deliverAt
+	}
+	if !s.running {
+		s.running = true
+		go s.deliver()
+		return
+	}
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// deliver writes the pending frames to the underlying stream as they come
+// due. It exits when there is nothing left to deliver so idle streams do
+// not hold a goroutine.
+func (s *flakyStream) deliver() {
+	for {
+		s.mtx.Lock()
+		if len(s.pending) == 0 || s.err != nil {
+			s.running = false
+			s.mtx.Unlock()
+			return
+		}
+		wait := time.Until(s.pending[0].deliverAt)
+		if wait > 0 {
+			s.mtx.Unlock()
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-s.wake:
+				timer.Stop()
+			case <-s.reset:
+				timer.Stop()
+				s.mtx.Lock()
+				s.err = network.ErrReset
+				s.running = false
+				s.mtx.Unlock()
+				return
+			}
+			continue
+		}
+		f := s.pending[0]
+		s.pending = s.pending[1:]
+		s.mtx.Unlock()
+
+		var err error
+		if f.closeFunc != nil {
+			err = f.closeFunc()
+		} else {
+			_, err = s.Stream.Write(f.data)
+			if err == nil {
+				atomic.AddUint64(&s.fh.delivered, 1)
+			}
+		}
+		if err != nil {
+			s.mtx.Lock()
+			s.err = err
+			s.mtx.Unlock()
+		}
+	}
+}
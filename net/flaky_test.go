@@ -0,0 +1,144 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package net
+
+import (
+	"context"
+	"encoding/binary"
+	"github.com/libp2p/go-libp2p/core/network"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/libp2p/go-msgio"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+const flakyTestProtocol = "/flakytest/1.0.0"
+
+type flakyTestMsg struct {
+	n        uint32
+	received time.Time
+}
+
+// sendFlakyTestMsgs writes n numbered messages from a FlakyHost with the
+// given conditions and returns the messages received by the other host
+// along with the time the first message was sent.
+func sendFlakyTestMsgs(t *testing.T, conditions LinkConditions, n int, setup func(fh *FlakyHost)) ([]flakyTestMsg, *FlakyHost, time.Time) {
+	mn := mocknet.New()
+	t.Cleanup(func() { mn.Close() })
+
+	h1, err := mn.GenPeer()
+	require.NoError(t, err)
+	h2, err := mn.GenPeer()
+	require.NoError(t, err)
+	require.NoError(t, mn.LinkAll())
+	require.NoError(t, mn.ConnectAllButSelf())
+
+	fh := NewFlakyHost(h1, conditions, 1)
+	if setup != nil {
+		setup(fh)
+	}
+
+	received := make(chan []flakyTestMsg)
+	h2.SetStreamHandler(flakyTestProtocol, func(s network.Stream) {
+		var msgs []flakyTestMsg
+		r := msgio.NewVarintReader(s)
+		for {
+			b, err := r.ReadMsg()
+			if err != nil {
+				break
+			}
+			msgs = append(msgs, flakyTestMsg{
+				n:        binary.BigEndian.Uint32(b),
+				received: time.Now(),
+			})
+			r.ReleaseMsg(b)
+		}
+		received <- msgs
+	})
+
+	s, err := fh.NewStream(context.Background(), h2.ID(), flakyTestProtocol)
+	require.NoError(t, err)
+
+	start := time.Now()
+	w := msgio.NewVarintWriter(s)
+	for i := 0; i < n; i++ {
+		b := make([]byte, 4)
+		binary.BigEndian.PutUint32(b, uint32(i))
+		require.NoError(t, w.WriteMsg(b))
+	}
+	require.NoError(t, s.Close())
+
+	select {
+	case msgs := <-received:
+		return msgs, fh, start
+	case <-time.After(time.Second * 10):
+		t.Fatal("timed out waiting for messages")
+	}
+	return nil, nil, start
+}
+
+func TestFlakyHostLatency(t *testing.T) {
+	msgs, fh, start := sendFlakyTestMsgs(t, LinkConditions{
+		Latency: time.Millisecond * 50,
+		Jitter:  time.Millisecond * 20,
+	}, 20, nil)
+
+	require.Len(t, msgs, 20)
+	assert.GreaterOrEqual(t, msgs[0].received.Sub(start), time.Millisecond*30)
+
+	// Without reordering the messages arrive in order despite the jitter.
+	for i, msg := range msgs {
+		assert.Equal(t, uint32(i), msg.n)
+	}
+	assert.Equal(t, FlakyStats{Delivered: 20}, fh.Stats())
+}
+
+func TestFlakyHostDrop(t *testing.T) {
+	msgs, fh, _ := sendFlakyTestMsgs(t, LinkConditions{
+		DropRate: 0.5,
+	}, 200, nil)
+
+	stats := fh.Stats()
+	assert.Equal(t, uint64(200), stats.Delivered+stats.Dropped)
+	assert.Equal(t, int(stats.Delivered), len(msgs))
+	assert.InDelta(t, 100, len(msgs), 40)
+
+	for i := 1; i < len(msgs); i++ {
+		assert.Less(t, msgs[i-1].n, msgs[i].n)
+	}
+}
+
+func TestFlakyHostReorder(t *testing.T) {
+	msgs, fh, _ := sendFlakyTestMsgs(t, LinkConditions{
+		ReorderRate: 0.3,
+	}, 50, nil)
+
+	require.Len(t, msgs, 50)
+	assert.Greater(t, fh.Stats().Reordered, uint64(0))
+
+	outOfOrder := 0
+	seen := make(map[uint32]bool)
+	for i, msg := range msgs {
+		seen[msg.n] = true
+		if i > 0 && msg.n < msgs[i-1].n {
+			outOfOrder++
+		}
+	}
+	assert.Len(t, seen, 50)
+	assert.Greater(t, outOfOrder, 0)
+}
+
+func TestFlakyHostPeerConditions(t *testing.T) {
+	msgs, fh, _ := sendFlakyTestMsgs(t, LinkConditions{}, 10, func(fh *FlakyHost) {
+		for _, p := range fh.Network().Peers() {
+			fh.SetLinkConditions(p, LinkConditions{DropRate: 1})
+		}
+	})
+
+	assert.Len(t, msgs, 0)
+	assert.Equal(t, FlakyStats{Dropped: 10}, fh.Stats())
+}
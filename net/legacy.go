@@ -0,0 +1,26 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package net
+
+import (
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"strings"
+)
+
+// LegacyProtocolVersion is the version of the stream protocols from before
+// messages were wrapped in a wire.MsgEnvelope. Messages on streams using it
+// are bare protobufs.
+//
+// The services continue to serve it, and fall back to it when dialing peers
+// which don't support the current version, so that nodes which have not yet
+// upgraded can still reach the rest of the network. It will be removed in a
+// future release.
+const LegacyProtocolVersion = "1.0.0"
+
+// IsLegacyProtocol returns whether the protocol ID is for the
+// LegacyProtocolVersion of a protocol.
+func IsLegacyProtocol(pid protocol.ID) bool {
+	return strings.HasSuffix(string(pid), "/"+LegacyProtocolVersion)
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package net
+
+import (
+	"context"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/libp2p/go-msgio"
+	"github.com/project-illium/ilxd/types/wire"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"testing"
+	"time"
+)
+
+const (
+	legacyTestProtocol  = "/legacytest/" + LegacyProtocolVersion
+	currentTestProtocol = "/legacytest/2.0.0"
+)
+
+func TestIsLegacyProtocol(t *testing.T) {
+	assert.True(t, IsLegacyProtocol(legacyTestProtocol))
+	assert.False(t, IsLegacyProtocol(currentTestProtocol))
+	assert.False(t, IsLegacyProtocol("/legacytest/11.0.0"))
+}
+
+func TestLegacyProtocolFallback(t *testing.T) {
+	mn := mocknet.New()
+	t.Cleanup(func() { mn.Close() })
+
+	h1, err := mn.GenPeer()
+	require.NoError(t, err)
+	legacyPeer, err := mn.GenPeer()
+	require.NoError(t, err)
+	currentPeer, err := mn.GenPeer()
+	require.NoError(t, err)
+	require.NoError(t, mn.LinkAll())
+	require.NoError(t, mn.ConnectAllButSelf())
+
+	// The legacy peer reads and writes bare protobufs as nodes from
+	// before the envelopes were added do.
+	legacyPeer.SetStreamHandler(legacyTestProtocol, func(s network.Stream) {
+		r := msgio.NewVarintReaderSize(s, network.MessageSizeMax)
+		for {
+			b, err := r.ReadMsg()
+			if err != nil {
+				s.Reset()
+				return
+			}
+			req := new(wire.MsgPollRequest)
+			if err := proto.Unmarshal(b, req); err != nil {
+				s.Reset()
+				return
+			}
+			r.ReleaseMsg(b)
+			if err := writeDelimited(s, &wire.MsgPollResponse{Request_ID: req.Request_ID}); err != nil {
+				s.Reset()
+				return
+			}
+		}
+	})
+
+	// The current peer serves both versions like the services do.
+	protocols := make(chan protocol.ID, 10)
+	handler := func(s network.Stream) {
+		protocols <- s.Protocol()
+		r := msgio.NewVarintReaderSize(s, network.MessageSizeMax)
+		for {
+			b, err := r.ReadMsg()
+			if err != nil {
+				s.Reset()
+				return
+			}
+			req := new(wire.MsgPollRequest)
+			if err := UnmarshalStreamMsg(s, b, req); err != nil {
+				s.Reset()
+				return
+			}
+			r.ReleaseMsg(b)
+			if err := WriteStreamMsg(s, &wire.MsgPollResponse{Request_ID: req.Request_ID}); err != nil {
+				s.Reset()
+				return
+			}
+		}
+	}
+	currentPeer.SetStreamHandler(currentTestProtocol, handler)
+	currentPeer.SetStreamHandler(legacyTestProtocol, handler)
+
+	ms := NewMultiplexedMessageSender(h1, func() ResponseMessage {
+		return new(wire.MsgPollResponse)
+	}, currentTestProtocol, legacyTestProtocol)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	for i, p := range []peer.ID{legacyPeer.ID(), currentPeer.ID()} {
+		resp := new(wire.MsgPollResponse)
+		assert.NoError(t, ms.SendRequest(ctx, p, &wire.MsgPollRequest{Request_ID: uint32(i)}, resp))
+		assert.Equal(t, uint32(i), resp.Request_ID)
+
+		resp = new(wire.MsgPollResponse)
+		assert.NoError(t, ms.SendMultiplexedRequest(ctx, p, uint32(i+10), &wire.MsgPollRequest{Request_ID: uint32(i + 10)}, resp))
+		assert.Equal(t, uint32(i+10), resp.Request_ID)
+	}
+
+	// The current version is preferred when the peer supports it.
+	assert.Equal(t, protocol.ID(currentTestProtocol), <-protocols)
+	assert.Equal(t, protocol.ID(currentTestProtocol), <-protocols)
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"github.com/libp2p/go-libp2p-kad-dht/metrics"
 	"github.com/libp2p/go-msgio/pbio"
+	"github.com/project-illium/ilxd/types/wire"
 	"go.opencensus.io/stats"
 	"google.golang.org/protobuf/proto"
 
@@ -325,11 +326,11 @@ func (ms *peerMessageSender) SendRequest(ctx context.Context, req proto.Message,
 }
 
 func (ms *peerMessageSender) writeMsg(pmes proto.Message) error {
-	return WriteMsg(ms.s, pmes)
+	return WriteStreamMsg(ms.s, pmes)
 }
 
 func (ms *peerMessageSender) ctxReadMsg(ctx context.Context, mes proto.Message) error {
-	return ReadMsg(ctx, ms.r, mes)
+	return ReadStreamMsg(ctx, ms.s, ms.r, mes)
 }
 
 func (ms *peerMessageSender) teardown() {
@@ -369,7 +370,27 @@ var writerPool = sync.Pool{
 	},
 }
 
+// WriteMsg wraps the message in a wire.MsgEnvelope and writes it
+// to w as a length delimited message.
 func WriteMsg(w io.Writer, mes proto.Message) error {
+	env, err := wire.NewEnvelope(mes)
+	if err != nil {
+		return err
+	}
+	return writeDelimited(w, env)
+}
+
+// WriteStreamMsg writes the message to the stream in the format used by
+// the stream's protocol. Messages on LegacyProtocolVersion streams are
+// written without an envelope.
+func WriteStreamMsg(s network.Stream, mes proto.Message) error {
+	if IsLegacyProtocol(s.Protocol()) {
+		return writeDelimited(s, mes)
+	}
+	return WriteMsg(s, mes)
+}
+
+func writeDelimited(w io.Writer, mes proto.Message) error {
 	bw := writerPool.Get().(*bufferedDelimitedWriter)
 	bw.Reset(w)
 	err := bw.WriteMsg(mes)
@@ -381,7 +402,25 @@ func WriteMsg(w io.Writer, mes proto.Message) error {
 	return err
 }
 
+// ReadMsg reads a message written by WriteMsg from r into mes. An error
+// is returned if the envelope doesn't carry a message of the same type.
 func ReadMsg(ctx context.Context, r msgio.ReadCloser, mes proto.Message) error {
+	return readMsg(ctx, r, mes, wire.UnmarshalEnvelope)
+}
+
+// ReadStreamMsg reads a message written by WriteStreamMsg from r, a
+// reader over the stream, into mes.
+func ReadStreamMsg(ctx context.Context, s network.Stream, r msgio.ReadCloser, mes proto.Message) error {
+	return readMsg(ctx, r, mes, unmarshalFunc(s.Protocol()))
+}
+
+// UnmarshalStreamMsg decodes a message read from the stream into mes
+// using the format of the stream's protocol.
+func UnmarshalStreamMsg(s network.Stream, b []byte, mes proto.Message) error {
+	return unmarshalFunc(s.Protocol())(b, mes)
+}
+
+func readMsg(ctx context.Context, r msgio.ReadCloser, mes proto.Message, unmarshal func([]byte, proto.Message) error) error {
 	errc := make(chan error, 1)
 	go func(r msgio.ReadCloser) {
 		defer close(errc)
@@ -391,7 +430,7 @@ func ReadMsg(ctx context.Context, r msgio.ReadCloser, mes proto.Message) error {
 			errc <- err
 			return
 		}
-		errc <- proto.Unmarshal(bytes, mes)
+		errc <- unmarshal(bytes, mes)
 	}(r)
 
 	t := time.NewTimer(readMessageTimeout)
@@ -406,3 +445,10 @@ func ReadMsg(ctx context.Context, r msgio.ReadCloser, mes proto.Message) error {
 		return ErrReadTimeout
 	}
 }
+
+func unmarshalFunc(pid protocol.ID) func([]byte, proto.Message) error {
+	if IsLegacyProtocol(pid) {
+		return proto.Unmarshal
+	}
+	return wire.UnmarshalEnvelope
+}
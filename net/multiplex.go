@@ -0,0 +1,306 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package net
+
+import (
+	"context"
+	"errors"
+	"github.com/libp2p/go-libp2p-kad-dht/metrics"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/libp2p/go-msgio"
+	"go.opencensus.io/stats"
+	"google.golang.org/protobuf/proto"
+	"sync"
+	"time"
+)
+
+// ErrDuplicateRequestID is returned when a request is sent with the same ID
+// as a request to the same peer which is still awaiting its response.
+var ErrDuplicateRequestID = errors.New("duplicate request ID")
+
+var errMuxStreamClosed = errors.New("multiplexed stream closed")
+
+// ResponseMessage is a response which carries the ID of the request
+// it responds to.
+type ResponseMessage interface {
+	proto.Message
+	GetRequest_ID() uint32
+}
+
+// MultiplexedMessageSender is a MessageSender which can also pipeline
+// requests to a peer over a single long-lived stream. Requests are written
+// without waiting for the responses to earlier requests and the responses
+// are matched to their requests by request ID.
+type MultiplexedMessageSender interface {
+	MessageSender
+
+	// SendMultiplexedRequest sends the request to the peer over the shared
+	// stream and waits for the response with the matching request ID.
+	SendMultiplexedRequest(ctx context.Context, p peer.ID, requestID uint32, req proto.Message, resp ResponseMessage) error
+}
+
+// multiplexedMessageSender adds the shared per-peer streams to a
+// messageSenderImpl. Requests sent with SendRequest continue to use
+// the pooled streams.
+type multiplexedMessageSender struct {
+	*messageSenderImpl
+
+	newResponse func() ResponseMessage
+	muxStreams  map[peer.ID]*muxStream
+	muxMtx      sync.Mutex
+}
+
+// NewMultiplexedMessageSender returns a new MultiplexedMessageSender. The
+// newResponse function returns an empty response message for the reader
+// to decode responses into.
+func NewMultiplexedMessageSender(h host.Host, newResponse func() ResponseMessage, protos ...protocol.ID) MultiplexedMessageSender {
+	ms := &multiplexedMessageSender{
+		messageSenderImpl: NewMessageSender(h, protos...).(*messageSenderImpl),
+		newResponse:       newResponse,
+		muxStreams:        make(map[peer.ID]*muxStream),
+	}
+
+	h.Network().Notify(&network.NotifyBundle{
+		DisconnectedF: func(_ network.Network, conn network.Conn) {
+			ms.muxMtx.Lock()
+			mux, ok := ms.muxStreams[conn.RemotePeer()]
+			delete(ms.muxStreams, conn.RemotePeer())
+			ms.muxMtx.Unlock()
+			if ok {
+				mux.close(errMuxStreamClosed)
+			}
+		},
+	})
+	return ms
+}
+
+// SendMultiplexedRequest sends the request to the peer over the shared
+// stream and waits for the response with the matching request ID.
+func (m *multiplexedMessageSender) SendMultiplexedRequest(ctx context.Context, p peer.ID, requestID uint32, req proto.Message, resp ResponseMessage) error {
+	start := time.Now()
+
+	// The timeout covers waiting for our turn to write as well as the
+	// response so a stalled stream cannot hold up requests forever.
+	ctx, cancel := context.WithTimeout(ctx, readMessageTimeout)
+	defer cancel()
+
+	var (
+		respCh <-chan ResponseMessage
+		mux    *muxStream
+		err    error
+	)
+	// As with the pooled streams, retry once on a new stream
+	// if the write fails.
+	for retry := false; ; retry = true {
+		mux, err = m.muxStreamForPeer(ctx, p)
+		if err == nil {
+			respCh, err = mux.send(ctx, requestID, req)
+		}
+		if err == nil {
+			break
+		}
+		if errors.Is(err, ErrDuplicateRequestID) || ctx.Err() != nil || retry {
+			stats.Record(ctx,
+				metrics.SentRequests.M(1),
+				metrics.SentRequestErrors.M(1),
+			)
+			return err
+		}
+		log.WithCaller(true).Trace("Error writing multiplexed request, retrying...", log.ArgsFromMap(map[string]any{
+			"to":    p,
+			"error": err,
+		}))
+	}
+
+	select {
+	case msg, ok := <-respCh:
+		if !ok {
+			err = mux.err()
+			break
+		}
+		proto.Merge(resp, msg)
+	case <-ctx.Done():
+		mux.cancel(requestID)
+		err = ctx.Err()
+		if errors.Is(err, context.DeadlineExceeded) {
+			err = ErrReadTimeout
+		}
+	}
+	if err != nil {
+		stats.Record(ctx,
+			metrics.SentRequests.M(1),
+			metrics.SentRequestErrors.M(1),
+		)
+		return err
+	}
+
+	stats.Record(ctx,
+		metrics.SentRequests.M(1),
+		metrics.OutboundRequestLatency.M(float64(time.Since(start))/float64(time.Millisecond)),
+	)
+	m.host.Peerstore().RecordLatency(p, time.Since(start))
+	return nil
+}
+
+// muxStreamForPeer returns the open shared stream to the peer, opening a
+// new one if there is none.
+func (m *multiplexedMessageSender) muxStreamForPeer(ctx context.Context, p peer.ID) (*muxStream, error) {
+	m.muxMtx.Lock()
+	mux, ok := m.muxStreams[p]
+	m.muxMtx.Unlock()
+	if ok && !mux.isClosed() {
+		return mux, nil
+	}
+
+	// The lock is not held while opening the stream as it can block
+	// and the disconnect notifier needs the lock.
+	s, err := m.host.NewStream(ctx, p, m.protocols...)
+	if err != nil {
+		return nil, err
+	}
+
+	m.muxMtx.Lock()
+	defer m.muxMtx.Unlock()
+
+	// Another request may have opened a stream in the meantime.
+	if mux, ok := m.muxStreams[p]; ok && !mux.isClosed() {
+		_ = s.Reset()
+		return mux, nil
+	}
+	mux = &muxStream{
+		s:        s,
+		writeMtx: NewCtxMutex(),
+		pending:  make(map[uint32]chan ResponseMessage),
+		done:     make(chan struct{}),
+	}
+	m.muxStreams[p] = mux
+	go mux.readLoop(m.newResponse)
+	return mux, nil
+}
+
+// muxStream is a stream shared by all of the multiplexed requests to a
+// peer. The remote peer answers the requests in the order they are read
+// but the responses are matched by ID so nothing depends on it.
+type muxStream struct {
+	s        network.Stream
+	writeMtx CtxMutex
+
+	pending  map[uint32]chan ResponseMessage
+	closeErr error
+	done     chan struct{}
+	mtx      sync.Mutex
+}
+
+// send registers the request ID and writes the request. It returns the
+// channel the response will be delivered on. The channel is closed if
+// the stream closes before the response arrives.
+func (mux *muxStream) send(ctx context.Context, requestID uint32, req proto.Message) (<-chan ResponseMessage, error) {
+	mux.mtx.Lock()
+	if mux.closeErr != nil {
+		mux.mtx.Unlock()
+		return nil, mux.closeErr
+	}
+	if _, ok := mux.pending[requestID]; ok {
+		mux.mtx.Unlock()
+		return nil, ErrDuplicateRequestID
+	}
+	ch := make(chan ResponseMessage, 1)
+	mux.pending[requestID] = ch
+	mux.mtx.Unlock()
+
+	if err := mux.writeMtx.Lock(ctx); err != nil {
+		mux.cancel(requestID)
+		return nil, err
+	}
+	// A write which is cut short by the deadline leaves a partial
+	// message on the stream so the stream is closed on any error.
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = mux.s.SetWriteDeadline(deadline)
+	}
+	err := WriteStreamMsg(mux.s, req)
+	_ = mux.s.SetWriteDeadline(time.Time{})
+	mux.writeMtx.Unlock()
+	if err != nil {
+		mux.close(err)
+		return nil, err
+	}
+	return ch, nil
+}
+
+// cancel stops waiting for the response to the request. A response
+// which arrives later is discarded.
+func (mux *muxStream) cancel(requestID uint32) {
+	mux.mtx.Lock()
+	defer mux.mtx.Unlock()
+
+	delete(mux.pending, requestID)
+}
+
+// readLoop reads responses from the stream and delivers them to the
+// waiting requests until the stream errors or is closed.
+func (mux *muxStream) readLoop(newResponse func() ResponseMessage) {
+	r := msgio.NewVarintReaderSize(mux.s, network.MessageSizeMax)
+	for {
+		msgBytes, err := r.ReadMsg()
+		if err != nil {
+			r.ReleaseMsg(msgBytes)
+			mux.close(err)
+			return
+		}
+		resp := newResponse()
+		err = UnmarshalStreamMsg(mux.s, msgBytes, resp)
+		r.ReleaseMsg(msgBytes)
+		if err != nil {
+			mux.close(err)
+			return
+		}
+
+		mux.mtx.Lock()
+		ch, ok := mux.pending[resp.GetRequest_ID()]
+		delete(mux.pending, resp.GetRequest_ID())
+		mux.mtx.Unlock()
+		if ok {
+			ch <- resp
+		}
+	}
+}
+
+// close resets the stream and fails any requests still waiting on
+// a response.
+func (mux *muxStream) close(err error) {
+	mux.mtx.Lock()
+	if mux.closeErr != nil {
+		mux.mtx.Unlock()
+		return
+	}
+	mux.closeErr = err
+	close(mux.done)
+	for id, ch := range mux.pending {
+		close(ch)
+		delete(mux.pending, id)
+	}
+	mux.mtx.Unlock()
+
+	_ = mux.s.Reset()
+}
+
+func (mux *muxStream) isClosed() bool {
+	select {
+	case <-mux.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (mux *muxStream) err() error {
+	mux.mtx.Lock()
+	defer mux.mtx.Unlock()
+
+	return mux.closeErr
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package net
+
+import (
+	"context"
+	"github.com/libp2p/go-libp2p/core/network"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/libp2p/go-msgio"
+	"github.com/project-illium/ilxd/types/wire"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const multiplexTestProtocol = "/multiplextest/2.0.0"
+
+func TestMultiplexedMessageSender(t *testing.T) {
+	mn := mocknet.New()
+	t.Cleanup(func() { mn.Close() })
+
+	h1, err := mn.GenPeer()
+	require.NoError(t, err)
+	h2, err := mn.GenPeer()
+	require.NoError(t, err)
+	require.NoError(t, mn.LinkAll())
+	require.NoError(t, mn.ConnectAllButSelf())
+
+	const numRequests = 10
+
+	// The handler reads every request before answering them in reverse
+	// order so the responses only line up if they are matched by ID.
+	var streams int32
+	h2.SetStreamHandler(multiplexTestProtocol, func(s network.Stream) {
+		atomic.AddInt32(&streams, 1)
+		r := msgio.NewVarintReaderSize(s, network.MessageSizeMax)
+		var reqs []*wire.MsgPollRequest
+		for len(reqs) < numRequests {
+			b, err := r.ReadMsg()
+			if err != nil {
+				s.Reset()
+				return
+			}
+			req := new(wire.MsgPollRequest)
+			require.NoError(t, wire.UnmarshalEnvelope(b, req))
+			r.ReleaseMsg(b)
+			reqs = append(reqs, req)
+		}
+		for i := len(reqs) - 1; i >= 0; i-- {
+			resp := &wire.MsgPollResponse{
+				Request_ID: reqs[i].Request_ID,
+				Votes:      [][]byte{{byte(reqs[i].Heights[0])}},
+			}
+			if err := WriteMsg(s, resp); err != nil {
+				s.Reset()
+				return
+			}
+		}
+	})
+
+	ms := NewMultiplexedMessageSender(h1, func() ResponseMessage {
+		return new(wire.MsgPollResponse)
+	}, multiplexTestProtocol)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for i := 0; i < numRequests; i++ {
+		wg.Add(1)
+		go func(id uint32) {
+			defer wg.Done()
+			req := &wire.MsgPollRequest{
+				Request_ID: id,
+				Heights:    []uint32{id * 2},
+			}
+			resp := new(wire.MsgPollResponse)
+			assert.NoError(t, ms.SendMultiplexedRequest(ctx, h2.ID(), id, req, resp))
+			assert.Equal(t, id, resp.Request_ID)
+			assert.Equal(t, [][]byte{{byte(id * 2)}}, resp.Votes)
+		}(uint32(i))
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&streams))
+}
+
+func TestMultiplexedMessageSenderStreamClosed(t *testing.T) {
+	mn := mocknet.New()
+	t.Cleanup(func() { mn.Close() })
+
+	h1, err := mn.GenPeer()
+	require.NoError(t, err)
+	h2, err := mn.GenPeer()
+	require.NoError(t, err)
+	require.NoError(t, mn.LinkAll())
+	require.NoError(t, mn.ConnectAllButSelf())
+
+	// The first stream is reset without a response. The request fails
+	// and the next request opens a new stream.
+	var streams int32
+	h2.SetStreamHandler(multiplexTestProtocol, func(s network.Stream) {
+		n := atomic.AddInt32(&streams, 1)
+		r := msgio.NewVarintReaderSize(s, network.MessageSizeMax)
+		for {
+			b, err := r.ReadMsg()
+			if err != nil {
+				return
+			}
+			req := new(wire.MsgPollRequest)
+			require.NoError(t, wire.UnmarshalEnvelope(b, req))
+			r.ReleaseMsg(b)
+			if n == 1 {
+				s.Reset()
+				return
+			}
+			if err := WriteMsg(s, &wire.MsgPollResponse{Request_ID: req.Request_ID}); err != nil {
+				return
+			}
+		}
+	})
+
+	ms := NewMultiplexedMessageSender(h1, func() ResponseMessage {
+		return new(wire.MsgPollResponse)
+	}, multiplexTestProtocol)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	err = ms.SendMultiplexedRequest(ctx, h2.ID(), 1, &wire.MsgPollRequest{Request_ID: 1}, new(wire.MsgPollResponse))
+	assert.Error(t, err)
+
+	resp := new(wire.MsgPollResponse)
+	require.NoError(t, ms.SendMultiplexedRequest(ctx, h2.ID(), 2, &wire.MsgPollRequest{Request_ID: 2}, resp))
+	assert.Equal(t, uint32(2), resp.Request_ID)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&streams))
+}
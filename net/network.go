@@ -34,6 +34,7 @@ import (
 	tor "github.com/project-illium/go-libp2p-tor-transport"
 	torOpts "github.com/project-illium/go-libp2p-tor-transport/config"
 	"github.com/project-illium/ilxd/blockchain"
+	"github.com/project-illium/ilxd/evidence"
 	"github.com/project-illium/ilxd/mempool"
 	"github.com/project-illium/ilxd/params/hash"
 	"github.com/project-illium/ilxd/types/blocks"
@@ -47,11 +48,16 @@ const (
 	BlockTopic = "blocks"
 	// TransactionsTopic is the routing topic for transaction relay
 	TransactionsTopic = "transactions"
+	// EvidenceTopic is the routing topic for equivocation evidence relay
+	EvidenceTopic = "evidence"
 	// RelayKey is the routing key used to advertise and discover relay peers
 	RelayKey = "/ilx/relaypeers"
 	// ValidatorProtectionFlag is a flag use to keep alive connections to
 	// validator peers.
 	ValidatorProtectionFlag = "validator"
+	// AnchorProtectionFlag is a flag used to keep alive connections to
+	// anchor peers.
+	AnchorProtectionFlag = "anchor"
 )
 
 // Network manages the libp2p network connections to other peers
@@ -63,9 +69,12 @@ type Network struct {
 	pubsub      *pubsub.PubSub
 	txTopic     *pubsub.Topic
 	blockTopic  *pubsub.Topic
+	evTopic     *pubsub.Topic
 	pstoreds    *Peerstoreds
 	txSub       *pubsub.Subscription
 	blkSub      *pubsub.Subscription
+	evSub       *pubsub.Subscription
+	anchors     *AnchorConnector
 
 	reachability    inet.Reachability
 	reachabilityMtx sync.RWMutex
@@ -115,6 +124,23 @@ func NewNetwork(ctx context.Context, opts ...Option) (*Network, error) {
 		seedAddrs = append(seedAddrs, *pi)
 	}
 
+	anchorAddrs := make([]peer.AddrInfo, 0, len(cfg.anchorAddrs))
+	for _, addr := range cfg.anchorAddrs {
+		ma, err := multiaddr.NewMultiaddr(addr)
+		if err != nil {
+			return nil, fmt.Errorf("%w: malformatted anchor peer", ErrNetworkConfig)
+		}
+
+		pi, err := peer.AddrInfoFromP2pAddr(ma)
+		if err != nil {
+			return nil, err
+		}
+		if pi.ID == self {
+			continue
+		}
+		anchorAddrs = append(anchorAddrs, *pi)
+	}
+
 	var (
 		kdht   *dht.IpfsDHT
 		pstore peerstore.Peerstore
@@ -160,6 +186,16 @@ loop:
 	if err != nil {
 		return nil, err
 	}
+	for _, rule := range cfg.allowRules {
+		if err := conngater.addAccessRule(AllowList, rule, false); err != nil {
+			return nil, fmt.Errorf("%w: allow rule %s: %s", ErrNetworkConfig, rule, err)
+		}
+	}
+	for _, rule := range cfg.denyRules {
+		if err := conngater.addAccessRule(DenyList, rule, false); err != nil {
+			return nil, fmt.Errorf("%w: deny rule %s: %s", ErrNetworkConfig, rule, err)
+		}
+	}
 
 	// Only peers running in server mode are discoverable given just
 	// their peerID. For validators its imperative that they are
@@ -474,6 +510,32 @@ loop:
 		return nil, err
 	}
 
+	err = ps.RegisterTopicValidator(EvidenceTopic, pubsub.ValidatorEx(func(ctx context.Context, p peer.ID, m *pubsub.Message) pubsub.ValidationResult {
+		ev := &blocks.EquivocationEvidence{}
+		if err := ev.Deserialize(m.Data); err != nil {
+			return pubsub.ValidationReject
+		}
+		if cfg.validateEvidence == nil {
+			return pubsub.ValidationIgnore
+		}
+		err := cfg.validateEvidence(ev, p)
+		switch {
+		case err == nil:
+			return pubsub.ValidationAccept
+		case errors.Is(err, evidence.ErrInvalidEvidence):
+			log.Debug("Reject equivocation evidence", log.ArgsFromMap(map[string]any{
+				"from peer": p,
+				"error":     err,
+			}))
+			return pubsub.ValidationReject
+		default:
+			return pubsub.ValidationIgnore
+		}
+	}))
+	if err != nil {
+		return nil, err
+	}
+
 	if err = kdht.Bootstrap(ctx); err != nil {
 		return nil, err
 	}
@@ -515,37 +577,65 @@ loop:
 		return nil, err
 	}
 
-	txSub, err := txTopic.Subscribe()
+	evTopic, err := ps.Join(EvidenceTopic)
+	if err != nil {
+		return nil, err
+	}
+
+	// In blocks only mode the node joins the transactions topic so it
+	// can publish its own transactions but doesn't subscribe to it.
+	var txSub *pubsub.Subscription
+	if !cfg.blocksOnly {
+		txSub, err = txTopic.Subscribe()
+		if err != nil {
+			return nil, err
+		}
+		go func() {
+			for {
+				_, err := txSub.Next(context.Background())
+				if errors.Is(err, pubsub.ErrSubscriptionCancelled) {
+					log.Debug("Pubsub tx subscription canceled")
+					return
+				}
+				if err != nil {
+					log.WithCaller(true).Error("Pubsub tx subscription error", log.Args("error", err))
+					continue
+				}
+			}
+		}()
+	}
+
+	blockSub, err := blockTopic.Subscribe()
 	if err != nil {
 		return nil, err
 	}
 	go func() {
 		for {
-			_, err := txSub.Next(context.Background())
+			_, err := blockSub.Next(context.Background())
 			if errors.Is(err, pubsub.ErrSubscriptionCancelled) {
-				log.Debug("Pubsub tx subscription canceled")
+				log.Debug("Pubsub block subscription canceled")
 				return
 			}
 			if err != nil {
-				log.WithCaller(true).Error("Pubsub tx subscription error", log.Args("error", err))
+				log.Debug("Pubsub block subscription error", log.Args("error", err))
 				continue
 			}
 		}
 	}()
 
-	blockSub, err := blockTopic.Subscribe()
+	evSub, err := evTopic.Subscribe()
 	if err != nil {
 		return nil, err
 	}
 	go func() {
 		for {
-			_, err := blockSub.Next(context.Background())
+			_, err := evSub.Next(context.Background())
 			if errors.Is(err, pubsub.ErrSubscriptionCancelled) {
-				log.Debug("Pubsub block subscription canceled")
+				log.Debug("Pubsub evidence subscription canceled")
 				return
 			}
 			if err != nil {
-				log.Debug("Pubsub block subscription error", log.Args("error", err))
+				log.Debug("Pubsub evidence subscription error", log.Args("error", err))
 				continue
 			}
 		}
@@ -559,9 +649,11 @@ loop:
 		pubsub:          ps,
 		txTopic:         txTopic,
 		blockTopic:      blockTopic,
+		evTopic:         evTopic,
 		pstoreds:        pstoreds,
 		txSub:           txSub,
 		blkSub:          blockSub,
+		evSub:           evSub,
 		reachabilityMtx: sync.RWMutex{},
 	}
 
@@ -579,6 +671,10 @@ loop:
 
 	host.Network().Notify(notifier)
 
+	if len(anchorAddrs) > 0 {
+		net.anchors = NewAnchorConnector(host, anchorAddrs)
+	}
+
 	subReachability, err := host.EventBus().Subscribe(new(event.EvtLocalReachabilityChanged))
 	if err != nil {
 		return nil, err
@@ -614,8 +710,14 @@ loop:
 
 // Close shuts down the network
 func (n *Network) Close() error {
-	n.txSub.Cancel()
+	if n.anchors != nil {
+		n.anchors.Close()
+	}
+	if n.txSub != nil {
+		n.txSub.Cancel()
+	}
 	n.blkSub.Cancel()
+	n.evSub.Cancel()
 	n.pstoreds.Close()
 	if err := n.host.Close(); err != nil {
 		return err
@@ -691,6 +793,36 @@ func (n *Network) BroadcastTransaction(tx *transactions.Transaction) error {
 	return n.txTopic.Publish(context.Background(), ser)
 }
 
+// BroadcastEvidence submits equivocation evidence to the evidence pubsub channel
+func (n *Network) BroadcastEvidence(ev *blocks.EquivocationEvidence) error {
+	ser, err := ev.Serialize()
+	if err != nil {
+		return err
+	}
+	return n.evTopic.Publish(context.Background(), ser)
+}
+
+// IsAnchorPeer returns whether the peer is one of the configured
+// anchor peers.
+func (n *Network) IsAnchorPeer(p peer.ID) bool {
+	if n.anchors == nil {
+		return false
+	}
+	return n.anchors.IsAnchor(p)
+}
+
+// EnforceAccessLists closes any connections which are not permitted by
+// the connection gater's access lists. It should be called after the
+// lists are changed as the gater only checks new connections.
+func (n *Network) EnforceAccessLists() {
+	for _, conn := range n.host.Network().Conns() {
+		if !n.connGater.ConnAllowed(conn) {
+			log.Debug("Closing connection disallowed by access list", log.Args("peer", conn.RemotePeer()))
+			conn.Close() //nolint:errcheck
+		}
+	}
+}
+
 // IncreaseBanscore increases the banscore for a peer and bans the peer if it goes over
 // the configured threshold.
 func (n *Network) IncreaseBanscore(p peer.ID, persistent, transient uint32, reason ...string) {
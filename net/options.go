@@ -36,6 +36,13 @@ func BlockValidator(validateBlock func(blk *blocks.XThinnerBlock, p peer.ID) err
 	}
 }
 
+func EvidenceValidator(validateEvidence func(ev *blocks.EquivocationEvidence, p peer.ID) error) Option {
+	return func(cfg *config) error {
+		cfg.validateEvidence = validateEvidence
+		return nil
+	}
+}
+
 func Params(params *params.NetworkParams) Option {
 	return func(cfg *config) error {
 		cfg.params = params
@@ -92,6 +99,34 @@ func SeedAddrs(addrs []string) Option {
 	}
 }
 
+// AnchorAddrs are the addresses of trusted peers the node always
+// keeps outbound connections to. The addresses must include the
+// peer ID.
+func AnchorAddrs(addrs []string) Option {
+	return func(cfg *config) error {
+		cfg.anchorAddrs = addrs
+		return nil
+	}
+}
+
+// AllowRules restricts inbound connections to the provided peer IDs
+// and IP ranges. See the AllowList for details.
+func AllowRules(rules []string) Option {
+	return func(cfg *config) error {
+		cfg.allowRules = rules
+		return nil
+	}
+}
+
+// DenyRules blocks connections to and from the provided peer IDs and
+// IP ranges.
+func DenyRules(rules []string) Option {
+	return func(cfg *config) error {
+		cfg.denyRules = rules
+		return nil
+	}
+}
+
 func DisableNatPortMap() Option {
 	return func(cfg *config) error {
 		cfg.disableNatPortMap = true
@@ -123,6 +158,18 @@ func ForceDHTServerMode() Option {
 	}
 }
 
+// BlocksOnly stops the node from subscribing to the transactions
+// topic. Gossipsub peers exchange their subscriptions when they
+// connect and only relay a topic's messages to peers subscribed to
+// it, so peers will not send this node unconfirmed transactions. The
+// node can still broadcast its own transactions.
+func BlocksOnly() Option {
+	return func(cfg *config) error {
+		cfg.blocksOnly = true
+		return nil
+	}
+}
+
 // TorBinary is the path to a tor binary file. If this option
 // is used the tor transport is activated and the tor binary
 // is started and managed by this process.
@@ -166,6 +213,9 @@ type config struct {
 	params            *params.NetworkParams
 	userAgent         string
 	seedAddrs         []string
+	anchorAddrs       []string
+	allowRules        []string
+	denyRules         []string
 	listenAddrs       []string
 	disableNatPortMap bool
 	maxMessageSize    int
@@ -174,8 +224,10 @@ type config struct {
 	datastore         repo.Datastore
 	acceptToMempool   func(tx *transactions.Transaction) error
 	validateBlock     func(blk *blocks.XThinnerBlock, p peer.ID) error
+	validateEvidence  func(ev *blocks.EquivocationEvidence, p peer.ID) error
 	maxBanscore       uint32
 	forceServerMode   bool
+	blocksOnly        bool
 	banDuration       time.Duration
 	torBinary         string
 	torrcFile         string
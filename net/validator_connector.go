@@ -122,6 +122,27 @@ func (vc *ValidatorConnector) ValidatorDialSuccessRate(p peer.ID) float64 {
 	return float64(stats.queryAttempts-stats.queryFailures) / float64(stats.queryAttempts)
 }
 
+// Refresh closes the connections to validators which have failed more
+// than half of their queries this epoch and immediately dials any
+// validators we are not connected to. This is used to help recover
+// when blocks are not finalizing.
+func (vc *ValidatorConnector) Refresh() {
+	vc.mtx.RLock()
+	var unresponsive []peer.ID
+	for p, stats := range vc.currentStats {
+		if stats.queryAttempts > 0 && stats.queryFailures*2 > stats.queryAttempts {
+			unresponsive = append(unresponsive, p)
+		}
+	}
+	vc.mtx.RUnlock()
+
+	for _, p := range unresponsive {
+		log.Debug("Closing connection to unresponsive validator", log.Args("peer", p))
+		vc.host.Network().ClosePeer(p)
+	}
+	vc.update()
+}
+
 func (vc *ValidatorConnector) resetStats() {
 	vc.mtx.Lock()
 	defer vc.mtx.Unlock()
@@ -9,6 +9,7 @@ import (
 	"github.com/project-illium/ilxd/types/blocks"
 	"math"
 	"path"
+	"time"
 )
 
 const (
@@ -54,6 +55,11 @@ type NetworkParams struct {
 	// of the bech32 serialization.
 	AddressPrefix string
 
+	// LegacyAddressPrefixes are address prefixes previously used by
+	// the network. Addresses using these prefixes are still accepted
+	// when decoding and are re-encoded using the current AddressPrefix.
+	LegacyAddressPrefixes []string
+
 	// The following controls the rate of coin emission for the network.
 	//
 	// EpochLength is the length of time (in seconds) between coinbase
@@ -85,6 +91,59 @@ type NetworkParams struct {
 	// AllowMockProofs sets whether the node be made to use mock proofs.
 	// This is primarily for testing purposes as full proofs are very heavy.
 	AllowMockProofs bool
+
+	// The following controls block timestamp validation.
+	//
+	// MedianTimeBlocks is the number of previous blocks used to calculate
+	// the median time past. A block's timestamp must be after the median
+	// time past.
+	MedianTimeBlocks uint32
+	// MedianTimePastHeight is the height at which the median time past
+	// rule takes effect. Blocks before this height are only required to
+	// be after their parent.
+	MedianTimePastHeight uint32
+	// MaxBlockFutureDrift is the maximum amount of time a block's timestamp
+	// may be ahead of the network adjusted time. Blocks beyond this are
+	// treated as orphans until the clock catches up.
+	MaxBlockFutureDrift time.Duration
+
+	// The following controls block and transaction size.
+	//
+	// MaxBlockSize is the maximum serialized size of a block in bytes.
+	// Blocks larger than this are invalid. It must be smaller than the
+	// network's maximum message size so that valid blocks can be relayed.
+	MaxBlockSize uint32
+	// MaxBlockSizeHeight is the height at which the MaxBlockSize rule
+	// takes effect. Blocks before this height are only limited by the
+	// maximum message size.
+	MaxBlockSizeHeight uint32
+	// MaxTransactionSize is the maximum serialized size of a transaction
+	// in bytes. Transactions larger than this are invalid.
+	MaxTransactionSize uint32
+
+	// CoinbaseMaturity is the number of blocks the stake rewards credited
+	// to the validators at the start of an epoch must wait before they can
+	// be claimed by a coinbase transaction.
+	CoinbaseMaturity uint32
+	// CoinbaseMaturityHeight is the height at which CoinbaseMaturity
+	// takes effect. Rewards credited by an epoch which started before
+	// this height may be claimed right away.
+	CoinbaseMaturityHeight uint32
+
+	// The following controls the avalanche consensus engine.
+	//
+	// AvalancheFinalizationScore is the confidence score at which a
+	// block is considered final. It must be between 1 and 32767.
+	AvalancheFinalizationScore uint16
+	// AvalancheMaxInflightPoll is the max outstanding requests that we
+	// can have for any block.
+	AvalancheMaxInflightPoll int
+	// AvalancheRequestTimeout is the amount of time to wait for a
+	// response to a poll.
+	AvalancheRequestTimeout time.Duration
+	// AvalanchePollInterval is the amount of time to wait between
+	// polling rounds.
+	AvalanchePollInterval time.Duration
 }
 
 var MainnetParams = NetworkParams{
@@ -108,6 +167,18 @@ var MainnetParams = NetworkParams{
 	TreasuryPercentage:         5,
 	LongTermInflationRate:      math.Pow(1.02, 1.0/52) - 1, // Annualizes to 2% over 52 periods.
 	AllowMockProofs:            false,
+	MedianTimeBlocks:           11,
+	MedianTimePastHeight:       math.MaxUint32, // Not yet scheduled
+	MaxBlockFutureDrift:        time.Second * 10,
+	MaxBlockSize:               1 << 22,        // 4 MiB
+	MaxBlockSizeHeight:         math.MaxUint32, // Not yet scheduled
+	MaxTransactionSize:         1000000,
+	CoinbaseMaturity:           100,
+	CoinbaseMaturityHeight:     math.MaxUint32, // Not yet scheduled
+	AvalancheFinalizationScore: 160,
+	AvalancheMaxInflightPoll:   160,
+	AvalancheRequestTimeout:    time.Minute,
+	AvalanchePollInterval:      time.Millisecond,
 }
 
 var Testnet1Params = NetworkParams{
@@ -130,6 +201,18 @@ var Testnet1Params = NetworkParams{
 	TreasuryPercentage:         5,
 	LongTermInflationRate:      math.Pow(1.02, 1.0/52) - 1, // Annualizes to 2% over 52 periods.
 	AllowMockProofs:            false,
+	MedianTimeBlocks:           11,
+	MedianTimePastHeight:       math.MaxUint32, // Not yet scheduled
+	MaxBlockFutureDrift:        time.Second * 10,
+	MaxBlockSize:               1 << 22,        // 4 MiB
+	MaxBlockSizeHeight:         math.MaxUint32, // Not yet scheduled
+	MaxTransactionSize:         1000000,
+	CoinbaseMaturity:           100,
+	CoinbaseMaturityHeight:     math.MaxUint32, // Not yet scheduled
+	AvalancheFinalizationScore: 160,
+	AvalancheMaxInflightPoll:   160,
+	AvalancheRequestTimeout:    time.Minute,
+	AvalanchePollInterval:      time.Millisecond,
 }
 
 var AlphanetParams = NetworkParams{
@@ -154,6 +237,18 @@ var AlphanetParams = NetworkParams{
 	TreasuryPercentage:         5,
 	LongTermInflationRate:      math.Pow(1.02, 1.0/52) - 1, // Annualizes to 2% over 52 periods.
 	AllowMockProofs:            false,
+	MedianTimeBlocks:           11,
+	MedianTimePastHeight:       math.MaxUint32, // Not yet scheduled
+	MaxBlockFutureDrift:        time.Second * 10,
+	MaxBlockSize:               1 << 22,        // 4 MiB
+	MaxBlockSizeHeight:         math.MaxUint32, // Not yet scheduled
+	MaxTransactionSize:         1000000,
+	CoinbaseMaturity:           100,
+	CoinbaseMaturityHeight:     math.MaxUint32, // Not yet scheduled
+	AvalancheFinalizationScore: 160,
+	AvalancheMaxInflightPoll:   160,
+	AvalancheRequestTimeout:    time.Minute,
+	AvalanchePollInterval:      time.Millisecond,
 }
 
 var RegestParams = NetworkParams{
@@ -175,4 +270,16 @@ var RegestParams = NetworkParams{
 	TreasuryPercentage:         5,
 	LongTermInflationRate:      math.Pow(1.02, 1.0/52) - 1, // Annualizes to 2% over 52 periods.
 	AllowMockProofs:            true,
+	MedianTimeBlocks:           11,
+	MedianTimePastHeight:       0,
+	MaxBlockFutureDrift:        time.Hour * 24, // Allows regtest blocks to be timestamped in the future
+	MaxBlockSize:               1 << 22,        // 4 MiB
+	MaxBlockSizeHeight:         0,
+	MaxTransactionSize:         1000000,
+	CoinbaseMaturity:           0, // Allows regtest rewards to be claimed immediately
+	CoinbaseMaturityHeight:     0,
+	AvalancheFinalizationScore: 32,
+	AvalancheMaxInflightPoll:   32,
+	AvalancheRequestTimeout:    time.Second * 10,
+	AvalanchePollInterval:      time.Millisecond,
 }
@@ -0,0 +1,240 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package plugins
+
+import (
+	"fmt"
+	"github.com/project-illium/ilxd/blockchain/indexers"
+	"github.com/project-illium/ilxd/types/blocks"
+	"github.com/project-illium/ilxd/types/transactions"
+	"github.com/project-illium/walletlib"
+	"google.golang.org/grpc"
+	"os"
+	"path"
+	"sync"
+)
+
+// eventQueueSize is the number of events buffered for each plugin
+// before new events are dropped.
+const eventQueueSize = 1000
+
+// handle runs the hooks for a single plugin.
+type handle struct {
+	plugin Plugin
+	events chan func()
+	quit   chan struct{}
+	wg     sync.WaitGroup
+}
+
+func (h *handle) run() {
+	defer h.wg.Done()
+	for {
+		select {
+		case fn := <-h.events:
+			h.call(fn)
+		case <-h.quit:
+			return
+		}
+	}
+}
+
+// call runs a hook, recovering from any panic so that a misbehaving
+// plugin cannot crash the node.
+func (h *handle) call(fn func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.WithCaller(true).Error("Plugin hook panicked", log.ArgsFromMap(map[string]any{
+				"plugin": h.plugin.Name(),
+				"panic":  r,
+			}))
+		}
+	}()
+	fn()
+}
+
+func (h *handle) enqueue(fn func()) {
+	select {
+	case h.events <- fn:
+	default:
+		log.Warn("Plugin event queue full. Dropping event.", log.Args("plugin", h.plugin.Name()))
+	}
+}
+
+// Manager starts and stops the plugins and delivers hooks to them.
+type Manager struct {
+	handles []*handle
+	started bool
+	mtx     sync.RWMutex
+}
+
+// NewManager returns a Manager for the registered plugins plus those
+// loaded from the given plugin files.
+func NewManager(paths []string) (*Manager, error) {
+	loaded := Registered()
+	for _, p := range paths {
+		plugin, err := Load(p)
+		if err != nil {
+			return nil, fmt.Errorf("error loading plugin %s: %w", p, err)
+		}
+		loaded = append(loaded, plugin)
+	}
+
+	m := &Manager{mtx: sync.RWMutex{}}
+	names := make(map[string]bool)
+	for _, p := range loaded {
+		if names[p.Name()] {
+			return nil, fmt.Errorf("duplicate plugin name %s", p.Name())
+		}
+		names[p.Name()] = true
+		m.handles = append(m.handles, &handle{plugin: p})
+	}
+	return m, nil
+}
+
+// pluginIndexer adapts an IndexerHook to the indexers.ExternalIndexer
+// interface. The plugin name is used as the index key.
+type pluginIndexer struct {
+	plugin Plugin
+	hook   IndexerHook
+}
+
+func (p *pluginIndexer) Key() string {
+	return "plugin/" + p.plugin.Name()
+}
+
+func (p *pluginIndexer) ConnectBlock(blk *blocks.Block) error {
+	return p.hook.IndexBlock(blk)
+}
+
+// Indexers returns an external indexer for each plugin which
+// implements IndexerHook.
+func (m *Manager) Indexers() []indexers.ExternalIndexer {
+	var ret []indexers.ExternalIndexer
+	for _, h := range m.handles {
+		if hook, ok := h.plugin.(IndexerHook); ok {
+			ret = append(ret, &pluginIndexer{plugin: h.plugin, hook: hook})
+		}
+	}
+	return ret
+}
+
+// RegisterRPC registers the gRPC services of the plugins that have them.
+func (m *Manager) RegisterRPC(server *grpc.Server) error {
+	for _, h := range m.handles {
+		if hook, ok := h.plugin.(RPCHook); ok {
+			if err := hook.RegisterRPC(server); err != nil {
+				return fmt.Errorf("plugin %s: %w", h.plugin.Name(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// Start starts each plugin. The environment's DataDir is the parent
+// directory; each plugin is given its own directory inside it.
+func (m *Manager) Start(env *Environment) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if m.started {
+		return nil
+	}
+	for _, h := range m.handles {
+		pluginEnv := *env
+		pluginEnv.DataDir = path.Join(env.DataDir, h.plugin.Name())
+		if err := os.MkdirAll(pluginEnv.DataDir, 0700); err != nil {
+			m.closePlugins()
+			return err
+		}
+		if err := h.plugin.Start(&pluginEnv); err != nil {
+			m.closePlugins()
+			return fmt.Errorf("plugin %s: %w", h.plugin.Name(), err)
+		}
+		h.events = make(chan func(), eventQueueSize)
+		h.quit = make(chan struct{})
+		h.wg.Add(1)
+		go h.run()
+		log.Info("Started plugin", log.Args("name", h.plugin.Name()))
+	}
+	m.started = true
+	return nil
+}
+
+// Close stops each plugin. Any queued events are discarded.
+func (m *Manager) Close() error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.started = false
+	return m.closePlugins()
+}
+
+// closePlugins closes each plugin that was started.
+//
+// This method is NOT safe for concurrent access.
+func (m *Manager) closePlugins() error {
+	var firstErr error
+	for _, h := range m.handles {
+		if h.quit == nil {
+			continue
+		}
+		close(h.quit)
+		h.wg.Wait()
+		h.quit = nil
+		if err := h.plugin.Close(); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("plugin %s: %w", h.plugin.Name(), err)
+		}
+	}
+	return firstErr
+}
+
+// TransactionReceived delivers a transaction accepted into the mempool
+// to the plugins implementing TransactionHook.
+func (m *Manager) TransactionReceived(tx *transactions.Transaction) {
+	m.dispatch(func(p Plugin) func() {
+		if hook, ok := p.(TransactionHook); ok {
+			return func() { hook.TransactionReceived(tx) }
+		}
+		return nil
+	})
+}
+
+// BlockConnected delivers a newly connected block to the plugins
+// implementing BlockHook.
+func (m *Manager) BlockConnected(blk *blocks.Block) {
+	m.dispatch(func(p Plugin) func() {
+		if hook, ok := p.(BlockHook); ok {
+			return func() { hook.BlockConnected(blk) }
+		}
+		return nil
+	})
+}
+
+// WalletTransaction delivers a wallet transaction to the plugins
+// implementing WalletHook.
+func (m *Manager) WalletTransaction(tx *walletlib.WalletTransaction) {
+	m.dispatch(func(p Plugin) func() {
+		if hook, ok := p.(WalletHook); ok {
+			return func() { hook.WalletTransaction(tx) }
+		}
+		return nil
+	})
+}
+
+// dispatch queues the hook returned by makeHook on each running plugin.
+// Events are dropped while the plugins are not running.
+func (m *Manager) dispatch(makeHook func(p Plugin) func()) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	if !m.started {
+		return
+	}
+	for _, h := range m.handles {
+		if fn := makeHook(h.plugin); fn != nil {
+			h.enqueue(fn)
+		}
+	}
+}
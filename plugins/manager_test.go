@@ -0,0 +1,104 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package plugins_test
+
+import (
+	"github.com/project-illium/ilxd/params"
+	"github.com/project-illium/ilxd/plugins"
+	"github.com/project-illium/ilxd/types/blocks"
+	"github.com/project-illium/ilxd/types/transactions"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+	"time"
+)
+
+type mockPlugin struct {
+	name    string
+	env     *plugins.Environment
+	txs     chan *transactions.Transaction
+	blks    chan *blocks.Block
+	closed  bool
+	doPanic bool
+}
+
+func (m *mockPlugin) Name() string { return m.name }
+
+func (m *mockPlugin) Start(env *plugins.Environment) error {
+	m.env = env
+	return nil
+}
+
+func (m *mockPlugin) Close() error {
+	m.closed = true
+	return nil
+}
+
+func (m *mockPlugin) TransactionReceived(tx *transactions.Transaction) {
+	if m.doPanic {
+		panic("bad plugin")
+	}
+	m.txs <- tx
+}
+
+func (m *mockPlugin) BlockConnected(blk *blocks.Block) {
+	m.blks <- blk
+}
+
+func TestManager(t *testing.T) {
+	dataDir, err := os.MkdirTemp("", "plugins")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dataDir)
+
+	p := &mockPlugin{
+		name:    "mock",
+		txs:     make(chan *transactions.Transaction, 1),
+		blks:    make(chan *blocks.Block, 1),
+		doPanic: true,
+	}
+	plugins.Register(p)
+	assert.Panics(t, func() { plugins.Register(p) })
+
+	m, err := plugins.NewManager(nil)
+	assert.NoError(t, err)
+
+	// Events are dropped before the plugins are started.
+	m.TransactionReceived(transactions.WrapTransaction(&transactions.StandardTransaction{}))
+
+	assert.NoError(t, m.Start(&plugins.Environment{
+		Params:  &params.RegestParams,
+		DataDir: dataDir,
+	}))
+	assert.Equal(t, &params.RegestParams, p.env.Params)
+	_, err = os.Stat(p.env.DataDir)
+	assert.NoError(t, err)
+
+	// A panicking hook does not take down the plugin.
+	m.TransactionReceived(transactions.WrapTransaction(&transactions.StandardTransaction{Fee: 1}))
+
+	blk := &blocks.Block{Header: &blocks.BlockHeader{Height: 5}}
+	m.BlockConnected(blk)
+	select {
+	case b := <-p.blks:
+		assert.Equal(t, uint32(5), b.Header.Height)
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for block hook")
+	}
+	select {
+	case <-p.txs:
+		t.Fatal("unexpected transaction hook")
+	default:
+	}
+
+	assert.NoError(t, m.Close())
+	assert.True(t, p.closed)
+
+	m.BlockConnected(blk)
+	select {
+	case <-p.blks:
+		t.Fatal("hook delivered after close")
+	case <-time.After(time.Millisecond * 100):
+	}
+}
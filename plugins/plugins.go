@@ -0,0 +1,149 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+// Package plugins provides the interface used to extend ilxd in-process.
+//
+// A plugin implements the Plugin interface along with any of the optional
+// hook interfaces below. Plugins can either be compiled into the node by
+// calling Register from an init function, or built separately with
+// `go build -buildmode=plugin` and loaded at startup with the --plugin
+// option. A plugin loaded from a file must export a function:
+//
+//	func NewPlugin() plugins.Plugin
+//
+// Hooks are delivered on a goroutine owned by each plugin, in the order
+// the events occurred, so a slow plugin does not hold up the node or other
+// plugins. If a plugin falls too far behind, events are dropped.
+package plugins
+
+import (
+	"errors"
+	"fmt"
+	"github.com/project-illium/ilxd/params"
+	"github.com/project-illium/ilxd/types/blocks"
+	"github.com/project-illium/ilxd/types/transactions"
+	"github.com/project-illium/walletlib"
+	"google.golang.org/grpc"
+	goplugin "plugin"
+	"sync"
+)
+
+// NewPluginSymbol is the name of the constructor looked up in plugin files.
+const NewPluginSymbol = "NewPlugin"
+
+// Plugin is the interface implemented by all plugins.
+type Plugin interface {
+	// Name returns a unique name for the plugin. It is used in
+	// the logs and to name the plugin's data directory.
+	Name() string
+
+	// Start is called once the node is running. The plugin may
+	// be started again after Close if the node restarts its
+	// subsystems.
+	Start(env *Environment) error
+
+	// Close is called when the node shuts down.
+	Close() error
+}
+
+// Environment is what the node exposes to a plugin when it is started.
+type Environment struct {
+	// Params are the parameters of the network the node is on.
+	Params *params.NetworkParams
+
+	// DataDir is a directory reserved for the plugin's own files.
+	DataDir string
+
+	// SubmitTransaction validates a transaction and relays it
+	// to the network.
+	SubmitTransaction func(tx *transactions.Transaction) error
+}
+
+// TransactionHook is implemented by plugins which want to be notified
+// when a transaction is accepted into the mempool.
+type TransactionHook interface {
+	TransactionReceived(tx *transactions.Transaction)
+}
+
+// BlockHook is implemented by plugins which want to be notified when a
+// block is finalized and connected to the chain.
+type BlockHook interface {
+	BlockConnected(blk *blocks.Block)
+}
+
+// WalletHook is implemented by plugins which want to be notified when a
+// transaction belonging to the wallet is finalized.
+type WalletHook interface {
+	WalletTransaction(tx *walletlib.WalletTransaction)
+}
+
+// IndexerHook is implemented by plugins which maintain their own index
+// of the chain. Unlike BlockHook, no block is missed: the node checkpoints
+// the height of the last block the plugin indexed and feeds it blocks from
+// there, backfilling from genesis the first time the plugin runs. If
+// IndexBlock returns an error the block is retried.
+type IndexerHook interface {
+	IndexBlock(blk *blocks.Block) error
+}
+
+// RPCHook is implemented by plugins which serve their own gRPC services.
+// RegisterRPC is called before the node's gRPC server starts serving.
+// The services are served alongside the node's own and use the same
+// authentication token.
+type RPCHook interface {
+	RegisterRPC(server *grpc.Server) error
+}
+
+var (
+	registry    []Plugin
+	registryMtx sync.Mutex
+)
+
+// Register makes a plugin compiled into the node available. It is meant
+// to be called from an init function and panics if a plugin with the same
+// name is already registered.
+func Register(p Plugin) {
+	registryMtx.Lock()
+	defer registryMtx.Unlock()
+
+	for _, r := range registry {
+		if r.Name() == p.Name() {
+			panic(fmt.Sprintf("plugins: plugin %s registered twice", p.Name()))
+		}
+	}
+	registry = append(registry, p)
+}
+
+// Registered returns the plugins that have been registered.
+func Registered() []Plugin {
+	registryMtx.Lock()
+	defer registryMtx.Unlock()
+
+	ret := make([]Plugin, len(registry))
+	copy(ret, registry)
+	return ret
+}
+
+// Load opens a plugin file built with `go build -buildmode=plugin` and
+// returns the plugin created by its NewPlugin function. The file must be
+// built with the same version of Go and of ilxd as the node.
+func Load(path string) (Plugin, error) {
+	p, err := goplugin.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	sym, err := p.Lookup(NewPluginSymbol)
+	if err != nil {
+		return nil, err
+	}
+	newPlugin, ok := sym.(func() Plugin)
+	if !ok {
+		return nil, fmt.Errorf("%s in %s has the wrong type", NewPluginSymbol, path)
+	}
+	plugin := newPlugin()
+	if plugin == nil {
+		return nil, errors.New("plugin constructor returned nil")
+	}
+	return plugin, nil
+}
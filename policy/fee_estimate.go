@@ -0,0 +1,112 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package policy
+
+import (
+	"errors"
+	"github.com/project-illium/ilxd/types"
+	"github.com/project-illium/ilxd/types/blocks"
+	"github.com/project-illium/ilxd/types/transactions"
+	"sort"
+)
+
+const (
+	// MaxConfTarget is the largest number of blocks a fee
+	// can be estimated for.
+	MaxConfTarget = 100
+
+	// fullBlockThreshold is the fraction of the blocksize soft
+	// limit above which a block is considered full.
+	fullBlockThreshold = .9
+)
+
+// EstimateFeePerKilobyte returns the fee per kilobyte a transaction should
+// pay to be included in a block within confTarget blocks.
+//
+// The fee paying transactions in the mempool are ordered by fee per kilobyte
+// and assumed to fill the next blocks up to the blocksize soft limit. The
+// estimate is just above the fee of the first transaction that would not fit
+// into the target number of blocks or the minimum fee if they all fit.
+//
+// If most of the recent blocks were full the estimate is at least the median
+// of the lowest fee each of the full blocks included as that is what it took
+// to get into a block recently.
+func (p *Policy) EstimateFeePerKilobyte(mempoolTxs []*transactions.Transaction, recentBlocks []*blocks.Block, confTarget uint32) (types.Amount, error) {
+	if confTarget == 0 || confTarget > MaxConfTarget {
+		return 0, errors.New("confirmation target out of range")
+	}
+	p.mtx.RLock()
+	minFee, softLimit := p.minFeePerKilobyte, p.blocksizeSoftLimit
+	p.mtx.RUnlock()
+
+	type feeRate struct {
+		size int
+		fpkb types.Amount
+	}
+	rates := make([]feeRate, 0, len(mempoolTxs))
+	for _, tx := range mempoolTxs {
+		fpkb, isFeePayer, err := CalcFeePerKilobyte(tx)
+		if err != nil {
+			return 0, err
+		}
+		if !isFeePayer {
+			continue
+		}
+		size, err := tx.SerializedSize()
+		if err != nil {
+			return 0, err
+		}
+		rates = append(rates, feeRate{size: size, fpkb: fpkb})
+	}
+	sort.Slice(rates, func(i, j int) bool {
+		return rates[i].fpkb > rates[j].fpkb
+	})
+
+	estimate := minFee
+	capacity := uint64(softLimit) * uint64(confTarget)
+	total := uint64(0)
+	for _, r := range rates {
+		total += uint64(r.size)
+		if total > capacity {
+			if r.fpkb+1 > estimate {
+				estimate = r.fpkb + 1
+			}
+			break
+		}
+	}
+
+	var lowestFees []types.Amount
+	for _, blk := range recentBlocks {
+		size, err := blk.SerializedSize()
+		if err != nil {
+			return 0, err
+		}
+		if float64(size) < float64(softLimit)*fullBlockThreshold {
+			continue
+		}
+		lowest := types.Amount(0)
+		for _, tx := range blk.Transactions {
+			fpkb, isFeePayer, err := CalcFeePerKilobyte(tx)
+			if err != nil {
+				return 0, err
+			}
+			if isFeePayer && (lowest == 0 || fpkb < lowest) {
+				lowest = fpkb
+			}
+		}
+		if lowest > 0 {
+			lowestFees = append(lowestFees, lowest)
+		}
+	}
+	if len(lowestFees) > len(recentBlocks)/2 {
+		sort.Slice(lowestFees, func(i, j int) bool {
+			return lowestFees[i] < lowestFees[j]
+		})
+		if median := lowestFees[len(lowestFees)/2]; median > estimate {
+			estimate = median
+		}
+	}
+	return estimate, nil
+}
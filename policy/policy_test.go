@@ -7,6 +7,7 @@ package policy
 import (
 	"github.com/project-illium/ilxd/blockchain"
 	"github.com/project-illium/ilxd/types"
+	"github.com/project-illium/ilxd/types/blocks"
 	"github.com/project-illium/ilxd/types/transactions"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -34,3 +35,54 @@ func TestFeePerKilobyte(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, types.Amount(float64(tx.GetStandardTransaction().Fee)/kbs), fpkb)
 }
+
+func TestEstimateFeePerKilobyte(t *testing.T) {
+	makeTx := func(fee uint64) *transactions.Transaction {
+		return transactions.WrapTransaction(&transactions.StandardTransaction{
+			Nullifiers: [][]byte{make([]byte, 32)},
+			TxoRoot:    make([]byte, 32),
+			Fee:        fee,
+			Proof:      make([]byte, 1000),
+		})
+	}
+	size, err := makeTx(20000).SerializedSize()
+	assert.NoError(t, err)
+
+	// Room for two transactions per block.
+	p, err := NewPolicy(nil, 10, 0, uint32(size*2))
+	assert.NoError(t, err)
+
+	mempoolTxs := []*transactions.Transaction{
+		makeTx(50000),
+		makeTx(40000),
+		makeTx(30000),
+		makeTx(20000),
+	}
+	fpkb := func(tx *transactions.Transaction) types.Amount {
+		f, _, err := CalcFeePerKilobyte(tx)
+		assert.NoError(t, err)
+		return f
+	}
+
+	// The third transaction doesn't fit in the next block.
+	estimate, err := p.EstimateFeePerKilobyte(mempoolTxs, nil, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, fpkb(mempoolTxs[2])+1, estimate)
+
+	// Everything fits in the next two blocks.
+	estimate, err = p.EstimateFeePerKilobyte(mempoolTxs, nil, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, types.Amount(10), estimate)
+
+	// Recent full blocks raise the estimate.
+	fullBlock := &blocks.Block{
+		Header:       &blocks.BlockHeader{},
+		Transactions: []*transactions.Transaction{makeTx(60000), makeTx(70000)},
+	}
+	estimate, err = p.EstimateFeePerKilobyte(mempoolTxs, []*blocks.Block{fullBlock, fullBlock, {Header: &blocks.BlockHeader{}}}, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, fpkb(fullBlock.Transactions[0]), estimate)
+
+	_, err = p.EstimateFeePerKilobyte(mempoolTxs, nil, 0)
+	assert.Error(t, err)
+}
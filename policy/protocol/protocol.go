@@ -22,7 +22,7 @@ import (
 
 const (
 	PolicyProtocol        = "/policy/"
-	PolicyProtocolVersion = "1.0.0"
+	PolicyProtocolVersion = "2.0.0"
 )
 
 // PolicyService is a libp2p network protocol that allows other peers
@@ -40,10 +40,11 @@ func NewPolicyService(ctx context.Context, network *net.Network, params *params.
 		ctx:     ctx,
 		network: network,
 		policy:  policy,
-		ms:      net.NewMessageSender(network.Host(), params.ProtocolPrefix+PolicyProtocol+PolicyProtocolVersion),
+		ms:      net.NewMessageSender(network.Host(), params.ProtocolPrefix+PolicyProtocol+PolicyProtocolVersion, params.ProtocolPrefix+PolicyProtocol+net.LegacyProtocolVersion),
 	}
 
 	ps.network.Host().SetStreamHandler(params.ProtocolPrefix+PolicyProtocol+PolicyProtocolVersion, ps.HandleNewStream)
+	ps.network.Host().SetStreamHandler(params.ProtocolPrefix+PolicyProtocol+net.LegacyProtocolVersion, ps.HandleNewStream)
 	return ps
 }
 
@@ -83,7 +84,7 @@ func (ps *PolicyService) handleNewMessage(s inet.Stream) {
 			return
 		}
 		req := new(wire.MsgPolicyRequest)
-		if err := proto.Unmarshal(msgBytes, req); err != nil {
+		if err := net.UnmarshalStreamMsg(s, msgBytes, req); err != nil {
 			reader.ReleaseMsg(msgBytes)
 			log.Debug("Error unmarshalling policy service message", log.ArgsFromMap(map[string]any{
 				"peer":  remotePeer,
@@ -126,7 +127,7 @@ func (ps *PolicyService) handleNewMessage(s inet.Stream) {
 		}
 
 		if resp != nil {
-			if err := net.WriteMsg(s, resp); err != nil {
+			if err := net.WriteStreamMsg(s, resp); err != nil {
 				log.WithCaller(true).Error("Error writing policy service response", log.ArgsFromMap(map[string]any{
 					"peer":  remotePeer,
 					"error": err,
@@ -0,0 +1,107 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"github.com/project-illium/ilxd/tasks"
+	"github.com/project-illium/ilxd/zk"
+	"github.com/project-illium/ilxd/zk/circparams"
+	"sync"
+	"time"
+)
+
+// provingTimer wraps the node's prover and records how long the standard
+// transaction proofs take per input. Proving time is dominated by the
+// inputs, each of which verifies an inclusion proof and runs its locking
+// script, so this is used to estimate how long a transaction will take
+// to prove before the wallet starts on it.
+type provingTimer struct {
+	prover   zk.Prover
+	perInput time.Duration
+	mtx      sync.RWMutex
+}
+
+func newProvingTimer(prover zk.Prover) *provingTimer {
+	return &provingTimer{prover: prover}
+}
+
+// Prove creates the proof with the wrapped prover and records how long
+// it took.
+func (t *provingTimer) Prove(program string, privateParams zk.Parameters, publicParams zk.Parameters, maxSteps ...uint64) ([]byte, error) {
+	return t.ProveWithProgress(program, privateParams, publicParams, nil, maxSteps...)
+}
+
+// ProveWithProgress is the same as Prove but reports the progress of the
+// proof if the wrapped prover supports it.
+func (t *provingTimer) ProveWithProgress(program string, privateParams zk.Parameters, publicParams zk.Parameters, progress zk.ProgressFunc, maxSteps ...uint64) ([]byte, error) {
+	var (
+		start = time.Now()
+		proof []byte
+		err   error
+	)
+	task := tasks.Start(tasks.KindProving, proofName(privateParams))
+	defer task.Done()
+
+	if pp, ok := t.prover.(zk.ProgressProver); ok {
+		proof, err = pp.ProveWithProgress(program, privateParams, publicParams, func(p zk.ProofProgress) {
+			if p.TotalSteps > 0 {
+				task.SetState("%s step %d of %d", p.Phase, p.StepsCompleted, p.TotalSteps)
+			} else {
+				task.SetState(p.Phase.String())
+			}
+			if progress != nil {
+				progress(p)
+			}
+		}, maxSteps...)
+	} else {
+		proof, err = t.prover.Prove(program, privateParams, publicParams, maxSteps...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if priv, ok := privateParams.(*circparams.StandardPrivateParams); ok && len(priv.Inputs) > 0 {
+		t.observe(time.Since(start) / time.Duration(len(priv.Inputs)))
+	}
+	return proof, nil
+}
+
+// proofName describes the proof being created for the task list.
+func proofName(privateParams zk.Parameters) string {
+	switch privateParams.(type) {
+	case *circparams.StandardPrivateParams:
+		return "standard transaction proof"
+	case *circparams.MintPrivateParams:
+		return "mint transaction proof"
+	case *circparams.StakePrivateParams:
+		return "stake transaction proof"
+	case *circparams.CoinbasePrivateParams:
+		return "coinbase transaction proof"
+	case *circparams.TreasuryPrivateParams:
+		return "treasury transaction proof"
+	default:
+		return "proof"
+	}
+}
+
+func (t *provingTimer) observe(perInput time.Duration) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if t.perInput == 0 {
+		t.perInput = perInput
+		return
+	}
+	// Weight the most recent proof so the estimate follows changes in
+	// the load on the machine.
+	t.perInput = (t.perInput*3 + perInput) / 4
+}
+
+// estimate returns the estimated time to prove a standard transaction
+// with the number of inputs. It returns zero until the first proof has
+// been created.
+func (t *provingTimer) estimate(inputs int) time.Duration {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	return t.perInput * time.Duration(inputs)
+}
@@ -0,0 +1,189 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package recovery
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+const (
+	// MaxRestarts is the maximum number of times a subsystem will be
+	// restarted within the RestartWindow. If a subsystem panics more
+	// often than this the panic is allowed to take down the node.
+	MaxRestarts = 5
+
+	// RestartWindow is the period over which restarts are counted.
+	RestartWindow = time.Minute * 10
+)
+
+var (
+	mtx             sync.Mutex
+	reportDir       string
+	restartsEnabled = true
+	crashes         = make(map[string]uint64)
+	restarts        = make(map[string][]time.Time)
+)
+
+// CrashReport is the structured report that is logged and written
+// to disk when a goroutine panics.
+type CrashReport struct {
+	Subsystem string         `json:"subsystem"`
+	Timestamp time.Time      `json:"timestamp"`
+	Panic     string         `json:"panic"`
+	Stack     string         `json:"stack"`
+	State     map[string]any `json:"state,omitempty"`
+	Restarted bool           `json:"restarted"`
+}
+
+// SetReportDirectory sets the directory where crash reports will be
+// written. If this is not set reports are only logged.
+func SetReportDirectory(dir string) {
+	mtx.Lock()
+	defer mtx.Unlock()
+	reportDir = dir
+}
+
+// SetRestartsEnabled controls whether subsystems which provide a
+// restart function are restarted after a panic. If disabled the
+// panic is re-raised after the crash report is written.
+func SetRestartsEnabled(enabled bool) {
+	mtx.Lock()
+	defer mtx.Unlock()
+	restartsEnabled = enabled
+}
+
+// Crashes returns the number of recovered panics for each subsystem.
+func Crashes() map[string]uint64 {
+	mtx.Lock()
+	defer mtx.Unlock()
+	ret := make(map[string]uint64, len(crashes))
+	for k, v := range crashes {
+		ret[k] = v
+	}
+	return ret
+}
+
+// Option is a configuration option for HandlePanic.
+type Option func(cfg *config)
+
+type config struct {
+	restart func()
+	state   func() map[string]any
+}
+
+// Restart is a function that will be called to restart the subsystem
+// after the panic is recovered.
+func Restart(f func()) Option {
+	return func(cfg *config) {
+		cfg.restart = f
+	}
+}
+
+// State is a function that returns a summary of the subsystem's state
+// to include in the crash report. It is called after the panic so it
+// must not block or acquire locks that may be held by the crashed
+// goroutine.
+func State(f func() map[string]any) Option {
+	return func(cfg *config) {
+		cfg.state = f
+	}
+}
+
+// HandlePanic must be deferred at the top of a goroutine entry point.
+// If the goroutine panics a crash report is logged and written to the
+// report directory, the crash counter for the subsystem is incremented,
+// and the subsystem is restarted if a restart function was provided.
+//
+// Goroutines without a restart function simply exit, so this should
+// only be used where dropping the work in progress (a single stream,
+// for example) leaves the node in a consistent state.
+func HandlePanic(subsystem string, opts ...Option) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	var cfg config
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	report := &CrashReport{
+		Subsystem: subsystem,
+		Timestamp: time.Now(),
+		Panic:     fmt.Sprintf("%v", r),
+		Stack:     string(debug.Stack()),
+	}
+	if cfg.state != nil {
+		func() {
+			// Don't let a bad state function mask the original panic.
+			defer func() { recover() }() //nolint:errcheck
+			report.State = cfg.state()
+		}()
+	}
+
+	mtx.Lock()
+	crashes[subsystem]++
+	report.Restarted = cfg.restart != nil && restartsEnabled && allowRestart(subsystem, report.Timestamp)
+	dir := reportDir
+	mtx.Unlock()
+
+	log.WithCaller(true).Error("Recovered from panic", log.ArgsFromMap(map[string]any{
+		"subsystem": subsystem,
+		"panic":     report.Panic,
+		"state":     report.State,
+		"restart":   report.Restarted,
+	}))
+	log.Debug(report.Stack)
+
+	if dir != "" {
+		if err := writeReport(dir, report); err != nil {
+			log.WithCaller(true).Error("Error writing crash report", log.Args("error", err))
+		}
+	}
+
+	if cfg.restart == nil {
+		return
+	}
+	if !report.Restarted {
+		panic(r)
+	}
+	cfg.restart()
+}
+
+// allowRestart records a restart for the subsystem and returns whether
+// it is within the restart limit. The mutex must be held.
+func allowRestart(subsystem string, now time.Time) bool {
+	recent := restarts[subsystem][:0]
+	for _, t := range restarts[subsystem] {
+		if now.Sub(t) < RestartWindow {
+			recent = append(recent, t)
+		}
+	}
+	if len(recent) >= MaxRestarts {
+		restarts[subsystem] = recent
+		return false
+	}
+	restarts[subsystem] = append(recent, now)
+	return true
+}
+
+func writeReport(dir string, report *CrashReport) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	ser, err := json.MarshalIndent(report, "", "    ")
+	if err != nil {
+		return err
+	}
+	filename := fmt.Sprintf("%s-%d.json", report.Subsystem, report.Timestamp.UnixNano())
+	return os.WriteFile(path.Join(dir, filename), ser, 0600)
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package recovery
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestHandlePanic(t *testing.T) {
+	dir := path.Join(t.TempDir(), "crash_reports")
+	SetReportDirectory(dir)
+	defer SetReportDirectory("")
+
+	restarted := make(chan struct{})
+	func() {
+		defer HandlePanic("test",
+			State(func() map[string]any { return map[string]any{"height": 5} }),
+			Restart(func() { close(restarted) }),
+		)
+		panic("boom")
+	}()
+
+	<-restarted
+	assert.Equal(t, uint64(1), Crashes()["test"])
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	b, err := os.ReadFile(path.Join(dir, files[0].Name()))
+	require.NoError(t, err)
+	var report CrashReport
+	require.NoError(t, json.Unmarshal(b, &report))
+	assert.Equal(t, "test", report.Subsystem)
+	assert.Equal(t, "boom", report.Panic)
+	assert.Equal(t, float64(5), report.State["height"])
+	assert.True(t, report.Restarted)
+	assert.NotEmpty(t, report.Stack)
+}
+
+func TestHandlePanicRestartLimit(t *testing.T) {
+	for i := 0; i < MaxRestarts; i++ {
+		func() {
+			defer HandlePanic("limit", Restart(func() {}))
+			panic("boom")
+		}()
+	}
+
+	assert.Panics(t, func() {
+		defer HandlePanic("limit", Restart(func() {}))
+		panic("boom")
+	})
+	assert.Equal(t, uint64(MaxRestarts+1), Crashes()["limit"])
+
+	SetRestartsEnabled(false)
+	defer SetRestartsEnabled(true)
+	assert.Panics(t, func() {
+		defer HandlePanic("disabled", Restart(func() {}))
+		panic("boom")
+	})
+}
@@ -52,15 +52,23 @@ type Config struct {
 	DataDir            string        `short:"d" long:"datadir" description:"Directory to store data"`
 	LogDir             string        `long:"logdir" description:"Directory to log output"`
 	WalletDir          string        `long:"walletdir" description:"Directory to store wallet data"`
+	KeyBackupDir       string        `long:"keybackupdir" description:"Directory to write an encrypted backup of the wallet's private keys to before they are deleted with DeletePrivateKeys"`
+	WalletUnlockFile   string        `long:"walletunlockfile" description:"Unlock the wallet on startup with the passphrase in this file. The file is created with ilxcli createwalletunlockfile and is encrypted with the key printed by walletunlockkeycmd."`
+	WalletUnlockKeyCmd string        `long:"walletunlockkeycmd" description:"A command which prints the key the walletunlockfile is encrypted with, such as a lookup in the OS keyring or a KMS decrypt, so the key is not stored on disk."`
+	WalletRelock       time.Duration `long:"walletrelock" description:"Lock the wallet again this long after it is unlocked on startup with walletunlockfile. If zero the wallet stays unlocked until walletlock is called."`
 	LogLevel           string        `short:"l" long:"loglevel" description:"Set the logging level [trace, debug, info, warning, error, fatal]." default:"info"`
 	EnableDebugLogging bool          `long:"debug" description:"Enable libp2p debug logging to the terminal"`
 	SeedAddrs          []string      `long:"seedaddr" description:"Override the default seed addresses with the provided values"`
+	AnchorPeers        []string      `long:"anchorpeer" description:"The address of a trusted peer to always keep an outbound connection to. Anchor peers are redialed if the connection drops and are preferred for block download. The address must include the peer ID. This option can be used more than once."`
+	AllowPeers         []string      `long:"allowpeer" description:"Only accept inbound connections from this peer ID, IP address, or IP range in CIDR notation. If unset inbound connections are accepted from any peer. This option can be used more than once. Rules added with the updateaccesslist RPC are saved to the database."`
+	DenyPeers          []string      `long:"denypeer" description:"Refuse connections to and from this peer ID, IP address, or IP range in CIDR notation. This takes precedence over allowpeer. This option can be used more than once."`
 	ListenAddrs        []string      `long:"listenaddr" description:"Override the default listen addresses with the provided values"`
 	Testnet            bool          `short:"t" long:"testnet" description:"Use the test network"`
 	Alphanet           bool          `long:"alpha" description:"Use the alpha network"`
 	Regtest            bool          `short:"r" long:"regtest" description:"Use regression testing mode"`
 	RegtestVal         bool          `long:"regtestval" description:"Set self as the regtest genesis validator. This can only be done on first startup."`
 	DisableNATPortMap  bool          `long:"noupnp" description:"Disable use of upnp"`
+	BlocksOnly         bool          `long:"blocksonly" description:"Relay and validate blocks but not unconfirmed transactions. Peers are told not to send this node transactions which reduces bandwidth for archival and monitoring nodes. The node can still broadcast transactions submitted over RPC. This should not be used by validators as their mempool will be empty."`
 	UserAgent          string        `long:"useragent" description:"A custom user agent to advertise to the network"`
 	NoTxIndex          bool          `long:"notxindex" description:"Disable the transaction index"`
 	DropTxIndex        bool          `long:"droptxindex" description:"Delete the tx index from the database"`
@@ -68,18 +76,40 @@ type Config struct {
 	DropWSIndex        bool          `long:"dropwsindex" description:"Delete the wallet server index from the database"`
 	AddrIndex          bool          `long:"addrindex" description:"Enable the address index"`
 	DropAddrIndex      bool          `long:"dropaddrindex" description:"Delete the address index from the database"`
+	NoFeeIndex         bool          `long:"nofeeindex" description:"Disable the fee index which records the fee rates paid in each block. The index is not used on pruned nodes."`
+	DropFeeIndex       bool          `long:"dropfeeindex" description:"Delete the fee index from the database"`
+	BlockFilterIndex   bool          `long:"blockfilterindex" description:"Enable the block filter index which stores a compact filter of the output commitments and nullifiers in each block. The filters are served over RPC and the p2p network so light wallets can sync without downloading full blocks. The index is not used on pruned nodes."`
+	DropFilterIndex    bool          `long:"dropblockfilterindex" description:"Delete the block filter index from the database"`
+	ScriptIndex        bool          `long:"scriptindex" description:"Enable the script index which indexes the notes published unencrypted, such as those sent to public addresses, by script hash"`
+	DropScriptIndex    bool          `long:"dropscriptindex" description:"Delete the script index from the database"`
 	MaxBanscore        uint32        `long:"maxbanscore" description:"The maximum ban score a peer is allowed to have before getting banned" default:"100"`
 	BanDuration        time.Duration `long:"banduration" description:"The duration for which banned peers are banned for" default:"24h"`
 	WalletSeed         string        `long:"walletseed" description:"A mnemonic seed to initialize the node with. This can only be used on first startup."`
 	CoinbaseAddress    string        `long:"coinbaseaddr" description:"An optional address to send all coinbase rewards to. If this option is not used the wallet will automatically select an internal address."`
+	InvoiceAddresses   []string      `long:"invoiceaddr" description:"Serve invoices for this wallet address over the network. Payers who know the address can request a fresh one-time address and payment terms from this node. The address is advertised in the DHT which links it to this node's peer ID. Use this option more than once to serve more than one address."`
+	DustChange         []string      `long:"dustchange" description:"Wallet change below this amount is added to the fee, or sent to the donationaddr, instead of creating an output that costs more to spend than it is worth. Formatted as <amount> for illium coins or <assetID>:<amount> for other assets. Use this option more than once to set thresholds for more than one asset."`
+	DonationAddress    string        `long:"donationaddr" description:"An optional address to send wallet change below the dustchange threshold to instead of adding it to the fee."`
+	ChangeAddress      string        `long:"changeaddress" description:"How the wallet picks the address for change outputs. 'new' uses a fresh wallet address for each transaction, 'fixed' sends all change to a single address used only for change, and 'reuse' sends change to the wallet's current address." default:"new"`
 	NetworkKey         string        `long:"networkkey" description:"A network key to use for this node. This will override the node's peer ID."`
 	Prune              bool          `long:"prune" description:"Delete the blockchain from disk. The node will store just the date needed to validate new blocks."`
-	MockProofs         bool          `long:"mock" description:"Set the node to use mock proofs instead of full proofs. This option is only available for regtest."`
+	ForkAlertDepth     uint32        `long:"forkalertdepth" description:"Log a warning and send a fork alert to subscribers when a competing branch extends more than this many blocks past the point where it forks from the main chain" default:"1"`
+	StallMultiple      uint32        `long:"stallmultiple" description:"If no block finalizes for this many multiples of the block generation interval (1s) the node reports degraded health and attempts to recover by polling more validators and reconnecting to unresponsive ones. Set to zero to disable." default:"300"`
+	StallWebhooks      []string      `long:"stallwebhook" description:"A URL to POST a JSON alert to when the node detects a consensus stall and when it recovers. Use this option more than once to alert more than one URL."`
+	MockProofs         bool          `long:"mockproofs" description:"Set the node to use mock proofs instead of full proofs. Proofs are accepted without being verified and the wallet creates them in seconds, which is useful for integration testing. This option is only available for regtest."`
+	LegacyMockProofs   bool          `long:"mock" hidden:"true" description:"Deprecated alias for mockproofs"`
+	ProofCacheSize     int           `long:"proofcachesize" description:"The number of proofs created by the wallet to keep on disk. Proving the same transaction again, such as after an RPC timeout, returns the cached proof instead of creating a new one. Set to zero to disable." default:"100"`
+	NoCrashRestart     bool          `long:"nocrashrestart" description:"By default subsystems that panic are restarted after writing a crash report. This option disables the restart and lets the panic shut down the node."`
+	RestartBackoff     time.Duration `long:"restartbackoff" description:"The time to wait before restarting a subsystem that fails its health check. This doubles after each consecutive failure." default:"1m"`
+	MaxRestartBackoff  time.Duration `long:"maxrestartbackoff" description:"The maximum time to wait between subsystem restarts" default:"30m"`
 	Checkpoint         string        `long:"checkpoint" description:"Set a custom block checkpoint. Proof validation will be skipped up to this block. Formatted as a json string {'blockID': 'hex', 'height': uint32}"`
-
-	Policy     Policy     `group:"Policy"`
-	RPCOpts    RPCOptions `group:"RPC Options"`
-	TorOptions TorOptions `group:"Tor Options"`
+	RecordConsensus    string        `long:"recordconsensus" description:"Record the consensus engine's avalanche polls and responses to this file. This is used to debug finalization problems. The file can be replayed offline with ilxcli replayconsensus."`
+	Plugins            []string      `long:"plugin" description:"A path to a plugin file built with -buildmode=plugin to load on startup. The plugin must be built with the same version of Go and ilxd as the node. Use this option more than once to load more than one plugin."`
+
+	Policy     Policy           `group:"Policy"`
+	RPCOpts    RPCOptions       `group:"RPC Options"`
+	TorOptions TorOptions       `group:"Tor Options"`
+	Tracing    TracingOptions   `group:"Tracing Options"`
+	Avalanche  AvalancheOptions `group:"Avalanche Options"`
 }
 
 type Policy struct {
@@ -96,10 +126,16 @@ type RPCOptions struct {
 	ExternalIPs                []string `long:"externalip" description:"This option should be used to specify the external IP address if using the auto-generated SSL certificate."`
 	GrpcListener               string   `long:"grpclisten" description:"Add an interface/port to listen for experimental gRPC connections in multiaddr format (default:/ip4/127.0.0.1/tcp/5001)"`
 	GrpcAuthToken              string   `long:"grpcauthtoken" description:"Set a token here if you want to enable client authentication with gRPC."`
+	GrpcPublicReadOnly         bool     `long:"grpcpublicreadonly" description:"Allow clients without the grpcauthtoken to call a restricted set of read only blockchain service methods, such as the chain tip, block and transaction lookups, and fee estimates. All other methods still require the token. This is intended for nodes serving public infrastructure such as block explorers."`
 	DisableNodeService         bool     `long:"disablenodeservice" description:"Disable the node RPC service. This option should be used if running a public blockchain or wallet server."`
 	DisableWalletService       bool     `long:"disablewalletservice" description:"Disable the wallet RPC service. This option should be used if running a public blockchain or wallet server."`
 	DisableWalletServerService bool     `long:"disablewalletserverservice" description:"Disable the wallet server RPC service. This will automatically be disable if wsindex is disabled."`
+	WalletReadOnly             bool     `long:"walletreadonly" description:"Run the wallet service as a read replica. Only balance, history, and other read methods are served along with ImportAddresses, so a node that has imported another wallet with exportwatchonly can serve its queries without being able to spend."`
 	EnableProverService        bool     `long:"enableproverservice" description:"Enable the prover RPC service. This is not turned on by default."`
+	GrpcMaxRecvMsgSize         int      `long:"grpcmaxrecvmsgsize" description:"The maximum size in bytes of a gRPC request the server will accept" default:"4194304"`
+	GrpcMaxSendMsgSize         int      `long:"grpcmaxsendmsgsize" description:"The maximum size in bytes of a gRPC response the server will send. Large wallets may need this raised to fetch their full transaction history." default:"16777216"`
+	EnableProfiling            bool     `long:"enableprofiling" description:"Serve the pprof and trace endpoints at /debug/pprof on the gRPC listener. Requests must include the grpcauthtoken in the AuthenticationToken header."`
+	EnableMetrics              bool     `long:"enablemetrics" description:"Serve prometheus metrics, including the consensus engine's state, at /metrics on the gRPC listener. If grpcauthtoken is set requests must include it in the AuthenticationToken header."`
 }
 
 type TorOptions struct {
@@ -108,6 +144,22 @@ type TorOptions struct {
 	DualStack     bool   `long:"tordualstack" description:"This option tells ilxd to accept connections over Tor AND over the clear internet. Clear TCP connections will be prioritized. This mode is NOT private."`
 }
 
+type TracingOptions struct {
+	Endpoint   string  `long:"tracingendpoint" description:"The host:port of an OTLP gRPC collector to export trace spans to. Tracing is disabled if this is not set."`
+	SampleRate float64 `long:"tracingsamplerate" description:"The fraction of traces to sample between 0 and 1" default:"1"`
+	Insecure   bool    `long:"tracinginsecure" description:"Connect to the tracing collector without TLS"`
+}
+
+// AvalancheOptions override the network's avalanche consensus params. A
+// zero value leaves the network's default in place. Overrides are rejected
+// on mainnet.
+type AvalancheOptions struct {
+	FinalizationScore uint16        `long:"finalizationscore" description:"The confidence score at which a block is considered final. Lower values finalize faster but with less certainty. Must be at most 32767."`
+	MaxInflightPoll   int           `long:"maxinflightpoll" description:"The max outstanding polls for any block"`
+	RequestTimeout    time.Duration `long:"pollrequesttimeout" description:"The amount of time to wait for a response to a poll"`
+	PollInterval      time.Duration `long:"pollinterval" description:"The amount of time to wait between polling rounds"`
+}
+
 // LoadConfig initializes and parses the config using a config file and command
 // line options.
 //
@@ -195,6 +247,33 @@ func LoadConfig() (*Config, error) {
 	if cfg.Alphanet && cfg.Regtest {
 		return nil, errors.New("invalid combination of alphanet and regtest")
 	}
+	if cfg.WalletUnlockFile != "" && cfg.WalletUnlockKeyCmd == "" {
+		return nil, errors.New("walletunlockfile requires walletunlockkeycmd")
+	}
+	if cfg.LegacyMockProofs {
+		cfg.MockProofs = true
+	}
+	if cfg.MockProofs && !cfg.Regtest {
+		return nil, errors.New("mockproofs is only available in regtest mode")
+	}
+	if cfg.RPCOpts.EnableProfiling && cfg.RPCOpts.GrpcAuthToken == "" {
+		return nil, errors.New("enableprofiling requires a grpcauthtoken")
+	}
+	if cfg.RPCOpts.GrpcPublicReadOnly && cfg.RPCOpts.GrpcAuthToken == "" {
+		return nil, errors.New("grpcpublicreadonly requires a grpcauthtoken")
+	}
+	for _, addr := range cfg.AnchorPeers {
+		ma, err := multiaddr.NewMultiaddr(addr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid anchorpeer %s: %w", addr, err)
+		}
+		if _, err := ma.ValueForProtocol(multiaddr.P_P2P); err != nil {
+			return nil, fmt.Errorf("anchorpeer %s is missing the peer ID", addr)
+		}
+	}
+	if cfg.Tracing.SampleRate < 0 || cfg.Tracing.SampleRate > 1 {
+		return nil, errors.New("tracingsamplerate must be between 0 and 1")
+	}
 
 	netStr := "mainnet"
 	if cfg.Testnet {
@@ -208,6 +287,11 @@ func LoadConfig() (*Config, error) {
 	if cfg.LogDir == "" {
 		cfg.LogDir = CleanAndExpandPath(path.Join(cfg.DataDir, "logs", netStr))
 	}
+	if cfg.KeyBackupDir == "" {
+		cfg.KeyBackupDir = CleanAndExpandPath(path.Join(cfg.DataDir, "keybackups", netStr))
+	} else {
+		cfg.KeyBackupDir = CleanAndExpandPath(cfg.KeyBackupDir)
+	}
 	if cfg.WalletDir == "" {
 		cfg.WalletDir = CleanAndExpandPath(path.Join(cfg.DataDir, "wallet", netStr))
 		if _, err := os.Stat(cfg.WalletDir); os.IsNotExist(err) {
@@ -39,10 +39,14 @@ const (
 	AccumulatorStateKey = "/ilxd/accumulator/"
 	// AccumulatorCheckpointKey is the datastore key for storing accumulator checkpoints.
 	AccumulatorCheckpointKey = "/ilxd/accumulatorcheckpoint/"
+	// EpochSnapshotKeyPrefix is the datastore key prefix for storing the chain state commitment taken at each epoch boundary.
+	EpochSnapshotKeyPrefix = "/ilxd/epochsnapshot/"
 	// CoinSupplyKey is the datastore key for storing the current supply of coins.
 	CoinSupplyKey = "/ilxd/coinsupply/"
 	// IndexerHeightKeyPrefix is the datastore key prefix for mapping indexers to sync heights.
 	IndexerHeightKeyPrefix = "/ilxd/indexerheight/"
+	// ExternalIndexerHeightKeyPrefix is the datastore key prefix for the checkpoints of external indexers.
+	ExternalIndexerHeightKeyPrefix = "/ilxd/externalindexerheight/"
 	// IndexKeyPrefix is the datastore key used by each indexer. This must be extended to use.
 	IndexKeyPrefix = "/ilxd/index/"
 	// ConnGaterKeyPrefix is the datastore namespace key used by the conngater.
@@ -55,6 +59,32 @@ const (
 	CachedAddrInfoDatastoreKey = "/ilxd/peerstore/addrinfo/"
 	// TreasuryWhitelistDatastoreKeyPrefix is the datastore key prefix for the treasury whitelist.
 	TreasuryWhitelistDatastoreKeyPrefix = "/ilxd/whitelist/"
+	// SpendTemplateDatastoreKeyPrefix is the datastore key prefix for the wallet's saved spend templates.
+	SpendTemplateDatastoreKeyPrefix = "/ilxd/spendtemplate/"
+	// InvoiceDatastoreKeyPrefix is the datastore key prefix for the wallet's merchant invoices.
+	InvoiceDatastoreKeyPrefix = "/ilxd/invoice/"
+	// CustomerIDDatastoreKeyPrefix is the datastore key prefix for customer identifiers keyed by wallet address.
+	CustomerIDDatastoreKeyPrefix = "/ilxd/customerid/"
+	// AddressLabelDatastoreKeyPrefix is the datastore key prefix for address labels keyed by wallet address.
+	AddressLabelDatastoreKeyPrefix = "/ilxd/addresslabel/"
+	// ChangeAddressDatastoreKeyPrefix is the datastore key prefix recording whether each wallet address is used for change.
+	ChangeAddressDatastoreKeyPrefix = "/ilxd/changeaddress/"
+	// FixedChangeAddressDatastoreKey is the datastore key for the address the fixed change address policy sends change to.
+	FixedChangeAddressDatastoreKey = "/ilxd/fixedchangeaddress/"
+	// EvidenceDatastoreKeyPrefix is the datastore key prefix for equivocation evidence keyed by producer ID.
+	EvidenceDatastoreKeyPrefix = "/ilxd/evidence/"
+	// SweepJobDatastoreKeyPrefix is the datastore key prefix for the wallet's sweep jobs keyed by job ID.
+	SweepJobDatastoreKeyPrefix = "/ilxd/sweepjob/"
+	// AtomicSwapDatastoreKeyPrefix is the datastore key prefix for the wallet's atomic swaps keyed by swap ID.
+	AtomicSwapDatastoreKeyPrefix = "/ilxd/atomicswap/"
+	// TreasuryProposalDatastoreKeyPrefix is the datastore key prefix for treasury proposals keyed by transaction ID.
+	TreasuryProposalDatastoreKeyPrefix = "/ilxd/treasuryproposal/"
+	// ViewPassphraseDatastoreKey is the datastore key used to store the check value for the wallet's view passphrase.
+	ViewPassphraseDatastoreKey = "/ilxd/viewpassphrase/"
+	// AddressPoolDatastoreKey is the datastore key used to store the addresses generated ahead for the view unlock tier.
+	AddressPoolDatastoreKey = "/ilxd/addresspool/"
+	// ContactDatastoreKeyPrefix is the datastore key prefix for the wallet's address book keyed by contact name.
+	ContactDatastoreKeyPrefix = "/ilxd/contact/"
 
 	// TxIndexKey is the datastore key for the transaction index.
 	TxIndexKey = "txindex"
@@ -62,6 +92,12 @@ const (
 	WalletServerIndexKey = "walletserverindex"
 	// AddrIndexKey is the datastore key for the address index.
 	AddrIndexKey = "addrindex"
+	// FeeIndexKey is the datastore key for the fee index.
+	FeeIndexKey = "feeindex"
+	// BlockFilterIndexKey is the datastore key for the block filter index.
+	BlockFilterIndexKey = "blockfilterindex"
+	// ScriptIndexKey is the datastore key for the script index.
+	ScriptIndexKey = "scriptindex"
 	// WalletServerAccumulatorKey is the accumulator key used by the wallet server index.
 	WalletServerAccumulatorKey = "accumulator"
 	// WalletServerBestBlockKey is the best block key used by the wallet server index.
@@ -74,6 +110,8 @@ const (
 	WalletServerNullifierKeyPrefix = "nullifier/"
 	// WalletServerTxKeyPrefix is the tx key prefix used by the wallet server index.
 	WalletServerTxKeyPrefix = "tx/"
+	// WalletServerRescanPrefix is the rescan checkpoint prefix used by the wallet server index.
+	WalletServerRescanPrefix = "rescan/"
 	// AddrIndexAddrKeyPrefix is the address key prefix used by the address index.
 	AddrIndexAddrKeyPrefix = "addr/"
 	// AddrIndexNulliferKeyPrefix is the nullifier key prefix used by the address index.
@@ -84,6 +122,10 @@ const (
 	AddrIndexMetadataPrefixKey = "metadata/"
 	// AddrIndexValidatorTxPrefixKey is the validator transaction datastore key prefix used by the address index.
 	AddrIndexValidatorTxPrefixKey = "validatortx/"
+	// ScriptIndexScriptKeyPrefix is the script hash key prefix used by the script index.
+	ScriptIndexScriptKeyPrefix = "script/"
+	// ScriptIndexOutputIndexKey is the outputs datastore key used by the script index.
+	ScriptIndexOutputIndexKey = "outputs/"
 )
 
 type Datastore interface {
@@ -0,0 +1,76 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	icrypto "github.com/project-illium/ilxd/crypto"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// CreateWalletUnlockFile encrypts the wallet passphrase with the key
+// printed by the key command and writes it to the file. The node can
+// then unlock the wallet on startup without the passphrase appearing
+// in the config.
+func CreateWalletUnlockFile(path, passphrase, keyCmd string) error {
+	if passphrase == "" {
+		return errors.New("passphrase is empty")
+	}
+	key, err := runKeyCommand(keyCmd)
+	if err != nil {
+		return err
+	}
+	ciphertext, err := icrypto.EncryptWithPassphrase([]byte(passphrase), key)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(CleanAndExpandPath(path), ciphertext, 0600)
+}
+
+// LoadWalletPassphrase decrypts the wallet passphrase in a file created
+// by CreateWalletUnlockFile using the key printed by the key command.
+func LoadWalletPassphrase(path, keyCmd string) (string, error) {
+	ciphertext, err := os.ReadFile(CleanAndExpandPath(path))
+	if err != nil {
+		return "", err
+	}
+	key, err := runKeyCommand(keyCmd)
+	if err != nil {
+		return "", err
+	}
+	passphrase, err := icrypto.DecryptWithPassphrase(ciphertext, key)
+	if err != nil {
+		return "", fmt.Errorf("error decrypting wallet unlock file: %w", err)
+	}
+	return string(passphrase), nil
+}
+
+// runKeyCommand runs the key command and returns what it prints to
+// stdout with the trailing whitespace removed. The command is expected
+// to fetch the key from the OS keyring or a KMS, for example
+// `secret-tool lookup service ilxd` or `security find-generic-password
+// -s ilxd -w`, so the key is never stored on disk next to the file.
+func runKeyCommand(keyCmd string) (string, error) {
+	fields := strings.Fields(keyCmd)
+	if len(fields) == 0 {
+		return "", errors.New("key command is empty")
+	}
+	cmd := exec.Command(fields[0], fields[1:]...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("key command failed: %s: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	key := strings.TrimRight(string(out), "\r\n")
+	if key == "" {
+		return "", errors.New("key command returned an empty key")
+	}
+	return key, nil
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestWalletUnlockFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "unlock")
+
+	if err := CreateWalletUnlockFile(file, "letmein", "echo secretkey"); err != nil {
+		t.Fatalf("Failed to create wallet unlock file: %v", err)
+	}
+	passphrase, err := LoadWalletPassphrase(file, "echo secretkey")
+	if err != nil {
+		t.Fatalf("Failed to load wallet passphrase: %v", err)
+	}
+	if passphrase != "letmein" {
+		t.Errorf("Expected passphrase letmein, got %s", passphrase)
+	}
+
+	if _, err := LoadWalletPassphrase(file, "echo wrongkey"); err == nil {
+		t.Error("Expected error decrypting with the wrong key")
+	}
+	if _, err := LoadWalletPassphrase(file, "false"); err == nil {
+		t.Error("Expected error when the key command fails")
+	}
+	if _, err := LoadWalletPassphrase(file, ""); err == nil {
+		t.Error("Expected error with an empty key command")
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"errors"
+	"fmt"
+	"github.com/project-illium/ilxd/params"
+	"github.com/project-illium/walletlib"
+	"strings"
+)
+
+// decodeAddress decodes an address for the given network. In addition to
+// the network's current address prefix, addresses using any of the legacy
+// prefixes are accepted so that addresses created before a format change
+// continue to work. The returned address always encodes using the current
+// prefix.
+func decodeAddress(addr string, chainParams *params.NetworkParams) (walletlib.Address, error) {
+	sep := strings.LastIndex(addr, "1")
+	if sep < 1 {
+		return nil, errors.New("invalid address encoding")
+	}
+	hrp := strings.ToLower(addr[:sep])
+
+	valid := hrp == chainParams.AddressPrefix
+	for _, prefix := range chainParams.LegacyAddressPrefixes {
+		if hrp == prefix {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return nil, fmt.Errorf("address prefix %s is not valid for %s", hrp, chainParams.Name)
+	}
+	return walletlib.DecodeAddress(addr, chainParams)
+}
+
+// convertAddress decodes the address using the compatibility decoder and
+// re-encodes it with the provided prefix. If the prefix is empty the
+// network's current address prefix is used.
+func convertAddress(addr string, chainParams *params.NetworkParams, prefix string) (string, error) {
+	decoded, err := decodeAddress(addr, chainParams)
+	if err != nil {
+		return "", err
+	}
+	if prefix == "" || prefix == chainParams.AddressPrefix {
+		return decoded.String(), nil
+	}
+	target := *chainParams
+	target.AddressPrefix = prefix
+	converted, err := walletlib.DecodeAddress(decoded.String(), &target)
+	if err != nil {
+		return "", err
+	}
+	ret := converted.String()
+	if ret == "" {
+		return "", errors.New("error encoding address")
+	}
+	return ret, nil
+}
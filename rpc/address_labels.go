@@ -0,0 +1,84 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"github.com/project-illium/ilxd/repo"
+	"github.com/project-illium/ilxd/rpc/pb"
+	"sort"
+	"strings"
+)
+
+// maxAddressLabelLen is the maximum length of an address label in bytes.
+const maxAddressLabelLen = 256
+
+func putAddressLabel(ds repo.Datastore, addr string, label string) error {
+	if len(label) > maxAddressLabelLen {
+		return errors.New("label too long")
+	}
+	key := datastore.NewKey(repo.AddressLabelDatastoreKeyPrefix + addr)
+	if label == "" {
+		return ds.Delete(context.Background(), key)
+	}
+	return ds.Put(context.Background(), key, []byte(label))
+}
+
+// getAddressLabel returns the label associated with the address or an
+// empty string if there is none.
+func getAddressLabel(ds repo.Datastore, addr string) (string, error) {
+	b, err := ds.Get(context.Background(), datastore.NewKey(repo.AddressLabelDatastoreKeyPrefix+addr))
+	if errors.Is(err, datastore.ErrNotFound) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// getAddressesByLabel returns the addresses with the label sorted so
+// that the result does not depend on the database's iteration order.
+func getAddressesByLabel(ds repo.Datastore, label string) ([]string, error) {
+	results, err := ds.Query(context.Background(), query.Query{
+		Prefix: repo.AddressLabelDatastoreKeyPrefix,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer results.Close()
+
+	var addrs []string
+	for result := range results.Next() {
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if string(result.Value) != label {
+			continue
+		}
+		addrs = append(addrs, strings.TrimPrefix(result.Key, repo.AddressLabelDatastoreKeyPrefix))
+	}
+	sort.Strings(addrs)
+	return addrs, nil
+}
+
+// setAddressLabels fills in the label for each of the inputs and
+// outputs whose address has one.
+func setAddressLabels(ds repo.Datastore, ios []*pb.IOMetadata) error {
+	for _, io := range ios {
+		txio := io.GetTxIo()
+		if txio == nil {
+			continue
+		}
+		label, err := getAddressLabel(ds, txio.Address)
+		if err != nil {
+			return err
+		}
+		txio.Label = label
+	}
+	return nil
+}
@@ -0,0 +1,535 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/project-illium/ilxd/blockchain"
+	icrypto "github.com/project-illium/ilxd/crypto"
+	"github.com/project-illium/ilxd/repo"
+	"github.com/project-illium/ilxd/rpc/pb"
+	"github.com/project-illium/ilxd/types"
+	"github.com/project-illium/ilxd/types/blocks"
+	"github.com/project-illium/ilxd/types/transactions"
+	"github.com/project-illium/ilxd/zk"
+	"github.com/project-illium/ilxd/zk/circparams"
+	"github.com/project-illium/walletlib"
+	walletpb "github.com/project-illium/walletlib/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultSwapInitiatorRefundDuration is how long after the swap is
+	// initiated that the initiator can refund the contract if no refund
+	// time is given.
+	DefaultSwapInitiatorRefundDuration = time.Hour * 48
+
+	// DefaultSwapParticipantRefundDuration is how long after the swap is
+	// joined that the participant can refund the contract if no refund
+	// time is given. It's half the initiator's so that the participant has
+	// time to redeem the initiator's contract after the secret is published.
+	DefaultSwapParticipantRefundDuration = time.Hour * 24
+
+	// atomicSwapTemplate is the name of the script template used for the
+	// swap contracts.
+	atomicSwapTemplate = "atomicswap"
+)
+
+var errAtomicSwapNotFound = errors.New("atomic swap not found")
+
+// atomicSwapManager saves the wallet's atomic swaps and watches for the
+// counterparty to redeem the contracts funded by the wallet.
+//
+// A swap contract can only be claimed by publishing the secret in the
+// ciphertext of one of the claiming transaction's outputs. Rather than
+// linking transactions to the contract, the ciphertext of each output is
+// checked against the secret hashes of the open swaps. Transactions are
+// checked as they enter the mempool and again when they are included in
+// a block so the secret is found as early as possible. Blocks connected
+// while the node was offline are scanned when it starts.
+type atomicSwapManager struct {
+	ds      repo.Datastore
+	watches map[types.ID][]byte
+	mtx     sync.Mutex
+}
+
+// newAtomicSwapManager returns a new atomicSwapManager watching the open
+// swaps in the datastore.
+func newAtomicSwapManager(ds repo.Datastore, chain *blockchain.Blockchain, quit chan struct{}) (*atomicSwapManager, error) {
+	m := &atomicSwapManager{
+		ds:      ds,
+		watches: make(map[types.ID][]byte),
+	}
+	swaps, err := m.getSwaps()
+	if err != nil {
+		return nil, err
+	}
+	var scanFrom uint32
+	for _, swap := range swaps {
+		if m.watch(swap) && (scanFrom == 0 || swap.WatchHeight < scanFrom) {
+			scanFrom = swap.WatchHeight
+		}
+	}
+	if len(m.watches) > 0 {
+		go func() {
+			_, bestHeight, _ := chain.BestBlock()
+			for height := scanFrom + 1; height <= bestHeight; height++ {
+				select {
+				case <-quit:
+					return
+				default:
+				}
+				blk, err := chain.GetBlockByHeight(height)
+				if err != nil {
+					return
+				}
+				m.ProcessBlock(blk)
+			}
+		}()
+	}
+	return m, nil
+}
+
+// AddSwap saves a new swap and starts watching for the secret if the
+// contract was funded by this wallet.
+func (m *atomicSwapManager) AddSwap(swap *pb.AtomicSwap) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if err := m.putSwap(swap); err != nil {
+		return err
+	}
+	m.watch(swap)
+	return nil
+}
+
+// GetSwap returns the swap with the ID.
+func (m *atomicSwapManager) GetSwap(id types.ID) (*pb.AtomicSwap, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.getSwap(id)
+}
+
+// GetSwaps returns all the swaps sorted by creation time.
+func (m *atomicSwapManager) GetSwaps() ([]*pb.AtomicSwap, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.getSwaps()
+}
+
+// SetRefunded records the refund of the swap's contract.
+func (m *atomicSwapManager) SetRefunded(id types.ID, txid types.ID) (*pb.AtomicSwap, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	swap, err := m.getSwap(id)
+	if err != nil {
+		return nil, err
+	}
+	swap.Status = pb.AtomicSwap_REFUNDED
+	swap.RefundTransaction_ID = txid[:]
+	delete(m.watches, id)
+	return swap, m.putSwap(swap)
+}
+
+// ProcessTransaction checks whether the transaction publishes the secret
+// for any of the open swaps.
+func (m *atomicSwapManager) ProcessTransaction(tx *transactions.Transaction) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.processTransaction(tx)
+}
+
+// ProcessBlock checks whether any of the transactions in the block
+// publish the secret for any of the open swaps.
+func (m *atomicSwapManager) ProcessBlock(blk *blocks.Block) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	for _, tx := range blk.Transactions {
+		m.processTransaction(tx)
+	}
+}
+
+// processTransaction must be called with the mutex held.
+func (m *atomicSwapManager) processTransaction(tx *transactions.Transaction) {
+	standardTx := tx.GetStandardTransaction()
+	if standardTx == nil || len(m.watches) == 0 {
+		return
+	}
+	for _, out := range standardTx.Outputs {
+		for id, hash := range m.watches {
+			secret, ok := matchSwapSecret(out.Ciphertext, hash)
+			if !ok {
+				continue
+			}
+			swap, err := m.getSwap(id)
+			if err != nil {
+				continue
+			}
+			txid := tx.ID()
+			swap.Secret = secret
+			swap.Status = pb.AtomicSwap_REDEEMED
+			swap.RedeemTransaction_ID = txid[:]
+			if err := m.putSwap(swap); err != nil {
+				continue
+			}
+			delete(m.watches, id)
+		}
+	}
+}
+
+// watch adds the swap to the watches if its contract was funded by this
+// wallet and has not been redeemed or refunded. It returns whether the
+// swap is being watched.
+//
+// The mutex must be held when calling this.
+func (m *atomicSwapManager) watch(swap *pb.AtomicSwap) bool {
+	if swap.Role == pb.AtomicSwap_REDEEMER || swap.Status != pb.AtomicSwap_FUNDED {
+		return false
+	}
+	m.watches[types.NewID(swap.Swap_ID)] = zk.AtomicSwapHash(swap.SecretHash)
+	return true
+}
+
+func (m *atomicSwapManager) putSwap(swap *pb.AtomicSwap) error {
+	ser, err := proto.Marshal(swap)
+	if err != nil {
+		return err
+	}
+	return m.ds.Put(context.Background(), datastore.NewKey(repo.AtomicSwapDatastoreKeyPrefix+types.NewID(swap.Swap_ID).String()), ser)
+}
+
+func (m *atomicSwapManager) getSwap(id types.ID) (*pb.AtomicSwap, error) {
+	ser, err := m.ds.Get(context.Background(), datastore.NewKey(repo.AtomicSwapDatastoreKeyPrefix+id.String()))
+	if errors.Is(err, datastore.ErrNotFound) {
+		return nil, errAtomicSwapNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	swap := new(pb.AtomicSwap)
+	if err := proto.Unmarshal(ser, swap); err != nil {
+		return nil, err
+	}
+	return swap, nil
+}
+
+func (m *atomicSwapManager) getSwaps() ([]*pb.AtomicSwap, error) {
+	results, err := m.ds.Query(context.Background(), query.Query{
+		Prefix: repo.AtomicSwapDatastoreKeyPrefix,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var swaps []*pb.AtomicSwap
+	for result, ok := results.NextSync(); ok; result, ok = results.NextSync() {
+		swap := new(pb.AtomicSwap)
+		if err := proto.Unmarshal(result.Value, swap); err != nil {
+			return nil, err
+		}
+		swaps = append(swaps, swap)
+	}
+	sort.Slice(swaps, func(i, j int) bool {
+		return swaps[i].Created < swaps[j].Created
+	})
+	return swaps, nil
+}
+
+// matchSwapSecret returns the secret if the first chunk of the ciphertext
+// is the preimage of the swap hash.
+//
+// The chunk the script sees may have had its most significant bits
+// cleared to fit within the field so each of the possible masks is tried.
+func matchSwapSecret(ciphertext []byte, hash []byte) ([]byte, bool) {
+	if len(ciphertext) < 32 {
+		return nil, false
+	}
+	secret := make([]byte, 32)
+	copy(secret, ciphertext[:32])
+	for x := uint8(0); x <= 3; x++ {
+		secret[0] = ciphertext[0] & (byte(255) >> x)
+		h := sha256.Sum256(secret)
+		if bytes.Equal(zk.AtomicSwapHash(h[:]), hash) {
+			return secret, true
+		}
+	}
+	return nil, false
+}
+
+// atomicSwapLockingScript returns the locking script for a swap contract.
+func atomicSwapLockingScript(secretHash []byte, claimKey, refundKey crypto.PubKey, refundAfter int64) (types.LockingScript, error) {
+	tmpl, err := zk.GetScriptTemplate(atomicSwapTemplate)
+	if err != nil {
+		return types.LockingScript{}, err
+	}
+	scriptCommitment := tmpl.ScriptCommitment()
+	if len(scriptCommitment) == 0 {
+		return types.LockingScript{}, zk.ErrProverUnavailable
+	}
+	claimNovaKey, ok := claimKey.(*icrypto.NovaPublicKey)
+	if !ok {
+		return types.LockingScript{}, errors.New("claim key is not type Nova public key")
+	}
+	refundNovaKey, ok := refundKey.(*icrypto.NovaPublicKey)
+	if !ok {
+		return types.LockingScript{}, errors.New("refund key is not type Nova public key")
+	}
+	claimX, claimY := claimNovaKey.ToXY()
+	refundX, refundY := refundNovaKey.ToXY()
+	refundTime := make([]byte, 8)
+	binary.BigEndian.PutUint64(refundTime, uint64(refundAfter))
+
+	return types.LockingScript{
+		ScriptCommitment: types.NewID(scriptCommitment),
+		LockingParams:    [][]byte{zk.AtomicSwapHash(secretHash), claimX, claimY, refundTime, refundX, refundY},
+	}, nil
+}
+
+// spendKeyForAddress returns the spend key of the wallet address.
+func spendKeyForAddress(keys map[walletlib.WalletPrivateKey]walletlib.Address, walletAddr string) (crypto.PrivKey, bool) {
+	for key, addr := range keys {
+		if addr.String() == walletAddr {
+			return key.SpendKey(), true
+		}
+	}
+	return nil, false
+}
+
+// walletKeyForPubkey returns the wallet address whose spend key has
+// the public key coordinates.
+func walletKeyForPubkey(keys map[walletlib.WalletPrivateKey]walletlib.Address, pubX, pubY []byte) (crypto.PrivKey, walletlib.Address, bool) {
+	for key, addr := range keys {
+		spendKey := key.SpendKey()
+		novaKey, ok := spendKey.GetPublic().(*icrypto.NovaPublicKey)
+		if !ok {
+			continue
+		}
+		x, y := novaKey.ToXY()
+		if bytes.Equal(x, pubX) && bytes.Equal(y, pubY) {
+			return spendKey, addr, true
+		}
+	}
+	return nil, nil, false
+}
+
+// buildSwapSpend builds and proves a transaction spending a swap contract
+// to the wallet address. If the secret is set the contract is claimed with
+// it and a zero value output publishing the secret is added. Otherwise the
+// contract is refunded, which requires a locktime after the refund time.
+func (s *GrpcServer) buildSwapSpend(note *walletpb.SpendNote, toAddr walletlib.Address, spendKey crypto.PrivKey, secret []byte, refundAfter int64, feePerKB types.Amount) (*transactions.Transaction, error) {
+	tmpl, err := zk.GetScriptTemplate(atomicSwapTemplate)
+	if err != nil {
+		return nil, err
+	}
+	if feePerKB == 0 {
+		feePerKB = s.policy.GetMinFeePerKilobyte()
+	}
+	// The wallet always computes the fee for two outputs.
+	fee := walletlib.ComputeFee(1, 2, feePerKB)
+	if types.Amount(note.Amount) <= fee {
+		return nil, errors.New("contract amount does not cover the fee")
+	}
+	outputs := []*walletlib.RawOutput{{Addr: toAddr, Amount: types.Amount(note.Amount) - fee}}
+	if secret != nil {
+		outputs = append(outputs, &walletlib.RawOutput{Addr: toAddr, Amount: 0})
+	}
+	rawTx, err := s.wallet.CreateRawTransaction([]*walletlib.RawInput{{Commitment: note.Commitment}}, outputs, false, feePerKB)
+	if err != nil {
+		return nil, err
+	}
+	standardTx := rawTx.Tx.GetStandardTransaction()
+
+	disclosureIndex := -1
+	if secret != nil {
+		for i, out := range rawTx.PrivateOutputs {
+			if out.Amount == 0 {
+				disclosureIndex = i
+				break
+			}
+		}
+		if disclosureIndex < 0 {
+			return nil, errors.New("secret output not found")
+		}
+		standardTx.Outputs[disclosureIndex].Ciphertext = secret
+	} else {
+		// The whole locktime window must be after the refund time.
+		now := time.Now().Unix()
+		precision := int64(walletlib.DefaultLocktimePrecision)
+		if now-refundAfter < precision {
+			precision = now - refundAfter
+		}
+		if precision <= 0 {
+			return nil, errors.New("contract cannot be refunded until the refund time")
+		}
+		standardTx.Locktime = &transactions.Locktime{
+			Timestamp: now,
+			Precision: precision,
+		}
+	}
+
+	sighash, err := standardTx.SigHash()
+	if err != nil {
+		return nil, err
+	}
+	sig, err := spendKey.Sign(sighash)
+	if err != nil {
+		return nil, err
+	}
+	var unlockingParams string
+	if secret != nil {
+		unlockingParams, err = zk.MakeAtomicSwapClaimParams(secret, sig, disclosureIndex)
+	} else {
+		unlockingParams, err = zk.MakeAtomicSwapRefundParams(sig)
+	}
+	if err != nil {
+		return nil, err
+	}
+	rawTx.PrivateInputs[0].Script = tmpl.Script()
+	rawTx.PrivateInputs[0].UnlockingParams = unlockingParams
+
+	privateParams := &circparams.StandardPrivateParams{
+		Inputs:  rawTx.PrivateInputs,
+		Outputs: rawTx.PrivateOutputs,
+	}
+	publicParams, err := standardTx.ToCircuitParams()
+	if err != nil {
+		return nil, err
+	}
+	proof, err := s.prover.Prove(zk.StandardValidationProgram(), privateParams, publicParams)
+	if err != nil {
+		return nil, err
+	}
+	standardTx.Proof = proof
+	return transactions.WrapTransaction(standardTx), nil
+}
+
+// findContractNote returns the wallet's unspent note for the contract
+// address.
+func (s *GrpcServer) findContractNote(contractAddr string) (*walletpb.SpendNote, error) {
+	notes, err := s.wallet.Notes()
+	if err != nil {
+		return nil, err
+	}
+	for _, note := range notes {
+		if note.Address == contractAddr {
+			return note, nil
+		}
+	}
+	return nil, errors.New("contract output not found in wallet")
+}
+
+// fundSwapContract creates a swap contract which the counterparty can
+// claim with the secret and which is refunded to the wallet's current
+// address, then funds it and saves the swap. The contract address is
+// imported so the wallet tracks the contract output.
+func (s *GrpcServer) fundSwapContract(role pb.AtomicSwap_Role, counterpartyKey []byte, secretHash, secret []byte, amount types.Amount, refundAfter int64, feePerKB types.Amount) (*pb.AtomicSwap, error) {
+	if s.atomicSwaps == nil {
+		return nil, status.Error(codes.Unavailable, "atomic swaps are not available")
+	}
+	claimKey, err := crypto.UnmarshalPublicKey(counterpartyKey)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if amount == 0 {
+		return nil, status.Error(codes.InvalidArgument, "amount is required")
+	}
+	if refundAfter <= time.Now().Unix() {
+		return nil, status.Error(codes.InvalidArgument, "refund time must be in the future")
+	}
+
+	keys, err := s.walletPrivateKeys()
+	if err != nil {
+		return nil, err
+	}
+	walletAddr, err := s.wallet.Address()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	refundKey, ok := spendKeyForAddress(keys, walletAddr.String())
+	if !ok {
+		return nil, status.Error(codes.Internal, "wallet address key not found")
+	}
+
+	lockingScript, err := atomicSwapLockingScript(secretHash, claimKey, refundKey.GetPublic(), refundAfter)
+	if errors.Is(err, zk.ErrProverUnavailable) {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	} else if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	viewKey, viewPubkey, err := icrypto.GenerateCurve25519Key(rand.Reader)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	viewKeyBytes, err := crypto.MarshalPrivateKey(viewKey)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	contractAddr, err := walletlib.NewBasicAddress(lockingScript, viewPubkey, s.chainParams)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if err := s.wallet.ImportAddress(contractAddr, lockingScript, viewKey, false, 0); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	_, height, _ := s.chain.BestBlock()
+	txid, err := s.wallet.Spend(contractAddr, amount, feePerKB)
+	if err != nil {
+		return nil, err
+	}
+
+	var id types.ID
+	if _, err := rand.Read(id[:]); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	swap := &pb.AtomicSwap{
+		Swap_ID:               id[:],
+		Role:                  role,
+		Status:                pb.AtomicSwap_FUNDED,
+		SecretHash:            secretHash,
+		Secret:                secret,
+		Amount:                uint64(amount),
+		RefundAfter:           refundAfter,
+		ContractAddress:       contractAddr.String(),
+		LockingScript:         lockingScript.Serialize(),
+		ViewPrivateKey:        viewKeyBytes,
+		WalletAddress:         walletAddr.String(),
+		FundingTransaction_ID: txid[:],
+		Created:               time.Now().Unix(),
+		WatchHeight:           height,
+	}
+	if err := s.atomicSwaps.AddSwap(swap); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return swap, nil
+}
+
+// walletPrivateKeys returns the wallet's private keys, mapping the
+// errors for a locked or public only wallet to their status codes.
+func (s *GrpcServer) walletPrivateKeys() (map[walletlib.WalletPrivateKey]walletlib.Address, error) {
+	keys, err := s.wallet.PrivateKeys()
+	if errors.Is(err, walletlib.ErrEncryptedKeychain) {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	if errors.Is(err, walletlib.ErrPublicOnlyKeychain) {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return keys, nil
+}
@@ -10,18 +10,42 @@ import (
 	"github.com/ipfs/go-datastore"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/project-illium/ilxd/blockchain"
+	"github.com/project-illium/ilxd/mempool"
 	"github.com/project-illium/ilxd/params"
+	"github.com/project-illium/ilxd/params/hash"
+	"github.com/project-illium/ilxd/policy"
 	"github.com/project-illium/ilxd/rpc/pb"
 	"github.com/project-illium/ilxd/types"
 	"github.com/project-illium/ilxd/types/blocks"
-	"github.com/project-illium/walletlib"
+	"github.com/project-illium/ilxd/types/transactions"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"sort"
 	"time"
 )
 
 const maxBatchSize = 2000
 
+// feeEstimateBlocks is the number of recent blocks used
+// when estimating fees.
+const feeEstimateBlocks = 10
+
+const (
+	// defaultFeeHistoryBlocks is the number of blocks returned by
+	// GetFeeHistory if the request does not set it.
+	defaultFeeHistoryBlocks = 10
+
+	// maxFeeHistoryBlocks is the most blocks GetFeeHistory returns.
+	maxFeeHistoryBlocks = 1000
+
+	// defaultChainStatsBlocks is the number of blocks GetChainStats
+	// computes the utilization over if the request does not set it.
+	defaultChainStatsBlocks = 100
+
+	// maxChainStatsBlocks is the most blocks GetChainStats will load.
+	maxChainStatsBlocks = 1000
+)
+
 // GetMempoolInfo returns the state of the current mempool
 func (s *GrpcServer) GetMempoolInfo(ctx context.Context, req *pb.GetMempoolInfoRequest) (*pb.GetMempoolInfoResponse, error) {
 	size := 0
@@ -66,6 +90,131 @@ func (s *GrpcServer) GetMempool(ctx context.Context, req *pb.GetMempoolRequest)
 	}, nil
 }
 
+// GetMempoolEntry returns a transaction from the mempool along with
+// its size and fee data.
+//
+// Transaction inputs are proven against a committed accumulator root
+// so a transaction can never spend the outputs of another unconfirmed
+// transaction. Each entry is therefore its own package and the fee
+// rate reported here is the rate used for block assembly.
+func (s *GrpcServer) GetMempoolEntry(ctx context.Context, req *pb.GetMempoolEntryRequest) (*pb.GetMempoolEntryResponse, error) {
+	tx, err := s.txMemPool.GetTransaction(types.NewID(req.Transaction_ID))
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	size, err := tx.SerializedSize()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	fpkb, _, err := policy.CalcFeePerKilobyte(tx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	var fee uint64
+	switch t := tx.GetTx().(type) {
+	case *transactions.Transaction_StandardTransaction:
+		fee = t.StandardTransaction.Fee
+	case *transactions.Transaction_MintTransaction:
+		fee = t.MintTransaction.Fee
+	}
+	return &pb.GetMempoolEntryResponse{
+		Transaction:    tx,
+		Size:           uint32(size),
+		Fee:            fee,
+		FeePerKilobyte: uint64(fpkb),
+	}, nil
+}
+
+// GetFeeEstimate returns the fee per kilobyte a transaction should pay to be
+// included in a block within the target number of blocks. The estimate is based
+// on the fees of the transactions in the mempool and in the recent blocks.
+func (s *GrpcServer) GetFeeEstimate(ctx context.Context, req *pb.GetFeeEstimateRequest) (*pb.GetFeeEstimateResponse, error) {
+	confTarget := req.ConfTarget
+	if confTarget == 0 {
+		confTarget = 1
+	}
+	if confTarget > policy.MaxConfTarget {
+		return nil, status.Errorf(codes.InvalidArgument, "conf target must not exceed %d", policy.MaxConfTarget)
+	}
+
+	txs := s.txMemPool.GetTransactions()
+	mempoolTxs := make([]*transactions.Transaction, 0, len(txs))
+	for _, tx := range txs {
+		mempoolTxs = append(mempoolTxs, tx)
+	}
+
+	// Pruned nodes may not have all the recent blocks so we
+	// just use the ones we have.
+	snap := s.chain.Snapshot()
+	_, height, _ := snap.BestBlock()
+	recentBlocks := make([]*blocks.Block, 0, feeEstimateBlocks)
+	for i := uint32(0); i < feeEstimateBlocks && i <= height; i++ {
+		blk, err := snap.GetBlockByHeight(height - i)
+		if err != nil {
+			break
+		}
+		recentBlocks = append(recentBlocks, blk)
+	}
+
+	fpkb, err := s.policy.EstimateFeePerKilobyte(mempoolTxs, recentBlocks, confTarget)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.GetFeeEstimateResponse{
+		FeePerKilobyte: uint64(fpkb),
+		ConfTarget:     confTarget,
+	}, nil
+}
+
+// GetFeeHistory returns the distribution of the fee rates paid in each of the
+// most recent blocks along with the median of the blocks' median fee rates.
+func (s *GrpcServer) GetFeeHistory(ctx context.Context, req *pb.GetFeeHistoryRequest) (*pb.GetFeeHistoryResponse, error) {
+	if s.feeIndex == nil {
+		return nil, status.Error(codes.Unavailable, "fee index is not enabled")
+	}
+	n := int(req.NBlocks)
+	if n == 0 {
+		n = defaultFeeHistoryBlocks
+	}
+	if n > maxFeeHistoryBlocks {
+		return nil, status.Errorf(codes.InvalidArgument, "n blocks must not exceed %d", maxFeeHistoryBlocks)
+	}
+
+	_, height, _ := s.chain.BestBlock()
+	history, err := s.feeIndex.GetFeeHistory(s.ds, height, n)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	resp := &pb.GetFeeHistoryResponse{
+		Blocks: make([]*pb.BlockFeeStats, 0, len(history)),
+	}
+	medians := make([]uint64, 0, len(history))
+	for _, stats := range history {
+		resp.Blocks = append(resp.Blocks, &pb.BlockFeeStats{
+			Height:    stats.Height,
+			Timestamp: stats.Timestamp,
+			FeePayers: stats.FeePayers,
+			BlockSize: stats.BlockSize,
+			TotalFees: stats.TotalFees,
+			Min:       stats.Min,
+			P10:       stats.P10,
+			P25:       stats.P25,
+			P50:       stats.P50,
+			P75:       stats.P75,
+			P90:       stats.P90,
+			Max:       stats.Max,
+		})
+		if stats.FeePayers > 0 {
+			medians = append(medians, stats.P50)
+		}
+	}
+	if len(medians) > 0 {
+		sort.Slice(medians, func(i, j int) bool { return medians[i] < medians[j] })
+		resp.MedianFeePerKilobyte = medians[len(medians)/2]
+	}
+	return resp, nil
+}
+
 // GetBlockchainInfo returns data about the blockchain including the most recent block hash and height.
 func (s *GrpcServer) GetBlockchainInfo(ctx context.Context, req *pb.GetBlockchainInfoRequest) (*pb.GetBlockchainInfoResponse, error) {
 	var nt pb.GetBlockchainInfoResponse_Network
@@ -82,18 +231,10 @@ func (s *GrpcServer) GetBlockchainInfo(ctx context.Context, req *pb.GetBlockchai
 		return nil, status.Error(codes.Internal, "unknown network params")
 	}
 
-	id, height, ts := s.chain.BestBlock()
-
-	currentSupply, err := s.chain.CurrentSupply()
-	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
-	}
-	totalStaked := s.chain.TotalStaked()
-
-	treasuryBal, err := s.chain.TreasuryBalance()
-	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
-	}
+	// Read everything from one snapshot so the supply, stake, and
+	// treasury all agree with the reported tip.
+	snap := s.chain.Snapshot()
+	id, height, ts := snap.BestBlock()
 
 	size, err := s.ds.DiskUsage(context.Background())
 	if err != nil {
@@ -106,14 +247,105 @@ func (s *GrpcServer) GetBlockchainInfo(ctx context.Context, req *pb.GetBlockchai
 		BestBlock_ID:      id[:],
 		BlockTime:         ts.Unix(),
 		TxIndex:           s.txIndex != nil,
-		CirculatingSupply: uint64(currentSupply),
-		TotalStaked:       uint64(totalStaked),
-		TreasuryBalance:   uint64(treasuryBal),
+		CirculatingSupply: uint64(snap.CurrentSupply()),
+		TotalStaked:       uint64(snap.TotalStaked()),
+		TreasuryBalance:   uint64(snap.TreasuryBalance()),
 		BlockchainSize:    size,
 		Epoch:             uint32(ts.Unix()-s.chainParams.GenesisBlock.Header.Timestamp) / uint32(s.chainParams.EpochLength),
+		TxoRoot:           snap.TxoRoot().Bytes(),
 	}, nil
 }
 
+// GetChainStats returns the network's block and transaction size limits
+// along with how much of the block size limit recent blocks have used.
+func (s *GrpcServer) GetChainStats(ctx context.Context, req *pb.GetChainStatsRequest) (*pb.GetChainStatsResponse, error) {
+	n := req.NBlocks
+	if n == 0 {
+		n = defaultChainStatsBlocks
+	}
+	if n > maxChainStatsBlocks {
+		return nil, status.Errorf(codes.InvalidArgument, "n blocks must not exceed %d", maxChainStatsBlocks)
+	}
+
+	resp := &pb.GetChainStatsResponse{
+		MaxBlockSize:       s.chainParams.MaxBlockSize,
+		MaxTransactionSize: s.chainParams.MaxTransactionSize,
+		BlockSizeSoftLimit: s.policy.GetBlocksizeSoftLimit(),
+	}
+
+	snap := s.chain.Snapshot()
+	_, height, _ := snap.BestBlock()
+	var totalSize, totalTxs uint64
+	for i := uint32(0); i < n && i <= height; i++ {
+		blk, err := snap.GetBlockByHeight(height - i)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		size, err := blk.SerializedSize()
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if i == 0 {
+			resp.TipBlockSize = uint32(size)
+		}
+		if uint32(size) > resp.LargestBlockSize {
+			resp.LargestBlockSize = uint32(size)
+		}
+		totalSize += uint64(size)
+		totalTxs += uint64(len(blk.Transactions))
+		resp.NBlocks++
+	}
+	if resp.NBlocks > 0 {
+		resp.AverageBlockSize = uint32(totalSize / uint64(resp.NBlocks))
+		resp.AverageTransactions = float64(totalTxs) / float64(resp.NBlocks)
+	}
+	if resp.MaxBlockSize > 0 {
+		resp.TipUtilization = float64(resp.TipBlockSize) / float64(resp.MaxBlockSize)
+		resp.AverageUtilization = float64(resp.AverageBlockSize) / float64(resp.MaxBlockSize)
+	}
+	return resp, nil
+}
+
+// GetNetworkParams returns the parameters of the network the node is running on.
+// This includes the coin emission schedule, epoch length, protocol prefix, fee
+// minimums, and the genesis block ID.
+func (s *GrpcServer) GetNetworkParams(ctx context.Context, req *pb.GetNetworkParamsRequest) (*pb.GetNetworkParamsResponse, error) {
+	resp := &pb.GetNetworkParamsResponse{
+		Name:                       s.chainParams.Name,
+		ProtocolPrefix:             string(s.chainParams.ProtocolPrefix),
+		AddressPrefix:              s.chainParams.AddressPrefix,
+		EpochLength:                s.chainParams.EpochLength,
+		TargetDistribution:         s.chainParams.TargetDistribution,
+		InitialDistributionPeriods: s.chainParams.InitialDistributionPeriods,
+		AValue:                     s.chainParams.AValue,
+		TreasuryPercentage:         s.chainParams.TreasuryPercentage,
+		LongTermInflationRate:      s.chainParams.LongTermInflationRate,
+		ValidatorExpiration:        int64(blockchain.ValidatorExpiration.Seconds()),
+		RestakePeriod:              int64(blockchain.RestakePeriod.Seconds()),
+		MedianTimeBlocks:           s.chainParams.MedianTimeBlocks,
+		MaxBlockFutureDrift:        int64(s.chainParams.MaxBlockFutureDrift.Seconds()),
+		MinFeePerKilobyte:          uint64(s.policy.GetMinFeePerKilobyte()),
+		MinStake:                   uint64(s.policy.GetMinStake()),
+		AllowMockProofs:            s.chainParams.AllowMockProofs,
+		CoinbaseMaturity:           s.chainParams.CoinbaseMaturity,
+		AvalancheFinalizationScore: uint32(s.chainParams.AvalancheFinalizationScore),
+		AvalancheMaxInflightPoll:   uint32(s.chainParams.AvalancheMaxInflightPoll),
+		AvalancheRequestTimeout:    s.chainParams.AvalancheRequestTimeout.Milliseconds(),
+		AvalanchePollInterval:      s.chainParams.AvalanchePollInterval.Milliseconds(),
+		MaxBlockSize:               s.chainParams.MaxBlockSize,
+		MaxTransactionSize:         s.chainParams.MaxTransactionSize,
+	}
+	if genesis := s.chainParams.GenesisBlock; genesis != nil {
+		genesisID := genesis.ID()
+		resp.GenesisBlock_ID = genesisID[:]
+		resp.GenesisTimestamp = genesis.Header.Timestamp
+		if len(genesis.Transactions) > 0 && genesis.Transactions[0].GetCoinbaseTransaction() != nil {
+			resp.GenesisCoinbase = genesis.Transactions[0].GetCoinbaseTransaction().NewCoins
+		}
+	}
+	return resp, nil
+}
+
 // GetBlockInfo returns a BlockHeader plus some extra metadata.
 func (s *GrpcServer) GetBlockInfo(ctx context.Context, req *pb.GetBlockInfoRequest) (*pb.GetBlockInfoResponse, error) {
 	var (
@@ -273,13 +505,14 @@ func (s *GrpcServer) GetHeaders(ctx context.Context, req *pb.GetHeadersRequest)
 	if endHeight-req.StartHeight+1 > maxBatchSize {
 		endHeight = req.StartHeight + maxBatchSize - 1
 	}
-	_, bestHeight, _ := s.chain.BestBlock()
+	snap := s.chain.Snapshot()
+	_, bestHeight, _ := snap.BestBlock()
 	if endHeight > bestHeight {
 		endHeight = bestHeight
 	}
 	headers := make([]*blocks.BlockHeader, 0, endHeight-req.StartHeight+1)
 	for i := req.StartHeight; i <= endHeight; i++ {
-		header, err := s.chain.GetHeaderByHeight(i)
+		header, err := snap.GetHeaderByHeight(i)
 		if err != nil {
 			return nil, status.Error(codes.NotFound, err.Error())
 		}
@@ -296,13 +529,14 @@ func (s *GrpcServer) GetCompressedBlocks(ctx context.Context, req *pb.GetCompres
 	if endHeight-req.StartHeight+1 > maxBatchSize || endHeight <= 0 {
 		endHeight = req.StartHeight + maxBatchSize - 1
 	}
-	_, bestHeight, _ := s.chain.BestBlock()
+	snap := s.chain.Snapshot()
+	_, bestHeight, _ := snap.BestBlock()
 	if endHeight > bestHeight {
 		endHeight = bestHeight
 	}
 	blks := make([]*blocks.CompressedBlock, 0, endHeight-req.StartHeight+1)
 	for i := req.StartHeight; i <= endHeight; i++ {
-		blk, err := s.chain.GetBlockByHeight(i)
+		blk, err := snap.GetBlockByHeight(i)
 		if err != nil {
 			return nil, status.Error(codes.NotFound, err.Error())
 		}
@@ -328,6 +562,83 @@ func (s *GrpcServer) GetCompressedBlocks(ctx context.Context, req *pb.GetCompres
 	}, nil
 }
 
+// GetBlockFilter returns the compact filter for a block. The filter is a
+// BIP158 style Golomb-coded set of the block's output commitments and
+// nullifiers. Light wallets can match the filter against their notes and
+// only download the blocks which match.
+//
+// **Requires BlockFilterIndex**
+func (s *GrpcServer) GetBlockFilter(ctx context.Context, req *pb.GetBlockFilterRequest) (*pb.GetBlockFilterResponse, error) {
+	if s.filterIndex == nil {
+		return nil, status.Error(codes.Unavailable, "block filter index is not enabled")
+	}
+	var (
+		blockID types.ID
+		height  uint32
+		err     error
+	)
+	if len(req.GetBlock_ID()) == 0 {
+		height = req.GetHeight()
+		blockID, err = s.chain.GetBlockIDByHeight(height)
+	} else {
+		blockID = types.NewID(req.GetBlock_ID())
+		height, err = s.chain.GetBlockHeight(blockID)
+	}
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	filter, header, err := s.filterIndex.GetBlockFilter(s.ds, blockID)
+	if errors.Is(err, datastore.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, "block filter not found")
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.GetBlockFilterResponse{
+		Block_ID:     blockID[:],
+		Height:       height,
+		Filter:       filter,
+		FilterHeader: header[:],
+	}, nil
+}
+
+// GetCommitmentsByScriptHash returns the commitments of all the notes in the
+// chain with the given script hash. Only notes which were published unencrypted,
+// such as those sent to public addresses or to a shared script, can be found.
+//
+// **Requires ScriptIndex**
+func (s *GrpcServer) GetCommitmentsByScriptHash(ctx context.Context, req *pb.GetCommitmentsByScriptHashRequest) (*pb.GetCommitmentsByScriptHashResponse, error) {
+	if s.scriptIndex == nil {
+		return nil, status.Error(codes.Unavailable, "script index is not enabled")
+	}
+	if len(req.ScriptHash) != hash.HashSize {
+		return nil, status.Error(codes.InvalidArgument, "invalid script hash")
+	}
+	outputs, err := s.scriptIndex.GetScriptOutputs(s.ds, types.NewID(req.ScriptHash))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	resp := &pb.GetCommitmentsByScriptHashResponse{
+		Commitments: make([]*pb.GetCommitmentsByScriptHashResponse_ScriptCommitment, 0, len(outputs)),
+	}
+	for _, out := range outputs {
+		var note types.SpendNote
+		if err := note.Deserialize(out.Note); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		resp.Commitments = append(resp.Commitments, &pb.GetCommitmentsByScriptHashResponse_ScriptCommitment{
+			Commitment:      out.Commitment,
+			Transaction_ID:  out.Txid,
+			Height:          out.Height,
+			CommitmentIndex: out.OutputIndex,
+			Amount:          uint64(note.Amount),
+			Asset_ID:        note.AssetID.Bytes(),
+			Salt:            note.Salt[:],
+			State:           note.State,
+		})
+	}
+	return resp, nil
+}
+
 // GetTransaction returns the transaction for the given transaction ID.
 //
 // **Requires TxIndex**
@@ -425,7 +736,7 @@ func (s *GrpcServer) GetAddressTransactions(ctx context.Context, req *pb.GetAddr
 		return nil, status.Error(codes.Unavailable, "addr index is not available")
 	}
 
-	addr, err := walletlib.DecodeAddress(req.Address, s.chainParams)
+	addr, err := decodeAddress(req.Address, s.chainParams)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "malformed address")
 	}
@@ -615,13 +926,45 @@ func (s *GrpcServer) GetValidator(ctx context.Context, req *pb.GetValidatorReque
 
 // GetValidatorSetInfo returns information about the validator set.
 func (s *GrpcServer) GetValidatorSetInfo(ctx context.Context, req *pb.GetValidatorSetInfoRequest) (*pb.GetValidatorSetInfoResponse, error) {
+	snap := s.chain.Snapshot()
 	return &pb.GetValidatorSetInfoResponse{
-		TotalStaked:   uint64(s.chain.TotalStaked()),
-		StakeWeight:   uint64(s.chain.TotalStakeWeight()),
-		NumValidators: uint32(s.chain.ValidatorSetSize()),
+		TotalStaked:   uint64(snap.TotalStaked()),
+		StakeWeight:   uint64(snap.TotalStakeWeight()),
+		NumValidators: uint32(snap.ValidatorSetSize()),
 	}, nil
 }
 
+// GetStakeDistribution returns statistics about how stake weight is
+// distributed across the validator set along with the minimum stake a
+// new validator would need to produce a block with the target probability.
+func (s *GrpcServer) GetStakeDistribution(ctx context.Context, req *pb.GetStakeDistributionRequest) (*pb.GetStakeDistributionResponse, error) {
+	target := req.TargetProbability
+	if target == 0 {
+		target = defaultTargetProbability
+	}
+	if target < 0 || target >= 1 {
+		return nil, status.Error(codes.InvalidArgument, "target probability must be between 0 and 1")
+	}
+	numBlocks := req.Blocks
+	if numBlocks == 0 {
+		numBlocks = defaultProbabilityBlocks
+	}
+
+	validators := s.chain.Validators()
+	weights := make([]types.Amount, 0, len(validators))
+	for _, v := range validators {
+		weights = append(weights, v.WeightedStake)
+	}
+	resp := stakeDistribution(weights)
+
+	minStake := minimumStakeForProbability(types.Amount(resp.StakeWeight), target, numBlocks)
+	if policyMin := s.policy.GetMinStake(); minStake < policyMin {
+		minStake = policyMin
+	}
+	resp.MinimumStake = uint64(minStake)
+	return resp, nil
+}
+
 // GetValidatorSet returns all the validators in the current validator set.
 func (s *GrpcServer) GetValidatorSet(ctx context.Context, req *pb.GetValidatorSetRequest) (*pb.GetValidatorSetResponse, error) {
 	validators := s.chain.Validators()
@@ -680,6 +1023,30 @@ func (s *GrpcServer) GetValidatorCoinbases(ctx context.Context, req *pb.GetValid
 	return resp, nil
 }
 
+// GetEquivocationEvidence returns the equivocation evidence recorded by
+// this node. Evidence is two signed blocks at the same height from the
+// same validator, either seen by this node or relayed by peers.
+func (s *GrpcServer) GetEquivocationEvidence(ctx context.Context, req *pb.GetEquivocationEvidenceRequest) (*pb.GetEquivocationEvidenceResponse, error) {
+	if s.evidence == nil {
+		return nil, status.Error(codes.Unavailable, "evidence store is not available")
+	}
+
+	var pid peer.ID
+	if len(req.Validator_ID) > 0 {
+		var err error
+		pid, err = peer.IDFromBytes(req.Validator_ID)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	evidence, err := s.evidence.Evidence(pid)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.GetEquivocationEvidenceResponse{Evidence: evidence}, nil
+}
+
 // GetAccumulatorCheckpoint returns the accumulator at the requested height.
 func (s *GrpcServer) GetAccumulatorCheckpoint(ctx context.Context, req *pb.GetAccumulatorCheckpointRequest) (*pb.GetAccumulatorCheckpointResponse, error) {
 	var (
@@ -702,6 +1069,101 @@ func (s *GrpcServer) GetAccumulatorCheckpoint(ctx context.Context, req *pb.GetAc
 	}, nil
 }
 
+// GetEpochSnapshot returns the commitment to the chain state taken at the
+// end of an epoch. The snapshot commits to the accumulator root, a hash of
+// the nullifier set, and a hash of the validator set as of the last block
+// of the epoch. Nodes that agree on the chain compute the same snapshots so
+// they can be used as anchors by light clients and to cross-check nodes.
+func (s *GrpcServer) GetEpochSnapshot(ctx context.Context, req *pb.GetEpochSnapshotRequest) (*pb.GetEpochSnapshotResponse, error) {
+	var (
+		snapshot *blockchain.EpochSnapshot
+		err      error
+	)
+	if req.Latest {
+		snapshot, err = s.chain.LatestEpochSnapshot()
+	} else {
+		snapshot, err = s.chain.GetEpochSnapshot(req.Epoch)
+	}
+	if errors.Is(err, datastore.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, "epoch snapshot not found")
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	commitment := snapshot.Commitment()
+	return &pb.GetEpochSnapshotResponse{
+		Epoch:            snapshot.Epoch,
+		Height:           snapshot.Height,
+		Block_ID:         snapshot.BlockID.Bytes(),
+		AccumulatorRoot:  snapshot.AccumulatorRoot.Bytes(),
+		NullifierSetHash: snapshot.NullifierSetHash.Bytes(),
+		ValidatorSetHash: snapshot.ValidatorSetHash.Bytes(),
+		Commitment:       commitment.Bytes(),
+	}, nil
+}
+
+// VerifyReserves checks a reserves proof created by the ProveReserves RPC
+// against the current chain state and returns the proven total.
+func (s *GrpcServer) VerifyReserves(ctx context.Context, req *pb.VerifyReservesRequest) (*pb.VerifyReservesResponse, error) {
+	if req.Proof == nil {
+		return nil, status.Error(codes.InvalidArgument, "proof is nil")
+	}
+	total, err := verifyReservesProof(req.Proof, s.chain)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &pb.VerifyReservesResponse{
+		Total:   uint64(total),
+		Message: req.Proof.Message,
+	}, nil
+}
+
+// VerifyPayment checks a payment proof created by the ProvePayment RPC
+// and returns the address, amount, and asset that were paid.
+//
+// **Requires TxIndex**
+func (s *GrpcServer) VerifyPayment(ctx context.Context, req *pb.VerifyPaymentRequest) (*pb.VerifyPaymentResponse, error) {
+	if s.txIndex == nil {
+		return nil, status.Error(codes.Unavailable, "tx index is not available")
+	}
+	if req.Proof == nil {
+		return nil, status.Error(codes.InvalidArgument, "proof is nil")
+	}
+	tx, blockID, err := s.txIndex.GetTransaction(s.ds, types.NewID(req.Proof.Transaction_ID))
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	addr, err := verifyPaymentProof(req.Proof, tx, s.chainParams)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	height, err := s.chain.GetBlockHeight(blockID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.VerifyPaymentResponse{
+		Address:  addr.String(),
+		Amount:   req.Proof.Amount,
+		Asset_ID: req.Proof.Asset_ID,
+		Block_ID: blockID.Bytes(),
+		Height:   height,
+	}, nil
+}
+
+// GetChainTips returns the tip of the main chain along with the tips of
+// recently seen competing branches, the height at which they fork from
+// the main chain, and whether they are still pending in consensus or
+// were rejected.
+func (s *GrpcServer) GetChainTips(ctx context.Context, req *pb.GetChainTipsRequest) (*pb.GetChainTipsResponse, error) {
+	tips := s.chain.ChainTips()
+	resp := &pb.GetChainTipsResponse{
+		Tips: make([]*pb.ChainTip, 0, len(tips)),
+	}
+	for i := range tips {
+		resp.Tips = append(resp.Tips, chainTipToPb(&tips[i]))
+	}
+	return resp, nil
+}
+
 // SubmitTransaction validates a transaction and submits it to the network. An error will be returned if it fails validation.
 func (s *GrpcServer) SubmitTransaction(ctx context.Context, req *pb.SubmitTransactionRequest) (*pb.SubmitTransactionResponse, error) {
 	err := s.broadcastTxFunc(req.Transaction)
@@ -714,6 +1176,28 @@ func (s *GrpcServer) SubmitTransaction(ctx context.Context, req *pb.SubmitTransa
 	}, nil
 }
 
+// SubmitPackage validates a group of transactions and submits them to the network
+// only if all of them are valid. An error will be returned if any fails validation.
+func (s *GrpcServer) SubmitPackage(ctx context.Context, req *pb.SubmitPackageRequest) (*pb.SubmitPackageResponse, error) {
+	if s.submitPackageFunc == nil {
+		return nil, status.Error(codes.Unimplemented, "package submission is not supported")
+	}
+	if len(req.Transactions) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no transactions in package")
+	}
+	if err := s.submitPackageFunc(req.Transactions); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	resp := &pb.SubmitPackageResponse{
+		Transaction_IDs: make([][]byte, 0, len(req.Transactions)),
+	}
+	for _, tx := range req.Transactions {
+		txid := tx.ID()
+		resp.Transaction_IDs = append(resp.Transaction_IDs, txid[:])
+	}
+	return resp, nil
+}
+
 // SubscribeBlocks returns a stream of notifications when new blocks are finalized and connected to the chain.
 func (s *GrpcServer) SubscribeBlocks(req *pb.SubscribeBlocksRequest, stream pb.BlockchainService_SubscribeBlocksServer) error {
 	sub := s.subscribeEvents()
@@ -824,6 +1308,83 @@ func (s *GrpcServer) SubscribeCompressedBlocks(req *pb.SubscribeCompressedBlocks
 	}
 }
 
+// SubscribeDoubleSpendAlerts returns a stream of notifications when a transaction is
+// rejected from the mempool because it spends a nullifier already spent by an unconfirmed
+// transaction in the pool. The first transaction seen stays in the pool.
+func (s *GrpcServer) SubscribeDoubleSpendAlerts(req *pb.SubscribeDoubleSpendAlertsRequest, stream pb.BlockchainService_SubscribeDoubleSpendAlertsServer) error {
+	sub := s.subscribeEvents()
+	defer sub.Close()
+
+	for {
+		select {
+		case <-s.quit:
+			return nil
+		case n := <-sub.C:
+			if notif, ok := n.(*mempool.Notification); ok {
+				if notif.Type == mempool.NTDoubleSpend {
+					alert, ok := notif.Data.(*mempool.DoubleSpendAlert)
+					if !ok {
+						continue
+					}
+					resp := &pb.DoubleSpendAlert{
+						Transaction_ID:            alert.Txid.Bytes(),
+						ConflictingTransaction_ID: alert.ConflictingTxid.Bytes(),
+						Nullifier:                 alert.Nullifier.Bytes(),
+					}
+					if err := stream.Send(resp); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+}
+
+// SubscribeForkAlerts returns a stream of notifications when the node sees a
+// competing branch which extends more than forkalertdepth blocks past the
+// point where it forks from the main chain.
+func (s *GrpcServer) SubscribeForkAlerts(req *pb.SubscribeForkAlertsRequest, stream pb.BlockchainService_SubscribeForkAlertsServer) error {
+	sub := s.subscribeEvents()
+	defer sub.Close()
+
+	for {
+		select {
+		case <-s.quit:
+			return nil
+		case n := <-sub.C:
+			if notif, ok := n.(*blockchain.Notification); ok {
+				if notif.Type == blockchain.NTForkDetected {
+					tip, ok := notif.Data.(*blockchain.ChainTip)
+					if !ok {
+						continue
+					}
+					if err := stream.Send(chainTipToPb(tip)); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+}
+
+func chainTipToPb(tip *blockchain.ChainTip) *pb.ChainTip {
+	status := pb.ChainTip_ACTIVE
+	switch tip.Status {
+	case blockchain.ChainTipPending:
+		status = pb.ChainTip_PENDING
+	case blockchain.ChainTipRejected:
+		status = pb.ChainTip_REJECTED
+	}
+	return &pb.ChainTip{
+		Block_ID:     tip.ID[:],
+		Height:       tip.Height,
+		ForkHeight:   tip.ForkHeight,
+		BranchLength: tip.BranchLength,
+		Status:       status,
+		LastSeen:     tip.LastSeen.Unix(),
+	}
+}
+
 func computeTotalFees(blk *blocks.Block) types.Amount {
 	total := types.Amount(0)
 	for _, tx := range blk.Transactions {
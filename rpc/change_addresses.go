@@ -0,0 +1,195 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"github.com/ipfs/go-datastore"
+	"github.com/project-illium/ilxd/params"
+	"github.com/project-illium/ilxd/repo"
+	"github.com/project-illium/walletlib"
+	"sync"
+)
+
+var (
+	changeAddressFlag  = []byte{0x01}
+	receiveAddressFlag = []byte{0x00}
+)
+
+// changeAddresses picks the address for the wallet's change according to
+// the change address policy and records which of the wallet's addresses
+// are used for change.
+//
+// The addresses created for change are recorded as they are created. The
+// rest are classified from the wallet's transactions as they connect,
+// including the ones replayed by a rescan. An address paid only by
+// transactions spending the wallet's own utxos is a change address. An
+// address paid by anyone else is a receive address, and stays one. This
+// picks up the change sent to the wallet's current address before the
+// policy existed without marking addresses that were handed out.
+type changeAddresses struct {
+	ds          repo.Datastore
+	wallet      *walletlib.Wallet
+	chainParams *params.NetworkParams
+	policy      ChangeAddressPolicy
+	quit        chan struct{}
+	mtx         sync.Mutex
+}
+
+// newChangeAddresses returns a new changeAddresses which classifies the
+// wallet's addresses until quit is closed.
+func newChangeAddresses(ds repo.Datastore, wallet *walletlib.Wallet, chainParams *params.NetworkParams, policy ChangeAddressPolicy, quit chan struct{}) *changeAddresses {
+	c := &changeAddresses{
+		ds:          ds,
+		wallet:      wallet,
+		chainParams: chainParams,
+		policy:      policy,
+		quit:        quit,
+		mtx:         sync.Mutex{},
+	}
+	go c.watch()
+	return c
+}
+
+// changeAddress returns the address to send a new transaction's change
+// to.
+func (c *changeAddresses) changeAddress() (walletlib.Address, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	switch c.policy {
+	case ChangeAddressReuse:
+		return c.wallet.Address()
+	case ChangeAddressFixed:
+		addr, err := c.fixedAddress()
+		if err != nil {
+			return nil, err
+		}
+		if addr != nil {
+			return addr, nil
+		}
+		addr, err = c.newChangeAddress()
+		if err != nil {
+			return nil, err
+		}
+		if err := c.ds.Put(context.Background(), datastore.NewKey(repo.FixedChangeAddressDatastoreKey), []byte(addr.String())); err != nil {
+			return nil, err
+		}
+		return addr, nil
+	default:
+		return c.newChangeAddress()
+	}
+}
+
+// previewAddress returns the address changeAddress would return without
+// creating one. It's nil if a new address would be created.
+func (c *changeAddresses) previewAddress() (walletlib.Address, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	switch c.policy {
+	case ChangeAddressReuse:
+		return c.wallet.Address()
+	case ChangeAddressFixed:
+		return c.fixedAddress()
+	default:
+		return nil, nil
+	}
+}
+
+// isChange returns whether the address is used for change.
+func (c *changeAddresses) isChange(addr string) (bool, error) {
+	flag, err := c.ds.Get(context.Background(), datastore.NewKey(repo.ChangeAddressDatastoreKeyPrefix+addr))
+	if errors.Is(err, datastore.ErrNotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return len(flag) > 0 && flag[0] == changeAddressFlag[0], nil
+}
+
+func (c *changeAddresses) fixedAddress() (walletlib.Address, error) {
+	addrStr, err := c.ds.Get(context.Background(), datastore.NewKey(repo.FixedChangeAddressDatastoreKey))
+	if errors.Is(err, datastore.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return walletlib.DecodeAddress(string(addrStr), c.chainParams)
+}
+
+func (c *changeAddresses) newChangeAddress() (walletlib.Address, error) {
+	addr, err := c.wallet.NewAddress()
+	if err != nil {
+		return nil, err
+	}
+	if err := c.ds.Put(context.Background(), datastore.NewKey(repo.ChangeAddressDatastoreKeyPrefix+addr.String()), changeAddressFlag); err != nil {
+		return nil, err
+	}
+	return addr, nil
+}
+
+func (c *changeAddresses) watch() {
+	sub := c.wallet.SubscribeTransactions()
+	defer sub.Close()
+
+	for {
+		select {
+		case wtx := <-sub.C:
+			if wtx != nil {
+				c.classify(wtx) //nolint:errcheck
+			}
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// classify records each of the wallet's addresses paid by the transaction
+// as either a change or receive address. The wallet sends its
+// notifications in no particular order during a rescan so a receive
+// address is never turned back into a change address.
+func (c *changeAddresses) classify(wtx *walletlib.WalletTransaction) error {
+	fromWallet := false
+	for _, in := range wtx.Inputs {
+		if _, ok := in.(*walletlib.TxIO); ok {
+			fromWallet = true
+			break
+		}
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	for _, out := range wtx.Outputs {
+		txio, ok := out.(*walletlib.TxIO)
+		if !ok {
+			continue
+		}
+		// The outputs include the ones the wallet paid to other
+		// wallets.
+		if _, err := c.wallet.AddressInfo(txio.Address); err != nil {
+			continue
+		}
+		key := datastore.NewKey(repo.ChangeAddressDatastoreKeyPrefix + txio.Address.String())
+		if !fromWallet {
+			if err := c.ds.Put(context.Background(), key, receiveAddressFlag); err != nil {
+				return err
+			}
+			continue
+		}
+		exists, err := c.ds.Has(context.Background(), key)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			if err := c.ds.Put(context.Background(), key, changeAddressFlag); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"github.com/project-illium/ilxd/rpc/pb"
+	"github.com/project-illium/ilxd/types"
+	"github.com/project-illium/walletlib"
+	"strconv"
+	"strings"
+)
+
+// ChangePolicy controls what the wallet does with change that would cost
+// more to spend than it is worth.
+type ChangePolicy struct {
+	// DustThresholds maps an asset ID to the smallest change output the
+	// wallet will create for that asset. Change below the threshold is
+	// added to the fee instead.
+	DustThresholds map[types.ID]types.Amount
+
+	// DonationAddress, if set, receives the sub-threshold change instead
+	// of it being added to the fee.
+	DonationAddress walletlib.Address
+
+	// AddressPolicy controls which address the change is sent to.
+	AddressPolicy ChangeAddressPolicy
+}
+
+// ChangeAddressPolicy controls which address the wallet sends change to.
+type ChangeAddressPolicy int
+
+const (
+	// ChangeAddressNew sends the change of each transaction to a fresh
+	// wallet address.
+	ChangeAddressNew ChangeAddressPolicy = iota
+
+	// ChangeAddressFixed sends all change to a single wallet address
+	// which is only used for change.
+	ChangeAddressFixed
+
+	// ChangeAddressReuse sends change to the wallet's current address.
+	ChangeAddressReuse
+)
+
+// ParseChangeAddressPolicy parses a change address policy formatted as
+// new, fixed, or reuse.
+func ParseChangeAddressPolicy(policy string) (ChangeAddressPolicy, error) {
+	switch policy {
+	case "new", "":
+		return ChangeAddressNew, nil
+	case "fixed":
+		return ChangeAddressFixed, nil
+	case "reuse":
+		return ChangeAddressReuse, nil
+	default:
+		return 0, errors.New("invalid change address policy: " + policy)
+	}
+}
+
+// ParseDustThresholds parses dust change thresholds formatted as either
+// <amount> for illium coins or <assetID>:<amount> for other assets.
+func ParseDustThresholds(thresholds []string) (map[types.ID]types.Amount, error) {
+	m := make(map[types.ID]types.Amount)
+	for _, t := range thresholds {
+		assetID := types.IlliumCoinID
+		amtStr := t
+		if i := strings.Index(t, ":"); i >= 0 {
+			id, err := types.NewIDFromString(t[:i])
+			if err != nil {
+				return nil, errors.New("invalid dust change asset ID: " + t[:i])
+			}
+			assetID = id
+			amtStr = t[i+1:]
+		}
+		amt, err := strconv.ParseUint(amtStr, 10, 64)
+		if err != nil {
+			return nil, errors.New("invalid dust change amount: " + amtStr)
+		}
+		m[assetID] = types.Amount(amt)
+	}
+	return m, nil
+}
+
+// applyChangePolicy removes the change output from the plan if it is below
+// the dust threshold for the asset. The change is either added to the fee or
+// paid to the donation address. It returns whether the plan was modified.
+func (s *GrpcServer) applyChangePolicy(plan *pb.TransactionPlan, assetID types.ID) bool {
+	threshold, ok := s.changePolicy.DustThresholds[assetID]
+	if !ok {
+		return false
+	}
+	for i, out := range plan.Outputs {
+		if !out.Change || types.Amount(out.Amount) >= threshold {
+			continue
+		}
+		if s.changePolicy.DonationAddress != nil {
+			// The fee is always computed for two outputs so swapping the
+			// change for a donation leaves it unchanged.
+			out.Address = s.changePolicy.DonationAddress.String()
+			out.Change = false
+		} else {
+			plan.Fee += out.Amount
+			plan.Outputs = append(plan.Outputs[:i], plan.Outputs[i+1:]...)
+		}
+		return true
+	}
+	return false
+}
+
+// spendPlan builds, proves, and broadcasts a transaction spending exactly
+// the inputs and outputs in the plan with the plan's fee. No change output
+// is added.
+//
+// Change outputs without an address are sent to the address picked by the
+// change address policy.
+func (s *GrpcServer) spendPlan(ctx context.Context, plan *pb.TransactionPlan) (types.ID, error) {
+	for _, out := range plan.Outputs {
+		if !out.Change || out.Address != "" {
+			continue
+		}
+		addr, err := s.changeAddresses.changeAddress()
+		if err != nil {
+			return types.ID{}, err
+		}
+		out.Address = addr.String()
+	}
+	req := &pb.CreateRawTransactionRequest{
+		Inputs:  make([]*pb.CreateRawTransactionRequest_Input, 0, len(plan.Inputs)),
+		Outputs: make([]*pb.CreateRawTransactionRequest_Output, 0, len(plan.Outputs)),
+	}
+	for _, in := range plan.Inputs {
+		req.Inputs = append(req.Inputs, &pb.CreateRawTransactionRequest_Input{
+			CommitmentOrPrivateInput: &pb.CreateRawTransactionRequest_Input_Commitment{
+				Commitment: in.Commitment,
+			},
+		})
+	}
+	for _, out := range plan.Outputs {
+		req.Outputs = append(req.Outputs, &pb.CreateRawTransactionRequest_Output{
+			Address: out.Address,
+			Amount:  out.Amount,
+		})
+	}
+	rawResp, err := s.CreateRawTransaction(ctx, req)
+	if err != nil {
+		return types.ID{}, err
+	}
+	standardTx := rawResp.RawTx.Tx.GetStandardTransaction()
+	if standardTx == nil {
+		return types.ID{}, errors.New("raw transaction is not a standard transaction")
+	}
+	// The wallet computes the fee from the transaction size. Anything left
+	// over from the inputs must go to the fee for the transaction to balance.
+	standardTx.Fee = plan.Fee
+
+	provedResp, err := s.ProveRawTransaction(ctx, &pb.ProveRawTransactionRequest{RawTx: rawResp.RawTx})
+	if err != nil {
+		return types.ID{}, err
+	}
+	if err := s.broadcastTxFunc(provedResp.ProvedTx); err != nil {
+		return types.ID{}, err
+	}
+	return provedResp.ProvedTx.ID(), nil
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"bytes"
+	"errors"
+	"github.com/project-illium/ilxd/rpc/pb"
+	"github.com/project-illium/ilxd/types"
+	"github.com/project-illium/walletlib"
+	walletpb "github.com/project-illium/walletlib/pb"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// maxBranchAndBoundTries caps the number of branches the branch and
+// bound search will visit before giving up.
+const maxBranchAndBoundTries = 100000
+
+// coinSelection is the result of selecting the inputs for a spend.
+type coinSelection struct {
+	notes []*walletpb.SpendNote
+
+	// noChange is true if the inputs cover the amount and fee closely
+	// enough that the leftover should be added to the fee rather than
+	// paid to a change output.
+	noChange bool
+}
+
+// commitments returns the commitments of the selected notes.
+func (cs *coinSelection) commitments() [][]byte {
+	commitments := make([][]byte, 0, len(cs.notes))
+	for _, note := range cs.notes {
+		commitments = append(commitments, note.Commitment)
+	}
+	return commitments
+}
+
+// total returns the sum of the selected notes.
+func (cs *coinSelection) total() types.Amount {
+	total := types.Amount(0)
+	for _, note := range cs.notes {
+		total += types.Amount(note.Amount)
+	}
+	return total
+}
+
+// selectCoins selects the inputs for a transaction paying the amount to a
+// single output using the strategy. The fee is computed the same way the
+// wallet computes it so the wallet will spend all the selected inputs.
+func (s *GrpcServer) selectCoins(strategy pb.SpendRequest_CoinSelection, amount, feePerKB types.Amount) (*coinSelection, error) {
+	if feePerKB == 0 {
+		// The wallet's default fee is the node's minimum fee.
+		feePerKB = s.policy.GetMinFeePerKilobyte()
+	}
+	notes, err := s.spendableNotes(feePerKB)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strategy {
+	case pb.SpendRequest_LARGEST_FIRST:
+		sort.SliceStable(notes, func(i, j int) bool {
+			return notes[i].Amount > notes[j].Amount
+		})
+	case pb.SpendRequest_SMALLEST_FIRST:
+		sort.SliceStable(notes, func(i, j int) bool {
+			return notes[i].Amount < notes[j].Amount
+		})
+	case pb.SpendRequest_BRANCH_AND_BOUND:
+		sort.SliceStable(notes, func(i, j int) bool {
+			return notes[i].Amount > notes[j].Amount
+		})
+		if selected := branchAndBound(notes, amount, feePerKB); selected != nil {
+			return &coinSelection{notes: selected, noChange: true}, nil
+		}
+	case pb.SpendRequest_PRIVACY_RANDOMIZED:
+		rand.Shuffle(len(notes), func(i, j int) {
+			notes[i], notes[j] = notes[j], notes[i]
+		})
+	default:
+		return nil, errors.New("unknown coin selection strategy")
+	}
+
+	total := types.Amount(0)
+	for i, note := range notes {
+		total += types.Amount(note.Amount)
+		if total >= amount+walletlib.ComputeFee(i+1, 2, feePerKB) {
+			return &coinSelection{notes: notes[:i+1]}, nil
+		}
+	}
+	return nil, walletlib.ErrInsufficientFunds
+}
+
+// spendableNotes returns the wallet's illium coin notes which may be
+// selected as inputs. Staked, timelocked, and watch-only notes are never
+// selected, nor are notes that cost more in fees to spend than they are
+// worth.
+func (s *GrpcServer) spendableNotes(feePerKB types.Amount) ([]*walletpb.SpendNote, error) {
+	notes, err := s.wallet.Notes()
+	if err != nil {
+		return nil, err
+	}
+	spendable := make([]*walletpb.SpendNote, 0, len(notes))
+	for _, note := range notes {
+		if note.WatchOnly || note.Staked || time.Unix(note.LockedUntil, 0).After(time.Now()) {
+			continue
+		}
+		if !bytes.Equal(note.Asset_ID, types.IlliumCoinID[:]) {
+			continue
+		}
+		if walletlib.IsDustInput(types.Amount(note.Amount), feePerKB) {
+			continue
+		}
+		spendable = append(spendable, note)
+	}
+	return spendable, nil
+}
+
+// branchAndBound searches for a subset of the notes, which must be sorted
+// largest first, that pays the amount and fee with a leftover no greater
+// than the cost of creating a change output and spending it later. It
+// returns nil if no such subset was found.
+func branchAndBound(notes []*walletpb.SpendNote, amount, feePerKB types.Amount) []*walletpb.SpendNote {
+	b := &bnbSearch{
+		notes:        notes,
+		amount:       amount,
+		feePerKB:     feePerKB,
+		costOfChange: walletlib.ComputeFee(1, 2, feePerKB) - walletlib.ComputeFee(0, 1, feePerKB),
+		remaining:    make([]types.Amount, len(notes)+1),
+	}
+	for i := len(notes) - 1; i >= 0; i-- {
+		b.remaining[i] = b.remaining[i+1] + types.Amount(notes[i].Amount)
+	}
+	return b.search(0, 0, nil)
+}
+
+type bnbSearch struct {
+	notes        []*walletpb.SpendNote
+	amount       types.Amount
+	feePerKB     types.Amount
+	costOfChange types.Amount
+
+	// remaining[i] is the sum of the notes from i onward.
+	remaining []types.Amount
+	tries     int
+}
+
+// search visits the branches which include and exclude the note at i
+// given the notes selected so far, which total the total.
+func (b *bnbSearch) search(i int, total types.Amount, selected []*walletpb.SpendNote) []*walletpb.SpendNote {
+	if b.tries >= maxBranchAndBoundTries {
+		return nil
+	}
+	b.tries++
+
+	target := b.amount + walletlib.ComputeFee(len(selected), 2, b.feePerKB)
+	if total >= target {
+		// Each note is worth more than the fee to spend it, give or
+		// take rounding, so more notes would only grow the leftover.
+		if total-target <= b.costOfChange {
+			return append([]*walletpb.SpendNote(nil), selected...)
+		}
+		return nil
+	}
+	if i == len(b.notes) || total+b.remaining[i] < target {
+		return nil
+	}
+	if found := b.search(i+1, total+types.Amount(b.notes[i].Amount), append(selected, b.notes[i])); found != nil {
+		return found
+	}
+	return b.search(i+1, total, selected)
+}
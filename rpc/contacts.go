@@ -0,0 +1,142 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"github.com/project-illium/ilxd/params"
+	"github.com/project-illium/ilxd/repo"
+	"github.com/project-illium/ilxd/rpc/pb"
+	"github.com/project-illium/walletlib"
+	"google.golang.org/protobuf/proto"
+	"sort"
+	"strings"
+)
+
+// maxContactNameLen is the maximum length of a contact name in bytes.
+const maxContactNameLen = 64
+
+var (
+	errContactNotFound = errors.New("contact not found")
+	errContactExists   = errors.New("a contact with that name already exists")
+)
+
+// validateContactName checks that the name can be used as a contact name.
+// A name that is itself an address is rejected so that a contact can never
+// be mistaken for the address it spells.
+func validateContactName(name string, chainParams *params.NetworkParams) error {
+	if name == "" {
+		return errors.New("contact name is required")
+	}
+	if len(name) > maxContactNameLen {
+		return errors.New("contact name too long")
+	}
+	if strings.Contains(name, "/") {
+		return errors.New("contact name may not contain '/'")
+	}
+	if name != strings.TrimSpace(name) {
+		return errors.New("contact name may not begin or end with whitespace")
+	}
+	if _, err := walletlib.DecodeAddress(name, chainParams); err == nil {
+		return errors.New("contact name may not be an address")
+	}
+	return nil
+}
+
+// contactKey returns the datastore key for the contact. Names are
+// case insensitive so "Alice" and "alice" are the same contact.
+func contactKey(name string) datastore.Key {
+	return datastore.NewKey(repo.ContactDatastoreKeyPrefix + strings.ToLower(name))
+}
+
+// addContact saves a new contact. It fails if a contact with the same
+// name already exists rather than silently replacing its address.
+func addContact(ds repo.Datastore, contact *pb.Contact) error {
+	key := contactKey(contact.Name)
+	exists, err := ds.Has(context.Background(), key)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errContactExists
+	}
+	ser, err := proto.Marshal(contact)
+	if err != nil {
+		return err
+	}
+	return ds.Put(context.Background(), key, ser)
+}
+
+func getContact(ds repo.Datastore, name string) (*pb.Contact, error) {
+	ser, err := ds.Get(context.Background(), contactKey(name))
+	if errors.Is(err, datastore.ErrNotFound) {
+		return nil, errContactNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	var contact pb.Contact
+	if err := proto.Unmarshal(ser, &contact); err != nil {
+		return nil, err
+	}
+	return &contact, nil
+}
+
+// getContacts returns the contacts sorted by name.
+func getContacts(ds repo.Datastore) ([]*pb.Contact, error) {
+	results, err := ds.Query(context.Background(), query.Query{
+		Prefix: repo.ContactDatastoreKeyPrefix,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer results.Close()
+
+	var contacts []*pb.Contact
+	for result, ok := results.NextSync(); ok; result, ok = results.NextSync() {
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		var contact pb.Contact
+		if err := proto.Unmarshal(result.Value, &contact); err != nil {
+			return nil, err
+		}
+		contacts = append(contacts, &contact)
+	}
+	sort.Slice(contacts, func(i, j int) bool {
+		return strings.ToLower(contacts[i].Name) < strings.ToLower(contacts[j].Name)
+	})
+	return contacts, nil
+}
+
+func deleteContact(ds repo.Datastore, name string) error {
+	key := contactKey(name)
+	exists, err := ds.Has(context.Background(), key)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errContactNotFound
+	}
+	return ds.Delete(context.Background(), key)
+}
+
+// resolveContact returns the address of the contact. The address is
+// checked against the network again as the address book may have been
+// copied from a node on a different network.
+func resolveContact(ds repo.Datastore, name string, chainParams *params.NetworkParams) (walletlib.Address, error) {
+	contact, err := getContact(ds, name)
+	if err != nil {
+		return nil, err
+	}
+	addr, err := decodeAddress(contact.Address, chainParams)
+	if err != nil {
+		return nil, fmt.Errorf("contact %s: %w", contact.Name, err)
+	}
+	return addr, nil
+}
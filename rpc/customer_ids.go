@@ -0,0 +1,56 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"github.com/ipfs/go-datastore"
+	"github.com/project-illium/ilxd/repo"
+	"github.com/project-illium/ilxd/rpc/pb"
+)
+
+// maxCustomerIDLen is the maximum length of a customer identifier in bytes.
+const maxCustomerIDLen = 256
+
+func putCustomerID(ds repo.Datastore, addr string, customerID string) error {
+	if len(customerID) > maxCustomerIDLen {
+		return errors.New("customer ID too long")
+	}
+	key := datastore.NewKey(repo.CustomerIDDatastoreKeyPrefix + addr)
+	if customerID == "" {
+		return ds.Delete(context.Background(), key)
+	}
+	return ds.Put(context.Background(), key, []byte(customerID))
+}
+
+// getCustomerID returns the customer identifier associated with the
+// address or an empty string if there is none.
+func getCustomerID(ds repo.Datastore, addr string) (string, error) {
+	b, err := ds.Get(context.Background(), datastore.NewKey(repo.CustomerIDDatastoreKeyPrefix+addr))
+	if errors.Is(err, datastore.ErrNotFound) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// setCustomerIDs fills in the customer identifier for each of the
+// inputs and outputs whose address has one.
+func setCustomerIDs(ds repo.Datastore, ios []*pb.IOMetadata) error {
+	for _, io := range ios {
+		txio := io.GetTxIo()
+		if txio == nil {
+			continue
+		}
+		customerID, err := getCustomerID(ds, txio.Address)
+		if err != nil {
+			return err
+		}
+		txio.Customer_ID = customerID
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"bytes"
+	"errors"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	icrypto "github.com/project-illium/ilxd/crypto"
+	"github.com/project-illium/ilxd/types"
+	"github.com/project-illium/ilxd/types/transactions"
+	"github.com/project-illium/ilxd/zk"
+	"github.com/project-illium/ilxd/zk/circparams"
+	"github.com/project-illium/walletlib"
+	walletpb "github.com/project-illium/walletlib/pb"
+)
+
+// delegatedStakeTemplate is the name of the script template used for
+// cold staking addresses.
+const delegatedStakeTemplate = "delegatedstake"
+
+// delegatedStakeLockingScript returns the locking script for a cold staking
+// address which the owner key can spend and the delegate key can stake.
+func delegatedStakeLockingScript(ownerKey, delegateKey crypto.PubKey) (types.LockingScript, error) {
+	tmpl, err := zk.GetScriptTemplate(delegatedStakeTemplate)
+	if err != nil {
+		return types.LockingScript{}, err
+	}
+	scriptCommitment := tmpl.ScriptCommitment()
+	if len(scriptCommitment) == 0 {
+		return types.LockingScript{}, zk.ErrProverUnavailable
+	}
+	ownerNovaKey, ok := ownerKey.(*icrypto.NovaPublicKey)
+	if !ok {
+		return types.LockingScript{}, errors.New("owner key is not type Nova public key")
+	}
+	delegateNovaKey, ok := delegateKey.(*icrypto.NovaPublicKey)
+	if !ok {
+		return types.LockingScript{}, errors.New("delegate key is not type Nova public key")
+	}
+	ownerX, ownerY := ownerNovaKey.ToXY()
+	delegateX, delegateY := delegateNovaKey.ToXY()
+
+	return types.LockingScript{
+		ScriptCommitment: types.NewID(scriptCommitment),
+		LockingParams:    [][]byte{ownerX, ownerY, delegateX, delegateY},
+	}, nil
+}
+
+// isDelegatedStake returns whether the note is locked with the cold
+// staking script.
+func isDelegatedStake(note *walletpb.SpendNote) bool {
+	if note.LockingScript == nil || len(note.LockingScript.LockingParams) != 4 {
+		return false
+	}
+	tmpl, err := zk.GetScriptTemplate(delegatedStakeTemplate)
+	if err != nil {
+		return false
+	}
+	scriptCommitment := tmpl.ScriptCommitment()
+	return len(scriptCommitment) > 0 && bytes.Equal(note.LockingScript.ScriptCommitment, scriptCommitment)
+}
+
+// buildDelegatedStake builds and proves a stake transaction for a cold
+// staking note. The owner key is used if it's in the wallet, otherwise
+// the note is staked with the delegate key.
+func (s *GrpcServer) buildDelegatedStake(note *walletpb.SpendNote, keys map[walletlib.WalletPrivateKey]walletlib.Address) (*transactions.Transaction, error) {
+	tmpl, err := zk.GetScriptTemplate(delegatedStakeTemplate)
+	if err != nil {
+		return nil, err
+	}
+	params := note.LockingScript.LockingParams
+	makeUnlockingParams := zk.MakeDelegatedStakeOwnerParams
+	spendKey, _, ok := walletKeyForPubkey(keys, params[0], params[1])
+	if !ok {
+		makeUnlockingParams = zk.MakeDelegatedStakeParams
+		spendKey, _, ok = walletKeyForPubkey(keys, params[2], params[3])
+		if !ok {
+			return nil, errors.New("neither the owner nor the delegate key of the delegated stake is in the wallet")
+		}
+	}
+
+	rawTx, err := s.wallet.CreateRawStakeTransaction(&walletlib.RawInput{Commitment: note.Commitment})
+	if err != nil {
+		return nil, err
+	}
+	stakeTx := rawTx.Tx.GetStakeTransaction()
+	if stakeTx.LockedUntil != 0 {
+		return nil, errors.New("delegated stake cannot be timelocked")
+	}
+	sighash, err := stakeTx.SigHash()
+	if err != nil {
+		return nil, err
+	}
+	sig, err := spendKey.Sign(sighash)
+	if err != nil {
+		return nil, err
+	}
+	unlockingParams, err := makeUnlockingParams(sig)
+	if err != nil {
+		return nil, err
+	}
+	privateParams := circparams.StakePrivateParams(rawTx.PrivateInputs[0])
+	privateParams.Script = tmpl.Script()
+	privateParams.UnlockingParams = unlockingParams
+
+	publicParams, err := stakeTx.ToCircuitParams()
+	if err != nil {
+		return nil, err
+	}
+	proof, err := s.prover.Prove(zk.StakeValidationProgram(), &privateParams, publicParams)
+	if err != nil {
+		return nil, err
+	}
+	stakeTx.Proof = proof
+	return transactions.WrapTransaction(stakeTx), nil
+}
+
+// buildDelegationRevoke builds and proves a transaction spending a cold
+// staking note to the wallet address with the owner key.
+func (s *GrpcServer) buildDelegationRevoke(note *walletpb.SpendNote, toAddr walletlib.Address, ownerKey crypto.PrivKey, feePerKB types.Amount) (*transactions.Transaction, error) {
+	tmpl, err := zk.GetScriptTemplate(delegatedStakeTemplate)
+	if err != nil {
+		return nil, err
+	}
+	if feePerKB == 0 {
+		feePerKB = s.policy.GetMinFeePerKilobyte()
+	}
+	// The wallet always computes the fee for two outputs.
+	fee := walletlib.ComputeFee(1, 2, feePerKB)
+	if types.Amount(note.Amount) <= fee {
+		return nil, errors.New("delegated amount does not cover the fee")
+	}
+	outputs := []*walletlib.RawOutput{{Addr: toAddr, Amount: types.Amount(note.Amount) - fee}}
+	rawTx, err := s.wallet.CreateRawTransaction([]*walletlib.RawInput{{Commitment: note.Commitment}}, outputs, false, feePerKB)
+	if err != nil {
+		return nil, err
+	}
+	standardTx := rawTx.Tx.GetStandardTransaction()
+
+	sighash, err := standardTx.SigHash()
+	if err != nil {
+		return nil, err
+	}
+	sig, err := ownerKey.Sign(sighash)
+	if err != nil {
+		return nil, err
+	}
+	unlockingParams, err := zk.MakeDelegatedStakeOwnerParams(sig)
+	if err != nil {
+		return nil, err
+	}
+	rawTx.PrivateInputs[0].Script = tmpl.Script()
+	rawTx.PrivateInputs[0].UnlockingParams = unlockingParams
+
+	privateParams := &circparams.StandardPrivateParams{
+		Inputs:  rawTx.PrivateInputs,
+		Outputs: rawTx.PrivateOutputs,
+	}
+	publicParams, err := standardTx.ToCircuitParams()
+	if err != nil {
+		return nil, err
+	}
+	proof, err := s.prover.Prove(zk.StandardValidationProgram(), privateParams, publicParams)
+	if err != nil {
+		return nil, err
+	}
+	standardTx.Proof = proof
+	return transactions.WrapTransaction(standardTx), nil
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"github.com/project-illium/ilxd/params/hash"
+	"github.com/project-illium/ilxd/rpc/pb"
+	"github.com/project-illium/ilxd/types"
+	"github.com/project-illium/walletlib"
+	walletpb "github.com/project-illium/walletlib/pb"
+	"google.golang.org/protobuf/proto"
+	mrand "math/rand"
+	"strings"
+)
+
+const (
+	// descriptorPrefix is prepended to encoded account descriptors so
+	// they can be recognized and so the format can be versioned.
+	descriptorPrefix = "ilxdesc1:"
+
+	descriptorChecksumLen = 4
+)
+
+// encodeDescriptor serializes the descriptor as the prefix followed by
+// the base64 encoding of the protobuf and a checksum.
+func encodeDescriptor(desc *pb.AccountDescriptor) (string, error) {
+	ser, err := proto.Marshal(desc)
+	if err != nil {
+		return "", err
+	}
+	checksum := hash.HashFunc(ser)[:descriptorChecksumLen]
+	return descriptorPrefix + base64.RawURLEncoding.EncodeToString(append(ser, checksum...)), nil
+}
+
+// decodeDescriptor parses an encoded descriptor and verifies its checksum.
+func decodeDescriptor(s string) (*pb.AccountDescriptor, error) {
+	if !strings.HasPrefix(s, descriptorPrefix) {
+		return nil, errors.New("unknown descriptor format")
+	}
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(s, descriptorPrefix))
+	if err != nil {
+		return nil, err
+	}
+	if len(b) < descriptorChecksumLen {
+		return nil, errors.New("descriptor too short")
+	}
+	ser, checksum := b[:len(b)-descriptorChecksumLen], b[len(b)-descriptorChecksumLen:]
+	if !bytes.Equal(hash.HashFunc(ser)[:descriptorChecksumLen], checksum) {
+		return nil, errors.New("invalid descriptor checksum")
+	}
+	desc := new(pb.AccountDescriptor)
+	if err := proto.Unmarshal(ser, desc); err != nil {
+		return nil, err
+	}
+	return desc, nil
+}
+
+// buildWatchOnlyTransaction builds an unsigned transaction spending the
+// given wallet notes with the change sent to changeAddr rather than to
+// this wallet. Unlike the wallet's own input selection the notes may be
+// watch-only.
+func (s *GrpcServer) buildWatchOnlyTransaction(commitments [][]byte, outputs []*walletlib.RawOutput, changeAddr walletlib.Address, feePerKB types.Amount) (*walletlib.RawTransaction, error) {
+	notes, err := s.wallet.Notes()
+	if err != nil {
+		return nil, err
+	}
+	noteMap := make(map[types.ID]*walletpb.SpendNote, len(notes))
+	for _, note := range notes {
+		noteMap[types.NewID(note.Commitment)] = note
+	}
+	selected := make([]*walletpb.SpendNote, 0, len(commitments))
+	for _, commitment := range commitments {
+		note, ok := noteMap[types.NewID(commitment)]
+		if !ok {
+			return nil, errors.New("input commitment not found in wallet")
+		}
+		selected = append(selected, note)
+	}
+
+	inputSource := func(amount types.Amount) (types.Amount, []*walletpb.SpendNote, error) {
+		total := types.Amount(0)
+		for i, note := range selected {
+			total += types.Amount(note.Amount)
+			if total > amount {
+				return total, selected[:i+1], nil
+			}
+		}
+		return total, selected, nil
+	}
+	changeSource := func() (walletlib.Address, error) {
+		return changeAddr, nil
+	}
+	if feePerKB == 0 {
+		feePerKB = s.policy.GetMinFeePerKilobyte()
+	}
+
+	rawTx, err := walletlib.BuildTransaction(outputs, inputSource, changeSource, s.wallet.GetInclusionProofs, feePerKB)
+	if err != nil {
+		return nil, err
+	}
+
+	// Randomize the input and output order so the change
+	// output can't be identified by its position.
+	if stx := rawTx.Tx.GetStandardTransaction(); stx != nil {
+		mrand.Shuffle(len(stx.Nullifiers), func(i, j int) {
+			stx.Nullifiers[i], stx.Nullifiers[j] = stx.Nullifiers[j], stx.Nullifiers[i]
+			rawTx.PrivateInputs[i], rawTx.PrivateInputs[j] = rawTx.PrivateInputs[j], rawTx.PrivateInputs[i]
+		})
+		mrand.Shuffle(len(stx.Outputs), func(i, j int) {
+			stx.Outputs[i], stx.Outputs[j] = stx.Outputs[j], stx.Outputs[i]
+			rawTx.PrivateOutputs[i], rawTx.PrivateOutputs[j] = rawTx.PrivateOutputs[j], rawTx.PrivateOutputs[i]
+		})
+	}
+	return rawTx, nil
+}
@@ -0,0 +1,447 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	icrypto "github.com/project-illium/ilxd/crypto"
+	"github.com/project-illium/ilxd/params"
+	"github.com/project-illium/ilxd/repo"
+	"github.com/project-illium/ilxd/rpc/pb"
+	"github.com/project-illium/ilxd/types"
+	"github.com/project-illium/ilxd/types/blocks"
+	"github.com/project-illium/ilxd/types/transactions"
+	"github.com/project-illium/walletlib"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	// webhookAttempts is the number of times delivery of a
+	// webhook is attempted before it is dropped.
+	webhookAttempts = 5
+
+	// webhookTimeout is the timeout for each webhook request.
+	webhookTimeout = time.Second * 10
+
+	// invoiceExpiryInterval is how often pending invoices are
+	// checked for expiry.
+	invoiceExpiryInterval = time.Minute
+)
+
+// invoiceWatch holds what is needed to match transaction outputs
+// to an open invoice.
+type invoiceWatch struct {
+	scriptHash types.ID
+	viewKey    *icrypto.Curve25519PrivateKey
+}
+
+// invoiceTracker tracks payments to the wallet's merchant invoices.
+//
+// Each invoice is paid to its own one-time address so any output to
+// that address is counted as payment. Outputs are matched as their
+// transactions are accepted into the mempool, included in a block
+// under consensus, and finalized. The invoice status only moves
+// forward with one exception: a payment in a block which loses the
+// vote drops back to PAID.
+type invoiceTracker struct {
+	ds          repo.Datastore
+	wallet      *walletlib.Wallet
+	chainParams *params.NetworkParams
+	watches     map[types.ID]*invoiceWatch
+	client      *http.Client
+	quit        chan struct{}
+	mtx         sync.Mutex
+}
+
+// newInvoiceTracker returns a new invoiceTracker watching all the
+// invoices in the datastore that are still open.
+func newInvoiceTracker(ds repo.Datastore, wallet *walletlib.Wallet, chainParams *params.NetworkParams, quit chan struct{}) (*invoiceTracker, error) {
+	t := &invoiceTracker{
+		ds:          ds,
+		wallet:      wallet,
+		chainParams: chainParams,
+		watches:     make(map[types.ID]*invoiceWatch),
+		client:      &http.Client{Timeout: webhookTimeout},
+		quit:        quit,
+		mtx:         sync.Mutex{},
+	}
+	invoices, err := t.getInvoices()
+	if err != nil {
+		return nil, err
+	}
+	for _, inv := range invoices {
+		if isOpenInvoice(inv) {
+			if err := t.watch(inv); err != nil {
+				return nil, err
+			}
+		}
+	}
+	go t.expiryHandler()
+	return t, nil
+}
+
+// CreateInvoice creates, saves, and starts watching a new invoice paid
+// to a fresh wallet address.
+func (t *invoiceTracker) CreateInvoice(amount types.Amount, memo string, expiry time.Duration, webhookURL string) (*pb.Invoice, error) {
+	addr, err := t.wallet.NewAddress()
+	if err != nil {
+		return nil, err
+	}
+	id := make([]byte, 32)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	inv := &pb.Invoice{
+		Invoice_ID:  id,
+		Address:     addr.String(),
+		Amount:      uint64(amount),
+		Memo:        memo,
+		Created:     now.Unix(),
+		Expires:     now.Add(expiry).Unix(),
+		Status:      pb.Invoice_PENDING,
+		Webhook_URL: webhookURL,
+	}
+	uri := types.PaymentURI{
+		Address: inv.Address,
+		Amount:  amount,
+		Memo:    memo,
+		Expires: time.Unix(inv.Expires, 0),
+	}
+	inv.Payment_URI = uri.String()
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	if err := t.putInvoice(inv); err != nil {
+		return nil, err
+	}
+	if err := t.watch(inv); err != nil {
+		return nil, err
+	}
+	return inv, nil
+}
+
+// GetInvoice returns the invoice with the given ID.
+func (t *invoiceTracker) GetInvoice(id types.ID) (*pb.Invoice, error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	return t.getInvoice(id)
+}
+
+// ProcessTransaction records any payments made by a transaction that
+// was accepted into the mempool.
+func (t *invoiceTracker) ProcessTransaction(tx *transactions.Transaction) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	t.processTransactions([]*transactions.Transaction{tx}, pb.Invoice_PAID, nil)
+}
+
+// ProcessBlock records any payments made by the transactions in a
+// block. If the block is finalized, payments that were confirmed in
+// a competing block at the same height are moved back to PAID.
+func (t *invoiceTracker) ProcessBlock(blk *blocks.Block, finalized bool) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	status := pb.Invoice_CONFIRMED
+	if finalized {
+		status = pb.Invoice_FINALIZED
+	}
+	t.processTransactions(blk.Transactions, status, blk)
+
+	if !finalized {
+		return
+	}
+	blockID := blk.ID()
+	for id := range t.watches {
+		inv, err := t.getInvoice(id)
+		if err != nil {
+			continue
+		}
+		changed := false
+		for _, p := range inv.Payments {
+			if p.Status == pb.Invoice_CONFIRMED && p.Height == blk.Header.Height && !bytes.Equal(p.Block_ID, blockID[:]) {
+				p.Status = pb.Invoice_PAID
+				p.Block_ID = nil
+				p.Height = 0
+				changed = true
+			}
+		}
+		if changed {
+			t.updateInvoice(inv, time.Now())
+		}
+	}
+}
+
+// processTransactions matches the transaction outputs against the open
+// invoices and moves the matching payments up to the given status.
+//
+// This method is NOT safe for concurrent access.
+func (t *invoiceTracker) processTransactions(txs []*transactions.Transaction, status pb.Invoice_Status, blk *blocks.Block) {
+	if len(t.watches) == 0 {
+		return
+	}
+	for _, tx := range txs {
+		paid := make(map[types.ID]types.Amount)
+		for _, out := range tx.Outputs() {
+			for id, w := range t.watches {
+				note, err := openOutput(out, []*icrypto.Curve25519PrivateKey{w.viewKey})
+				if err != nil {
+					continue
+				}
+				if note.ScriptHash != w.scriptHash || note.AssetID != types.IlliumCoinID {
+					continue
+				}
+				paid[id] += note.Amount
+			}
+		}
+		txid := tx.ID()
+		for id, amount := range paid {
+			inv, err := t.getInvoice(id)
+			if err != nil {
+				continue
+			}
+			var payment *pb.Invoice_Payment
+			for _, p := range inv.Payments {
+				if bytes.Equal(p.Transaction_ID, txid[:]) {
+					payment = p
+					break
+				}
+			}
+			if payment == nil {
+				payment = &pb.Invoice_Payment{
+					Transaction_ID: txid[:],
+					Amount:         uint64(amount),
+				}
+				inv.Payments = append(inv.Payments, payment)
+			} else if payment.Status >= status {
+				continue
+			}
+			payment.Status = status
+			if blk != nil {
+				blockID := blk.ID()
+				payment.Block_ID = blockID[:]
+				payment.Height = blk.Header.Height
+			}
+			t.updateInvoice(inv, time.Now())
+		}
+	}
+}
+
+// updateInvoice recomputes the invoice status, saves it, and notifies
+// the webhook if the status changed. Invoices which are finalized or
+// expired are no longer watched.
+//
+// This method is NOT safe for concurrent access.
+func (t *invoiceTracker) updateInvoice(inv *pb.Invoice, now time.Time) {
+	oldStatus := inv.Status
+	inv.AmountPaid = 0
+	for _, p := range inv.Payments {
+		inv.AmountPaid += p.Amount
+	}
+	inv.Status = invoiceStatus(inv, now)
+	if err := t.putInvoice(inv); err != nil {
+		return
+	}
+	if !isOpenInvoice(inv) {
+		delete(t.watches, types.NewID(inv.Invoice_ID))
+	}
+	if inv.Status != oldStatus && inv.Webhook_URL != "" {
+		go t.sendWebhook(proto.Clone(inv).(*pb.Invoice))
+	}
+}
+
+// expiryHandler periodically expires the pending invoices which
+// have passed their expiry time.
+func (t *invoiceTracker) expiryHandler() {
+	ticker := time.NewTicker(invoiceExpiryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			t.mtx.Lock()
+			now := time.Now()
+			for id := range t.watches {
+				inv, err := t.getInvoice(id)
+				if err != nil {
+					continue
+				}
+				if invoiceStatus(inv, now) != inv.Status {
+					t.updateInvoice(inv, now)
+				}
+			}
+			t.mtx.Unlock()
+		case <-t.quit:
+			return
+		}
+	}
+}
+
+// sendWebhook POSTs the JSON encoded invoice to its webhook URL. Any
+// 2xx response is treated as success. Failed deliveries are retried
+// with backoff and then dropped. Merchants that need certainty should
+// fall back to polling GetInvoiceStatus.
+func (t *invoiceTracker) sendWebhook(inv *pb.Invoice) {
+	body, err := protojson.Marshal(inv)
+	if err != nil {
+		return
+	}
+	backoff := time.Second
+	for i := 0; i < webhookAttempts; i++ {
+		resp, err := t.client.Post(inv.Webhook_URL, "application/json", bytes.NewReader(body))
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+				return
+			}
+		}
+		select {
+		case <-time.After(backoff):
+			backoff *= 2
+		case <-t.quit:
+			return
+		}
+	}
+}
+
+// watch starts matching outputs against the invoice's address.
+//
+// This method is NOT safe for concurrent access.
+func (t *invoiceTracker) watch(inv *pb.Invoice) error {
+	addr, err := decodeAddress(inv.Address, t.chainParams)
+	if err != nil {
+		return err
+	}
+	addrInfo, err := t.wallet.AddressInfo(addr)
+	if err != nil {
+		return err
+	}
+	privKey, err := crypto.UnmarshalPrivateKey(addrInfo.ViewPrivKey)
+	if err != nil {
+		return err
+	}
+	viewKey, ok := privKey.(*icrypto.Curve25519PrivateKey)
+	if !ok {
+		return errors.New("invalid view key type")
+	}
+	t.watches[types.NewID(inv.Invoice_ID)] = &invoiceWatch{
+		scriptHash: types.ID(addr.ScriptHash()),
+		viewKey:    viewKey,
+	}
+	return nil
+}
+
+func (t *invoiceTracker) putInvoice(inv *pb.Invoice) error {
+	ser, err := proto.Marshal(inv)
+	if err != nil {
+		return err
+	}
+	return t.ds.Put(context.Background(), datastore.NewKey(repo.InvoiceDatastoreKeyPrefix+types.NewID(inv.Invoice_ID).String()), ser)
+}
+
+func (t *invoiceTracker) getInvoice(id types.ID) (*pb.Invoice, error) {
+	ser, err := t.ds.Get(context.Background(), datastore.NewKey(repo.InvoiceDatastoreKeyPrefix+id.String()))
+	if err != nil {
+		return nil, err
+	}
+	var inv pb.Invoice
+	if err := proto.Unmarshal(ser, &inv); err != nil {
+		return nil, err
+	}
+	return &inv, nil
+}
+
+func (t *invoiceTracker) getInvoices() ([]*pb.Invoice, error) {
+	results, err := t.ds.Query(context.Background(), query.Query{
+		Prefix: repo.InvoiceDatastoreKeyPrefix,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer results.Close()
+
+	var invoices []*pb.Invoice
+	for result, ok := results.NextSync(); ok; result, ok = results.NextSync() {
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		var inv pb.Invoice
+		if err := proto.Unmarshal(result.Value, &inv); err != nil {
+			return nil, err
+		}
+		invoices = append(invoices, &inv)
+	}
+	return invoices, nil
+}
+
+// invoiceStatus computes the status of the invoice from its payments.
+// The invoice takes the highest status that payments totaling the full
+// amount have reached. Once an invoice has expired it stays expired;
+// payments sent after expiry are still received by the wallet but are
+// not credited to the invoice.
+func invoiceStatus(inv *pb.Invoice, now time.Time) pb.Invoice_Status {
+	if inv.Status == pb.Invoice_EXPIRED {
+		return pb.Invoice_EXPIRED
+	}
+	var paid, confirmed, finalized uint64
+	for _, p := range inv.Payments {
+		switch p.Status {
+		case pb.Invoice_FINALIZED:
+			finalized += p.Amount
+			confirmed += p.Amount
+			paid += p.Amount
+		case pb.Invoice_CONFIRMED:
+			confirmed += p.Amount
+			paid += p.Amount
+		case pb.Invoice_PAID:
+			paid += p.Amount
+		}
+	}
+	switch {
+	case finalized >= inv.Amount:
+		return pb.Invoice_FINALIZED
+	case confirmed >= inv.Amount:
+		return pb.Invoice_CONFIRMED
+	case paid >= inv.Amount:
+		return pb.Invoice_PAID
+	case now.After(time.Unix(inv.Expires, 0)):
+		return pb.Invoice_EXPIRED
+	}
+	return pb.Invoice_PENDING
+}
+
+// isOpenInvoice returns whether payments to the invoice are still tracked.
+func isOpenInvoice(inv *pb.Invoice) bool {
+	return inv.Status != pb.Invoice_FINALIZED && inv.Status != pb.Invoice_EXPIRED
+}
+
+func validateWebhookURL(webhookURL string) error {
+	u, err := url.Parse(webhookURL)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported webhook scheme %s", u.Scheme)
+	}
+	if u.Host == "" {
+		return errors.New("webhook URL has no host")
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	icrypto "github.com/project-illium/ilxd/crypto"
+	"github.com/project-illium/walletlib"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// deleteNonceTTL is how long a nonce returned by GetDeletePrivateKeysNonce
+// remains valid.
+const deleteNonceTTL = time.Minute
+
+// keyBackup is the plaintext of the backup written before the wallet's
+// private keys are deleted.
+type keyBackup struct {
+	Network      string         `json:"network"`
+	Created      time.Time      `json:"created"`
+	MnemonicSeed string         `json:"mnemonicSeed,omitempty"`
+	Keys         []keyBackupKey `json:"keys"`
+}
+
+type keyBackupKey struct {
+	Address    string `json:"address"`
+	PrivateKey string `json:"privateKey"`
+}
+
+// newDeleteNonce generates a new nonce for DeletePrivateKeys, replacing
+// any previous one.
+func (s *GrpcServer) newDeleteNonce() ([]byte, time.Time, error) {
+	nonce := make([]byte, 32)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, time.Time{}, err
+	}
+	expires := time.Now().Add(deleteNonceTTL)
+
+	s.deleteNonceMtx.Lock()
+	defer s.deleteNonceMtx.Unlock()
+
+	s.deleteNonce = nonce
+	s.deleteNonceExpires = expires
+	return nonce, expires, nil
+}
+
+// useDeleteNonce returns whether the nonce matches the current unexpired
+// nonce. The nonce is cleared either way so each one can only be tried once.
+func (s *GrpcServer) useDeleteNonce(nonce []byte) bool {
+	s.deleteNonceMtx.Lock()
+	defer s.deleteNonceMtx.Unlock()
+
+	valid := s.deleteNonce != nil &&
+		subtle.ConstantTimeCompare(s.deleteNonce, nonce) == 1 &&
+		time.Now().Before(s.deleteNonceExpires)
+	s.deleteNonce = nil
+	return valid
+}
+
+// writeKeyBackup writes the wallet's seed and private keys, encrypted with
+// the passphrase, to a new file in the key backup directory and returns
+// the path of the file.
+func (s *GrpcServer) writeKeyBackup(passphrase string) (string, error) {
+	seed, err := s.wallet.MnemonicSeed()
+	if err != nil {
+		return "", err
+	}
+	keys, err := s.wallet.PrivateKeys()
+	if err != nil {
+		return "", err
+	}
+
+	backup := keyBackup{
+		Network:      s.chainParams.Name,
+		Created:      time.Now(),
+		MnemonicSeed: seed,
+		Keys:         make([]keyBackupKey, 0, len(keys)),
+	}
+	for key, addr := range keys {
+		key := key
+		backup.Keys = append(backup.Keys, keyBackupKey{
+			Address:    addr.String(),
+			PrivateKey: walletlib.EncodePrivateKey(&key),
+		})
+	}
+	sort.Slice(backup.Keys, func(i, j int) bool {
+		return backup.Keys[i].Address < backup.Keys[j].Address
+	})
+
+	plaintext, err := json.Marshal(&backup)
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := icrypto.EncryptWithPassphrase(plaintext, passphrase)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(s.keyBackupDir, 0700); err != nil {
+		return "", err
+	}
+	path := filepath.Join(s.keyBackupDir, fmt.Sprintf("keybackup-%d.enc", backup.Created.UnixNano()))
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return "", err
+	}
+	if _, err := f.Write(ciphertext); err != nil {
+		f.Close()
+		return "", err
+	}
+	// Make sure the backup is on disk before the keys are deleted.
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+	return path, nil
+}
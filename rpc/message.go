@@ -0,0 +1,64 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"errors"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	icrypto "github.com/project-illium/ilxd/crypto"
+	"github.com/project-illium/ilxd/params"
+	"github.com/project-illium/ilxd/params/hash"
+	"github.com/project-illium/ilxd/types"
+	"github.com/project-illium/ilxd/zk"
+)
+
+// signedMessagePrefix is prepended to messages before they are hashed
+// so that a signed message can never be mistaken for a transaction
+// sighash.
+const signedMessagePrefix = "Illium Signed Message:\n"
+
+// messageDigest returns the digest that is signed by SignMessage.
+func messageDigest(message string) []byte {
+	return hash.HashFunc([]byte(signedMessagePrefix + message))
+}
+
+// verifyMessage checks that the public key is the spend key of the
+// address and that the signature over the message is valid.
+//
+// Only basic addresses are supported. The address only commits to
+// the hash of the locking script, so the public key must be provided
+// by the signer.
+func verifyMessage(addr, message string, sig, pubkey []byte, chainParams *params.NetworkParams) (bool, error) {
+	address, err := decodeAddress(addr, chainParams)
+	if err != nil {
+		return false, err
+	}
+	pub, err := crypto.UnmarshalPublicKey(pubkey)
+	if err != nil {
+		return false, err
+	}
+	novaKey, ok := pub.(*icrypto.NovaPublicKey)
+	if !ok {
+		return false, errors.New("public key is not type Nova public key")
+	}
+	pubX, pubY := novaKey.ToXY()
+
+	scriptCommitment, err := zk.LurkCommit(zk.BasicTransferScript())
+	if err != nil {
+		return false, err
+	}
+	lockingScript := types.LockingScript{
+		ScriptCommitment: types.NewID(scriptCommitment),
+		LockingParams:    [][]byte{pubX, pubY},
+	}
+	scriptHash, err := lockingScript.Hash()
+	if err != nil {
+		return false, err
+	}
+	if scriptHash != address.ScriptHash() {
+		return false, nil
+	}
+	return novaKey.Verify(messageDigest(message), sig)
+}
@@ -6,22 +6,44 @@ package rpc
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/project-illium/ilxd/blockchain"
+	"github.com/project-illium/ilxd/net"
+	"github.com/project-illium/ilxd/params"
 	"github.com/project-illium/ilxd/rpc/pb"
+	"github.com/project-illium/ilxd/tasks"
 	"github.com/project-illium/ilxd/types"
+	"github.com/project-illium/ilxd/zk"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"math/rand"
+	"runtime"
+	"sort"
+	"time"
 )
 
-// GetHostInfo returns info about the libp2p host
+// GetHostInfo returns info about the libp2p host and whether blocks
+// are finalizing.
 func (s *GrpcServer) GetHostInfo(ctx context.Context, req *pb.GetHostInfoRequest) (*pb.GetHostInfoResponse, error) {
 	maaddrs := s.network.Host().Addrs()
 	addrs := make([]string, 0, len(maaddrs))
 	for _, addr := range maaddrs {
 		addrs = append(addrs, addr.String())
 	}
+	offset := s.chain.TimeSource().Offset()
+	var health ConsensusHealth
+	if s.consensusHealthFunc != nil {
+		health = s.consensusHealthFunc()
+	}
+	var zkErr string
+	if err := zk.LoadProverLibrary(); err != nil {
+		zkErr = err.Error()
+	}
+	_, mockProofs := s.prover.(*zk.MockProver)
 	return &pb.GetHostInfoResponse{
 		Peer_ID:       s.network.Host().ID().String(),
 		Addrs:         addrs,
@@ -30,9 +52,82 @@ func (s *GrpcServer) GetHostInfo(ctx context.Context, req *pb.GetHostInfoRequest
 		WalletServer:  s.wsIndex != nil,
 		ProvingServer: s.provingServiceActive,
 		Reachability:  s.network.Reachability().String(),
+		ClockOffset:   offset.Milliseconds(),
+		ClockSkewed:   offset.Abs() > blockchain.MaxClockSkew,
+
+		ConsensusDegraded:     health.Degraded,
+		LastProgress:          health.LastProgress.Unix(),
+		StallRecoveryAttempts: uint32(health.RecoveryAttempts),
+
+		ZkProverAvailable: zkErr == "",
+		ZkProverError:     zkErr,
+		MockProofs:        mockProofs,
 	}, nil
 }
 
+// GetValidatorInfo returns this node's staking status including its stake,
+// unclaimed rewards, the reward it can expect at the start of the next epoch
+// given its current stake weight, and whether autostaking is enabled.
+func (s *GrpcServer) GetValidatorInfo(ctx context.Context, req *pb.GetValidatorInfoRequest) (*pb.GetValidatorInfoResponse, error) {
+	pid := s.network.Host().ID()
+	validatorID, err := pid.Marshal()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	reward, nextEpoch := s.chain.NextValidatorReward()
+	resp := &pb.GetValidatorInfoResponse{
+		Validator_ID: validatorID,
+		NextEpoch:    nextEpoch.Unix(),
+	}
+	if s.autoStakeEnabledFunc != nil {
+		resp.Autostake = s.autoStakeEnabledFunc()
+	}
+
+	validator, err := s.chain.GetValidator(pid)
+	if err != nil {
+		return resp, nil
+	}
+	resp.IsValidator = true
+	resp.TotalStake = uint64(validator.TotalStake)
+	resp.StakeWeight = uint64(validator.WeightedStake)
+	resp.UnclaimedCoins = uint64(validator.UnclaimedCoins)
+	resp.EpochBlocks = validator.EpochBlocks
+	resp.ValidatorSince = validator.ValidatorSince.Unix()
+
+	// The reward is split by stake weight with stake which was added
+	// during the epoch only earning for the fraction of the epoch it
+	// was staked.
+	epochLength := time.Duration(s.chainParams.EpochLength) * time.Second
+	weight := types.Amount(0)
+	for nullifier, stake := range validator.Nullifiers {
+		expiration := stake.Blockstamp.Add(blockchain.ValidatorExpiration)
+		resp.Stake = append(resp.Stake, &pb.Validator_Stake{
+			Nullifier:          nullifier.Bytes(),
+			Amount:             uint64(stake.Amount),
+			TimelockedUntil:    stake.Locktime.Unix(),
+			Expiration:         expiration.Unix(),
+			RestakeEligibility: expiration.Add(-blockchain.RestakePeriod).Unix(),
+		})
+
+		timeSinceStake := nextEpoch.Sub(stake.Blockstamp)
+		if timeSinceStake >= blockchain.ValidatorExpiration {
+			continue
+		}
+		if timeSinceStake >= epochLength {
+			weight += stake.WeightedAmount
+		} else {
+			weight += types.Amount(float64(stake.WeightedAmount) * (timeSinceStake.Seconds() / epochLength.Seconds()))
+		}
+	}
+	sort.Slice(resp.Stake, func(i, j int) bool {
+		return resp.Stake[i].Expiration < resp.Stake[j].Expiration
+	})
+	if totalWeight := s.chain.TotalStakeWeight(); totalWeight > 0 && !validator.CoinbasePenalty {
+		resp.ExpectedEpochReward = uint64(float64(reward) * (float64(weight) / float64(totalWeight)))
+	}
+	return resp, nil
+}
+
 // GetNetworkKey returns the node's network private key
 func (s *GrpcServer) GetNetworkKey(ctx context.Context, req *pb.GetNetworkKeyRequest) (*pb.GetNetworkKeyResponse, error) {
 	key, err := s.networkKeyFunc()
@@ -145,6 +240,156 @@ func (s *GrpcServer) UnblockPeer(ctx context.Context, req *pb.UnblockPeerRequest
 	return &pb.UnblockPeerResponse{}, nil
 }
 
+// GetAccessLists returns the peer IDs and IP ranges in the connection
+// allow and deny lists.
+func (s *GrpcServer) GetAccessLists(ctx context.Context, req *pb.GetAccessListsRequest) (*pb.GetAccessListsResponse, error) {
+	return &pb.GetAccessListsResponse{
+		Allow: s.network.ConnGater().AccessRules(net.AllowList),
+		Deny:  s.network.ConnGater().AccessRules(net.DenyList),
+	}, nil
+}
+
+// UpdateAccessList adds or removes peer IDs and IP ranges from the allow
+// or deny list.
+func (s *GrpcServer) UpdateAccessList(ctx context.Context, req *pb.UpdateAccessListRequest) (*pb.UpdateAccessListResponse, error) {
+	list := net.AllowList
+	if req.List == pb.UpdateAccessListRequest_DENY {
+		list = net.DenyList
+	}
+	for _, rule := range append(req.Add, req.Remove...) {
+		if _, err := net.ParseAccessRule(rule); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%s: %s", rule, err)
+		}
+	}
+	for _, rule := range req.Add {
+		if err := s.network.ConnGater().AddAccessRule(list, rule); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+	for _, rule := range req.Remove {
+		if err := s.network.ConnGater().RemoveAccessRule(list, rule); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+	s.network.EnforceAccessLists()
+	return &pb.UpdateAccessListResponse{}, nil
+}
+
+// GetConsensusPeerScores returns the reliability scores the consensus
+// engine keeps for the validators it polls.
+func (s *GrpcServer) GetConsensusPeerScores(ctx context.Context, req *pb.GetConsensusPeerScoresRequest) (*pb.GetConsensusPeerScoresResponse, error) {
+	if s.peerScoresFunc == nil {
+		return nil, status.Error(codes.Unavailable, "consensus engine not running")
+	}
+	scores := s.peerScoresFunc()
+	resp := &pb.GetConsensusPeerScoresResponse{
+		Scores: make([]*pb.ConsensusPeerScore, 0, len(scores)),
+	}
+	for _, score := range scores {
+		ps := &pb.ConsensusPeerScore{
+			Peer_ID:    score.Peer.String(),
+			Score:      score.Score,
+			Responses:  score.Responses,
+			Timeouts:   score.Timeouts,
+			FlipFlops:  score.FlipFlops,
+			AvgLatency: score.AvgLatency.Milliseconds(),
+		}
+		if !score.BannedUntil.IsZero() {
+			ps.BannedUntil = score.BannedUntil.Unix()
+		}
+		resp.Scores = append(resp.Scores, ps)
+	}
+	return resp, nil
+}
+
+// GetConsensusState returns the consensus engine's vote records for the
+// heights it's voting on, the number of polls in flight, and a histogram
+// of how long blocks have taken to finalize.
+func (s *GrpcServer) GetConsensusState(ctx context.Context, req *pb.GetConsensusStateRequest) (*pb.GetConsensusStateResponse, error) {
+	if s.consensusStateFunc == nil {
+		return nil, status.Error(codes.Unavailable, "consensus engine not running")
+	}
+	state := s.consensusStateFunc()
+	if state == nil {
+		return nil, status.Error(codes.Unavailable, "consensus engine not running")
+	}
+	resp := &pb.GetConsensusStateResponse{
+		FinalizationScore:  uint32(state.FinalizationScore),
+		InflightQueries:    uint32(state.InflightQueries),
+		Heights:            make([]*pb.ConsensusHeightState, 0, len(state.Heights)),
+		QueuedResponses:    uint32(state.Queues.Responses.Queued),
+		QueuedHousekeeping: uint32(state.Queues.Housekeeping.Queued),
+		FinalizationLatency: &pb.ConsensusLatencyHistogram{
+			Buckets: make([]int64, 0, len(state.FinalizationLatency.Buckets)),
+			Counts:  state.FinalizationLatency.Counts,
+			Count:   state.FinalizationLatency.Count,
+			Sum:     state.FinalizationLatency.Sum.Milliseconds(),
+		},
+	}
+	for _, bound := range state.FinalizationLatency.Buckets {
+		resp.FinalizationLatency.Buckets = append(resp.FinalizationLatency.Buckets, bound.Milliseconds())
+	}
+	for _, hs := range state.Heights {
+		h := &pb.ConsensusHeightState{
+			Height:           hs.Height,
+			Blocks:           make([]*pb.ConsensusBlockVoteState, 0, len(hs.Blocks)),
+			ActiveBit:        uint32(hs.ActiveBit),
+			BitConfidence:    uint32(hs.BitConfidence),
+			InflightRequests: uint32(hs.InflightRequests),
+			TotalVotes:       uint64(hs.TotalVotes),
+			Age:              hs.Age.Milliseconds(),
+		}
+		for _, b := range hs.Blocks {
+			h.Blocks = append(h.Blocks, &pb.ConsensusBlockVoteState{
+				Block_ID:   b.ID.Bytes(),
+				Status:     b.Status.String(),
+				Acceptable: b.Acceptable,
+				Confidence: uint32(b.Confidence),
+			})
+		}
+		resp.Heights = append(resp.Heights, h)
+	}
+	return resp, nil
+}
+
+// GetTasks returns the node's running background tasks, such as chain
+// sync, rescans, proofs, and consensus polls, along with when they
+// started and their current state. This can be used to see what the
+// node is doing when it appears to be stuck.
+func (s *GrpcServer) GetTasks(ctx context.Context, req *pb.GetTasksRequest) (*pb.GetTasksResponse, error) {
+	running := tasks.List()
+	resp := &pb.GetTasksResponse{
+		Tasks:      make([]*pb.GetTasksResponse_Task, 0, len(running)),
+		Goroutines: uint32(runtime.NumGoroutine()),
+	}
+	for _, t := range running {
+		resp.Tasks = append(resp.Tasks, &pb.GetTasksResponse_Task{
+			Kind:    t.Kind,
+			Name:    t.Name,
+			State:   t.State,
+			Started: t.Started.Unix(),
+			Updated: t.Updated.Unix(),
+		})
+	}
+	if s.consensusStateFunc != nil {
+		if state := s.consensusStateFunc(); state != nil {
+			now := time.Now()
+			for _, hs := range state.Heights {
+				if hs.InflightRequests == 0 {
+					continue
+				}
+				resp.Tasks = append(resp.Tasks, &pb.GetTasksResponse_Task{
+					Kind:    tasks.KindConsensusPoll,
+					Name:    fmt.Sprintf("height %d", hs.Height),
+					State:   fmt.Sprintf("%d polls in flight, %d votes", hs.InflightRequests, hs.TotalVotes),
+					Started: now.Add(-hs.Age).Unix(),
+				})
+			}
+		}
+	}
+	return resp, nil
+}
+
 // SetLogLevel changes the logging level of the node
 func (s *GrpcServer) SetLogLevel(ctx context.Context, req *pb.SetLogLevelRequest) (*pb.SetLogLevelResponse, error) {
 	var logLevelSeverity = map[pb.SetLogLevelRequest_Level]string{
@@ -235,6 +480,125 @@ func (s *GrpcServer) UpdateTreasuryWhitelist(ctx context.Context, req *pb.Update
 	return &pb.UpdateTreasuryWhitelistResponse{}, nil
 }
 
+// CreateTreasuryProposal drafts a treasury withdrawal paying the provided outputs
+// and creates its zk proof. The proposal is saved and returned along with a
+// serialized copy which can be handed to validators for review. The transaction
+// is not broadcast until SubmitTreasuryProposal is called.
+func (s *GrpcServer) CreateTreasuryProposal(ctx context.Context, req *pb.CreateTreasuryProposalRequest) (*pb.CreateTreasuryProposalResponse, error) {
+	if s.treasuryProposals == nil {
+		return nil, status.Error(codes.Unavailable, "treasury proposals are not available")
+	}
+	proposal, err := s.buildTreasuryProposal(req.Outputs, req.ProposalHash, req.Description)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	ser, err := serializeTreasuryProposal(proposal)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if err := s.treasuryProposals.Put(proposal); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.CreateTreasuryProposalResponse{
+		Proposal:           proposal,
+		SerializedProposal: ser,
+	}, nil
+}
+
+// ImportTreasuryProposal decodes a serialized treasury proposal and checks that
+// the outputs pay the listed addresses the listed amounts and that the treasury
+// can cover the withdrawal. If the checks pass the proposal is saved. If approve
+// is true the transaction is also added to the node's treasury whitelist.
+func (s *GrpcServer) ImportTreasuryProposal(ctx context.Context, req *pb.ImportTreasuryProposalRequest) (*pb.ImportTreasuryProposalResponse, error) {
+	if s.treasuryProposals == nil {
+		return nil, status.Error(codes.Unavailable, "treasury proposals are not available")
+	}
+	proposal := new(pb.TreasuryProposal)
+	if err := proto.Unmarshal(req.SerializedProposal, proposal); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.checkTreasuryProposal(proposal); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	proposal.Status = pb.TreasuryProposal_DRAFT
+	proposal.Approvals = nil
+	proposal.Created = time.Now().Unix()
+	if err := s.treasuryProposals.Put(proposal); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if req.Approve {
+		s.policy.AddToTreasuryWhitelist(types.NewID(proposal.Proposal_ID))
+	}
+	return &pb.ImportTreasuryProposalResponse{
+		Proposal: proposal,
+	}, nil
+}
+
+// CollectTreasuryApprovals queries the validators for their treasury whitelists
+// and records the validators, and the fraction of the stake, which have approved
+// the proposal.
+func (s *GrpcServer) CollectTreasuryApprovals(ctx context.Context, req *pb.CollectTreasuryApprovalsRequest) (*pb.CollectTreasuryApprovalsResponse, error) {
+	if s.treasuryProposals == nil {
+		return nil, status.Error(codes.Unavailable, "treasury proposals are not available")
+	}
+	proposal, err := s.treasuryProposals.Get(types.NewID(req.Proposal_ID))
+	if errors.Is(err, errTreasuryProposalNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	proposal.Approvals = s.collectTreasuryApprovals(types.NewID(proposal.Proposal_ID))
+	if err := s.treasuryProposals.Put(proposal); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.CollectTreasuryApprovalsResponse{
+		Proposal: proposal,
+	}, nil
+}
+
+// SubmitTreasuryProposal broadcasts the proposal's treasury transaction. The
+// transaction must be in this node's treasury whitelist.
+func (s *GrpcServer) SubmitTreasuryProposal(ctx context.Context, req *pb.SubmitTreasuryProposalRequest) (*pb.SubmitTreasuryProposalResponse, error) {
+	if s.treasuryProposals == nil {
+		return nil, status.Error(codes.Unavailable, "treasury proposals are not available")
+	}
+	proposal, err := s.treasuryProposals.Get(types.NewID(req.Proposal_ID))
+	if errors.Is(err, errTreasuryProposalNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if proposal.Status == pb.TreasuryProposal_CONFIRMED {
+		return nil, status.Error(codes.FailedPrecondition, "proposal is already confirmed")
+	}
+	if err := s.broadcastTxFunc(proposal.RawTx.Tx); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	proposal.Status = pb.TreasuryProposal_SUBMITTED
+	if err := s.treasuryProposals.Put(proposal); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	txid := proposal.RawTx.Tx.ID()
+	return &pb.SubmitTreasuryProposalResponse{
+		Transaction_ID: txid[:],
+	}, nil
+}
+
+// ListTreasuryProposals returns the saved treasury proposals which have not yet
+// been confirmed in a block.
+func (s *GrpcServer) ListTreasuryProposals(ctx context.Context, req *pb.ListTreasuryProposalsRequest) (*pb.ListTreasuryProposalsResponse, error) {
+	if s.treasuryProposals == nil {
+		return nil, status.Error(codes.Unavailable, "treasury proposals are not available")
+	}
+	proposals, err := s.treasuryProposals.List(req.IncludeConfirmed)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.ListTreasuryProposalsResponse{
+		Proposals: proposals,
+	}, nil
+}
+
 // ReconsiderBlock tries to reprocess the given block
 func (s *GrpcServer) ReconsiderBlock(ctx context.Context, req *pb.ReconsiderBlockRequest) (*pb.ReconsiderBlockResponse, error) {
 	var (
@@ -260,3 +624,47 @@ func (s *GrpcServer) RecomputeChainState(ctx context.Context, req *pb.RecomputeC
 	go s.reindexChainFunc() //nolint:errcheck
 	return &pb.RecomputeChainStateResponse{}, nil
 }
+
+// CaptureProfile records a runtime profile of the node for the given duration
+// and writes it to the profiles directory inside the node's data directory.
+func (s *GrpcServer) CaptureProfile(ctx context.Context, req *pb.CaptureProfileRequest) (*pb.CaptureProfileResponse, error) {
+	filePath, err := s.captureProfile(ctx, req.ProfileType, time.Duration(req.DurationSeconds)*time.Second)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.CaptureProfileResponse{
+		FilePath: filePath,
+	}, nil
+}
+
+// SetMockTime overrides the node's clock with the provided time. The node's
+// notion of time remains fixed at this value until it is changed again. Setting
+// the timestamp to zero restores the real clock. This RPC is only available in
+// regtest mode.
+func (s *GrpcServer) SetMockTime(ctx context.Context, req *pb.SetMockTimeRequest) (*pb.SetMockTimeResponse, error) {
+	if s.chainParams.Name != params.RegestParams.Name {
+		return nil, status.Error(codes.FailedPrecondition, "mock time is only available in regtest mode")
+	}
+	if req.Timestamp < 0 {
+		return nil, status.Error(codes.InvalidArgument, "timestamp cannot be negative")
+	}
+	var t time.Time
+	if req.Timestamp > 0 {
+		t = time.Unix(req.Timestamp, 0)
+	}
+	s.chain.TimeSource().SetMockTime(t)
+	return &pb.SetMockTimeResponse{}, nil
+}
+
+// AdvanceTime moves the node's mock time forward by the provided number of seconds.
+// If a mock time is not set, it starts from the current time. This RPC is only
+// available in regtest mode.
+func (s *GrpcServer) AdvanceTime(ctx context.Context, req *pb.AdvanceTimeRequest) (*pb.AdvanceTimeResponse, error) {
+	if s.chainParams.Name != params.RegestParams.Name {
+		return nil, status.Error(codes.FailedPrecondition, "mock time is only available in regtest mode")
+	}
+	t := s.chain.TimeSource().AdvanceTime(time.Duration(req.Seconds) * time.Second)
+	return &pb.AdvanceTimeResponse{
+		Timestamp: t.Unix(),
+	}, nil
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"errors"
+	icrypto "github.com/project-illium/ilxd/crypto"
+	"github.com/project-illium/ilxd/params"
+	"github.com/project-illium/ilxd/rpc/pb"
+	"github.com/project-illium/ilxd/types"
+	"github.com/project-illium/ilxd/types/transactions"
+	"github.com/project-illium/walletlib"
+)
+
+// openOutput recovers the note behind a transaction output. Outputs to
+// public addresses carry the note in the clear, all others must be
+// decrypted with the view key of the receiving address. The recovered
+// note is only returned if it opens the output commitment.
+func openOutput(out *transactions.Output, viewKeys []*icrypto.Curve25519PrivateKey) (*types.SpendNote, error) {
+	candidates := [][]byte{out.Ciphertext}
+	for _, k := range viewKeys {
+		plaintext, err := k.Decrypt(out.Ciphertext)
+		if err == nil {
+			candidates = append(candidates, plaintext)
+		}
+	}
+	for _, plaintext := range candidates {
+		note := new(types.SpendNote)
+		if err := note.Deserialize(plaintext); err != nil {
+			continue
+		}
+		commitment, err := note.Commitment()
+		if err != nil {
+			continue
+		}
+		if commitment.Compare(types.NewID(out.Commitment)) == 0 {
+			return note, nil
+		}
+	}
+	return nil, errors.New("unable to decrypt output")
+}
+
+// verifyPaymentProof checks that the note disclosed in the proof opens
+// the commitment of the referenced transaction output. The note's script
+// hash is taken from the address in the proof so a valid proof also
+// shows the output was paid to that address.
+func verifyPaymentProof(proof *pb.PaymentProof, tx *transactions.Transaction, chainParams *params.NetworkParams) (walletlib.Address, error) {
+	outputs := tx.Outputs()
+	if int(proof.OutputIndex) >= len(outputs) {
+		return nil, errors.New("output index out of range")
+	}
+	addr, err := decodeAddress(proof.Address, chainParams)
+	if err != nil {
+		return nil, err
+	}
+	state := new(types.State)
+	if err := state.Deserialize(proof.State); err != nil {
+		return nil, err
+	}
+	if len(proof.Salt) != 32 {
+		return nil, errors.New("invalid salt length")
+	}
+	note := types.SpendNote{
+		ScriptHash: addr.ScriptHash(),
+		Amount:     types.Amount(proof.Amount),
+		AssetID:    types.NewID(proof.Asset_ID),
+		State:      *state,
+	}
+	copy(note.Salt[:], proof.Salt)
+	commitment, err := note.Commitment()
+	if err != nil {
+		return nil, err
+	}
+	if commitment.Compare(types.NewID(outputs[proof.OutputIndex].Commitment)) != 0 {
+		return nil, errors.New("proof does not match output commitment")
+	}
+	return addr, nil
+}
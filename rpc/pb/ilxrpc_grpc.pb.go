@@ -26,9 +26,29 @@ type BlockchainServiceClient interface {
 	GetMempoolInfo(ctx context.Context, in *GetMempoolInfoRequest, opts ...grpc.CallOption) (*GetMempoolInfoResponse, error)
 	// GetMempool returns all the transactions in the mempool
 	GetMempool(ctx context.Context, in *GetMempoolRequest, opts ...grpc.CallOption) (*GetMempoolResponse, error)
+	// GetMempoolEntry returns a transaction from the mempool along with
+	// its size and fee data.
+	GetMempoolEntry(ctx context.Context, in *GetMempoolEntryRequest, opts ...grpc.CallOption) (*GetMempoolEntryResponse, error)
+	// GetFeeEstimate returns the fee per kilobyte a transaction should pay
+	// to be included in a block within the target number of blocks. The
+	// estimate is based on the fees of the transactions in the mempool and
+	// in the recent blocks.
+	GetFeeEstimate(ctx context.Context, in *GetFeeEstimateRequest, opts ...grpc.CallOption) (*GetFeeEstimateResponse, error)
+	// GetFeeHistory returns the distribution of the fee rates paid in each of the
+	// most recent blocks. Unlike GetFeeEstimate, which reflects the current mempool,
+	// this can be used to smooth an estimate over a number of blocks. It requires
+	// the fee index, which is enabled by default on nodes that are not pruned.
+	GetFeeHistory(ctx context.Context, in *GetFeeHistoryRequest, opts ...grpc.CallOption) (*GetFeeHistoryResponse, error)
 	// GetBlockchainInfo returns data about the blockchain including the most recent
 	// block hash and height.
 	GetBlockchainInfo(ctx context.Context, in *GetBlockchainInfoRequest, opts ...grpc.CallOption) (*GetBlockchainInfoResponse, error)
+	// GetChainStats returns the network's block and transaction size limits
+	// along with how much of the block size limit recent blocks have used.
+	GetChainStats(ctx context.Context, in *GetChainStatsRequest, opts ...grpc.CallOption) (*GetChainStatsResponse, error)
+	// GetNetworkParams returns the parameters of the network the node is running on.
+	// This includes the coin emission schedule, epoch length, protocol prefix, fee
+	// minimums, and the genesis block ID.
+	GetNetworkParams(ctx context.Context, in *GetNetworkParamsRequest, opts ...grpc.CallOption) (*GetNetworkParamsResponse, error)
 	// GetBlockInfo returns a BlockHeader plus some extra metadata.
 	GetBlockInfo(ctx context.Context, in *GetBlockInfoRequest, opts ...grpc.CallOption) (*GetBlockInfoResponse, error)
 	// GetBlock returns a BlockInfo metadata object plus the transactions either
@@ -44,6 +64,19 @@ type BlockchainServiceClient interface {
 	GetHeaders(ctx context.Context, in *GetHeadersRequest, opts ...grpc.CallOption) (*GetHeadersResponse, error)
 	// GetCompressedBlocks returns a batch of CompressedBlocks according to the request parameters.
 	GetCompressedBlocks(ctx context.Context, in *GetCompressedBlocksRequest, opts ...grpc.CallOption) (*GetCompressedBlocksResponse, error)
+	// GetBlockFilter returns the compact filter for a block. The filter is a
+	// BIP158 style Golomb-coded set of the block's output commitments and
+	// nullifiers. Light wallets can match the filter against their notes and
+	// only download the blocks which match.
+	//
+	// **Requires BlockFilterIndex**
+	GetBlockFilter(ctx context.Context, in *GetBlockFilterRequest, opts ...grpc.CallOption) (*GetBlockFilterResponse, error)
+	// GetCommitmentsByScriptHash returns the commitments of all the notes in the
+	// chain with the given script hash. Only notes which were published unencrypted,
+	// such as those sent to public addresses or to a shared script, can be found.
+	//
+	// **Requires ScriptIndex**
+	GetCommitmentsByScriptHash(ctx context.Context, in *GetCommitmentsByScriptHashRequest, opts ...grpc.CallOption) (*GetCommitmentsByScriptHashResponse, error)
 	// GetTransaction returns the transaction for the given transaction ID.
 	//
 	// **Requires TxIndex**
@@ -66,24 +99,64 @@ type BlockchainServiceClient interface {
 	GetValidatorSetInfo(ctx context.Context, in *GetValidatorSetInfoRequest, opts ...grpc.CallOption) (*GetValidatorSetInfoResponse, error)
 	// GetValidatorSet returns all the validators in the current validator set.
 	GetValidatorSet(ctx context.Context, in *GetValidatorSetRequest, opts ...grpc.CallOption) (*GetValidatorSetResponse, error)
+	// GetStakeDistribution returns statistics about how stake weight is
+	// distributed across the validator set along with the minimum stake a
+	// new validator would need to produce a block with the target probability.
+	GetStakeDistribution(ctx context.Context, in *GetStakeDistributionRequest, opts ...grpc.CallOption) (*GetStakeDistributionResponse, error)
 	// GetValidatorCoinbases returns a list of coinbase txids for the validator.
 	//
 	// **Requires AddrIndex**
 	GetValidatorCoinbases(ctx context.Context, in *GetValidatorCoinbasesRequest, opts ...grpc.CallOption) (*GetValidatorCoinbasesResponse, error)
+	// GetEquivocationEvidence returns the equivocation evidence recorded by
+	// this node. Evidence is two signed blocks at the same height from the
+	// same validator, either seen by this node or relayed by peers.
+	GetEquivocationEvidence(ctx context.Context, in *GetEquivocationEvidenceRequest, opts ...grpc.CallOption) (*GetEquivocationEvidenceResponse, error)
+	// VerifyReserves checks a reserves proof created by the ProveReserves RPC
+	// against the current chain state and returns the proven total.
+	VerifyReserves(ctx context.Context, in *VerifyReservesRequest, opts ...grpc.CallOption) (*VerifyReservesResponse, error)
+	// VerifyPayment checks a payment proof created by the ProvePayment RPC
+	// and returns the address, amount, and asset that were paid.
+	//
+	// **Requires TxIndex**
+	VerifyPayment(ctx context.Context, in *VerifyPaymentRequest, opts ...grpc.CallOption) (*VerifyPaymentResponse, error)
 	// GetAccumulatorCheckpoint returns the accumulator at the requested height.
 	// If there is no checkpoint at that height, the *prior* checkpoint found in the
 	// chain will be returned. If there is no prior checkpoint (as is prior to the first)
 	// an error will be returned.
 	GetAccumulatorCheckpoint(ctx context.Context, in *GetAccumulatorCheckpointRequest, opts ...grpc.CallOption) (*GetAccumulatorCheckpointResponse, error)
+	// GetEpochSnapshot returns the commitment to the chain state taken at the
+	// end of an epoch. The snapshot commits to the accumulator root, a hash of
+	// the nullifier set, and a hash of the validator set as of the last block
+	// of the epoch. Nodes that agree on the chain compute the same snapshots so
+	// they can be used as anchors by light clients and to cross-check nodes.
+	GetEpochSnapshot(ctx context.Context, in *GetEpochSnapshotRequest, opts ...grpc.CallOption) (*GetEpochSnapshotResponse, error)
+	// GetChainTips returns the tip of the main chain along with the tips of
+	// recently seen competing branches, the height at which they fork from
+	// the main chain, and whether they are still pending in consensus or
+	// were rejected.
+	GetChainTips(ctx context.Context, in *GetChainTipsRequest, opts ...grpc.CallOption) (*GetChainTipsResponse, error)
 	// SubmitTransaction validates a transaction and submits it to the network. An error will be returned
 	// if it fails validation.
 	SubmitTransaction(ctx context.Context, in *SubmitTransactionRequest, opts ...grpc.CallOption) (*SubmitTransactionResponse, error)
+	// SubmitPackage validates a group of transactions and submits them to the network only
+	// if all of them are valid. If any transaction fails validation none are added to the
+	// mempool and an error identifying the transaction is returned. The transactions may not
+	// spend the same nullifiers and may only be standard, mint, or stake transactions.
+	SubmitPackage(ctx context.Context, in *SubmitPackageRequest, opts ...grpc.CallOption) (*SubmitPackageResponse, error)
 	// SubscribeBlocks returns a stream of notifications when new blocks are finalized and
 	// connected to the chain.
 	SubscribeBlocks(ctx context.Context, in *SubscribeBlocksRequest, opts ...grpc.CallOption) (BlockchainService_SubscribeBlocksClient, error)
 	// SubscribeCompressedBlocks returns a stream of CompressedBlock notifications when new
 	// blocks are finalized and connected to the chain.
 	SubscribeCompressedBlocks(ctx context.Context, in *SubscribeCompressedBlocksRequest, opts ...grpc.CallOption) (BlockchainService_SubscribeCompressedBlocksClient, error)
+	// SubscribeDoubleSpendAlerts returns a stream of notifications when a transaction is
+	// rejected from the mempool because it spends a nullifier already spent by an unconfirmed
+	// transaction in the pool. The first transaction seen stays in the pool.
+	SubscribeDoubleSpendAlerts(ctx context.Context, in *SubscribeDoubleSpendAlertsRequest, opts ...grpc.CallOption) (BlockchainService_SubscribeDoubleSpendAlertsClient, error)
+	// SubscribeForkAlerts returns a stream of notifications when the node sees a
+	// competing branch which extends more than forkalertdepth blocks past the
+	// point where it forks from the main chain.
+	SubscribeForkAlerts(ctx context.Context, in *SubscribeForkAlertsRequest, opts ...grpc.CallOption) (BlockchainService_SubscribeForkAlertsClient, error)
 }
 
 type blockchainServiceClient struct {
@@ -112,6 +185,33 @@ func (c *blockchainServiceClient) GetMempool(ctx context.Context, in *GetMempool
 	return out, nil
 }
 
+func (c *blockchainServiceClient) GetMempoolEntry(ctx context.Context, in *GetMempoolEntryRequest, opts ...grpc.CallOption) (*GetMempoolEntryResponse, error) {
+	out := new(GetMempoolEntryResponse)
+	err := c.cc.Invoke(ctx, "/pb.BlockchainService/GetMempoolEntry", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockchainServiceClient) GetFeeEstimate(ctx context.Context, in *GetFeeEstimateRequest, opts ...grpc.CallOption) (*GetFeeEstimateResponse, error) {
+	out := new(GetFeeEstimateResponse)
+	err := c.cc.Invoke(ctx, "/pb.BlockchainService/GetFeeEstimate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockchainServiceClient) GetFeeHistory(ctx context.Context, in *GetFeeHistoryRequest, opts ...grpc.CallOption) (*GetFeeHistoryResponse, error) {
+	out := new(GetFeeHistoryResponse)
+	err := c.cc.Invoke(ctx, "/pb.BlockchainService/GetFeeHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *blockchainServiceClient) GetBlockchainInfo(ctx context.Context, in *GetBlockchainInfoRequest, opts ...grpc.CallOption) (*GetBlockchainInfoResponse, error) {
 	out := new(GetBlockchainInfoResponse)
 	err := c.cc.Invoke(ctx, "/pb.BlockchainService/GetBlockchainInfo", in, out, opts...)
@@ -121,6 +221,24 @@ func (c *blockchainServiceClient) GetBlockchainInfo(ctx context.Context, in *Get
 	return out, nil
 }
 
+func (c *blockchainServiceClient) GetChainStats(ctx context.Context, in *GetChainStatsRequest, opts ...grpc.CallOption) (*GetChainStatsResponse, error) {
+	out := new(GetChainStatsResponse)
+	err := c.cc.Invoke(ctx, "/pb.BlockchainService/GetChainStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockchainServiceClient) GetNetworkParams(ctx context.Context, in *GetNetworkParamsRequest, opts ...grpc.CallOption) (*GetNetworkParamsResponse, error) {
+	out := new(GetNetworkParamsResponse)
+	err := c.cc.Invoke(ctx, "/pb.BlockchainService/GetNetworkParams", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *blockchainServiceClient) GetBlockInfo(ctx context.Context, in *GetBlockInfoRequest, opts ...grpc.CallOption) (*GetBlockInfoResponse, error) {
 	out := new(GetBlockInfoResponse)
 	err := c.cc.Invoke(ctx, "/pb.BlockchainService/GetBlockInfo", in, out, opts...)
@@ -175,6 +293,24 @@ func (c *blockchainServiceClient) GetCompressedBlocks(ctx context.Context, in *G
 	return out, nil
 }
 
+func (c *blockchainServiceClient) GetBlockFilter(ctx context.Context, in *GetBlockFilterRequest, opts ...grpc.CallOption) (*GetBlockFilterResponse, error) {
+	out := new(GetBlockFilterResponse)
+	err := c.cc.Invoke(ctx, "/pb.BlockchainService/GetBlockFilter", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockchainServiceClient) GetCommitmentsByScriptHash(ctx context.Context, in *GetCommitmentsByScriptHashRequest, opts ...grpc.CallOption) (*GetCommitmentsByScriptHashResponse, error) {
+	out := new(GetCommitmentsByScriptHashResponse)
+	err := c.cc.Invoke(ctx, "/pb.BlockchainService/GetCommitmentsByScriptHash", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *blockchainServiceClient) GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*GetTransactionResponse, error) {
 	out := new(GetTransactionResponse)
 	err := c.cc.Invoke(ctx, "/pb.BlockchainService/GetTransaction", in, out, opts...)
@@ -229,6 +365,15 @@ func (c *blockchainServiceClient) GetValidatorSet(ctx context.Context, in *GetVa
 	return out, nil
 }
 
+func (c *blockchainServiceClient) GetStakeDistribution(ctx context.Context, in *GetStakeDistributionRequest, opts ...grpc.CallOption) (*GetStakeDistributionResponse, error) {
+	out := new(GetStakeDistributionResponse)
+	err := c.cc.Invoke(ctx, "/pb.BlockchainService/GetStakeDistribution", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *blockchainServiceClient) GetValidatorCoinbases(ctx context.Context, in *GetValidatorCoinbasesRequest, opts ...grpc.CallOption) (*GetValidatorCoinbasesResponse, error) {
 	out := new(GetValidatorCoinbasesResponse)
 	err := c.cc.Invoke(ctx, "/pb.BlockchainService/GetValidatorCoinbases", in, out, opts...)
@@ -238,6 +383,33 @@ func (c *blockchainServiceClient) GetValidatorCoinbases(ctx context.Context, in
 	return out, nil
 }
 
+func (c *blockchainServiceClient) GetEquivocationEvidence(ctx context.Context, in *GetEquivocationEvidenceRequest, opts ...grpc.CallOption) (*GetEquivocationEvidenceResponse, error) {
+	out := new(GetEquivocationEvidenceResponse)
+	err := c.cc.Invoke(ctx, "/pb.BlockchainService/GetEquivocationEvidence", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockchainServiceClient) VerifyReserves(ctx context.Context, in *VerifyReservesRequest, opts ...grpc.CallOption) (*VerifyReservesResponse, error) {
+	out := new(VerifyReservesResponse)
+	err := c.cc.Invoke(ctx, "/pb.BlockchainService/VerifyReserves", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockchainServiceClient) VerifyPayment(ctx context.Context, in *VerifyPaymentRequest, opts ...grpc.CallOption) (*VerifyPaymentResponse, error) {
+	out := new(VerifyPaymentResponse)
+	err := c.cc.Invoke(ctx, "/pb.BlockchainService/VerifyPayment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *blockchainServiceClient) GetAccumulatorCheckpoint(ctx context.Context, in *GetAccumulatorCheckpointRequest, opts ...grpc.CallOption) (*GetAccumulatorCheckpointResponse, error) {
 	out := new(GetAccumulatorCheckpointResponse)
 	err := c.cc.Invoke(ctx, "/pb.BlockchainService/GetAccumulatorCheckpoint", in, out, opts...)
@@ -247,6 +419,24 @@ func (c *blockchainServiceClient) GetAccumulatorCheckpoint(ctx context.Context,
 	return out, nil
 }
 
+func (c *blockchainServiceClient) GetEpochSnapshot(ctx context.Context, in *GetEpochSnapshotRequest, opts ...grpc.CallOption) (*GetEpochSnapshotResponse, error) {
+	out := new(GetEpochSnapshotResponse)
+	err := c.cc.Invoke(ctx, "/pb.BlockchainService/GetEpochSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockchainServiceClient) GetChainTips(ctx context.Context, in *GetChainTipsRequest, opts ...grpc.CallOption) (*GetChainTipsResponse, error) {
+	out := new(GetChainTipsResponse)
+	err := c.cc.Invoke(ctx, "/pb.BlockchainService/GetChainTips", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *blockchainServiceClient) SubmitTransaction(ctx context.Context, in *SubmitTransactionRequest, opts ...grpc.CallOption) (*SubmitTransactionResponse, error) {
 	out := new(SubmitTransactionResponse)
 	err := c.cc.Invoke(ctx, "/pb.BlockchainService/SubmitTransaction", in, out, opts...)
@@ -256,6 +446,15 @@ func (c *blockchainServiceClient) SubmitTransaction(ctx context.Context, in *Sub
 	return out, nil
 }
 
+func (c *blockchainServiceClient) SubmitPackage(ctx context.Context, in *SubmitPackageRequest, opts ...grpc.CallOption) (*SubmitPackageResponse, error) {
+	out := new(SubmitPackageResponse)
+	err := c.cc.Invoke(ctx, "/pb.BlockchainService/SubmitPackage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *blockchainServiceClient) SubscribeBlocks(ctx context.Context, in *SubscribeBlocksRequest, opts ...grpc.CallOption) (BlockchainService_SubscribeBlocksClient, error) {
 	stream, err := c.cc.NewStream(ctx, &BlockchainService_ServiceDesc.Streams[0], "/pb.BlockchainService/SubscribeBlocks", opts...)
 	if err != nil {
@@ -320,6 +519,70 @@ func (x *blockchainServiceSubscribeCompressedBlocksClient) Recv() (*CompressedBl
 	return m, nil
 }
 
+func (c *blockchainServiceClient) SubscribeDoubleSpendAlerts(ctx context.Context, in *SubscribeDoubleSpendAlertsRequest, opts ...grpc.CallOption) (BlockchainService_SubscribeDoubleSpendAlertsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &BlockchainService_ServiceDesc.Streams[2], "/pb.BlockchainService/SubscribeDoubleSpendAlerts", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &blockchainServiceSubscribeDoubleSpendAlertsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BlockchainService_SubscribeDoubleSpendAlertsClient interface {
+	Recv() (*DoubleSpendAlert, error)
+	grpc.ClientStream
+}
+
+type blockchainServiceSubscribeDoubleSpendAlertsClient struct {
+	grpc.ClientStream
+}
+
+func (x *blockchainServiceSubscribeDoubleSpendAlertsClient) Recv() (*DoubleSpendAlert, error) {
+	m := new(DoubleSpendAlert)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *blockchainServiceClient) SubscribeForkAlerts(ctx context.Context, in *SubscribeForkAlertsRequest, opts ...grpc.CallOption) (BlockchainService_SubscribeForkAlertsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &BlockchainService_ServiceDesc.Streams[3], "/pb.BlockchainService/SubscribeForkAlerts", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &blockchainServiceSubscribeForkAlertsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BlockchainService_SubscribeForkAlertsClient interface {
+	Recv() (*ChainTip, error)
+	grpc.ClientStream
+}
+
+type blockchainServiceSubscribeForkAlertsClient struct {
+	grpc.ClientStream
+}
+
+func (x *blockchainServiceSubscribeForkAlertsClient) Recv() (*ChainTip, error) {
+	m := new(ChainTip)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // BlockchainServiceServer is the server API for BlockchainService service.
 // All implementations must embed UnimplementedBlockchainServiceServer
 // for forward compatibility
@@ -328,9 +591,29 @@ type BlockchainServiceServer interface {
 	GetMempoolInfo(context.Context, *GetMempoolInfoRequest) (*GetMempoolInfoResponse, error)
 	// GetMempool returns all the transactions in the mempool
 	GetMempool(context.Context, *GetMempoolRequest) (*GetMempoolResponse, error)
+	// GetMempoolEntry returns a transaction from the mempool along with
+	// its size and fee data.
+	GetMempoolEntry(context.Context, *GetMempoolEntryRequest) (*GetMempoolEntryResponse, error)
+	// GetFeeEstimate returns the fee per kilobyte a transaction should pay
+	// to be included in a block within the target number of blocks. The
+	// estimate is based on the fees of the transactions in the mempool and
+	// in the recent blocks.
+	GetFeeEstimate(context.Context, *GetFeeEstimateRequest) (*GetFeeEstimateResponse, error)
+	// GetFeeHistory returns the distribution of the fee rates paid in each of the
+	// most recent blocks. Unlike GetFeeEstimate, which reflects the current mempool,
+	// this can be used to smooth an estimate over a number of blocks. It requires
+	// the fee index, which is enabled by default on nodes that are not pruned.
+	GetFeeHistory(context.Context, *GetFeeHistoryRequest) (*GetFeeHistoryResponse, error)
 	// GetBlockchainInfo returns data about the blockchain including the most recent
 	// block hash and height.
 	GetBlockchainInfo(context.Context, *GetBlockchainInfoRequest) (*GetBlockchainInfoResponse, error)
+	// GetChainStats returns the network's block and transaction size limits
+	// along with how much of the block size limit recent blocks have used.
+	GetChainStats(context.Context, *GetChainStatsRequest) (*GetChainStatsResponse, error)
+	// GetNetworkParams returns the parameters of the network the node is running on.
+	// This includes the coin emission schedule, epoch length, protocol prefix, fee
+	// minimums, and the genesis block ID.
+	GetNetworkParams(context.Context, *GetNetworkParamsRequest) (*GetNetworkParamsResponse, error)
 	// GetBlockInfo returns a BlockHeader plus some extra metadata.
 	GetBlockInfo(context.Context, *GetBlockInfoRequest) (*GetBlockInfoResponse, error)
 	// GetBlock returns a BlockInfo metadata object plus the transactions either
@@ -346,6 +629,19 @@ type BlockchainServiceServer interface {
 	GetHeaders(context.Context, *GetHeadersRequest) (*GetHeadersResponse, error)
 	// GetCompressedBlocks returns a batch of CompressedBlocks according to the request parameters.
 	GetCompressedBlocks(context.Context, *GetCompressedBlocksRequest) (*GetCompressedBlocksResponse, error)
+	// GetBlockFilter returns the compact filter for a block. The filter is a
+	// BIP158 style Golomb-coded set of the block's output commitments and
+	// nullifiers. Light wallets can match the filter against their notes and
+	// only download the blocks which match.
+	//
+	// **Requires BlockFilterIndex**
+	GetBlockFilter(context.Context, *GetBlockFilterRequest) (*GetBlockFilterResponse, error)
+	// GetCommitmentsByScriptHash returns the commitments of all the notes in the
+	// chain with the given script hash. Only notes which were published unencrypted,
+	// such as those sent to public addresses or to a shared script, can be found.
+	//
+	// **Requires ScriptIndex**
+	GetCommitmentsByScriptHash(context.Context, *GetCommitmentsByScriptHashRequest) (*GetCommitmentsByScriptHashResponse, error)
 	// GetTransaction returns the transaction for the given transaction ID.
 	//
 	// **Requires TxIndex**
@@ -368,24 +664,64 @@ type BlockchainServiceServer interface {
 	GetValidatorSetInfo(context.Context, *GetValidatorSetInfoRequest) (*GetValidatorSetInfoResponse, error)
 	// GetValidatorSet returns all the validators in the current validator set.
 	GetValidatorSet(context.Context, *GetValidatorSetRequest) (*GetValidatorSetResponse, error)
+	// GetStakeDistribution returns statistics about how stake weight is
+	// distributed across the validator set along with the minimum stake a
+	// new validator would need to produce a block with the target probability.
+	GetStakeDistribution(context.Context, *GetStakeDistributionRequest) (*GetStakeDistributionResponse, error)
 	// GetValidatorCoinbases returns a list of coinbase txids for the validator.
 	//
 	// **Requires AddrIndex**
 	GetValidatorCoinbases(context.Context, *GetValidatorCoinbasesRequest) (*GetValidatorCoinbasesResponse, error)
+	// GetEquivocationEvidence returns the equivocation evidence recorded by
+	// this node. Evidence is two signed blocks at the same height from the
+	// same validator, either seen by this node or relayed by peers.
+	GetEquivocationEvidence(context.Context, *GetEquivocationEvidenceRequest) (*GetEquivocationEvidenceResponse, error)
+	// VerifyReserves checks a reserves proof created by the ProveReserves RPC
+	// against the current chain state and returns the proven total.
+	VerifyReserves(context.Context, *VerifyReservesRequest) (*VerifyReservesResponse, error)
+	// VerifyPayment checks a payment proof created by the ProvePayment RPC
+	// and returns the address, amount, and asset that were paid.
+	//
+	// **Requires TxIndex**
+	VerifyPayment(context.Context, *VerifyPaymentRequest) (*VerifyPaymentResponse, error)
 	// GetAccumulatorCheckpoint returns the accumulator at the requested height.
 	// If there is no checkpoint at that height, the *prior* checkpoint found in the
 	// chain will be returned. If there is no prior checkpoint (as is prior to the first)
 	// an error will be returned.
 	GetAccumulatorCheckpoint(context.Context, *GetAccumulatorCheckpointRequest) (*GetAccumulatorCheckpointResponse, error)
+	// GetEpochSnapshot returns the commitment to the chain state taken at the
+	// end of an epoch. The snapshot commits to the accumulator root, a hash of
+	// the nullifier set, and a hash of the validator set as of the last block
+	// of the epoch. Nodes that agree on the chain compute the same snapshots so
+	// they can be used as anchors by light clients and to cross-check nodes.
+	GetEpochSnapshot(context.Context, *GetEpochSnapshotRequest) (*GetEpochSnapshotResponse, error)
+	// GetChainTips returns the tip of the main chain along with the tips of
+	// recently seen competing branches, the height at which they fork from
+	// the main chain, and whether they are still pending in consensus or
+	// were rejected.
+	GetChainTips(context.Context, *GetChainTipsRequest) (*GetChainTipsResponse, error)
 	// SubmitTransaction validates a transaction and submits it to the network. An error will be returned
 	// if it fails validation.
 	SubmitTransaction(context.Context, *SubmitTransactionRequest) (*SubmitTransactionResponse, error)
+	// SubmitPackage validates a group of transactions and submits them to the network only
+	// if all of them are valid. If any transaction fails validation none are added to the
+	// mempool and an error identifying the transaction is returned. The transactions may not
+	// spend the same nullifiers and may only be standard, mint, or stake transactions.
+	SubmitPackage(context.Context, *SubmitPackageRequest) (*SubmitPackageResponse, error)
 	// SubscribeBlocks returns a stream of notifications when new blocks are finalized and
 	// connected to the chain.
 	SubscribeBlocks(*SubscribeBlocksRequest, BlockchainService_SubscribeBlocksServer) error
 	// SubscribeCompressedBlocks returns a stream of CompressedBlock notifications when new
 	// blocks are finalized and connected to the chain.
 	SubscribeCompressedBlocks(*SubscribeCompressedBlocksRequest, BlockchainService_SubscribeCompressedBlocksServer) error
+	// SubscribeDoubleSpendAlerts returns a stream of notifications when a transaction is
+	// rejected from the mempool because it spends a nullifier already spent by an unconfirmed
+	// transaction in the pool. The first transaction seen stays in the pool.
+	SubscribeDoubleSpendAlerts(*SubscribeDoubleSpendAlertsRequest, BlockchainService_SubscribeDoubleSpendAlertsServer) error
+	// SubscribeForkAlerts returns a stream of notifications when the node sees a
+	// competing branch which extends more than forkalertdepth blocks past the
+	// point where it forks from the main chain.
+	SubscribeForkAlerts(*SubscribeForkAlertsRequest, BlockchainService_SubscribeForkAlertsServer) error
 	mustEmbedUnimplementedBlockchainServiceServer()
 }
 
@@ -399,9 +735,24 @@ func (UnimplementedBlockchainServiceServer) GetMempoolInfo(context.Context, *Get
 func (UnimplementedBlockchainServiceServer) GetMempool(context.Context, *GetMempoolRequest) (*GetMempoolResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMempool not implemented")
 }
+func (UnimplementedBlockchainServiceServer) GetMempoolEntry(context.Context, *GetMempoolEntryRequest) (*GetMempoolEntryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMempoolEntry not implemented")
+}
+func (UnimplementedBlockchainServiceServer) GetFeeEstimate(context.Context, *GetFeeEstimateRequest) (*GetFeeEstimateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFeeEstimate not implemented")
+}
+func (UnimplementedBlockchainServiceServer) GetFeeHistory(context.Context, *GetFeeHistoryRequest) (*GetFeeHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFeeHistory not implemented")
+}
 func (UnimplementedBlockchainServiceServer) GetBlockchainInfo(context.Context, *GetBlockchainInfoRequest) (*GetBlockchainInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBlockchainInfo not implemented")
 }
+func (UnimplementedBlockchainServiceServer) GetChainStats(context.Context, *GetChainStatsRequest) (*GetChainStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChainStats not implemented")
+}
+func (UnimplementedBlockchainServiceServer) GetNetworkParams(context.Context, *GetNetworkParamsRequest) (*GetNetworkParamsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNetworkParams not implemented")
+}
 func (UnimplementedBlockchainServiceServer) GetBlockInfo(context.Context, *GetBlockInfoRequest) (*GetBlockInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBlockInfo not implemented")
 }
@@ -420,6 +771,12 @@ func (UnimplementedBlockchainServiceServer) GetHeaders(context.Context, *GetHead
 func (UnimplementedBlockchainServiceServer) GetCompressedBlocks(context.Context, *GetCompressedBlocksRequest) (*GetCompressedBlocksResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetCompressedBlocks not implemented")
 }
+func (UnimplementedBlockchainServiceServer) GetBlockFilter(context.Context, *GetBlockFilterRequest) (*GetBlockFilterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockFilter not implemented")
+}
+func (UnimplementedBlockchainServiceServer) GetCommitmentsByScriptHash(context.Context, *GetCommitmentsByScriptHashRequest) (*GetCommitmentsByScriptHashResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCommitmentsByScriptHash not implemented")
+}
 func (UnimplementedBlockchainServiceServer) GetTransaction(context.Context, *GetTransactionRequest) (*GetTransactionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTransaction not implemented")
 }
@@ -438,21 +795,48 @@ func (UnimplementedBlockchainServiceServer) GetValidatorSetInfo(context.Context,
 func (UnimplementedBlockchainServiceServer) GetValidatorSet(context.Context, *GetValidatorSetRequest) (*GetValidatorSetResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetValidatorSet not implemented")
 }
+func (UnimplementedBlockchainServiceServer) GetStakeDistribution(context.Context, *GetStakeDistributionRequest) (*GetStakeDistributionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStakeDistribution not implemented")
+}
 func (UnimplementedBlockchainServiceServer) GetValidatorCoinbases(context.Context, *GetValidatorCoinbasesRequest) (*GetValidatorCoinbasesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetValidatorCoinbases not implemented")
 }
+func (UnimplementedBlockchainServiceServer) GetEquivocationEvidence(context.Context, *GetEquivocationEvidenceRequest) (*GetEquivocationEvidenceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEquivocationEvidence not implemented")
+}
+func (UnimplementedBlockchainServiceServer) VerifyReserves(context.Context, *VerifyReservesRequest) (*VerifyReservesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyReserves not implemented")
+}
+func (UnimplementedBlockchainServiceServer) VerifyPayment(context.Context, *VerifyPaymentRequest) (*VerifyPaymentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyPayment not implemented")
+}
 func (UnimplementedBlockchainServiceServer) GetAccumulatorCheckpoint(context.Context, *GetAccumulatorCheckpointRequest) (*GetAccumulatorCheckpointResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAccumulatorCheckpoint not implemented")
 }
+func (UnimplementedBlockchainServiceServer) GetEpochSnapshot(context.Context, *GetEpochSnapshotRequest) (*GetEpochSnapshotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEpochSnapshot not implemented")
+}
+func (UnimplementedBlockchainServiceServer) GetChainTips(context.Context, *GetChainTipsRequest) (*GetChainTipsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChainTips not implemented")
+}
 func (UnimplementedBlockchainServiceServer) SubmitTransaction(context.Context, *SubmitTransactionRequest) (*SubmitTransactionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SubmitTransaction not implemented")
 }
+func (UnimplementedBlockchainServiceServer) SubmitPackage(context.Context, *SubmitPackageRequest) (*SubmitPackageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitPackage not implemented")
+}
 func (UnimplementedBlockchainServiceServer) SubscribeBlocks(*SubscribeBlocksRequest, BlockchainService_SubscribeBlocksServer) error {
 	return status.Errorf(codes.Unimplemented, "method SubscribeBlocks not implemented")
 }
 func (UnimplementedBlockchainServiceServer) SubscribeCompressedBlocks(*SubscribeCompressedBlocksRequest, BlockchainService_SubscribeCompressedBlocksServer) error {
 	return status.Errorf(codes.Unimplemented, "method SubscribeCompressedBlocks not implemented")
 }
+func (UnimplementedBlockchainServiceServer) SubscribeDoubleSpendAlerts(*SubscribeDoubleSpendAlertsRequest, BlockchainService_SubscribeDoubleSpendAlertsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeDoubleSpendAlerts not implemented")
+}
+func (UnimplementedBlockchainServiceServer) SubscribeForkAlerts(*SubscribeForkAlertsRequest, BlockchainService_SubscribeForkAlertsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeForkAlerts not implemented")
+}
 func (UnimplementedBlockchainServiceServer) mustEmbedUnimplementedBlockchainServiceServer() {}
 
 // UnsafeBlockchainServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -502,6 +886,60 @@ func _BlockchainService_GetMempool_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BlockchainService_GetMempoolEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMempoolEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainServiceServer).GetMempoolEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.BlockchainService/GetMempoolEntry",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainServiceServer).GetMempoolEntry(ctx, req.(*GetMempoolEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockchainService_GetFeeEstimate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFeeEstimateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainServiceServer).GetFeeEstimate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.BlockchainService/GetFeeEstimate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainServiceServer).GetFeeEstimate(ctx, req.(*GetFeeEstimateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockchainService_GetFeeHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFeeHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainServiceServer).GetFeeHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.BlockchainService/GetFeeHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainServiceServer).GetFeeHistory(ctx, req.(*GetFeeHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BlockchainService_GetBlockchainInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetBlockchainInfoRequest)
 	if err := dec(in); err != nil {
@@ -520,6 +958,42 @@ func _BlockchainService_GetBlockchainInfo_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BlockchainService_GetChainStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChainStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainServiceServer).GetChainStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.BlockchainService/GetChainStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainServiceServer).GetChainStats(ctx, req.(*GetChainStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockchainService_GetNetworkParams_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNetworkParamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainServiceServer).GetNetworkParams(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.BlockchainService/GetNetworkParams",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainServiceServer).GetNetworkParams(ctx, req.(*GetNetworkParamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BlockchainService_GetBlockInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetBlockInfoRequest)
 	if err := dec(in); err != nil {
@@ -628,6 +1102,42 @@ func _BlockchainService_GetCompressedBlocks_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BlockchainService_GetBlockFilter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockFilterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainServiceServer).GetBlockFilter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.BlockchainService/GetBlockFilter",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainServiceServer).GetBlockFilter(ctx, req.(*GetBlockFilterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockchainService_GetCommitmentsByScriptHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCommitmentsByScriptHashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainServiceServer).GetCommitmentsByScriptHash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.BlockchainService/GetCommitmentsByScriptHash",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainServiceServer).GetCommitmentsByScriptHash(ctx, req.(*GetCommitmentsByScriptHashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BlockchainService_GetTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetTransactionRequest)
 	if err := dec(in); err != nil {
@@ -736,26 +1246,98 @@ func _BlockchainService_GetValidatorSet_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BlockchainService_GetValidatorCoinbases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetValidatorCoinbasesRequest)
+func _BlockchainService_GetStakeDistribution_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStakeDistributionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BlockchainServiceServer).GetValidatorCoinbases(ctx, in)
+		return srv.(BlockchainServiceServer).GetStakeDistribution(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.BlockchainService/GetValidatorCoinbases",
+		FullMethod: "/pb.BlockchainService/GetStakeDistribution",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BlockchainServiceServer).GetValidatorCoinbases(ctx, req.(*GetValidatorCoinbasesRequest))
+		return srv.(BlockchainServiceServer).GetStakeDistribution(ctx, req.(*GetStakeDistributionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BlockchainService_GetAccumulatorCheckpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetAccumulatorCheckpointRequest)
+func _BlockchainService_GetValidatorCoinbases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetValidatorCoinbasesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainServiceServer).GetValidatorCoinbases(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.BlockchainService/GetValidatorCoinbases",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainServiceServer).GetValidatorCoinbases(ctx, req.(*GetValidatorCoinbasesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockchainService_GetEquivocationEvidence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEquivocationEvidenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainServiceServer).GetEquivocationEvidence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.BlockchainService/GetEquivocationEvidence",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainServiceServer).GetEquivocationEvidence(ctx, req.(*GetEquivocationEvidenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockchainService_VerifyReserves_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyReservesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainServiceServer).VerifyReserves(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.BlockchainService/VerifyReserves",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainServiceServer).VerifyReserves(ctx, req.(*VerifyReservesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockchainService_VerifyPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyPaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainServiceServer).VerifyPayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.BlockchainService/VerifyPayment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainServiceServer).VerifyPayment(ctx, req.(*VerifyPaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockchainService_GetAccumulatorCheckpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccumulatorCheckpointRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -772,6 +1354,42 @@ func _BlockchainService_GetAccumulatorCheckpoint_Handler(srv interface{}, ctx co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BlockchainService_GetEpochSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEpochSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainServiceServer).GetEpochSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.BlockchainService/GetEpochSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainServiceServer).GetEpochSnapshot(ctx, req.(*GetEpochSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockchainService_GetChainTips_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChainTipsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainServiceServer).GetChainTips(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.BlockchainService/GetChainTips",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainServiceServer).GetChainTips(ctx, req.(*GetChainTipsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BlockchainService_SubmitTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SubmitTransactionRequest)
 	if err := dec(in); err != nil {
@@ -790,6 +1408,24 @@ func _BlockchainService_SubmitTransaction_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BlockchainService_SubmitPackage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitPackageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainServiceServer).SubmitPackage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.BlockchainService/SubmitPackage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainServiceServer).SubmitPackage(ctx, req.(*SubmitPackageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BlockchainService_SubscribeBlocks_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(SubscribeBlocksRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -832,6 +1468,48 @@ func (x *blockchainServiceSubscribeCompressedBlocksServer) Send(m *CompressedBlo
 	return x.ServerStream.SendMsg(m)
 }
 
+func _BlockchainService_SubscribeDoubleSpendAlerts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeDoubleSpendAlertsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlockchainServiceServer).SubscribeDoubleSpendAlerts(m, &blockchainServiceSubscribeDoubleSpendAlertsServer{stream})
+}
+
+type BlockchainService_SubscribeDoubleSpendAlertsServer interface {
+	Send(*DoubleSpendAlert) error
+	grpc.ServerStream
+}
+
+type blockchainServiceSubscribeDoubleSpendAlertsServer struct {
+	grpc.ServerStream
+}
+
+func (x *blockchainServiceSubscribeDoubleSpendAlertsServer) Send(m *DoubleSpendAlert) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BlockchainService_SubscribeForkAlerts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeForkAlertsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlockchainServiceServer).SubscribeForkAlerts(m, &blockchainServiceSubscribeForkAlertsServer{stream})
+}
+
+type BlockchainService_SubscribeForkAlertsServer interface {
+	Send(*ChainTip) error
+	grpc.ServerStream
+}
+
+type blockchainServiceSubscribeForkAlertsServer struct {
+	grpc.ServerStream
+}
+
+func (x *blockchainServiceSubscribeForkAlertsServer) Send(m *ChainTip) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // BlockchainService_ServiceDesc is the grpc.ServiceDesc for BlockchainService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -847,10 +1525,30 @@ var BlockchainService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetMempool",
 			Handler:    _BlockchainService_GetMempool_Handler,
 		},
+		{
+			MethodName: "GetMempoolEntry",
+			Handler:    _BlockchainService_GetMempoolEntry_Handler,
+		},
+		{
+			MethodName: "GetFeeEstimate",
+			Handler:    _BlockchainService_GetFeeEstimate_Handler,
+		},
+		{
+			MethodName: "GetFeeHistory",
+			Handler:    _BlockchainService_GetFeeHistory_Handler,
+		},
 		{
 			MethodName: "GetBlockchainInfo",
 			Handler:    _BlockchainService_GetBlockchainInfo_Handler,
 		},
+		{
+			MethodName: "GetChainStats",
+			Handler:    _BlockchainService_GetChainStats_Handler,
+		},
+		{
+			MethodName: "GetNetworkParams",
+			Handler:    _BlockchainService_GetNetworkParams_Handler,
+		},
 		{
 			MethodName: "GetBlockInfo",
 			Handler:    _BlockchainService_GetBlockInfo_Handler,
@@ -875,6 +1573,14 @@ var BlockchainService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetCompressedBlocks",
 			Handler:    _BlockchainService_GetCompressedBlocks_Handler,
 		},
+		{
+			MethodName: "GetBlockFilter",
+			Handler:    _BlockchainService_GetBlockFilter_Handler,
+		},
+		{
+			MethodName: "GetCommitmentsByScriptHash",
+			Handler:    _BlockchainService_GetCommitmentsByScriptHash_Handler,
+		},
 		{
 			MethodName: "GetTransaction",
 			Handler:    _BlockchainService_GetTransaction_Handler,
@@ -899,18 +1605,46 @@ var BlockchainService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetValidatorSet",
 			Handler:    _BlockchainService_GetValidatorSet_Handler,
 		},
+		{
+			MethodName: "GetStakeDistribution",
+			Handler:    _BlockchainService_GetStakeDistribution_Handler,
+		},
 		{
 			MethodName: "GetValidatorCoinbases",
 			Handler:    _BlockchainService_GetValidatorCoinbases_Handler,
 		},
+		{
+			MethodName: "GetEquivocationEvidence",
+			Handler:    _BlockchainService_GetEquivocationEvidence_Handler,
+		},
+		{
+			MethodName: "VerifyReserves",
+			Handler:    _BlockchainService_VerifyReserves_Handler,
+		},
+		{
+			MethodName: "VerifyPayment",
+			Handler:    _BlockchainService_VerifyPayment_Handler,
+		},
 		{
 			MethodName: "GetAccumulatorCheckpoint",
 			Handler:    _BlockchainService_GetAccumulatorCheckpoint_Handler,
 		},
+		{
+			MethodName: "GetEpochSnapshot",
+			Handler:    _BlockchainService_GetEpochSnapshot_Handler,
+		},
+		{
+			MethodName: "GetChainTips",
+			Handler:    _BlockchainService_GetChainTips_Handler,
+		},
 		{
 			MethodName: "SubmitTransaction",
 			Handler:    _BlockchainService_SubmitTransaction_Handler,
 		},
+		{
+			MethodName: "SubmitPackage",
+			Handler:    _BlockchainService_SubmitPackage_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -923,6 +1657,16 @@ var BlockchainService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _BlockchainService_SubscribeCompressedBlocks_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "SubscribeDoubleSpendAlerts",
+			Handler:       _BlockchainService_SubscribeDoubleSpendAlerts_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeForkAlerts",
+			Handler:       _BlockchainService_SubscribeForkAlerts_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "ilxrpc.proto",
 }
@@ -1341,6 +2085,13 @@ type WalletServiceClient interface {
 	GetAddresses(ctx context.Context, in *GetAddressesRequest, opts ...grpc.CallOption) (*GetAddressesResponse, error)
 	// GetAddressInfo returns additional metadata about an address.
 	GetAddressInfo(ctx context.Context, in *GetAddressInfoRequest, opts ...grpc.CallOption) (*GetAddressInfoResponse, error)
+	// GetAddressInfoBatch returns the metadata for multiple addresses, or all
+	// of the wallet's addresses, keyed by address.
+	GetAddressInfoBatch(ctx context.Context, in *GetAddressInfoBatchRequest, opts ...grpc.CallOption) (*GetAddressInfoBatchResponse, error)
+	// ConvertAddresses re-encodes addresses into the current address format. Addresses
+	// using one of the network's legacy prefixes are accepted. If no addresses are
+	// provided all the wallet's addresses are converted.
+	ConvertAddresses(ctx context.Context, in *ConvertAddressesRequest, opts ...grpc.CallOption) (*ConvertAddressesResponse, error)
 	// GetNewAddress generates a new address and returns it. Both a new spend key
 	// and view key will be derived from the mnemonic seed.
 	GetNewAddress(ctx context.Context, in *GetNewAddressRequest, opts ...grpc.CallOption) (*GetNewAddressResponse, error)
@@ -1352,8 +2103,97 @@ type WalletServiceClient interface {
 	//
 	// **Requires wallet to be unlocked**
 	GetPrivateKey(ctx context.Context, in *GetPrivateKeyRequest, opts ...grpc.CallOption) (*GetPrivateKeyResponse, error)
+	// GetPrivateKeyBatch returns the serialized spend and view keys for multiple
+	// addresses, or all of the wallet's addresses, keyed by address.
+	//
+	// **Requires wallet to be unlocked**
+	GetPrivateKeyBatch(ctx context.Context, in *GetPrivateKeyBatchRequest, opts ...grpc.CallOption) (*GetPrivateKeyBatchResponse, error)
+	// SignMessage signs a message with the spend key of one of the wallet's
+	// addresses. This can be used to prove ownership of the address to a
+	// third party without moving any funds.
+	//
+	// **Requires wallet to be unlocked**
+	SignMessage(ctx context.Context, in *SignMessageRequest, opts ...grpc.CallOption) (*SignMessageResponse, error)
+	// VerifyMessage verifies a signature created by SignMessage.
+	VerifyMessage(ctx context.Context, in *VerifyMessageRequest, opts ...grpc.CallOption) (*VerifyMessageResponse, error)
+	// ProveReserves creates a proof that the wallet controls unspent coins
+	// totaling at least the requested amount. The proof can be checked by
+	// anyone with the VerifyReserves RPC.
+	//
+	// The proof reveals the notes it contains, including their amounts, so
+	// the wallet only includes as many of its largest notes as are needed
+	// to reach the requested amount.
+	//
+	// **Requires wallet to be unlocked**
+	ProveReserves(ctx context.Context, in *ProveReservesRequest, opts ...grpc.CallOption) (*ProveReservesResponse, error)
+	// ProvePayment creates a proof that a transaction output paid a specific
+	// amount to a specific address. The proof discloses the output's note,
+	// and nothing else about the wallet, so it can be handed to a third
+	// party to settle a payment dispute. It can be checked with the
+	// VerifyPayment RPC.
+	//
+	// The wallet must hold the view key of the receiving address unless the
+	// output was paid to a public address. Senders cannot decrypt their own
+	// outputs after the fact so they must supply the private output that was
+	// returned by CreateRawTransaction.
+	//
+	// **Requires TxIndex**
+	ProvePayment(ctx context.Context, in *ProvePaymentRequest, opts ...grpc.CallOption) (*ProvePaymentResponse, error)
+	// RequestInvoice requests a fresh one-time address and payment terms from
+	// the node serving invoices for the payee's address. If no peer ID is
+	// provided the network is searched for the payee's node. The terms are
+	// signed by the payee address's spend key and are checked before they
+	// are returned.
+	RequestInvoice(ctx context.Context, in *RequestInvoiceRequest, opts ...grpc.CallOption) (*RequestInvoiceResponse, error)
+	// CreateInvoice creates a merchant invoice paid to a fresh wallet address.
+	// Payments to the address are tracked as they are accepted into the mempool,
+	// included in a block that is being voted on, and finalized by consensus.
+	// If a webhook URL is provided the JSON encoded invoice is POSTed to it
+	// each time the invoice status changes.
+	//
+	// **Requires wallet to be unlocked**
+	CreateInvoice(ctx context.Context, in *CreateInvoiceRequest, opts ...grpc.CallOption) (*CreateInvoiceResponse, error)
+	// GetInvoiceStatus returns a merchant invoice along with the payments
+	// made to it and its current status.
+	GetInvoiceStatus(ctx context.Context, in *GetInvoiceStatusRequest, opts ...grpc.CallOption) (*GetInvoiceStatusResponse, error)
 	// ImportAddress imports a watch address into the wallet.
 	ImportAddress(ctx context.Context, in *ImportAddressRequest, opts ...grpc.CallOption) (*ImportAddressResponse, error)
+	// SetCustomerID associates an opaque customer identifier with one of the
+	// wallet's addresses. The identifier is returned alongside the address in
+	// GetAddressInfo, GetTransactions, and SubscribeWalletTransactions so that
+	// deposits can be credited without an external address database. An empty
+	// identifier removes the association.
+	SetCustomerID(ctx context.Context, in *SetCustomerIDRequest, opts ...grpc.CallOption) (*SetCustomerIDResponse, error)
+	// SetAddressLabel attaches a label to one of the wallet's addresses. The
+	// label is returned alongside the address in GetAddressInfo, GetUtxos,
+	// GetTransactions, and SubscribeWalletTransactions so that bookkeeping
+	// tools can categorize flows. An empty label removes the existing one.
+	SetAddressLabel(ctx context.Context, in *SetAddressLabelRequest, opts ...grpc.CallOption) (*SetAddressLabelResponse, error)
+	// GetAddressesByLabel returns the wallet's addresses with the given label
+	// sorted in lexicographical order.
+	GetAddressesByLabel(ctx context.Context, in *GetAddressesByLabelRequest, opts ...grpc.CallOption) (*GetAddressesByLabelResponse, error)
+	// ImportAddresses imports several watch addresses into the wallet. If a
+	// rescan is requested only a single rescan is run after all the addresses
+	// have been imported rather than one per address.
+	ImportAddresses(ctx context.Context, in *ImportAddressesRequest, opts ...grpc.CallOption) (*ImportAddressesResponse, error)
+	// ExportWatchOnly returns the addresses, locking scripts, and view keys
+	// needed to watch this wallet from another node. The result can be passed
+	// directly to ImportAddresses. This is used to set up wallet read replicas
+	// that serve balance and history queries without holding the spend keys.
+	ExportWatchOnly(ctx context.Context, in *ExportWatchOnlyRequest, opts ...grpc.CallOption) (*ExportWatchOnlyResponse, error)
+	// ExportDescriptor returns an account descriptor for the wallet. The
+	// descriptor is a single string holding the view keys and locking scripts
+	// for the wallet's addresses along with a change address, but none of the
+	// spend keys. It can be imported into an online node with ImportDescriptor
+	// to track the balance and build unsigned transactions which are then
+	// signed and proven by this wallet.
+	ExportDescriptor(ctx context.Context, in *ExportDescriptorRequest, opts ...grpc.CallOption) (*ExportDescriptorResponse, error)
+	// ImportDescriptor imports an account descriptor created by ExportDescriptor
+	// and rescans the chain from the descriptor's birthday. The descriptor's
+	// notes are watch-only so transactions spending them must be created with
+	// CreateRawTransaction, passing the input commitments explicitly and the
+	// descriptor's change address so the change returns to the account.
+	ImportDescriptor(ctx context.Context, in *ImportDescriptorRequest, opts ...grpc.CallOption) (*ImportDescriptorResponse, error)
 	// CreateMultisigSpendKeypair generates a spend keypair for use in a multisig address
 	CreateMultisigSpendKeypair(ctx context.Context, in *CreateMultisigSpendKeypairRequest, opts ...grpc.CallOption) (*CreateMultisigSpendKeypairResponse, error)
 	// CreateMultisigViewKeypair generates a view keypair for use in a multisig address
@@ -1371,15 +2211,41 @@ type WalletServiceClient interface {
 	WalletLock(ctx context.Context, in *WalletLockRequest, opts ...grpc.CallOption) (*WalletLockResponse, error)
 	// WalletUnlock decrypts the wallet seed and holds it in memory for the specified period of time
 	WalletUnlock(ctx context.Context, in *WalletUnlockRequest, opts ...grpc.CallOption) (*WalletUnlockResponse, error)
+	// WalletViewUnlock unlocks the wallet with the view passphrase for the
+	// specified period of time. This permits balance and history queries and
+	// address generation, but spending still requires WalletUnlock.
+	WalletViewUnlock(ctx context.Context, in *WalletViewUnlockRequest, opts ...grpc.CallOption) (*WalletViewUnlockResponse, error)
+	// SetWalletViewPassphrase sets a second, lighter, passphrase which only
+	// permits balance and history queries and address generation. Once it
+	// is set the wallet service only serves those methods while the wallet
+	// is unlocked with either passphrase, and every other method requires the
+	// full passphrase. An empty view passphrase permits them without unlocking.
+	//
+	// **Requires wallet to be unlocked**
+	SetWalletViewPassphrase(ctx context.Context, in *SetWalletViewPassphraseRequest, opts ...grpc.CallOption) (*SetWalletViewPassphraseResponse, error)
 	// SetWalletPassphrase encrypts the wallet for the first time
 	SetWalletPassphrase(ctx context.Context, in *SetWalletPassphraseRequest, opts ...grpc.CallOption) (*SetWalletPassphraseResponse, error)
 	// ChangeWalletPassphrase changes the passphrase used to encrypt the wallet private keys
 	ChangeWalletPassphrase(ctx context.Context, in *ChangeWalletPassphraseRequest, opts ...grpc.CallOption) (*ChangeWalletPassphraseResponse, error)
+	// GetDeletePrivateKeysNonce returns a single use nonce which must be passed
+	// to DeletePrivateKeys to confirm the deletion. The nonce expires after one
+	// minute and only the most recently generated nonce is valid.
+	GetDeletePrivateKeysNonce(ctx context.Context, in *GetDeletePrivateKeysNonceRequest, opts ...grpc.CallOption) (*GetDeletePrivateKeysNonceResponse, error)
 	// DeletePrivateKeys deletes the wallet's private keys and seed from disk essentially turning the wallet
 	// into a watch-only wallet. It will still record incoming transactions but cannot spend them.
 	//
+	// The request must include a nonce from GetDeletePrivateKeysNonce. Before
+	// the keys are deleted they are written to a backup file in the node's key
+	// backup directory, encrypted with the provided backup passphrase.
+	//
 	// **Requires wallet to be unlocked**
 	DeletePrivateKeys(ctx context.Context, in *DeletePrivateKeysRequest, opts ...grpc.CallOption) (*DeletePrivateKeysResponse, error)
+	// RepairWallet checks the wallet's utxos against the chain and fixes any
+	// inconsistencies, such as those left by a crash during a rescan. Utxos
+	// whose commitment is not in the accumulator or whose nullifier has been
+	// spent are removed. Missing metadata is derived again from the wallet's
+	// addresses. The actions taken are returned.
+	RepairWallet(ctx context.Context, in *RepairWalletRequest, opts ...grpc.CallOption) (*RepairWalletResponse, error)
 	// CreateRawTransaction creates a new, unsigned (unproven) transaction using the given parameters
 	CreateRawTransaction(ctx context.Context, in *CreateRawTransactionRequest, opts ...grpc.CallOption) (*CreateRawTransactionResponse, error)
 	// CreateRawStakeTransaction creates a new, unsigned (unproven) stake transaction using the given parameters
@@ -1395,8 +2261,27 @@ type WalletServiceClient interface {
 	//
 	// **Requires wallet to be unlocked**
 	SetAutoStakeRewards(ctx context.Context, in *SetAutoStakeRewardsRequest, opts ...grpc.CallOption) (*SetAutoStakeRewardsResponse, error)
+	// DelegateStake sends coins to a cold staking address. The delegate key,
+	// held by an online validator, can stake the coins but cannot spend them.
+	// The wallet's key, which can then be kept offline, can spend the coins at
+	// any time. The address is imported so the wallet tracks the coins.
+	//
+	// To stake the coins the validator imports the address using the locking
+	// script and view key returned here and calls Stake with the commitment.
+	//
+	// **Requires wallet to be unlocked**
+	DelegateStake(ctx context.Context, in *DelegateStakeRequest, opts ...grpc.CallOption) (*DelegateStakeResponse, error)
+	// RevokeDelegation spends the coins at a cold staking address back to the
+	// wallet. This removes the stake from the delegate's validator.
+	//
+	// **Requires wallet to be unlocked**
+	RevokeDelegation(ctx context.Context, in *RevokeDelegationRequest, opts ...grpc.CallOption) (*RevokeDelegationResponse, error)
 	// Spend sends coins from the wallet according to the provided parameters
 	//
+	// If the node is configured with a dust change threshold, change below the
+	// threshold is added to the fee, or sent to the donation address, rather
+	// than creating an output that costs more to spend than it is worth.
+	//
 	// **Requires wallet to be unlocked**
 	Spend(ctx context.Context, in *SpendRequest, opts ...grpc.CallOption) (*SpendResponse, error)
 	// TimelockCoins moves coins into a timelocked address using the requested timelock.
@@ -1414,9 +2299,99 @@ type WalletServiceClient interface {
 	//
 	// **Requires wallet to be unlocked**
 	SweepWallet(ctx context.Context, in *SweepWalletRequest, opts ...grpc.CallOption) (*SweepWalletResponse, error)
+	// StartSweepJob sweeps the coins from this wallet to the provided address
+	// in the background, splitting the inputs across as many transactions as
+	// needed to keep each under the per-transaction input limit. The
+	// transactions are proven and broadcast one after another. The job is
+	// saved as it progresses and resumes when the node restarts.
+	//
+	// **Requires wallet to be unlocked**
+	StartSweepJob(ctx context.Context, in *StartSweepJobRequest, opts ...grpc.CallOption) (*StartSweepJobResponse, error)
+	// GetSweepJobs returns the wallet's sweep jobs.
+	GetSweepJobs(ctx context.Context, in *GetSweepJobsRequest, opts ...grpc.CallOption) (*GetSweepJobsResponse, error)
+	// CancelSweepJob stops a running sweep job once the transaction it is
+	// proving, if any, is finished. Transactions already broadcast are not
+	// affected.
+	CancelSweepJob(ctx context.Context, in *CancelSweepJobRequest, opts ...grpc.CallOption) (*CancelSweepJobResponse, error)
+	// ResumeSweepJob restarts a sweep job which failed or was canceled. The
+	// inputs which have already been swept are skipped.
+	//
+	// **Requires wallet to be unlocked**
+	ResumeSweepJob(ctx context.Context, in *ResumeSweepJobRequest, opts ...grpc.CallOption) (*ResumeSweepJobResponse, error)
+	// InitiateSwap starts a cross chain atomic swap. A random secret is
+	// generated and the coins are sent to a hash time locked contract which
+	// the counterparty can claim by publishing the secret, or which this
+	// wallet can refund after the refund time. The counterparty then locks
+	// their coins on the other chain with the sha256 hash of the secret.
+	//
+	// The counterparty needs the contract address, locking script, and view
+	// key from the response to find and redeem the contract.
+	//
+	// **Requires wallet to be unlocked**
+	InitiateSwap(ctx context.Context, in *InitiateSwapRequest, opts ...grpc.CallOption) (*InitiateSwapResponse, error)
+	// ParticipateSwap locks coins in a hash time locked contract using the
+	// secret hash from a swap the counterparty initiated on another chain.
+	// The wallet watches for the counterparty to redeem the contract and
+	// saves the secret they publish so that the other side of the swap can
+	// be redeemed.
+	//
+	// The refund time must be well before the refund time of the
+	// initiator's contract.
+	//
+	// **Requires wallet to be unlocked**
+	ParticipateSwap(ctx context.Context, in *ParticipateSwapRequest, opts ...grpc.CallOption) (*ParticipateSwapResponse, error)
+	// RedeemSwap claims the coins in a contract funded by the counterparty
+	// using the secret. The secret is published in the transaction. The
+	// contract's claim key must be the spend key of one of the wallet's
+	// addresses, which can be found with SignMessage.
+	//
+	// If the contract isn't in the wallet yet it is imported and the wallet
+	// rescans from the rescan height. Call again once the rescan finishes.
+	//
+	// **Requires wallet to be unlocked**
+	RedeemSwap(ctx context.Context, in *RedeemSwapRequest, opts ...grpc.CallOption) (*RedeemSwapResponse, error)
+	// RefundSwap returns the coins in a contract funded by this wallet
+	// after the refund time has passed.
+	//
+	// **Requires wallet to be unlocked**
+	RefundSwap(ctx context.Context, in *RefundSwapRequest, opts ...grpc.CallOption) (*RefundSwapResponse, error)
+	// GetSwaps returns the wallet's atomic swaps.
+	GetSwaps(ctx context.Context, in *GetSwapsRequest, opts ...grpc.CallOption) (*GetSwapsResponse, error)
+	// SaveSpendTemplate saves a named spend template that can later be
+	// executed with SpendFromTemplate. If a template with the same name
+	// already exists it is overwritten.
+	SaveSpendTemplate(ctx context.Context, in *SaveSpendTemplateRequest, opts ...grpc.CallOption) (*SaveSpendTemplateResponse, error)
+	// GetSpendTemplates returns all the saved spend templates.
+	GetSpendTemplates(ctx context.Context, in *GetSpendTemplatesRequest, opts ...grpc.CallOption) (*GetSpendTemplatesResponse, error)
+	// DeleteSpendTemplate deletes a saved spend template.
+	DeleteSpendTemplate(ctx context.Context, in *DeleteSpendTemplateRequest, opts ...grpc.CallOption) (*DeleteSpendTemplateResponse, error)
+	// SpendFromTemplate builds, proves, and broadcasts a transaction paying
+	// the recipients in the named spend template.
+	//
+	// **Requires wallet to be unlocked**
+	SpendFromTemplate(ctx context.Context, in *SpendFromTemplateRequest, opts ...grpc.CallOption) (*SpendFromTemplateResponse, error)
+	// AddContact saves an address in the wallet's address book under a name.
+	// Names are case insensitive and must be unique. The address must be
+	// valid for the node's network.
+	AddContact(ctx context.Context, in *AddContactRequest, opts ...grpc.CallOption) (*AddContactResponse, error)
+	// ListContacts returns the contacts in the address book sorted by name.
+	ListContacts(ctx context.Context, in *ListContactsRequest, opts ...grpc.CallOption) (*ListContactsResponse, error)
+	// RemoveContact removes a contact from the address book.
+	RemoveContact(ctx context.Context, in *RemoveContactRequest, opts ...grpc.CallOption) (*RemoveContactResponse, error)
 	// SubscribeWalletTransactions subscribes to a stream of WalletTransactionsNotifications that return
 	// whenever a transaction belonging to the wallet finalizes.
 	SubscribeWalletTransactions(ctx context.Context, in *SubscribeWalletTransactionsRequest, opts ...grpc.CallOption) (WalletService_SubscribeWalletTransactionsClient, error)
+	// SubscribeTransactionEvents streams an event whenever a transaction
+	// paying to or spending from the wallet finalizes. The event says whether
+	// the transaction is incoming or outgoing. It's followed by a confirmation
+	// event for each new block until the transaction reaches the requested
+	// number of confirmations. An event is also sent when one of the
+	// wallet's staked utxos expires or is slashed.
+	SubscribeTransactionEvents(ctx context.Context, in *SubscribeTransactionEventsRequest, opts ...grpc.CallOption) (WalletService_SubscribeTransactionEventsClient, error)
+	// GetRescanStatus returns the progress of the wallet's current or
+	// most recent rescan, such as one started by importing an address
+	// with a rescan.
+	GetRescanStatus(ctx context.Context, in *GetRescanStatusRequest, opts ...grpc.CallOption) (*GetRescanStatusResponse, error)
 	// SubscribeWalletSyncNotifications streams notifications about the status of the wallet sync.
 	SubscribeWalletSyncNotifications(ctx context.Context, in *SubscribeWalletSyncNotificationsRequest, opts ...grpc.CallOption) (WalletService_SubscribeWalletSyncNotificationsClient, error)
 }
@@ -1492,6 +2467,24 @@ func (c *walletServiceClient) GetAddressInfo(ctx context.Context, in *GetAddress
 	return out, nil
 }
 
+func (c *walletServiceClient) GetAddressInfoBatch(ctx context.Context, in *GetAddressInfoBatchRequest, opts ...grpc.CallOption) (*GetAddressInfoBatchResponse, error) {
+	out := new(GetAddressInfoBatchResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/GetAddressInfoBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ConvertAddresses(ctx context.Context, in *ConvertAddressesRequest, opts ...grpc.CallOption) (*ConvertAddressesResponse, error) {
+	out := new(ConvertAddressesResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/ConvertAddresses", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *walletServiceClient) GetNewAddress(ctx context.Context, in *GetNewAddressRequest, opts ...grpc.CallOption) (*GetNewAddressResponse, error) {
 	out := new(GetNewAddressResponse)
 	err := c.cc.Invoke(ctx, "/pb.WalletService/GetNewAddress", in, out, opts...)
@@ -1528,135 +2521,306 @@ func (c *walletServiceClient) GetPrivateKey(ctx context.Context, in *GetPrivateK
 	return out, nil
 }
 
-func (c *walletServiceClient) ImportAddress(ctx context.Context, in *ImportAddressRequest, opts ...grpc.CallOption) (*ImportAddressResponse, error) {
-	out := new(ImportAddressResponse)
-	err := c.cc.Invoke(ctx, "/pb.WalletService/ImportAddress", in, out, opts...)
+func (c *walletServiceClient) GetPrivateKeyBatch(ctx context.Context, in *GetPrivateKeyBatchRequest, opts ...grpc.CallOption) (*GetPrivateKeyBatchResponse, error) {
+	out := new(GetPrivateKeyBatchResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/GetPrivateKeyBatch", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *walletServiceClient) CreateMultisigSpendKeypair(ctx context.Context, in *CreateMultisigSpendKeypairRequest, opts ...grpc.CallOption) (*CreateMultisigSpendKeypairResponse, error) {
-	out := new(CreateMultisigSpendKeypairResponse)
-	err := c.cc.Invoke(ctx, "/pb.WalletService/CreateMultisigSpendKeypair", in, out, opts...)
+func (c *walletServiceClient) SignMessage(ctx context.Context, in *SignMessageRequest, opts ...grpc.CallOption) (*SignMessageResponse, error) {
+	out := new(SignMessageResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/SignMessage", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *walletServiceClient) CreateMultisigViewKeypair(ctx context.Context, in *CreateMultisigViewKeypairRequest, opts ...grpc.CallOption) (*CreateMultisigViewKeypairResponse, error) {
-	out := new(CreateMultisigViewKeypairResponse)
-	err := c.cc.Invoke(ctx, "/pb.WalletService/CreateMultisigViewKeypair", in, out, opts...)
+func (c *walletServiceClient) VerifyMessage(ctx context.Context, in *VerifyMessageRequest, opts ...grpc.CallOption) (*VerifyMessageResponse, error) {
+	out := new(VerifyMessageResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/VerifyMessage", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *walletServiceClient) CreateMultisigAddress(ctx context.Context, in *CreateMultisigAddressRequest, opts ...grpc.CallOption) (*CreateMultisigAddressResponse, error) {
-	out := new(CreateMultisigAddressResponse)
-	err := c.cc.Invoke(ctx, "/pb.WalletService/CreateMultisigAddress", in, out, opts...)
+func (c *walletServiceClient) ProveReserves(ctx context.Context, in *ProveReservesRequest, opts ...grpc.CallOption) (*ProveReservesResponse, error) {
+	out := new(ProveReservesResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/ProveReserves", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *walletServiceClient) CreateMultiSignature(ctx context.Context, in *CreateMultiSignatureRequest, opts ...grpc.CallOption) (*CreateMultiSignatureResponse, error) {
-	out := new(CreateMultiSignatureResponse)
-	err := c.cc.Invoke(ctx, "/pb.WalletService/CreateMultiSignature", in, out, opts...)
+func (c *walletServiceClient) ProvePayment(ctx context.Context, in *ProvePaymentRequest, opts ...grpc.CallOption) (*ProvePaymentResponse, error) {
+	out := new(ProvePaymentResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/ProvePayment", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *walletServiceClient) ProveMultisig(ctx context.Context, in *ProveMultisigRequest, opts ...grpc.CallOption) (*ProveMultisigResponse, error) {
-	out := new(ProveMultisigResponse)
-	err := c.cc.Invoke(ctx, "/pb.WalletService/ProveMultisig", in, out, opts...)
+func (c *walletServiceClient) RequestInvoice(ctx context.Context, in *RequestInvoiceRequest, opts ...grpc.CallOption) (*RequestInvoiceResponse, error) {
+	out := new(RequestInvoiceResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/RequestInvoice", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *walletServiceClient) WalletLock(ctx context.Context, in *WalletLockRequest, opts ...grpc.CallOption) (*WalletLockResponse, error) {
-	out := new(WalletLockResponse)
-	err := c.cc.Invoke(ctx, "/pb.WalletService/WalletLock", in, out, opts...)
+func (c *walletServiceClient) CreateInvoice(ctx context.Context, in *CreateInvoiceRequest, opts ...grpc.CallOption) (*CreateInvoiceResponse, error) {
+	out := new(CreateInvoiceResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/CreateInvoice", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *walletServiceClient) WalletUnlock(ctx context.Context, in *WalletUnlockRequest, opts ...grpc.CallOption) (*WalletUnlockResponse, error) {
-	out := new(WalletUnlockResponse)
-	err := c.cc.Invoke(ctx, "/pb.WalletService/WalletUnlock", in, out, opts...)
+func (c *walletServiceClient) GetInvoiceStatus(ctx context.Context, in *GetInvoiceStatusRequest, opts ...grpc.CallOption) (*GetInvoiceStatusResponse, error) {
+	out := new(GetInvoiceStatusResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/GetInvoiceStatus", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *walletServiceClient) SetWalletPassphrase(ctx context.Context, in *SetWalletPassphraseRequest, opts ...grpc.CallOption) (*SetWalletPassphraseResponse, error) {
-	out := new(SetWalletPassphraseResponse)
-	err := c.cc.Invoke(ctx, "/pb.WalletService/SetWalletPassphrase", in, out, opts...)
+func (c *walletServiceClient) ImportAddress(ctx context.Context, in *ImportAddressRequest, opts ...grpc.CallOption) (*ImportAddressResponse, error) {
+	out := new(ImportAddressResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/ImportAddress", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *walletServiceClient) ChangeWalletPassphrase(ctx context.Context, in *ChangeWalletPassphraseRequest, opts ...grpc.CallOption) (*ChangeWalletPassphraseResponse, error) {
-	out := new(ChangeWalletPassphraseResponse)
-	err := c.cc.Invoke(ctx, "/pb.WalletService/ChangeWalletPassphrase", in, out, opts...)
+func (c *walletServiceClient) SetCustomerID(ctx context.Context, in *SetCustomerIDRequest, opts ...grpc.CallOption) (*SetCustomerIDResponse, error) {
+	out := new(SetCustomerIDResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/SetCustomerID", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *walletServiceClient) DeletePrivateKeys(ctx context.Context, in *DeletePrivateKeysRequest, opts ...grpc.CallOption) (*DeletePrivateKeysResponse, error) {
-	out := new(DeletePrivateKeysResponse)
-	err := c.cc.Invoke(ctx, "/pb.WalletService/DeletePrivateKeys", in, out, opts...)
+func (c *walletServiceClient) SetAddressLabel(ctx context.Context, in *SetAddressLabelRequest, opts ...grpc.CallOption) (*SetAddressLabelResponse, error) {
+	out := new(SetAddressLabelResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/SetAddressLabel", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *walletServiceClient) CreateRawTransaction(ctx context.Context, in *CreateRawTransactionRequest, opts ...grpc.CallOption) (*CreateRawTransactionResponse, error) {
-	out := new(CreateRawTransactionResponse)
-	err := c.cc.Invoke(ctx, "/pb.WalletService/CreateRawTransaction", in, out, opts...)
+func (c *walletServiceClient) GetAddressesByLabel(ctx context.Context, in *GetAddressesByLabelRequest, opts ...grpc.CallOption) (*GetAddressesByLabelResponse, error) {
+	out := new(GetAddressesByLabelResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/GetAddressesByLabel", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *walletServiceClient) CreateRawStakeTransaction(ctx context.Context, in *CreateRawStakeTransactionRequest, opts ...grpc.CallOption) (*CreateRawStakeTransactionResponse, error) {
-	out := new(CreateRawStakeTransactionResponse)
-	err := c.cc.Invoke(ctx, "/pb.WalletService/CreateRawStakeTransaction", in, out, opts...)
+func (c *walletServiceClient) ImportAddresses(ctx context.Context, in *ImportAddressesRequest, opts ...grpc.CallOption) (*ImportAddressesResponse, error) {
+	out := new(ImportAddressesResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/ImportAddresses", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *walletServiceClient) ProveRawTransaction(ctx context.Context, in *ProveRawTransactionRequest, opts ...grpc.CallOption) (*ProveRawTransactionResponse, error) {
-	out := new(ProveRawTransactionResponse)
-	err := c.cc.Invoke(ctx, "/pb.WalletService/ProveRawTransaction", in, out, opts...)
+func (c *walletServiceClient) ExportWatchOnly(ctx context.Context, in *ExportWatchOnlyRequest, opts ...grpc.CallOption) (*ExportWatchOnlyResponse, error) {
+	out := new(ExportWatchOnlyResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/ExportWatchOnly", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *walletServiceClient) Stake(ctx context.Context, in *StakeRequest, opts ...grpc.CallOption) (*StakeResponse, error) {
-	out := new(StakeResponse)
-	err := c.cc.Invoke(ctx, "/pb.WalletService/Stake", in, out, opts...)
+func (c *walletServiceClient) ExportDescriptor(ctx context.Context, in *ExportDescriptorRequest, opts ...grpc.CallOption) (*ExportDescriptorResponse, error) {
+	out := new(ExportDescriptorResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/ExportDescriptor", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ImportDescriptor(ctx context.Context, in *ImportDescriptorRequest, opts ...grpc.CallOption) (*ImportDescriptorResponse, error) {
+	out := new(ImportDescriptorResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/ImportDescriptor", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) CreateMultisigSpendKeypair(ctx context.Context, in *CreateMultisigSpendKeypairRequest, opts ...grpc.CallOption) (*CreateMultisigSpendKeypairResponse, error) {
+	out := new(CreateMultisigSpendKeypairResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/CreateMultisigSpendKeypair", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) CreateMultisigViewKeypair(ctx context.Context, in *CreateMultisigViewKeypairRequest, opts ...grpc.CallOption) (*CreateMultisigViewKeypairResponse, error) {
+	out := new(CreateMultisigViewKeypairResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/CreateMultisigViewKeypair", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) CreateMultisigAddress(ctx context.Context, in *CreateMultisigAddressRequest, opts ...grpc.CallOption) (*CreateMultisigAddressResponse, error) {
+	out := new(CreateMultisigAddressResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/CreateMultisigAddress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) CreateMultiSignature(ctx context.Context, in *CreateMultiSignatureRequest, opts ...grpc.CallOption) (*CreateMultiSignatureResponse, error) {
+	out := new(CreateMultiSignatureResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/CreateMultiSignature", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ProveMultisig(ctx context.Context, in *ProveMultisigRequest, opts ...grpc.CallOption) (*ProveMultisigResponse, error) {
+	out := new(ProveMultisigResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/ProveMultisig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) WalletLock(ctx context.Context, in *WalletLockRequest, opts ...grpc.CallOption) (*WalletLockResponse, error) {
+	out := new(WalletLockResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/WalletLock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) WalletUnlock(ctx context.Context, in *WalletUnlockRequest, opts ...grpc.CallOption) (*WalletUnlockResponse, error) {
+	out := new(WalletUnlockResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/WalletUnlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) WalletViewUnlock(ctx context.Context, in *WalletViewUnlockRequest, opts ...grpc.CallOption) (*WalletViewUnlockResponse, error) {
+	out := new(WalletViewUnlockResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/WalletViewUnlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) SetWalletViewPassphrase(ctx context.Context, in *SetWalletViewPassphraseRequest, opts ...grpc.CallOption) (*SetWalletViewPassphraseResponse, error) {
+	out := new(SetWalletViewPassphraseResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/SetWalletViewPassphrase", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) SetWalletPassphrase(ctx context.Context, in *SetWalletPassphraseRequest, opts ...grpc.CallOption) (*SetWalletPassphraseResponse, error) {
+	out := new(SetWalletPassphraseResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/SetWalletPassphrase", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ChangeWalletPassphrase(ctx context.Context, in *ChangeWalletPassphraseRequest, opts ...grpc.CallOption) (*ChangeWalletPassphraseResponse, error) {
+	out := new(ChangeWalletPassphraseResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/ChangeWalletPassphrase", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) GetDeletePrivateKeysNonce(ctx context.Context, in *GetDeletePrivateKeysNonceRequest, opts ...grpc.CallOption) (*GetDeletePrivateKeysNonceResponse, error) {
+	out := new(GetDeletePrivateKeysNonceResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/GetDeletePrivateKeysNonce", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) DeletePrivateKeys(ctx context.Context, in *DeletePrivateKeysRequest, opts ...grpc.CallOption) (*DeletePrivateKeysResponse, error) {
+	out := new(DeletePrivateKeysResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/DeletePrivateKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) RepairWallet(ctx context.Context, in *RepairWalletRequest, opts ...grpc.CallOption) (*RepairWalletResponse, error) {
+	out := new(RepairWalletResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/RepairWallet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) CreateRawTransaction(ctx context.Context, in *CreateRawTransactionRequest, opts ...grpc.CallOption) (*CreateRawTransactionResponse, error) {
+	out := new(CreateRawTransactionResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/CreateRawTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) CreateRawStakeTransaction(ctx context.Context, in *CreateRawStakeTransactionRequest, opts ...grpc.CallOption) (*CreateRawStakeTransactionResponse, error) {
+	out := new(CreateRawStakeTransactionResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/CreateRawStakeTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ProveRawTransaction(ctx context.Context, in *ProveRawTransactionRequest, opts ...grpc.CallOption) (*ProveRawTransactionResponse, error) {
+	out := new(ProveRawTransactionResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/ProveRawTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) Stake(ctx context.Context, in *StakeRequest, opts ...grpc.CallOption) (*StakeResponse, error) {
+	out := new(StakeResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/Stake", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1672,6 +2836,24 @@ func (c *walletServiceClient) SetAutoStakeRewards(ctx context.Context, in *SetAu
 	return out, nil
 }
 
+func (c *walletServiceClient) DelegateStake(ctx context.Context, in *DelegateStakeRequest, opts ...grpc.CallOption) (*DelegateStakeResponse, error) {
+	out := new(DelegateStakeResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/DelegateStake", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) RevokeDelegation(ctx context.Context, in *RevokeDelegationRequest, opts ...grpc.CallOption) (*RevokeDelegationResponse, error) {
+	out := new(RevokeDelegationResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/RevokeDelegation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *walletServiceClient) Spend(ctx context.Context, in *SpendRequest, opts ...grpc.CallOption) (*SpendResponse, error) {
 	out := new(SpendResponse)
 	err := c.cc.Invoke(ctx, "/pb.WalletService/Spend", in, out, opts...)
@@ -1699,91 +2881,276 @@ func (c *walletServiceClient) SweepWallet(ctx context.Context, in *SweepWalletRe
 	return out, nil
 }
 
-func (c *walletServiceClient) SubscribeWalletTransactions(ctx context.Context, in *SubscribeWalletTransactionsRequest, opts ...grpc.CallOption) (WalletService_SubscribeWalletTransactionsClient, error) {
-	stream, err := c.cc.NewStream(ctx, &WalletService_ServiceDesc.Streams[0], "/pb.WalletService/SubscribeWalletTransactions", opts...)
+func (c *walletServiceClient) StartSweepJob(ctx context.Context, in *StartSweepJobRequest, opts ...grpc.CallOption) (*StartSweepJobResponse, error) {
+	out := new(StartSweepJobResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/StartSweepJob", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &walletServiceSubscribeWalletTransactionsClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
+	return out, nil
+}
+
+func (c *walletServiceClient) GetSweepJobs(ctx context.Context, in *GetSweepJobsRequest, opts ...grpc.CallOption) (*GetSweepJobsResponse, error) {
+	out := new(GetSweepJobsResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/GetSweepJobs", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
+	return out, nil
+}
+
+func (c *walletServiceClient) CancelSweepJob(ctx context.Context, in *CancelSweepJobRequest, opts ...grpc.CallOption) (*CancelSweepJobResponse, error) {
+	out := new(CancelSweepJobResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/CancelSweepJob", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	return x, nil
+	return out, nil
 }
 
-type WalletService_SubscribeWalletTransactionsClient interface {
-	Recv() (*WalletTransactionNotification, error)
-	grpc.ClientStream
+func (c *walletServiceClient) ResumeSweepJob(ctx context.Context, in *ResumeSweepJobRequest, opts ...grpc.CallOption) (*ResumeSweepJobResponse, error) {
+	out := new(ResumeSweepJobResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/ResumeSweepJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-type walletServiceSubscribeWalletTransactionsClient struct {
-	grpc.ClientStream
+func (c *walletServiceClient) InitiateSwap(ctx context.Context, in *InitiateSwapRequest, opts ...grpc.CallOption) (*InitiateSwapResponse, error) {
+	out := new(InitiateSwapResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/InitiateSwap", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (x *walletServiceSubscribeWalletTransactionsClient) Recv() (*WalletTransactionNotification, error) {
-	m := new(WalletTransactionNotification)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
+func (c *walletServiceClient) ParticipateSwap(ctx context.Context, in *ParticipateSwapRequest, opts ...grpc.CallOption) (*ParticipateSwapResponse, error) {
+	out := new(ParticipateSwapResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/ParticipateSwap", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	return m, nil
+	return out, nil
 }
 
-func (c *walletServiceClient) SubscribeWalletSyncNotifications(ctx context.Context, in *SubscribeWalletSyncNotificationsRequest, opts ...grpc.CallOption) (WalletService_SubscribeWalletSyncNotificationsClient, error) {
-	stream, err := c.cc.NewStream(ctx, &WalletService_ServiceDesc.Streams[1], "/pb.WalletService/SubscribeWalletSyncNotifications", opts...)
+func (c *walletServiceClient) RedeemSwap(ctx context.Context, in *RedeemSwapRequest, opts ...grpc.CallOption) (*RedeemSwapResponse, error) {
+	out := new(RedeemSwapResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/RedeemSwap", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &walletServiceSubscribeWalletSyncNotificationsClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
+	return out, nil
+}
+
+func (c *walletServiceClient) RefundSwap(ctx context.Context, in *RefundSwapRequest, opts ...grpc.CallOption) (*RefundSwapResponse, error) {
+	out := new(RefundSwapResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/RefundSwap", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
+	return out, nil
+}
+
+func (c *walletServiceClient) GetSwaps(ctx context.Context, in *GetSwapsRequest, opts ...grpc.CallOption) (*GetSwapsResponse, error) {
+	out := new(GetSwapsResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/GetSwaps", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	return x, nil
+	return out, nil
 }
 
-type WalletService_SubscribeWalletSyncNotificationsClient interface {
-	Recv() (*WalletSyncNotification, error)
-	grpc.ClientStream
+func (c *walletServiceClient) SaveSpendTemplate(ctx context.Context, in *SaveSpendTemplateRequest, opts ...grpc.CallOption) (*SaveSpendTemplateResponse, error) {
+	out := new(SaveSpendTemplateResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/SaveSpendTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-type walletServiceSubscribeWalletSyncNotificationsClient struct {
-	grpc.ClientStream
+func (c *walletServiceClient) GetSpendTemplates(ctx context.Context, in *GetSpendTemplatesRequest, opts ...grpc.CallOption) (*GetSpendTemplatesResponse, error) {
+	out := new(GetSpendTemplatesResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/GetSpendTemplates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (x *walletServiceSubscribeWalletSyncNotificationsClient) Recv() (*WalletSyncNotification, error) {
-	m := new(WalletSyncNotification)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
+func (c *walletServiceClient) DeleteSpendTemplate(ctx context.Context, in *DeleteSpendTemplateRequest, opts ...grpc.CallOption) (*DeleteSpendTemplateResponse, error) {
+	out := new(DeleteSpendTemplateResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/DeleteSpendTemplate", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	return m, nil
+	return out, nil
 }
 
-// WalletServiceServer is the server API for WalletService service.
-// All implementations must embed UnimplementedWalletServiceServer
-// for forward compatibility
-type WalletServiceServer interface {
-	// GetBalance returns the combined balance of all addresses in the wallet
-	GetBalance(context.Context, *GetBalanceRequest) (*GetBalanceResponse, error)
-	// GetWalletSeed returns the mnemonic seed for the wallet. If the wallet
-	// seed has been deleted via the `DeletePrivateKeys` RPC an error will be
-	// returned.
-	//
-	// **Requires wallet to be unlocked**
-	GetWalletSeed(context.Context, *GetWalletSeedRequest) (*GetWalletSeedResponse, error)
-	// GetAddress returns the most recent address of the wallet.
-	GetAddress(context.Context, *GetAddressRequest) (*GetAddressResponse, error)
-	// GetTimelockedAddress returns a timelocked address that cannot be spent
-	// from until the given timelock has passed.
-	//
-	// The private key used for this address is the same as the wallet's most
-	// recent spend key used in a basic address. This implies the key can be
-	// derived from seed, however the wallet will not detect incoming payments
-	// to this address unless the timelock is included in the utxo's state field.
+func (c *walletServiceClient) SpendFromTemplate(ctx context.Context, in *SpendFromTemplateRequest, opts ...grpc.CallOption) (*SpendFromTemplateResponse, error) {
+	out := new(SpendFromTemplateResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/SpendFromTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) AddContact(ctx context.Context, in *AddContactRequest, opts ...grpc.CallOption) (*AddContactResponse, error) {
+	out := new(AddContactResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/AddContact", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ListContacts(ctx context.Context, in *ListContactsRequest, opts ...grpc.CallOption) (*ListContactsResponse, error) {
+	out := new(ListContactsResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/ListContacts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) RemoveContact(ctx context.Context, in *RemoveContactRequest, opts ...grpc.CallOption) (*RemoveContactResponse, error) {
+	out := new(RemoveContactResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/RemoveContact", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) SubscribeWalletTransactions(ctx context.Context, in *SubscribeWalletTransactionsRequest, opts ...grpc.CallOption) (WalletService_SubscribeWalletTransactionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &WalletService_ServiceDesc.Streams[0], "/pb.WalletService/SubscribeWalletTransactions", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &walletServiceSubscribeWalletTransactionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type WalletService_SubscribeWalletTransactionsClient interface {
+	Recv() (*WalletTransactionNotification, error)
+	grpc.ClientStream
+}
+
+type walletServiceSubscribeWalletTransactionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *walletServiceSubscribeWalletTransactionsClient) Recv() (*WalletTransactionNotification, error) {
+	m := new(WalletTransactionNotification)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *walletServiceClient) SubscribeTransactionEvents(ctx context.Context, in *SubscribeTransactionEventsRequest, opts ...grpc.CallOption) (WalletService_SubscribeTransactionEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &WalletService_ServiceDesc.Streams[1], "/pb.WalletService/SubscribeTransactionEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &walletServiceSubscribeTransactionEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type WalletService_SubscribeTransactionEventsClient interface {
+	Recv() (*TransactionEvent, error)
+	grpc.ClientStream
+}
+
+type walletServiceSubscribeTransactionEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *walletServiceSubscribeTransactionEventsClient) Recv() (*TransactionEvent, error) {
+	m := new(TransactionEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *walletServiceClient) GetRescanStatus(ctx context.Context, in *GetRescanStatusRequest, opts ...grpc.CallOption) (*GetRescanStatusResponse, error) {
+	out := new(GetRescanStatusResponse)
+	err := c.cc.Invoke(ctx, "/pb.WalletService/GetRescanStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) SubscribeWalletSyncNotifications(ctx context.Context, in *SubscribeWalletSyncNotificationsRequest, opts ...grpc.CallOption) (WalletService_SubscribeWalletSyncNotificationsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &WalletService_ServiceDesc.Streams[2], "/pb.WalletService/SubscribeWalletSyncNotifications", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &walletServiceSubscribeWalletSyncNotificationsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type WalletService_SubscribeWalletSyncNotificationsClient interface {
+	Recv() (*WalletSyncNotification, error)
+	grpc.ClientStream
+}
+
+type walletServiceSubscribeWalletSyncNotificationsClient struct {
+	grpc.ClientStream
+}
+
+func (x *walletServiceSubscribeWalletSyncNotificationsClient) Recv() (*WalletSyncNotification, error) {
+	m := new(WalletSyncNotification)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// WalletServiceServer is the server API for WalletService service.
+// All implementations must embed UnimplementedWalletServiceServer
+// for forward compatibility
+type WalletServiceServer interface {
+	// GetBalance returns the combined balance of all addresses in the wallet
+	GetBalance(context.Context, *GetBalanceRequest) (*GetBalanceResponse, error)
+	// GetWalletSeed returns the mnemonic seed for the wallet. If the wallet
+	// seed has been deleted via the `DeletePrivateKeys` RPC an error will be
+	// returned.
+	//
+	// **Requires wallet to be unlocked**
+	GetWalletSeed(context.Context, *GetWalletSeedRequest) (*GetWalletSeedResponse, error)
+	// GetAddress returns the most recent address of the wallet.
+	GetAddress(context.Context, *GetAddressRequest) (*GetAddressResponse, error)
+	// GetTimelockedAddress returns a timelocked address that cannot be spent
+	// from until the given timelock has passed.
+	//
+	// The private key used for this address is the same as the wallet's most
+	// recent spend key used in a basic address. This implies the key can be
+	// derived from seed, however the wallet will not detect incoming payments
+	// to this address unless the timelock is included in the utxo's state field.
 	GetTimelockedAddress(context.Context, *GetTimelockedAddressRequest) (*GetTimelockedAddressResponse, error)
 	// GetPublicAddress returns a public address for the wallet. This address type
 	// requires that the private output data that is normally encrypted with the
@@ -1797,6 +3164,13 @@ type WalletServiceServer interface {
 	GetAddresses(context.Context, *GetAddressesRequest) (*GetAddressesResponse, error)
 	// GetAddressInfo returns additional metadata about an address.
 	GetAddressInfo(context.Context, *GetAddressInfoRequest) (*GetAddressInfoResponse, error)
+	// GetAddressInfoBatch returns the metadata for multiple addresses, or all
+	// of the wallet's addresses, keyed by address.
+	GetAddressInfoBatch(context.Context, *GetAddressInfoBatchRequest) (*GetAddressInfoBatchResponse, error)
+	// ConvertAddresses re-encodes addresses into the current address format. Addresses
+	// using one of the network's legacy prefixes are accepted. If no addresses are
+	// provided all the wallet's addresses are converted.
+	ConvertAddresses(context.Context, *ConvertAddressesRequest) (*ConvertAddressesResponse, error)
 	// GetNewAddress generates a new address and returns it. Both a new spend key
 	// and view key will be derived from the mnemonic seed.
 	GetNewAddress(context.Context, *GetNewAddressRequest) (*GetNewAddressResponse, error)
@@ -1808,8 +3182,97 @@ type WalletServiceServer interface {
 	//
 	// **Requires wallet to be unlocked**
 	GetPrivateKey(context.Context, *GetPrivateKeyRequest) (*GetPrivateKeyResponse, error)
+	// GetPrivateKeyBatch returns the serialized spend and view keys for multiple
+	// addresses, or all of the wallet's addresses, keyed by address.
+	//
+	// **Requires wallet to be unlocked**
+	GetPrivateKeyBatch(context.Context, *GetPrivateKeyBatchRequest) (*GetPrivateKeyBatchResponse, error)
+	// SignMessage signs a message with the spend key of one of the wallet's
+	// addresses. This can be used to prove ownership of the address to a
+	// third party without moving any funds.
+	//
+	// **Requires wallet to be unlocked**
+	SignMessage(context.Context, *SignMessageRequest) (*SignMessageResponse, error)
+	// VerifyMessage verifies a signature created by SignMessage.
+	VerifyMessage(context.Context, *VerifyMessageRequest) (*VerifyMessageResponse, error)
+	// ProveReserves creates a proof that the wallet controls unspent coins
+	// totaling at least the requested amount. The proof can be checked by
+	// anyone with the VerifyReserves RPC.
+	//
+	// The proof reveals the notes it contains, including their amounts, so
+	// the wallet only includes as many of its largest notes as are needed
+	// to reach the requested amount.
+	//
+	// **Requires wallet to be unlocked**
+	ProveReserves(context.Context, *ProveReservesRequest) (*ProveReservesResponse, error)
+	// ProvePayment creates a proof that a transaction output paid a specific
+	// amount to a specific address. The proof discloses the output's note,
+	// and nothing else about the wallet, so it can be handed to a third
+	// party to settle a payment dispute. It can be checked with the
+	// VerifyPayment RPC.
+	//
+	// The wallet must hold the view key of the receiving address unless the
+	// output was paid to a public address. Senders cannot decrypt their own
+	// outputs after the fact so they must supply the private output that was
+	// returned by CreateRawTransaction.
+	//
+	// **Requires TxIndex**
+	ProvePayment(context.Context, *ProvePaymentRequest) (*ProvePaymentResponse, error)
+	// RequestInvoice requests a fresh one-time address and payment terms from
+	// the node serving invoices for the payee's address. If no peer ID is
+	// provided the network is searched for the payee's node. The terms are
+	// signed by the payee address's spend key and are checked before they
+	// are returned.
+	RequestInvoice(context.Context, *RequestInvoiceRequest) (*RequestInvoiceResponse, error)
+	// CreateInvoice creates a merchant invoice paid to a fresh wallet address.
+	// Payments to the address are tracked as they are accepted into the mempool,
+	// included in a block that is being voted on, and finalized by consensus.
+	// If a webhook URL is provided the JSON encoded invoice is POSTed to it
+	// each time the invoice status changes.
+	//
+	// **Requires wallet to be unlocked**
+	CreateInvoice(context.Context, *CreateInvoiceRequest) (*CreateInvoiceResponse, error)
+	// GetInvoiceStatus returns a merchant invoice along with the payments
+	// made to it and its current status.
+	GetInvoiceStatus(context.Context, *GetInvoiceStatusRequest) (*GetInvoiceStatusResponse, error)
 	// ImportAddress imports a watch address into the wallet.
 	ImportAddress(context.Context, *ImportAddressRequest) (*ImportAddressResponse, error)
+	// SetCustomerID associates an opaque customer identifier with one of the
+	// wallet's addresses. The identifier is returned alongside the address in
+	// GetAddressInfo, GetTransactions, and SubscribeWalletTransactions so that
+	// deposits can be credited without an external address database. An empty
+	// identifier removes the association.
+	SetCustomerID(context.Context, *SetCustomerIDRequest) (*SetCustomerIDResponse, error)
+	// SetAddressLabel attaches a label to one of the wallet's addresses. The
+	// label is returned alongside the address in GetAddressInfo, GetUtxos,
+	// GetTransactions, and SubscribeWalletTransactions so that bookkeeping
+	// tools can categorize flows. An empty label removes the existing one.
+	SetAddressLabel(context.Context, *SetAddressLabelRequest) (*SetAddressLabelResponse, error)
+	// GetAddressesByLabel returns the wallet's addresses with the given label
+	// sorted in lexicographical order.
+	GetAddressesByLabel(context.Context, *GetAddressesByLabelRequest) (*GetAddressesByLabelResponse, error)
+	// ImportAddresses imports several watch addresses into the wallet. If a
+	// rescan is requested only a single rescan is run after all the addresses
+	// have been imported rather than one per address.
+	ImportAddresses(context.Context, *ImportAddressesRequest) (*ImportAddressesResponse, error)
+	// ExportWatchOnly returns the addresses, locking scripts, and view keys
+	// needed to watch this wallet from another node. The result can be passed
+	// directly to ImportAddresses. This is used to set up wallet read replicas
+	// that serve balance and history queries without holding the spend keys.
+	ExportWatchOnly(context.Context, *ExportWatchOnlyRequest) (*ExportWatchOnlyResponse, error)
+	// ExportDescriptor returns an account descriptor for the wallet. The
+	// descriptor is a single string holding the view keys and locking scripts
+	// for the wallet's addresses along with a change address, but none of the
+	// spend keys. It can be imported into an online node with ImportDescriptor
+	// to track the balance and build unsigned transactions which are then
+	// signed and proven by this wallet.
+	ExportDescriptor(context.Context, *ExportDescriptorRequest) (*ExportDescriptorResponse, error)
+	// ImportDescriptor imports an account descriptor created by ExportDescriptor
+	// and rescans the chain from the descriptor's birthday. The descriptor's
+	// notes are watch-only so transactions spending them must be created with
+	// CreateRawTransaction, passing the input commitments explicitly and the
+	// descriptor's change address so the change returns to the account.
+	ImportDescriptor(context.Context, *ImportDescriptorRequest) (*ImportDescriptorResponse, error)
 	// CreateMultisigSpendKeypair generates a spend keypair for use in a multisig address
 	CreateMultisigSpendKeypair(context.Context, *CreateMultisigSpendKeypairRequest) (*CreateMultisigSpendKeypairResponse, error)
 	// CreateMultisigViewKeypair generates a view keypair for use in a multisig address
@@ -1827,15 +3290,41 @@ type WalletServiceServer interface {
 	WalletLock(context.Context, *WalletLockRequest) (*WalletLockResponse, error)
 	// WalletUnlock decrypts the wallet seed and holds it in memory for the specified period of time
 	WalletUnlock(context.Context, *WalletUnlockRequest) (*WalletUnlockResponse, error)
+	// WalletViewUnlock unlocks the wallet with the view passphrase for the
+	// specified period of time. This permits balance and history queries and
+	// address generation, but spending still requires WalletUnlock.
+	WalletViewUnlock(context.Context, *WalletViewUnlockRequest) (*WalletViewUnlockResponse, error)
+	// SetWalletViewPassphrase sets a second, lighter, passphrase which only
+	// permits balance and history queries and address generation. Once it
+	// is set the wallet service only serves those methods while the wallet
+	// is unlocked with either passphrase, and every other method requires the
+	// full passphrase. An empty view passphrase permits them without unlocking.
+	//
+	// **Requires wallet to be unlocked**
+	SetWalletViewPassphrase(context.Context, *SetWalletViewPassphraseRequest) (*SetWalletViewPassphraseResponse, error)
 	// SetWalletPassphrase encrypts the wallet for the first time
 	SetWalletPassphrase(context.Context, *SetWalletPassphraseRequest) (*SetWalletPassphraseResponse, error)
 	// ChangeWalletPassphrase changes the passphrase used to encrypt the wallet private keys
 	ChangeWalletPassphrase(context.Context, *ChangeWalletPassphraseRequest) (*ChangeWalletPassphraseResponse, error)
+	// GetDeletePrivateKeysNonce returns a single use nonce which must be passed
+	// to DeletePrivateKeys to confirm the deletion. The nonce expires after one
+	// minute and only the most recently generated nonce is valid.
+	GetDeletePrivateKeysNonce(context.Context, *GetDeletePrivateKeysNonceRequest) (*GetDeletePrivateKeysNonceResponse, error)
 	// DeletePrivateKeys deletes the wallet's private keys and seed from disk essentially turning the wallet
 	// into a watch-only wallet. It will still record incoming transactions but cannot spend them.
 	//
+	// The request must include a nonce from GetDeletePrivateKeysNonce. Before
+	// the keys are deleted they are written to a backup file in the node's key
+	// backup directory, encrypted with the provided backup passphrase.
+	//
 	// **Requires wallet to be unlocked**
 	DeletePrivateKeys(context.Context, *DeletePrivateKeysRequest) (*DeletePrivateKeysResponse, error)
+	// RepairWallet checks the wallet's utxos against the chain and fixes any
+	// inconsistencies, such as those left by a crash during a rescan. Utxos
+	// whose commitment is not in the accumulator or whose nullifier has been
+	// spent are removed. Missing metadata is derived again from the wallet's
+	// addresses. The actions taken are returned.
+	RepairWallet(context.Context, *RepairWalletRequest) (*RepairWalletResponse, error)
 	// CreateRawTransaction creates a new, unsigned (unproven) transaction using the given parameters
 	CreateRawTransaction(context.Context, *CreateRawTransactionRequest) (*CreateRawTransactionResponse, error)
 	// CreateRawStakeTransaction creates a new, unsigned (unproven) stake transaction using the given parameters
@@ -1851,8 +3340,27 @@ type WalletServiceServer interface {
 	//
 	// **Requires wallet to be unlocked**
 	SetAutoStakeRewards(context.Context, *SetAutoStakeRewardsRequest) (*SetAutoStakeRewardsResponse, error)
+	// DelegateStake sends coins to a cold staking address. The delegate key,
+	// held by an online validator, can stake the coins but cannot spend them.
+	// The wallet's key, which can then be kept offline, can spend the coins at
+	// any time. The address is imported so the wallet tracks the coins.
+	//
+	// To stake the coins the validator imports the address using the locking
+	// script and view key returned here and calls Stake with the commitment.
+	//
+	// **Requires wallet to be unlocked**
+	DelegateStake(context.Context, *DelegateStakeRequest) (*DelegateStakeResponse, error)
+	// RevokeDelegation spends the coins at a cold staking address back to the
+	// wallet. This removes the stake from the delegate's validator.
+	//
+	// **Requires wallet to be unlocked**
+	RevokeDelegation(context.Context, *RevokeDelegationRequest) (*RevokeDelegationResponse, error)
 	// Spend sends coins from the wallet according to the provided parameters
 	//
+	// If the node is configured with a dust change threshold, change below the
+	// threshold is added to the fee, or sent to the donation address, rather
+	// than creating an output that costs more to spend than it is worth.
+	//
 	// **Requires wallet to be unlocked**
 	Spend(context.Context, *SpendRequest) (*SpendResponse, error)
 	// TimelockCoins moves coins into a timelocked address using the requested timelock.
@@ -1870,9 +3378,99 @@ type WalletServiceServer interface {
 	//
 	// **Requires wallet to be unlocked**
 	SweepWallet(context.Context, *SweepWalletRequest) (*SweepWalletResponse, error)
+	// StartSweepJob sweeps the coins from this wallet to the provided address
+	// in the background, splitting the inputs across as many transactions as
+	// needed to keep each under the per-transaction input limit. The
+	// transactions are proven and broadcast one after another. The job is
+	// saved as it progresses and resumes when the node restarts.
+	//
+	// **Requires wallet to be unlocked**
+	StartSweepJob(context.Context, *StartSweepJobRequest) (*StartSweepJobResponse, error)
+	// GetSweepJobs returns the wallet's sweep jobs.
+	GetSweepJobs(context.Context, *GetSweepJobsRequest) (*GetSweepJobsResponse, error)
+	// CancelSweepJob stops a running sweep job once the transaction it is
+	// proving, if any, is finished. Transactions already broadcast are not
+	// affected.
+	CancelSweepJob(context.Context, *CancelSweepJobRequest) (*CancelSweepJobResponse, error)
+	// ResumeSweepJob restarts a sweep job which failed or was canceled. The
+	// inputs which have already been swept are skipped.
+	//
+	// **Requires wallet to be unlocked**
+	ResumeSweepJob(context.Context, *ResumeSweepJobRequest) (*ResumeSweepJobResponse, error)
+	// InitiateSwap starts a cross chain atomic swap. A random secret is
+	// generated and the coins are sent to a hash time locked contract which
+	// the counterparty can claim by publishing the secret, or which this
+	// wallet can refund after the refund time. The counterparty then locks
+	// their coins on the other chain with the sha256 hash of the secret.
+	//
+	// The counterparty needs the contract address, locking script, and view
+	// key from the response to find and redeem the contract.
+	//
+	// **Requires wallet to be unlocked**
+	InitiateSwap(context.Context, *InitiateSwapRequest) (*InitiateSwapResponse, error)
+	// ParticipateSwap locks coins in a hash time locked contract using the
+	// secret hash from a swap the counterparty initiated on another chain.
+	// The wallet watches for the counterparty to redeem the contract and
+	// saves the secret they publish so that the other side of the swap can
+	// be redeemed.
+	//
+	// The refund time must be well before the refund time of the
+	// initiator's contract.
+	//
+	// **Requires wallet to be unlocked**
+	ParticipateSwap(context.Context, *ParticipateSwapRequest) (*ParticipateSwapResponse, error)
+	// RedeemSwap claims the coins in a contract funded by the counterparty
+	// using the secret. The secret is published in the transaction. The
+	// contract's claim key must be the spend key of one of the wallet's
+	// addresses, which can be found with SignMessage.
+	//
+	// If the contract isn't in the wallet yet it is imported and the wallet
+	// rescans from the rescan height. Call again once the rescan finishes.
+	//
+	// **Requires wallet to be unlocked**
+	RedeemSwap(context.Context, *RedeemSwapRequest) (*RedeemSwapResponse, error)
+	// RefundSwap returns the coins in a contract funded by this wallet
+	// after the refund time has passed.
+	//
+	// **Requires wallet to be unlocked**
+	RefundSwap(context.Context, *RefundSwapRequest) (*RefundSwapResponse, error)
+	// GetSwaps returns the wallet's atomic swaps.
+	GetSwaps(context.Context, *GetSwapsRequest) (*GetSwapsResponse, error)
+	// SaveSpendTemplate saves a named spend template that can later be
+	// executed with SpendFromTemplate. If a template with the same name
+	// already exists it is overwritten.
+	SaveSpendTemplate(context.Context, *SaveSpendTemplateRequest) (*SaveSpendTemplateResponse, error)
+	// GetSpendTemplates returns all the saved spend templates.
+	GetSpendTemplates(context.Context, *GetSpendTemplatesRequest) (*GetSpendTemplatesResponse, error)
+	// DeleteSpendTemplate deletes a saved spend template.
+	DeleteSpendTemplate(context.Context, *DeleteSpendTemplateRequest) (*DeleteSpendTemplateResponse, error)
+	// SpendFromTemplate builds, proves, and broadcasts a transaction paying
+	// the recipients in the named spend template.
+	//
+	// **Requires wallet to be unlocked**
+	SpendFromTemplate(context.Context, *SpendFromTemplateRequest) (*SpendFromTemplateResponse, error)
+	// AddContact saves an address in the wallet's address book under a name.
+	// Names are case insensitive and must be unique. The address must be
+	// valid for the node's network.
+	AddContact(context.Context, *AddContactRequest) (*AddContactResponse, error)
+	// ListContacts returns the contacts in the address book sorted by name.
+	ListContacts(context.Context, *ListContactsRequest) (*ListContactsResponse, error)
+	// RemoveContact removes a contact from the address book.
+	RemoveContact(context.Context, *RemoveContactRequest) (*RemoveContactResponse, error)
 	// SubscribeWalletTransactions subscribes to a stream of WalletTransactionsNotifications that return
 	// whenever a transaction belonging to the wallet finalizes.
 	SubscribeWalletTransactions(*SubscribeWalletTransactionsRequest, WalletService_SubscribeWalletTransactionsServer) error
+	// SubscribeTransactionEvents streams an event whenever a transaction
+	// paying to or spending from the wallet finalizes. The event says whether
+	// the transaction is incoming or outgoing. It's followed by a confirmation
+	// event for each new block until the transaction reaches the requested
+	// number of confirmations. An event is also sent when one of the
+	// wallet's staked utxos expires or is slashed.
+	SubscribeTransactionEvents(*SubscribeTransactionEventsRequest, WalletService_SubscribeTransactionEventsServer) error
+	// GetRescanStatus returns the progress of the wallet's current or
+	// most recent rescan, such as one started by importing an address
+	// with a rescan.
+	GetRescanStatus(context.Context, *GetRescanStatusRequest) (*GetRescanStatusResponse, error)
 	// SubscribeWalletSyncNotifications streams notifications about the status of the wallet sync.
 	SubscribeWalletSyncNotifications(*SubscribeWalletSyncNotificationsRequest, WalletService_SubscribeWalletSyncNotificationsServer) error
 	mustEmbedUnimplementedWalletServiceServer()
@@ -1903,6 +3501,12 @@ func (UnimplementedWalletServiceServer) GetAddresses(context.Context, *GetAddres
 func (UnimplementedWalletServiceServer) GetAddressInfo(context.Context, *GetAddressInfoRequest) (*GetAddressInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAddressInfo not implemented")
 }
+func (UnimplementedWalletServiceServer) GetAddressInfoBatch(context.Context, *GetAddressInfoBatchRequest) (*GetAddressInfoBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAddressInfoBatch not implemented")
+}
+func (UnimplementedWalletServiceServer) ConvertAddresses(context.Context, *ConvertAddressesRequest) (*ConvertAddressesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConvertAddresses not implemented")
+}
 func (UnimplementedWalletServiceServer) GetNewAddress(context.Context, *GetNewAddressRequest) (*GetNewAddressResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetNewAddress not implemented")
 }
@@ -1915,9 +3519,54 @@ func (UnimplementedWalletServiceServer) GetUtxos(context.Context, *GetUtxosReque
 func (UnimplementedWalletServiceServer) GetPrivateKey(context.Context, *GetPrivateKeyRequest) (*GetPrivateKeyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPrivateKey not implemented")
 }
+func (UnimplementedWalletServiceServer) GetPrivateKeyBatch(context.Context, *GetPrivateKeyBatchRequest) (*GetPrivateKeyBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPrivateKeyBatch not implemented")
+}
+func (UnimplementedWalletServiceServer) SignMessage(context.Context, *SignMessageRequest) (*SignMessageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignMessage not implemented")
+}
+func (UnimplementedWalletServiceServer) VerifyMessage(context.Context, *VerifyMessageRequest) (*VerifyMessageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyMessage not implemented")
+}
+func (UnimplementedWalletServiceServer) ProveReserves(context.Context, *ProveReservesRequest) (*ProveReservesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProveReserves not implemented")
+}
+func (UnimplementedWalletServiceServer) ProvePayment(context.Context, *ProvePaymentRequest) (*ProvePaymentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProvePayment not implemented")
+}
+func (UnimplementedWalletServiceServer) RequestInvoice(context.Context, *RequestInvoiceRequest) (*RequestInvoiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestInvoice not implemented")
+}
+func (UnimplementedWalletServiceServer) CreateInvoice(context.Context, *CreateInvoiceRequest) (*CreateInvoiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateInvoice not implemented")
+}
+func (UnimplementedWalletServiceServer) GetInvoiceStatus(context.Context, *GetInvoiceStatusRequest) (*GetInvoiceStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInvoiceStatus not implemented")
+}
 func (UnimplementedWalletServiceServer) ImportAddress(context.Context, *ImportAddressRequest) (*ImportAddressResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ImportAddress not implemented")
 }
+func (UnimplementedWalletServiceServer) SetCustomerID(context.Context, *SetCustomerIDRequest) (*SetCustomerIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCustomerID not implemented")
+}
+func (UnimplementedWalletServiceServer) SetAddressLabel(context.Context, *SetAddressLabelRequest) (*SetAddressLabelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAddressLabel not implemented")
+}
+func (UnimplementedWalletServiceServer) GetAddressesByLabel(context.Context, *GetAddressesByLabelRequest) (*GetAddressesByLabelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAddressesByLabel not implemented")
+}
+func (UnimplementedWalletServiceServer) ImportAddresses(context.Context, *ImportAddressesRequest) (*ImportAddressesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportAddresses not implemented")
+}
+func (UnimplementedWalletServiceServer) ExportWatchOnly(context.Context, *ExportWatchOnlyRequest) (*ExportWatchOnlyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportWatchOnly not implemented")
+}
+func (UnimplementedWalletServiceServer) ExportDescriptor(context.Context, *ExportDescriptorRequest) (*ExportDescriptorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportDescriptor not implemented")
+}
+func (UnimplementedWalletServiceServer) ImportDescriptor(context.Context, *ImportDescriptorRequest) (*ImportDescriptorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportDescriptor not implemented")
+}
 func (UnimplementedWalletServiceServer) CreateMultisigSpendKeypair(context.Context, *CreateMultisigSpendKeypairRequest) (*CreateMultisigSpendKeypairResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateMultisigSpendKeypair not implemented")
 }
@@ -1939,15 +3588,27 @@ func (UnimplementedWalletServiceServer) WalletLock(context.Context, *WalletLockR
 func (UnimplementedWalletServiceServer) WalletUnlock(context.Context, *WalletUnlockRequest) (*WalletUnlockResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method WalletUnlock not implemented")
 }
+func (UnimplementedWalletServiceServer) WalletViewUnlock(context.Context, *WalletViewUnlockRequest) (*WalletViewUnlockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WalletViewUnlock not implemented")
+}
+func (UnimplementedWalletServiceServer) SetWalletViewPassphrase(context.Context, *SetWalletViewPassphraseRequest) (*SetWalletViewPassphraseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetWalletViewPassphrase not implemented")
+}
 func (UnimplementedWalletServiceServer) SetWalletPassphrase(context.Context, *SetWalletPassphraseRequest) (*SetWalletPassphraseResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetWalletPassphrase not implemented")
 }
 func (UnimplementedWalletServiceServer) ChangeWalletPassphrase(context.Context, *ChangeWalletPassphraseRequest) (*ChangeWalletPassphraseResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ChangeWalletPassphrase not implemented")
 }
+func (UnimplementedWalletServiceServer) GetDeletePrivateKeysNonce(context.Context, *GetDeletePrivateKeysNonceRequest) (*GetDeletePrivateKeysNonceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeletePrivateKeysNonce not implemented")
+}
 func (UnimplementedWalletServiceServer) DeletePrivateKeys(context.Context, *DeletePrivateKeysRequest) (*DeletePrivateKeysResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeletePrivateKeys not implemented")
 }
+func (UnimplementedWalletServiceServer) RepairWallet(context.Context, *RepairWalletRequest) (*RepairWalletResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RepairWallet not implemented")
+}
 func (UnimplementedWalletServiceServer) CreateRawTransaction(context.Context, *CreateRawTransactionRequest) (*CreateRawTransactionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateRawTransaction not implemented")
 }
@@ -1963,570 +3624,1332 @@ func (UnimplementedWalletServiceServer) Stake(context.Context, *StakeRequest) (*
 func (UnimplementedWalletServiceServer) SetAutoStakeRewards(context.Context, *SetAutoStakeRewardsRequest) (*SetAutoStakeRewardsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetAutoStakeRewards not implemented")
 }
+func (UnimplementedWalletServiceServer) DelegateStake(context.Context, *DelegateStakeRequest) (*DelegateStakeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DelegateStake not implemented")
+}
+func (UnimplementedWalletServiceServer) RevokeDelegation(context.Context, *RevokeDelegationRequest) (*RevokeDelegationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeDelegation not implemented")
+}
 func (UnimplementedWalletServiceServer) Spend(context.Context, *SpendRequest) (*SpendResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Spend not implemented")
 }
 func (UnimplementedWalletServiceServer) TimelockCoins(context.Context, *TimelockCoinsRequest) (*TimelockCoinsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method TimelockCoins not implemented")
 }
-func (UnimplementedWalletServiceServer) SweepWallet(context.Context, *SweepWalletRequest) (*SweepWalletResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SweepWallet not implemented")
+func (UnimplementedWalletServiceServer) SweepWallet(context.Context, *SweepWalletRequest) (*SweepWalletResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SweepWallet not implemented")
+}
+func (UnimplementedWalletServiceServer) StartSweepJob(context.Context, *StartSweepJobRequest) (*StartSweepJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartSweepJob not implemented")
+}
+func (UnimplementedWalletServiceServer) GetSweepJobs(context.Context, *GetSweepJobsRequest) (*GetSweepJobsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSweepJobs not implemented")
+}
+func (UnimplementedWalletServiceServer) CancelSweepJob(context.Context, *CancelSweepJobRequest) (*CancelSweepJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelSweepJob not implemented")
+}
+func (UnimplementedWalletServiceServer) ResumeSweepJob(context.Context, *ResumeSweepJobRequest) (*ResumeSweepJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeSweepJob not implemented")
+}
+func (UnimplementedWalletServiceServer) InitiateSwap(context.Context, *InitiateSwapRequest) (*InitiateSwapResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InitiateSwap not implemented")
+}
+func (UnimplementedWalletServiceServer) ParticipateSwap(context.Context, *ParticipateSwapRequest) (*ParticipateSwapResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ParticipateSwap not implemented")
+}
+func (UnimplementedWalletServiceServer) RedeemSwap(context.Context, *RedeemSwapRequest) (*RedeemSwapResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RedeemSwap not implemented")
+}
+func (UnimplementedWalletServiceServer) RefundSwap(context.Context, *RefundSwapRequest) (*RefundSwapResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefundSwap not implemented")
+}
+func (UnimplementedWalletServiceServer) GetSwaps(context.Context, *GetSwapsRequest) (*GetSwapsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSwaps not implemented")
+}
+func (UnimplementedWalletServiceServer) SaveSpendTemplate(context.Context, *SaveSpendTemplateRequest) (*SaveSpendTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveSpendTemplate not implemented")
+}
+func (UnimplementedWalletServiceServer) GetSpendTemplates(context.Context, *GetSpendTemplatesRequest) (*GetSpendTemplatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSpendTemplates not implemented")
+}
+func (UnimplementedWalletServiceServer) DeleteSpendTemplate(context.Context, *DeleteSpendTemplateRequest) (*DeleteSpendTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSpendTemplate not implemented")
+}
+func (UnimplementedWalletServiceServer) SpendFromTemplate(context.Context, *SpendFromTemplateRequest) (*SpendFromTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SpendFromTemplate not implemented")
+}
+func (UnimplementedWalletServiceServer) AddContact(context.Context, *AddContactRequest) (*AddContactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddContact not implemented")
+}
+func (UnimplementedWalletServiceServer) ListContacts(context.Context, *ListContactsRequest) (*ListContactsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListContacts not implemented")
+}
+func (UnimplementedWalletServiceServer) RemoveContact(context.Context, *RemoveContactRequest) (*RemoveContactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveContact not implemented")
+}
+func (UnimplementedWalletServiceServer) SubscribeWalletTransactions(*SubscribeWalletTransactionsRequest, WalletService_SubscribeWalletTransactionsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeWalletTransactions not implemented")
+}
+func (UnimplementedWalletServiceServer) SubscribeTransactionEvents(*SubscribeTransactionEventsRequest, WalletService_SubscribeTransactionEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeTransactionEvents not implemented")
+}
+func (UnimplementedWalletServiceServer) GetRescanStatus(context.Context, *GetRescanStatusRequest) (*GetRescanStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRescanStatus not implemented")
+}
+func (UnimplementedWalletServiceServer) SubscribeWalletSyncNotifications(*SubscribeWalletSyncNotificationsRequest, WalletService_SubscribeWalletSyncNotificationsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeWalletSyncNotifications not implemented")
+}
+func (UnimplementedWalletServiceServer) mustEmbedUnimplementedWalletServiceServer() {}
+
+// UnsafeWalletServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WalletServiceServer will
+// result in compilation errors.
+type UnsafeWalletServiceServer interface {
+	mustEmbedUnimplementedWalletServiceServer()
+}
+
+func RegisterWalletServiceServer(s grpc.ServiceRegistrar, srv WalletServiceServer) {
+	s.RegisterService(&WalletService_ServiceDesc, srv)
+}
+
+func _WalletService_GetBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WalletService/GetBalance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetBalance(ctx, req.(*GetBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetWalletSeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWalletSeedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetWalletSeed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WalletService/GetWalletSeed",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetWalletSeed(ctx, req.(*GetWalletSeedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WalletService/GetAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetAddress(ctx, req.(*GetAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetTimelockedAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTimelockedAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetTimelockedAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WalletService/GetTimelockedAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetTimelockedAddress(ctx, req.(*GetTimelockedAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetPublicAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPublicAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetPublicAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WalletService/GetPublicAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetPublicAddress(ctx, req.(*GetPublicAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetAddresses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAddressesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetAddresses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WalletService/GetAddresses",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetAddresses(ctx, req.(*GetAddressesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetAddressInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAddressInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetAddressInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WalletService/GetAddressInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetAddressInfo(ctx, req.(*GetAddressInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetAddressInfoBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAddressInfoBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetAddressInfoBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WalletService/GetAddressInfoBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetAddressInfoBatch(ctx, req.(*GetAddressInfoBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ConvertAddresses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConvertAddressesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ConvertAddresses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WalletService/ConvertAddresses",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ConvertAddresses(ctx, req.(*ConvertAddressesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetNewAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNewAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetNewAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WalletService/GetNewAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetNewAddress(ctx, req.(*GetNewAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WalletService/GetTransactions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetTransactions(ctx, req.(*GetTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetUtxos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUtxosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetUtxos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WalletService/GetUtxos",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetUtxos(ctx, req.(*GetUtxosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetPrivateKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPrivateKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetPrivateKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WalletService/GetPrivateKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetPrivateKey(ctx, req.(*GetPrivateKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetPrivateKeyBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPrivateKeyBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetPrivateKeyBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WalletService/GetPrivateKeyBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetPrivateKeyBatch(ctx, req.(*GetPrivateKeyBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_SignMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).SignMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WalletService/SignMessage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).SignMessage(ctx, req.(*SignMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_VerifyMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).VerifyMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WalletService/VerifyMessage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).VerifyMessage(ctx, req.(*VerifyMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ProveReserves_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProveReservesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ProveReserves(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WalletService/ProveReserves",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ProveReserves(ctx, req.(*ProveReservesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ProvePayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProvePaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ProvePayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WalletService/ProvePayment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ProvePayment(ctx, req.(*ProvePaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_RequestInvoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestInvoiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).RequestInvoice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WalletService/RequestInvoice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).RequestInvoice(ctx, req.(*RequestInvoiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_CreateInvoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateInvoiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).CreateInvoice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WalletService/CreateInvoice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).CreateInvoice(ctx, req.(*CreateInvoiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetInvoiceStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInvoiceStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetInvoiceStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WalletService/GetInvoiceStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetInvoiceStatus(ctx, req.(*GetInvoiceStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ImportAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ImportAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WalletService/ImportAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ImportAddress(ctx, req.(*ImportAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_SetCustomerID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCustomerIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).SetCustomerID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WalletService/SetCustomerID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).SetCustomerID(ctx, req.(*SetCustomerIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_SetAddressLabel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAddressLabelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).SetAddressLabel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WalletService/SetAddressLabel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).SetAddressLabel(ctx, req.(*SetAddressLabelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetAddressesByLabel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAddressesByLabelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetAddressesByLabel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WalletService/GetAddressesByLabel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetAddressesByLabel(ctx, req.(*GetAddressesByLabelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ImportAddresses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportAddressesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ImportAddresses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WalletService/ImportAddresses",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ImportAddresses(ctx, req.(*ImportAddressesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ExportWatchOnly_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportWatchOnlyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ExportWatchOnly(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WalletService/ExportWatchOnly",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ExportWatchOnly(ctx, req.(*ExportWatchOnlyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ExportDescriptor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportDescriptorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ExportDescriptor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WalletService/ExportDescriptor",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ExportDescriptor(ctx, req.(*ExportDescriptorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ImportDescriptor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportDescriptorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ImportDescriptor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WalletService/ImportDescriptor",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ImportDescriptor(ctx, req.(*ImportDescriptorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_CreateMultisigSpendKeypair_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateMultisigSpendKeypairRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).CreateMultisigSpendKeypair(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WalletService/CreateMultisigSpendKeypair",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).CreateMultisigSpendKeypair(ctx, req.(*CreateMultisigSpendKeypairRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_CreateMultisigViewKeypair_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateMultisigViewKeypairRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).CreateMultisigViewKeypair(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WalletService/CreateMultisigViewKeypair",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).CreateMultisigViewKeypair(ctx, req.(*CreateMultisigViewKeypairRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_CreateMultisigAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateMultisigAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).CreateMultisigAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WalletService/CreateMultisigAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).CreateMultisigAddress(ctx, req.(*CreateMultisigAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_CreateMultiSignature_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateMultiSignatureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).CreateMultiSignature(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WalletService/CreateMultiSignature",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).CreateMultiSignature(ctx, req.(*CreateMultiSignatureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ProveMultisig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProveMultisigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ProveMultisig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WalletService/ProveMultisig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ProveMultisig(ctx, req.(*ProveMultisigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_WalletLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WalletLockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).WalletLock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WalletService/WalletLock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).WalletLock(ctx, req.(*WalletLockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedWalletServiceServer) SubscribeWalletTransactions(*SubscribeWalletTransactionsRequest, WalletService_SubscribeWalletTransactionsServer) error {
-	return status.Errorf(codes.Unimplemented, "method SubscribeWalletTransactions not implemented")
+
+func _WalletService_WalletUnlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WalletUnlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).WalletUnlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WalletService/WalletUnlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).WalletUnlock(ctx, req.(*WalletUnlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedWalletServiceServer) SubscribeWalletSyncNotifications(*SubscribeWalletSyncNotificationsRequest, WalletService_SubscribeWalletSyncNotificationsServer) error {
-	return status.Errorf(codes.Unimplemented, "method SubscribeWalletSyncNotifications not implemented")
+
+func _WalletService_WalletViewUnlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WalletViewUnlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).WalletViewUnlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WalletService/WalletViewUnlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).WalletViewUnlock(ctx, req.(*WalletViewUnlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedWalletServiceServer) mustEmbedUnimplementedWalletServiceServer() {}
 
-// UnsafeWalletServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to WalletServiceServer will
-// result in compilation errors.
-type UnsafeWalletServiceServer interface {
-	mustEmbedUnimplementedWalletServiceServer()
+func _WalletService_SetWalletViewPassphrase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetWalletViewPassphraseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).SetWalletViewPassphrase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WalletService/SetWalletViewPassphrase",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).SetWalletViewPassphrase(ctx, req.(*SetWalletViewPassphraseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterWalletServiceServer(s grpc.ServiceRegistrar, srv WalletServiceServer) {
-	s.RegisterService(&WalletService_ServiceDesc, srv)
+func _WalletService_SetWalletPassphrase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetWalletPassphraseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).SetWalletPassphrase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WalletService/SetWalletPassphrase",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).SetWalletPassphrase(ctx, req.(*SetWalletPassphraseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_GetBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetBalanceRequest)
+func _WalletService_ChangeWalletPassphrase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeWalletPassphraseRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).GetBalance(ctx, in)
+		return srv.(WalletServiceServer).ChangeWalletPassphrase(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.WalletService/GetBalance",
+		FullMethod: "/pb.WalletService/ChangeWalletPassphrase",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).GetBalance(ctx, req.(*GetBalanceRequest))
+		return srv.(WalletServiceServer).ChangeWalletPassphrase(ctx, req.(*ChangeWalletPassphraseRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_GetWalletSeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetWalletSeedRequest)
+func _WalletService_GetDeletePrivateKeysNonce_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeletePrivateKeysNonceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).GetWalletSeed(ctx, in)
+		return srv.(WalletServiceServer).GetDeletePrivateKeysNonce(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.WalletService/GetWalletSeed",
+		FullMethod: "/pb.WalletService/GetDeletePrivateKeysNonce",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).GetWalletSeed(ctx, req.(*GetWalletSeedRequest))
+		return srv.(WalletServiceServer).GetDeletePrivateKeysNonce(ctx, req.(*GetDeletePrivateKeysNonceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_GetAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetAddressRequest)
+func _WalletService_DeletePrivateKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePrivateKeysRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).GetAddress(ctx, in)
+		return srv.(WalletServiceServer).DeletePrivateKeys(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.WalletService/GetAddress",
+		FullMethod: "/pb.WalletService/DeletePrivateKeys",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).GetAddress(ctx, req.(*GetAddressRequest))
+		return srv.(WalletServiceServer).DeletePrivateKeys(ctx, req.(*DeletePrivateKeysRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_GetTimelockedAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetTimelockedAddressRequest)
+func _WalletService_RepairWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RepairWalletRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).GetTimelockedAddress(ctx, in)
+		return srv.(WalletServiceServer).RepairWallet(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.WalletService/GetTimelockedAddress",
+		FullMethod: "/pb.WalletService/RepairWallet",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).GetTimelockedAddress(ctx, req.(*GetTimelockedAddressRequest))
+		return srv.(WalletServiceServer).RepairWallet(ctx, req.(*RepairWalletRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_GetPublicAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetPublicAddressRequest)
+func _WalletService_CreateRawTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRawTransactionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).GetPublicAddress(ctx, in)
+		return srv.(WalletServiceServer).CreateRawTransaction(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.WalletService/GetPublicAddress",
+		FullMethod: "/pb.WalletService/CreateRawTransaction",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).GetPublicAddress(ctx, req.(*GetPublicAddressRequest))
+		return srv.(WalletServiceServer).CreateRawTransaction(ctx, req.(*CreateRawTransactionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_GetAddresses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetAddressesRequest)
+func _WalletService_CreateRawStakeTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRawStakeTransactionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).GetAddresses(ctx, in)
+		return srv.(WalletServiceServer).CreateRawStakeTransaction(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.WalletService/GetAddresses",
+		FullMethod: "/pb.WalletService/CreateRawStakeTransaction",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).GetAddresses(ctx, req.(*GetAddressesRequest))
+		return srv.(WalletServiceServer).CreateRawStakeTransaction(ctx, req.(*CreateRawStakeTransactionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_GetAddressInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetAddressInfoRequest)
+func _WalletService_ProveRawTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProveRawTransactionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).GetAddressInfo(ctx, in)
+		return srv.(WalletServiceServer).ProveRawTransaction(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.WalletService/GetAddressInfo",
+		FullMethod: "/pb.WalletService/ProveRawTransaction",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).GetAddressInfo(ctx, req.(*GetAddressInfoRequest))
+		return srv.(WalletServiceServer).ProveRawTransaction(ctx, req.(*ProveRawTransactionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_GetNewAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetNewAddressRequest)
+func _WalletService_Stake_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StakeRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).GetNewAddress(ctx, in)
+		return srv.(WalletServiceServer).Stake(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.WalletService/GetNewAddress",
+		FullMethod: "/pb.WalletService/Stake",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).GetNewAddress(ctx, req.(*GetNewAddressRequest))
+		return srv.(WalletServiceServer).Stake(ctx, req.(*StakeRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_GetTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetTransactionsRequest)
+func _WalletService_SetAutoStakeRewards_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAutoStakeRewardsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).GetTransactions(ctx, in)
+		return srv.(WalletServiceServer).SetAutoStakeRewards(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.WalletService/GetTransactions",
+		FullMethod: "/pb.WalletService/SetAutoStakeRewards",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).GetTransactions(ctx, req.(*GetTransactionsRequest))
+		return srv.(WalletServiceServer).SetAutoStakeRewards(ctx, req.(*SetAutoStakeRewardsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_GetUtxos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetUtxosRequest)
+func _WalletService_DelegateStake_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DelegateStakeRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).GetUtxos(ctx, in)
+		return srv.(WalletServiceServer).DelegateStake(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.WalletService/GetUtxos",
+		FullMethod: "/pb.WalletService/DelegateStake",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).GetUtxos(ctx, req.(*GetUtxosRequest))
+		return srv.(WalletServiceServer).DelegateStake(ctx, req.(*DelegateStakeRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_GetPrivateKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetPrivateKeyRequest)
+func _WalletService_RevokeDelegation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeDelegationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).GetPrivateKey(ctx, in)
+		return srv.(WalletServiceServer).RevokeDelegation(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.WalletService/GetPrivateKey",
+		FullMethod: "/pb.WalletService/RevokeDelegation",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).GetPrivateKey(ctx, req.(*GetPrivateKeyRequest))
+		return srv.(WalletServiceServer).RevokeDelegation(ctx, req.(*RevokeDelegationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_ImportAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ImportAddressRequest)
+func _WalletService_Spend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SpendRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).ImportAddress(ctx, in)
+		return srv.(WalletServiceServer).Spend(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.WalletService/ImportAddress",
+		FullMethod: "/pb.WalletService/Spend",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).ImportAddress(ctx, req.(*ImportAddressRequest))
+		return srv.(WalletServiceServer).Spend(ctx, req.(*SpendRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_CreateMultisigSpendKeypair_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateMultisigSpendKeypairRequest)
+func _WalletService_TimelockCoins_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TimelockCoinsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).CreateMultisigSpendKeypair(ctx, in)
+		return srv.(WalletServiceServer).TimelockCoins(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.WalletService/CreateMultisigSpendKeypair",
+		FullMethod: "/pb.WalletService/TimelockCoins",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).CreateMultisigSpendKeypair(ctx, req.(*CreateMultisigSpendKeypairRequest))
+		return srv.(WalletServiceServer).TimelockCoins(ctx, req.(*TimelockCoinsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_CreateMultisigViewKeypair_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateMultisigViewKeypairRequest)
+func _WalletService_SweepWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SweepWalletRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).CreateMultisigViewKeypair(ctx, in)
+		return srv.(WalletServiceServer).SweepWallet(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.WalletService/CreateMultisigViewKeypair",
+		FullMethod: "/pb.WalletService/SweepWallet",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).CreateMultisigViewKeypair(ctx, req.(*CreateMultisigViewKeypairRequest))
+		return srv.(WalletServiceServer).SweepWallet(ctx, req.(*SweepWalletRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_CreateMultisigAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateMultisigAddressRequest)
+func _WalletService_StartSweepJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartSweepJobRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).CreateMultisigAddress(ctx, in)
+		return srv.(WalletServiceServer).StartSweepJob(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.WalletService/CreateMultisigAddress",
+		FullMethod: "/pb.WalletService/StartSweepJob",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).CreateMultisigAddress(ctx, req.(*CreateMultisigAddressRequest))
+		return srv.(WalletServiceServer).StartSweepJob(ctx, req.(*StartSweepJobRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_CreateMultiSignature_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateMultiSignatureRequest)
+func _WalletService_GetSweepJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSweepJobsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).CreateMultiSignature(ctx, in)
+		return srv.(WalletServiceServer).GetSweepJobs(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.WalletService/CreateMultiSignature",
+		FullMethod: "/pb.WalletService/GetSweepJobs",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).CreateMultiSignature(ctx, req.(*CreateMultiSignatureRequest))
+		return srv.(WalletServiceServer).GetSweepJobs(ctx, req.(*GetSweepJobsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_ProveMultisig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ProveMultisigRequest)
+func _WalletService_CancelSweepJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelSweepJobRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).ProveMultisig(ctx, in)
+		return srv.(WalletServiceServer).CancelSweepJob(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.WalletService/ProveMultisig",
+		FullMethod: "/pb.WalletService/CancelSweepJob",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).ProveMultisig(ctx, req.(*ProveMultisigRequest))
+		return srv.(WalletServiceServer).CancelSweepJob(ctx, req.(*CancelSweepJobRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_WalletLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(WalletLockRequest)
+func _WalletService_ResumeSweepJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeSweepJobRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).WalletLock(ctx, in)
+		return srv.(WalletServiceServer).ResumeSweepJob(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.WalletService/WalletLock",
+		FullMethod: "/pb.WalletService/ResumeSweepJob",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).WalletLock(ctx, req.(*WalletLockRequest))
+		return srv.(WalletServiceServer).ResumeSweepJob(ctx, req.(*ResumeSweepJobRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_WalletUnlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(WalletUnlockRequest)
+func _WalletService_InitiateSwap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InitiateSwapRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).WalletUnlock(ctx, in)
+		return srv.(WalletServiceServer).InitiateSwap(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.WalletService/WalletUnlock",
+		FullMethod: "/pb.WalletService/InitiateSwap",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).WalletUnlock(ctx, req.(*WalletUnlockRequest))
+		return srv.(WalletServiceServer).InitiateSwap(ctx, req.(*InitiateSwapRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_SetWalletPassphrase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SetWalletPassphraseRequest)
+func _WalletService_ParticipateSwap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ParticipateSwapRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).SetWalletPassphrase(ctx, in)
+		return srv.(WalletServiceServer).ParticipateSwap(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.WalletService/SetWalletPassphrase",
+		FullMethod: "/pb.WalletService/ParticipateSwap",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).SetWalletPassphrase(ctx, req.(*SetWalletPassphraseRequest))
+		return srv.(WalletServiceServer).ParticipateSwap(ctx, req.(*ParticipateSwapRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_ChangeWalletPassphrase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ChangeWalletPassphraseRequest)
+func _WalletService_RedeemSwap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RedeemSwapRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).ChangeWalletPassphrase(ctx, in)
+		return srv.(WalletServiceServer).RedeemSwap(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.WalletService/ChangeWalletPassphrase",
+		FullMethod: "/pb.WalletService/RedeemSwap",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).ChangeWalletPassphrase(ctx, req.(*ChangeWalletPassphraseRequest))
+		return srv.(WalletServiceServer).RedeemSwap(ctx, req.(*RedeemSwapRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_DeletePrivateKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeletePrivateKeysRequest)
+func _WalletService_RefundSwap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefundSwapRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).DeletePrivateKeys(ctx, in)
+		return srv.(WalletServiceServer).RefundSwap(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.WalletService/DeletePrivateKeys",
+		FullMethod: "/pb.WalletService/RefundSwap",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).DeletePrivateKeys(ctx, req.(*DeletePrivateKeysRequest))
+		return srv.(WalletServiceServer).RefundSwap(ctx, req.(*RefundSwapRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_CreateRawTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateRawTransactionRequest)
+func _WalletService_GetSwaps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSwapsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).CreateRawTransaction(ctx, in)
+		return srv.(WalletServiceServer).GetSwaps(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.WalletService/CreateRawTransaction",
+		FullMethod: "/pb.WalletService/GetSwaps",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).CreateRawTransaction(ctx, req.(*CreateRawTransactionRequest))
+		return srv.(WalletServiceServer).GetSwaps(ctx, req.(*GetSwapsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_CreateRawStakeTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateRawStakeTransactionRequest)
+func _WalletService_SaveSpendTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveSpendTemplateRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).CreateRawStakeTransaction(ctx, in)
+		return srv.(WalletServiceServer).SaveSpendTemplate(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.WalletService/CreateRawStakeTransaction",
+		FullMethod: "/pb.WalletService/SaveSpendTemplate",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).CreateRawStakeTransaction(ctx, req.(*CreateRawStakeTransactionRequest))
+		return srv.(WalletServiceServer).SaveSpendTemplate(ctx, req.(*SaveSpendTemplateRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_ProveRawTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ProveRawTransactionRequest)
+func _WalletService_GetSpendTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSpendTemplatesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).ProveRawTransaction(ctx, in)
+		return srv.(WalletServiceServer).GetSpendTemplates(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.WalletService/ProveRawTransaction",
+		FullMethod: "/pb.WalletService/GetSpendTemplates",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).ProveRawTransaction(ctx, req.(*ProveRawTransactionRequest))
+		return srv.(WalletServiceServer).GetSpendTemplates(ctx, req.(*GetSpendTemplatesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_Stake_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(StakeRequest)
+func _WalletService_DeleteSpendTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSpendTemplateRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).Stake(ctx, in)
+		return srv.(WalletServiceServer).DeleteSpendTemplate(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.WalletService/Stake",
+		FullMethod: "/pb.WalletService/DeleteSpendTemplate",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).Stake(ctx, req.(*StakeRequest))
+		return srv.(WalletServiceServer).DeleteSpendTemplate(ctx, req.(*DeleteSpendTemplateRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_SetAutoStakeRewards_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SetAutoStakeRewardsRequest)
+func _WalletService_SpendFromTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SpendFromTemplateRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).SetAutoStakeRewards(ctx, in)
+		return srv.(WalletServiceServer).SpendFromTemplate(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.WalletService/SetAutoStakeRewards",
+		FullMethod: "/pb.WalletService/SpendFromTemplate",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).SetAutoStakeRewards(ctx, req.(*SetAutoStakeRewardsRequest))
+		return srv.(WalletServiceServer).SpendFromTemplate(ctx, req.(*SpendFromTemplateRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_Spend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SpendRequest)
+func _WalletService_AddContact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddContactRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).Spend(ctx, in)
+		return srv.(WalletServiceServer).AddContact(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.WalletService/Spend",
+		FullMethod: "/pb.WalletService/AddContact",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).Spend(ctx, req.(*SpendRequest))
+		return srv.(WalletServiceServer).AddContact(ctx, req.(*AddContactRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_TimelockCoins_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TimelockCoinsRequest)
+func _WalletService_ListContacts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListContactsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).TimelockCoins(ctx, in)
+		return srv.(WalletServiceServer).ListContacts(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.WalletService/TimelockCoins",
+		FullMethod: "/pb.WalletService/ListContacts",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).TimelockCoins(ctx, req.(*TimelockCoinsRequest))
+		return srv.(WalletServiceServer).ListContacts(ctx, req.(*ListContactsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_SweepWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SweepWalletRequest)
+func _WalletService_RemoveContact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveContactRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).SweepWallet(ctx, in)
+		return srv.(WalletServiceServer).RemoveContact(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.WalletService/SweepWallet",
+		FullMethod: "/pb.WalletService/RemoveContact",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).SweepWallet(ctx, req.(*SweepWalletRequest))
+		return srv.(WalletServiceServer).RemoveContact(ctx, req.(*RemoveContactRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -2552,6 +4975,45 @@ func (x *walletServiceSubscribeWalletTransactionsServer) Send(m *WalletTransacti
 	return x.ServerStream.SendMsg(m)
 }
 
+func _WalletService_SubscribeTransactionEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeTransactionEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WalletServiceServer).SubscribeTransactionEvents(m, &walletServiceSubscribeTransactionEventsServer{stream})
+}
+
+type WalletService_SubscribeTransactionEventsServer interface {
+	Send(*TransactionEvent) error
+	grpc.ServerStream
+}
+
+type walletServiceSubscribeTransactionEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *walletServiceSubscribeTransactionEventsServer) Send(m *TransactionEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _WalletService_GetRescanStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRescanStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetRescanStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WalletService/GetRescanStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetRescanStatus(ctx, req.(*GetRescanStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _WalletService_SubscribeWalletSyncNotifications_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(SubscribeWalletSyncNotificationsRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -2608,6 +5070,14 @@ var WalletService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAddressInfo",
 			Handler:    _WalletService_GetAddressInfo_Handler,
 		},
+		{
+			MethodName: "GetAddressInfoBatch",
+			Handler:    _WalletService_GetAddressInfoBatch_Handler,
+		},
+		{
+			MethodName: "ConvertAddresses",
+			Handler:    _WalletService_ConvertAddresses_Handler,
+		},
 		{
 			MethodName: "GetNewAddress",
 			Handler:    _WalletService_GetNewAddress_Handler,
@@ -2624,10 +5094,70 @@ var WalletService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPrivateKey",
 			Handler:    _WalletService_GetPrivateKey_Handler,
 		},
+		{
+			MethodName: "GetPrivateKeyBatch",
+			Handler:    _WalletService_GetPrivateKeyBatch_Handler,
+		},
+		{
+			MethodName: "SignMessage",
+			Handler:    _WalletService_SignMessage_Handler,
+		},
+		{
+			MethodName: "VerifyMessage",
+			Handler:    _WalletService_VerifyMessage_Handler,
+		},
+		{
+			MethodName: "ProveReserves",
+			Handler:    _WalletService_ProveReserves_Handler,
+		},
+		{
+			MethodName: "ProvePayment",
+			Handler:    _WalletService_ProvePayment_Handler,
+		},
+		{
+			MethodName: "RequestInvoice",
+			Handler:    _WalletService_RequestInvoice_Handler,
+		},
+		{
+			MethodName: "CreateInvoice",
+			Handler:    _WalletService_CreateInvoice_Handler,
+		},
+		{
+			MethodName: "GetInvoiceStatus",
+			Handler:    _WalletService_GetInvoiceStatus_Handler,
+		},
 		{
 			MethodName: "ImportAddress",
 			Handler:    _WalletService_ImportAddress_Handler,
 		},
+		{
+			MethodName: "SetCustomerID",
+			Handler:    _WalletService_SetCustomerID_Handler,
+		},
+		{
+			MethodName: "SetAddressLabel",
+			Handler:    _WalletService_SetAddressLabel_Handler,
+		},
+		{
+			MethodName: "GetAddressesByLabel",
+			Handler:    _WalletService_GetAddressesByLabel_Handler,
+		},
+		{
+			MethodName: "ImportAddresses",
+			Handler:    _WalletService_ImportAddresses_Handler,
+		},
+		{
+			MethodName: "ExportWatchOnly",
+			Handler:    _WalletService_ExportWatchOnly_Handler,
+		},
+		{
+			MethodName: "ExportDescriptor",
+			Handler:    _WalletService_ExportDescriptor_Handler,
+		},
+		{
+			MethodName: "ImportDescriptor",
+			Handler:    _WalletService_ImportDescriptor_Handler,
+		},
 		{
 			MethodName: "CreateMultisigSpendKeypair",
 			Handler:    _WalletService_CreateMultisigSpendKeypair_Handler,
@@ -2656,6 +5186,14 @@ var WalletService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "WalletUnlock",
 			Handler:    _WalletService_WalletUnlock_Handler,
 		},
+		{
+			MethodName: "WalletViewUnlock",
+			Handler:    _WalletService_WalletViewUnlock_Handler,
+		},
+		{
+			MethodName: "SetWalletViewPassphrase",
+			Handler:    _WalletService_SetWalletViewPassphrase_Handler,
+		},
 		{
 			MethodName: "SetWalletPassphrase",
 			Handler:    _WalletService_SetWalletPassphrase_Handler,
@@ -2664,10 +5202,18 @@ var WalletService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ChangeWalletPassphrase",
 			Handler:    _WalletService_ChangeWalletPassphrase_Handler,
 		},
+		{
+			MethodName: "GetDeletePrivateKeysNonce",
+			Handler:    _WalletService_GetDeletePrivateKeysNonce_Handler,
+		},
 		{
 			MethodName: "DeletePrivateKeys",
 			Handler:    _WalletService_DeletePrivateKeys_Handler,
 		},
+		{
+			MethodName: "RepairWallet",
+			Handler:    _WalletService_RepairWallet_Handler,
+		},
 		{
 			MethodName: "CreateRawTransaction",
 			Handler:    _WalletService_CreateRawTransaction_Handler,
@@ -2688,6 +5234,14 @@ var WalletService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SetAutoStakeRewards",
 			Handler:    _WalletService_SetAutoStakeRewards_Handler,
 		},
+		{
+			MethodName: "DelegateStake",
+			Handler:    _WalletService_DelegateStake_Handler,
+		},
+		{
+			MethodName: "RevokeDelegation",
+			Handler:    _WalletService_RevokeDelegation_Handler,
+		},
 		{
 			MethodName: "Spend",
 			Handler:    _WalletService_Spend_Handler,
@@ -2700,6 +5254,74 @@ var WalletService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SweepWallet",
 			Handler:    _WalletService_SweepWallet_Handler,
 		},
+		{
+			MethodName: "StartSweepJob",
+			Handler:    _WalletService_StartSweepJob_Handler,
+		},
+		{
+			MethodName: "GetSweepJobs",
+			Handler:    _WalletService_GetSweepJobs_Handler,
+		},
+		{
+			MethodName: "CancelSweepJob",
+			Handler:    _WalletService_CancelSweepJob_Handler,
+		},
+		{
+			MethodName: "ResumeSweepJob",
+			Handler:    _WalletService_ResumeSweepJob_Handler,
+		},
+		{
+			MethodName: "InitiateSwap",
+			Handler:    _WalletService_InitiateSwap_Handler,
+		},
+		{
+			MethodName: "ParticipateSwap",
+			Handler:    _WalletService_ParticipateSwap_Handler,
+		},
+		{
+			MethodName: "RedeemSwap",
+			Handler:    _WalletService_RedeemSwap_Handler,
+		},
+		{
+			MethodName: "RefundSwap",
+			Handler:    _WalletService_RefundSwap_Handler,
+		},
+		{
+			MethodName: "GetSwaps",
+			Handler:    _WalletService_GetSwaps_Handler,
+		},
+		{
+			MethodName: "SaveSpendTemplate",
+			Handler:    _WalletService_SaveSpendTemplate_Handler,
+		},
+		{
+			MethodName: "GetSpendTemplates",
+			Handler:    _WalletService_GetSpendTemplates_Handler,
+		},
+		{
+			MethodName: "DeleteSpendTemplate",
+			Handler:    _WalletService_DeleteSpendTemplate_Handler,
+		},
+		{
+			MethodName: "SpendFromTemplate",
+			Handler:    _WalletService_SpendFromTemplate_Handler,
+		},
+		{
+			MethodName: "AddContact",
+			Handler:    _WalletService_AddContact_Handler,
+		},
+		{
+			MethodName: "ListContacts",
+			Handler:    _WalletService_ListContacts_Handler,
+		},
+		{
+			MethodName: "RemoveContact",
+			Handler:    _WalletService_RemoveContact_Handler,
+		},
+		{
+			MethodName: "GetRescanStatus",
+			Handler:    _WalletService_GetRescanStatus_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -2707,6 +5329,11 @@ var WalletService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _WalletService_SubscribeWalletTransactions_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "SubscribeTransactionEvents",
+			Handler:       _WalletService_SubscribeTransactionEvents_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "SubscribeWalletSyncNotifications",
 			Handler:       _WalletService_SubscribeWalletSyncNotifications_Handler,
@@ -2720,8 +5347,13 @@ var WalletService_ServiceDesc = grpc.ServiceDesc{
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type NodeServiceClient interface {
-	// GetHostInfo returns info about the libp2p host
+	// GetHostInfo returns info about the libp2p host and whether blocks
+	// are finalizing.
 	GetHostInfo(ctx context.Context, in *GetHostInfoRequest, opts ...grpc.CallOption) (*GetHostInfoResponse, error)
+	// GetValidatorInfo returns this node's staking status including its stake,
+	// unclaimed rewards, the reward it can expect at the start of the next epoch
+	// given its current stake weight, and whether autostaking is enabled.
+	GetValidatorInfo(ctx context.Context, in *GetValidatorInfoRequest, opts ...grpc.CallOption) (*GetValidatorInfoResponse, error)
 	// GetNetworkKey returns the node's network private key
 	GetNetworkKey(ctx context.Context, in *GetNetworkKeyRequest, opts ...grpc.CallOption) (*GetNetworkKeyResponse, error)
 	// GetPeers returns a list of peers that this node is connected to
@@ -2734,6 +5366,31 @@ type NodeServiceClient interface {
 	BlockPeer(ctx context.Context, in *BlockPeerRequest, opts ...grpc.CallOption) (*BlockPeerResponse, error)
 	// UnblockPeer removes a peer from the block list
 	UnblockPeer(ctx context.Context, in *UnblockPeerRequest, opts ...grpc.CallOption) (*UnblockPeerResponse, error)
+	// GetAccessLists returns the peer IDs and IP ranges in the connection
+	// allow and deny lists. If the allow list is not empty only inbound
+	// connections from a listed peer ID or IP range are accepted. Connections
+	// to or from anything in the deny list are always refused.
+	GetAccessLists(ctx context.Context, in *GetAccessListsRequest, opts ...grpc.CallOption) (*GetAccessListsResponse, error)
+	// UpdateAccessList adds or removes peer IDs and IP ranges from the allow
+	// or deny list. The change is committed to the datastore and will persist
+	// between sessions. Existing connections which are no longer permitted
+	// are closed.
+	UpdateAccessList(ctx context.Context, in *UpdateAccessListRequest, opts ...grpc.CallOption) (*UpdateAccessListResponse, error)
+	// GetConsensusPeerScores returns the reliability scores the consensus
+	// engine keeps for the validators it polls. Validators are polled in
+	// proportion to their stake weight multiplied by their score and are
+	// temporarily banned from polling if the score falls too low.
+	GetConsensusPeerScores(ctx context.Context, in *GetConsensusPeerScoresRequest, opts ...grpc.CallOption) (*GetConsensusPeerScoresResponse, error)
+	// GetConsensusState returns the consensus engine's vote records for the
+	// heights it's voting on, the number of polls in flight, and a histogram
+	// of how long blocks have taken to finalize. This can be used to see why
+	// blocks are not finalizing.
+	GetConsensusState(ctx context.Context, in *GetConsensusStateRequest, opts ...grpc.CallOption) (*GetConsensusStateResponse, error)
+	// GetTasks returns the node's running background tasks, such as chain
+	// sync, rescans, proofs, and consensus polls, along with when they
+	// started and their current state. This can be used to see what the
+	// node is doing when it appears to be stuck.
+	GetTasks(ctx context.Context, in *GetTasksRequest, opts ...grpc.CallOption) (*GetTasksResponse, error)
 	// SetLogLevel changes the logging level of the node
 	SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error)
 	// GetMinFeePerKilobyte returns the node's current minimum transaction fee needed to relay
@@ -2760,11 +5417,43 @@ type NodeServiceClient interface {
 	// UpdateTreasuryWhitelist adds or removes a transaction to from the treasury whitelist.
 	// This update is committed to the datastore and will persist between sessions.
 	UpdateTreasuryWhitelist(ctx context.Context, in *UpdateTreasuryWhitelistRequest, opts ...grpc.CallOption) (*UpdateTreasuryWhitelistResponse, error)
+	// CreateTreasuryProposal drafts a treasury withdrawal paying the provided outputs
+	// and creates its zk proof. The proposal is saved and returned along with a
+	// serialized copy which can be handed to validators for review. The transaction
+	// is not broadcast until SubmitTreasuryProposal is called.
+	CreateTreasuryProposal(ctx context.Context, in *CreateTreasuryProposalRequest, opts ...grpc.CallOption) (*CreateTreasuryProposalResponse, error)
+	// ImportTreasuryProposal decodes a serialized treasury proposal and checks that
+	// the outputs pay the listed addresses the listed amounts and that the treasury
+	// can cover the withdrawal. If the checks pass the proposal is saved. If approve
+	// is true the transaction is also added to the node's treasury whitelist.
+	ImportTreasuryProposal(ctx context.Context, in *ImportTreasuryProposalRequest, opts ...grpc.CallOption) (*ImportTreasuryProposalResponse, error)
+	// CollectTreasuryApprovals queries the validators for their treasury whitelists
+	// and records the validators, and the fraction of the stake, which have approved
+	// the proposal.
+	CollectTreasuryApprovals(ctx context.Context, in *CollectTreasuryApprovalsRequest, opts ...grpc.CallOption) (*CollectTreasuryApprovalsResponse, error)
+	// SubmitTreasuryProposal broadcasts the proposal's treasury transaction. The
+	// transaction must be in this node's treasury whitelist.
+	SubmitTreasuryProposal(ctx context.Context, in *SubmitTreasuryProposalRequest, opts ...grpc.CallOption) (*SubmitTreasuryProposalResponse, error)
+	// ListTreasuryProposals returns the saved treasury proposals which have not yet
+	// been confirmed in a block.
+	ListTreasuryProposals(ctx context.Context, in *ListTreasuryProposalsRequest, opts ...grpc.CallOption) (*ListTreasuryProposalsResponse, error)
 	// ReconsiderBlock tries to reprocess the given block
 	ReconsiderBlock(ctx context.Context, in *ReconsiderBlockRequest, opts ...grpc.CallOption) (*ReconsiderBlockResponse, error)
 	// RecomputeChainState deletes the accumulator, validator set, and nullifier set and rebuilds them by
 	// loading and re-processing all blocks from genesis.
 	RecomputeChainState(ctx context.Context, in *RecomputeChainStateRequest, opts ...grpc.CallOption) (*RecomputeChainStateResponse, error)
+	// CaptureProfile records a runtime profile of the node for the given duration
+	// and writes it to the profiles directory inside the node's data directory.
+	CaptureProfile(ctx context.Context, in *CaptureProfileRequest, opts ...grpc.CallOption) (*CaptureProfileResponse, error)
+	// SetMockTime overrides the node's clock with the provided time. The node's
+	// notion of time remains fixed at this value until it is changed again. Setting
+	// the timestamp to zero restores the real clock. This RPC is only available in
+	// regtest mode.
+	SetMockTime(ctx context.Context, in *SetMockTimeRequest, opts ...grpc.CallOption) (*SetMockTimeResponse, error)
+	// AdvanceTime moves the node's mock time forward by the provided number of seconds.
+	// If a mock time is not set, it starts from the current time. This RPC is only
+	// available in regtest mode.
+	AdvanceTime(ctx context.Context, in *AdvanceTimeRequest, opts ...grpc.CallOption) (*AdvanceTimeResponse, error)
 }
 
 type nodeServiceClient struct {
@@ -2784,6 +5473,15 @@ func (c *nodeServiceClient) GetHostInfo(ctx context.Context, in *GetHostInfoRequ
 	return out, nil
 }
 
+func (c *nodeServiceClient) GetValidatorInfo(ctx context.Context, in *GetValidatorInfoRequest, opts ...grpc.CallOption) (*GetValidatorInfoResponse, error) {
+	out := new(GetValidatorInfoResponse)
+	err := c.cc.Invoke(ctx, "/pb.NodeService/GetValidatorInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *nodeServiceClient) GetNetworkKey(ctx context.Context, in *GetNetworkKeyRequest, opts ...grpc.CallOption) (*GetNetworkKeyResponse, error) {
 	out := new(GetNetworkKeyResponse)
 	err := c.cc.Invoke(ctx, "/pb.NodeService/GetNetworkKey", in, out, opts...)
@@ -2838,6 +5536,51 @@ func (c *nodeServiceClient) UnblockPeer(ctx context.Context, in *UnblockPeerRequ
 	return out, nil
 }
 
+func (c *nodeServiceClient) GetAccessLists(ctx context.Context, in *GetAccessListsRequest, opts ...grpc.CallOption) (*GetAccessListsResponse, error) {
+	out := new(GetAccessListsResponse)
+	err := c.cc.Invoke(ctx, "/pb.NodeService/GetAccessLists", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) UpdateAccessList(ctx context.Context, in *UpdateAccessListRequest, opts ...grpc.CallOption) (*UpdateAccessListResponse, error) {
+	out := new(UpdateAccessListResponse)
+	err := c.cc.Invoke(ctx, "/pb.NodeService/UpdateAccessList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) GetConsensusPeerScores(ctx context.Context, in *GetConsensusPeerScoresRequest, opts ...grpc.CallOption) (*GetConsensusPeerScoresResponse, error) {
+	out := new(GetConsensusPeerScoresResponse)
+	err := c.cc.Invoke(ctx, "/pb.NodeService/GetConsensusPeerScores", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) GetConsensusState(ctx context.Context, in *GetConsensusStateRequest, opts ...grpc.CallOption) (*GetConsensusStateResponse, error) {
+	out := new(GetConsensusStateResponse)
+	err := c.cc.Invoke(ctx, "/pb.NodeService/GetConsensusState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) GetTasks(ctx context.Context, in *GetTasksRequest, opts ...grpc.CallOption) (*GetTasksResponse, error) {
+	out := new(GetTasksResponse)
+	err := c.cc.Invoke(ctx, "/pb.NodeService/GetTasks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *nodeServiceClient) SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error) {
 	out := new(SetLogLevelResponse)
 	err := c.cc.Invoke(ctx, "/pb.NodeService/SetLogLevel", in, out, opts...)
@@ -2865,72 +5608,144 @@ func (c *nodeServiceClient) SetMinFeePerKilobyte(ctx context.Context, in *SetMin
 	return out, nil
 }
 
-func (c *nodeServiceClient) GetMinStake(ctx context.Context, in *GetMinStakeRequest, opts ...grpc.CallOption) (*GetMinStakeResponse, error) {
-	out := new(GetMinStakeResponse)
-	err := c.cc.Invoke(ctx, "/pb.NodeService/GetMinStake", in, out, opts...)
+func (c *nodeServiceClient) GetMinStake(ctx context.Context, in *GetMinStakeRequest, opts ...grpc.CallOption) (*GetMinStakeResponse, error) {
+	out := new(GetMinStakeResponse)
+	err := c.cc.Invoke(ctx, "/pb.NodeService/GetMinStake", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) SetMinStake(ctx context.Context, in *SetMinStakeRequest, opts ...grpc.CallOption) (*SetMinStakeResponse, error) {
+	out := new(SetMinStakeResponse)
+	err := c.cc.Invoke(ctx, "/pb.NodeService/SetMinStake", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) GetBlockSizeSoftLimit(ctx context.Context, in *GetBlockSizeSoftLimitRequest, opts ...grpc.CallOption) (*GetBlockSizeSoftLimitResponse, error) {
+	out := new(GetBlockSizeSoftLimitResponse)
+	err := c.cc.Invoke(ctx, "/pb.NodeService/GetBlockSizeSoftLimit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) SetBlockSizeSoftLimit(ctx context.Context, in *SetBlockSizeSoftLimitRequest, opts ...grpc.CallOption) (*SetBlockSizeSoftLimitResponse, error) {
+	out := new(SetBlockSizeSoftLimitResponse)
+	err := c.cc.Invoke(ctx, "/pb.NodeService/SetBlockSizeSoftLimit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) GetTreasuryWhitelist(ctx context.Context, in *GetTreasuryWhitelistRequest, opts ...grpc.CallOption) (*GetTreasuryWhitelistResponse, error) {
+	out := new(GetTreasuryWhitelistResponse)
+	err := c.cc.Invoke(ctx, "/pb.NodeService/GetTreasuryWhitelist", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) UpdateTreasuryWhitelist(ctx context.Context, in *UpdateTreasuryWhitelistRequest, opts ...grpc.CallOption) (*UpdateTreasuryWhitelistResponse, error) {
+	out := new(UpdateTreasuryWhitelistResponse)
+	err := c.cc.Invoke(ctx, "/pb.NodeService/UpdateTreasuryWhitelist", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) CreateTreasuryProposal(ctx context.Context, in *CreateTreasuryProposalRequest, opts ...grpc.CallOption) (*CreateTreasuryProposalResponse, error) {
+	out := new(CreateTreasuryProposalResponse)
+	err := c.cc.Invoke(ctx, "/pb.NodeService/CreateTreasuryProposal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) ImportTreasuryProposal(ctx context.Context, in *ImportTreasuryProposalRequest, opts ...grpc.CallOption) (*ImportTreasuryProposalResponse, error) {
+	out := new(ImportTreasuryProposalResponse)
+	err := c.cc.Invoke(ctx, "/pb.NodeService/ImportTreasuryProposal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) CollectTreasuryApprovals(ctx context.Context, in *CollectTreasuryApprovalsRequest, opts ...grpc.CallOption) (*CollectTreasuryApprovalsResponse, error) {
+	out := new(CollectTreasuryApprovalsResponse)
+	err := c.cc.Invoke(ctx, "/pb.NodeService/CollectTreasuryApprovals", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *nodeServiceClient) SetMinStake(ctx context.Context, in *SetMinStakeRequest, opts ...grpc.CallOption) (*SetMinStakeResponse, error) {
-	out := new(SetMinStakeResponse)
-	err := c.cc.Invoke(ctx, "/pb.NodeService/SetMinStake", in, out, opts...)
+func (c *nodeServiceClient) SubmitTreasuryProposal(ctx context.Context, in *SubmitTreasuryProposalRequest, opts ...grpc.CallOption) (*SubmitTreasuryProposalResponse, error) {
+	out := new(SubmitTreasuryProposalResponse)
+	err := c.cc.Invoke(ctx, "/pb.NodeService/SubmitTreasuryProposal", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *nodeServiceClient) GetBlockSizeSoftLimit(ctx context.Context, in *GetBlockSizeSoftLimitRequest, opts ...grpc.CallOption) (*GetBlockSizeSoftLimitResponse, error) {
-	out := new(GetBlockSizeSoftLimitResponse)
-	err := c.cc.Invoke(ctx, "/pb.NodeService/GetBlockSizeSoftLimit", in, out, opts...)
+func (c *nodeServiceClient) ListTreasuryProposals(ctx context.Context, in *ListTreasuryProposalsRequest, opts ...grpc.CallOption) (*ListTreasuryProposalsResponse, error) {
+	out := new(ListTreasuryProposalsResponse)
+	err := c.cc.Invoke(ctx, "/pb.NodeService/ListTreasuryProposals", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *nodeServiceClient) SetBlockSizeSoftLimit(ctx context.Context, in *SetBlockSizeSoftLimitRequest, opts ...grpc.CallOption) (*SetBlockSizeSoftLimitResponse, error) {
-	out := new(SetBlockSizeSoftLimitResponse)
-	err := c.cc.Invoke(ctx, "/pb.NodeService/SetBlockSizeSoftLimit", in, out, opts...)
+func (c *nodeServiceClient) ReconsiderBlock(ctx context.Context, in *ReconsiderBlockRequest, opts ...grpc.CallOption) (*ReconsiderBlockResponse, error) {
+	out := new(ReconsiderBlockResponse)
+	err := c.cc.Invoke(ctx, "/pb.NodeService/ReconsiderBlock", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *nodeServiceClient) GetTreasuryWhitelist(ctx context.Context, in *GetTreasuryWhitelistRequest, opts ...grpc.CallOption) (*GetTreasuryWhitelistResponse, error) {
-	out := new(GetTreasuryWhitelistResponse)
-	err := c.cc.Invoke(ctx, "/pb.NodeService/GetTreasuryWhitelist", in, out, opts...)
+func (c *nodeServiceClient) RecomputeChainState(ctx context.Context, in *RecomputeChainStateRequest, opts ...grpc.CallOption) (*RecomputeChainStateResponse, error) {
+	out := new(RecomputeChainStateResponse)
+	err := c.cc.Invoke(ctx, "/pb.NodeService/RecomputeChainState", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *nodeServiceClient) UpdateTreasuryWhitelist(ctx context.Context, in *UpdateTreasuryWhitelistRequest, opts ...grpc.CallOption) (*UpdateTreasuryWhitelistResponse, error) {
-	out := new(UpdateTreasuryWhitelistResponse)
-	err := c.cc.Invoke(ctx, "/pb.NodeService/UpdateTreasuryWhitelist", in, out, opts...)
+func (c *nodeServiceClient) CaptureProfile(ctx context.Context, in *CaptureProfileRequest, opts ...grpc.CallOption) (*CaptureProfileResponse, error) {
+	out := new(CaptureProfileResponse)
+	err := c.cc.Invoke(ctx, "/pb.NodeService/CaptureProfile", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *nodeServiceClient) ReconsiderBlock(ctx context.Context, in *ReconsiderBlockRequest, opts ...grpc.CallOption) (*ReconsiderBlockResponse, error) {
-	out := new(ReconsiderBlockResponse)
-	err := c.cc.Invoke(ctx, "/pb.NodeService/ReconsiderBlock", in, out, opts...)
+func (c *nodeServiceClient) SetMockTime(ctx context.Context, in *SetMockTimeRequest, opts ...grpc.CallOption) (*SetMockTimeResponse, error) {
+	out := new(SetMockTimeResponse)
+	err := c.cc.Invoke(ctx, "/pb.NodeService/SetMockTime", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *nodeServiceClient) RecomputeChainState(ctx context.Context, in *RecomputeChainStateRequest, opts ...grpc.CallOption) (*RecomputeChainStateResponse, error) {
-	out := new(RecomputeChainStateResponse)
-	err := c.cc.Invoke(ctx, "/pb.NodeService/RecomputeChainState", in, out, opts...)
+func (c *nodeServiceClient) AdvanceTime(ctx context.Context, in *AdvanceTimeRequest, opts ...grpc.CallOption) (*AdvanceTimeResponse, error) {
+	out := new(AdvanceTimeResponse)
+	err := c.cc.Invoke(ctx, "/pb.NodeService/AdvanceTime", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -2941,8 +5756,13 @@ func (c *nodeServiceClient) RecomputeChainState(ctx context.Context, in *Recompu
 // All implementations must embed UnimplementedNodeServiceServer
 // for forward compatibility
 type NodeServiceServer interface {
-	// GetHostInfo returns info about the libp2p host
+	// GetHostInfo returns info about the libp2p host and whether blocks
+	// are finalizing.
 	GetHostInfo(context.Context, *GetHostInfoRequest) (*GetHostInfoResponse, error)
+	// GetValidatorInfo returns this node's staking status including its stake,
+	// unclaimed rewards, the reward it can expect at the start of the next epoch
+	// given its current stake weight, and whether autostaking is enabled.
+	GetValidatorInfo(context.Context, *GetValidatorInfoRequest) (*GetValidatorInfoResponse, error)
 	// GetNetworkKey returns the node's network private key
 	GetNetworkKey(context.Context, *GetNetworkKeyRequest) (*GetNetworkKeyResponse, error)
 	// GetPeers returns a list of peers that this node is connected to
@@ -2955,6 +5775,31 @@ type NodeServiceServer interface {
 	BlockPeer(context.Context, *BlockPeerRequest) (*BlockPeerResponse, error)
 	// UnblockPeer removes a peer from the block list
 	UnblockPeer(context.Context, *UnblockPeerRequest) (*UnblockPeerResponse, error)
+	// GetAccessLists returns the peer IDs and IP ranges in the connection
+	// allow and deny lists. If the allow list is not empty only inbound
+	// connections from a listed peer ID or IP range are accepted. Connections
+	// to or from anything in the deny list are always refused.
+	GetAccessLists(context.Context, *GetAccessListsRequest) (*GetAccessListsResponse, error)
+	// UpdateAccessList adds or removes peer IDs and IP ranges from the allow
+	// or deny list. The change is committed to the datastore and will persist
+	// between sessions. Existing connections which are no longer permitted
+	// are closed.
+	UpdateAccessList(context.Context, *UpdateAccessListRequest) (*UpdateAccessListResponse, error)
+	// GetConsensusPeerScores returns the reliability scores the consensus
+	// engine keeps for the validators it polls. Validators are polled in
+	// proportion to their stake weight multiplied by their score and are
+	// temporarily banned from polling if the score falls too low.
+	GetConsensusPeerScores(context.Context, *GetConsensusPeerScoresRequest) (*GetConsensusPeerScoresResponse, error)
+	// GetConsensusState returns the consensus engine's vote records for the
+	// heights it's voting on, the number of polls in flight, and a histogram
+	// of how long blocks have taken to finalize. This can be used to see why
+	// blocks are not finalizing.
+	GetConsensusState(context.Context, *GetConsensusStateRequest) (*GetConsensusStateResponse, error)
+	// GetTasks returns the node's running background tasks, such as chain
+	// sync, rescans, proofs, and consensus polls, along with when they
+	// started and their current state. This can be used to see what the
+	// node is doing when it appears to be stuck.
+	GetTasks(context.Context, *GetTasksRequest) (*GetTasksResponse, error)
 	// SetLogLevel changes the logging level of the node
 	SetLogLevel(context.Context, *SetLogLevelRequest) (*SetLogLevelResponse, error)
 	// GetMinFeePerKilobyte returns the node's current minimum transaction fee needed to relay
@@ -2981,11 +5826,43 @@ type NodeServiceServer interface {
 	// UpdateTreasuryWhitelist adds or removes a transaction to from the treasury whitelist.
 	// This update is committed to the datastore and will persist between sessions.
 	UpdateTreasuryWhitelist(context.Context, *UpdateTreasuryWhitelistRequest) (*UpdateTreasuryWhitelistResponse, error)
+	// CreateTreasuryProposal drafts a treasury withdrawal paying the provided outputs
+	// and creates its zk proof. The proposal is saved and returned along with a
+	// serialized copy which can be handed to validators for review. The transaction
+	// is not broadcast until SubmitTreasuryProposal is called.
+	CreateTreasuryProposal(context.Context, *CreateTreasuryProposalRequest) (*CreateTreasuryProposalResponse, error)
+	// ImportTreasuryProposal decodes a serialized treasury proposal and checks that
+	// the outputs pay the listed addresses the listed amounts and that the treasury
+	// can cover the withdrawal. If the checks pass the proposal is saved. If approve
+	// is true the transaction is also added to the node's treasury whitelist.
+	ImportTreasuryProposal(context.Context, *ImportTreasuryProposalRequest) (*ImportTreasuryProposalResponse, error)
+	// CollectTreasuryApprovals queries the validators for their treasury whitelists
+	// and records the validators, and the fraction of the stake, which have approved
+	// the proposal.
+	CollectTreasuryApprovals(context.Context, *CollectTreasuryApprovalsRequest) (*CollectTreasuryApprovalsResponse, error)
+	// SubmitTreasuryProposal broadcasts the proposal's treasury transaction. The
+	// transaction must be in this node's treasury whitelist.
+	SubmitTreasuryProposal(context.Context, *SubmitTreasuryProposalRequest) (*SubmitTreasuryProposalResponse, error)
+	// ListTreasuryProposals returns the saved treasury proposals which have not yet
+	// been confirmed in a block.
+	ListTreasuryProposals(context.Context, *ListTreasuryProposalsRequest) (*ListTreasuryProposalsResponse, error)
 	// ReconsiderBlock tries to reprocess the given block
 	ReconsiderBlock(context.Context, *ReconsiderBlockRequest) (*ReconsiderBlockResponse, error)
 	// RecomputeChainState deletes the accumulator, validator set, and nullifier set and rebuilds them by
 	// loading and re-processing all blocks from genesis.
 	RecomputeChainState(context.Context, *RecomputeChainStateRequest) (*RecomputeChainStateResponse, error)
+	// CaptureProfile records a runtime profile of the node for the given duration
+	// and writes it to the profiles directory inside the node's data directory.
+	CaptureProfile(context.Context, *CaptureProfileRequest) (*CaptureProfileResponse, error)
+	// SetMockTime overrides the node's clock with the provided time. The node's
+	// notion of time remains fixed at this value until it is changed again. Setting
+	// the timestamp to zero restores the real clock. This RPC is only available in
+	// regtest mode.
+	SetMockTime(context.Context, *SetMockTimeRequest) (*SetMockTimeResponse, error)
+	// AdvanceTime moves the node's mock time forward by the provided number of seconds.
+	// If a mock time is not set, it starts from the current time. This RPC is only
+	// available in regtest mode.
+	AdvanceTime(context.Context, *AdvanceTimeRequest) (*AdvanceTimeResponse, error)
 	mustEmbedUnimplementedNodeServiceServer()
 }
 
@@ -2996,6 +5873,9 @@ type UnimplementedNodeServiceServer struct {
 func (UnimplementedNodeServiceServer) GetHostInfo(context.Context, *GetHostInfoRequest) (*GetHostInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetHostInfo not implemented")
 }
+func (UnimplementedNodeServiceServer) GetValidatorInfo(context.Context, *GetValidatorInfoRequest) (*GetValidatorInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetValidatorInfo not implemented")
+}
 func (UnimplementedNodeServiceServer) GetNetworkKey(context.Context, *GetNetworkKeyRequest) (*GetNetworkKeyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetNetworkKey not implemented")
 }
@@ -3014,6 +5894,21 @@ func (UnimplementedNodeServiceServer) BlockPeer(context.Context, *BlockPeerReque
 func (UnimplementedNodeServiceServer) UnblockPeer(context.Context, *UnblockPeerRequest) (*UnblockPeerResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UnblockPeer not implemented")
 }
+func (UnimplementedNodeServiceServer) GetAccessLists(context.Context, *GetAccessListsRequest) (*GetAccessListsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccessLists not implemented")
+}
+func (UnimplementedNodeServiceServer) UpdateAccessList(context.Context, *UpdateAccessListRequest) (*UpdateAccessListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAccessList not implemented")
+}
+func (UnimplementedNodeServiceServer) GetConsensusPeerScores(context.Context, *GetConsensusPeerScoresRequest) (*GetConsensusPeerScoresResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConsensusPeerScores not implemented")
+}
+func (UnimplementedNodeServiceServer) GetConsensusState(context.Context, *GetConsensusStateRequest) (*GetConsensusStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConsensusState not implemented")
+}
+func (UnimplementedNodeServiceServer) GetTasks(context.Context, *GetTasksRequest) (*GetTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTasks not implemented")
+}
 func (UnimplementedNodeServiceServer) SetLogLevel(context.Context, *SetLogLevelRequest) (*SetLogLevelResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetLogLevel not implemented")
 }
@@ -3041,12 +5936,36 @@ func (UnimplementedNodeServiceServer) GetTreasuryWhitelist(context.Context, *Get
 func (UnimplementedNodeServiceServer) UpdateTreasuryWhitelist(context.Context, *UpdateTreasuryWhitelistRequest) (*UpdateTreasuryWhitelistResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateTreasuryWhitelist not implemented")
 }
+func (UnimplementedNodeServiceServer) CreateTreasuryProposal(context.Context, *CreateTreasuryProposalRequest) (*CreateTreasuryProposalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTreasuryProposal not implemented")
+}
+func (UnimplementedNodeServiceServer) ImportTreasuryProposal(context.Context, *ImportTreasuryProposalRequest) (*ImportTreasuryProposalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportTreasuryProposal not implemented")
+}
+func (UnimplementedNodeServiceServer) CollectTreasuryApprovals(context.Context, *CollectTreasuryApprovalsRequest) (*CollectTreasuryApprovalsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CollectTreasuryApprovals not implemented")
+}
+func (UnimplementedNodeServiceServer) SubmitTreasuryProposal(context.Context, *SubmitTreasuryProposalRequest) (*SubmitTreasuryProposalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitTreasuryProposal not implemented")
+}
+func (UnimplementedNodeServiceServer) ListTreasuryProposals(context.Context, *ListTreasuryProposalsRequest) (*ListTreasuryProposalsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTreasuryProposals not implemented")
+}
 func (UnimplementedNodeServiceServer) ReconsiderBlock(context.Context, *ReconsiderBlockRequest) (*ReconsiderBlockResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReconsiderBlock not implemented")
 }
 func (UnimplementedNodeServiceServer) RecomputeChainState(context.Context, *RecomputeChainStateRequest) (*RecomputeChainStateResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RecomputeChainState not implemented")
 }
+func (UnimplementedNodeServiceServer) CaptureProfile(context.Context, *CaptureProfileRequest) (*CaptureProfileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CaptureProfile not implemented")
+}
+func (UnimplementedNodeServiceServer) SetMockTime(context.Context, *SetMockTimeRequest) (*SetMockTimeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMockTime not implemented")
+}
+func (UnimplementedNodeServiceServer) AdvanceTime(context.Context, *AdvanceTimeRequest) (*AdvanceTimeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdvanceTime not implemented")
+}
 func (UnimplementedNodeServiceServer) mustEmbedUnimplementedNodeServiceServer() {}
 
 // UnsafeNodeServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -3078,6 +5997,24 @@ func _NodeService_GetHostInfo_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NodeService_GetValidatorInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetValidatorInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).GetValidatorInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.NodeService/GetValidatorInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).GetValidatorInfo(ctx, req.(*GetValidatorInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _NodeService_GetNetworkKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetNetworkKeyRequest)
 	if err := dec(in); err != nil {
@@ -3186,6 +6123,96 @@ func _NodeService_UnblockPeer_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NodeService_GetAccessLists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccessListsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).GetAccessLists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.NodeService/GetAccessLists",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).GetAccessLists(ctx, req.(*GetAccessListsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_UpdateAccessList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAccessListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).UpdateAccessList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.NodeService/UpdateAccessList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).UpdateAccessList(ctx, req.(*UpdateAccessListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_GetConsensusPeerScores_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConsensusPeerScoresRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).GetConsensusPeerScores(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.NodeService/GetConsensusPeerScores",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).GetConsensusPeerScores(ctx, req.(*GetConsensusPeerScoresRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_GetConsensusState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConsensusStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).GetConsensusState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.NodeService/GetConsensusState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).GetConsensusState(ctx, req.(*GetConsensusStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_GetTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).GetTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.NodeService/GetTasks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).GetTasks(ctx, req.(*GetTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _NodeService_SetLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SetLogLevelRequest)
 	if err := dec(in); err != nil {
@@ -3348,6 +6375,96 @@ func _NodeService_UpdateTreasuryWhitelist_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NodeService_CreateTreasuryProposal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTreasuryProposalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).CreateTreasuryProposal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.NodeService/CreateTreasuryProposal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).CreateTreasuryProposal(ctx, req.(*CreateTreasuryProposalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_ImportTreasuryProposal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportTreasuryProposalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).ImportTreasuryProposal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.NodeService/ImportTreasuryProposal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).ImportTreasuryProposal(ctx, req.(*ImportTreasuryProposalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_CollectTreasuryApprovals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectTreasuryApprovalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).CollectTreasuryApprovals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.NodeService/CollectTreasuryApprovals",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).CollectTreasuryApprovals(ctx, req.(*CollectTreasuryApprovalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_SubmitTreasuryProposal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitTreasuryProposalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).SubmitTreasuryProposal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.NodeService/SubmitTreasuryProposal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).SubmitTreasuryProposal(ctx, req.(*SubmitTreasuryProposalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_ListTreasuryProposals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTreasuryProposalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).ListTreasuryProposals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.NodeService/ListTreasuryProposals",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).ListTreasuryProposals(ctx, req.(*ListTreasuryProposalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _NodeService_ReconsiderBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ReconsiderBlockRequest)
 	if err := dec(in); err != nil {
@@ -3384,6 +6501,60 @@ func _NodeService_RecomputeChainState_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NodeService_CaptureProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CaptureProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).CaptureProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.NodeService/CaptureProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).CaptureProfile(ctx, req.(*CaptureProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_SetMockTime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMockTimeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).SetMockTime(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.NodeService/SetMockTime",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).SetMockTime(ctx, req.(*SetMockTimeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_AdvanceTime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdvanceTimeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).AdvanceTime(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.NodeService/AdvanceTime",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).AdvanceTime(ctx, req.(*AdvanceTimeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // NodeService_ServiceDesc is the grpc.ServiceDesc for NodeService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -3395,6 +6566,10 @@ var NodeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetHostInfo",
 			Handler:    _NodeService_GetHostInfo_Handler,
 		},
+		{
+			MethodName: "GetValidatorInfo",
+			Handler:    _NodeService_GetValidatorInfo_Handler,
+		},
 		{
 			MethodName: "GetNetworkKey",
 			Handler:    _NodeService_GetNetworkKey_Handler,
@@ -3419,6 +6594,26 @@ var NodeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UnblockPeer",
 			Handler:    _NodeService_UnblockPeer_Handler,
 		},
+		{
+			MethodName: "GetAccessLists",
+			Handler:    _NodeService_GetAccessLists_Handler,
+		},
+		{
+			MethodName: "UpdateAccessList",
+			Handler:    _NodeService_UpdateAccessList_Handler,
+		},
+		{
+			MethodName: "GetConsensusPeerScores",
+			Handler:    _NodeService_GetConsensusPeerScores_Handler,
+		},
+		{
+			MethodName: "GetConsensusState",
+			Handler:    _NodeService_GetConsensusState_Handler,
+		},
+		{
+			MethodName: "GetTasks",
+			Handler:    _NodeService_GetTasks_Handler,
+		},
 		{
 			MethodName: "SetLogLevel",
 			Handler:    _NodeService_SetLogLevel_Handler,
@@ -3455,6 +6650,26 @@ var NodeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateTreasuryWhitelist",
 			Handler:    _NodeService_UpdateTreasuryWhitelist_Handler,
 		},
+		{
+			MethodName: "CreateTreasuryProposal",
+			Handler:    _NodeService_CreateTreasuryProposal_Handler,
+		},
+		{
+			MethodName: "ImportTreasuryProposal",
+			Handler:    _NodeService_ImportTreasuryProposal_Handler,
+		},
+		{
+			MethodName: "CollectTreasuryApprovals",
+			Handler:    _NodeService_CollectTreasuryApprovals_Handler,
+		},
+		{
+			MethodName: "SubmitTreasuryProposal",
+			Handler:    _NodeService_SubmitTreasuryProposal_Handler,
+		},
+		{
+			MethodName: "ListTreasuryProposals",
+			Handler:    _NodeService_ListTreasuryProposals_Handler,
+		},
 		{
 			MethodName: "ReconsiderBlock",
 			Handler:    _NodeService_ReconsiderBlock_Handler,
@@ -3463,6 +6678,18 @@ var NodeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RecomputeChainState",
 			Handler:    _NodeService_RecomputeChainState_Handler,
 		},
+		{
+			MethodName: "CaptureProfile",
+			Handler:    _NodeService_CaptureProfile_Handler,
+		},
+		{
+			MethodName: "SetMockTime",
+			Handler:    _NodeService_SetMockTime_Handler,
+		},
+		{
+			MethodName: "AdvanceTime",
+			Handler:    _NodeService_AdvanceTime_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "ilxrpc.proto",
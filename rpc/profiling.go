@@ -0,0 +1,107 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/project-illium/ilxd/rpc/pb"
+	"os"
+	"path"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"strings"
+	"time"
+)
+
+const (
+	// ProfilesDirectory is the name of the directory inside the
+	// node's data directory where captured profiles are written.
+	ProfilesDirectory = "profiles"
+
+	defaultProfileDuration = time.Second * 30
+	maxProfileDuration     = time.Minute * 10
+)
+
+// captureProfile records the requested profile and writes it to the profiles
+// directory. Duration based profiles (cpu, block, mutex, trace) block until
+// the duration elapses or the context is canceled.
+func (s *GrpcServer) captureProfile(ctx context.Context, profileType pb.CaptureProfileRequest_ProfileType, duration time.Duration) (string, error) {
+	if s.dataDir == "" {
+		return "", errors.New("data directory not set")
+	}
+	if duration == 0 {
+		duration = defaultProfileDuration
+	}
+	if duration > maxProfileDuration {
+		return "", fmt.Errorf("profile duration exceeds max of %s", maxProfileDuration)
+	}
+
+	dir := path.Join(s.dataDir, ProfilesDirectory)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+
+	ext := "pprof"
+	if profileType == pb.CaptureProfileRequest_TRACE {
+		ext = "trace"
+	}
+	filePath := path.Join(dir, fmt.Sprintf("%s-%d.%s", strings.ToLower(profileType.String()), time.Now().Unix(), ext))
+	f, err := os.Create(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	wait := func() {
+		select {
+		case <-time.After(duration):
+		case <-ctx.Done():
+		}
+	}
+
+	switch profileType {
+	case pb.CaptureProfileRequest_CPU:
+		if err := pprof.StartCPUProfile(f); err != nil {
+			os.Remove(filePath)
+			return "", err
+		}
+		wait()
+		pprof.StopCPUProfile()
+	case pb.CaptureProfileRequest_TRACE:
+		if err := trace.Start(f); err != nil {
+			os.Remove(filePath)
+			return "", err
+		}
+		wait()
+		trace.Stop()
+	case pb.CaptureProfileRequest_BLOCK:
+		runtime.SetBlockProfileRate(1)
+		wait()
+		err = pprof.Lookup("block").WriteTo(f, 0)
+		runtime.SetBlockProfileRate(0)
+	case pb.CaptureProfileRequest_MUTEX:
+		prev := runtime.SetMutexProfileFraction(1)
+		wait()
+		err = pprof.Lookup("mutex").WriteTo(f, 0)
+		runtime.SetMutexProfileFraction(prev)
+	case pb.CaptureProfileRequest_HEAP:
+		runtime.GC()
+		err = pprof.Lookup("heap").WriteTo(f, 0)
+	case pb.CaptureProfileRequest_ALLOCS:
+		err = pprof.Lookup("allocs").WriteTo(f, 0)
+	case pb.CaptureProfileRequest_GOROUTINE:
+		err = pprof.Lookup("goroutine").WriteTo(f, 0)
+	default:
+		err = errors.New("unknown profile type")
+	}
+	if err != nil {
+		os.Remove(filePath)
+		return "", err
+	}
+	return filePath, nil
+}
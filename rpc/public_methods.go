@@ -0,0 +1,39 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import "strings"
+
+const blockchainServicePrefix = "/pb.BlockchainService/"
+
+// publicMethods are the blockchain service methods which may be called
+// without the auth token when the public read only RPC is enabled. They
+// only read the chain tip, blocks, transactions, and fee estimates.
+// Methods which need an index that may be expensive to query, stream
+// data, or change the state of the node are left out.
+var publicMethods = map[string]bool{
+	"GetBlockchainInfo":  true,
+	"GetNetworkParams":   true,
+	"GetChainTips":       true,
+	"GetBlockInfo":       true,
+	"GetBlock":           true,
+	"GetRawBlock":        true,
+	"GetCompressedBlock": true,
+	"GetHeaders":         true,
+	"GetTransaction":     true,
+	"GetMerkleProof":     true,
+	"GetMempoolInfo":     true,
+	"GetFeeEstimate":     true,
+	"GetFeeHistory":      true,
+}
+
+// IsPublicMethod returns whether the method may be called without the
+// auth token when the public read only RPC is enabled.
+func IsPublicMethod(fullMethod string) bool {
+	if !strings.HasPrefix(fullMethod, blockchainServicePrefix) {
+		return false
+	}
+	return publicMethods[strings.TrimPrefix(fullMethod, blockchainServicePrefix)]
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"fmt"
+	"github.com/project-illium/ilxd/types"
+	"github.com/project-illium/ilxd/zk/circparams"
+	"math"
+)
+
+// checkRawTransactionAmounts runs the amount checks made by the standard
+// validation program against the private inputs and outputs of a raw
+// transaction. Proving takes minutes, so it's better to fail here than
+// to find out the proof is invalid once it's done.
+//
+// For each asset the sum of the inputs and the sum of the outputs must
+// fit in a u64, and the outputs must not exceed the inputs. For illium
+// coins the fee is added to the outputs.
+func checkRawTransactionAmounts(inputs []circparams.PrivateInput, outputs []circparams.PrivateOutput, fee types.Amount) error {
+	inSums := make(map[types.ID]types.Amount)
+	for i, in := range inputs {
+		sum := inSums[in.AssetID]
+		if sum > math.MaxUint64-in.Amount {
+			return fmt.Errorf("input %d: sum of asset %s inputs overflows", i, in.AssetID)
+		}
+		inSums[in.AssetID] = sum + in.Amount
+	}
+	outSums := map[types.ID]types.Amount{
+		types.IlliumCoinID: 0,
+	}
+	for i, out := range outputs {
+		sum := outSums[out.AssetID]
+		if sum > math.MaxUint64-out.Amount {
+			return fmt.Errorf("output %d: sum of asset %s outputs overflows", i, out.AssetID)
+		}
+		outSums[out.AssetID] = sum + out.Amount
+	}
+	for assetID, outSum := range outSums {
+		inSum := inSums[assetID]
+		if assetID == types.IlliumCoinID {
+			if outSum > math.MaxUint64-fee {
+				return fmt.Errorf("sum of outputs and fee overflows")
+			}
+			if outSum+fee > inSum {
+				return fmt.Errorf("outputs plus fee (%d) exceed inputs (%d)", outSum+fee, inSum)
+			}
+			continue
+		}
+		if outSum > inSum {
+			return fmt.Errorf("asset %s outputs (%d) exceed inputs (%d)", assetID, outSum, inSum)
+		}
+	}
+	return nil
+}
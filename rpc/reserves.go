@@ -0,0 +1,141 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/project-illium/ilxd/blockchain"
+	icrypto "github.com/project-illium/ilxd/crypto"
+	"github.com/project-illium/ilxd/params/hash"
+	"github.com/project-illium/ilxd/rpc/pb"
+	"github.com/project-illium/ilxd/types"
+	"github.com/project-illium/ilxd/zk"
+)
+
+// reservesProofPrefix domain separates the reserves proof signatures
+// from signed messages and transaction sighashes.
+const reservesProofPrefix = "Illium Reserves Proof:\n"
+
+// reservesDigest returns the digest that is signed for each note in a
+// reserves proof. It binds the signature to the verifier's challenge
+// message and to the specific note and accumulator root so signatures
+// cannot be replayed in a different proof.
+func reservesDigest(message string, commitment, txoRoot []byte) []byte {
+	preimage := make([]byte, 0, len(reservesProofPrefix)+len(message)+len(commitment)+len(txoRoot))
+	preimage = append(preimage, reservesProofPrefix...)
+	preimage = append(preimage, message...)
+	preimage = append(preimage, commitment...)
+	preimage = append(preimage, txoRoot...)
+	return hash.HashFunc(preimage)
+}
+
+// verifyReservesProof checks each note in the proof against the chain
+// and returns the total value of the notes. The proof is valid only if
+// every note:
+//   - opens to its commitment,
+//   - is included in the accumulator under a known txo root,
+//   - has not been spent, and
+//   - carries a valid signature from the spend key in its locking script.
+func verifyReservesProof(proof *pb.ReservesProof, chain *blockchain.Blockchain) (types.Amount, error) {
+	if len(proof.Notes) == 0 {
+		return 0, errors.New("proof contains no notes")
+	}
+	basicTransferCommitment, err := zk.LurkCommit(zk.BasicTransferScript())
+	if err != nil {
+		return 0, err
+	}
+
+	seen := make(map[types.ID]bool)
+	total := types.Amount(0)
+	for i, n := range proof.Notes {
+		if n.TxoProof == nil {
+			return 0, fmt.Errorf("note %d: missing inclusion proof", i)
+		}
+		if !bytes.Equal(n.ScriptCommitment, basicTransferCommitment) {
+			return 0, fmt.Errorf("note %d: only basic transfer notes are supported", i)
+		}
+		if len(n.LockingParams) != 2 {
+			return 0, fmt.Errorf("note %d: invalid locking params", i)
+		}
+		if !bytes.Equal(n.Asset_ID, types.IlliumCoinID[:]) {
+			return 0, fmt.Errorf("note %d: only illium coins may be counted", i)
+		}
+
+		lockingScript := types.LockingScript{
+			ScriptCommitment: types.NewID(n.ScriptCommitment),
+			LockingParams:    n.LockingParams,
+		}
+		scriptHash, err := lockingScript.Hash()
+		if err != nil {
+			return 0, fmt.Errorf("note %d: %w", i, err)
+		}
+		state := new(types.State)
+		if err := state.Deserialize(n.State); err != nil {
+			return 0, fmt.Errorf("note %d: %w", i, err)
+		}
+		note := types.SpendNote{
+			ScriptHash: scriptHash,
+			Amount:     types.Amount(n.Amount),
+			AssetID:    types.NewID(n.Asset_ID),
+			State:      *state,
+		}
+		copy(note.Salt[:], n.Salt)
+		commitment, err := note.Commitment()
+		if err != nil {
+			return 0, fmt.Errorf("note %d: %w", i, err)
+		}
+		if !bytes.Equal(commitment[:], n.TxoProof.Commitment) {
+			return 0, fmt.Errorf("note %d: note does not match commitment", i)
+		}
+		if seen[commitment] {
+			return 0, fmt.Errorf("note %d: duplicate commitment", i)
+		}
+		seen[commitment] = true
+
+		exists, err := chain.TxoRootExists(types.NewID(n.TxoProof.TxoRoot))
+		if err != nil {
+			return 0, err
+		}
+		if !exists {
+			return 0, fmt.Errorf("note %d: txo root not found", i)
+		}
+		valid, err := blockchain.ValidateInclusionProof(commitment[:], n.TxoProof.Index, n.TxoProof.Hashes, n.TxoProof.Flags, n.TxoProof.TxoRoot)
+		if err != nil {
+			return 0, fmt.Errorf("note %d: %w", i, err)
+		}
+		if !valid {
+			return 0, fmt.Errorf("note %d: invalid inclusion proof", i)
+		}
+
+		nullifier, err := types.CalculateNullifier(n.TxoProof.Index, note.Salt, n.ScriptCommitment, n.LockingParams...)
+		if err != nil {
+			return 0, fmt.Errorf("note %d: %w", i, err)
+		}
+		spent, err := chain.NullifierExists(nullifier)
+		if err != nil {
+			return 0, err
+		}
+		if spent {
+			return 0, fmt.Errorf("note %d: note has been spent", i)
+		}
+
+		pubkey, err := icrypto.PublicKeyFromXY(n.LockingParams[0], n.LockingParams[1])
+		if err != nil {
+			return 0, fmt.Errorf("note %d: %w", i, err)
+		}
+		valid, err = pubkey.Verify(reservesDigest(proof.Message, commitment[:], n.TxoProof.TxoRoot), n.Signature)
+		if err != nil {
+			return 0, fmt.Errorf("note %d: %w", i, err)
+		}
+		if !valid {
+			return 0, fmt.Errorf("note %d: invalid signature", i)
+		}
+
+		total += note.Amount
+	}
+	return total, nil
+}
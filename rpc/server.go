@@ -10,6 +10,9 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/project-illium/ilxd/blockchain"
 	"github.com/project-illium/ilxd/blockchain/indexers"
+	"github.com/project-illium/ilxd/consensus"
+	"github.com/project-illium/ilxd/evidence"
+	"github.com/project-illium/ilxd/invoice"
 	"github.com/project-illium/ilxd/mempool"
 	"github.com/project-illium/ilxd/net"
 	"github.com/project-illium/ilxd/params"
@@ -17,6 +20,7 @@ import (
 	"github.com/project-illium/ilxd/repo"
 	"github.com/project-illium/ilxd/rpc/pb"
 	"github.com/project-illium/ilxd/types"
+	"github.com/project-illium/ilxd/types/blocks"
 	"github.com/project-illium/ilxd/types/transactions"
 	"github.com/project-illium/ilxd/zk"
 	"github.com/project-illium/walletlib"
@@ -24,6 +28,7 @@ import (
 	"google.golang.org/grpc/reflection"
 	"net/http"
 	"sync"
+	"time"
 )
 
 var _ pb.BlockchainServiceServer = (*GrpcServer)(nil)
@@ -38,53 +43,98 @@ type GrpcServerConfig struct {
 	Server     *grpc.Server
 	HTTPServer *http.Server
 
-	Chain                *blockchain.Blockchain
-	Network              *net.Network
-	Wallet               *walletlib.Wallet
-	Prover               zk.Prover
-	Policy               *policy.Policy
-	BroadcastTxFunc      func(tx *transactions.Transaction) error
-	SetLogLevelFunc      func(level string) error
-	ReindexChainFunc     func() error
-	RequestBlockFunc     func(blockID types.ID, remotePeer peer.ID)
-	AutoStakeFunc        func(bool) error
-	NetworkKeyFunc       func() (crypto.PrivKey, error)
-	ChainParams          *params.NetworkParams
-	Ds                   repo.Datastore
-	TxMemPool            *mempool.Mempool
-	DisableNodeService   bool
-	DisableWalletService bool
-	DisableWalletServer  bool
-	DisableProverServer  bool
-
-	TxIndex   *indexers.TxIndex
-	WSIndex   *indexers.WalletServerIndex
-	AddrIndex *indexers.AddrIndex
+	Chain                 *blockchain.Blockchain
+	Network               *net.Network
+	Wallet                *walletlib.Wallet
+	WalletDs              repo.Datastore
+	InvoiceService        *invoice.InvoiceService
+	Prover                zk.Prover
+	Policy                *policy.Policy
+	BroadcastTxFunc       func(tx *transactions.Transaction) error
+	SubmitPackageFunc     func(txs []*transactions.Transaction) error
+	SetLogLevelFunc       func(level string) error
+	ReindexChainFunc      func() error
+	RequestBlockFunc      func(blockID types.ID, remotePeer peer.ID)
+	AutoStakeFunc         func(bool) error
+	AutoStakeEnabledFunc  func() bool
+	NetworkKeyFunc        func() (crypto.PrivKey, error)
+	ConsensusHealthFunc   func() ConsensusHealth
+	PeerScoresFunc        func() []consensus.PeerScore
+	ConsensusStateFunc    func() *consensus.EngineState
+	ProvingTimeFunc       func(inputs int) time.Duration
+	TreasuryWhitelistFunc func(p peer.ID) ([]types.ID, error)
+	WalletRescanFunc      func() WalletRescanStatus
+	ChainParams           *params.NetworkParams
+	DataDir               string
+	KeyBackupDir          string
+	Ds                    repo.Datastore
+	TxMemPool             *mempool.Mempool
+	DisableNodeService    bool
+	DisableWalletService  bool
+	DisableWalletServer   bool
+	DisableProverServer   bool
+	ChangePolicy          ChangePolicy
+	Evidence              *evidence.Store
+
+	TxIndex          *indexers.TxIndex
+	WSIndex          *indexers.WalletServerIndex
+	AddrIndex        *indexers.AddrIndex
+	FeeIndex         *indexers.FeeIndex
+	BlockFilterIndex *indexers.BlockFilterIndex
+	ScriptIndex      *indexers.ScriptIndex
 }
 
 // GrpcServer is the gRPC server implementation. It holds all the objects
 // necessary to serve the RPCs and implements the ilxdrpc.proto interface.
 type GrpcServer struct {
-	chain            *blockchain.Blockchain
-	chainParams      *params.NetworkParams
-	ds               repo.Datastore
-	txMemPool        *mempool.Mempool
-	network          *net.Network
-	policy           *policy.Policy
-	wallet           *walletlib.Wallet
-	prover           zk.Prover
-	broadcastTxFunc  func(tx *transactions.Transaction) error
-	setLogLevelFunc  func(level string) error
-	reindexChainFunc func() error
-	requestBlockFunc func(blockID types.ID, remotePeer peer.ID)
-	autoStakeFunc    func(bool) error
-	networkKeyFunc   func() (crypto.PrivKey, error)
+	chain                 *blockchain.Blockchain
+	chainParams           *params.NetworkParams
+	dataDir               string
+	keyBackupDir          string
+	ds                    repo.Datastore
+	txMemPool             *mempool.Mempool
+	network               *net.Network
+	policy                *policy.Policy
+	wallet                *walletlib.Wallet
+	walletDs              repo.Datastore
+	invoiceService        *invoice.InvoiceService
+	invoices              *invoiceTracker
+	sweepJobs             *sweepJobManager
+	atomicSwaps           *atomicSwapManager
+	stakes                *stakeWatcher
+	viewUnlock            *viewUnlock
+	changeAddresses       *changeAddresses
+	treasuryProposals     *treasuryProposalStore
+	prover                zk.Prover
+	broadcastTxFunc       func(tx *transactions.Transaction) error
+	submitPackageFunc     func(txs []*transactions.Transaction) error
+	setLogLevelFunc       func(level string) error
+	reindexChainFunc      func() error
+	requestBlockFunc      func(blockID types.ID, remotePeer peer.ID)
+	autoStakeFunc         func(bool) error
+	autoStakeEnabledFunc  func() bool
+	networkKeyFunc        func() (crypto.PrivKey, error)
+	consensusHealthFunc   func() ConsensusHealth
+	peerScoresFunc        func() []consensus.PeerScore
+	consensusStateFunc    func() *consensus.EngineState
+	provingTimeFunc       func(inputs int) time.Duration
+	treasuryWhitelistFunc func(p peer.ID) ([]types.ID, error)
+	walletRescanFunc      func() WalletRescanStatus
+	changePolicy          ChangePolicy
+	evidence              *evidence.Store
 
 	txIndex              *indexers.TxIndex
 	wsIndex              *indexers.WalletServerIndex
 	addrIndex            *indexers.AddrIndex
+	feeIndex             *indexers.FeeIndex
+	filterIndex          *indexers.BlockFilterIndex
+	scriptIndex          *indexers.ScriptIndex
 	provingServiceActive bool
 
+	deleteNonce        []byte
+	deleteNonceExpires time.Time
+	deleteNonceMtx     sync.Mutex
+
 	httpServer *http.Server
 	subs       map[types.ID]*subscription
 	subMtx     sync.RWMutex
@@ -102,37 +152,66 @@ type GrpcServer struct {
 // be started.
 func NewGrpcServer(cfg *GrpcServerConfig) *GrpcServer {
 	s := &GrpcServer{
-		chain:                cfg.Chain,
-		chainParams:          cfg.ChainParams,
-		ds:                   cfg.Ds,
-		txMemPool:            cfg.TxMemPool,
-		network:              cfg.Network,
-		wallet:               cfg.Wallet,
-		prover:               cfg.Prover,
-		broadcastTxFunc:      cfg.BroadcastTxFunc,
-		setLogLevelFunc:      cfg.SetLogLevelFunc,
-		reindexChainFunc:     cfg.ReindexChainFunc,
-		requestBlockFunc:     cfg.RequestBlockFunc,
-		autoStakeFunc:        cfg.AutoStakeFunc,
-		networkKeyFunc:       cfg.NetworkKeyFunc,
-		txIndex:              cfg.TxIndex,
-		wsIndex:              cfg.WSIndex,
-		addrIndex:            cfg.AddrIndex,
-		provingServiceActive: !cfg.DisableProverServer,
-		policy:               cfg.Policy,
-		httpServer:           cfg.HTTPServer,
-		subs:                 make(map[types.ID]*subscription),
-		subMtx:               sync.RWMutex{},
-		events:               make(chan interface{}),
-		quit:                 make(chan struct{}),
+		chain:                 cfg.Chain,
+		chainParams:           cfg.ChainParams,
+		dataDir:               cfg.DataDir,
+		keyBackupDir:          cfg.KeyBackupDir,
+		ds:                    cfg.Ds,
+		txMemPool:             cfg.TxMemPool,
+		network:               cfg.Network,
+		wallet:                cfg.Wallet,
+		walletDs:              cfg.WalletDs,
+		invoiceService:        cfg.InvoiceService,
+		prover:                cfg.Prover,
+		broadcastTxFunc:       cfg.BroadcastTxFunc,
+		submitPackageFunc:     cfg.SubmitPackageFunc,
+		setLogLevelFunc:       cfg.SetLogLevelFunc,
+		reindexChainFunc:      cfg.ReindexChainFunc,
+		requestBlockFunc:      cfg.RequestBlockFunc,
+		autoStakeFunc:         cfg.AutoStakeFunc,
+		autoStakeEnabledFunc:  cfg.AutoStakeEnabledFunc,
+		networkKeyFunc:        cfg.NetworkKeyFunc,
+		consensusHealthFunc:   cfg.ConsensusHealthFunc,
+		peerScoresFunc:        cfg.PeerScoresFunc,
+		consensusStateFunc:    cfg.ConsensusStateFunc,
+		provingTimeFunc:       cfg.ProvingTimeFunc,
+		treasuryWhitelistFunc: cfg.TreasuryWhitelistFunc,
+		walletRescanFunc:      cfg.WalletRescanFunc,
+		changePolicy:          cfg.ChangePolicy,
+		evidence:              cfg.Evidence,
+		txIndex:               cfg.TxIndex,
+		wsIndex:               cfg.WSIndex,
+		addrIndex:             cfg.AddrIndex,
+		feeIndex:              cfg.FeeIndex,
+		filterIndex:           cfg.BlockFilterIndex,
+		scriptIndex:           cfg.ScriptIndex,
+		provingServiceActive:  !cfg.DisableProverServer,
+		policy:                cfg.Policy,
+		httpServer:            cfg.HTTPServer,
+		subs:                  make(map[types.ID]*subscription),
+		subMtx:                sync.RWMutex{},
+		events:                make(chan interface{}),
+		quit:                  make(chan struct{}),
 	}
 	reflection.Register(cfg.Server)
 	pb.RegisterBlockchainServiceServer(cfg.Server, s)
 	if !cfg.DisableNodeService {
 		pb.RegisterNodeServiceServer(cfg.Server, s)
+		s.treasuryProposals = newTreasuryProposalStore(s.ds)
 	}
 	if !cfg.DisableWalletService {
 		pb.RegisterWalletServiceServer(cfg.Server, s)
+
+		// If the saved invoices fail to load the tracker is left
+		// nil and the invoice RPCs will return Unavailable.
+		if s.wallet != nil {
+			s.invoices, _ = newInvoiceTracker(s.ds, s.wallet, s.chainParams, s.quit)
+			s.sweepJobs, _ = newSweepJobManager(s.ds, s.wallet, s.chainParams, s.quit)
+			s.atomicSwaps, _ = newAtomicSwapManager(s.ds, s.chain, s.quit)
+			s.stakes, _ = newStakeWatcher(s.wallet, s.walletDs, s.ds, s.chain, s.publishTransactionEvent)
+			s.viewUnlock = newViewUnlock(s.ds)
+			s.changeAddresses = newChangeAddresses(s.ds, s.wallet, s.chainParams, s.changePolicy.AddressPolicy, s.quit)
+		}
 	}
 	if !cfg.DisableWalletServer {
 		pb.RegisterWalletServerServiceServer(cfg.Server, s)
@@ -142,6 +221,9 @@ func NewGrpcServer(cfg *GrpcServerConfig) *GrpcServer {
 	}
 
 	s.chain.Subscribe(s.handleBlockchainNotifications)
+	if s.txMemPool != nil {
+		s.txMemPool.Subscribe(s.handleMempoolNotifications)
+	}
 
 	return s
 }
@@ -150,6 +232,29 @@ func (s *GrpcServer) Close() {
 	close(s.quit)
 }
 
+// ConsensusHealth reports whether blocks are finalizing.
+type ConsensusHealth struct {
+	// Degraded is true if no block has finalized for longer than
+	// the configured stall timeout.
+	Degraded bool
+	// LastProgress is the last time the chain was seen advancing.
+	LastProgress time.Time
+	// RecoveryAttempts is the number of recovery attempts made
+	// during the current stall.
+	RecoveryAttempts int
+}
+
+// WalletRescanStatus reports the progress of a wallet rescan.
+type WalletRescanStatus struct {
+	Rescanning    bool
+	StartHeight   uint32
+	ScannedHeight uint32
+	BestHeight    uint32
+	Started       time.Time
+	// Finished is zero while the rescan is running.
+	Finished time.Time
+}
+
 type subscription struct {
 	C    chan interface{}
 	quit chan struct{}
@@ -159,7 +264,57 @@ func (sub *subscription) Close() {
 	close(sub.quit)
 }
 
+// NotifyBlockProposed is called when a valid block is handed to consensus
+// but has not yet been finalized. Invoice payments in the block are marked
+// as confirmed.
+func (s *GrpcServer) NotifyBlockProposed(blk *blocks.Block) {
+	if s.invoices != nil {
+		go s.invoices.ProcessBlock(blk, false)
+	}
+}
+
 func (s *GrpcServer) handleBlockchainNotifications(n *blockchain.Notification) {
+	if s.invoices != nil && n.Type == blockchain.NTBlockConnected {
+		if blk, ok := n.Data.(*blocks.Block); ok {
+			go s.invoices.ProcessBlock(blk, true)
+		}
+	}
+	if s.atomicSwaps != nil && n.Type == blockchain.NTBlockConnected {
+		if blk, ok := n.Data.(*blocks.Block); ok {
+			go s.atomicSwaps.ProcessBlock(blk)
+		}
+	}
+	if s.treasuryProposals != nil && n.Type == blockchain.NTBlockConnected {
+		if blk, ok := n.Data.(*blocks.Block); ok {
+			go s.treasuryProposals.ProcessBlock(blk)
+		}
+	}
+	if s.stakes != nil && n.Type == blockchain.NTBlockConnected {
+		if blk, ok := n.Data.(*blocks.Block); ok {
+			go s.stakes.ProcessBlock(blk)
+		}
+	}
+
+	s.subMtx.RLock()
+	defer s.subMtx.RUnlock()
+
+	for _, sub := range s.subs {
+		sub.C <- n
+	}
+}
+
+func (s *GrpcServer) handleMempoolNotifications(n *mempool.Notification) {
+	if s.invoices != nil && n.Type == mempool.NTTransactionAccepted {
+		if tx, ok := n.Data.(*transactions.Transaction); ok {
+			s.invoices.ProcessTransaction(tx)
+		}
+	}
+	if s.atomicSwaps != nil && n.Type == mempool.NTTransactionAccepted {
+		if tx, ok := n.Data.(*transactions.Transaction); ok {
+			s.atomicSwaps.ProcessTransaction(tx)
+		}
+	}
+
 	s.subMtx.RLock()
 	defer s.subMtx.RUnlock()
 
@@ -168,6 +323,17 @@ func (s *GrpcServer) handleBlockchainNotifications(n *blockchain.Notification) {
 	}
 }
 
+// publishTransactionEvent sends a wallet event which doesn't come from a
+// wallet transaction to the subscribers.
+func (s *GrpcServer) publishTransactionEvent(event *pb.TransactionEvent) {
+	s.subMtx.RLock()
+	defer s.subMtx.RUnlock()
+
+	for _, sub := range s.subs {
+		sub.C <- event
+	}
+}
+
 func (s *GrpcServer) subscribeEvents() *subscription {
 	sub := &subscription{
 		C:    make(chan interface{}),
@@ -0,0 +1,124 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"github.com/project-illium/ilxd/params"
+	"github.com/project-illium/ilxd/repo"
+	"github.com/project-illium/ilxd/rpc/pb"
+	"github.com/project-illium/ilxd/types"
+	walletpb "github.com/project-illium/walletlib/pb"
+	"google.golang.org/protobuf/proto"
+	"strings"
+	"time"
+)
+
+// validateSpendTemplate checks that the template is well formed. Addresses
+// are checked against the network so that a bad template fails when it is
+// saved rather than when it is executed.
+func validateSpendTemplate(template *pb.SpendTemplate, chainParams *params.NetworkParams) error {
+	if template.Name == "" {
+		return errors.New("template name is required")
+	}
+	if strings.Contains(template.Name, "/") {
+		return errors.New("template name may not contain '/'")
+	}
+	if len(template.Recipients) == 0 {
+		return errors.New("template has no recipients")
+	}
+	totalPercent := float64(0)
+	for i, r := range template.Recipients {
+		if _, err := decodeAddress(r.Address, chainParams); err != nil {
+			return fmt.Errorf("recipient %d: %w", i, err)
+		}
+		if (r.Amount == 0) == (r.BalancePercent == 0) {
+			return fmt.Errorf("recipient %d: exactly one of amount or balance percent must be set", i)
+		}
+		if r.BalancePercent < 0 || r.BalancePercent > 100 {
+			return fmt.Errorf("recipient %d: balance percent must be between 0 and 100", i)
+		}
+		totalPercent += r.BalancePercent
+	}
+	if totalPercent > 100 {
+		return errors.New("recipient balance percents total more than 100")
+	}
+	return nil
+}
+
+func putSpendTemplate(ds repo.Datastore, template *pb.SpendTemplate) error {
+	ser, err := proto.Marshal(template)
+	if err != nil {
+		return err
+	}
+	return ds.Put(context.Background(), datastore.NewKey(repo.SpendTemplateDatastoreKeyPrefix+template.Name), ser)
+}
+
+func getSpendTemplate(ds repo.Datastore, name string) (*pb.SpendTemplate, error) {
+	ser, err := ds.Get(context.Background(), datastore.NewKey(repo.SpendTemplateDatastoreKeyPrefix+name))
+	if err != nil {
+		return nil, err
+	}
+	var template pb.SpendTemplate
+	if err := proto.Unmarshal(ser, &template); err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+func getSpendTemplates(ds repo.Datastore) ([]*pb.SpendTemplate, error) {
+	results, err := ds.Query(context.Background(), query.Query{
+		Prefix: repo.SpendTemplateDatastoreKeyPrefix,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer results.Close()
+
+	var templates []*pb.SpendTemplate
+	for result, ok := results.NextSync(); ok; result, ok = results.NextSync() {
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		var template pb.SpendTemplate
+		if err := proto.Unmarshal(result.Value, &template); err != nil {
+			return nil, err
+		}
+		templates = append(templates, &template)
+	}
+	return templates, nil
+}
+
+func deleteSpendTemplate(ds repo.Datastore, name string) error {
+	return ds.Delete(context.Background(), datastore.NewKey(repo.SpendTemplateDatastoreKeyPrefix+name))
+}
+
+// spendableTotal returns the total value of the notes the template is
+// allowed to spend. If the template lists input commitments only those
+// notes are counted, otherwise it is the wallet's spendable balance.
+func spendableTotal(notes []*walletpb.SpendNote, commitments [][]byte, now time.Time) types.Amount {
+	total := types.Amount(0)
+	for _, note := range notes {
+		if len(commitments) > 0 {
+			for _, c := range commitments {
+				if bytes.Equal(c, note.Commitment) {
+					total += types.Amount(note.Amount)
+					break
+				}
+			}
+			continue
+		}
+		if note.WatchOnly || note.Staked || time.Unix(note.LockedUntil, 0).After(now) {
+			continue
+		}
+		total += types.Amount(note.Amount)
+	}
+	return total
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"github.com/project-illium/ilxd/rpc/pb"
+	"github.com/project-illium/ilxd/types"
+	"math"
+	"sort"
+)
+
+const (
+	defaultTargetProbability = 0.5
+	defaultProbabilityBlocks = 1000
+)
+
+// stakeDistribution computes the distribution statistics for the
+// validator stake weights. The weights are sorted in place.
+func stakeDistribution(weights []types.Amount) *pb.GetStakeDistributionResponse {
+	sort.Slice(weights, func(i, j int) bool { return weights[i] < weights[j] })
+
+	resp := &pb.GetStakeDistributionResponse{
+		NumValidators: uint32(len(weights)),
+	}
+	if len(weights) == 0 {
+		return resp
+	}
+
+	total := types.Amount(0)
+	for _, w := range weights {
+		total += w
+	}
+	resp.StakeWeight = uint64(total)
+
+	mid := len(weights) / 2
+	if len(weights)%2 == 0 {
+		resp.MedianStakeWeight = uint64(weights[mid-1]/2 + weights[mid]/2)
+	} else {
+		resp.MedianStakeWeight = uint64(weights[mid])
+	}
+
+	if total > 0 {
+		// G = 2*sum(i*x_i) / (n*sum(x)) - (n+1)/n with the weights
+		// sorted ascending and i starting at one.
+		n := float64(len(weights))
+		weightedSum := 0.0
+		for i, w := range weights {
+			weightedSum += float64(i+1) * float64(w)
+		}
+		resp.Gini = 2*weightedSum/(n*float64(total)) - (n+1)/n
+	}
+
+	cumulative := types.Amount(0)
+	for i := len(weights) - 1; i >= 0; i-- {
+		cumulative += weights[i]
+		resp.NakamotoCoefficient++
+		if cumulative > total/2 {
+			break
+		}
+	}
+
+	var bucket *pb.GetStakeDistributionResponse_Bucket
+	for _, w := range weights {
+		if bucket == nil || uint64(w) >= bucket.MaxWeight {
+			min, max := uint64(0), uint64(1)
+			for max <= uint64(w) && max <= math.MaxUint64/10 {
+				min, max = max, max*10
+			}
+			bucket = &pb.GetStakeDistributionResponse_Bucket{
+				MinWeight: min,
+				MaxWeight: max,
+			}
+			resp.Histogram = append(resp.Histogram, bucket)
+		}
+		bucket.Count++
+		bucket.TotalWeight += uint64(w)
+	}
+	return resp
+}
+
+// minimumStakeForProbability returns the stake weight a new validator
+// would need to have the target probability of producing at least one
+// of the next n blocks given the current total stake weight.
+//
+// Each block is produced by a validator selected with probability
+// w/(W+w) so the per-block probability q must satisfy 1-(1-q)^n >= p.
+func minimumStakeForProbability(totalWeight types.Amount, p float64, n uint32) types.Amount {
+	q := 1 - math.Pow(1-p, 1/float64(n))
+	return types.Amount(math.Ceil(q * float64(totalWeight) / (1 - q)))
+}
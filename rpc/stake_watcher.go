@@ -0,0 +1,190 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"context"
+	"github.com/ipfs/go-datastore"
+	"github.com/project-illium/ilxd/blockchain"
+	"github.com/project-illium/ilxd/repo"
+	"github.com/project-illium/ilxd/rpc/pb"
+	"github.com/project-illium/ilxd/types"
+	"github.com/project-illium/ilxd/types/blocks"
+	"github.com/project-illium/walletlib"
+	walletpb "github.com/project-illium/walletlib/pb"
+	"google.golang.org/protobuf/proto"
+	"sync"
+)
+
+// stakeWatcher updates the wallet's staked notes when the chain removes
+// them from the validator set without a transaction spending them.
+//
+// The wallet marks a note as staked when it sees the stake transaction but
+// it never learns when the stake expires, or when the validator is banned
+// and its stake is added to the nullifier set, since neither shows up in
+// the block's transactions. Until a rescan the note would keep showing as
+// staked, or as spendable after it was slashed.
+//
+// After each block the watcher compares the wallet's staked notes against
+// the validator set. A note that was staked at the previous block but no
+// longer is, and wasn't spent by the block, was either slashed, if its
+// nullifier is now in the nullifier set, or expired. Slashed notes are
+// deleted from the wallet and expired notes are marked as not staked.
+type stakeWatcher struct {
+	wallet   *walletlib.Wallet
+	walletDs repo.Datastore
+	ds       repo.Datastore
+	chain    *blockchain.Blockchain
+	publish  func(event *pb.TransactionEvent)
+
+	// staked maps the nullifiers of the wallet's notes which were
+	// in the validator set at the last block to their commitments.
+	staked map[types.Nullifier]types.ID
+	mtx    sync.Mutex
+}
+
+// newStakeWatcher returns a new stakeWatcher. Notes which the wallet has
+// marked as staked but whose stake expired while the node was offline
+// are marked as not staked.
+func newStakeWatcher(wallet *walletlib.Wallet, walletDs, ds repo.Datastore, chain *blockchain.Blockchain, publish func(event *pb.TransactionEvent)) (*stakeWatcher, error) {
+	w := &stakeWatcher{
+		wallet:   wallet,
+		walletDs: walletDs,
+		ds:       ds,
+		chain:    chain,
+		publish:  publish,
+		staked:   make(map[types.Nullifier]types.ID),
+	}
+	notes, err := w.stakedNotes()
+	if err != nil {
+		return nil, err
+	}
+	snap := chain.Snapshot()
+	for nullifier, note := range notes {
+		if snap.IsStaked(nullifier) {
+			w.staked[nullifier] = types.NewID(note.Commitment)
+			continue
+		}
+		spent, err := chain.NullifierExists(nullifier)
+		if err != nil {
+			return nil, err
+		}
+		// Spent notes are left for the wallet to remove.
+		if !spent {
+			if err := w.unstake(note); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return w, nil
+}
+
+// ProcessBlock checks the wallet's staked notes against the validator set
+// after the block was connected.
+func (w *stakeWatcher) ProcessBlock(blk *blocks.Block) {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	notes, err := w.stakedNotes()
+	if err != nil {
+		return
+	}
+	spent := make(map[types.Nullifier]bool)
+	for _, n := range blk.Nullifiers() {
+		spent[n] = true
+	}
+
+	snap := w.chain.Snapshot()
+	staked := make(map[types.Nullifier]types.ID)
+	for nullifier, note := range notes {
+		if snap.IsStaked(nullifier) {
+			staked[nullifier] = types.NewID(note.Commitment)
+		}
+	}
+
+	for nullifier := range w.staked {
+		if _, ok := staked[nullifier]; ok || spent[nullifier] {
+			continue
+		}
+		note, ok := notes[nullifier]
+		if !ok {
+			continue
+		}
+		// Notes that fail to update are checked again
+		// at the next block.
+		banned, err := w.chain.NullifierExists(nullifier)
+		if err != nil {
+			staked[nullifier] = types.NewID(note.Commitment)
+			continue
+		}
+		eventType := pb.TransactionEvent_STAKE_EXPIRED
+		if banned {
+			eventType = pb.TransactionEvent_STAKE_SLASHED
+			err = w.remove(note, nullifier)
+		} else {
+			err = w.unstake(note)
+		}
+		if err != nil {
+			staked[nullifier] = types.NewID(note.Commitment)
+			continue
+		}
+		label, _ := getAddressLabel(w.ds, note.Address)
+		w.publish(&pb.TransactionEvent{
+			Type:        eventType,
+			Block_ID:    blk.ID().Bytes(),
+			BlockHeight: blk.Header.Height,
+			Utxo: &pb.Utxo{
+				Commitment:   note.Commitment,
+				Amount:       note.Amount,
+				Address:      note.Address,
+				WatchOnly:    note.WatchOnly,
+				Staked:       note.Staked,
+				LockedUntill: note.LockedUntil,
+				Label:        label,
+			},
+		})
+	}
+	w.staked = staked
+}
+
+// stakedNotes returns the wallet's staked notes keyed by nullifier.
+func (w *stakeWatcher) stakedNotes() (map[types.Nullifier]*walletpb.SpendNote, error) {
+	notes, err := w.wallet.Notes()
+	if err != nil {
+		return nil, err
+	}
+	staked := make(map[types.Nullifier]*walletpb.SpendNote)
+	for _, note := range notes {
+		if !note.Staked || note.LockingScript == nil {
+			continue
+		}
+		var salt [32]byte
+		copy(salt[:], note.Salt)
+		nullifier, err := types.CalculateNullifier(note.AccIndex, salt, note.LockingScript.ScriptCommitment, note.LockingScript.LockingParams...)
+		if err != nil {
+			return nil, err
+		}
+		staked[nullifier] = note
+	}
+	return staked, nil
+}
+
+func (w *stakeWatcher) unstake(note *walletpb.SpendNote) error {
+	note.Staked = false
+	ser, err := proto.Marshal(note)
+	if err != nil {
+		return err
+	}
+	commitment := types.NewID(note.Commitment)
+	return w.walletDs.Put(context.Background(), datastore.NewKey(walletlib.NotesDatastoreKeyPrefix+commitment.String()), ser)
+}
+
+func (w *stakeWatcher) remove(note *walletpb.SpendNote, nullifier types.Nullifier) error {
+	commitment := types.NewID(note.Commitment)
+	if err := w.walletDs.Delete(context.Background(), datastore.NewKey(walletlib.NotesDatastoreKeyPrefix+commitment.String())); err != nil {
+		return err
+	}
+	return w.walletDs.Delete(context.Background(), datastore.NewKey(walletlib.NullifierKeyPrefix+nullifier.String()))
+}
@@ -0,0 +1,369 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"github.com/project-illium/ilxd/params"
+	"github.com/project-illium/ilxd/repo"
+	"github.com/project-illium/ilxd/rpc/pb"
+	"github.com/project-illium/ilxd/types"
+	"github.com/project-illium/walletlib"
+	"google.golang.org/protobuf/proto"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultSweepInputsPerTx is the default maximum number of inputs
+	// in each transaction created by a sweep job.
+	DefaultSweepInputsPerTx = 20
+
+	// sweepJobResumeDelay is how long to wait after startup before
+	// resuming the running sweep jobs. This gives the wallet a chance
+	// to sync and be unlocked first.
+	sweepJobResumeDelay = time.Minute
+)
+
+var errSweepJobNotFound = errors.New("sweep job not found")
+
+// sweepJobManager runs the wallet's sweep jobs. A sweep of a large wallet
+// would take a very long time to prove as a single transaction so the
+// inputs are split into chunks and each chunk is swept in its own
+// transaction. The chunks spend disjoint inputs so they don't depend on
+// each other's outputs. They are proven one after another, and the job
+// is saved after each broadcast so it can pick up where it left off if
+// the node restarts.
+type sweepJobManager struct {
+	ds          repo.Datastore
+	wallet      *walletlib.Wallet
+	chainParams *params.NetworkParams
+	running     map[types.ID]chan struct{}
+	quit        chan struct{}
+	mtx         sync.Mutex
+}
+
+// newSweepJobManager returns a new sweepJobManager. Jobs which were
+// running when the node shut down are resumed after a delay.
+func newSweepJobManager(ds repo.Datastore, wallet *walletlib.Wallet, chainParams *params.NetworkParams, quit chan struct{}) (*sweepJobManager, error) {
+	m := &sweepJobManager{
+		ds:          ds,
+		wallet:      wallet,
+		chainParams: chainParams,
+		running:     make(map[types.ID]chan struct{}),
+		quit:        quit,
+	}
+	jobs, err := m.getJobs()
+	if err != nil {
+		return nil, err
+	}
+	var toResume []*pb.SweepJob
+	for _, job := range jobs {
+		if job.Status == pb.SweepJob_RUNNING {
+			toResume = append(toResume, job)
+		}
+	}
+	if len(toResume) > 0 {
+		go func() {
+			select {
+			case <-time.After(sweepJobResumeDelay):
+			case <-quit:
+				return
+			}
+			m.mtx.Lock()
+			defer m.mtx.Unlock()
+			for _, job := range toResume {
+				m.start(job)
+			}
+		}()
+	}
+	return m, nil
+}
+
+// StartJob creates and starts a new sweep job. If no commitments are
+// provided all the spendable coins in the wallet are swept.
+func (m *sweepJobManager) StartJob(toAddr string, feePerKB types.Amount, commitments [][]byte, maxInputs uint32) (*pb.SweepJob, error) {
+	if _, err := decodeAddress(toAddr, m.chainParams); err != nil {
+		return nil, err
+	}
+	if maxInputs == 0 {
+		maxInputs = DefaultSweepInputsPerTx
+	}
+	if len(commitments) == 0 {
+		notes, err := m.wallet.Notes()
+		if err != nil {
+			return nil, err
+		}
+		for _, note := range notes {
+			if note.WatchOnly || note.Staked || time.Unix(note.LockedUntil, 0).After(time.Now()) {
+				continue
+			}
+			commitments = append(commitments, note.Commitment)
+		}
+	}
+	if len(commitments) == 0 {
+		return nil, errors.New("no spendable utxos in wallet")
+	}
+
+	var id types.ID
+	if _, err := rand.Read(id[:]); err != nil {
+		return nil, err
+	}
+	job := &pb.SweepJob{
+		Job_ID:             id[:],
+		ToAddress:          toAddr,
+		FeePerKilobyte:     uint64(feePerKB),
+		MaxInputsPerTx:     maxInputs,
+		TotalInputs:        uint32(len(commitments)),
+		PendingCommitments: commitments,
+		Status:             pb.SweepJob_RUNNING,
+		Created:            time.Now().Unix(),
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if err := m.putJob(job); err != nil {
+		return nil, err
+	}
+	m.start(job)
+	return job, nil
+}
+
+// GetJobs returns all the sweep jobs sorted by creation time.
+func (m *sweepJobManager) GetJobs() ([]*pb.SweepJob, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.getJobs()
+}
+
+// CancelJob stops the job after the transaction it's proving, if any.
+func (m *sweepJobManager) CancelJob(id types.ID) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	job, err := m.getJob(id)
+	if err != nil {
+		return err
+	}
+	if job.Status != pb.SweepJob_RUNNING {
+		return errors.New("sweep job is not running")
+	}
+	if cancel, ok := m.running[id]; ok {
+		close(cancel)
+		delete(m.running, id)
+	}
+	job.Status = pb.SweepJob_CANCELED
+	return m.putJob(job)
+}
+
+// ResumeJob restarts a job which failed or was canceled.
+func (m *sweepJobManager) ResumeJob(id types.ID) (*pb.SweepJob, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	job, err := m.getJob(id)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status != pb.SweepJob_FAILED && job.Status != pb.SweepJob_CANCELED {
+		return nil, errors.New("only failed or canceled sweep jobs can be resumed")
+	}
+	job.Status = pb.SweepJob_RUNNING
+	job.Error = ""
+	if err := m.putJob(job); err != nil {
+		return nil, err
+	}
+	m.start(job)
+	return job, nil
+}
+
+// start runs the job in a new goroutine.
+//
+// The mutex must be held when calling this.
+func (m *sweepJobManager) start(job *pb.SweepJob) {
+	id := types.NewID(job.Job_ID)
+	if _, ok := m.running[id]; ok {
+		return
+	}
+	cancel := make(chan struct{})
+	m.running[id] = cancel
+	go m.run(id, cancel)
+}
+
+// run sweeps the pending commitments a chunk at a time until they have
+// all been swept, a transaction fails, or the job is canceled.
+func (m *sweepJobManager) run(id types.ID, cancel chan struct{}) {
+	defer func() {
+		m.mtx.Lock()
+		if m.running[id] == cancel {
+			delete(m.running, id)
+		}
+		m.mtx.Unlock()
+	}()
+
+	for {
+		select {
+		case <-cancel:
+			return
+		case <-m.quit:
+			return
+		default:
+		}
+
+		m.mtx.Lock()
+		job, err := m.getJob(id)
+		m.mtx.Unlock()
+		if err != nil {
+			return
+		}
+		if job.Status != pb.SweepJob_RUNNING {
+			return
+		}
+
+		chunk, err := m.nextChunk(job)
+		if err != nil {
+			m.finish(id, cancel, pb.SweepJob_FAILED, err)
+			return
+		}
+		if len(chunk) == 0 {
+			m.finish(id, cancel, pb.SweepJob_COMPLETED, nil)
+			return
+		}
+
+		addr, err := decodeAddress(job.ToAddress, m.chainParams)
+		if err != nil {
+			m.finish(id, cancel, pb.SweepJob_FAILED, err)
+			return
+		}
+		txid, err := m.wallet.SweepWallet(addr, types.Amount(job.FeePerKilobyte), chunk...)
+		if err != nil {
+			m.finish(id, cancel, pb.SweepJob_FAILED, err)
+			return
+		}
+
+		m.mtx.Lock()
+		job, err = m.getJob(id)
+		if err == nil {
+			job.Transaction_IDs = append(job.Transaction_IDs, txid[:])
+			job.PendingCommitments = removeCommitments(job.PendingCommitments, chunk)
+			err = m.putJob(job)
+		}
+		m.mtx.Unlock()
+		if err != nil {
+			return
+		}
+	}
+}
+
+// nextChunk returns the next set of commitments to sweep. Pending
+// commitments which are no longer in the wallet are skipped. This
+// happens when the node restarts after a transaction was
+// broadcast but before the job was saved, or when the coins were spent
+// by another transaction.
+func (m *sweepJobManager) nextChunk(job *pb.SweepJob) ([]types.ID, error) {
+	notes, err := m.wallet.Notes()
+	if err != nil {
+		return nil, err
+	}
+	var chunk []types.ID
+	for _, c := range job.PendingCommitments {
+		if findNote(notes, c) == nil {
+			continue
+		}
+		chunk = append(chunk, types.NewID(c))
+		if len(chunk) >= int(job.MaxInputsPerTx) {
+			break
+		}
+	}
+	return chunk, nil
+}
+
+// finish saves the final status of the job unless it was canceled
+// while the last transaction was being proven.
+func (m *sweepJobManager) finish(id types.ID, cancel chan struct{}, status pb.SweepJob_Status, jobErr error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if m.running[id] != cancel {
+		return
+	}
+	job, err := m.getJob(id)
+	if err != nil {
+		return
+	}
+	job.Status = status
+	if status == pb.SweepJob_COMPLETED {
+		job.PendingCommitments = nil
+	}
+	if jobErr != nil {
+		job.Error = jobErr.Error()
+	}
+	m.putJob(job)
+}
+
+func (m *sweepJobManager) putJob(job *pb.SweepJob) error {
+	ser, err := proto.Marshal(job)
+	if err != nil {
+		return err
+	}
+	return m.ds.Put(context.Background(), datastore.NewKey(repo.SweepJobDatastoreKeyPrefix+types.NewID(job.Job_ID).String()), ser)
+}
+
+func (m *sweepJobManager) getJob(id types.ID) (*pb.SweepJob, error) {
+	ser, err := m.ds.Get(context.Background(), datastore.NewKey(repo.SweepJobDatastoreKeyPrefix+id.String()))
+	if errors.Is(err, datastore.ErrNotFound) {
+		return nil, errSweepJobNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	job := new(pb.SweepJob)
+	if err := proto.Unmarshal(ser, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+func (m *sweepJobManager) getJobs() ([]*pb.SweepJob, error) {
+	results, err := m.ds.Query(context.Background(), query.Query{
+		Prefix: repo.SweepJobDatastoreKeyPrefix,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var jobs []*pb.SweepJob
+	for result, ok := results.NextSync(); ok; result, ok = results.NextSync() {
+		job := new(pb.SweepJob)
+		if err := proto.Unmarshal(result.Value, job); err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].Created < jobs[j].Created
+	})
+	return jobs, nil
+}
+
+func removeCommitments(commitments [][]byte, remove []types.ID) [][]byte {
+	remaining := make([][]byte, 0, len(commitments))
+	for _, c := range commitments {
+		found := false
+		for _, r := range remove {
+			if types.NewID(c) == r {
+				found = true
+				break
+			}
+		}
+		if !found {
+			remaining = append(remaining, c)
+		}
+	}
+	return remaining
+}
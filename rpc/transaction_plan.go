@@ -0,0 +1,197 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"github.com/project-illium/ilxd/rpc/pb"
+	"github.com/project-illium/ilxd/types"
+	"github.com/project-illium/walletlib"
+	walletpb "github.com/project-illium/walletlib/pb"
+	"time"
+)
+
+// planTransaction selects the inputs for a transaction paying the outputs
+// and returns what the transaction would look like. Nothing is proven or
+// broadcast.
+func (s *GrpcServer) planTransaction(outputs []*walletlib.RawOutput, commitments [][]byte, feePerKB types.Amount) (*pb.TransactionPlan, error) {
+	inputs := make([]*walletlib.RawInput, 0, len(commitments))
+	for _, c := range commitments {
+		inputs = append(inputs, &walletlib.RawInput{Commitment: c})
+	}
+	rawTx, err := s.wallet.CreateRawTransaction(inputs, outputs, true, feePerKB)
+	if err != nil {
+		return nil, err
+	}
+	notes, err := s.wallet.Notes()
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &pb.TransactionPlan{
+		Fee: rawTx.Tx.GetStandardTransaction().GetFee(),
+	}
+	totalIn := types.Amount(0)
+	for _, in := range rawTx.PrivateInputs {
+		// The salt is random so it identifies the note being spent.
+		for _, note := range notes {
+			if bytes.Equal(note.Salt, in.Salt[:]) {
+				plan.Inputs = append(plan.Inputs, &pb.TransactionPlan_Input{
+					Commitment: note.Commitment,
+					Amount:     note.Amount,
+				})
+				break
+			}
+		}
+		totalIn += in.Amount
+	}
+	totalOut := types.Amount(0)
+	for _, out := range outputs {
+		plan.Outputs = append(plan.Outputs, &pb.TransactionPlan_Output{
+			Address: out.Addr.String(),
+			Amount:  uint64(out.Amount),
+		})
+		totalOut += out.Amount
+	}
+	fee := types.Amount(plan.Fee)
+	if totalIn > totalOut+fee {
+		// The change address is left empty if the policy creates a new
+		// one so that planning doesn't use up addresses. It's picked
+		// when the plan is spent.
+		changeAddr, err := s.changeAddresses.previewAddress()
+		if err != nil {
+			return nil, err
+		}
+		changeAddrStr := ""
+		if changeAddr != nil {
+			changeAddrStr = changeAddr.String()
+		}
+		plan.Outputs = append(plan.Outputs, &pb.TransactionPlan_Output{
+			Address: changeAddrStr,
+			Amount:  uint64(totalIn - totalOut - fee),
+			Change:  true,
+		})
+	}
+	s.setProvingEstimate(plan)
+	return plan, nil
+}
+
+// planTimelock returns what a TimelockCoins transaction would look like.
+func (s *GrpcServer) planTimelock(amount types.Amount, lockUntil time.Time, commitments [][]byte, feePerKB types.Amount) (*pb.TransactionPlan, error) {
+	addr, err := s.wallet.TimelockedAddress(lockUntil)
+	if err != nil {
+		return nil, err
+	}
+	locktime := make([]byte, 8)
+	binary.BigEndian.PutUint64(locktime, uint64(lockUntil.Unix()))
+
+	return s.planTransaction([]*walletlib.RawOutput{
+		{
+			Addr:   addr,
+			Amount: amount,
+			State:  types.State{locktime},
+		},
+	}, commitments, feePerKB)
+}
+
+// planSweep returns what a SweepWallet transaction would look like. The
+// wallet spends every spendable utxo, or the provided ones, to a single
+// output and subtracts the fee from it.
+func (s *GrpcServer) planSweep(toAddr walletlib.Address, commitments [][]byte, feePerKB types.Amount) (*pb.TransactionPlan, error) {
+	if feePerKB == 0 {
+		// The wallet's default fee is the node's minimum fee.
+		feePerKB = s.policy.GetMinFeePerKilobyte()
+	}
+	notes, err := s.wallet.Notes()
+	if err != nil {
+		return nil, err
+	}
+	plan := &pb.TransactionPlan{}
+	totalIn := types.Amount(0)
+	for _, note := range notes {
+		if len(commitments) > 0 {
+			if !containsCommitment(commitments, note.Commitment) {
+				continue
+			}
+			if time.Unix(note.LockedUntil, 0).After(time.Now()) {
+				return nil, errors.New("input commitment is timelocked")
+			}
+		} else if note.WatchOnly || note.Staked || time.Unix(note.LockedUntil, 0).After(time.Now()) {
+			continue
+		}
+		plan.Inputs = append(plan.Inputs, &pb.TransactionPlan_Input{
+			Commitment: note.Commitment,
+			Amount:     note.Amount,
+		})
+		totalIn += types.Amount(note.Amount)
+	}
+	if len(plan.Inputs) == 0 {
+		return nil, errors.New("no spendable utxos in wallet")
+	}
+	fee := walletlib.ComputeFee(len(plan.Inputs), 1, feePerKB)
+	if totalIn <= fee {
+		return nil, errors.New("wallet balance is too small to cover the fee")
+	}
+	plan.Fee = uint64(fee)
+	plan.Outputs = []*pb.TransactionPlan_Output{
+		{
+			Address: toAddr.String(),
+			Amount:  uint64(totalIn - fee),
+		},
+	}
+	s.setProvingEstimate(plan)
+	return plan, nil
+}
+
+// setProvingEstimate sets the number of proofs the wallet will create
+// for the plan and the estimated time to create them. The plan is for a
+// single standard transaction so one proof covers all the inputs.
+func (s *GrpcServer) setProvingEstimate(plan *pb.TransactionPlan) {
+	plan.Proofs = 1
+	if s.provingTimeFunc != nil {
+		plan.EstimatedProvingTime = s.provingTimeFunc(len(plan.Inputs)).Milliseconds()
+	}
+}
+
+// planStake returns the utxos that would be staked. Stake transactions
+// have no outputs and pay no fee.
+func (s *GrpcServer) planStake(commitments [][]byte) (*pb.TransactionPlan, error) {
+	notes, err := s.wallet.Notes()
+	if err != nil {
+		return nil, err
+	}
+	plan := &pb.TransactionPlan{}
+	for _, c := range commitments {
+		note := findNote(notes, c)
+		if note == nil {
+			return nil, errors.New("commitment not found in wallet")
+		}
+		plan.Inputs = append(plan.Inputs, &pb.TransactionPlan_Input{
+			Commitment: note.Commitment,
+			Amount:     note.Amount,
+		})
+	}
+	return plan, nil
+}
+
+func findNote(notes []*walletpb.SpendNote, commitment []byte) *walletpb.SpendNote {
+	for _, note := range notes {
+		if bytes.Equal(note.Commitment, commitment) {
+			return note
+		}
+	}
+	return nil
+}
+
+func containsCommitment(commitments [][]byte, commitment []byte) bool {
+	for _, c := range commitments {
+		if bytes.Equal(c, commitment) {
+			return true
+		}
+	}
+	return false
+}
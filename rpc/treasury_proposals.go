@@ -0,0 +1,399 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"github.com/project-illium/ilxd/blockchain"
+	icrypto "github.com/project-illium/ilxd/crypto"
+	"github.com/project-illium/ilxd/repo"
+	"github.com/project-illium/ilxd/rpc/pb"
+	"github.com/project-illium/ilxd/types"
+	"github.com/project-illium/ilxd/types/blocks"
+	"github.com/project-illium/ilxd/types/transactions"
+	"github.com/project-illium/ilxd/zk"
+	"github.com/project-illium/ilxd/zk/circparams"
+	"github.com/project-illium/walletlib"
+	"google.golang.org/protobuf/proto"
+	"sort"
+	"sync"
+	"time"
+)
+
+var errTreasuryProposalNotFound = errors.New("treasury proposal not found")
+
+// treasuryProposalStore saves the treasury proposals created or imported
+// by this node. Proposals are marked confirmed when their transaction is
+// seen in a block.
+type treasuryProposalStore struct {
+	ds  repo.Datastore
+	mtx sync.Mutex
+}
+
+func newTreasuryProposalStore(ds repo.Datastore) *treasuryProposalStore {
+	return &treasuryProposalStore{ds: ds}
+}
+
+// Put saves the proposal, overwriting any existing proposal with the
+// same ID.
+func (ts *treasuryProposalStore) Put(proposal *pb.TreasuryProposal) error {
+	ts.mtx.Lock()
+	defer ts.mtx.Unlock()
+	return ts.put(proposal)
+}
+
+// Get returns the proposal with the ID.
+func (ts *treasuryProposalStore) Get(id types.ID) (*pb.TreasuryProposal, error) {
+	ts.mtx.Lock()
+	defer ts.mtx.Unlock()
+	return ts.get(id)
+}
+
+// List returns the proposals sorted by creation time.
+func (ts *treasuryProposalStore) List(includeConfirmed bool) ([]*pb.TreasuryProposal, error) {
+	ts.mtx.Lock()
+	defer ts.mtx.Unlock()
+
+	results, err := ts.ds.Query(context.Background(), query.Query{
+		Prefix: repo.TreasuryProposalDatastoreKeyPrefix,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var proposals []*pb.TreasuryProposal
+	for result, ok := results.NextSync(); ok; result, ok = results.NextSync() {
+		proposal := new(pb.TreasuryProposal)
+		if err := proto.Unmarshal(result.Value, proposal); err != nil {
+			return nil, err
+		}
+		if proposal.Status == pb.TreasuryProposal_CONFIRMED && !includeConfirmed {
+			continue
+		}
+		proposals = append(proposals, proposal)
+	}
+	sort.Slice(proposals, func(i, j int) bool {
+		return proposals[i].Created < proposals[j].Created
+	})
+	return proposals, nil
+}
+
+// ProcessBlock marks any proposals whose transaction is in the block
+// as confirmed.
+func (ts *treasuryProposalStore) ProcessBlock(blk *blocks.Block) {
+	ts.mtx.Lock()
+	defer ts.mtx.Unlock()
+
+	for _, tx := range blk.Transactions {
+		if tx.GetTreasuryTransaction() == nil {
+			continue
+		}
+		proposal, err := ts.get(tx.ID())
+		if err != nil {
+			continue
+		}
+		proposal.Status = pb.TreasuryProposal_CONFIRMED
+		ts.put(proposal) //nolint:errcheck
+	}
+}
+
+func (ts *treasuryProposalStore) put(proposal *pb.TreasuryProposal) error {
+	ser, err := proto.Marshal(proposal)
+	if err != nil {
+		return err
+	}
+	return ts.ds.Put(context.Background(), datastore.NewKey(repo.TreasuryProposalDatastoreKeyPrefix+types.NewID(proposal.Proposal_ID).String()), ser)
+}
+
+func (ts *treasuryProposalStore) get(id types.ID) (*pb.TreasuryProposal, error) {
+	ser, err := ts.ds.Get(context.Background(), datastore.NewKey(repo.TreasuryProposalDatastoreKeyPrefix+id.String()))
+	if errors.Is(err, datastore.ErrNotFound) {
+		return nil, errTreasuryProposalNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	proposal := new(pb.TreasuryProposal)
+	if err := proto.Unmarshal(ser, proposal); err != nil {
+		return nil, err
+	}
+	return proposal, nil
+}
+
+// buildTreasuryProposal creates and proves a treasury transaction paying
+// the outputs.
+func (s *GrpcServer) buildTreasuryProposal(outputs []*pb.CreateTreasuryProposalRequest_Output, proposalHash []byte, description string) (*pb.TreasuryProposal, error) {
+	if len(outputs) == 0 {
+		return nil, errors.New("no outputs")
+	}
+	if len(proposalHash) > blockchain.MaxDocumentHashLen {
+		return nil, errors.New("proposal hash is too long")
+	}
+
+	treasuryTx := &transactions.TreasuryTransaction{
+		ProposalHash: proposalHash,
+	}
+	var (
+		rawTx         = &pb.RawTransaction{}
+		privateParams = make(circparams.TreasuryPrivateParams, 0, len(outputs))
+		addrs         = make([]string, 0, len(outputs))
+	)
+	for _, out := range outputs {
+		if out.Amount == 0 {
+			return nil, errors.New("output amount must be greater than zero")
+		}
+		addr, err := decodeAddress(out.Address, s.chainParams)
+		if err != nil {
+			return nil, err
+		}
+		txOut, privOut, err := buildTreasuryOutput(addr, types.Amount(out.Amount))
+		if err != nil {
+			return nil, err
+		}
+		state, err := privOut.State.Serialize(true)
+		if err != nil {
+			return nil, err
+		}
+		treasuryTx.Amount += out.Amount
+		treasuryTx.Outputs = append(treasuryTx.Outputs, txOut)
+		privateParams = append(privateParams, privOut)
+		rawTx.Outputs = append(rawTx.Outputs, &pb.PrivateOutput{
+			ScriptHash: privOut.ScriptHash.Bytes(),
+			Amount:     uint64(privOut.Amount),
+			Salt:       privOut.Salt.Bytes(),
+			Asset_ID:   privOut.AssetID.Bytes(),
+			State:      state,
+		})
+		addrs = append(addrs, addr.String())
+	}
+
+	balance, err := s.chain.TreasuryBalance()
+	if err != nil {
+		return nil, err
+	}
+	if types.Amount(treasuryTx.Amount) > balance {
+		return nil, fmt.Errorf("withdrawal of %d exceeds the treasury balance of %d", treasuryTx.Amount, balance)
+	}
+
+	publicParams, err := treasuryTx.ToCircuitParams()
+	if err != nil {
+		return nil, err
+	}
+	treasuryTx.Proof, err = s.prover.Prove(zk.TreasuryValidationProgram(), &privateParams, publicParams)
+	if err != nil {
+		return nil, err
+	}
+	rawTx.Tx = transactions.WrapTransaction(treasuryTx)
+	txid := rawTx.Tx.ID()
+
+	return &pb.TreasuryProposal{
+		Proposal_ID:     txid[:],
+		Description:     description,
+		RawTx:           rawTx,
+		OutputAddresses: addrs,
+		Status:          pb.TreasuryProposal_DRAFT,
+		Created:         time.Now().Unix(),
+	}, nil
+}
+
+// checkTreasuryProposal checks that each of the proposal's outputs pays the
+// listed address the listed amount and that the treasury can cover the
+// withdrawal. The proof is not checked here. It's validated along with the
+// rest of the transaction when it's submitted to the mempool.
+func (s *GrpcServer) checkTreasuryProposal(proposal *pb.TreasuryProposal) error {
+	if proposal.RawTx == nil || proposal.RawTx.Tx == nil {
+		return errors.New("proposal is missing the transaction")
+	}
+	treasuryTx := proposal.RawTx.Tx.GetTreasuryTransaction()
+	if treasuryTx == nil {
+		return errors.New("proposal transaction is not a treasury transaction")
+	}
+	if !bytes.Equal(proposal.Proposal_ID, proposal.RawTx.Tx.ID().Bytes()) {
+		return errors.New("proposal ID does not match the transaction ID")
+	}
+	if len(treasuryTx.Outputs) == 0 {
+		return errors.New("proposal has no outputs")
+	}
+	if len(proposal.RawTx.Outputs) != len(treasuryTx.Outputs) || len(proposal.OutputAddresses) != len(treasuryTx.Outputs) {
+		return errors.New("proposal must include the private data and address for each output")
+	}
+
+	total := types.Amount(0)
+	for i, out := range treasuryTx.Outputs {
+		priv := proposal.RawTx.Outputs[i]
+		state := new(types.State)
+		if err := state.Deserialize(priv.State); err != nil {
+			return fmt.Errorf("output %d: %w", i, err)
+		}
+		note := types.SpendNote{
+			ScriptHash: types.NewID(priv.ScriptHash),
+			Amount:     types.Amount(priv.Amount),
+			AssetID:    types.NewID(priv.Asset_ID),
+			Salt:       types.NewID(priv.Salt),
+			State:      *state,
+		}
+		if note.AssetID != types.IlliumCoinID {
+			return fmt.Errorf("output %d does not pay illium", i)
+		}
+		commitment, err := note.Commitment()
+		if err != nil {
+			return fmt.Errorf("output %d: %w", i, err)
+		}
+		if !bytes.Equal(commitment.Bytes(), out.Commitment) {
+			return fmt.Errorf("output %d commitment does not match the private output data", i)
+		}
+		addr, err := decodeAddress(proposal.OutputAddresses[i], s.chainParams)
+		if err != nil {
+			return fmt.Errorf("output %d: %w", i, err)
+		}
+		if !outputPaysAddress(note, addr) {
+			return fmt.Errorf("output %d does not pay address %s", i, proposal.OutputAddresses[i])
+		}
+		total += note.Amount
+	}
+	if total != types.Amount(treasuryTx.Amount) {
+		return errors.New("output amounts do not add up to the withdrawal amount")
+	}
+
+	balance, err := s.chain.TreasuryBalance()
+	if err != nil {
+		return err
+	}
+	if types.Amount(treasuryTx.Amount) > balance {
+		return fmt.Errorf("withdrawal of %d exceeds the treasury balance of %d", treasuryTx.Amount, balance)
+	}
+	return nil
+}
+
+// collectTreasuryApprovals queries each validator for its treasury whitelist
+// and returns the validators which have whitelisted the transaction.
+func (s *GrpcServer) collectTreasuryApprovals(txid types.ID) *pb.TreasuryApprovals {
+	var (
+		approvals = &pb.TreasuryApprovals{}
+		wg        sync.WaitGroup
+		mtx       sync.Mutex
+	)
+	for _, val := range s.chain.Validators() {
+		approvals.TotalStake += uint64(val.WeightedStake)
+
+		wg.Add(1)
+		go func(val *blockchain.Validator) {
+			defer wg.Done()
+
+			var (
+				whitelist []types.ID
+				err       error
+			)
+			if val.PeerID == s.network.Host().ID() {
+				whitelist = s.policy.GetTreasuryWhitelist()
+			} else if s.treasuryWhitelistFunc != nil {
+				whitelist, err = s.treasuryWhitelistFunc(val.PeerID)
+			} else {
+				err = errors.New("policy service unavailable")
+			}
+
+			mtx.Lock()
+			defer mtx.Unlock()
+			if err != nil {
+				approvals.Unreachable++
+				return
+			}
+			for _, id := range whitelist {
+				if id == txid {
+					approvals.Validator_IDs = append(approvals.Validator_IDs, val.PeerID.String())
+					approvals.ApprovedStake += uint64(val.WeightedStake)
+					break
+				}
+			}
+		}(val)
+	}
+	wg.Wait()
+
+	sort.Strings(approvals.Validator_IDs)
+	approvals.Collected = time.Now().Unix()
+	return approvals
+}
+
+// serializeTreasuryProposal serializes the proposal for review. The
+// fields which are local to this node are left out.
+func serializeTreasuryProposal(proposal *pb.TreasuryProposal) ([]byte, error) {
+	cpy := proto.Clone(proposal).(*pb.TreasuryProposal)
+	cpy.Status = pb.TreasuryProposal_DRAFT
+	cpy.Created = 0
+	cpy.Approvals = nil
+	return proto.Marshal(cpy)
+}
+
+// buildTreasuryOutput creates the output and its private data paying the
+// amount to the address.
+func buildTreasuryOutput(addr walletlib.Address, amount types.Amount) (*transactions.Output, circparams.PrivateOutput, error) {
+	salt, err := types.RandomSalt()
+	if err != nil {
+		return nil, circparams.PrivateOutput{}, err
+	}
+	note := types.SpendNote{
+		Amount:  amount,
+		AssetID: types.IlliumCoinID,
+		Salt:    salt,
+	}
+
+	var ciphertext []byte
+	switch a := addr.(type) {
+	case *walletlib.BasicAddress:
+		note.ScriptHash = a.ScriptHash()
+		ser, err := note.Serialize()
+		if err != nil {
+			return nil, circparams.PrivateOutput{}, err
+		}
+		viewKey, ok := a.ViewKey().(*icrypto.Curve25519PublicKey)
+		if !ok {
+			return nil, circparams.PrivateOutput{}, errors.New("address view key is not curve25519")
+		}
+		ciphertext, err = viewKey.Encrypt(ser)
+		if err != nil {
+			return nil, circparams.PrivateOutput{}, err
+		}
+	case *walletlib.PublicAddress, *walletlib.ExchangeAddress:
+		scriptHash := a.ScriptHash()
+		note.ScriptHash = types.NewID(zk.PublicAddressScriptHash())
+		note.State = types.State{scriptHash[:]}
+		ciphertext, err = note.ToPublicCiphertext()
+		if err != nil {
+			return nil, circparams.PrivateOutput{}, err
+		}
+	default:
+		return nil, circparams.PrivateOutput{}, errors.New("unknown address type")
+	}
+
+	commitment, err := note.Commitment()
+	if err != nil {
+		return nil, circparams.PrivateOutput{}, err
+	}
+	return &transactions.Output{
+		Commitment: commitment[:],
+		Ciphertext: ciphertext,
+	}, circparams.PrivateOutput{
+		ScriptHash: note.ScriptHash,
+		Amount:     note.Amount,
+		AssetID:    note.AssetID,
+		Salt:       note.Salt,
+		State:      note.State,
+	}, nil
+}
+
+// outputPaysAddress returns whether the note is spendable by the address.
+func outputPaysAddress(note types.SpendNote, addr walletlib.Address) bool {
+	scriptHash := addr.ScriptHash()
+	switch addr.(type) {
+	case *walletlib.PublicAddress, *walletlib.ExchangeAddress:
+		return note.ScriptHash == types.NewID(zk.PublicAddressScriptHash()) &&
+			len(note.State) == 1 && bytes.Equal(note.State[0], scriptHash[:])
+	default:
+		return note.ScriptHash == scriptHash
+	}
+}
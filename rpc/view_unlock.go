@@ -0,0 +1,304 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/ipfs/go-datastore"
+	"github.com/project-illium/ilxd/crypto"
+	"github.com/project-illium/ilxd/repo"
+	"github.com/project-illium/ilxd/rpc/pb"
+	"github.com/project-illium/walletlib"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strings"
+	"sync"
+	"time"
+)
+
+// addressPoolSize is the number of addresses generated ahead of time so
+// that GetNewAddress can hand them out while the wallet is view unlocked.
+// New addresses are derived from the seed so they can't be generated
+// without the full passphrase.
+const addressPoolSize = 20
+
+// viewPassphraseCheck is encrypted with the view passphrase and stored so
+// the passphrase can be checked without storing it. If the view passphrase
+// is empty noViewPassphrase is stored instead so the view methods can be
+// served without running the key derivation on each call.
+var (
+	viewPassphraseCheck = []byte("ilxd view passphrase")
+	noViewPassphrase    = []byte("none")
+)
+
+var errViewLocked = status.Error(codes.PermissionDenied, "wallet is locked, unlock it with the view passphrase or the full passphrase")
+var errFullPassphraseRequired = status.Error(codes.PermissionDenied, "method requires the wallet to be unlocked with the full passphrase")
+
+// walletViewMethods are the wallet service methods that may be called while
+// the wallet is unlocked with the view passphrase. They only read the
+// wallet's balance and history or hand out addresses.
+var walletViewMethods = map[string]bool{
+	"GetBalance":                       true,
+	"GetAddress":                       true,
+	"GetPublicAddress":                 true,
+	"GetAddresses":                     true,
+	"GetAddressInfo":                   true,
+	"GetAddressInfoBatch":              true,
+	"ConvertAddresses":                 true,
+	"GetNewAddress":                    true,
+	"GetTransactions":                  true,
+	"GetUtxos":                         true,
+	"VerifyMessage":                    true,
+	"GetInvoiceStatus":                 true,
+	"GetAddressesByLabel":              true,
+	"GetSweepJobs":                     true,
+	"GetSwaps":                         true,
+	"GetSpendTemplates":                true,
+	"ListContacts":                     true,
+	"GetRescanStatus":                  true,
+	"SubscribeWalletTransactions":      true,
+	"SubscribeTransactionEvents":       true,
+	"SubscribeWalletSyncNotifications": true,
+}
+
+// walletUnlockMethods may always be called so that the wallet can be
+// unlocked and locked.
+var walletUnlockMethods = map[string]bool{
+	"WalletLock":       true,
+	"WalletUnlock":     true,
+	"WalletViewUnlock": true,
+}
+
+// viewUnlock tracks the view only unlock tier. Once a view passphrase is
+// set, the wallet service only serves the view methods while the wallet
+// is unlocked with either passphrase, and every other method requires the
+// full passphrase. The view passphrase may be empty, in which case the
+// view methods are always served.
+type viewUnlock struct {
+	ds      repo.Datastore
+	expires time.Time
+	mtx     sync.Mutex
+}
+
+func newViewUnlock(ds repo.Datastore) *viewUnlock {
+	return &viewUnlock{ds: ds}
+}
+
+// check returns the encrypted check value for the view passphrase or
+// nil if no view passphrase has been set.
+func (v *viewUnlock) check() ([]byte, error) {
+	ciphertext, err := v.ds.Get(context.Background(), datastore.NewKey(repo.ViewPassphraseDatastoreKey))
+	if errors.Is(err, datastore.ErrNotFound) {
+		return nil, nil
+	}
+	return ciphertext, err
+}
+
+func (v *viewUnlock) setPassphrase(passphrase string) error {
+	ciphertext := noViewPassphrase
+	if passphrase != "" {
+		var err error
+		ciphertext, err = crypto.EncryptWithPassphrase(viewPassphraseCheck, passphrase)
+		if err != nil {
+			return err
+		}
+	}
+	return v.ds.Put(context.Background(), datastore.NewKey(repo.ViewPassphraseDatastoreKey), ciphertext)
+}
+
+func (v *viewUnlock) removePassphrase() error {
+	v.lock()
+	if err := v.ds.Delete(context.Background(), datastore.NewKey(repo.ViewPassphraseDatastoreKey)); err != nil {
+		return err
+	}
+	return v.ds.Delete(context.Background(), datastore.NewKey(repo.AddressPoolDatastoreKey))
+}
+
+// unlock unlocks the view tier for the duration if the passphrase
+// matches the view passphrase.
+func (v *viewUnlock) unlock(passphrase string, duration time.Duration) error {
+	ciphertext, err := v.check()
+	if err != nil {
+		return err
+	}
+	if ciphertext == nil {
+		return errors.New("no view passphrase is set")
+	}
+	if !bytes.Equal(ciphertext, noViewPassphrase) {
+		if _, err := crypto.DecryptWithPassphrase(ciphertext, passphrase); err != nil {
+			return err
+		}
+	}
+
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+	v.expires = time.Now().Add(duration)
+	return nil
+}
+
+func (v *viewUnlock) lock() {
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+	v.expires = time.Time{}
+}
+
+func (v *viewUnlock) unlocked() bool {
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+	return time.Now().Before(v.expires)
+}
+
+// fillAddressPool generates addresses until the pool is full. It's a
+// no-op if the wallet is locked.
+func (v *viewUnlock) fillAddressPool(wallet *walletlib.Wallet) error {
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+
+	pool, err := v.addressPool()
+	if err != nil {
+		return err
+	}
+	for len(pool) < addressPoolSize {
+		addr, err := wallet.NewAddress()
+		if errors.Is(err, walletlib.ErrEncryptedKeychain) || errors.Is(err, walletlib.ErrPublicOnlyKeychain) {
+			break
+		} else if err != nil {
+			return err
+		}
+		pool = append(pool, addr.String())
+	}
+	return v.putAddressPool(pool)
+}
+
+// popAddress removes the oldest address from the pool and returns it.
+func (v *viewUnlock) popAddress() (string, error) {
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+
+	pool, err := v.addressPool()
+	if err != nil {
+		return "", err
+	}
+	if len(pool) == 0 {
+		return "", errors.New("address pool is empty, unlock the wallet with the full passphrase to refill it")
+	}
+	if err := v.putAddressPool(pool[1:]); err != nil {
+		return "", err
+	}
+	return pool[0], nil
+}
+
+func (v *viewUnlock) addressPool() ([]string, error) {
+	ser, err := v.ds.Get(context.Background(), datastore.NewKey(repo.AddressPoolDatastoreKey))
+	if errors.Is(err, datastore.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if len(ser) == 0 {
+		return nil, nil
+	}
+	return strings.Split(string(ser), "\n"), nil
+}
+
+func (v *viewUnlock) putAddressPool(addrs []string) error {
+	return v.ds.Put(context.Background(), datastore.NewKey(repo.AddressPoolDatastoreKey), []byte(strings.Join(addrs, "\n")))
+}
+
+// CheckWalletAccess returns an error if the method may not be called
+// because of the view only unlock tier. Methods outside the wallet service
+// are always allowed.
+func (s *GrpcServer) CheckWalletAccess(fullMethod string) error {
+	if s.viewUnlock == nil || !strings.HasPrefix(fullMethod, walletServicePrefix) {
+		return nil
+	}
+	method := strings.TrimPrefix(fullMethod, walletServicePrefix)
+	if walletUnlockMethods[method] || !s.walletLocked() {
+		return nil
+	}
+	ciphertext, err := s.viewUnlock.check()
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	if ciphertext == nil {
+		return nil
+	}
+	if !walletViewMethods[method] {
+		return errFullPassphraseRequired
+	}
+	if bytes.Equal(ciphertext, noViewPassphrase) || s.viewUnlock.unlocked() {
+		return nil
+	}
+	return errViewLocked
+}
+
+// newAddress returns a new wallet address. If the wallet is locked and a
+// view passphrase is set the address is taken from the pool generated
+// ahead of time.
+func (s *GrpcServer) newAddress() (string, error) {
+	addr, err := s.wallet.NewAddress()
+	if err == nil {
+		return addr.String(), nil
+	}
+	if !errors.Is(err, walletlib.ErrEncryptedKeychain) || s.viewUnlock == nil {
+		return "", err
+	}
+	ciphertext, cerr := s.viewUnlock.check()
+	if cerr != nil {
+		return "", cerr
+	}
+	if ciphertext == nil {
+		return "", err
+	}
+	return s.viewUnlock.popAddress()
+}
+
+// walletLocked returns whether the wallet's seed is encrypted.
+func (s *GrpcServer) walletLocked() bool {
+	_, err := s.wallet.MnemonicSeed()
+	return errors.Is(err, walletlib.ErrEncryptedKeychain)
+}
+
+// SetWalletViewPassphrase sets the passphrase for the view only unlock
+// tier. Unlocking the wallet with it using WalletViewUnlock permits balance
+// and history queries and address generation, while spending and every
+// other method still require the full passphrase. An empty passphrase
+// permits them without unlocking. Addresses are generated ahead of time for
+// GetNewAddress to hand out while the wallet is view unlocked, and the pool
+// is refilled whenever the wallet is unlocked with the full passphrase.
+//
+// **Requires wallet to be unlocked**
+func (s *GrpcServer) SetWalletViewPassphrase(ctx context.Context, req *pb.SetWalletViewPassphraseRequest) (*pb.SetWalletViewPassphraseResponse, error) {
+	if s.walletLocked() {
+		return nil, status.Error(codes.PermissionDenied, walletlib.ErrEncryptedKeychain.Error())
+	}
+	if req.Remove {
+		if err := s.viewUnlock.removePassphrase(); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		return &pb.SetWalletViewPassphraseResponse{}, nil
+	}
+	if err := s.viewUnlock.setPassphrase(req.ViewPassphrase); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if err := s.viewUnlock.fillAddressPool(s.wallet); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.SetWalletViewPassphraseResponse{}, nil
+}
+
+// WalletViewUnlock unlocks the wallet's view only tier for the specified
+// period of time.
+func (s *GrpcServer) WalletViewUnlock(ctx context.Context, req *pb.WalletViewUnlockRequest) (*pb.WalletViewUnlockResponse, error) {
+	err := s.viewUnlock.unlock(req.Passphrase, time.Second*time.Duration(req.Duration))
+	if errors.Is(err, crypto.ErrPassphraseDecryption) {
+		return nil, status.Error(codes.PermissionDenied, "invalid view passphrase")
+	} else if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return &pb.WalletViewUnlockResponse{}, nil
+}
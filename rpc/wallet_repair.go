@@ -0,0 +1,136 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"context"
+	"github.com/ipfs/go-datastore"
+	"github.com/project-illium/ilxd/blockchain"
+	"github.com/project-illium/ilxd/rpc/pb"
+	"github.com/project-illium/ilxd/types"
+	"github.com/project-illium/walletlib"
+	walletpb "github.com/project-illium/walletlib/pb"
+	"google.golang.org/protobuf/proto"
+	"strings"
+)
+
+// repairWallet checks each of the wallet's notes against the accumulator
+// and the nullifier set. Notes that are not in the accumulator, or that
+// have already been spent, are removed. Metadata that can be derived from
+// the wallet's addresses or the inclusion proof is filled in. If dryRun is
+// true nothing is written.
+func (s *GrpcServer) repairWallet(dryRun bool) (*pb.RepairWalletResponse, error) {
+	notes, err := s.wallet.Notes()
+	if err != nil {
+		return nil, err
+	}
+	addrs, err := s.wallet.Addresses()
+	if err != nil {
+		return nil, err
+	}
+	addrsByScriptHash := make(map[types.ID]walletlib.Address)
+	for _, addr := range addrs {
+		scriptHash := addr.ScriptHash()
+		addrsByScriptHash[types.NewID(scriptHash[:])] = addr
+	}
+
+	resp := &pb.RepairWalletResponse{
+		Checked: uint32(len(notes)),
+	}
+	remove := func(note *walletpb.SpendNote, nullifier *types.Nullifier, reason string) error {
+		resp.Actions = append(resp.Actions, &pb.RepairWalletResponse_Action{
+			Commitment: note.Commitment,
+			Type:       pb.RepairWalletResponse_Action_REMOVED,
+			Reason:     reason,
+		})
+		if dryRun {
+			return nil
+		}
+		commitment := types.NewID(note.Commitment)
+		if err := s.walletDs.Delete(context.Background(), datastore.NewKey(walletlib.NotesDatastoreKeyPrefix+commitment.String())); err != nil {
+			return err
+		}
+		if nullifier != nil {
+			return s.walletDs.Delete(context.Background(), datastore.NewKey(walletlib.NullifierKeyPrefix+nullifier.String()))
+		}
+		return nil
+	}
+
+	for _, note := range notes {
+		commitment := types.NewID(note.Commitment)
+		proofs, root, err := s.wallet.GetInclusionProofs(commitment)
+		if err != nil {
+			if err := remove(note, nil, "commitment not found in the accumulator"); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		valid, err := blockchain.ValidateInclusionProof(note.Commitment, proofs[0].Index, proofs[0].Hashes, proofs[0].Flags, root[:])
+		if err != nil || !valid {
+			if err := remove(note, nil, "invalid inclusion proof"); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		var updated []string
+		if note.AccIndex != proofs[0].Index {
+			note.AccIndex = proofs[0].Index
+			updated = append(updated, "accumulator index")
+		}
+		if addr, ok := addrsByScriptHash[types.NewID(note.ScriptHash)]; ok {
+			if note.Address != addr.String() {
+				note.Address = addr.String()
+				updated = append(updated, "address")
+			}
+			if note.LockingScript == nil {
+				info, err := s.wallet.AddressInfo(addr)
+				if err != nil {
+					return nil, err
+				}
+				note.LockingScript = info.LockingScript
+				updated = append(updated, "locking script")
+			}
+		}
+
+		if note.LockingScript != nil {
+			var salt [32]byte
+			copy(salt[:], note.Salt)
+			nullifier, err := types.CalculateNullifier(note.AccIndex, salt, note.LockingScript.ScriptCommitment, note.LockingScript.LockingParams...)
+			if err != nil {
+				return nil, err
+			}
+			spent, err := s.chain.NullifierExists(nullifier)
+			if err != nil {
+				return nil, err
+			}
+			if spent {
+				if err := remove(note, &nullifier, "nullifier is spent"); err != nil {
+					return nil, err
+				}
+				continue
+			}
+		}
+
+		if len(updated) > 0 {
+			resp.Actions = append(resp.Actions, &pb.RepairWalletResponse_Action{
+				Commitment: note.Commitment,
+				Type:       pb.RepairWalletResponse_Action_UPDATED,
+				Reason:     "restored " + strings.Join(updated, ", "),
+			})
+			if dryRun {
+				continue
+			}
+			ser, err := proto.Marshal(note)
+			if err != nil {
+				return nil, err
+			}
+			if err := s.walletDs.Put(context.Background(), datastore.NewKey(walletlib.NotesDatastoreKeyPrefix+commitment.String()), ser); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import "strings"
+
+const walletServicePrefix = "/pb.WalletService/"
+
+// walletReplicaMethods are the wallet service methods served when the node
+// is running as a wallet read replica. The replica's wallet only watches the
+// addresses imported from the writer, so methods that sign, spend, change
+// the keys, or hand out the replica's own receiving addresses are left out.
+var walletReplicaMethods = map[string]bool{
+	"GetBalance":                       true,
+	"GetAddresses":                     true,
+	"GetAddressInfo":                   true,
+	"GetAddressInfoBatch":              true,
+	"ConvertAddresses":                 true,
+	"GetTransactions":                  true,
+	"GetUtxos":                         true,
+	"VerifyMessage":                    true,
+	"GetInvoiceStatus":                 true,
+	"SetCustomerID":                    true,
+	"SetAddressLabel":                  true,
+	"GetAddressesByLabel":              true,
+	"ImportAddress":                    true,
+	"ImportAddresses":                  true,
+	"ExportWatchOnly":                  true,
+	"ExportDescriptor":                 true,
+	"ImportDescriptor":                 true,
+	"SubscribeWalletTransactions":      true,
+	"SubscribeTransactionEvents":       true,
+	"SubscribeWalletSyncNotifications": true,
+	"GetRescanStatus":                  true,
+}
+
+// WalletReplicaAllows returns whether the method may be called when the
+// wallet service is running as a read replica. Methods outside the wallet
+// service are always allowed.
+func WalletReplicaAllows(fullMethod string) bool {
+	if !strings.HasPrefix(fullMethod, walletServicePrefix) {
+		return true
+	}
+	return walletReplicaMethods[strings.TrimPrefix(fullMethod, walletServicePrefix)]
+}
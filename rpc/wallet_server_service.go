@@ -10,6 +10,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/project-illium/ilxd/blockchain"
 	icrypto "github.com/project-illium/ilxd/crypto"
+	"github.com/project-illium/ilxd/recovery"
 	"github.com/project-illium/ilxd/rpc/pb"
 	"github.com/project-illium/ilxd/types"
 	"github.com/project-illium/ilxd/types/transactions"
@@ -50,7 +51,10 @@ func (s *GrpcServer) RegisterViewKey(ctx context.Context, req *pb.RegisterViewKe
 			return nil, status.Error(codes.Internal, err.Error())
 		}
 
-		go s.wsIndex.RescanViewkey(s.ds, viewKey, checkpoint, height, s.chain.GetBlockByHeight)
+		go func() {
+			defer recovery.HandlePanic("wallet-rescan")
+			s.wsIndex.RescanViewkey(s.ds, viewKey, checkpoint, height, s.chain.GetBlockByHeight) //nolint:errcheck
+		}()
 	}
 
 	return &pb.RegisterViewKeyResponse{}, nil
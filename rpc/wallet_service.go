@@ -5,21 +5,30 @@
 package rpc
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"github.com/ipfs/go-datastore"
 	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/project-illium/ilxd/blockchain"
 	icrypto "github.com/project-illium/ilxd/crypto"
+	"github.com/project-illium/ilxd/invoice"
 	"github.com/project-illium/ilxd/types"
+	"github.com/project-illium/ilxd/types/blocks"
 	"github.com/project-illium/ilxd/types/transactions"
 	"github.com/project-illium/ilxd/zk"
 	"github.com/project-illium/ilxd/zk/circparams"
 	"github.com/project-illium/walletlib"
+	walletpb "github.com/project-illium/walletlib/pb"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"io"
+	"sort"
 	"time"
 
 	pb "github.com/project-illium/ilxd/rpc/pb"
@@ -31,9 +40,35 @@ func (s *GrpcServer) GetBalance(ctx context.Context, req *pb.GetBalanceRequest)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
-	return &pb.GetBalanceResponse{
+	resp := &pb.GetBalanceResponse{
 		Balance: uint64(balance),
-	}, nil
+	}
+	snap := s.chain.Snapshot()
+	if validator, err := snap.GetValidator(s.network.Host().ID()); err == nil {
+		if _, height, _ := snap.BestBlock(); height+1 < snap.RewardsMatureAt() {
+			resp.Immature = uint64(validator.UnclaimedCoins)
+		}
+	}
+	if req.Breakdown {
+		notes, err := s.wallet.Notes()
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		now := time.Now()
+		for _, note := range notes {
+			switch {
+			case note.WatchOnly:
+				resp.WatchOnly += note.Amount
+			case note.Staked:
+				resp.Staked += note.Amount
+			case time.Unix(note.LockedUntil, 0).After(now):
+				resp.Timelocked += note.Amount
+			default:
+				resp.Spendable += note.Amount
+			}
+		}
+	}
+	return resp, nil
 }
 
 // GetWalletSeed returns the mnemonic seed for the wallet. If the wallet
@@ -58,13 +93,29 @@ func (s *GrpcServer) GetWalletSeed(ctx context.Context, req *pb.GetWalletSeedReq
 }
 
 // GetAddress returns the most recent address of the wallet.
+//
+// Change addresses are never returned. If the most recent address is
+// used for change a new address is returned instead.
 func (s *GrpcServer) GetAddress(ctx context.Context, req *pb.GetAddressRequest) (*pb.GetAddressResponse, error) {
 	addr, err := s.wallet.Address()
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
+	addrStr := addr.String()
+	if s.changePolicy.AddressPolicy != ChangeAddressReuse {
+		isChange, err := s.changeAddresses.isChange(addrStr)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if isChange {
+			addrStr, err = s.newAddress()
+			if err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+		}
+	}
 	return &pb.GetAddressResponse{
-		Address: addr.String(),
+		Address: addrStr,
 	}, nil
 }
 
@@ -119,11 +170,47 @@ func (s *GrpcServer) GetAddresses(ctx context.Context, req *pb.GetAddressesReque
 
 // GetAddressInfo returns additional metadata about an address.
 func (s *GrpcServer) GetAddressInfo(ctx context.Context, req *pb.GetAddressInfoRequest) (*pb.GetAddressInfoResponse, error) {
-	addr, err := walletlib.DecodeAddress(req.Address, s.chainParams)
+	addr, err := decodeAddress(req.Address, s.chainParams)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
+	return s.addressInfo(addr)
+}
+
+// GetAddressInfoBatch returns the metadata for multiple addresses, or all
+// of the wallet's addresses, keyed by address.
+func (s *GrpcServer) GetAddressInfoBatch(ctx context.Context, req *pb.GetAddressInfoBatchRequest) (*pb.GetAddressInfoBatchResponse, error) {
+	var addrs []walletlib.Address
+	if req.All {
+		walletAddrs, err := s.wallet.Addresses()
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		addrs = walletAddrs
+	} else {
+		for _, a := range req.Addresses {
+			addr, err := decodeAddress(a, s.chainParams)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "%s: %s", a, err)
+			}
+			addrs = append(addrs, addr)
+		}
+	}
+
+	resp := &pb.GetAddressInfoBatchResponse{
+		Infos: make(map[string]*pb.GetAddressInfoResponse, len(addrs)),
+	}
+	for _, addr := range addrs {
+		info, err := s.addressInfo(addr)
+		if err != nil {
+			return nil, err
+		}
+		resp.Infos[addr.String()] = info
+	}
+	return resp, nil
+}
 
+func (s *GrpcServer) addressInfo(addr walletlib.Address) (*pb.GetAddressInfoResponse, error) {
 	addrInfo, err := s.wallet.AddressInfo(addr)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
@@ -135,11 +222,53 @@ func (s *GrpcServer) GetAddressInfo(ctx context.Context, req *pb.GetAddressInfoR
 	}
 	lockingScript.Serialize()
 
+	customerID, err := getCustomerID(s.ds, addr.String())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	label, err := getAddressLabel(s.ds, addr.String())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
 	resp := &pb.GetAddressInfoResponse{
 		Address:        addr.String(),
 		LockingScript:  lockingScript.Serialize(),
 		ViewPrivateKey: addrInfo.ViewPrivKey,
 		WatchOnly:      addrInfo.WatchOnly,
+		Customer_ID:    customerID,
+		Label:          label,
+	}
+	return resp, nil
+}
+
+// ConvertAddresses re-encodes addresses into the current address format. Addresses
+// using one of the network's legacy prefixes are accepted. If no addresses are
+// provided all the wallet's addresses are converted.
+func (s *GrpcServer) ConvertAddresses(ctx context.Context, req *pb.ConvertAddressesRequest) (*pb.ConvertAddressesResponse, error) {
+	addrs := req.Addresses
+	if len(addrs) == 0 {
+		walletAddrs, err := s.wallet.Addresses()
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		for _, addr := range walletAddrs {
+			addrs = append(addrs, addr.String())
+		}
+	}
+
+	resp := &pb.ConvertAddressesResponse{
+		Addresses: make([]*pb.ConvertAddressesResponse_ConvertedAddress, 0, len(addrs)),
+	}
+	for _, addr := range addrs {
+		converted, err := convertAddress(addr, s.chainParams, req.AddressPrefix)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("%s: %s", addr, err))
+		}
+		resp.Addresses = append(resp.Addresses, &pb.ConvertAddressesResponse_ConvertedAddress{
+			Original:  addr,
+			Converted: converted,
+		})
 	}
 	return resp, nil
 }
@@ -147,7 +276,10 @@ func (s *GrpcServer) GetAddressInfo(ctx context.Context, req *pb.GetAddressInfoR
 // GetNewAddress generates a new address and returns it. Both a new spend key
 // and view key will be derived from the mnemonic seed.
 func (s *GrpcServer) GetNewAddress(ctx context.Context, req *pb.GetNewAddressRequest) (*pb.GetNewAddressResponse, error) {
-	addr, err := s.wallet.NewAddress()
+	if len(req.Customer_ID) > maxCustomerIDLen {
+		return nil, status.Error(codes.InvalidArgument, "customer ID too long")
+	}
+	addr, err := s.newAddress()
 	if errors.Is(err, walletlib.ErrEncryptedKeychain) {
 		return nil, status.Error(codes.PermissionDenied, err.Error())
 	}
@@ -157,8 +289,11 @@ func (s *GrpcServer) GetNewAddress(ctx context.Context, req *pb.GetNewAddressReq
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
+	if err := putCustomerID(s.ds, addr, req.Customer_ID); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
 	return &pb.GetNewAddressResponse{
-		Address: addr.String(),
+		Address: addr,
 	}, nil
 }
 
@@ -172,12 +307,19 @@ func (s *GrpcServer) GetTransactions(ctx context.Context, req *pb.GetTransaction
 		Txs: make([]*pb.WalletTransaction, 0, 1),
 	}
 	for _, tx := range txs {
-		resp.Txs = append(resp.Txs, &pb.WalletTransaction{
+		wtx := &pb.WalletTransaction{
 			Transaction_ID: tx.Txid[:],
 			NetCoins:       int64(tx.AmountIn) - int64(tx.AmountOut),
 			Inputs:         ioToPBio(tx.Inputs),
 			Outputs:        ioToPBio(tx.Outputs),
-		})
+		}
+		if err := setCustomerIDs(s.ds, append(wtx.Inputs, wtx.Outputs...)); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if err := setAddressLabels(s.ds, append(wtx.Inputs, wtx.Outputs...)); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		resp.Txs = append(resp.Txs, wtx)
 	}
 	return resp, nil
 }
@@ -191,7 +333,21 @@ func (s *GrpcServer) GetUtxos(ctx context.Context, req *pb.GetUtxosRequest) (*pb
 	resp := &pb.GetUtxosResponse{
 		Utxos: make([]*pb.Utxo, 0, 1),
 	}
+	labels := make(map[string]string)
+	change := make(map[string]bool)
 	for _, note := range notes {
+		label, ok := labels[note.Address]
+		if !ok {
+			label, err = getAddressLabel(s.ds, note.Address)
+			if err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+			labels[note.Address] = label
+			change[note.Address], err = s.changeAddresses.isChange(note.Address)
+			if err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+		}
 		resp.Utxos = append(resp.Utxos, &pb.Utxo{
 			Commitment:   note.Commitment,
 			Amount:       note.Amount,
@@ -199,6 +355,8 @@ func (s *GrpcServer) GetUtxos(ctx context.Context, req *pb.GetUtxosRequest) (*pb
 			WatchOnly:    note.WatchOnly,
 			Staked:       note.Staked,
 			LockedUntill: note.LockedUntil,
+			Label:        label,
+			Change:       change[note.Address],
 		})
 	}
 	return resp, nil
@@ -233,133 +391,742 @@ func (s *GrpcServer) GetPrivateKey(ctx context.Context, req *pb.GetPrivateKeyReq
 	return nil, status.Error(codes.NotFound, "address not found")
 }
 
-// ImportAddress imports a watch address into the wallet.
-func (s *GrpcServer) ImportAddress(ctx context.Context, req *pb.ImportAddressRequest) (*pb.ImportAddressResponse, error) {
-	addr, err := walletlib.DecodeAddress(req.Address, s.chainParams)
+// GetPrivateKeyBatch returns the serialized spend and view keys for multiple
+// addresses, or all of the wallet's addresses, keyed by address.
+//
+// **Requires wallet to be unlocked**
+func (s *GrpcServer) GetPrivateKeyBatch(ctx context.Context, req *pb.GetPrivateKeyBatchRequest) (*pb.GetPrivateKeyBatchResponse, error) {
+	keys, err := s.wallet.PrivateKeys()
+	if errors.Is(err, walletlib.ErrEncryptedKeychain) {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	if errors.Is(err, walletlib.ErrPublicOnlyKeychain) {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
 	}
-	if len(req.LockingScript) < zk.CommitmentLen {
-		return nil, status.Error(codes.InvalidArgument, "invalid locking script")
+
+	byAddr := make(map[string]crypto.PrivKey, len(keys))
+	for key, addr := range keys {
+		k := key
+		byAddr[addr.String()] = &k
 	}
-	lockingScript := new(types.LockingScript)
-	if err := lockingScript.Deserialize(req.LockingScript); err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+
+	addrs := req.Addresses
+	if req.All {
+		addrs = make([]string, 0, len(byAddr))
+		for addr := range byAddr {
+			addrs = append(addrs, addr)
+		}
 	}
-	privKey, err := crypto.UnmarshalPrivateKey(req.ViewPrivateKey)
-	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+
+	resp := &pb.GetPrivateKeyBatchResponse{
+		SerializedKeys: make(map[string][]byte, len(addrs)),
 	}
-	err = s.wallet.ImportAddress(addr, *lockingScript, privKey, req.Rescan, req.RescanFromHeight)
-	return &pb.ImportAddressResponse{}, err
+	for _, addr := range addrs {
+		key, ok := byAddr[addr]
+		if !ok {
+			return nil, status.Errorf(codes.NotFound, "address %s not found", addr)
+		}
+		ser, err := crypto.MarshalPrivateKey(key)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		resp.SerializedKeys[addr] = ser
+	}
+	return resp, nil
 }
 
-// CreateMultisigSpendKeypair generates a spend keypair for use in a multisig address
-func (s *GrpcServer) CreateMultisigSpendKeypair(ctx context.Context, req *pb.CreateMultisigSpendKeypairRequest) (*pb.CreateMultisigSpendKeypairResponse, error) {
-	priv, pub, err := icrypto.GenerateNovaKey(rand.Reader)
-	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+// SignMessage signs a message with the spend key of one of the wallet's
+// addresses. This can be used to prove ownership of the address to a
+// third party without moving any funds.
+//
+// **Requires wallet to be unlocked**
+func (s *GrpcServer) SignMessage(ctx context.Context, req *pb.SignMessageRequest) (*pb.SignMessageResponse, error) {
+	keys, err := s.wallet.PrivateKeys()
+	if errors.Is(err, walletlib.ErrEncryptedKeychain) {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	if errors.Is(err, walletlib.ErrPublicOnlyKeychain) {
+		return nil, status.Error(codes.Unavailable, err.Error())
 	}
-	privBytes, err := crypto.MarshalPrivateKey(priv)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
-	pubBytes, err := crypto.MarshalPublicKey(pub)
+
+	for key, addr := range keys {
+		if addr.String() == req.Address {
+			spendKey := key.SpendKey()
+			sig, err := spendKey.Sign(messageDigest(req.Message))
+			if err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+			pubkey, err := crypto.MarshalPublicKey(spendKey.GetPublic())
+			if err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+			return &pb.SignMessageResponse{
+				Signature: sig,
+				PublicKey: pubkey,
+			}, nil
+		}
+	}
+	return nil, status.Error(codes.NotFound, "address not found")
+}
+
+// VerifyMessage verifies a signature created by SignMessage.
+func (s *GrpcServer) VerifyMessage(ctx context.Context, req *pb.VerifyMessageRequest) (*pb.VerifyMessageResponse, error) {
+	valid, err := verifyMessage(req.Address, req.Message, req.Signature, req.PublicKey, s.chainParams)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
-	return &pb.CreateMultisigSpendKeypairResponse{
-		Privkey: privBytes,
-		Pubkey:  pubBytes,
+	return &pb.VerifyMessageResponse{
+		Valid: valid,
 	}, nil
 }
 
-// CreateMultisigViewKeypair generates a view keypair for use in a multisig address
-func (s *GrpcServer) CreateMultisigViewKeypair(ctx context.Context, req *pb.CreateMultisigViewKeypairRequest) (*pb.CreateMultisigViewKeypairResponse, error) {
-	priv, pub, err := icrypto.GenerateCurve25519Key(rand.Reader)
+// ProveReserves creates a proof that the wallet controls unspent coins
+// totaling at least the requested amount. The proof can be checked by
+// anyone with the VerifyReserves RPC.
+//
+// The proof reveals the notes it contains, including their amounts, so
+// the wallet only includes as many of its largest notes as are needed
+// to reach the requested amount.
+//
+// **Requires wallet to be unlocked**
+func (s *GrpcServer) ProveReserves(ctx context.Context, req *pb.ProveReservesRequest) (*pb.ProveReservesResponse, error) {
+	keys, err := s.wallet.PrivateKeys()
+	if errors.Is(err, walletlib.ErrEncryptedKeychain) {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	if errors.Is(err, walletlib.ErrPublicOnlyKeychain) {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
-	privBytes, err := crypto.MarshalPrivateKey(priv)
+	spendKeys := make(map[string]crypto.PrivKey, len(keys))
+	for key, addr := range keys {
+		spendKeys[addr.String()] = key.SpendKey()
+	}
+
+	notes, err := s.wallet.Notes()
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
-	pubBytes, err := crypto.MarshalPublicKey(pub)
+	basicTransferCommitment, err := zk.LurkCommit(zk.BasicTransferScript())
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
-	return &pb.CreateMultisigViewKeypairResponse{
-		Privkey: privBytes,
-		Pubkey:  pubBytes,
-	}, nil
-}
 
-// CreateMultisigAddress generates a new multisig address using the provided public keys
-//
-// Note this address is *not* imported. You will need to call `ImportAddress` if you want to watch
-// it.
-func (s *GrpcServer) CreateMultisigAddress(ctx context.Context, req *pb.CreateMultisigAddressRequest) (*pb.CreateMultisigAddressResponse, error) {
-	threshold := make([]byte, 4)
-	binary.BigEndian.PutUint32(threshold, req.Threshold)
+	// Only notes locked by one of our basic addresses can be signed for.
+	eligible := make([]*walletpb.SpendNote, 0, len(notes))
+	for _, note := range notes {
+		if note.WatchOnly || note.LockingScript == nil {
+			continue
+		}
+		if !bytes.Equal(note.LockingScript.ScriptCommitment, basicTransferCommitment) {
+			continue
+		}
+		if !bytes.Equal(note.Asset_ID, types.IlliumCoinID[:]) {
+			continue
+		}
+		if _, ok := spendKeys[note.Address]; !ok {
+			continue
+		}
+		eligible = append(eligible, note)
+	}
+	sort.Slice(eligible, func(i, j int) bool {
+		return eligible[i].Amount > eligible[j].Amount
+	})
+
+	var (
+		selected    []*walletpb.SpendNote
+		commitments []types.ID
+		total       uint64
+	)
+	for _, note := range eligible {
+		if req.Amount > 0 && total >= req.Amount {
+			break
+		}
+		selected = append(selected, note)
+		commitments = append(commitments, types.NewID(note.Commitment))
+		total += note.Amount
+	}
+	if len(selected) == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "wallet has no eligible notes")
+	}
+	if total < req.Amount {
+		return nil, status.Error(codes.FailedPrecondition, "wallet reserves are less than the requested amount")
+	}
 
-	scriptCommitment, err := zk.LurkCommit(zk.MultisigScript())
+	inclusionProofs, txoRoot, err := s.wallet.GetInclusionProofs(commitments...)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	lockingScript := types.LockingScript{
-		ScriptCommitment: types.NewID(scriptCommitment),
-		LockingParams:    [][]byte{threshold},
+	proof := &pb.ReservesProof{
+		Message: req.Message,
+		Notes:   make([]*pb.ReservesProof_Note, 0, len(selected)),
 	}
-	for _, key := range req.Pubkeys {
-		pubkey, err := crypto.UnmarshalPublicKey(key)
+	for i, note := range selected {
+		sig, err := spendKeys[note.Address].Sign(reservesDigest(req.Message, note.Commitment, txoRoot[:]))
 		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		proof.Notes = append(proof.Notes, &pb.ReservesProof_Note{
+			Amount:           note.Amount,
+			Asset_ID:         note.Asset_ID,
+			Salt:             note.Salt,
+			State:            note.State,
+			ScriptCommitment: note.LockingScript.ScriptCommitment,
+			LockingParams:    note.LockingScript.LockingParams,
+			TxoProof: &pb.TxoProof{
+				Commitment: note.Commitment,
+				Hashes:     inclusionProofs[i].Hashes,
+				Flags:      inclusionProofs[i].Flags,
+				Index:      inclusionProofs[i].Index,
+				TxoRoot:    txoRoot[:],
+			},
+			Signature: sig,
+		})
+	}
+	return &pb.ProveReservesResponse{
+		Proof: proof,
+		Total: total,
+	}, nil
+}
+
+// ProvePayment creates a proof that a transaction output paid a specific
+// amount to a specific address. The proof discloses the output's note,
+// and nothing else about the wallet, so it can be handed to a third
+// party to settle a payment dispute. It can be checked with the
+// VerifyPayment RPC.
+//
+// The wallet must hold the view key of the receiving address unless the
+// output was paid to a public address. Senders cannot decrypt their own
+// outputs after the fact so they must supply the private output that was
+// returned by CreateRawTransaction.
+//
+// **Requires TxIndex**
+func (s *GrpcServer) ProvePayment(ctx context.Context, req *pb.ProvePaymentRequest) (*pb.ProvePaymentResponse, error) {
+	if s.txIndex == nil {
+		return nil, status.Error(codes.Unavailable, "tx index is not available")
+	}
+	tx, _, err := s.txIndex.GetTransaction(s.ds, types.NewID(req.Transaction_ID))
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	outputs := tx.Outputs()
+	if int(req.OutputIndex) >= len(outputs) {
+		return nil, status.Error(codes.InvalidArgument, "output index out of range")
+	}
+
+	var note *types.SpendNote
+	if req.PrivateOutput != nil {
+		state := new(types.State)
+		if err := state.Deserialize(req.PrivateOutput.State); err != nil {
 			return nil, status.Error(codes.InvalidArgument, err.Error())
 		}
-		novaKey, ok := pubkey.(*icrypto.NovaPublicKey)
-		if !ok {
-			return nil, status.Error(codes.InvalidArgument, "Public key is not type Nova")
+		note = &types.SpendNote{
+			ScriptHash: types.NewID(req.PrivateOutput.ScriptHash),
+			Amount:     types.Amount(req.PrivateOutput.Amount),
+			AssetID:    types.NewID(req.PrivateOutput.Asset_ID),
+			State:      *state,
+		}
+		copy(note.Salt[:], req.PrivateOutput.Salt)
+	} else {
+		viewKeys, err := s.wallet.ViewKeys()
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		note, err = openOutput(outputs[req.OutputIndex], viewKeys)
+		if err != nil {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
 		}
-
-		x, y := novaKey.ToXY()
-		lockingScript.LockingParams = append(lockingScript.LockingParams, x, y)
 	}
 
-	viewKey, err := crypto.UnmarshalPublicKey(req.ViewPubkey)
-	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+	addrStr := req.Address
+	if addrStr == "" {
+		addrs, err := s.wallet.Addresses()
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		for _, addr := range addrs {
+			if types.ID(addr.ScriptHash()) == note.ScriptHash {
+				addrStr = addr.String()
+				break
+			}
+		}
+		if addrStr == "" {
+			return nil, status.Error(codes.InvalidArgument, "output was not paid to a wallet address, the address must be provided")
+		}
 	}
 
-	addr, err := walletlib.NewBasicAddress(lockingScript, viewKey, s.chainParams)
+	proof := &pb.PaymentProof{
+		Transaction_ID: req.Transaction_ID,
+		OutputIndex:    req.OutputIndex,
+		Address:        addrStr,
+		Amount:         uint64(note.Amount),
+		Asset_ID:       note.AssetID.Bytes(),
+		Salt:           note.Salt[:],
+	}
+	proof.State, err = note.State.Serialize(false)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
-	return &pb.CreateMultisigAddressResponse{
-		Address: addr.String(),
+	// Check the proof before handing it out so a wrong address or
+	// private output is reported here rather than by the verifier.
+	if _, err := verifyPaymentProof(proof, tx, s.chainParams); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &pb.ProvePaymentResponse{
+		Proof: proof,
 	}, nil
 }
 
-// CreateMultiSignature generates and returns a signature for use when proving a multisig transaction
-func (s *GrpcServer) CreateMultiSignature(ctx context.Context, req *pb.CreateMultiSignatureRequest) (*pb.CreateMultiSignatureResponse, error) {
-	privKey, err := crypto.UnmarshalPrivateKey(req.PrivateKey)
+// RequestInvoice requests a fresh one-time address and payment terms from
+// the node serving invoices for the payee's address. If no peer ID is
+// provided the network is searched for the payee's node. The terms are
+// signed by the payee address's spend key and are checked before they
+// are returned.
+func (s *GrpcServer) RequestInvoice(ctx context.Context, req *pb.RequestInvoiceRequest) (*pb.RequestInvoiceResponse, error) {
+	if s.invoiceService == nil {
+		return nil, status.Error(codes.Unavailable, "invoice service is not available")
+	}
+	payeeAddr, err := decodeAddress(req.PayeeAddress, s.chainParams)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
-	var sigHash []byte
-	if req.GetTx() != nil {
-		tx := req.GetTx()
-		standardTx := tx.GetStandardTransaction()
-		if standardTx == nil {
-			return nil, status.Error(codes.InvalidArgument, "standard transaction missing")
-		}
-		sigHash, err = standardTx.SigHash()
+	var pid peer.ID
+	if req.Peer_ID != "" {
+		pid, err = peer.Decode(req.Peer_ID)
 		if err != nil {
-			return nil, status.Error(codes.Internal, err.Error())
+			return nil, status.Error(codes.InvalidArgument, err.Error())
 		}
-	} else if req.GetSighash() != nil {
-		sigHash = req.GetSighash()
-	} else {
-		return nil, status.Error(codes.InvalidArgument, "transaction or sighash required")
+	}
+	inv, err := s.invoiceService.RequestInvoice(payeeAddr, pid, types.Amount(req.Amount), req.Memo)
+	if errors.Is(err, invoice.ErrNoPayee) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	} else if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	return &pb.RequestInvoiceResponse{
+		Address: inv.Address.String(),
+		Amount:  uint64(inv.Amount),
+		Memo:    inv.Memo,
+		Expires: inv.Expires.Unix(),
+	}, nil
+}
+
+// CreateInvoice creates a merchant invoice paid to a fresh wallet address.
+// Payments to the address are tracked as they are accepted into the mempool,
+// included in a block that is being voted on, and finalized by consensus.
+// If a webhook URL is provided the JSON encoded invoice is POSTed to it
+// each time the invoice status changes.
+//
+// **Requires wallet to be unlocked**
+func (s *GrpcServer) CreateInvoice(ctx context.Context, req *pb.CreateInvoiceRequest) (*pb.CreateInvoiceResponse, error) {
+	if s.invoices == nil {
+		return nil, status.Error(codes.Unavailable, "invoice tracking is not available")
+	}
+	if req.Amount == 0 {
+		return nil, status.Error(codes.InvalidArgument, "amount must be greater than zero")
+	}
+	if req.Expiry < 0 {
+		return nil, status.Error(codes.InvalidArgument, "expiry must not be negative")
+	}
+	expiry := invoice.InvoiceExpiry
+	if req.Expiry > 0 {
+		expiry = time.Duration(req.Expiry) * time.Second
+	}
+	if req.Webhook_URL != "" {
+		if err := validateWebhookURL(req.Webhook_URL); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	inv, err := s.invoices.CreateInvoice(types.Amount(req.Amount), req.Memo, expiry, req.Webhook_URL)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.CreateInvoiceResponse{
+		Invoice: inv,
+	}, nil
+}
+
+// GetInvoiceStatus returns a merchant invoice along with the payments
+// made to it and its current status.
+func (s *GrpcServer) GetInvoiceStatus(ctx context.Context, req *pb.GetInvoiceStatusRequest) (*pb.GetInvoiceStatusResponse, error) {
+	if s.invoices == nil {
+		return nil, status.Error(codes.Unavailable, "invoice tracking is not available")
+	}
+	inv, err := s.invoices.GetInvoice(types.NewID(req.Invoice_ID))
+	if errors.Is(err, datastore.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, "invoice not found")
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.GetInvoiceStatusResponse{
+		Invoice: inv,
+	}, nil
+}
+
+// ImportAddress imports a watch address into the wallet.
+func (s *GrpcServer) ImportAddress(ctx context.Context, req *pb.ImportAddressRequest) (*pb.ImportAddressResponse, error) {
+	addr, lockingScript, privKey, err := s.decodeImportAddress(req.Address, req.LockingScript, req.ViewPrivateKey)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	err = s.wallet.ImportAddress(addr, *lockingScript, privKey, req.Rescan, req.RescanFromHeight)
+	return &pb.ImportAddressResponse{}, err
+}
+
+// SetCustomerID associates an opaque customer identifier with one of the
+// wallet's addresses. The identifier is returned alongside the address in
+// GetAddressInfo, GetTransactions, and SubscribeWalletTransactions so that
+// deposits can be credited without an external address database. An empty
+// identifier removes the association.
+func (s *GrpcServer) SetCustomerID(ctx context.Context, req *pb.SetCustomerIDRequest) (*pb.SetCustomerIDResponse, error) {
+	addr, err := decodeAddress(req.Address, s.chainParams)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if _, err := s.wallet.AddressInfo(addr); errors.Is(err, datastore.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, "address not found in wallet")
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if len(req.Customer_ID) > maxCustomerIDLen {
+		return nil, status.Error(codes.InvalidArgument, "customer ID too long")
+	}
+	if err := putCustomerID(s.ds, addr.String(), req.Customer_ID); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.SetCustomerIDResponse{}, nil
+}
+
+// SetAddressLabel attaches a label to one of the wallet's addresses. The
+// label is returned alongside the address in GetAddressInfo, GetUtxos,
+// GetTransactions, and SubscribeWalletTransactions. An empty label removes
+// the existing one.
+func (s *GrpcServer) SetAddressLabel(ctx context.Context, req *pb.SetAddressLabelRequest) (*pb.SetAddressLabelResponse, error) {
+	addr, err := decodeAddress(req.Address, s.chainParams)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if _, err := s.wallet.AddressInfo(addr); errors.Is(err, datastore.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, "address not found in wallet")
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if len(req.Label) > maxAddressLabelLen {
+		return nil, status.Error(codes.InvalidArgument, "label too long")
+	}
+	if err := putAddressLabel(s.ds, addr.String(), req.Label); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.SetAddressLabelResponse{}, nil
+}
+
+// GetAddressesByLabel returns the wallet's addresses with the given label
+// sorted in lexicographical order.
+func (s *GrpcServer) GetAddressesByLabel(ctx context.Context, req *pb.GetAddressesByLabelRequest) (*pb.GetAddressesByLabelResponse, error) {
+	if req.Label == "" {
+		return nil, status.Error(codes.InvalidArgument, "label is required")
+	}
+	addrs, err := getAddressesByLabel(s.ds, req.Label)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.GetAddressesByLabelResponse{
+		Addresses: addrs,
+	}, nil
+}
+
+// ImportAddresses imports several watch addresses into the wallet. If a
+// rescan is requested only a single rescan is run after all the addresses
+// have been imported rather than one per address.
+func (s *GrpcServer) ImportAddresses(ctx context.Context, req *pb.ImportAddressesRequest) (*pb.ImportAddressesResponse, error) {
+	if len(req.Addresses) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no addresses to import")
+	}
+
+	type importAddr struct {
+		addr          walletlib.Address
+		lockingScript *types.LockingScript
+		privKey       crypto.PrivKey
+	}
+	// Decode everything up front so a bad entry doesn't
+	// leave the batch partially imported.
+	toImport := make([]importAddr, 0, len(req.Addresses))
+	for i, a := range req.Addresses {
+		addr, lockingScript, privKey, err := s.decodeImportAddress(a.Address, a.LockingScript, a.ViewPrivateKey)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("address %d: %s", i, err))
+		}
+		toImport = append(toImport, importAddr{addr, lockingScript, privKey})
+	}
+
+	// The wallet adds each view key to its scanner as it is imported
+	// so the rescan started with the last address covers all of them.
+	for i, a := range toImport {
+		rescan := req.Rescan && i == len(toImport)-1
+		if err := s.wallet.ImportAddress(a.addr, *a.lockingScript, a.privKey, rescan, req.RescanFromHeight); err != nil {
+			return nil, status.Error(codes.Internal, fmt.Sprintf("address %d: %s", i, err))
+		}
+	}
+	return &pb.ImportAddressesResponse{}, nil
+}
+
+// ExportWatchOnly returns the addresses, locking scripts, and view keys
+// needed to watch this wallet from another node. The result can be passed
+// directly to ImportAddresses. This is used to set up wallet read replicas
+// that serve balance and history queries without holding the spend keys.
+func (s *GrpcServer) ExportWatchOnly(ctx context.Context, req *pb.ExportWatchOnlyRequest) (*pb.ExportWatchOnlyResponse, error) {
+	addrs, err := s.watchOnlyAddresses()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.ExportWatchOnlyResponse{
+		Addresses: addrs,
+	}, nil
+}
+
+// ExportDescriptor returns an account descriptor for the wallet. The
+// descriptor is a single string holding the view keys and locking scripts
+// for the wallet's addresses along with a change address, but none of the
+// spend keys. It can be imported into an online node with ImportDescriptor
+// to track the balance and build unsigned transactions which are then
+// signed and proven by this wallet.
+func (s *GrpcServer) ExportDescriptor(ctx context.Context, req *pb.ExportDescriptorRequest) (*pb.ExportDescriptorResponse, error) {
+	addrs, err := s.watchOnlyAddresses()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	changeAddr, err := s.wallet.Address()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	descriptor, err := encodeDescriptor(&pb.AccountDescriptor{
+		Network:        s.chainParams.Name,
+		BirthdayHeight: req.BirthdayHeight,
+		ChangeAddress:  changeAddr.String(),
+		Addresses:      addrs,
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.ExportDescriptorResponse{
+		Descriptor_: descriptor,
+	}, nil
+}
+
+// ImportDescriptor imports an account descriptor created by ExportDescriptor
+// and rescans the chain from the descriptor's birthday. The descriptor's
+// notes are watch-only so transactions spending them must be created with
+// CreateRawTransaction, passing the input commitments explicitly and the
+// descriptor's change address so the change returns to the account.
+func (s *GrpcServer) ImportDescriptor(ctx context.Context, req *pb.ImportDescriptorRequest) (*pb.ImportDescriptorResponse, error) {
+	desc, err := decodeDescriptor(req.Descriptor_)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if desc.Network != s.chainParams.Name {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("descriptor is for network %s", desc.Network))
+	}
+	if _, err := decodeAddress(desc.ChangeAddress, s.chainParams); err != nil {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid change address: %s", err))
+	}
+	_, err = s.ImportAddresses(ctx, &pb.ImportAddressesRequest{
+		Addresses:        desc.Addresses,
+		Rescan:           req.Rescan,
+		RescanFromHeight: desc.BirthdayHeight,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ImportDescriptorResponse{
+		NumAddresses:   uint32(len(desc.Addresses)),
+		ChangeAddress:  desc.ChangeAddress,
+		BirthdayHeight: desc.BirthdayHeight,
+	}, nil
+}
+
+// watchOnlyAddresses returns the wallet's addresses in the format accepted
+// by ImportAddresses. Addresses sharing a view key are only included once.
+func (s *GrpcServer) watchOnlyAddresses() ([]*pb.ImportAddressesRequest_Address, error) {
+	addrs, err := s.wallet.Addresses()
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]*walletpb.AddrInfo, 0, len(addrs))
+	for _, addr := range addrs {
+		if _, ok := addr.(*walletlib.BasicAddress); !ok {
+			continue
+		}
+		info, err := s.wallet.AddressInfo(addr)
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, info)
+	}
+
+	// Timelocked addresses reuse the view key of the address they were
+	// derived from and a view key can only be imported once, so export
+	// basic addresses ahead of any others sharing their key.
+	basicScript := zk.BasicTransferScriptCommitment()
+	sort.SliceStable(infos, func(i, j int) bool {
+		return bytes.Equal(infos[i].LockingScript.ScriptCommitment, basicScript) &&
+			!bytes.Equal(infos[j].LockingScript.ScriptCommitment, basicScript)
+	})
+
+	ret := make([]*pb.ImportAddressesRequest_Address, 0, len(infos))
+	seen := make(map[string]bool)
+	for _, info := range infos {
+		if seen[string(info.ViewPrivKey)] {
+			continue
+		}
+		seen[string(info.ViewPrivKey)] = true
+
+		lockingScript := types.LockingScript{
+			ScriptCommitment: types.NewID(info.LockingScript.ScriptCommitment),
+			LockingParams:    info.LockingScript.LockingParams,
+		}
+		ret = append(ret, &pb.ImportAddressesRequest_Address{
+			Address:        info.Addr,
+			LockingScript:  lockingScript.Serialize(),
+			ViewPrivateKey: info.ViewPrivKey,
+		})
+	}
+	return ret, nil
+}
+
+func (s *GrpcServer) decodeImportAddress(address string, lockingScriptBytes, viewKeyBytes []byte) (walletlib.Address, *types.LockingScript, crypto.PrivKey, error) {
+	addr, err := decodeAddress(address, s.chainParams)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(lockingScriptBytes) < zk.CommitmentLen {
+		return nil, nil, nil, errors.New("invalid locking script")
+	}
+	lockingScript := new(types.LockingScript)
+	if err := lockingScript.Deserialize(lockingScriptBytes); err != nil {
+		return nil, nil, nil, err
+	}
+	privKey, err := crypto.UnmarshalPrivateKey(viewKeyBytes)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return addr, lockingScript, privKey, nil
+}
+
+// CreateMultisigSpendKeypair generates a spend keypair for use in a multisig address
+func (s *GrpcServer) CreateMultisigSpendKeypair(ctx context.Context, req *pb.CreateMultisigSpendKeypairRequest) (*pb.CreateMultisigSpendKeypairResponse, error) {
+	priv, pub, err := icrypto.GenerateNovaKey(rand.Reader)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	privBytes, err := crypto.MarshalPrivateKey(priv)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	pubBytes, err := crypto.MarshalPublicKey(pub)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.CreateMultisigSpendKeypairResponse{
+		Privkey: privBytes,
+		Pubkey:  pubBytes,
+	}, nil
+}
+
+// CreateMultisigViewKeypair generates a view keypair for use in a multisig address
+func (s *GrpcServer) CreateMultisigViewKeypair(ctx context.Context, req *pb.CreateMultisigViewKeypairRequest) (*pb.CreateMultisigViewKeypairResponse, error) {
+	priv, pub, err := icrypto.GenerateCurve25519Key(rand.Reader)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	privBytes, err := crypto.MarshalPrivateKey(priv)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	pubBytes, err := crypto.MarshalPublicKey(pub)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.CreateMultisigViewKeypairResponse{
+		Privkey: privBytes,
+		Pubkey:  pubBytes,
+	}, nil
+}
+
+// CreateMultisigAddress generates a new multisig address using the provided public keys
+//
+// Note this address is *not* imported. You will need to call `ImportAddress` if you want to watch
+// it.
+func (s *GrpcServer) CreateMultisigAddress(ctx context.Context, req *pb.CreateMultisigAddressRequest) (*pb.CreateMultisigAddressResponse, error) {
+	threshold := make([]byte, 4)
+	binary.BigEndian.PutUint32(threshold, req.Threshold)
+
+	scriptCommitment, err := zk.LurkCommit(zk.MultisigScript())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	lockingScript := types.LockingScript{
+		ScriptCommitment: types.NewID(scriptCommitment),
+		LockingParams:    [][]byte{threshold},
+	}
+	for _, key := range req.Pubkeys {
+		pubkey, err := crypto.UnmarshalPublicKey(key)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		novaKey, ok := pubkey.(*icrypto.NovaPublicKey)
+		if !ok {
+			return nil, status.Error(codes.InvalidArgument, "Public key is not type Nova")
+		}
+
+		x, y := novaKey.ToXY()
+		lockingScript.LockingParams = append(lockingScript.LockingParams, x, y)
+	}
+
+	viewKey, err := crypto.UnmarshalPublicKey(req.ViewPubkey)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	addr, err := walletlib.NewBasicAddress(lockingScript, viewKey, s.chainParams)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.CreateMultisigAddressResponse{
+		Address: addr.String(),
+	}, nil
+}
+
+// CreateMultiSignature generates and returns a signature for use when proving a multisig transaction
+func (s *GrpcServer) CreateMultiSignature(ctx context.Context, req *pb.CreateMultiSignatureRequest) (*pb.CreateMultiSignatureResponse, error) {
+	privKey, err := crypto.UnmarshalPrivateKey(req.PrivateKey)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	var sigHash []byte
+	if req.GetTx() != nil {
+		tx := req.GetTx()
+		standardTx := tx.GetStandardTransaction()
+		if standardTx == nil {
+			return nil, status.Error(codes.InvalidArgument, "standard transaction missing")
+		}
+		sigHash, err = standardTx.SigHash()
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	} else if req.GetSighash() != nil {
+		sigHash = req.GetSighash()
+	} else {
+		return nil, status.Error(codes.InvalidArgument, "transaction or sighash required")
 	}
 	sig, err := privKey.Sign(sigHash)
 	if err != nil {
@@ -466,15 +1233,26 @@ func (s *GrpcServer) ProveMultisig(ctx context.Context, req *pb.ProveMultisigReq
 	}, nil
 }
 
-// WalletLock encrypts the wallet's private keys
+// WalletLock encrypts the wallet's private keys. It also locks the
+// view only tier.
 func (s *GrpcServer) WalletLock(ctx context.Context, req *pb.WalletLockRequest) (*pb.WalletLockResponse, error) {
+	if s.viewUnlock != nil {
+		s.viewUnlock.lock()
+	}
 	err := s.wallet.Lock()
 	return &pb.WalletLockResponse{}, err
 }
 
-// WalletUnlock decrypts the wallet seed and holds it in memory for the specified period of time
+// WalletUnlock decrypts the wallet seed and holds it in memory for the specified period of time.
+// If a view passphrase is set the pool of addresses handed out while the wallet is view unlocked
+// is refilled.
 func (s *GrpcServer) WalletUnlock(ctx context.Context, req *pb.WalletUnlockRequest) (*pb.WalletUnlockResponse, error) {
 	err := s.wallet.Unlock(req.Passphrase, time.Second*time.Duration(req.Duration))
+	if err == nil && s.viewUnlock != nil {
+		if ciphertext, cerr := s.viewUnlock.check(); cerr == nil && ciphertext != nil {
+			s.viewUnlock.fillAddressPool(s.wallet) //nolint:errcheck
+		}
+	}
 	return &pb.WalletUnlockResponse{}, err
 }
 
@@ -490,25 +1268,83 @@ func (s *GrpcServer) ChangeWalletPassphrase(ctx context.Context, req *pb.ChangeW
 	return &pb.ChangeWalletPassphraseResponse{}, err
 }
 
+// GetDeletePrivateKeysNonce returns a single use nonce which must be passed
+// to DeletePrivateKeys to confirm the deletion. The nonce expires after one
+// minute and only the most recently generated nonce is valid.
+func (s *GrpcServer) GetDeletePrivateKeysNonce(ctx context.Context, req *pb.GetDeletePrivateKeysNonceRequest) (*pb.GetDeletePrivateKeysNonceResponse, error) {
+	nonce, expires, err := s.newDeleteNonce()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.GetDeletePrivateKeysNonceResponse{
+		Nonce:   nonce,
+		Expires: expires.Unix(),
+	}, nil
+}
+
 // DeletePrivateKeys deletes the wallet's private keys and seed from disk essentially turning the wallet
 // into a watch-only wallet. It will still record incoming transactions but cannot spend them.
 //
+// The request must include a nonce from GetDeletePrivateKeysNonce. Before
+// the keys are deleted they are written to a backup file in the node's key
+// backup directory, encrypted with the provided backup passphrase.
+//
 // **Requires wallet to be unlocked**
 func (s *GrpcServer) DeletePrivateKeys(ctx context.Context, req *pb.DeletePrivateKeysRequest) (*pb.DeletePrivateKeysResponse, error) {
-	err := s.wallet.PrunePrivateKeys()
+	if req.BackupPassphrase == "" {
+		return nil, status.Error(codes.InvalidArgument, "backup passphrase is required")
+	}
+	if !s.useDeleteNonce(req.Nonce) {
+		return nil, status.Error(codes.FailedPrecondition, "invalid or expired nonce")
+	}
+	backupFile, err := s.writeKeyBackup(req.BackupPassphrase)
 	if errors.Is(err, walletlib.ErrEncryptedKeychain) {
 		return nil, status.Error(codes.PermissionDenied, err.Error())
 	}
-	return nil, err
-}
-
-// CreateRawTransaction creates a new, unsigned (unproven) transaction using the given parameters
-func (s *GrpcServer) CreateRawTransaction(ctx context.Context, req *pb.CreateRawTransactionRequest) (*pb.CreateRawTransactionResponse, error) {
-	inputs := make([]*walletlib.RawInput, 0, len(req.Inputs))
-	for _, in := range req.Inputs {
-		rawInput := &walletlib.RawInput{}
-		if in.GetCommitment() != nil {
-			rawInput.Commitment = in.GetCommitment()
+	if errors.Is(err, walletlib.ErrPublicOnlyKeychain) {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if err := s.wallet.PrunePrivateKeys(); err != nil {
+		if errors.Is(err, walletlib.ErrEncryptedKeychain) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.DeletePrivateKeysResponse{
+		BackupFile: backupFile,
+	}, nil
+}
+
+// RepairWallet checks the wallet's utxos against the chain and fixes any
+// inconsistencies, such as those left by a crash during a rescan. Utxos
+// whose commitment is not in the accumulator or whose nullifier has been
+// spent are removed. Missing metadata is derived again from the wallet's
+// addresses. The actions taken are returned.
+func (s *GrpcServer) RepairWallet(ctx context.Context, req *pb.RepairWalletRequest) (*pb.RepairWalletResponse, error) {
+	resp, err := s.repairWallet(req.DryRun)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return resp, nil
+}
+
+// CreateRawTransaction creates a new, unsigned (unproven) transaction using the given parameters
+func (s *GrpcServer) CreateRawTransaction(ctx context.Context, req *pb.CreateRawTransactionRequest) (*pb.CreateRawTransactionResponse, error) {
+	var expiry time.Time
+	if req.Expiry != 0 {
+		expiry = time.Unix(req.Expiry, 0)
+		if !expiry.After(s.chain.TimeSource().AdjustedTime()) {
+			return nil, status.Error(codes.InvalidArgument, "expiry must be in the future")
+		}
+	}
+	inputs := make([]*walletlib.RawInput, 0, len(req.Inputs))
+	for _, in := range req.Inputs {
+		rawInput := &walletlib.RawInput{}
+		if in.GetCommitment() != nil {
+			rawInput.Commitment = in.GetCommitment()
 		} else if in.GetInput() != nil {
 			rawInput.PrivateInput = &circparams.PrivateInput{
 				Amount:          types.Amount(in.GetInput().Amount),
@@ -531,7 +1367,7 @@ func (s *GrpcServer) CreateRawTransaction(ctx context.Context, req *pb.CreateRaw
 	}
 	outputs := make([]*walletlib.RawOutput, 0, len(req.Outputs))
 	for _, out := range req.Outputs {
-		addr, err := walletlib.DecodeAddress(out.Address, s.chainParams)
+		addr, err := decodeAddress(out.Address, s.chainParams)
 		if err != nil {
 			return nil, status.Error(codes.InvalidArgument, err.Error())
 		}
@@ -547,9 +1383,44 @@ func (s *GrpcServer) CreateRawTransaction(ctx context.Context, req *pb.CreateRaw
 		outputs = append(outputs, rawOut)
 	}
 
-	rawTx, err := s.wallet.CreateRawTransaction(inputs, outputs, req.AppendChangeOutput, types.Amount(req.FeePerKilobyte))
-	if err != nil {
-		return nil, err
+	var rawTx *walletlib.RawTransaction
+	if req.ChangeAddress != "" {
+		if !req.AppendChangeOutput {
+			return nil, status.Error(codes.InvalidArgument, "change address requires append change output")
+		}
+		changeAddr, err := decodeAddress(req.ChangeAddress, s.chainParams)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		commitments := make([][]byte, 0, len(inputs))
+		for _, in := range inputs {
+			if in.Commitment == nil {
+				return nil, status.Error(codes.InvalidArgument, "inputs must be commitments when using a change address")
+			}
+			commitments = append(commitments, in.Commitment)
+		}
+		rawTx, err = s.buildWatchOnlyTransaction(commitments, outputs, changeAddr, types.Amount(req.FeePerKilobyte))
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	} else {
+		var err error
+		rawTx, err = s.wallet.CreateRawTransaction(inputs, outputs, req.AppendChangeOutput, types.Amount(req.FeePerKilobyte))
+		if err != nil {
+			return nil, err
+		}
+	}
+	// The transaction is not yet proven so the locktime can be replaced.
+	// Open the window a little in the past to allow for clock differences
+	// between this node and the block producers.
+	if !expiry.IsZero() && rawTx.Tx.GetStandardTransaction() != nil {
+		start := s.chain.TimeSource().AdjustedTime().Add(-time.Second * walletlib.DefaultLocktimePrecision)
+		rawTx.Tx.GetStandardTransaction().Locktime = blockchain.NewExpiringLocktime(start, expiry)
+	}
+	if standardTx := rawTx.Tx.GetStandardTransaction(); standardTx != nil {
+		if err := checkRawTransactionAmounts(rawTx.PrivateInputs, rawTx.PrivateOutputs, types.Amount(standardTx.Fee)); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 	}
 	resp := &pb.CreateRawTransactionResponse{
 		RawTx: &pb.RawTransaction{
@@ -558,11 +1429,14 @@ func (s *GrpcServer) CreateRawTransaction(ctx context.Context, req *pb.CreateRaw
 			Outputs: make([]*pb.PrivateOutput, 0, len(rawTx.PrivateOutputs)),
 		},
 	}
+	if txExpiry, ok := blockchain.TransactionExpiry(rawTx.Tx); ok {
+		resp.Expiry = txExpiry.Unix()
+	}
 
 	for i, in := range rawTx.PrivateInputs {
 		var commitment types.ID
 
-		if req.Inputs[i].GetCommitment() != nil {
+		if i < len(req.Inputs) && req.Inputs[i].GetCommitment() != nil {
 			commitment = types.NewID(req.Inputs[i].GetCommitment())
 		} else {
 			scriptCommitment, err := zk.LurkCommit(in.Script)
@@ -819,6 +1693,10 @@ func (s *GrpcServer) ProveRawTransaction(ctx context.Context, req *pb.ProveRawTr
 			privateParams.Outputs = append(privateParams.Outputs, privOut)
 		}
 
+		if err := checkRawTransactionAmounts(privateParams.Inputs, privateParams.Outputs, types.Amount(standardTx.Fee)); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
 		publicParams, err := standardTx.ToCircuitParams()
 		if err != nil {
 			return nil, status.Error(codes.Internal, err.Error())
@@ -925,14 +1803,52 @@ func (s *GrpcServer) ProveRawTransaction(ctx context.Context, req *pb.ProveRawTr
 
 // Stake stakes the selected wallet UTXOs and turns the node into a validator
 //
+// Cold staking UTXOs, imported from a delegated stake address, are staked
+// with the delegate key if the owner key is not in the wallet.
+//
 // **Requires wallet to be unlocked**
 func (s *GrpcServer) Stake(ctx context.Context, req *pb.StakeRequest) (*pb.StakeResponse, error) {
+	if req.DryRun {
+		plan, err := s.planStake(req.Commitments)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return &pb.StakeResponse{Plan: plan}, nil
+	}
+	notes, err := s.wallet.Notes()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
 	commitments := make([]types.ID, 0, len(req.Commitments))
+	var delegated []*walletpb.SpendNote
 	for _, c := range req.Commitments {
+		if note := findNote(notes, c); note != nil && isDelegatedStake(note) {
+			delegated = append(delegated, note)
+			continue
+		}
 		commitments = append(commitments, types.NewID(c))
 	}
-	err := s.wallet.Stake(commitments)
-	return &pb.StakeResponse{}, err
+	if len(delegated) > 0 {
+		keys, err := s.walletPrivateKeys()
+		if err != nil {
+			return nil, err
+		}
+		for _, note := range delegated {
+			tx, err := s.buildDelegatedStake(note, keys)
+			if err != nil {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+			if err := s.broadcastTxFunc(tx); err != nil {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+		}
+	}
+	if len(commitments) > 0 {
+		if err := s.wallet.Stake(commitments); err != nil {
+			return nil, err
+		}
+	}
+	return &pb.StakeResponse{}, nil
 }
 
 // SetAutoStakeRewards make it such that any validator rewards that are earned are automatically staked
@@ -943,23 +1859,255 @@ func (s *GrpcServer) SetAutoStakeRewards(ctx context.Context, req *pb.SetAutoSta
 	return &pb.SetAutoStakeRewardsResponse{}, err
 }
 
+// DelegateStake sends coins to a cold staking address. The delegate key,
+// held by an online validator, can stake the coins but cannot spend them.
+// The wallet's key, which can then be kept offline, can spend the coins at
+// any time. The address is imported so the wallet tracks the coins.
+//
+// To stake the coins the validator imports the address using the locking
+// script and view key returned here and calls Stake with the commitment.
+//
+// **Requires wallet to be unlocked**
+func (s *GrpcServer) DelegateStake(ctx context.Context, req *pb.DelegateStakeRequest) (*pb.DelegateStakeResponse, error) {
+	delegateKey, err := crypto.UnmarshalPublicKey(req.DelegatePublicKey)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if req.Amount == 0 {
+		return nil, status.Error(codes.InvalidArgument, "amount is required")
+	}
+
+	keys, err := s.walletPrivateKeys()
+	if err != nil {
+		return nil, err
+	}
+	walletAddr, err := s.wallet.Address()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	ownerKey, ok := spendKeyForAddress(keys, walletAddr.String())
+	if !ok {
+		return nil, status.Error(codes.Internal, "wallet address key not found")
+	}
+
+	lockingScript, err := delegatedStakeLockingScript(ownerKey.GetPublic(), delegateKey)
+	if errors.Is(err, zk.ErrProverUnavailable) {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	} else if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	viewKey, viewPubkey, err := icrypto.GenerateCurve25519Key(rand.Reader)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	viewKeyBytes, err := crypto.MarshalPrivateKey(viewKey)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	addr, err := walletlib.NewBasicAddress(lockingScript, viewPubkey, s.chainParams)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if err := s.wallet.ImportAddress(addr, lockingScript, viewKey, false, 0); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	txid, err := s.wallet.Spend(addr, types.Amount(req.Amount), types.Amount(req.FeePerKilobyte))
+	if err != nil {
+		return nil, err
+	}
+	return &pb.DelegateStakeResponse{
+		Address:        addr.String(),
+		LockingScript:  lockingScript.Serialize(),
+		ViewPrivateKey: viewKeyBytes,
+		Transaction_ID: txid[:],
+	}, nil
+}
+
+// RevokeDelegation spends the coins at a cold staking address back to the
+// wallet. This removes the stake from the delegate's validator.
+//
+// **Requires wallet to be unlocked**
+func (s *GrpcServer) RevokeDelegation(ctx context.Context, req *pb.RevokeDelegationRequest) (*pb.RevokeDelegationResponse, error) {
+	if _, err := decodeAddress(req.Address, s.chainParams); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	note, err := s.findContractNote(req.Address)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "delegated stake not found in wallet")
+	}
+	if !isDelegatedStake(note) {
+		return nil, status.Error(codes.InvalidArgument, "address is not a cold staking address")
+	}
+
+	keys, err := s.walletPrivateKeys()
+	if err != nil {
+		return nil, err
+	}
+	params := note.LockingScript.LockingParams
+	ownerKey, ownerAddr, ok := walletKeyForPubkey(keys, params[0], params[1])
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "the delegation's owner key is not in the wallet")
+	}
+
+	tx, err := s.buildDelegationRevoke(note, ownerAddr, ownerKey, types.Amount(req.FeePerKilobyte))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if err := s.broadcastTxFunc(tx); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	txid := tx.ID()
+	return &pb.RevokeDelegationResponse{Transaction_ID: txid[:]}, nil
+}
+
 // Spend sends coins from the wallet according to the provided parameters
 //
+// If the node is configured with a dust change threshold, change below the
+// threshold is added to the fee, or sent to the donation address, rather
+// than creating an output that costs more to spend than it is worth.
+//
+// The change is sent to the address picked by the node's change address
+// policy.
+//
+// If no input commitments are provided the inputs are selected using the
+// requested coin selection strategy.
+//
+// The funds can be sent to an address book contact or an ilx: payment URI
+// instead of an address.
+//
 // **Requires wallet to be unlocked**
 func (s *GrpcServer) Spend(ctx context.Context, req *pb.SpendRequest) (*pb.SpendResponse, error) {
 	commitments := make([]types.ID, 0, len(req.InputCommitments))
 	for _, c := range req.InputCommitments {
 		commitments = append(commitments, types.NewID(c))
 	}
-	addr, err := walletlib.DecodeAddress(req.ToAddress, s.chainParams)
+	if req.Payment_URI != "" {
+		if err := applyPaymentURI(req); err != nil {
+			return nil, err
+		}
+	}
+	addr, err := s.spendDestination(req.ToAddress, req.ToContact)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+		return nil, err
+	}
+	if len(commitments) == 0 && req.CoinSelection != pb.SpendRequest_DEFAULT {
+		selection, err := s.selectCoins(req.CoinSelection, types.Amount(req.Amount), types.Amount(req.FeePerKilobyte))
+		if err != nil {
+			return nil, err
+		}
+		if selection.noChange {
+			plan := &pb.TransactionPlan{
+				Fee: uint64(selection.total()) - req.Amount,
+				Outputs: []*pb.TransactionPlan_Output{
+					{
+						Address: addr.String(),
+						Amount:  req.Amount,
+					},
+				},
+			}
+			for _, note := range selection.notes {
+				plan.Inputs = append(plan.Inputs, &pb.TransactionPlan_Input{
+					Commitment: note.Commitment,
+					Amount:     note.Amount,
+				})
+			}
+			if req.DryRun {
+				return &pb.SpendResponse{Plan: plan}, nil
+			}
+			txid, err := s.spendPlan(ctx, plan)
+			if err != nil {
+				return nil, err
+			}
+			return s.spendResponse(txid), nil
+		}
+		req.InputCommitments = selection.commitments()
+		for _, c := range req.InputCommitments {
+			commitments = append(commitments, types.NewID(c))
+		}
+	}
+	// The wallet sends change to its current address so the transaction
+	// is built from a plan unless that's the policy.
+	reuseChange := s.changePolicy.AddressPolicy == ChangeAddressReuse
+	if req.DryRun || len(s.changePolicy.DustThresholds) > 0 || !reuseChange {
+		plan, err := s.planTransaction([]*walletlib.RawOutput{{Addr: addr, Amount: types.Amount(req.Amount)}}, req.InputCommitments, types.Amount(req.FeePerKilobyte))
+		if err != nil {
+			return nil, err
+		}
+		applied := s.applyChangePolicy(plan, types.IlliumCoinID)
+		if req.DryRun {
+			return &pb.SpendResponse{Plan: plan}, nil
+		}
+		if applied || !reuseChange {
+			txid, err := s.spendPlan(ctx, plan)
+			if err != nil {
+				return nil, err
+			}
+			return s.spendResponse(txid), nil
+		}
 	}
 	txid, err := s.wallet.Spend(addr, types.Amount(req.Amount), types.Amount(req.FeePerKilobyte), commitments...)
 	if err != nil {
 		return nil, err
 	}
-	return &pb.SpendResponse{Transaction_ID: txid[:]}, nil
+	return s.spendResponse(txid), nil
+}
+
+// spendDestination returns the address to spend to. Exactly one of the
+// address or an address book contact must be given.
+func (s *GrpcServer) spendDestination(toAddress, toContact string) (walletlib.Address, error) {
+	if toContact == "" {
+		addr, err := decodeAddress(toAddress, s.chainParams)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return addr, nil
+	}
+	if toAddress != "" {
+		return nil, status.Error(codes.InvalidArgument, "only one of to address or to contact may be set")
+	}
+	addr, err := resolveContact(s.ds, toContact, s.chainParams)
+	if errors.Is(err, errContactNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	} else if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return addr, nil
+}
+
+// applyPaymentURI sets the address and, if none was given, the amount of
+// the spend request from its payment URI.
+func applyPaymentURI(req *pb.SpendRequest) error {
+	if req.ToAddress != "" || req.ToContact != "" {
+		return status.Error(codes.InvalidArgument, "only one of to address, to contact, or payment uri may be set")
+	}
+	uri, err := types.ParsePaymentURI(req.Payment_URI)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if uri.Expired() {
+		return status.Error(codes.FailedPrecondition, "payment request has expired")
+	}
+	if req.Amount == 0 {
+		req.Amount = uint64(uri.Amount)
+	} else if uri.Amount > 0 && types.Amount(req.Amount) != uri.Amount {
+		return status.Error(codes.InvalidArgument, "amount does not match the payment uri amount")
+	}
+	if req.Amount == 0 {
+		return status.Error(codes.InvalidArgument, "payment uri has no amount")
+	}
+	req.ToAddress = uri.Address
+	return nil
+}
+
+func (s *GrpcServer) spendResponse(txid types.ID) *pb.SpendResponse {
+	resp := &pb.SpendResponse{Transaction_ID: txid[:]}
+	if tx, err := s.txMemPool.GetTransaction(txid); err == nil {
+		if expiry, ok := blockchain.TransactionExpiry(tx); ok {
+			resp.Expiry = expiry.Unix()
+		}
+	}
+	return resp
 }
 
 // TimelockCoins moves coins into a timelocked address using the requested timelock.
@@ -974,6 +2122,13 @@ func (s *GrpcServer) TimelockCoins(ctx context.Context, req *pb.TimelockCoinsReq
 	for _, c := range req.InputCommitments {
 		commitments = append(commitments, types.NewID(c))
 	}
+	if req.DryRun {
+		plan, err := s.planTimelock(types.Amount(req.Amount), time.Unix(req.LockUntil, 0), req.InputCommitments, types.Amount(req.FeePerKilobyte))
+		if err != nil {
+			return nil, err
+		}
+		return &pb.TimelockCoinsResponse{Plan: plan}, nil
+	}
 
 	txid, err := s.wallet.TimelockCoins(types.Amount(req.Amount), time.Unix(req.LockUntil, 0), types.Amount(req.FeePerKilobyte), commitments...)
 	if err != nil {
@@ -989,7 +2144,7 @@ func (s *GrpcServer) TimelockCoins(ctx context.Context, req *pb.TimelockCoinsReq
 //
 // **Requires wallet to be unlocked**
 func (s *GrpcServer) SweepWallet(ctx context.Context, req *pb.SweepWalletRequest) (*pb.SweepWalletResponse, error) {
-	addr, err := walletlib.DecodeAddress(req.ToAddress, s.chainParams)
+	addr, err := decodeAddress(req.ToAddress, s.chainParams)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
@@ -997,6 +2152,13 @@ func (s *GrpcServer) SweepWallet(ctx context.Context, req *pb.SweepWalletRequest
 	for _, c := range req.InputCommitments {
 		commitments = append(commitments, types.NewID(c))
 	}
+	if req.DryRun {
+		plan, err := s.planSweep(addr, req.InputCommitments, types.Amount(req.FeePerKilobyte))
+		if err != nil {
+			return nil, err
+		}
+		return &pb.SweepWalletResponse{Plan: plan}, nil
+	}
 	txid, err := s.wallet.SweepWallet(addr, types.Amount(req.FeePerKilobyte), commitments...)
 	if err != nil {
 		return nil, err
@@ -1004,6 +2166,423 @@ func (s *GrpcServer) SweepWallet(ctx context.Context, req *pb.SweepWalletRequest
 	return &pb.SweepWalletResponse{Transaction_ID: txid[:]}, nil
 }
 
+// StartSweepJob sweeps the coins from this wallet to the provided address
+// in the background, splitting the inputs across as many transactions as
+// needed to keep each under the per-transaction input limit.
+func (s *GrpcServer) StartSweepJob(ctx context.Context, req *pb.StartSweepJobRequest) (*pb.StartSweepJobResponse, error) {
+	if s.sweepJobs == nil {
+		return nil, status.Error(codes.Unavailable, "sweep jobs are not available")
+	}
+	job, err := s.sweepJobs.StartJob(req.ToAddress, types.Amount(req.FeePerKilobyte), req.InputCommitments, req.MaxInputsPerTx)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &pb.StartSweepJobResponse{Job: job}, nil
+}
+
+// GetSweepJobs returns the wallet's sweep jobs.
+func (s *GrpcServer) GetSweepJobs(ctx context.Context, req *pb.GetSweepJobsRequest) (*pb.GetSweepJobsResponse, error) {
+	if s.sweepJobs == nil {
+		return nil, status.Error(codes.Unavailable, "sweep jobs are not available")
+	}
+	jobs, err := s.sweepJobs.GetJobs()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.GetSweepJobsResponse{Jobs: jobs}, nil
+}
+
+// CancelSweepJob stops a running sweep job once the transaction it is
+// proving, if any, is finished.
+func (s *GrpcServer) CancelSweepJob(ctx context.Context, req *pb.CancelSweepJobRequest) (*pb.CancelSweepJobResponse, error) {
+	if s.sweepJobs == nil {
+		return nil, status.Error(codes.Unavailable, "sweep jobs are not available")
+	}
+	err := s.sweepJobs.CancelJob(types.NewID(req.Job_ID))
+	if errors.Is(err, errSweepJobNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	} else if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return &pb.CancelSweepJobResponse{}, nil
+}
+
+// ResumeSweepJob restarts a sweep job which failed or was canceled.
+func (s *GrpcServer) ResumeSweepJob(ctx context.Context, req *pb.ResumeSweepJobRequest) (*pb.ResumeSweepJobResponse, error) {
+	if s.sweepJobs == nil {
+		return nil, status.Error(codes.Unavailable, "sweep jobs are not available")
+	}
+	job, err := s.sweepJobs.ResumeJob(types.NewID(req.Job_ID))
+	if errors.Is(err, errSweepJobNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	} else if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return &pb.ResumeSweepJobResponse{Job: job}, nil
+}
+
+// InitiateSwap starts a cross chain atomic swap by locking coins in a
+// hash time locked contract with a new random secret.
+//
+// **Requires wallet to be unlocked**
+func (s *GrpcServer) InitiateSwap(ctx context.Context, req *pb.InitiateSwapRequest) (*pb.InitiateSwapResponse, error) {
+	secret, err := zk.RandomFieldElement()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	secretHash := sha256.Sum256(secret[:])
+
+	refundAfter := req.RefundAfter
+	if refundAfter == 0 {
+		refundAfter = time.Now().Add(DefaultSwapInitiatorRefundDuration).Unix()
+	}
+	swap, err := s.fundSwapContract(pb.AtomicSwap_INITIATOR, req.CounterpartyPublicKey, secretHash[:], secret[:], types.Amount(req.Amount), refundAfter, types.Amount(req.FeePerKilobyte))
+	if err != nil {
+		return nil, err
+	}
+	return &pb.InitiateSwapResponse{Swap: swap}, nil
+}
+
+// ParticipateSwap locks coins in a hash time locked contract using the
+// secret hash from a swap the counterparty initiated on another chain.
+//
+// **Requires wallet to be unlocked**
+func (s *GrpcServer) ParticipateSwap(ctx context.Context, req *pb.ParticipateSwapRequest) (*pb.ParticipateSwapResponse, error) {
+	if len(req.SecretHash) != sha256.Size {
+		return nil, status.Error(codes.InvalidArgument, "secret hash must be 32 bytes")
+	}
+	refundAfter := req.RefundAfter
+	if refundAfter == 0 {
+		refundAfter = time.Now().Add(DefaultSwapParticipantRefundDuration).Unix()
+	}
+	swap, err := s.fundSwapContract(pb.AtomicSwap_PARTICIPANT, req.CounterpartyPublicKey, req.SecretHash, nil, types.Amount(req.Amount), refundAfter, types.Amount(req.FeePerKilobyte))
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ParticipateSwapResponse{Swap: swap}, nil
+}
+
+// RedeemSwap claims the coins in a contract funded by the counterparty
+// using the secret.
+//
+// **Requires wallet to be unlocked**
+func (s *GrpcServer) RedeemSwap(ctx context.Context, req *pb.RedeemSwapRequest) (*pb.RedeemSwapResponse, error) {
+	if s.atomicSwaps == nil {
+		return nil, status.Error(codes.Unavailable, "atomic swaps are not available")
+	}
+	contractAddr, err := decodeAddress(req.ContractAddress, s.chainParams)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	var lockingScript types.LockingScript
+	if err := lockingScript.Deserialize(req.LockingScript); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	tmpl, err := zk.GetScriptTemplate(atomicSwapTemplate)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	scriptCommitment := tmpl.ScriptCommitment()
+	if len(scriptCommitment) == 0 {
+		return nil, status.Error(codes.Unavailable, zk.ErrProverUnavailable.Error())
+	}
+	if lockingScript.ScriptCommitment != types.NewID(scriptCommitment) || len(lockingScript.LockingParams) != 6 {
+		return nil, status.Error(codes.InvalidArgument, "locking script is not an atomic swap contract")
+	}
+	if len(req.Secret) != 32 {
+		return nil, status.Error(codes.InvalidArgument, "secret must be 32 bytes")
+	}
+	secretHash := sha256.Sum256(req.Secret)
+	if !bytes.Equal(zk.AtomicSwapHash(secretHash[:]), lockingScript.LockingParams[0]) {
+		return nil, status.Error(codes.InvalidArgument, "secret does not match the contract's hash")
+	}
+
+	keys, err := s.walletPrivateKeys()
+	if err != nil {
+		return nil, err
+	}
+	spendKey, walletAddr, ok := walletKeyForPubkey(keys, lockingScript.LockingParams[1], lockingScript.LockingParams[2])
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "the contract's claim key is not in the wallet")
+	}
+
+	if _, err := s.wallet.AddressInfo(contractAddr); err != nil {
+		viewKey, err := crypto.UnmarshalPrivateKey(req.ViewPrivateKey)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if err := s.wallet.ImportAddress(contractAddr, lockingScript, viewKey, true, req.RescanHeight); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.FailedPrecondition, "contract imported. Try again once the wallet finishes rescanning for the contract output")
+	}
+	note, err := s.findContractNote(contractAddr.String())
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	tx, err := s.buildSwapSpend(note, walletAddr, spendKey, req.Secret, 0, types.Amount(req.FeePerKilobyte))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if err := s.broadcastTxFunc(tx); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	txid := tx.ID()
+
+	var id types.ID
+	if _, err := rand.Read(id[:]); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	var refundAfter int64
+	if len(lockingScript.LockingParams[3]) == 8 {
+		refundAfter = int64(binary.BigEndian.Uint64(lockingScript.LockingParams[3]))
+	}
+	_, height, _ := s.chain.BestBlock()
+	swap := &pb.AtomicSwap{
+		Swap_ID:              id[:],
+		Role:                 pb.AtomicSwap_REDEEMER,
+		Status:               pb.AtomicSwap_REDEEMED,
+		SecretHash:           secretHash[:],
+		Secret:               req.Secret,
+		Amount:               note.Amount,
+		RefundAfter:          refundAfter,
+		ContractAddress:      contractAddr.String(),
+		LockingScript:        req.LockingScript,
+		ViewPrivateKey:       req.ViewPrivateKey,
+		WalletAddress:        walletAddr.String(),
+		RedeemTransaction_ID: txid[:],
+		Created:              time.Now().Unix(),
+		WatchHeight:          height,
+	}
+	if err := s.atomicSwaps.AddSwap(swap); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.RedeemSwapResponse{Swap: swap}, nil
+}
+
+// RefundSwap returns the coins in a contract funded by this wallet
+// after the refund time has passed.
+//
+// **Requires wallet to be unlocked**
+func (s *GrpcServer) RefundSwap(ctx context.Context, req *pb.RefundSwapRequest) (*pb.RefundSwapResponse, error) {
+	if s.atomicSwaps == nil {
+		return nil, status.Error(codes.Unavailable, "atomic swaps are not available")
+	}
+	id := types.NewID(req.Swap_ID)
+	swap, err := s.atomicSwaps.GetSwap(id)
+	if errors.Is(err, errAtomicSwapNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if swap.Role == pb.AtomicSwap_REDEEMER {
+		return nil, status.Error(codes.FailedPrecondition, "only contracts funded by this wallet can be refunded")
+	}
+	if swap.Status == pb.AtomicSwap_REFUNDED {
+		return nil, status.Error(codes.FailedPrecondition, "swap has already been refunded")
+	}
+	if time.Now().Unix() <= swap.RefundAfter {
+		return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("swap cannot be refunded until %s", time.Unix(swap.RefundAfter, 0)))
+	}
+
+	keys, err := s.walletPrivateKeys()
+	if err != nil {
+		return nil, err
+	}
+	spendKey, ok := spendKeyForAddress(keys, swap.WalletAddress)
+	if !ok {
+		return nil, status.Error(codes.Internal, "the contract's refund key is not in the wallet")
+	}
+	toAddr, err := decodeAddress(swap.WalletAddress, s.chainParams)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	note, err := s.findContractNote(swap.ContractAddress)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	tx, err := s.buildSwapSpend(note, toAddr, spendKey, nil, swap.RefundAfter, types.Amount(req.FeePerKilobyte))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if err := s.broadcastTxFunc(tx); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	swap, err = s.atomicSwaps.SetRefunded(id, tx.ID())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.RefundSwapResponse{Swap: swap}, nil
+}
+
+// GetSwaps returns the wallet's atomic swaps.
+func (s *GrpcServer) GetSwaps(ctx context.Context, req *pb.GetSwapsRequest) (*pb.GetSwapsResponse, error) {
+	if s.atomicSwaps == nil {
+		return nil, status.Error(codes.Unavailable, "atomic swaps are not available")
+	}
+	swaps, err := s.atomicSwaps.GetSwaps()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.GetSwapsResponse{Swaps: swaps}, nil
+}
+
+// SaveSpendTemplate saves a named spend template that can later be
+// executed with SpendFromTemplate. If a template with the same name
+// already exists it is overwritten.
+func (s *GrpcServer) SaveSpendTemplate(ctx context.Context, req *pb.SaveSpendTemplateRequest) (*pb.SaveSpendTemplateResponse, error) {
+	if req.Template == nil {
+		return nil, status.Error(codes.InvalidArgument, "template is nil")
+	}
+	if err := validateSpendTemplate(req.Template, s.chainParams); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := putSpendTemplate(s.ds, req.Template); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.SaveSpendTemplateResponse{}, nil
+}
+
+// GetSpendTemplates returns all the saved spend templates.
+func (s *GrpcServer) GetSpendTemplates(ctx context.Context, req *pb.GetSpendTemplatesRequest) (*pb.GetSpendTemplatesResponse, error) {
+	templates, err := getSpendTemplates(s.ds)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.GetSpendTemplatesResponse{
+		Templates: templates,
+	}, nil
+}
+
+// DeleteSpendTemplate deletes a saved spend template.
+func (s *GrpcServer) DeleteSpendTemplate(ctx context.Context, req *pb.DeleteSpendTemplateRequest) (*pb.DeleteSpendTemplateResponse, error) {
+	if _, err := getSpendTemplate(s.ds, req.Name); errors.Is(err, datastore.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, "template not found")
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if err := deleteSpendTemplate(s.ds, req.Name); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.DeleteSpendTemplateResponse{}, nil
+}
+
+// SpendFromTemplate builds, proves, and broadcasts a transaction paying
+// the recipients in the named spend template.
+//
+// **Requires wallet to be unlocked**
+func (s *GrpcServer) SpendFromTemplate(ctx context.Context, req *pb.SpendFromTemplateRequest) (*pb.SpendFromTemplateResponse, error) {
+	template, err := getSpendTemplate(s.ds, req.Name)
+	if errors.Is(err, datastore.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, "template not found")
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	// Percentage recipients are resolved against the balance at the
+	// time the template is executed.
+	var total types.Amount
+	for _, r := range template.Recipients {
+		if r.BalancePercent > 0 {
+			notes, err := s.wallet.Notes()
+			if err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+			total = spendableTotal(notes, template.InputCommitments, time.Now())
+			break
+		}
+	}
+
+	createReq := &pb.CreateRawTransactionRequest{
+		AppendChangeOutput: true,
+		FeePerKilobyte:     template.FeePerKilobyte,
+	}
+	for _, c := range template.InputCommitments {
+		createReq.Inputs = append(createReq.Inputs, &pb.CreateRawTransactionRequest_Input{
+			CommitmentOrPrivateInput: &pb.CreateRawTransactionRequest_Input_Commitment{
+				Commitment: c,
+			},
+		})
+	}
+	for i, r := range template.Recipients {
+		amount := r.Amount
+		if r.BalancePercent > 0 {
+			amount = uint64(float64(total) * r.BalancePercent / 100)
+		}
+		if amount == 0 {
+			return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("recipient %d amount is zero", i))
+		}
+		createReq.Outputs = append(createReq.Outputs, &pb.CreateRawTransactionRequest_Output{
+			Address: r.Address,
+			Amount:  amount,
+		})
+	}
+
+	rawResp, err := s.CreateRawTransaction(ctx, createReq)
+	if err != nil {
+		return nil, err
+	}
+	proveResp, err := s.ProveRawTransaction(ctx, &pb.ProveRawTransactionRequest{
+		RawTx: rawResp.RawTx,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.broadcastTxFunc(proveResp.ProvedTx); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	txid := proveResp.ProvedTx.ID()
+	return &pb.SpendFromTemplateResponse{
+		Transaction_ID: txid[:],
+		Expiry:         rawResp.Expiry,
+	}, nil
+}
+
+// AddContact saves an address in the wallet's address book under a name.
+// Names are case insensitive and must be unique. The address must be
+// valid for the node's network.
+func (s *GrpcServer) AddContact(ctx context.Context, req *pb.AddContactRequest) (*pb.AddContactResponse, error) {
+	if err := validateContactName(req.Name, s.chainParams); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	addr, err := decodeAddress(req.Address, s.chainParams)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	contact := &pb.Contact{
+		Name:    req.Name,
+		Address: addr.String(),
+		Created: time.Now().Unix(),
+	}
+	if err := addContact(s.ds, contact); errors.Is(err, errContactExists) {
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.AddContactResponse{Contact: contact}, nil
+}
+
+// ListContacts returns the contacts in the address book sorted by name.
+func (s *GrpcServer) ListContacts(ctx context.Context, req *pb.ListContactsRequest) (*pb.ListContactsResponse, error) {
+	contacts, err := getContacts(s.ds)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.ListContactsResponse{Contacts: contacts}, nil
+}
+
+// RemoveContact removes a contact from the address book.
+func (s *GrpcServer) RemoveContact(ctx context.Context, req *pb.RemoveContactRequest) (*pb.RemoveContactResponse, error) {
+	if err := deleteContact(s.ds, req.Name); errors.Is(err, errContactNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.RemoveContactResponse{}, nil
+}
+
 // SubscribeWalletTransactions subscribes to a stream of WalletTransactionsNotifications that return
 // whenever a transaction belonging to the wallet finalizes.
 func (s *GrpcServer) SubscribeWalletTransactions(req *pb.SubscribeWalletTransactionsRequest, stream pb.WalletService_SubscribeWalletTransactionsServer) error {
@@ -1014,13 +2593,12 @@ func (s *GrpcServer) SubscribeWalletTransactions(req *pb.SubscribeWalletTransact
 		select {
 		case walletTx := <-sub.C:
 			if walletTx != nil {
-				err := stream.Send(&pb.WalletTransactionNotification{
-					Transaction: &pb.WalletTransaction{
-						Transaction_ID: walletTx.Txid.Bytes(),
-						NetCoins:       int64(walletTx.AmountIn) - int64(walletTx.AmountOut),
-						Inputs:         ioToPBio(walletTx.Inputs),
-						Outputs:        ioToPBio(walletTx.Outputs),
-					},
+				wtx, err := s.walletTransactionToPB(walletTx)
+				if err != nil {
+					return status.Error(codes.Internal, err.Error())
+				}
+				err = stream.Send(&pb.WalletTransactionNotification{
+					Transaction: wtx,
 					Block_ID:    walletTx.BlockID.Bytes(),
 					BlockHeight: walletTx.BlockHeight,
 				})
@@ -1036,6 +2614,144 @@ func (s *GrpcServer) SubscribeWalletTransactions(req *pb.SubscribeWalletTransact
 	}
 }
 
+// SubscribeTransactionEvents streams an event whenever a transaction paying to
+// or spending from the wallet finalizes. The event says whether the transaction
+// is incoming or outgoing. It's followed by a confirmation event for each new
+// block until the transaction reaches the requested number of confirmations.
+// An event is also sent when one of the wallet's staked utxos expires or is
+// slashed and removed from the validator set.
+func (s *GrpcServer) SubscribeTransactionEvents(req *pb.SubscribeTransactionEventsRequest, stream pb.WalletService_SubscribeTransactionEventsServer) error {
+	sub := s.wallet.SubscribeTransactions()
+	defer sub.Close()
+
+	blockSub := s.subscribeEvents()
+	defer blockSub.Close()
+
+	// Transactions which still need confirmation events.
+	pending := make(map[types.ID]*pb.TransactionEvent)
+
+	for {
+		select {
+		case walletTx := <-sub.C:
+			if walletTx == nil {
+				continue
+			}
+			wtx, err := s.walletTransactionToPB(walletTx)
+			if err != nil {
+				return status.Error(codes.Internal, err.Error())
+			}
+			// The wallet may be catching up to the chain in
+			// which case the transaction is already buried.
+			confirmations := uint32(1)
+			if _, height, _ := s.chain.BestBlock(); height > walletTx.BlockHeight {
+				confirmations = height - walletTx.BlockHeight + 1
+			}
+			event := &pb.TransactionEvent{
+				Type:          pb.TransactionEvent_INCOMING,
+				Transaction:   wtx,
+				Block_ID:      walletTx.BlockID.Bytes(),
+				BlockHeight:   walletTx.BlockHeight,
+				Confirmations: confirmations,
+			}
+			if wtx.NetCoins < 0 {
+				event.Type = pb.TransactionEvent_OUTGOING
+			}
+			if err := stream.Send(event); err == io.EOF {
+				return nil
+			} else if err != nil {
+				return status.Error(codes.InvalidArgument, err.Error())
+			}
+			if confirmations < req.Confirmations {
+				pending[walletTx.Txid] = event
+			}
+		case n := <-blockSub.C:
+			if event, ok := n.(*pb.TransactionEvent); ok {
+				if err := stream.Send(event); err == io.EOF {
+					return nil
+				} else if err != nil {
+					return status.Error(codes.InvalidArgument, err.Error())
+				}
+				continue
+			}
+			notif, ok := n.(*blockchain.Notification)
+			if !ok || notif.Type != blockchain.NTBlockConnected {
+				continue
+			}
+			blk, ok := notif.Data.(*blocks.Block)
+			if !ok {
+				continue
+			}
+			for txid, event := range pending {
+				// The wallet and the chain notifications are not
+				// ordered so the block containing the transaction
+				// may arrive after the transaction.
+				if blk.Header.Height <= event.BlockHeight+event.Confirmations-1 {
+					continue
+				}
+				event.Type = pb.TransactionEvent_CONFIRMATION
+				event.Confirmations = blk.Header.Height - event.BlockHeight + 1
+				if err := stream.Send(event); err == io.EOF {
+					return nil
+				} else if err != nil {
+					return status.Error(codes.InvalidArgument, err.Error())
+				}
+				if event.Confirmations >= req.Confirmations {
+					delete(pending, txid)
+				}
+			}
+		case <-stream.Context().Done():
+			return nil
+		case <-s.quit:
+			return nil
+		}
+	}
+}
+
+// walletTransactionToPB converts the wallet transaction to its protobuf
+// representation, including the customer IDs and labels of its outputs.
+func (s *GrpcServer) walletTransactionToPB(walletTx *walletlib.WalletTransaction) (*pb.WalletTransaction, error) {
+	wtx := &pb.WalletTransaction{
+		Transaction_ID: walletTx.Txid.Bytes(),
+		NetCoins:       int64(walletTx.AmountIn) - int64(walletTx.AmountOut),
+		Inputs:         ioToPBio(walletTx.Inputs),
+		Outputs:        ioToPBio(walletTx.Outputs),
+	}
+	if err := setCustomerIDs(s.ds, append(wtx.Inputs, wtx.Outputs...)); err != nil {
+		return nil, err
+	}
+	if err := setAddressLabels(s.ds, append(wtx.Inputs, wtx.Outputs...)); err != nil {
+		return nil, err
+	}
+	return wtx, nil
+}
+
+// GetRescanStatus returns the progress of the wallet's current or most
+// recent rescan.
+func (s *GrpcServer) GetRescanStatus(ctx context.Context, req *pb.GetRescanStatusRequest) (*pb.GetRescanStatusResponse, error) {
+	if s.walletRescanFunc == nil {
+		return nil, status.Error(codes.Unavailable, "rescan status is not available")
+	}
+	rescan := s.walletRescanFunc()
+	resp := &pb.GetRescanStatusResponse{
+		Rescanning:    rescan.Rescanning,
+		StartHeight:   rescan.StartHeight,
+		ScannedHeight: rescan.ScannedHeight,
+		BestHeight:    rescan.BestHeight,
+	}
+	if !rescan.Started.IsZero() {
+		resp.Started = rescan.Started.Unix()
+	}
+	if !rescan.Finished.IsZero() {
+		resp.Finished = rescan.Finished.Unix()
+	}
+	scanned := rescan.ScannedHeight - rescan.StartHeight
+	if rescan.Rescanning && scanned > 0 && rescan.BestHeight > rescan.ScannedHeight {
+		perBlock := time.Since(rescan.Started) / time.Duration(scanned)
+		resp.RemainingSeconds = int64((perBlock * time.Duration(rescan.BestHeight-rescan.ScannedHeight)).Seconds())
+	}
+	return resp, nil
+}
+
 // SubscribeWalletSyncNotifications streams notifications about the status of the wallet sync.
 func (s *GrpcServer) SubscribeWalletSyncNotifications(req *pb.SubscribeWalletSyncNotificationsRequest, stream pb.WalletService_SubscribeWalletSyncNotificationsServer) error {
 	sub := s.wallet.SubscribeSyncNotifications()
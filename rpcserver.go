@@ -6,18 +6,26 @@ package main
 
 import (
 	"context"
+	"crypto/subtle"
 	"errors"
 	"github.com/improbable-eng/grpc-web/go/grpcweb"
 	"github.com/multiformats/go-multiaddr"
 	manet "github.com/multiformats/go-multiaddr/net"
 	"github.com/project-illium/ilxd/repo"
 	"github.com/project-illium/ilxd/rpc"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	_ "google.golang.org/grpc/encoding/gzip" // Registers the gzip compressor so clients can request it
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 	"net/http"
+	"net/http/pprof"
+	"strings"
 )
 
 // AuthenticationTokenKey is the key used in the context to authenticate clients.
@@ -25,14 +33,22 @@ import (
 // the client to set a key value in the context metadata to 'AuthenticationToken: cfg.AuthToken'
 const AuthenticationTokenKey = "AuthenticationToken"
 
-func newGrpcServer(cfgOpts repo.RPCOptions, rpcCfg *rpc.GrpcServerConfig) (*rpc.GrpcServer, error) {
-	i := interceptor{authToken: cfgOpts.GrpcAuthToken}
+// errWalletReadOnly is returned for wallet methods that are not served
+// when the node is a wallet read replica.
+var errWalletReadOnly = status.Error(codes.PermissionDenied, "wallet service is read only on this node")
+
+func newGrpcServer(cfgOpts repo.RPCOptions, metricsHandler http.Handler, rpcCfg *rpc.GrpcServerConfig, registerServices func(server *grpc.Server) error) (*rpc.GrpcServer, error) {
+	i := interceptor{
+		authToken:      cfgOpts.GrpcAuthToken,
+		publicReadOnly: cfgOpts.GrpcPublicReadOnly,
+		walletReadOnly: cfgOpts.WalletReadOnly,
+	}
 	opts := []grpc.ServerOption{grpc.StreamInterceptor(i.interceptStreaming), grpc.UnaryInterceptor(i.interceptUnary)}
 	creds, err := credentials.NewServerTLSFromFile(cfgOpts.RPCCert, cfgOpts.RPCKey)
 	if err != nil {
 		return nil, err
 	}
-	opts = append(opts, grpc.Creds(creds), grpc.MaxSendMsgSize(1000000))
+	opts = append(opts, grpc.Creds(creds), grpc.MaxRecvMsgSize(cfgOpts.GrpcMaxRecvMsgSize), grpc.MaxSendMsgSize(cfgOpts.GrpcMaxSendMsgSize))
 	server := grpc.NewServer(opts...)
 
 	allowAllOrigins := grpcweb.WithOriginFunc(func(origin string) bool {
@@ -42,8 +58,25 @@ func newGrpcServer(cfgOpts repo.RPCOptions, rpcCfg *rpc.GrpcServerConfig) (*rpc.
 
 	rpcCfg.Server = server
 
+	var profilingMux *http.ServeMux
+	if cfgOpts.EnableProfiling {
+		profilingMux = newProfilingMux()
+	}
+
 	handler := func(resp http.ResponseWriter, req *http.Request) {
-		if wrappedGrpc.IsGrpcWebRequest(req) || wrappedGrpc.IsAcceptableGrpcCorsRequest(req) {
+		if profilingMux != nil && strings.HasPrefix(req.URL.Path, "/debug/pprof") {
+			if cfgOpts.GrpcAuthToken == "" || !tokenMatches(req.Header.Get(AuthenticationTokenKey), cfgOpts.GrpcAuthToken) {
+				http.Error(resp, "invalid authentication token", http.StatusUnauthorized)
+				return
+			}
+			profilingMux.ServeHTTP(resp, req)
+		} else if metricsHandler != nil && req.URL.Path == "/metrics" {
+			if cfgOpts.GrpcAuthToken != "" && !tokenMatches(req.Header.Get(AuthenticationTokenKey), cfgOpts.GrpcAuthToken) {
+				http.Error(resp, "invalid authentication token", http.StatusUnauthorized)
+				return
+			}
+			metricsHandler.ServeHTTP(resp, req)
+		} else if wrappedGrpc.IsGrpcWebRequest(req) || wrappedGrpc.IsAcceptableGrpcCorsRequest(req) {
 			wrappedGrpc.ServeHTTP(resp, req)
 		} else {
 			server.ServeHTTP(resp, req)
@@ -68,6 +101,15 @@ func newGrpcServer(cfgOpts repo.RPCOptions, rpcCfg *rpc.GrpcServerConfig) (*rpc.
 	rpcCfg.HTTPServer = httpServer
 
 	gRPCServer := rpc.NewGrpcServer(rpcCfg)
+	i.walletAccess = gRPCServer.CheckWalletAccess
+
+	// Additional services must be registered before the server
+	// starts serving.
+	if registerServices != nil {
+		if err := registerServices(server); err != nil {
+			return nil, err
+		}
+	}
 
 	go func() {
 		if err := httpServer.ListenAndServeTLS(cfgOpts.RPCCert, cfgOpts.RPCKey); err != nil {
@@ -77,8 +119,55 @@ func newGrpcServer(cfgOpts repo.RPCOptions, rpcCfg *rpc.GrpcServerConfig) (*rpc.
 	return gRPCServer, nil
 }
 
+// newProfilingMux returns a mux serving the standard pprof and
+// execution trace endpoints.
+func newProfilingMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	return mux
+}
+
 type interceptor struct {
-	authToken string
+	authToken      string
+	publicReadOnly bool
+	walletReadOnly bool
+
+	// walletAccess checks the wallet's view only unlock tier.
+	// It's set once the gRPC server is created.
+	walletAccess func(fullMethod string) error
+}
+
+// checkWalletAccess returns an error if the wallet's unlock state does
+// not permit the method to be called.
+func (i *interceptor) checkWalletAccess(fullMethod string) error {
+	if i.walletAccess == nil {
+		return nil
+	}
+	return i.walletAccess(fullMethod)
+}
+
+// authenticate checks the auth token unless the method is one of the
+// public read only methods and they are enabled.
+func (i *interceptor) authenticate(ctx context.Context, fullMethod string) error {
+	if i.publicReadOnly && rpc.IsPublicMethod(fullMethod) {
+		return nil
+	}
+	return validateAuthenticationToken(ctx, i.authToken)
+}
+
+// tracedServerStream overrides the stream context so that streaming
+// handlers inherit the span started by the interceptor.
+type tracedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *tracedServerStream) Context() context.Context {
+	return s.ctx
 }
 
 func (i *interceptor) interceptStreaming(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
@@ -90,12 +179,27 @@ func (i *interceptor) interceptStreaming(srv interface{}, ss grpc.ServerStream,
 		}))
 	}
 
-	err := validateAuthenticationToken(ss.Context(), i.authToken)
+	err := i.authenticate(ss.Context(), info.FullMethod)
 	if err != nil {
 		return err
 	}
+	if i.walletReadOnly && !rpc.WalletReplicaAllows(info.FullMethod) {
+		return errWalletReadOnly
+	}
+	if err := i.checkWalletAccess(info.FullMethod); err != nil {
+		return err
+	}
+
+	ctx, span := tracer.Start(ss.Context(), info.FullMethod,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attribute.String("rpc.method", info.FullMethod)))
+	defer span.End()
 
-	err = handler(srv, ss)
+	err = handler(srv, &tracedServerStream{ServerStream: ss, ctx: ctx})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, err.Error())
+	}
 	if err != nil && ok {
 		st, ok := status.FromError(err)
 		if ok {
@@ -125,12 +229,27 @@ func (i *interceptor) interceptUnary(ctx context.Context, req interface{}, info
 		}))
 	}
 
-	err = validateAuthenticationToken(ctx, i.authToken)
+	err = i.authenticate(ctx, info.FullMethod)
 	if err != nil {
 		return nil, err
 	}
+	if i.walletReadOnly && !rpc.WalletReplicaAllows(info.FullMethod) {
+		return nil, errWalletReadOnly
+	}
+	if err := i.checkWalletAccess(info.FullMethod); err != nil {
+		return nil, err
+	}
+
+	ctx, span := tracer.Start(ctx, info.FullMethod,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attribute.String("rpc.method", info.FullMethod)))
+	defer span.End()
 
 	resp, err = handler(ctx, req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, err.Error())
+	}
 	if err != nil && ok {
 		st, ok := status.FromError(err)
 		if ok {
@@ -153,8 +272,14 @@ func (i *interceptor) interceptUnary(ctx context.Context, req interface{}, info
 
 func validateAuthenticationToken(ctx context.Context, authToken string) error {
 	md, ok := metadata.FromIncomingContext(ctx)
-	if authToken != "" && (!ok || len(md.Get(AuthenticationTokenKey)) == 0 || md.Get(AuthenticationTokenKey)[0] != authToken) {
+	if authToken != "" && (!ok || len(md.Get(AuthenticationTokenKey)) == 0 || !tokenMatches(md.Get(AuthenticationTokenKey)[0], authToken)) {
 		return errors.New("invalid authentication token")
 	}
 	return nil
 }
+
+// tokenMatches compares the tokens in constant time so the
+// response time doesn't leak how much of the token was correct.
+func tokenMatches(token, authToken string) bool {
+	return subtle.ConstantTimeCompare([]byte(token), []byte(authToken)) == 1
+}
@@ -20,12 +20,16 @@ import (
 	"github.com/project-illium/ilxd/blockchain"
 	"github.com/project-illium/ilxd/blockchain/indexers"
 	"github.com/project-illium/ilxd/consensus"
+	"github.com/project-illium/ilxd/evidence"
 	"github.com/project-illium/ilxd/gen"
+	"github.com/project-illium/ilxd/invoice"
 	"github.com/project-illium/ilxd/mempool"
 	"github.com/project-illium/ilxd/net"
 	params "github.com/project-illium/ilxd/params"
+	"github.com/project-illium/ilxd/plugins"
 	policy2 "github.com/project-illium/ilxd/policy"
 	"github.com/project-illium/ilxd/policy/protocol"
+	"github.com/project-illium/ilxd/recovery"
 	"github.com/project-illium/ilxd/repo"
 	"github.com/project-illium/ilxd/rpc"
 	"github.com/project-illium/ilxd/sync"
@@ -36,8 +40,13 @@ import (
 	"github.com/project-illium/logger"
 	"github.com/project-illium/walletlib"
 	"github.com/project-illium/walletlib/client"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/pterm/pterm"
+	"math"
 	mrand "math/rand"
+	"net/http"
 	"path"
 	"path/filepath"
 	"sort"
@@ -49,6 +58,7 @@ const (
 	maxOrphanDuration     = time.Hour
 	maxOrphans            = 100
 	orphanResyncThreshold = 5
+	syncStallTimeout      = time.Minute * 5
 )
 
 var log = logger.DisabledLogger.WithLevel(pterm.LogLevelDisabled)
@@ -71,12 +81,19 @@ type Server struct {
 	blockchain   *blockchain.Blockchain
 	mempool      *mempool.Mempool
 	engine       *consensus.ConsensusEngine
+	recorder     *consensus.TrafficRecorder
 	chainService *sync.ChainService
 	syncManager  *sync.SyncManager
 	generator    *gen.BlockGenerator
 	grpcServer   *rpc.GrpcServer
+	plugins      *plugins.Manager
+	extIndexes   *indexers.ExternalIndexManager
+	supervisor   *supervisor
 	wallet       *walletlib.Wallet
 	coinbaseAddr walletlib.Address
+	evidence     *evidence.Store
+	valConn      *net.ValidatorConnector
+	stallMonitor *stallMonitor
 
 	orphanBlocks map[types.ID]*orphanBlock
 	orphanLock   stdsync.RWMutex
@@ -85,6 +102,7 @@ type Server struct {
 	inventoryLock   stdsync.RWMutex
 
 	submittedTxs     map[types.ID]struct{}
+	packageTxs       map[types.ID]struct{}
 	submittedTxsLock stdsync.RWMutex
 
 	inflightRequests map[types.ID]bool
@@ -94,6 +112,8 @@ type Server struct {
 	autoStakeLock    stdsync.RWMutex
 	coinbasesToStake map[types.ID]struct{}
 	networkKey       crypto.PrivKey
+	provingTimer     *provingTimer
+	shutdownTracing  func(context.Context) error
 
 	ready chan struct{}
 }
@@ -116,6 +136,10 @@ func BuildServer(config *repo.Config) (*Server, error) {
 		golog.SetDebugLogging()
 	}
 
+	// Crash reporting
+	recovery.SetReportDirectory(path.Join(config.LogDir, "crash_reports"))
+	recovery.SetRestartsEnabled(!config.NoCrashRestart)
+
 	// Parameter selection
 	var netParams *params.NetworkParams
 	if config.Testnet {
@@ -141,6 +165,34 @@ func BuildServer(config *repo.Config) (*Server, error) {
 		netParams.Checkpoints = append(netParams.Checkpoints, cp)
 	}
 
+	if config.Avalanche != (repo.AvalancheOptions{}) && netParams.Name == params.MainnetParams.Name {
+		return nil, errors.New("avalanche options cannot be overridden on mainnet")
+	}
+	if config.Avalanche.FinalizationScore > 0 {
+		if config.Avalanche.FinalizationScore > math.MaxInt16 {
+			return nil, fmt.Errorf("finalizationscore must be at most %d", math.MaxInt16)
+		}
+		netParams.AvalancheFinalizationScore = config.Avalanche.FinalizationScore
+	}
+	if config.Avalanche.MaxInflightPoll > 0 {
+		netParams.AvalancheMaxInflightPoll = config.Avalanche.MaxInflightPoll
+	}
+	if config.Avalanche.RequestTimeout > 0 {
+		netParams.AvalancheRequestTimeout = config.Avalanche.RequestTimeout
+	}
+	if config.Avalanche.PollInterval > 0 {
+		netParams.AvalanchePollInterval = config.Avalanche.PollInterval
+	}
+
+	// Tracing
+	if config.Tracing.Endpoint != "" {
+		shutdown, err := setupTracing(ctx, config.Tracing, netParams.Name)
+		if err != nil {
+			return nil, err
+		}
+		s.shutdownTracing = shutdown
+	}
+
 	var (
 		prover   zk.Prover
 		verifier zk.Verifier
@@ -149,6 +201,16 @@ func BuildServer(config *repo.Config) (*Server, error) {
 		if !netParams.AllowMockProofs {
 			return nil, errors.New("mock proofs not allowed with network params selection")
 		}
+		log.Warn("Mock proofs enabled. Transaction proofs will not be verified")
+		prover = &zk.MockProver{}
+		v := &zk.MockVerifier{}
+		v.SetValid(true)
+		verifier = v
+	} else if err := zk.LoadProverLibrary(); err != nil {
+		if !netParams.AllowMockProofs {
+			return nil, fmt.Errorf("%s. Proofs cannot be verified on %s without it", err, netParams.Name)
+		}
+		log.Warn("Rust prover library could not be loaded. Falling back to mock proofs", log.Args("error", err))
 		prover = &zk.MockProver{}
 		v := &zk.MockVerifier{}
 		v.SetValid(true)
@@ -159,8 +221,16 @@ func BuildServer(config *repo.Config) (*Server, error) {
 		zk.LoadZKPublicParameters()
 		fmt.Print("\033[A\033[K")
 		loadingSpinner.Success("Loaded parameters")
-		prover = &zk.LurkProver{}
+		s.provingTimer = newProvingTimer(&zk.LurkProver{})
+		prover = s.provingTimer
 		verifier = &zk.LurkVerifier{}
+		if config.ProofCacheSize > 0 {
+			cache, err := zk.NewProofCache(path.Join(config.DataDir, "proofcache"), config.ProofCacheSize)
+			if err != nil {
+				return nil, err
+			}
+			prover = zk.NewCachingProver(prover, cache)
+		}
 	}
 
 	if config.CoinbaseAddress != "" {
@@ -171,6 +241,39 @@ func BuildServer(config *repo.Config) (*Server, error) {
 		s.coinbaseAddr = addr
 	}
 
+	invoiceAddrs := make([]walletlib.Address, 0, len(config.InvoiceAddresses))
+	for _, a := range config.InvoiceAddresses {
+		addr, err := walletlib.DecodeAddress(a, netParams)
+		if err != nil {
+			return nil, err
+		}
+		invoiceAddrs = append(invoiceAddrs, addr)
+	}
+
+	dustThresholds, err := rpc.ParseDustThresholds(config.DustChange)
+	if err != nil {
+		return nil, err
+	}
+	changeAddrPolicy, err := rpc.ParseChangeAddressPolicy(config.ChangeAddress)
+	if err != nil {
+		return nil, err
+	}
+	changePolicy := rpc.ChangePolicy{
+		DustThresholds: dustThresholds,
+		AddressPolicy:  changeAddrPolicy,
+	}
+	if config.DonationAddress != "" {
+		addr, err := walletlib.DecodeAddress(config.DonationAddress, netParams)
+		if err != nil {
+			return nil, err
+		}
+		changePolicy.DonationAddress = addr
+	}
+
+	if err := validateStallWebhooks(config.StallWebhooks); err != nil {
+		return nil, err
+	}
+
 	// Setup up badger datastore
 	badgerOpts := &badger.DefaultOptions
 	badgerOpts.MaxTableSize = 256 << 20
@@ -206,6 +309,9 @@ func BuildServer(config *repo.Config) (*Server, error) {
 		txIndex     *indexers.TxIndex
 		wsIndex     *indexers.WalletServerIndex
 		addrIndex   *indexers.AddrIndex
+		feeIndex    *indexers.FeeIndex
+		filterIndex *indexers.BlockFilterIndex
+		scriptIndex *indexers.ScriptIndex
 	)
 	if !config.NoTxIndex && !config.DropTxIndex {
 		txIndex = indexers.NewTxIndex()
@@ -228,6 +334,21 @@ func BuildServer(config *repo.Config) (*Server, error) {
 		indexerList = append(indexerList, addrIndex)
 	}
 
+	if !config.NoFeeIndex && !config.DropFeeIndex && !config.Prune {
+		feeIndex = indexers.NewFeeIndex()
+		indexerList = append(indexerList, feeIndex)
+	}
+
+	if config.BlockFilterIndex && !config.DropFilterIndex && !config.Prune {
+		filterIndex = indexers.NewBlockFilterIndex()
+		indexerList = append(indexerList, filterIndex)
+	}
+
+	if config.ScriptIndex && !config.DropScriptIndex {
+		scriptIndex = indexers.NewScriptIndex()
+		indexerList = append(indexerList, scriptIndex)
+	}
+
 	if config.WSIndex && config.NoTxIndex {
 		return nil, errors.New("tx index must be used with wallet server index")
 	}
@@ -243,6 +364,7 @@ func BuildServer(config *repo.Config) (*Server, error) {
 		blockchain.SignatureCache(sigCache),
 		blockchain.SnarkProofCache(proofCache),
 		blockchain.Verifier(verifier),
+		blockchain.ForkAlertDepth(config.ForkAlertDepth),
 	}
 
 	if config.Prune {
@@ -269,19 +391,47 @@ func BuildServer(config *repo.Config) (*Server, error) {
 			return nil, err
 		}
 	}
+	if config.DropFeeIndex {
+		if err := indexers.DropFeeIndex(ds); err != nil {
+			return nil, err
+		}
+	}
+	if config.DropFilterIndex {
+		if err := indexers.DropBlockFilterIndex(ds); err != nil {
+			return nil, err
+		}
+	}
+	if config.DropScriptIndex {
+		if err := indexers.DropScriptIndex(ds); err != nil {
+			return nil, err
+		}
+	}
 
 	chain, err := blockchain.NewBlockchain(blockchainOpts...)
 	if err != nil {
 		return nil, err
 	}
 
-	// Create wallet
+	if wsIndex != nil {
+		if err := wsIndex.ResumeRescans(ds, chain.GetBlockByHeight); err != nil {
+			return nil, err
+		}
+	}
+
+	// Create wallet. The datastore is opened here so the RPC server
+	// can repair the wallet's notes.
+	walletDs, err := badger.NewDatastore(config.WalletDir, &badger.DefaultOptions)
+	if err != nil {
+		return nil, err
+	}
+	walletBlocks := newWalletBlockSource(chain)
 	walletOpts := []walletlib.Option{
 		walletlib.Prover(prover),
 		walletlib.DataDir(config.WalletDir),
+		walletlib.Datastore(walletDs),
 		walletlib.Params(netParams),
 		walletlib.FeePerKB(policy.GetMinFeePerKilobyte()),
-		walletlib.BlockchainSource(s.makeBlockchainClient(chain)),
+		walletlib.BlockchainSource(s.makeBlockchainClient(chain, walletBlocks)),
 		walletlib.Logger(log),
 	}
 	if config.WalletSeed != "" {
@@ -291,6 +441,12 @@ func BuildServer(config *repo.Config) (*Server, error) {
 	if err != nil {
 		return nil, err
 	}
+	walletBlocks.setWallet(wallet)
+	if config.WalletUnlockFile != "" {
+		if err := unlockWalletFromFile(wallet, config); err != nil {
+			return nil, err
+		}
+	}
 
 	// Load or create the private key for the node
 	var privKey crypto.PrivKey
@@ -358,6 +514,7 @@ func BuildServer(config *repo.Config) (*Server, error) {
 		mempool.BlockchainView(chain),
 		mempool.Policy(policy),
 		mempool.Verifier(verifier),
+		mempool.TimeSource(chain.TimeSource()),
 	}
 
 	mpool, err := mempool.NewMempool(mempoolOpts...)
@@ -369,12 +526,16 @@ func BuildServer(config *repo.Config) (*Server, error) {
 	networkOpts := []net.Option{
 		net.Datastore(ds),
 		net.SeedAddrs(seedAddrs),
+		net.AnchorAddrs(config.AnchorPeers),
+		net.AllowRules(config.AllowPeers),
+		net.DenyRules(config.DenyPeers),
 		net.ListenAddrs(listenAddrs),
 		net.UserAgent(config.UserAgent),
 		net.PrivateKey(privKey),
 		net.Params(netParams),
 		net.BlockValidator(s.handleIncomingBlock),
 		net.MempoolValidator(s.processMempoolTransaction),
+		net.EvidenceValidator(s.handleIncomingEvidence),
 		net.MaxBanscore(config.MaxBanscore),
 		net.BanDuration(config.BanDuration),
 		net.MaxMessageSize(config.Policy.MaxMessageSize),
@@ -394,6 +555,12 @@ func BuildServer(config *repo.Config) (*Server, error) {
 	}
 	if chain.ValidatorExists(hostID) {
 		networkOpts = append(networkOpts, net.ForceDHTServerMode())
+		if config.BlocksOnly {
+			log.Warn("Blocks only mode is enabled on a validator node. Generated blocks will only contain transactions submitted to this node.")
+		}
+	}
+	if config.BlocksOnly {
+		networkOpts = append(networkOpts, net.BlocksOnly())
 	}
 
 	network, err := net.NewNetwork(ctx, networkOpts...)
@@ -404,7 +571,7 @@ func BuildServer(config *repo.Config) (*Server, error) {
 	valConn := net.NewValidatorConnector(network.Host(), hostID, chain.GetValidator, chain.Validators, chain.Subscribe)
 	policy.SetValidatorStatFunc(valConn.ValidatorDialSuccessRate)
 
-	engine, err := consensus.NewConsensusEngine(ctx, []consensus.Option{
+	engineOpts := []consensus.Option{
 		consensus.Params(netParams),
 		consensus.Network(network),
 		consensus.ValidatorConnector(valConn),
@@ -413,38 +580,93 @@ func BuildServer(config *repo.Config) (*Server, error) {
 		consensus.GetBlockID(chain.GetBlockIDByHeight),
 		consensus.GetBlock(s.fetchBlock),
 		consensus.PeerID(network.Host().ID()),
-	}...)
+	}
+	if config.RecordConsensus != "" {
+		s.recorder, err = consensus.OpenTrafficRecorder(config.RecordConsensus)
+		if err != nil {
+			return nil, err
+		}
+		engineOpts = append(engineOpts, consensus.Recorder(s.recorder))
+	}
+	engine, err := consensus.NewConsensusEngine(ctx, engineOpts...)
 	if err != nil {
 		return nil, err
 	}
 
+	var metricsHandler http.Handler
+	if config.RPCOpts.EnableMetrics {
+		registry := prometheus.NewRegistry()
+		registry.MustRegister(
+			collectors.NewGoCollector(),
+			collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+			engine.MetricsCollector(),
+		)
+		metricsHandler = promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+	}
+
 	// Start the policy service. We don't need to track this object in server,
 	// and it will shut down when the context is canceled.
-	protocol.NewPolicyService(ctx, network, netParams, policy)
-
-	grpcServer, err := newGrpcServer(config.RPCOpts, &rpc.GrpcServerConfig{
-		Chain:                chain,
-		Network:              network,
-		Policy:               policy,
-		Wallet:               wallet,
-		Prover:               prover,
-		BroadcastTxFunc:      s.submitTransaction,
-		SetLogLevelFunc:      UpdateLogLevel,
-		ReindexChainFunc:     s.reIndexChain,
-		RequestBlockFunc:     s.requestBlock,
-		AutoStakeFunc:        s.setAutostake,
-		NetworkKeyFunc:       s.getNetworkKey,
-		ChainParams:          netParams,
-		Ds:                   ds,
-		TxMemPool:            mpool,
-		TxIndex:              txIndex,
-		WSIndex:              wsIndex,
-		AddrIndex:            addrIndex,
-		DisableNodeService:   config.RPCOpts.DisableNodeService,
-		DisableWalletService: config.RPCOpts.DisableWalletService,
-		DisableWalletServer:  config.RPCOpts.DisableWalletServerService || wsIndex == nil,
-		DisableProverServer:  !config.RPCOpts.EnableProverService,
+	policyService := protocol.NewPolicyService(ctx, network, netParams, policy)
+
+	// The invoice service is always created so that the wallet can request
+	// invoices from other peers. It only serves invoices if addresses are
+	// configured.
+	invoiceService := invoice.NewInvoiceService(ctx, network, netParams, wallet, invoiceAddrs)
+
+	pluginManager, err := plugins.NewManager(config.Plugins)
+	if err != nil {
+		return nil, err
+	}
+	extIndexes := indexers.NewExternalIndexManager(ds, chain.GetBlockByHeight, func() uint32 {
+		_, height, _ := chain.BestBlock()
+		return height
 	})
+	for _, indexer := range pluginManager.Indexers() {
+		extIndexes.Register(indexer)
+	}
+
+	evidenceStore := evidence.NewStore(ds)
+
+	grpcServer, err := newGrpcServer(config.RPCOpts, metricsHandler, &rpc.GrpcServerConfig{
+		Chain:                 chain,
+		Network:               network,
+		Policy:                policy,
+		Wallet:                wallet,
+		WalletDs:              walletDs,
+		InvoiceService:        invoiceService,
+		Prover:                prover,
+		BroadcastTxFunc:       s.submitTransaction,
+		SubmitPackageFunc:     s.submitPackage,
+		SetLogLevelFunc:       UpdateLogLevel,
+		ReindexChainFunc:      s.reIndexChain,
+		RequestBlockFunc:      s.requestBlock,
+		AutoStakeFunc:         s.setAutostake,
+		AutoStakeEnabledFunc:  s.autostakeEnabled,
+		NetworkKeyFunc:        s.getNetworkKey,
+		ChainParams:           netParams,
+		DataDir:               config.DataDir,
+		KeyBackupDir:          config.KeyBackupDir,
+		Ds:                    ds,
+		TxMemPool:             mpool,
+		TxIndex:               txIndex,
+		WSIndex:               wsIndex,
+		AddrIndex:             addrIndex,
+		FeeIndex:              feeIndex,
+		BlockFilterIndex:      filterIndex,
+		ScriptIndex:           scriptIndex,
+		DisableNodeService:    config.RPCOpts.DisableNodeService,
+		DisableWalletService:  config.RPCOpts.DisableWalletService,
+		DisableWalletServer:   config.RPCOpts.DisableWalletServerService || wsIndex == nil,
+		DisableProverServer:   !config.RPCOpts.EnableProverService,
+		ChangePolicy:          changePolicy,
+		Evidence:              evidenceStore,
+		ConsensusHealthFunc:   s.consensusHealth,
+		PeerScoresFunc:        s.consensusPeerScores,
+		ConsensusStateFunc:    s.consensusState,
+		ProvingTimeFunc:       s.provingTime,
+		TreasuryWhitelistFunc: policyService.GetTreasuryWhitelist,
+		WalletRescanFunc:      walletBlocks.RescanStatus,
+	}, pluginManager.RegisterRPC)
 	if err != nil {
 		return nil, err
 	}
@@ -465,7 +687,13 @@ func BuildServer(config *repo.Config) (*Server, error) {
 		return nil, err
 	}
 
-	s.chainService, err = sync.NewChainService(ctx, s.fetchBlock, s.isCurrent, chain, network, netParams)
+	var fetchBlockFilter sync.FetchBlockFilterFunc
+	if filterIndex != nil {
+		fetchBlockFilter = func(blockID types.ID) ([]byte, types.ID, error) {
+			return filterIndex.GetBlockFilter(ds, blockID)
+		}
+	}
+	s.chainService, err = sync.NewChainService(ctx, s.fetchBlock, fetchBlockFilter, s.isCurrent, chain, network, netParams)
 	if err != nil {
 		return nil, err
 	}
@@ -479,6 +707,7 @@ func BuildServer(config *repo.Config) (*Server, error) {
 	s.mempool = mpool
 	s.blockchain = chain
 	s.engine = engine
+	s.valConn = valConn
 	s.syncManager = sync.NewSyncManager(&sync.SyncManagerConfig{
 		Ctx:               ctx,
 		Chain:             chain,
@@ -494,6 +723,7 @@ func BuildServer(config *repo.Config) (*Server, error) {
 	s.orphanBlocks = make(map[types.ID]*orphanBlock)
 	s.activeInventory = make(map[types.ID]*blocks.Block)
 	s.submittedTxs = make(map[types.ID]struct{})
+	s.packageTxs = make(map[types.ID]struct{})
 	s.inflightRequests = make(map[types.ID]bool)
 	s.orphanLock = stdsync.RWMutex{}
 	s.inventoryLock = stdsync.RWMutex{}
@@ -503,26 +733,42 @@ func BuildServer(config *repo.Config) (*Server, error) {
 	s.policy = policy
 	s.generator = generator
 	s.grpcServer = grpcServer
+	s.plugins = pluginManager
+	s.extIndexes = extIndexes
 	s.wallet = wallet
+	s.evidence = evidenceStore
 	s.autoStake = bytes.Equal(autostake, []byte{0x01})
 	s.coinbasesToStake = make(map[types.ID]struct{})
 	s.networkKey = privKey
+	if config.StallMultiple > 0 {
+		s.stallMonitor = s.newStallMonitor(config.StallMultiple, config.StallWebhooks)
+	}
 
 	chain.Subscribe(s.handleBlockchainNotification)
+	mpool.Subscribe(s.handleMempoolNotification)
 
-	s.printListenAddrs()
-
-	s.wallet.Start()
+	walletSub := wallet.SubscribeTransactions()
+	go func() {
+		defer walletSub.Close()
+		for {
+			select {
+			case tx := <-walletSub.C:
+				s.plugins.WalletTransaction(tx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
 
-	go s.syncManager.Start()
+	s.printListenAddrs()
 
-	// If we are the genesis validator then start generating immediately.
-	_, height, _ := chain.BestBlock()
-	if height == 0 {
-		if chain.Validators()[0].PeerID == network.Host().ID() {
-			s.syncManager.SetCurrent()
-			generator.Start()
-		}
+	s.supervisor = newSupervisor(restartPolicy{
+		backoff:    config.RestartBackoff,
+		maxBackoff: config.MaxRestartBackoff,
+	})
+	s.registerSubsystems()
+	if err := s.supervisor.startAll(); err != nil {
+		return nil, err
 	}
 
 	// If we lose all of our peers and then they come back
@@ -531,14 +777,16 @@ func BuildServer(config *repo.Config) (*Server, error) {
 	onlineMtx := stdsync.RWMutex{}
 	online := true
 	connected := func(_ inet.Network, conn inet.Conn) {
+		go s.addTimeSample(conn.RemotePeer())
+
 		onlineMtx.RLock()
 		o := online
 		onlineMtx.RUnlock()
 		if !o && s.syncManager.IsCurrent() {
 			log.Warn("Restarting sync manager due to lost internet connection")
-			s.generator.Close()
-			s.syncManager.Close()
-			go s.syncManager.Start()
+			if err := s.supervisor.restart("sync", nil); err != nil {
+				log.WithCaller(true).Error("Error restarting sync manager", log.Args("error", err))
+			}
 			onlineMtx.Lock()
 			online = true
 			onlineMtx.Unlock()
@@ -558,13 +806,42 @@ func BuildServer(config *repo.Config) (*Server, error) {
 	}
 
 	s.network.Host().Network().Notify(notifier)
+	for _, p := range s.network.Host().Network().Peers() {
+		go s.addTimeSample(p)
+	}
 
 	return &s, nil
 }
 
+// addTimeSample queries the peer for its current time and adds it
+// to the blockchain's median time source.
+func (s *Server) addTimeSample(p peer.ID) {
+	<-s.ready
+
+	t, err := s.chainService.GetTime(p)
+	if err != nil {
+		log.Debug("Error fetching peer time", log.ArgsFromMap(map[string]any{
+			"peer":  p,
+			"error": err,
+		}))
+		return
+	}
+	s.blockchain.TimeSource().AddTimeSample(p.String(), t)
+}
+
 func (s *Server) processMempoolTransaction(tx *transactions.Transaction) error {
 	<-s.ready
 
+	// Package transactions are added to the mempool together before
+	// they are broadcast so they only need to be relayed.
+	s.submittedTxsLock.Lock()
+	_, inPackage := s.packageTxs[tx.ID()]
+	delete(s.packageTxs, tx.ID())
+	s.submittedTxsLock.Unlock()
+	if inPackage {
+		return nil
+	}
+
 	// We will let our own txs through even if we're not current.
 	s.submittedTxsLock.RLock()
 	_, ok := s.submittedTxs[tx.ID()]
@@ -597,10 +874,72 @@ func (s *Server) submitTransaction(tx *transactions.Transaction) error {
 	return s.network.BroadcastTransaction(tx)
 }
 
+// submitPackage adds the transactions to the mempool if all of them are
+// valid and then broadcasts them.
+func (s *Server) submitPackage(txs []*transactions.Transaction) error {
+	<-s.ready
+
+	if !s.syncManager.IsCurrent() {
+		return blockchain.NotCurrentError("chain not current")
+	}
+	if err := s.mempool.ProcessPackage(txs); err != nil {
+		return err
+	}
+
+	s.submittedTxsLock.Lock()
+	for _, tx := range txs {
+		s.packageTxs[tx.ID()] = struct{}{}
+	}
+	s.submittedTxsLock.Unlock()
+
+	for _, tx := range txs {
+		if err := s.network.BroadcastTransaction(tx); err != nil {
+			// The package is already in the mempool so a failed
+			// broadcast is logged rather than returned.
+			s.submittedTxsLock.Lock()
+			delete(s.packageTxs, tx.ID())
+			s.submittedTxsLock.Unlock()
+			log.Warn("Error broadcasting package transaction", log.ArgsFromMap(map[string]any{
+				"txid":  tx.ID().String(),
+				"error": err,
+			}))
+		}
+	}
+	return nil
+}
+
 func (s *Server) getNetworkKey() (crypto.PrivKey, error) {
 	return repo.LoadNetworkKey(s.ds)
 }
 
+// consensusPeerScores returns the consensus engine's validator scores.
+// The RPC server is started before the engine so it may not exist yet.
+func (s *Server) consensusPeerScores() []consensus.PeerScore {
+	if s.engine == nil {
+		return nil
+	}
+	return s.engine.PeerScores()
+}
+
+// provingTime returns the estimated time to prove a transaction with
+// the number of inputs, or zero if there is no estimate.
+func (s *Server) provingTime(inputs int) time.Duration {
+	if s.provingTimer == nil {
+		return 0
+	}
+	return s.provingTimer.estimate(inputs)
+}
+
+// consensusState returns a snapshot of the consensus engine's state or
+// nil if the engine has not been started.
+func (s *Server) consensusState() *consensus.EngineState {
+	if s.engine == nil {
+		return nil
+	}
+	state := s.engine.State()
+	return &state
+}
+
 func (s *Server) handleIncomingBlock(xThinnerBlk *blocks.XThinnerBlock, p peer.ID) error {
 	<-s.ready
 	_, height, _ := s.blockchain.BestBlock()
@@ -630,6 +969,48 @@ func (s *Server) handleIncomingBlock(xThinnerBlk *blocks.XThinnerBlock, p peer.I
 	return s.processBlock(blk, p, false)
 }
 
+// handleIncomingEvidence records equivocation evidence relayed by a peer.
+// Evidence is only relayed further if it is valid, new, and against a
+// producer in the validator set.
+func (s *Server) handleIncomingEvidence(ev *blocks.EquivocationEvidence, p peer.ID) error {
+	<-s.ready
+	producerID, err := peer.IDFromBytes(ev.GetFirst().GetProducer_ID())
+	if err != nil {
+		return fmt.Errorf("%w: %s", evidence.ErrInvalidEvidence, err)
+	}
+	if _, err := s.blockchain.GetValidator(producerID); err != nil {
+		return fmt.Errorf("%w: producer is not a validator", evidence.ErrInvalidEvidence)
+	}
+	if err := s.evidence.Put(ev); err != nil {
+		return err
+	}
+	log.Warn("Received equivocation evidence", log.ArgsFromMap(map[string]any{
+		"producer":  producerID,
+		"height":    ev.First.Height,
+		"from peer": p,
+	}))
+	return nil
+}
+
+// recordEvidence records equivocation evidence detected by this node and
+// relays it to the network.
+func (s *Server) recordEvidence(ev *blocks.EquivocationEvidence) {
+	if err := s.evidence.Put(ev); err != nil {
+		if !errors.Is(err, evidence.ErrKnownEvidence) {
+			log.WithCaller(true).Debug("Error recording equivocation evidence", log.Args("error", err))
+		}
+		return
+	}
+	producerID, _ := peer.IDFromBytes(ev.First.Producer_ID)
+	log.Warn("Detected equivocation", log.ArgsFromMap(map[string]any{
+		"producer": producerID,
+		"height":   ev.First.Height,
+	}))
+	if err := s.network.BroadcastEvidence(ev); err != nil {
+		log.WithCaller(true).Error("Error relaying equivocation evidence", log.Args("error", err))
+	}
+}
+
 func (s *Server) handleBlockchainNotification(ntf *blockchain.Notification) {
 	<-s.ready
 
@@ -637,6 +1018,12 @@ func (s *Server) handleBlockchainNotification(ntf *blockchain.Notification) {
 	case blockchain.NTBlockConnected:
 		if blk, ok := ntf.Data.(*blocks.Block); ok {
 			s.mempool.RemoveBlockTransactions(blk.Transactions)
+			s.plugins.BlockConnected(blk)
+			s.extIndexes.BlockConnected()
+
+			if s.params.CoinbaseMaturity > 0 && blk.Header.Height+1 == s.blockchain.RewardsMatureAt() {
+				s.claimCoinbase()
+			}
 
 			s.autoStakeLock.RLock()
 			toStake := make(map[types.ID]struct{})
@@ -690,40 +1077,60 @@ func (s *Server) handleBlockchainNotification(ntf *blockchain.Notification) {
 		if s.isCurrent() {
 			log.Info("New blockchain epoch")
 		}
-		validator, err := s.blockchain.GetValidator(s.network.Host().ID())
-		if err == nil && validator.UnclaimedCoins > 0 && s.isCurrent() {
-			tx, err := s.wallet.BuildCoinbaseTransaction(validator.UnclaimedCoins, s.coinbaseAddr, s.networkKey)
-			if err != nil {
-				log.WithCaller(true).Error("Error building auto coinbase transaction",
+		// Rewards which need to mature are claimed once the block
+		// before they mature is connected.
+		if _, height, _ := s.blockchain.BestBlock(); height+1 >= s.blockchain.RewardsMatureAt() {
+			s.claimCoinbase()
+		}
+	}
+}
+
+// claimCoinbase builds a coinbase transaction claiming this node's
+// unclaimed coins and broadcasts it.
+func (s *Server) claimCoinbase() {
+	validator, err := s.blockchain.GetValidator(s.network.Host().ID())
+	if err == nil && validator.UnclaimedCoins > 0 && s.isCurrent() {
+		tx, err := s.wallet.BuildCoinbaseTransaction(validator.UnclaimedCoins, s.coinbaseAddr, s.networkKey)
+		if err != nil {
+			log.WithCaller(true).Error("Error building auto coinbase transaction",
+				log.Args("error", err))
+			return
+		}
+		s.autoStakeLock.Lock()
+		if s.autoStake {
+			s.coinbasesToStake[tx.ID()] = struct{}{}
+		}
+		s.autoStakeLock.Unlock()
+
+		// Broadcast the coinbase at a random interval between now and a half hour.
+		// This prevents a flood of coinbase transactions right at the start of a
+		// new epoch.
+		//
+		// If regtest mode broadcast immediately.
+		randomDuration := time.Duration(mrand.Intn(60*30)) * time.Second
+		if s.params.Name == params.RegestParams.Name {
+			randomDuration = 0
+		}
+		time.AfterFunc(randomDuration, func() {
+			log.Info("Broadcasting coinbase transaction for self", log.ArgsFromMap(map[string]any{
+				"txid":      tx.ID().String(),
+				"new coins": uint64(validator.UnclaimedCoins),
+			}))
+			if err := s.submitTransaction(tx); err != nil {
+				log.WithCaller(true).Error("Error submitting auto coinbase transaction",
 					log.Args("error", err))
 				return
 			}
-			s.autoStakeLock.Lock()
-			if s.autoStake {
-				s.coinbasesToStake[tx.ID()] = struct{}{}
-			}
-			s.autoStakeLock.Unlock()
-
-			// Broadcast the coinbase at a random interval between now and a half hour.
-			// This prevents a flood of coinbase transactions right at the start of a
-			// new epoch.
-			//
-			// If regtest mode broadcast immediately.
-			randomDuration := time.Duration(mrand.Intn(60*30)) * time.Second
-			if s.params.Name == params.RegestParams.Name {
-				randomDuration = 0
-			}
-			time.AfterFunc(randomDuration, func() {
-				log.Info("Broadcasting coinbase transaction for self", log.ArgsFromMap(map[string]any{
-					"txid":      tx.ID().String(),
-					"new coins": uint64(validator.UnclaimedCoins),
-				}))
-				if err := s.submitTransaction(tx); err != nil {
-					log.WithCaller(true).Error("Error submitting auto coinbase transaction",
-						log.Args("error", err))
-					return
-				}
-			})
+		})
+	}
+}
+
+func (s *Server) handleMempoolNotification(ntf *mempool.Notification) {
+	<-s.ready
+
+	if ntf.Type == mempool.NTTransactionAccepted {
+		if tx, ok := ntf.Data.(*transactions.Transaction); ok {
+			s.plugins.TransactionReceived(tx)
 		}
 	}
 }
@@ -740,6 +1147,13 @@ func (s *Server) setAutostake(autostake bool) error {
 	return s.ds.Put(context.Background(), datastore.NewKey(repo.AutostakeDatastoreKey), b)
 }
 
+func (s *Server) autostakeEnabled() bool {
+	s.autoStakeLock.RLock()
+	defer s.autoStakeLock.RUnlock()
+
+	return s.autoStake
+}
+
 func (s *Server) processBlock(blk *blocks.Block, relayingPeer peer.ID, recheck bool) error {
 	<-s.ready
 	_, err := s.blockchain.CheckConnectBlock(blk)
@@ -768,9 +1182,9 @@ func (s *Server) processBlock(blk *blocks.Block, relayingPeer peer.ID, recheck b
 			time.Now().After(tipTimstamp.Add(time.Minute*5)) {
 
 			log.Warn("Restarting sync manager due to large number of orphans")
-			s.generator.Close()
-			s.syncManager.Close()
-			go s.syncManager.Start()
+			if err := s.supervisor.restart("sync", nil); err != nil {
+				log.WithCaller(true).Error("Error restarting sync manager", log.Args("error", err))
+			}
 		}
 		s.orphanLock.Unlock()
 		return err
@@ -836,8 +1250,12 @@ func (s *Server) processBlock(blk *blocks.Block, relayingPeer peer.ID, recheck b
 			log.Args("error", err))
 	}
 
+	var rivals []*blocks.Block
 	s.inventoryLock.Lock()
 	for _, inv := range s.activeInventory {
+		if inv.Header.Height == blk.Header.Height && inv.ID() != blk.ID() {
+			rivals = append(rivals, inv)
+		}
 		if inv.Header.Height == blk.Header.Height &&
 			inv.ID() != blk.ID() &&
 			bytes.Equal(inv.Header.Producer_ID, blk.Header.Producer_ID) &&
@@ -846,6 +1264,10 @@ func (s *Server) processBlock(blk *blocks.Block, relayingPeer peer.ID, recheck b
 			// The block producer sent us two blocks at the same height
 			// too close together.
 			s.network.IncreaseBanscore(relayingPeer, 101, 0, "relayed two blocks at same height in short succession")
+			s.recordEvidence(&blocks.EquivocationEvidence{
+				First:  inv.Header,
+				Second: blk.Header,
+			})
 			s.inventoryLock.Unlock()
 			return errors.New("multiple blocks from the same validator")
 		}
@@ -853,12 +1275,21 @@ func (s *Server) processBlock(blk *blocks.Block, relayingPeer peer.ID, recheck b
 	s.activeInventory[blk.ID()] = blk
 	s.inventoryLock.Unlock()
 
+	// Competing blocks at the same height are tracked as pending
+	// branches until consensus decides between them.
+	if len(rivals) > 0 {
+		for _, rival := range append(rivals, blk) {
+			s.blockchain.RecordChainTip(rival.ID(), rival.Header.Height, rival.Header.Height-1, blockchain.ChainTipPending)
+		}
+	}
+
 	s.orphanLock.Lock()
 	delete(s.orphanBlocks, blk.ID())
 	s.orphanLock.Unlock()
 
 	s.generator.Interrupt(blk.Header.Height)
 	s.engine.NewBlock(blk.Header, isAcceptable, callback)
+	s.grpcServer.NotifyBlockProposed(blk)
 
 	go func(b *blocks.Block, t time.Time) {
 		select {
@@ -888,6 +1319,7 @@ func (s *Server) processBlock(blk *blocks.Block, relayingPeer peer.ID, recheck b
 					"id":     blk.ID().String(),
 					"height": blk.Header.Height,
 				}))
+				s.blockchain.RecordChainTip(blk.ID(), blk.Header.Height, blk.Header.Height-1, blockchain.ChainTipRejected)
 				if s.generator.IsOwnBlock(blk.ID()) {
 					s.mempool.ProcessRejectedBlock(blk)
 				}
@@ -1094,13 +1526,7 @@ func (s *Server) requestBlock(blockID types.ID, remotePeer peer.ID) {
 
 func (s *Server) reIndexChain() error {
 	<-s.ready
-	s.generator.Close()
-	s.syncManager.Close()
-	if err := s.blockchain.ReindexChainState(); err != nil {
-		return err
-	}
-	go s.syncManager.Start()
-	return nil
+	return s.supervisor.restart("sync", s.blockchain.ReindexChainState)
 }
 
 func (s *Server) handleCurrentStatusChange() {
@@ -1110,44 +1536,175 @@ func (s *Server) handleCurrentStatusChange() {
 	}
 }
 
+// registerSubsystems registers the server's components with the supervisor
+// along with their dependencies. Most of the components start running in
+// their constructors so only those with explicit start functions are
+// restarted on a health check failure.
+func (s *Server) registerSubsystems() {
+	s.supervisor.register(&subsystem{
+		name: "datastore",
+		stop: s.ds.Close,
+	})
+	s.supervisor.register(&subsystem{
+		name: "net",
+		deps: []string{"datastore"},
+		stop: s.network.Close,
+	})
+	s.supervisor.register(&subsystem{
+		name: "blockchain",
+		deps: []string{"datastore"},
+		stop: s.blockchain.Close,
+	})
+	s.supervisor.register(&subsystem{
+		name: "mempool",
+		deps: []string{"blockchain"},
+		stop: func() error {
+			s.mempool.Close()
+			return nil
+		},
+	})
+	s.supervisor.register(&subsystem{
+		name: "consensus",
+		deps: []string{"net"},
+		stop: func() error {
+			s.engine.Close()
+			if s.recorder != nil {
+				return s.recorder.Close()
+			}
+			return nil
+		},
+	})
+	s.supervisor.register(&subsystem{
+		name: "wallet",
+		deps: []string{"blockchain"},
+		start: func() error {
+			s.wallet.Start()
+			return nil
+		},
+		stop: func() error {
+			s.wallet.Close()
+			return nil
+		},
+	})
+	s.supervisor.register(&subsystem{
+		name: "sync",
+		deps: []string{"net", "blockchain", "consensus"},
+		start: func() error {
+			go s.syncManager.Start()
+			return nil
+		},
+		stop: func() error {
+			s.syncManager.Close()
+			return nil
+		},
+		health: s.syncHealthProbe(),
+	})
+	s.supervisor.register(&subsystem{
+		name: "generator",
+		deps: []string{"sync", "mempool"},
+		start: func() error {
+			// If we are the genesis validator then start generating immediately.
+			// Otherwise the generator is started when the sync manager is current.
+			_, height, _ := s.blockchain.BestBlock()
+			if height == 0 && s.blockchain.Validators()[0].PeerID == s.network.Host().ID() {
+				s.syncManager.SetCurrent()
+				s.generator.Start()
+			}
+			return nil
+		},
+		stop: func() error {
+			s.generator.Close()
+			return nil
+		},
+	})
+	if s.stallMonitor != nil {
+		s.supervisor.register(&subsystem{
+			name: "stallmonitor",
+			deps: []string{"sync", "consensus"},
+			start: func() error {
+				s.stallMonitor.start()
+				return nil
+			},
+			stop: func() error {
+				s.stallMonitor.stop()
+				return nil
+			},
+		})
+	}
+	s.supervisor.register(&subsystem{
+		name: "rpc",
+		deps: []string{"net", "blockchain", "mempool", "wallet"},
+		stop: func() error {
+			s.grpcServer.Close()
+			return nil
+		},
+	})
+	s.supervisor.register(&subsystem{
+		name: "plugins",
+		deps: []string{"rpc"},
+		start: func() error {
+			err := s.plugins.Start(&plugins.Environment{
+				Params:            s.params,
+				DataDir:           path.Join(s.config.DataDir, "plugins"),
+				SubmitTransaction: s.submitTransaction,
+			})
+			if err != nil {
+				return err
+			}
+			s.extIndexes.Start()
+			return nil
+		},
+		stop: func() error {
+			s.extIndexes.Close()
+			return s.plugins.Close()
+		},
+	})
+}
+
+// syncHealthProbe returns a health probe for the sync manager. The probe
+// fails if the sync manager is not current and the chain has not advanced
+// for syncStallTimeout despite having peers to sync from.
+func (s *Server) syncHealthProbe() func() error {
+	_, lastHeight, _ := s.blockchain.BestBlock()
+	lastProgress := time.Now()
+	return func() error {
+		_, height, _ := s.blockchain.BestBlock()
+		if height != lastHeight || s.syncManager.IsCurrent() || len(s.network.Host().Network().Peers()) == 0 {
+			lastHeight = height
+			lastProgress = time.Now()
+			return nil
+		}
+		if time.Since(lastProgress) > syncStallTimeout {
+			lastProgress = time.Now()
+			return fmt.Errorf("sync stalled at height %d", height)
+		}
+		return nil
+	}
+}
+
 // Close shuts down all the parts of the server and blocks until
 // they finish closing.
 func (s *Server) Close() error {
 	<-s.ready
 	s.cancelFunc()
-	s.generator.Close()
-	s.syncManager.Close()
-	s.engine.Close()
-	s.mempool.Close()
-	s.wallet.Close()
-	if err := s.blockchain.Close(); err != nil {
+	if err := s.supervisor.stopAll(); err != nil {
 		return err
 	}
-	if err := s.network.Close(); err != nil {
-		return err
-	}
-	if err := s.ds.Close(); err != nil {
-		return err
+	if s.shutdownTracing != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		defer cancel()
+		if err := s.shutdownTracing(ctx); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
-func (s *Server) makeBlockchainClient(chain *blockchain.Blockchain) *client.InternalClient {
+func (s *Server) makeBlockchainClient(chain *blockchain.Blockchain, src *walletBlockSource) *client.InternalClient {
 	c := &client.InternalClient{
 		BroadcastFunc:                s.submitTransaction,
-		GetAccumulatorCheckpointFunc: chain.GetAccumulatorCheckpointByHeight,
-		GetBlocksFunc: func(from, to uint32) ([]*blocks.Block, uint32, error) {
-			blocks := make([]*blocks.Block, 0, to-from+1)
-			for i := from; i <= to; i++ {
-				blk, err := chain.GetBlockByHeight(i)
-				if err != nil {
-					return nil, 0, err
-				}
-				blocks = append(blocks, blk)
-			}
-			_, bestHeight, _ := chain.BestBlock()
-			return blocks, bestHeight, nil
-		},
+		GetAccumulatorCheckpointFunc: src.GetAccumulatorCheckpoint,
+		GetBlocksFunc:                src.GetBlocks,
 		SubscribeBlocksFunc: func() (<-chan *blocks.Block, error) {
 			ch := make(chan *blocks.Block)
 			chain.Subscribe(func(ntf *blockchain.Notification) {
@@ -1171,6 +1728,29 @@ func (s *Server) limitOrphans() {
 	}
 }
 
+// unlockWalletFromFile unlocks the wallet with the passphrase in the
+// wallet unlock file so an unattended node can restart without someone
+// entering the passphrase.
+func unlockWalletFromFile(wallet *walletlib.Wallet, config *repo.Config) error {
+	passphrase, err := repo.LoadWalletPassphrase(config.WalletUnlockFile, config.WalletUnlockKeyCmd)
+	if err != nil {
+		return err
+	}
+	duration := config.WalletRelock
+	if duration == 0 {
+		duration = time.Duration(math.MaxInt64)
+	}
+	if err := wallet.Unlock(passphrase, duration); err != nil {
+		return fmt.Errorf("error unlocking wallet: %w", err)
+	}
+	if config.WalletRelock > 0 {
+		log.Info("Wallet unlocked", log.Args("relock", config.WalletRelock))
+	} else {
+		log.Info("Wallet unlocked")
+	}
+	return nil
+}
+
 func printSplashScreen() {
 	colors := []string{
 		"\033[35m", // Magenta
@@ -1202,4 +1782,10 @@ func (s *Server) printListenAddrs() {
 		log.Info(fmt.Sprintf("Listening on %s", addr))
 	}
 	log.Info(fmt.Sprintf("gRPC server listening on %s", s.config.RPCOpts.GrpcListener))
+	if s.config.RPCOpts.EnableProfiling {
+		log.Info("Profiling endpoints enabled at /debug/pprof")
+	}
+	if s.config.RPCOpts.EnableMetrics {
+		log.Info("Prometheus metrics enabled at /metrics")
+	}
 }
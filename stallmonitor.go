@@ -0,0 +1,224 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/project-illium/ilxd/gen"
+	"github.com/project-illium/ilxd/rpc"
+	"github.com/project-illium/ilxd/types"
+	"net/http"
+	"net/url"
+	stdsync "sync"
+	"time"
+)
+
+const (
+	// stallCheckInterval is how often the stall monitor checks
+	// whether the chain has advanced.
+	stallCheckInterval = time.Second * 10
+
+	// stallPollSampleSize is the number of validators the consensus
+	// engine polls on each tick while recovering from a stall.
+	stallPollSampleSize = 8
+
+	stallWebhookTimeout = time.Second * 10
+)
+
+// stallAlert is the JSON body POSTed to the stall webhooks.
+type stallAlert struct {
+	Status           string `json:"status"`
+	Height           uint32 `json:"height"`
+	BlockID          string `json:"blockID"`
+	LastProgress     int64  `json:"lastProgress"`
+	StalledSeconds   int64  `json:"stalledSeconds"`
+	RecoveryAttempts int    `json:"recoveryAttempts"`
+}
+
+// stallMonitor watches for consensus stalls. If no block has finalized for
+// longer than the timeout while the node is current and has peers, the node
+// is marked degraded, the webhooks are alerted, and the recover function is
+// called. Recovery is retried each timeout period until a block finalizes.
+type stallMonitor struct {
+	timeout     time.Duration
+	webhooks    []string
+	client      *http.Client
+	bestBlock   func() (types.ID, uint32, time.Time)
+	isCurrent   func() bool
+	numPeers    func() int
+	recoverFunc func()
+
+	degraded     bool
+	lastHeight   uint32
+	lastProgress time.Time
+	lastRecovery time.Time
+	attempts     int
+	mtx          stdsync.RWMutex
+	quit         chan struct{}
+}
+
+// validateStallWebhooks returns an error if any of the webhook URLs
+// is not an http(s) URL.
+func validateStallWebhooks(webhooks []string) error {
+	for _, webhook := range webhooks {
+		u, err := url.Parse(webhook)
+		if err != nil {
+			return err
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid stall webhook URL %s", webhook)
+		}
+	}
+	return nil
+}
+
+func (sm *stallMonitor) start() {
+	sm.mtx.Lock()
+	_, sm.lastHeight, _ = sm.bestBlock()
+	sm.lastProgress = time.Now()
+	sm.quit = make(chan struct{})
+	sm.mtx.Unlock()
+
+	go sm.run()
+}
+
+func (sm *stallMonitor) stop() {
+	close(sm.quit)
+}
+
+func (sm *stallMonitor) run() {
+	ticker := time.NewTicker(stallCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			sm.check()
+		case <-sm.quit:
+			return
+		}
+	}
+}
+
+func (sm *stallMonitor) check() {
+	id, height, _ := sm.bestBlock()
+
+	sm.mtx.Lock()
+	defer sm.mtx.Unlock()
+
+	if height != sm.lastHeight || !sm.isCurrent() || sm.numPeers() == 0 {
+		sm.lastHeight = height
+		sm.lastProgress = time.Now()
+		if sm.degraded {
+			sm.degraded = false
+			log.Info("Blocks are finalizing again. Consensus has recovered.", log.ArgsFromMap(map[string]any{
+				"height":   height,
+				"attempts": sm.attempts,
+			}))
+			go sm.sendAlert(sm.alert("healthy", id, height))
+			sm.attempts = 0
+		}
+		return
+	}
+
+	stalled := time.Since(sm.lastProgress)
+	if stalled <= sm.timeout || time.Since(sm.lastRecovery) <= sm.timeout {
+		return
+	}
+
+	if !sm.degraded {
+		sm.degraded = true
+		log.Warn("No block has finalized recently. Consensus may be stalled.", log.ArgsFromMap(map[string]any{
+			"height":  height,
+			"stalled": stalled.Truncate(time.Second),
+		}))
+	}
+	sm.attempts++
+	sm.lastRecovery = time.Now()
+	log.Info("Attempting to recover from consensus stall", log.Args("attempt", sm.attempts))
+	go sm.sendAlert(sm.alert("degraded", id, height))
+	go sm.recoverFunc()
+}
+
+// alert builds the webhook body. The mutex must be held.
+func (sm *stallMonitor) alert(status string, id types.ID, height uint32) *stallAlert {
+	return &stallAlert{
+		Status:           status,
+		Height:           height,
+		BlockID:          id.String(),
+		LastProgress:     sm.lastProgress.Unix(),
+		StalledSeconds:   int64(time.Since(sm.lastProgress).Seconds()),
+		RecoveryAttempts: sm.attempts,
+	}
+}
+
+// sendAlert POSTs the alert to each webhook. Delivery is best effort.
+func (sm *stallMonitor) sendAlert(alert *stallAlert) {
+	if len(sm.webhooks) == 0 {
+		return
+	}
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return
+	}
+	for _, webhook := range sm.webhooks {
+		resp, err := sm.client.Post(webhook, "application/json", bytes.NewReader(body))
+		if err != nil {
+			log.Debug("Error sending stall webhook", log.ArgsFromMap(map[string]any{
+				"url":   webhook,
+				"error": err,
+			}))
+			continue
+		}
+		resp.Body.Close()
+	}
+}
+
+// health returns the current consensus health.
+func (sm *stallMonitor) health() rpc.ConsensusHealth {
+	sm.mtx.RLock()
+	defer sm.mtx.RUnlock()
+
+	return rpc.ConsensusHealth{
+		Degraded:         sm.degraded,
+		LastProgress:     sm.lastProgress,
+		RecoveryAttempts: sm.attempts,
+	}
+}
+
+// newStallMonitor returns a stall monitor for the server. Stalls are
+// measured in multiples of the block generation interval.
+func (s *Server) newStallMonitor(multiple uint32, webhooks []string) *stallMonitor {
+	return &stallMonitor{
+		timeout:   gen.BlockGenerationInterval * time.Duration(multiple),
+		webhooks:  webhooks,
+		client:    &http.Client{Timeout: stallWebhookTimeout},
+		bestBlock: s.blockchain.BestBlock,
+		isCurrent: s.isCurrent,
+		numPeers: func() int {
+			return len(s.network.Host().Network().Peers())
+		},
+		recoverFunc:  s.recoverFromStall,
+		lastProgress: time.Now(),
+		quit:         make(chan struct{}),
+	}
+}
+
+// consensusHealth returns the health reported by the stall monitor. The
+// node is always reported healthy if stall monitoring is disabled.
+func (s *Server) consensusHealth() rpc.ConsensusHealth {
+	if s.stallMonitor == nil {
+		return rpc.ConsensusHealth{}
+	}
+	return s.stallMonitor.health()
+}
+
+// recoverFromStall polls an expanded sample of validators for the next
+// stall period and recycles connections to unresponsive validators.
+func (s *Server) recoverFromStall() {
+	s.engine.ExpandPolling(stallPollSampleSize, s.stallMonitor.timeout)
+	s.valConn.Refresh()
+}
@@ -0,0 +1,290 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	stdsync "sync"
+	"time"
+)
+
+const healthCheckInterval = time.Minute
+
+// restartPolicy controls how quickly the supervisor restarts a subsystem
+// after its health probe fails. The delay doubles after each consecutive
+// failure up to maxBackoff and resets once the probe succeeds.
+type restartPolicy struct {
+	backoff    time.Duration
+	maxBackoff time.Duration
+}
+
+// subsystem is a part of the node managed by the supervisor.
+//
+// Subsystems are started in dependency order and stopped in reverse.
+// Restarting a subsystem also restarts everything that depends on it.
+// Start and stop are optional as many of the subsystems start running
+// in their constructors.
+type subsystem struct {
+	name  string
+	deps  []string
+	start func() error
+	stop  func() error
+
+	// health is an optional probe. If it returns an error the subsystem
+	// is restarted according to the restart policy.
+	health func() error
+
+	running     bool
+	failures    int
+	nextAttempt time.Time
+}
+
+// supervisor manages the lifecycle of the node's subsystems.
+type supervisor struct {
+	policy     restartPolicy
+	subsystems map[string]*subsystem
+	order      []*subsystem
+	mtx        stdsync.Mutex
+	wg         stdsync.WaitGroup
+	quit       chan struct{}
+}
+
+func newSupervisor(policy restartPolicy) *supervisor {
+	return &supervisor{
+		policy:     policy,
+		subsystems: make(map[string]*subsystem),
+		quit:       make(chan struct{}),
+	}
+}
+
+// register adds a subsystem to the supervisor. All subsystems must be
+// registered before startAll is called.
+func (sv *supervisor) register(sub *subsystem) {
+	sv.mtx.Lock()
+	defer sv.mtx.Unlock()
+
+	sv.subsystems[sub.name] = sub
+}
+
+// startAll starts all the subsystems in dependency order and begins
+// polling the health probes.
+func (sv *supervisor) startAll() error {
+	sv.mtx.Lock()
+	defer sv.mtx.Unlock()
+
+	order, err := sv.sortSubsystems()
+	if err != nil {
+		return err
+	}
+	sv.order = order
+
+	for _, sub := range sv.order {
+		if err := sv.startSubsystem(sub); err != nil {
+			return err
+		}
+	}
+
+	sv.wg.Add(1)
+	go sv.healthLoop()
+	return nil
+}
+
+// stopAll stops the subsystems in reverse dependency order. All
+// subsystems are stopped even if one returns an error. The first error
+// is returned.
+func (sv *supervisor) stopAll() error {
+	close(sv.quit)
+	sv.wg.Wait()
+
+	sv.mtx.Lock()
+	defer sv.mtx.Unlock()
+
+	var firstErr error
+	for i := len(sv.order) - 1; i >= 0; i-- {
+		if err := sv.stopSubsystem(sv.order[i]); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// restart stops the named subsystem and its dependents, runs the
+// optional whileStopped function, then starts them all again.
+func (sv *supervisor) restart(name string, whileStopped func() error) error {
+	sv.mtx.Lock()
+	defer sv.mtx.Unlock()
+
+	return sv.restartSubsystem(name, whileStopped)
+}
+
+func (sv *supervisor) restartSubsystem(name string, whileStopped func() error) error {
+	if _, ok := sv.subsystems[name]; !ok {
+		return fmt.Errorf("unknown subsystem %s", name)
+	}
+
+	affected := sv.dependents(name)
+	for i := len(affected) - 1; i >= 0; i-- {
+		if err := sv.stopSubsystem(affected[i]); err != nil {
+			return err
+		}
+	}
+	if whileStopped != nil {
+		if err := whileStopped(); err != nil {
+			return err
+		}
+	}
+	for _, sub := range affected {
+		if err := sv.startSubsystem(sub); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dependents returns the named subsystem along with every subsystem that
+// transitively depends on it, in start order. The mutex must be held.
+func (sv *supervisor) dependents(name string) []*subsystem {
+	affected := map[string]bool{name: true}
+	var ret []*subsystem
+	for _, sub := range sv.order {
+		for _, dep := range sub.deps {
+			if affected[dep] {
+				affected[sub.name] = true
+			}
+		}
+		if affected[sub.name] {
+			ret = append(ret, sub)
+		}
+	}
+	return ret
+}
+
+func (sv *supervisor) startSubsystem(sub *subsystem) error {
+	if sub.running {
+		return nil
+	}
+	if sub.start != nil {
+		if err := sub.start(); err != nil {
+			return fmt.Errorf("error starting %s: %w", sub.name, err)
+		}
+	}
+	sub.running = true
+	log.Debug("Subsystem started", log.Args("subsystem", sub.name))
+	return nil
+}
+
+func (sv *supervisor) stopSubsystem(sub *subsystem) error {
+	if !sub.running {
+		return nil
+	}
+	sub.running = false
+	if sub.stop != nil {
+		if err := sub.stop(); err != nil {
+			return fmt.Errorf("error stopping %s: %w", sub.name, err)
+		}
+	}
+	log.Debug("Subsystem stopped", log.Args("subsystem", sub.name))
+	return nil
+}
+
+// sortSubsystems returns the subsystems sorted so that each comes after
+// all of its dependencies. The mutex must be held.
+func (sv *supervisor) sortSubsystems() ([]*subsystem, error) {
+	var (
+		order    = make([]*subsystem, 0, len(sv.subsystems))
+		visited  = make(map[string]bool)
+		visiting = make(map[string]bool)
+		visit    func(name string) error
+	)
+	visit = func(name string) error {
+		if visited[name] {
+			return nil
+		}
+		if visiting[name] {
+			return fmt.Errorf("subsystem dependency cycle at %s", name)
+		}
+		sub, ok := sv.subsystems[name]
+		if !ok {
+			return fmt.Errorf("unknown subsystem %s", name)
+		}
+		visiting[name] = true
+		for _, dep := range sub.deps {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		visiting[name] = false
+		visited[name] = true
+		order = append(order, sub)
+		return nil
+	}
+
+	// Visit in a stable order so the start order is deterministic.
+	names := make([]string, 0, len(sv.subsystems))
+	for name := range sv.subsystems {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := visit(name); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+func (sv *supervisor) healthLoop() {
+	defer sv.wg.Done()
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			sv.checkHealth()
+		case <-sv.quit:
+			return
+		}
+	}
+}
+
+func (sv *supervisor) checkHealth() {
+	sv.mtx.Lock()
+	defer sv.mtx.Unlock()
+
+	for _, sub := range sv.order {
+		if !sub.running || sub.health == nil {
+			continue
+		}
+		err := sub.health()
+		if err == nil {
+			sub.failures = 0
+			continue
+		}
+		if time.Now().Before(sub.nextAttempt) {
+			continue
+		}
+
+		sub.failures++
+		backoff := sv.policy.backoff << (sub.failures - 1)
+		if backoff > sv.policy.maxBackoff || backoff <= 0 {
+			backoff = sv.policy.maxBackoff
+		}
+		sub.nextAttempt = time.Now().Add(backoff)
+
+		log.Warn("Subsystem health check failed. Restarting.", log.ArgsFromMap(map[string]any{
+			"subsystem": sub.name,
+			"error":     err,
+			"failures":  sub.failures,
+			"backoff":   backoff,
+		}))
+		if err := sv.restartSubsystem(sub.name, nil); err != nil {
+			log.WithCaller(true).Error("Error restarting subsystem", log.ArgsFromMap(map[string]any{
+				"subsystem": sub.name,
+				"error":     err,
+			}))
+		}
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/project-illium/ilxd/blockchain"
 	"github.com/project-illium/ilxd/net"
 	"github.com/project-illium/ilxd/params"
+	"github.com/project-illium/ilxd/recovery"
 	"github.com/project-illium/ilxd/types"
 	"github.com/project-illium/ilxd/types/blocks"
 	"github.com/project-illium/ilxd/types/transactions"
@@ -26,7 +27,7 @@ import (
 
 const (
 	ChainServiceProtocol        = "/chainservice/"
-	ChainServiceProtocolVersion = "1.0.0"
+	ChainServiceProtocolVersion = "2.0.0"
 
 	maxBatchSize = 2000
 )
@@ -37,6 +38,10 @@ var ErrNotFound = errors.New("not found")
 type FetchBlockFunc func(blockID types.ID) (*blocks.Block, error)
 type IsCurrentFunc func() bool
 
+// FetchBlockFilterFunc returns the serialized compact filter and the
+// filter header for the block.
+type FetchBlockFilterFunc func(blockID types.ID) ([]byte, types.ID, error)
+
 type ChainService struct {
 	ctx        context.Context
 	network    *net.Network
@@ -45,17 +50,23 @@ type ChainService struct {
 	isCurrent  IsCurrentFunc
 	chain      *blockchain.Blockchain
 	ms         net.MessageSender
+
+	fetchBlockFilter FetchBlockFilterFunc
 }
 
-func NewChainService(ctx context.Context, fetchBlock FetchBlockFunc, isCurrent IsCurrentFunc, chain *blockchain.Blockchain, network *net.Network, params *params.NetworkParams) (*ChainService, error) {
+// NewChainService returns a new ChainService. The fetchBlockFilter function
+// may be nil if the node doesn't have the block filter index, in which case
+// filter requests are answered as unavailable.
+func NewChainService(ctx context.Context, fetchBlock FetchBlockFunc, fetchBlockFilter FetchBlockFilterFunc, isCurrent IsCurrentFunc, chain *blockchain.Blockchain, network *net.Network, params *params.NetworkParams) (*ChainService, error) {
 	cs := &ChainService{
-		ctx:        ctx,
-		network:    network,
-		fetchBlock: fetchBlock,
-		isCurrent:  isCurrent,
-		chain:      chain,
-		params:     params,
-		ms:         net.NewMessageSender(network.Host(), params.ProtocolPrefix+ChainServiceProtocol+ChainServiceProtocolVersion),
+		ctx:              ctx,
+		network:          network,
+		fetchBlock:       fetchBlock,
+		fetchBlockFilter: fetchBlockFilter,
+		isCurrent:        isCurrent,
+		chain:            chain,
+		params:           params,
+		ms:               net.NewMessageSender(network.Host(), params.ProtocolPrefix+ChainServiceProtocol+ChainServiceProtocolVersion, params.ProtocolPrefix+ChainServiceProtocol+net.LegacyProtocolVersion),
 	}
 	pruned, err := chain.IsPruned()
 	if err != nil {
@@ -63,6 +74,7 @@ func NewChainService(ctx context.Context, fetchBlock FetchBlockFunc, isCurrent I
 	}
 	if !pruned {
 		cs.network.Host().SetStreamHandler(cs.params.ProtocolPrefix+ChainServiceProtocol+ChainServiceProtocolVersion, cs.HandleNewStream)
+		cs.network.Host().SetStreamHandler(cs.params.ProtocolPrefix+ChainServiceProtocol+net.LegacyProtocolVersion, cs.HandleNewStream)
 	}
 	return cs, nil
 }
@@ -72,6 +84,7 @@ func (cs *ChainService) HandleNewStream(s inet.Stream) {
 }
 
 func (cs *ChainService) handleNewMessage(s inet.Stream) {
+	defer recovery.HandlePanic("chainservice-stream")
 	defer s.Close()
 	contextReader := ctxio.NewReader(cs.ctx, s)
 	reader := msgio.NewVarintReaderSize(contextReader, 1<<23)
@@ -103,7 +116,7 @@ func (cs *ChainService) handleNewMessage(s inet.Stream) {
 			return
 		}
 		req := new(wire.MsgChainServiceRequest)
-		if err := proto.Unmarshal(msgBytes, req); err != nil {
+		if err := net.UnmarshalStreamMsg(s, msgBytes, req); err != nil {
 			reader.ReleaseMsg(msgBytes)
 			log.Debug("Error unmarshalling chain service message", log.ArgsFromMap(map[string]any{
 				"peer":  remotePeer,
@@ -126,6 +139,10 @@ func (cs *ChainService) handleNewMessage(s inet.Stream) {
 			resp, err = cs.handleGetBlockID(m.GetBlockId)
 		case *wire.MsgChainServiceRequest_GetBest:
 			resp, err = cs.handleGetBest(m.GetBest)
+		case *wire.MsgChainServiceRequest_GetTime:
+			resp, err = cs.handleGetTime(m.GetTime)
+		case *wire.MsgChainServiceRequest_GetBlockFilter:
+			resp, err = cs.handleGetBlockFilter(m.GetBlockFilter)
 		case *wire.MsgChainServiceRequest_GetHeadersStream:
 			err = cs.handleGetHeadersStream(m.GetHeadersStream, s)
 			if err != nil {
@@ -163,7 +180,7 @@ func (cs *ChainService) handleNewMessage(s inet.Stream) {
 		}
 
 		if resp != nil {
-			if err := net.WriteMsg(s, resp); err != nil {
+			if err := net.WriteStreamMsg(s, resp); err != nil {
 				log.WithCaller(true).Error("Error writing chain service response", log.ArgsFromMap(map[string]any{
 					"peer":  remotePeer,
 					"error": err,
@@ -358,11 +375,12 @@ func (cs *ChainService) GetHeadersStream(p peer.ID, startHeight uint32) (<-chan
 		},
 	}
 
-	s, err := cs.network.Host().NewStream(context.Background(), p, cs.params.ProtocolPrefix+ChainServiceProtocol+ChainServiceProtocolVersion)
+	s, err := cs.network.Host().NewStream(context.Background(), p, cs.params.ProtocolPrefix+ChainServiceProtocol+ChainServiceProtocolVersion,
+		cs.params.ProtocolPrefix+ChainServiceProtocol+net.LegacyProtocolVersion)
 	if err != nil {
 		return nil, err
 	}
-	err = net.WriteMsg(s, req)
+	err = net.WriteStreamMsg(s, req)
 	if err != nil {
 		return nil, err
 	}
@@ -374,7 +392,7 @@ func (cs *ChainService) GetHeadersStream(p peer.ID, startHeight uint32) (<-chan
 		for {
 			header := new(blocks.BlockHeader)
 			ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
-			if err := net.ReadMsg(ctx, reader, header); err != nil {
+			if err := net.ReadStreamMsg(ctx, s, reader, header); err != nil {
 				close(ch)
 				s.Close()
 				cancel()
@@ -401,7 +419,7 @@ func (cs *ChainService) handleGetHeadersStream(req *wire.GetHeadersStreamReq, s
 			s.Close()
 			return err
 		}
-		if err := net.WriteMsg(s, header); err != nil {
+		if err := net.WriteStreamMsg(s, header); err != nil {
 			s.Close()
 			return err
 		}
@@ -419,11 +437,12 @@ func (cs *ChainService) GetBlockTxsStream(p peer.ID, startHeight uint32, noProof
 		},
 	}
 
-	s, err := cs.network.Host().NewStream(context.Background(), p, cs.params.ProtocolPrefix+ChainServiceProtocol+ChainServiceProtocolVersion)
+	s, err := cs.network.Host().NewStream(context.Background(), p, cs.params.ProtocolPrefix+ChainServiceProtocol+ChainServiceProtocolVersion,
+		cs.params.ProtocolPrefix+ChainServiceProtocol+net.LegacyProtocolVersion)
 	if err != nil {
 		return nil, err
 	}
-	err = net.WriteMsg(s, req)
+	err = net.WriteStreamMsg(s, req)
 	if err != nil {
 		return nil, err
 	}
@@ -435,7 +454,7 @@ func (cs *ChainService) GetBlockTxsStream(p peer.ID, startHeight uint32, noProof
 		for {
 			txs := new(blocks.BlockTxs)
 			ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
-			if err := net.ReadMsg(ctx, reader, txs); err != nil {
+			if err := net.ReadStreamMsg(ctx, s, reader, txs); err != nil {
 				close(ch)
 				s.Close()
 				cancel()
@@ -470,7 +489,7 @@ func (cs *ChainService) handleGetBlockTxsStream(req *wire.GetBlockTxsStreamReq,
 			}
 		}
 
-		if err := net.WriteMsg(s, txs); err != nil {
+		if err := net.WriteStreamMsg(s, txs); err != nil {
 			s.Close()
 			return err
 		}
@@ -519,3 +538,76 @@ func (cs *ChainService) handleGetBest(req *wire.GetBestReq) (*wire.MsgGetBestRes
 
 	return resp, nil
 }
+
+// GetTime returns the remote peer's current time. The returned time is
+// adjusted by half the round trip time of the request.
+func (cs *ChainService) GetTime(p peer.ID) (time.Time, error) {
+	var (
+		req = &wire.MsgChainServiceRequest{
+			Msg: &wire.MsgChainServiceRequest_GetTime{
+				GetTime: &wire.GetTimeReq{},
+			},
+		}
+		resp = new(wire.MsgGetTimeResp)
+	)
+	start := time.Now()
+	err := cs.ms.SendRequest(cs.ctx, p, req, resp)
+	if err != nil {
+		return time.Time{}, err
+	}
+	rtt := time.Since(start)
+
+	return time.UnixMilli(resp.UnixMillis).Add(rtt / 2), nil
+}
+
+func (cs *ChainService) handleGetTime(req *wire.GetTimeReq) (*wire.MsgGetTimeResp, error) {
+	return &wire.MsgGetTimeResp{
+		UnixMillis: time.Now().UnixMilli(),
+	}, nil
+}
+
+// GetBlockFilter returns the serialized compact filter and the filter
+// header for the block from the remote peer.
+func (cs *ChainService) GetBlockFilter(p peer.ID, blockID types.ID) ([]byte, types.ID, error) {
+	var (
+		req = &wire.MsgChainServiceRequest{
+			Msg: &wire.MsgChainServiceRequest_GetBlockFilter{
+				GetBlockFilter: &wire.GetBlockFilterReq{
+					Block_ID: blockID[:],
+				},
+			},
+		}
+		resp = new(wire.MsgBlockFilterResp)
+	)
+	err := cs.ms.SendRequest(cs.ctx, p, req, resp)
+	if err != nil {
+		return nil, types.ID{}, err
+	}
+
+	if resp.Error == wire.ErrorResponse_NotFound {
+		return nil, types.ID{}, ErrNotFound
+	}
+
+	if resp.Error != wire.ErrorResponse_None {
+		return nil, types.ID{}, fmt.Errorf("error response from peer: %s", resp.GetError().String())
+	}
+
+	return resp.Filter, types.NewID(resp.FilterHeader), nil
+}
+
+func (cs *ChainService) handleGetBlockFilter(req *wire.GetBlockFilterReq) (*wire.MsgBlockFilterResp, error) {
+	if cs.fetchBlockFilter == nil {
+		return &wire.MsgBlockFilterResp{Error: wire.ErrorResponse_Unavailable}, nil
+	}
+	filter, header, err := cs.fetchBlockFilter(types.NewID(req.Block_ID))
+	if err != nil {
+		return &wire.MsgBlockFilterResp{Error: wire.ErrorResponse_NotFound}, nil
+	}
+
+	resp := &wire.MsgBlockFilterResp{
+		Filter:       filter,
+		FilterHeader: header[:],
+	}
+
+	return resp, nil
+}
@@ -6,14 +6,17 @@ package sync
 
 import (
 	"context"
+	"errors"
 	"github.com/go-test/deep"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/p2p/net/mock"
 	"github.com/project-illium/ilxd/blockchain/harness"
+	"github.com/project-illium/ilxd/blockchain/indexers"
 	"github.com/project-illium/ilxd/net"
 	"github.com/project-illium/ilxd/params"
 	"github.com/project-illium/ilxd/repo"
 	"github.com/project-illium/ilxd/repo/mock"
+	"github.com/project-illium/ilxd/types"
 	"github.com/project-illium/ilxd/types/blocks"
 	"github.com/project-illium/ilxd/types/transactions"
 	"github.com/stretchr/testify/assert"
@@ -47,7 +50,7 @@ func TestChainService(t *testing.T) {
 	err = testHarness1.GenerateBlocks(10)
 	assert.NoError(t, err)
 
-	service1, err := NewChainService(context.Background(), testHarness1.Blockchain().GetBlockByID, func() bool { return true }, testHarness1.Blockchain(), network1, testHarness1.Blockchain().Params())
+	service1, err := NewChainService(context.Background(), testHarness1.Blockchain().GetBlockByID, nil, func() bool { return true }, testHarness1.Blockchain(), network1, testHarness1.Blockchain().Params())
 	assert.NoError(t, err)
 
 	host2, err := mn.GenPeer()
@@ -72,7 +75,7 @@ func TestChainService(t *testing.T) {
 	err = testHarness2.GenerateBlocks(10)
 	assert.NoError(t, err)
 
-	service2, err := NewChainService(context.Background(), testHarness2.Blockchain().GetBlockByID, func() bool { return true }, testHarness2.Blockchain(), network2, testHarness2.Blockchain().Params())
+	service2, err := NewChainService(context.Background(), testHarness2.Blockchain().GetBlockByID, nil, func() bool { return true }, testHarness2.Blockchain(), network2, testHarness2.Blockchain().Params())
 	assert.NoError(t, err)
 
 	assert.NoError(t, mn.LinkAll())
@@ -140,3 +143,46 @@ func TestChainService(t *testing.T) {
 	}
 	assert.Equal(t, uint32(11), i)
 }
+
+func TestGetBlockFilter(t *testing.T) {
+	mn := mocknet.New()
+
+	f, err := harness.BlocksData.Open("blocks/blocks.dat")
+	assert.NoError(t, err)
+	testHarness, err := harness.NewTestHarness(harness.DefaultOptions(), harness.LoadBlocks(f, 10))
+	assert.NoError(t, err)
+	chain := testHarness.Blockchain()
+
+	node1, err := makeMockNode(mn, chain)
+	assert.NoError(t, err)
+	node2, err := makeMockNode(mn, chain)
+	assert.NoError(t, err)
+
+	assert.NoError(t, mn.LinkAll())
+	assert.NoError(t, mn.ConnectAllButSelf())
+
+	// node2 doesn't have the filter index.
+	b5, err := chain.GetBlockByHeight(5)
+	assert.NoError(t, err)
+	_, _, err = node1.service.GetBlockFilter(node2.network.Host().ID(), b5.ID())
+	assert.Error(t, err)
+
+	filter := indexers.BuildBlockFilter(b5).Bytes()
+	filterHeader := indexers.BlockFilterHeader(filter, types.ID{})
+	node2.service.fetchBlockFilter = func(blockID types.ID) ([]byte, types.ID, error) {
+		if blockID != b5.ID() {
+			return nil, types.ID{}, errors.New("not found")
+		}
+		return filter, filterHeader, nil
+	}
+
+	retFilter, retHeader, err := node1.service.GetBlockFilter(node2.network.Host().ID(), b5.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, filter, retFilter)
+	assert.Equal(t, filterHeader, retHeader)
+
+	b4, err := chain.GetBlockByHeight(4)
+	assert.NoError(t, err)
+	_, _, err = node1.service.GetBlockFilter(node2.network.Host().ID(), b4.ID())
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/project-illium/ilxd/blockchain"
 	"github.com/project-illium/ilxd/net"
 	"github.com/project-illium/ilxd/params"
+	"github.com/project-illium/ilxd/tasks"
 	"github.com/project-illium/ilxd/types"
 	"github.com/project-illium/ilxd/types/blocks"
 	"github.com/project-illium/ilxd/zk"
@@ -108,11 +109,16 @@ func (sm *SyncManager) Start() {
 
 	_, startheight, _ := sm.chain.BestBlock()
 
+	task := tasks.Start(tasks.KindSync, "chain sync")
+	defer task.Done()
+
 	// Before we start we want to do a large peer query to see if there
 	// are any forks out there. If there are, we will sort the peers into
 	// buckets depending on which fork they are on.
 	log.WithCaller(true).Trace("Waiting for enough peers to start sync")
+	task.SetState("waiting for peers")
 	sm.waitForPeers()
+	task.SetState("querying peers for forks")
 	for {
 		err := sm.populatePeerBuckets()
 		if err != nil {
@@ -133,6 +139,7 @@ func (sm *SyncManager) Start() {
 	// Sync up to the checkpoints if we're not already past them.
 	if len(sm.params.Checkpoints) > 0 && startheight < sm.params.Checkpoints[len(sm.params.Checkpoints)-1].Height {
 		log.WithCaller(true).Trace("Syncing to checkpoints", log.Args("start height", startheight))
+		task.SetState("syncing to checkpoints from height %d", startheight)
 		sm.syncToCheckpoints(startheight)
 	}
 
@@ -157,6 +164,7 @@ syncLoop:
 		// the subset that we query. This will ensure that, if there is a
 		// fork, we will encounter it as we sync forward.
 		bestID, height, _ := sm.chain.BestBlock()
+		task.SetState("syncing blocks %d to %d", height+1, height+lookaheadSize)
 		log.WithCaller(true).Trace("Syncing blocks", log.ArgsFromMap(map[string]any{
 			"current height": height,
 			"to height":      height + lookaheadSize,
@@ -202,6 +210,7 @@ syncLoop:
 				log.Debug("Error find fork point", log.Args("error", err))
 				continue
 			}
+			task.SetState("resolving fork at height %d", forkHeight)
 			log.WithCaller(true).Trace("Query peers not in agreement", log.ArgsFromMap(map[string]any{
 				"forkHeight": forkHeight,
 				"forkBlock":  forkBlock.String(),
@@ -291,6 +300,9 @@ syncLoop:
 			if len(firstBlocks) > 1 {
 				for blockID, p := range blockMap {
 					if blockID != bestID {
+						if tip, ok := syncTo[blockID]; ok {
+							sm.chain.RecordChainTip(tip.ID(), tip.Header.Height, forkHeight, blockchain.ChainTipRejected)
+						}
 						sm.network.IncreaseBanscore(p, 101, 0, "served invalid chain fork")
 						sm.bucketMtx.Lock()
 						var banBucket types.ID
@@ -402,6 +414,15 @@ func (sm *SyncManager) queryPeersForBlockID(height uint32) (map[types.ID]peer.ID
 		toQuery[p] = true
 	}
 
+	// Always query the anchor peers. They are trusted by the
+	// operator so if we are being fed a bad chain by the other
+	// peers this makes sure we see the fork.
+	for _, p := range peers {
+		if sm.network.IsAnchorPeer(p) {
+			toQuery[p] = true
+		}
+	}
+
 	// Add a peer from each bucket to make sure that as
 	// we're syncing we discover any forks that might be
 	// out there.
@@ -454,7 +475,10 @@ bucketLoop:
 	count := 0
 	for r := range ch {
 		if r.height > bestHeight {
-			ret[r.blockID] = r.p
+			// Prefer to download each chain from an anchor peer.
+			if p, ok := ret[r.blockID]; !ok || !sm.network.IsAnchorPeer(p) {
+				ret[r.blockID] = r.p
+			}
 		}
 		count++
 	}
@@ -559,7 +583,7 @@ func (sm *SyncManager) syncToCheckpoints(currentHeight uint32) {
 				time.Sleep(time.Second * 5)
 				continue
 			}
-			p := peers[rand.Intn(len(peers))]
+			p := sm.selectDownloadPeer(peers)
 			pruned, err := sm.chain.IsPruned()
 			if err != nil {
 				log.Error("Error checking pruned state", log.Args("error", err))
@@ -884,6 +908,21 @@ func (sm *SyncManager) waitForPeers() {
 	}
 }
 
+// selectDownloadPeer returns a random anchor peer from the list of peers
+// if there is one, otherwise a random peer.
+func (sm *SyncManager) selectDownloadPeer(peers []peer.ID) peer.ID {
+	anchors := make([]peer.ID, 0, len(peers))
+	for _, p := range peers {
+		if sm.network.IsAnchorPeer(p) {
+			anchors = append(anchors, p)
+		}
+	}
+	if len(anchors) > 0 {
+		return anchors[rand.Intn(len(anchors))]
+	}
+	return peers[rand.Intn(len(peers))]
+}
+
 func (sm *SyncManager) syncPeers() []peer.ID {
 	peers := make([]peer.ID, 0, len(sm.network.Host().Network().Peers()))
 peerLoop:
@@ -899,7 +938,8 @@ peerLoop:
 			continue
 		}
 		for _, proto := range protocols {
-			if proto == sm.params.ProtocolPrefix+ChainServiceProtocol+ChainServiceProtocolVersion {
+			if proto == sm.params.ProtocolPrefix+ChainServiceProtocol+ChainServiceProtocolVersion ||
+				proto == sm.params.ProtocolPrefix+ChainServiceProtocol+net.LegacyProtocolVersion {
 				peers = append(peers, p)
 				break
 			}
@@ -0,0 +1,106 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+// Package tasks keeps a registry of the node's long running background
+// work, such as chain sync, rescans, and proofs, so that it can be listed
+// over the RPC when the node appears to be stuck.
+package tasks
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// The kinds of tasks tracked by the registry.
+const (
+	KindSync    = "sync"
+	KindRescan  = "rescan"
+	KindProving = "proving"
+
+	// KindConsensusPoll is used for the heights the consensus engine
+	// is polling validators for. The engine tracks its polls itself
+	// so they are not in the registry.
+	KindConsensusPoll = "consensus poll"
+)
+
+var (
+	mtx    sync.Mutex
+	nextID uint64
+	active = make(map[uint64]*Task)
+)
+
+// Task is a snapshot of a background task.
+type Task struct {
+	ID   uint64
+	Kind string
+	// Name describes what the task is doing, for example which
+	// view key is being rescanned.
+	Name string
+	// State is the task's most recently reported progress.
+	State   string
+	Started time.Time
+	// Updated is the last time the state changed.
+	Updated time.Time
+}
+
+// Handle is used by a running task to report its state. Done must be
+// called when the task finishes.
+type Handle struct {
+	id uint64
+}
+
+// Start registers a new running task.
+func Start(kind, name string) *Handle {
+	mtx.Lock()
+	defer mtx.Unlock()
+
+	nextID++
+	now := time.Now()
+	active[nextID] = &Task{
+		ID:      nextID,
+		Kind:    kind,
+		Name:    name,
+		State:   "running",
+		Started: now,
+		Updated: now,
+	}
+	return &Handle{id: nextID}
+}
+
+// SetState updates the task's state. The arguments are formatted
+// as with fmt.Sprintf.
+func (h *Handle) SetState(format string, args ...any) {
+	mtx.Lock()
+	defer mtx.Unlock()
+
+	if t, ok := active[h.id]; ok {
+		t.State = fmt.Sprintf(format, args...)
+		t.Updated = time.Now()
+	}
+}
+
+// Done removes the task from the registry.
+func (h *Handle) Done() {
+	mtx.Lock()
+	defer mtx.Unlock()
+
+	delete(active, h.id)
+}
+
+// List returns the running tasks in the order they were started.
+func List() []Task {
+	mtx.Lock()
+	defer mtx.Unlock()
+
+	ret := make([]Task, 0, len(active))
+	for _, t := range active {
+		ret = append(ret, *t)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].ID < ret[j].ID
+	})
+	return ret
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package tasks
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTasks(t *testing.T) {
+	sync := Start(KindSync, "chain sync")
+	rescan := Start(KindRescan, "view key abc")
+
+	list := List()
+	assert.Len(t, list, 2)
+	assert.Equal(t, KindSync, list[0].Kind)
+	assert.Equal(t, "chain sync", list[0].Name)
+	assert.Equal(t, "running", list[0].State)
+	assert.Equal(t, KindRescan, list[1].Kind)
+
+	rescan.SetState("height %d of %d", 10, 100)
+	list = List()
+	assert.Equal(t, "height 10 of 100", list[1].State)
+	assert.False(t, list[1].Updated.Before(list[1].Started))
+
+	sync.Done()
+	list = List()
+	assert.Len(t, list, 1)
+	assert.Equal(t, KindRescan, list[0].Kind)
+
+	// Updating a finished task is a no-op.
+	sync.SetState("syncing")
+	rescan.Done()
+	rescan.Done()
+	assert.Len(t, List(), 0)
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"github.com/project-illium/ilxd/repo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+)
+
+var tracer = otel.Tracer("github.com/project-illium/ilxd")
+
+// setupTracing installs a global tracer provider which batches spans and
+// exports them to the OTLP collector at the given endpoint. The returned
+// function flushes any buffered spans and shuts down the exporter.
+//
+// If this is not called the global provider is a no-op and all the spans
+// created throughout the codebase are discarded.
+func setupTracing(ctx context.Context, opts repo.TracingOptions, netName string) (func(context.Context) error, error) {
+	exporterOpts := []otlptracegrpc.Option{
+		otlptracegrpc.WithEndpoint(opts.Endpoint),
+	}
+	if opts.Insecure {
+		exporterOpts = append(exporterOpts, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, exporterOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName("ilxd"),
+		semconv.ServiceVersion(repo.VersionString()),
+		attribute.String("illium.network", netName),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRate))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
@@ -5,6 +5,7 @@
 package blocks
 
 import (
+	"bytes"
 	"encoding/json"
 	"github.com/project-illium/ilxd/params/hash"
 	"github.com/project-illium/ilxd/types"
@@ -15,6 +16,7 @@ import (
 
 var _ types.Serializable = (*BlockHeader)(nil)
 var _ types.Serializable = (*Block)(nil)
+var _ types.Serializable = (*EquivocationEvidence)(nil)
 
 type headerJSON struct {
 	Version     uint32             `json:"version"`
@@ -26,6 +28,11 @@ type headerJSON struct {
 	Signature   types.HexEncodable `json:"signature"`
 }
 
+type evidenceJSON struct {
+	First  *BlockHeader `json:"first"`
+	Second *BlockHeader `json:"second"`
+}
+
 type blockJSON struct {
 	Header       headerJSON                  `json:"header"`
 	Transactions []*transactions.Transaction `json:"transactions"`
@@ -37,11 +44,11 @@ func (h *BlockHeader) ID() types.ID {
 }
 
 func (h *BlockHeader) Serialize() ([]byte, error) {
-	return proto.Marshal(h)
+	return types.CanonicalMarshal(h)
 }
 
 func (h *BlockHeader) SerializedSize() (int, error) {
-	ser, err := proto.Marshal(h)
+	ser, err := types.CanonicalMarshal(h)
 	if err != nil {
 		return 0, err
 	}
@@ -67,7 +74,7 @@ func (h *BlockHeader) SigHash() ([]byte, error) {
 	cpy := proto.Clone(h)
 	cpy.(*BlockHeader).Signature = nil
 
-	b, err := proto.Marshal(cpy)
+	b, err := types.CanonicalMarshal(cpy)
 	if err != nil {
 		return nil, err
 	}
@@ -145,11 +152,11 @@ func (b *Block) Txids() []types.ID {
 }
 
 func (b *Block) Serialize() ([]byte, error) {
-	return proto.Marshal(b)
+	return types.CanonicalMarshal(b)
 }
 
 func (b *Block) SerializedSize() (int, error) {
-	ser, err := proto.Marshal(b)
+	ser, err := types.CanonicalMarshal(b)
 	if err != nil {
 		return 0, err
 	}
@@ -327,3 +334,53 @@ func (b *CompressedBlock) UnmarshalJSON(data []byte) error {
 	}
 	return nil
 }
+
+// ID returns the same ID regardless of the order of the two headers.
+func (e *EquivocationEvidence) ID() types.ID {
+	a, b := e.First.ID(), e.Second.ID()
+	if bytes.Compare(a[:], b[:]) > 0 {
+		a, b = b, a
+	}
+	return types.NewIDFromData(append(a[:], b[:]...))
+}
+
+func (e *EquivocationEvidence) Serialize() ([]byte, error) {
+	return proto.Marshal(e)
+}
+
+func (e *EquivocationEvidence) SerializedSize() (int, error) {
+	ser, err := proto.Marshal(e)
+	if err != nil {
+		return 0, err
+	}
+	return len(ser), nil
+}
+
+func (e *EquivocationEvidence) Deserialize(data []byte) error {
+	newEvidence := &EquivocationEvidence{}
+	if err := proto.Unmarshal(data, newEvidence); err != nil {
+		return err
+	}
+	e.First = newEvidence.First
+	e.Second = newEvidence.Second
+	return nil
+}
+
+func (e *EquivocationEvidence) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&evidenceJSON{
+		First:  e.First,
+		Second: e.Second,
+	})
+}
+
+func (e *EquivocationEvidence) UnmarshalJSON(data []byte) error {
+	newEvidence := &evidenceJSON{}
+	if err := json.Unmarshal(data, newEvidence); err != nil {
+		return err
+	}
+	*e = EquivocationEvidence{
+		First:  newEvidence.First,
+		Second: newEvidence.Second,
+	}
+	return nil
+}
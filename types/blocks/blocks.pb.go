@@ -372,6 +372,63 @@ func (x *CompressedBlock) GetTxs() []*CompressedBlock_CompressedTx {
 	return nil
 }
 
+// EquivocationEvidence is two signed block headers at the same
+// height from the same producer.
+type EquivocationEvidence struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	First  *BlockHeader `protobuf:"bytes,1,opt,name=first,proto3" json:"first,omitempty"`
+	Second *BlockHeader `protobuf:"bytes,2,opt,name=second,proto3" json:"second,omitempty"`
+}
+
+func (x *EquivocationEvidence) Reset() {
+	*x = EquivocationEvidence{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_blocks_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EquivocationEvidence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EquivocationEvidence) ProtoMessage() {}
+
+func (x *EquivocationEvidence) ProtoReflect() protoreflect.Message {
+	mi := &file_blocks_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EquivocationEvidence.ProtoReflect.Descriptor instead.
+func (*EquivocationEvidence) Descriptor() ([]byte, []int) {
+	return file_blocks_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *EquivocationEvidence) GetFirst() *BlockHeader {
+	if x != nil {
+		return x.First
+	}
+	return nil
+}
+
+func (x *EquivocationEvidence) GetSecond() *BlockHeader {
+	if x != nil {
+		return x.Second
+	}
+	return nil
+}
+
 type XThinnerBlock_PrefilledTransaction struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -384,7 +441,7 @@ type XThinnerBlock_PrefilledTransaction struct {
 func (x *XThinnerBlock_PrefilledTransaction) Reset() {
 	*x = XThinnerBlock_PrefilledTransaction{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_blocks_proto_msgTypes[5]
+		mi := &file_blocks_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -397,7 +454,7 @@ func (x *XThinnerBlock_PrefilledTransaction) String() string {
 func (*XThinnerBlock_PrefilledTransaction) ProtoMessage() {}
 
 func (x *XThinnerBlock_PrefilledTransaction) ProtoReflect() protoreflect.Message {
-	mi := &file_blocks_proto_msgTypes[5]
+	mi := &file_blocks_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -440,7 +497,7 @@ type CompressedBlock_CompressedTx struct {
 func (x *CompressedBlock_CompressedTx) Reset() {
 	*x = CompressedBlock_CompressedTx{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_blocks_proto_msgTypes[6]
+		mi := &file_blocks_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -453,7 +510,7 @@ func (x *CompressedBlock_CompressedTx) String() string {
 func (*CompressedBlock_CompressedTx) ProtoMessage() {}
 
 func (x *CompressedBlock_CompressedTx) ProtoReflect() protoreflect.Message {
-	mi := &file_blocks_proto_msgTypes[6]
+	mi := &file_blocks_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -551,9 +608,15 @@ var file_blocks_proto_rawDesc = []byte{
 	0x6e, 0x75, 0x6c, 0x6c, 0x69, 0x66, 0x69, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c,
 	0x52, 0x0a, 0x6e, 0x75, 0x6c, 0x6c, 0x69, 0x66, 0x69, 0x65, 0x72, 0x73, 0x12, 0x21, 0x0a, 0x07,
 	0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x07, 0x2e,
-	0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x42,
-	0x0b, 0x5a, 0x09, 0x2e, 0x2e, 0x2f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x22,
+	0x60, 0x0a, 0x14, 0x45, 0x71, 0x75, 0x69, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45,
+	0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x22, 0x0a, 0x05, 0x66, 0x69, 0x72, 0x73, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x52, 0x05, 0x66, 0x69, 0x72, 0x73, 0x74, 0x12, 0x24, 0x0a, 0x06, 0x73,
+	0x65, 0x63, 0x6f, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x06, 0x73, 0x65, 0x63, 0x6f, 0x6e,
+	0x64, 0x42, 0x0b, 0x5a, 0x09, 0x2e, 0x2e, 0x2f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -568,32 +631,35 @@ func file_blocks_proto_rawDescGZIP() []byte {
 	return file_blocks_proto_rawDescData
 }
 
-var file_blocks_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_blocks_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_blocks_proto_goTypes = []interface{}{
 	(*BlockHeader)(nil),                        // 0: BlockHeader
 	(*Block)(nil),                              // 1: Block
 	(*BlockTxs)(nil),                           // 2: BlockTxs
 	(*XThinnerBlock)(nil),                      // 3: XThinnerBlock
 	(*CompressedBlock)(nil),                    // 4: CompressedBlock
-	(*XThinnerBlock_PrefilledTransaction)(nil), // 5: XThinnerBlock.PrefilledTransaction
-	(*CompressedBlock_CompressedTx)(nil),       // 6: CompressedBlock.CompressedTx
-	(*transactions.Transaction)(nil),           // 7: Transaction
-	(*transactions.Output)(nil),                // 8: Output
+	(*EquivocationEvidence)(nil),               // 5: EquivocationEvidence
+	(*XThinnerBlock_PrefilledTransaction)(nil), // 6: XThinnerBlock.PrefilledTransaction
+	(*CompressedBlock_CompressedTx)(nil),       // 7: CompressedBlock.CompressedTx
+	(*transactions.Transaction)(nil),           // 8: Transaction
+	(*transactions.Output)(nil),                // 9: Output
 }
 var file_blocks_proto_depIdxs = []int32{
-	0, // 0: Block.header:type_name -> BlockHeader
-	7, // 1: Block.transactions:type_name -> Transaction
-	7, // 2: BlockTxs.transactions:type_name -> Transaction
-	0, // 3: XThinnerBlock.header:type_name -> BlockHeader
-	5, // 4: XThinnerBlock.prefilled_txs:type_name -> XThinnerBlock.PrefilledTransaction
-	6, // 5: CompressedBlock.txs:type_name -> CompressedBlock.CompressedTx
-	7, // 6: XThinnerBlock.PrefilledTransaction.transaction:type_name -> Transaction
-	8, // 7: CompressedBlock.CompressedTx.outputs:type_name -> Output
-	8, // [8:8] is the sub-list for method output_type
-	8, // [8:8] is the sub-list for method input_type
-	8, // [8:8] is the sub-list for extension type_name
-	8, // [8:8] is the sub-list for extension extendee
-	0, // [0:8] is the sub-list for field type_name
+	0,  // 0: Block.header:type_name -> BlockHeader
+	8,  // 1: Block.transactions:type_name -> Transaction
+	8,  // 2: BlockTxs.transactions:type_name -> Transaction
+	0,  // 3: XThinnerBlock.header:type_name -> BlockHeader
+	6,  // 4: XThinnerBlock.prefilled_txs:type_name -> XThinnerBlock.PrefilledTransaction
+	7,  // 5: CompressedBlock.txs:type_name -> CompressedBlock.CompressedTx
+	0,  // 6: EquivocationEvidence.first:type_name -> BlockHeader
+	0,  // 7: EquivocationEvidence.second:type_name -> BlockHeader
+	8,  // 8: XThinnerBlock.PrefilledTransaction.transaction:type_name -> Transaction
+	9,  // 9: CompressedBlock.CompressedTx.outputs:type_name -> Output
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_blocks_proto_init() }
@@ -663,7 +729,7 @@ func file_blocks_proto_init() {
 			}
 		}
 		file_blocks_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*XThinnerBlock_PrefilledTransaction); i {
+			switch v := v.(*EquivocationEvidence); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -675,6 +741,18 @@ func file_blocks_proto_init() {
 			}
 		}
 		file_blocks_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*XThinnerBlock_PrefilledTransaction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_blocks_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CompressedBlock_CompressedTx); i {
 			case 0:
 				return &v.state
@@ -693,7 +771,7 @@ func file_blocks_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_blocks_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
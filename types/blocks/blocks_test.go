@@ -6,13 +6,16 @@ package blocks_test
 
 import (
 	"bytes"
+	"encoding/hex"
 	"encoding/json"
 	"github.com/go-test/deep"
 	"github.com/project-illium/ilxd/params"
 	"github.com/project-illium/ilxd/types/blocks"
 	"github.com/project-illium/ilxd/types/transactions"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 	"testing"
 )
 
@@ -76,3 +79,157 @@ func TestJSONMarshalUnmarshal(t *testing.T) {
 
 	assert.Empty(t, deep.Equal(b, proto.Clone(&b2)))
 }
+
+type canonicalMessage interface {
+	Serialize() ([]byte, error)
+	SerializedSize() (int, error)
+}
+
+func canonicalTestVectors() []struct {
+	name     string
+	msg      canonicalMessage
+	expected string
+} {
+	output := &transactions.Output{
+		Commitment: bytes.Repeat([]byte{0x01}, 4),
+		Ciphertext: bytes.Repeat([]byte{0x02}, 4),
+	}
+	locktime := &transactions.Locktime{
+		Timestamp: 1700000000,
+		Precision: 600,
+	}
+	return []struct {
+		name     string
+		msg      canonicalMessage
+		expected string
+	}{
+		{
+			name: "block header",
+			msg: &blocks.BlockHeader{
+				Version:     1,
+				Height:      1000,
+				Parent:      bytes.Repeat([]byte{0x11}, 4),
+				Timestamp:   1700000000,
+				TxRoot:      bytes.Repeat([]byte{0x22}, 4),
+				Producer_ID: bytes.Repeat([]byte{0x33}, 4),
+				Signature:   bytes.Repeat([]byte{0x44}, 4),
+			},
+			expected: "080110e8071a04111111112080e2cfaa062a04222222223204333333333a0444444444",
+		},
+		{
+			name: "standard transaction",
+			msg: transactions.WrapTransaction(&transactions.StandardTransaction{
+				Outputs:    []*transactions.Output{output},
+				Nullifiers: [][]byte{bytes.Repeat([]byte{0x03}, 4)},
+				TxoRoot:    bytes.Repeat([]byte{0x04}, 4),
+				Locktime:   locktime,
+				Fee:        5000,
+				Proof:      bytes.Repeat([]byte{0x05}, 4),
+			}),
+			expected: "0a2e0a0c0a04010101011204020202021204030303031a040404040422090880e2cfaa0610d804288827320405050505",
+		},
+		{
+			name: "coinbase transaction",
+			msg: transactions.WrapTransaction(&transactions.CoinbaseTransaction{
+				Validator_ID: bytes.Repeat([]byte{0x06}, 4),
+				NewCoins:     100000,
+				Outputs:      []*transactions.Output{output},
+				Signature:    bytes.Repeat([]byte{0x07}, 4),
+				Proof:        bytes.Repeat([]byte{0x08}, 4),
+			}),
+			expected: "12240a040606060610a08d061a0c0a04010101011204020202022204070707072a0408080808",
+		},
+		{
+			name: "stake transaction",
+			msg: transactions.WrapTransaction(&transactions.StakeTransaction{
+				Validator_ID: bytes.Repeat([]byte{0x09}, 4),
+				Amount:       250000,
+				Nullifier:    bytes.Repeat([]byte{0x0a}, 4),
+				TxoRoot:      bytes.Repeat([]byte{0x0b}, 4),
+				LockedUntil:  1800000000,
+				Signature:    bytes.Repeat([]byte{0x0c}, 4),
+				Proof:        bytes.Repeat([]byte{0x0d}, 4),
+			}),
+			expected: "1a280a04090909091090a10f1a040a0a0a0a22040b0b0b0b2880a4a7da0632040c0c0c0c3a040d0d0d0d",
+		},
+		{
+			name: "treasury transaction",
+			msg: transactions.WrapTransaction(&transactions.TreasuryTransaction{
+				Amount:       750000,
+				Outputs:      []*transactions.Output{output},
+				ProposalHash: bytes.Repeat([]byte{0x0e}, 4),
+				Proof:        bytes.Repeat([]byte{0x0f}, 4),
+			}),
+			expected: "221e08b0e32d120c0a04010101011204020202021a040e0e0e0e22040f0f0f0f",
+		},
+		{
+			name: "mint transaction",
+			msg: transactions.WrapTransaction(&transactions.MintTransaction{
+				Type:         transactions.MintTransaction_VARIABLE_SUPPLY,
+				Asset_ID:     bytes.Repeat([]byte{0x10}, 4),
+				DocumentHash: bytes.Repeat([]byte{0x11}, 4),
+				NewTokens:    42,
+				Outputs:      []*transactions.Output{output},
+				Fee:          3000,
+				Nullifiers:   [][]byte{bytes.Repeat([]byte{0x12}, 4)},
+				TxoRoot:      bytes.Repeat([]byte{0x13}, 4),
+				MintKey:      bytes.Repeat([]byte{0x14}, 4),
+				Locktime:     locktime,
+				Signature:    bytes.Repeat([]byte{0x15}, 4),
+				Proof:        bytes.Repeat([]byte{0x16}, 4),
+			}),
+			expected: "2a4a08011204101010101a0411111111202a2a0c0a040101010112040202020230b8173a04121212124204131313134a041414141452090880e2cfaa0610d8045a0415151515620416161616",
+		},
+	}
+}
+
+// TestCanonicalEncoding pins the canonical encoding of the consensus
+// messages. IDs and signature hashes are computed over these bytes so a
+// change to the protobuf library that alters them would fork the chain.
+func TestCanonicalEncoding(t *testing.T) {
+	for _, test := range canonicalTestVectors() {
+		ser, err := test.msg.Serialize()
+		assert.NoError(t, err, test.name)
+		assert.Equal(t, test.expected, hex.EncodeToString(ser), test.name)
+
+		size, err := test.msg.SerializedSize()
+		assert.NoError(t, err, test.name)
+		assert.Equal(t, len(ser), size, test.name)
+	}
+}
+
+func TestCanonicalEncodingDropsUnknownFields(t *testing.T) {
+	unknown := protowire.AppendVarint(protowire.AppendTag(nil, 99, protowire.VarintType), 1)
+
+	for _, test := range canonicalTestVectors() {
+		m := proto.Clone(test.msg.(proto.Message))
+		m.ProtoReflect().SetUnknown(unknown)
+		if tx, ok := m.(*transactions.Transaction); ok {
+			// Set unknown fields on the nested messages as well.
+			tx.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+				v.Message().SetUnknown(unknown)
+				return true
+			})
+			for _, out := range tx.Outputs() {
+				out.ProtoReflect().SetUnknown(unknown)
+			}
+		}
+
+		ser, err := m.(canonicalMessage).Serialize()
+		assert.NoError(t, err, test.name)
+		assert.Equal(t, test.expected, hex.EncodeToString(ser), test.name)
+
+		// The original message must not be modified.
+		assert.Equal(t, unknown, []byte(m.ProtoReflect().GetUnknown()), test.name)
+	}
+
+	// The ID of a transaction must not change if unknown fields
+	// are appended by a peer relaying it.
+	tx := canonicalTestVectors()[1].msg.(*transactions.Transaction)
+	ser, err := tx.Serialize()
+	assert.NoError(t, err)
+
+	var tx2 transactions.Transaction
+	assert.NoError(t, tx2.Deserialize(append(ser, unknown...)))
+	assert.Equal(t, tx.ID(), tx2.ID())
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package types
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// sortedFields caches the fields of each message descriptor
+// sorted by field number.
+var sortedFields sync.Map // map[protoreflect.FullName][]protoreflect.FieldDescriptor
+
+// CanonicalMarshal serializes a consensus message in its canonical encoding.
+// Block and transaction IDs and signature hashes are computed over this
+// encoding so it must never change.
+//
+// The message is encoded field by field here rather than by the protobuf
+// library, whose output is not guaranteed to be stable across versions.
+// Each populated field is written in field number order, repeated scalars
+// are packed, and unknown fields are never written. Dropping unknown fields
+// prevents anyone relaying a block or transaction from changing its ID
+// without invalidating it. Map and group fields are not supported.
+func CanonicalMarshal(m proto.Message) ([]byte, error) {
+	return appendMessage(nil, m.ProtoReflect())
+}
+
+func appendMessage(b []byte, m protoreflect.Message) ([]byte, error) {
+	var err error
+	for _, fd := range fieldsByNumber(m.Descriptor()) {
+		if !m.Has(fd) {
+			continue
+		}
+		v := m.Get(fd)
+		switch {
+		case fd.IsMap():
+			return nil, fmt.Errorf("canonical encoding: map field %s not supported", fd.FullName())
+		case fd.IsList():
+			b, err = appendList(b, fd, v.List())
+		default:
+			b = protowire.AppendTag(b, fd.Number(), wireType(fd.Kind()))
+			b, err = appendValue(b, fd, v)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+func appendList(b []byte, fd protoreflect.FieldDescriptor, l protoreflect.List) ([]byte, error) {
+	var err error
+	if wt := wireType(fd.Kind()); wt != protowire.BytesType {
+		var packed []byte
+		for i := 0; i < l.Len(); i++ {
+			if packed, err = appendValue(packed, fd, l.Get(i)); err != nil {
+				return nil, err
+			}
+		}
+		b = protowire.AppendTag(b, fd.Number(), protowire.BytesType)
+		return protowire.AppendBytes(b, packed), nil
+	}
+	for i := 0; i < l.Len(); i++ {
+		b = protowire.AppendTag(b, fd.Number(), protowire.BytesType)
+		if b, err = appendValue(b, fd, l.Get(i)); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+func appendValue(b []byte, fd protoreflect.FieldDescriptor, v protoreflect.Value) ([]byte, error) {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return protowire.AppendVarint(b, protowire.EncodeBool(v.Bool())), nil
+	case protoreflect.EnumKind:
+		return protowire.AppendVarint(b, uint64(v.Enum())), nil
+	case protoreflect.Int32Kind, protoreflect.Int64Kind:
+		return protowire.AppendVarint(b, uint64(v.Int())), nil
+	case protoreflect.Uint32Kind, protoreflect.Uint64Kind:
+		return protowire.AppendVarint(b, v.Uint()), nil
+	case protoreflect.Sint32Kind, protoreflect.Sint64Kind:
+		return protowire.AppendVarint(b, protowire.EncodeZigZag(v.Int())), nil
+	case protoreflect.Fixed32Kind:
+		return protowire.AppendFixed32(b, uint32(v.Uint())), nil
+	case protoreflect.Sfixed32Kind:
+		return protowire.AppendFixed32(b, uint32(v.Int())), nil
+	case protoreflect.FloatKind:
+		return protowire.AppendFixed32(b, math.Float32bits(float32(v.Float()))), nil
+	case protoreflect.Fixed64Kind:
+		return protowire.AppendFixed64(b, v.Uint()), nil
+	case protoreflect.Sfixed64Kind:
+		return protowire.AppendFixed64(b, uint64(v.Int())), nil
+	case protoreflect.DoubleKind:
+		return protowire.AppendFixed64(b, math.Float64bits(v.Float())), nil
+	case protoreflect.StringKind:
+		return protowire.AppendString(b, v.String()), nil
+	case protoreflect.BytesKind:
+		return protowire.AppendBytes(b, v.Bytes()), nil
+	case protoreflect.MessageKind:
+		ser, err := appendMessage(nil, v.Message())
+		if err != nil {
+			return nil, err
+		}
+		return protowire.AppendBytes(b, ser), nil
+	}
+	return nil, fmt.Errorf("canonical encoding: %s field %s not supported", fd.Kind(), fd.FullName())
+}
+
+func wireType(k protoreflect.Kind) protowire.Type {
+	switch k {
+	case protoreflect.Fixed32Kind, protoreflect.Sfixed32Kind, protoreflect.FloatKind:
+		return protowire.Fixed32Type
+	case protoreflect.Fixed64Kind, protoreflect.Sfixed64Kind, protoreflect.DoubleKind:
+		return protowire.Fixed64Type
+	case protoreflect.StringKind, protoreflect.BytesKind, protoreflect.MessageKind, protoreflect.GroupKind:
+		return protowire.BytesType
+	}
+	return protowire.VarintType
+}
+
+func fieldsByNumber(md protoreflect.MessageDescriptor) []protoreflect.FieldDescriptor {
+	if fields, ok := sortedFields.Load(md.FullName()); ok {
+		return fields.([]protoreflect.FieldDescriptor)
+	}
+	fields := make([]protoreflect.FieldDescriptor, md.Fields().Len())
+	for i := range fields {
+		fields[i] = md.Fields().Get(i)
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Number() < fields[j].Number()
+	})
+	sortedFields.Store(md.FullName(), fields)
+	return fields
+}
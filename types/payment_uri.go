@@ -0,0 +1,95 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package types
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PaymentURIScheme is the URI scheme used for payment requests.
+const PaymentURIScheme = "ilx"
+
+// PaymentURI is a request for payment which can be handed out as a
+// link or QR code. It takes the form:
+//
+//	ilx:<address>?amount=<ILX>&memo=<memo>&expires=<unix timestamp>
+//
+// Only the address is required. The amount is in ILX, not nanoillium,
+// so the URI reads the same as the amount shown to the user.
+//
+// The address is not validated as the types package doesn't know the
+// network parameters. Callers must decode it before paying.
+type PaymentURI struct {
+	Address string
+	Amount  Amount
+	Memo    string
+	// Expires is the time after which the request should not be paid.
+	// A zero time means the request does not expire.
+	Expires time.Time
+}
+
+// String returns the URI encoding of the payment request.
+func (p *PaymentURI) String() string {
+	params := url.Values{}
+	if p.Amount > 0 {
+		params.Set("amount", formatAmountString(uint64(p.Amount)))
+	}
+	if p.Memo != "" {
+		params.Set("memo", p.Memo)
+	}
+	if !p.Expires.IsZero() {
+		params.Set("expires", strconv.FormatInt(p.Expires.Unix(), 10))
+	}
+	s := PaymentURIScheme + ":" + p.Address
+	if len(params) > 0 {
+		s += "?" + strings.ReplaceAll(params.Encode(), "+", "%20")
+	}
+	return s
+}
+
+// Expired returns whether the payment request has expired.
+func (p *PaymentURI) Expired() bool {
+	return !p.Expires.IsZero() && time.Now().After(p.Expires)
+}
+
+// ParsePaymentURI decodes a payment request URI.
+func ParsePaymentURI(s string) (*PaymentURI, error) {
+	u, err := url.Parse(strings.TrimSpace(s))
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(u.Scheme, PaymentURIScheme) {
+		return nil, errors.New("not an ilx payment uri")
+	}
+	if u.Opaque == "" {
+		return nil, errors.New("payment uri is missing the address")
+	}
+	params, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		return nil, err
+	}
+	p := &PaymentURI{
+		Address: u.Opaque,
+		Memo:    params.Get("memo"),
+	}
+	if amt := params.Get("amount"); amt != "" {
+		p.Amount, err = AmountFromILX(amt)
+		if err != nil {
+			return nil, errors.New("invalid payment uri amount")
+		}
+	}
+	if expires := params.Get("expires"); expires != "" {
+		ts, err := strconv.ParseInt(expires, 10, 64)
+		if err != nil || ts <= 0 {
+			return nil, errors.New("invalid payment uri expiry")
+		}
+		p.Expires = time.Unix(ts, 0)
+	}
+	return p, nil
+}
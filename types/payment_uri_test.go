@@ -0,0 +1,68 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package types
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestPaymentURI(t *testing.T) {
+	tests := []struct {
+		name string
+		uri  PaymentURI
+		str  string
+	}{
+		{
+			name: "address only",
+			uri:  PaymentURI{Address: "il1qxyz"},
+			str:  "ilx:il1qxyz",
+		},
+		{
+			name: "all fields",
+			uri: PaymentURI{
+				Address: "il1qxyz",
+				Amount:  1500000000,
+				Memo:    "order #12 & tip",
+				Expires: time.Unix(1700000000, 0),
+			},
+			str: "ilx:il1qxyz?amount=1.5&expires=1700000000&memo=order%20%2312%20%26%20tip",
+		},
+		{
+			name: "fractional amount",
+			uri:  PaymentURI{Address: "il1qxyz", Amount: 1},
+			str:  "ilx:il1qxyz?amount=0.000000001",
+		},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.str, test.uri.String(), test.name)
+
+		parsed, err := ParsePaymentURI(test.str)
+		assert.NoError(t, err, test.name)
+		assert.Equal(t, test.uri.Address, parsed.Address, test.name)
+		assert.Equal(t, test.uri.Amount, parsed.Amount, test.name)
+		assert.Equal(t, test.uri.Memo, parsed.Memo, test.name)
+		assert.True(t, test.uri.Expires.Equal(parsed.Expires), test.name)
+	}
+
+	parsed, err := ParsePaymentURI("ILX:il1qxyz?memo=coffee+beans")
+	assert.NoError(t, err)
+	assert.Equal(t, "coffee beans", parsed.Memo)
+
+	for _, bad := range []string{
+		"il1qxyz",
+		"bitcoin:il1qxyz",
+		"ilx:",
+		"ilx:il1qxyz?amount=abc",
+		"ilx:il1qxyz?expires=-5",
+	} {
+		_, err := ParsePaymentURI(bad)
+		assert.Error(t, err, bad)
+	}
+
+	assert.False(t, (&PaymentURI{Address: "il1qxyz"}).Expired())
+	assert.True(t, (&PaymentURI{Address: "il1qxyz", Expires: time.Now().Add(-time.Minute)}).Expired())
+}
@@ -179,11 +179,11 @@ func (tx *Transaction) Type() string {
 }
 
 func (tx *Transaction) Serialize() ([]byte, error) {
-	return proto.Marshal(tx)
+	return types.CanonicalMarshal(tx)
 }
 
 func (tx *Transaction) SerializedSize() (int, error) {
-	ser, err := proto.Marshal(tx)
+	ser, err := types.CanonicalMarshal(tx)
 	if err != nil {
 		return 0, err
 	}
@@ -283,7 +283,7 @@ type locktimeJSON struct {
 }
 
 func (tx *StandardTransaction) Serialize() ([]byte, error) {
-	return proto.Marshal(tx)
+	return types.CanonicalMarshal(tx)
 }
 
 func (tx *StandardTransaction) Deserialize(data []byte) error {
@@ -304,7 +304,7 @@ func (tx *StandardTransaction) SigHash() ([]byte, error) {
 	cpy := proto.Clone(tx)
 	cpy.(*StandardTransaction).Proof = nil
 
-	b, err := proto.Marshal(cpy)
+	b, err := types.CanonicalMarshal(cpy)
 	if err != nil {
 		return nil, err
 	}
@@ -402,7 +402,7 @@ type coinbaseTxJSON struct {
 }
 
 func (tx *CoinbaseTransaction) Serialize() ([]byte, error) {
-	return proto.Marshal(tx)
+	return types.CanonicalMarshal(tx)
 }
 
 func (tx *CoinbaseTransaction) Deserialize(data []byte) error {
@@ -423,7 +423,7 @@ func (tx *CoinbaseTransaction) SigHash() ([]byte, error) {
 	cpy.(*CoinbaseTransaction).Signature = nil
 	cpy.(*CoinbaseTransaction).Proof = nil
 
-	b, err := proto.Marshal(cpy)
+	b, err := types.CanonicalMarshal(cpy)
 	if err != nil {
 		return nil, err
 	}
@@ -488,7 +488,7 @@ type stakeTxJSON struct {
 }
 
 func (tx *StakeTransaction) Serialize() ([]byte, error) {
-	return proto.Marshal(tx)
+	return types.CanonicalMarshal(tx)
 }
 
 func (tx *StakeTransaction) Deserialize(data []byte) error {
@@ -511,7 +511,7 @@ func (tx *StakeTransaction) SigHash() ([]byte, error) {
 	cpy.(*StakeTransaction).Signature = nil
 	cpy.(*StakeTransaction).Proof = nil
 
-	b, err := proto.Marshal(cpy)
+	b, err := types.CanonicalMarshal(cpy)
 	if err != nil {
 		return nil, err
 	}
@@ -577,7 +577,7 @@ type treasuryTxJSON struct {
 }
 
 func (tx *TreasuryTransaction) Serialize() ([]byte, error) {
-	return proto.Marshal(tx)
+	return types.CanonicalMarshal(tx)
 }
 
 func (tx *TreasuryTransaction) Deserialize(data []byte) error {
@@ -596,7 +596,7 @@ func (tx *TreasuryTransaction) SigHash() ([]byte, error) {
 	cpy := proto.Clone(tx)
 	cpy.(*TreasuryTransaction).Proof = nil
 
-	b, err := proto.Marshal(cpy)
+	b, err := types.CanonicalMarshal(cpy)
 	if err != nil {
 		return nil, err
 	}
@@ -664,7 +664,7 @@ type mintTxJSON struct {
 }
 
 func (tx *MintTransaction) Serialize() ([]byte, error) {
-	return proto.Marshal(tx)
+	return types.CanonicalMarshal(tx)
 }
 
 func (tx *MintTransaction) Deserialize(data []byte) error {
@@ -691,7 +691,7 @@ func (tx *MintTransaction) SigHash() ([]byte, error) {
 	cpy.(*MintTransaction).Signature = nil
 	cpy.(*MintTransaction).Proof = nil
 
-	b, err := proto.Marshal(cpy)
+	b, err := types.CanonicalMarshal(cpy)
 	if err != nil {
 		return nil, err
 	}
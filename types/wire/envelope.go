@@ -0,0 +1,219 @@
+// Copyright (c) 2024 Project Illium
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"errors"
+	"fmt"
+	"github.com/project-illium/ilxd/types/blocks"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"hash/crc32"
+)
+
+// MessageType identifies the message carried in a MsgEnvelope.
+//
+// The values are part of the wire protocol. New types must be
+// appended and existing ones never renumbered.
+type MessageType uint32
+
+const (
+	MsgTypeConsensusRequest MessageType = iota + 1
+	MsgTypePollRequest
+	MsgTypePollResponse
+	MsgTypeBlockResp
+	MsgTypeChainServiceRequest
+	MsgTypeBlockTxsResp
+	MsgTypeBlockTxidsResp
+	MsgTypeGetBlockIDResp
+	MsgTypeGetBestResp
+	MsgTypeGetTimeResp
+	MsgTypeBlockHeader
+	MsgTypeBlockTxs
+	MsgTypePolicyRequest
+	MsgTypeGetFeePerKBResp
+	MsgTypeGetMinStakeResp
+	MsgTypeGetBlocksizeSoftLimitResp
+	MsgTypeGetTreasuryWhitelistResp
+	MsgTypeInvoiceRequest
+	MsgTypeInvoiceResp
+	MsgTypeConsensusRecord
+	MsgTypeBlockFilterResp
+)
+
+const (
+	// maxSmallMsgSize is the size limit for requests and
+	// responses which only carry a few fixed size fields.
+	maxSmallMsgSize = 1 << 16
+
+	// maxListMsgSize is the size limit for messages carrying a
+	// list of heights, votes, or IDs.
+	maxListMsgSize = 1 << 20
+
+	// maxBlockMsgSize is the size limit for messages carrying
+	// a block or its transactions.
+	maxBlockMsgSize = 1 << 23
+)
+
+var (
+	// ErrUnknownMessageType means the envelope's message type,
+	// or the message being wrapped, is not in the registry.
+	ErrUnknownMessageType = errors.New("unknown message type")
+
+	// ErrUnexpectedMessageType means the envelope carries a
+	// different message than the one it was decoded into.
+	ErrUnexpectedMessageType = errors.New("unexpected message type")
+
+	// ErrUnsupportedVersion means the envelope's message version
+	// is older than the oldest version this node accepts.
+	ErrUnsupportedVersion = errors.New("unsupported message version")
+
+	// ErrMessageTooLarge means the payload exceeds the size
+	// limit for its message type.
+	ErrMessageTooLarge = errors.New("message exceeds the size limit for its type")
+
+	// ErrBadChecksum means the payload does not match the checksum.
+	ErrBadChecksum = errors.New("message checksum mismatch")
+)
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// messageSpec is a registered message type.
+type messageSpec struct {
+	typ        MessageType
+	version    uint32
+	minVersion uint32
+	maxSize    int
+	newMsg     func() proto.Message
+}
+
+var (
+	registryByType = make(map[MessageType]*messageSpec)
+	registryByName = make(map[protoreflect.FullName]*messageSpec)
+)
+
+func init() {
+	// This file's init runs before the generated one so the
+	// descriptors need to be initialized first.
+	file_message_proto_init()
+
+	RegisterMessage(MsgTypeConsensusRequest, 1, 1, maxListMsgSize, func() proto.Message { return new(MsgConsensusRequest) })
+	RegisterMessage(MsgTypePollRequest, 1, 1, maxListMsgSize, func() proto.Message { return new(MsgPollRequest) })
+	RegisterMessage(MsgTypePollResponse, 1, 1, maxListMsgSize, func() proto.Message { return new(MsgPollResponse) })
+	RegisterMessage(MsgTypeBlockResp, 1, 1, maxBlockMsgSize, func() proto.Message { return new(MsgBlockResp) })
+	RegisterMessage(MsgTypeChainServiceRequest, 1, 1, maxSmallMsgSize, func() proto.Message { return new(MsgChainServiceRequest) })
+	RegisterMessage(MsgTypeBlockTxsResp, 1, 1, maxBlockMsgSize, func() proto.Message { return new(MsgBlockTxsResp) })
+	RegisterMessage(MsgTypeBlockTxidsResp, 1, 1, maxBlockMsgSize, func() proto.Message { return new(MsgBlockTxidsResp) })
+	RegisterMessage(MsgTypeGetBlockIDResp, 1, 1, maxSmallMsgSize, func() proto.Message { return new(MsgGetBlockIDResp) })
+	RegisterMessage(MsgTypeGetBestResp, 1, 1, maxSmallMsgSize, func() proto.Message { return new(MsgGetBestResp) })
+	RegisterMessage(MsgTypeGetTimeResp, 1, 1, maxSmallMsgSize, func() proto.Message { return new(MsgGetTimeResp) })
+	RegisterMessage(MsgTypeBlockHeader, 1, 1, maxSmallMsgSize, func() proto.Message { return new(blocks.BlockHeader) })
+	RegisterMessage(MsgTypeBlockTxs, 1, 1, maxBlockMsgSize, func() proto.Message { return new(blocks.BlockTxs) })
+	RegisterMessage(MsgTypePolicyRequest, 1, 1, maxSmallMsgSize, func() proto.Message { return new(MsgPolicyRequest) })
+	RegisterMessage(MsgTypeGetFeePerKBResp, 1, 1, maxSmallMsgSize, func() proto.Message { return new(MsgGetFeePerKBResp) })
+	RegisterMessage(MsgTypeGetMinStakeResp, 1, 1, maxSmallMsgSize, func() proto.Message { return new(MsgGetMinStakeResp) })
+	RegisterMessage(MsgTypeGetBlocksizeSoftLimitResp, 1, 1, maxSmallMsgSize, func() proto.Message { return new(MsgGetBlocksizeSoftLimitResp) })
+	RegisterMessage(MsgTypeGetTreasuryWhitelistResp, 1, 1, maxListMsgSize, func() proto.Message { return new(MsgGetTreasuryWhitelistResp) })
+	RegisterMessage(MsgTypeInvoiceRequest, 1, 1, maxSmallMsgSize, func() proto.Message { return new(MsgInvoiceRequest) })
+	RegisterMessage(MsgTypeInvoiceResp, 1, 1, maxSmallMsgSize, func() proto.Message { return new(MsgInvoiceResp) })
+	RegisterMessage(MsgTypeConsensusRecord, 1, 1, maxListMsgSize, func() proto.Message { return new(ConsensusRecord) })
+	RegisterMessage(MsgTypeBlockFilterResp, 1, 1, maxListMsgSize, func() proto.Message { return new(MsgBlockFilterResp) })
+}
+
+// RegisterMessage adds a message to the registry so that it can be sent
+// in a MsgEnvelope. The version is the version of the message this node
+// sends and minVersion is the oldest version it will accept. Newer versions
+// are accepted as protobuf ignores fields it doesn't know about.
+//
+// It must only be called from an init function. It panics if either the
+// type or the message is already registered.
+func RegisterMessage(typ MessageType, version, minVersion uint32, maxSize int, newMsg func() proto.Message) {
+	name := newMsg().ProtoReflect().Descriptor().FullName()
+	if _, ok := registryByType[typ]; ok {
+		panic(fmt.Sprintf("wire: message type %d registered twice", typ))
+	}
+	if _, ok := registryByName[name]; ok {
+		panic(fmt.Sprintf("wire: message %s registered twice", name))
+	}
+	spec := &messageSpec{
+		typ:        typ,
+		version:    version,
+		minVersion: minVersion,
+		maxSize:    maxSize,
+		newMsg:     newMsg,
+	}
+	registryByType[typ] = spec
+	registryByName[name] = spec
+}
+
+// NewEnvelope serializes the message and wraps it in an envelope.
+func NewEnvelope(msg proto.Message) (*MsgEnvelope, error) {
+	spec, ok := registryByName[msg.ProtoReflect().Descriptor().FullName()]
+	if !ok {
+		return nil, ErrUnknownMessageType
+	}
+	payload, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	if len(payload) > spec.maxSize {
+		return nil, ErrMessageTooLarge
+	}
+	return &MsgEnvelope{
+		Type:     uint32(spec.typ),
+		Version:  spec.version,
+		Checksum: crc32.Checksum(payload, castagnoli),
+		Payload:  payload,
+	}, nil
+}
+
+// OpenEnvelope validates the envelope and returns the message it carries.
+// This can be used to dispatch on the message type when a stream may carry
+// more than one type of message.
+func OpenEnvelope(env *MsgEnvelope) (proto.Message, error) {
+	spec, err := validateEnvelope(env)
+	if err != nil {
+		return nil, err
+	}
+	msg := spec.newMsg()
+	if err := proto.Unmarshal(env.Payload, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// UnmarshalEnvelope decodes a serialized envelope into the message. An
+// error is returned if the envelope carries a different type of message.
+func UnmarshalEnvelope(b []byte, msg proto.Message) error {
+	env := new(MsgEnvelope)
+	if err := proto.Unmarshal(b, env); err != nil {
+		return err
+	}
+	spec, err := validateEnvelope(env)
+	if err != nil {
+		return err
+	}
+	if registryByName[msg.ProtoReflect().Descriptor().FullName()] != spec {
+		return ErrUnexpectedMessageType
+	}
+	return proto.Unmarshal(env.Payload, msg)
+}
+
+func validateEnvelope(env *MsgEnvelope) (*messageSpec, error) {
+	spec, ok := registryByType[MessageType(env.Type)]
+	if !ok {
+		return nil, ErrUnknownMessageType
+	}
+	if env.Version < spec.minVersion {
+		return nil, ErrUnsupportedVersion
+	}
+	if len(env.Payload) > spec.maxSize {
+		return nil, ErrMessageTooLarge
+	}
+	if crc32.Checksum(env.Payload, castagnoli) != env.Checksum {
+		return nil, ErrBadChecksum
+	}
+	return spec, nil
+}
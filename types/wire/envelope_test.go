@@ -0,0 +1,72 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"testing"
+)
+
+func TestEnvelope(t *testing.T) {
+	msg := &MsgPollRequest{Request_ID: 5, Heights: []uint32{1, 2, 3}}
+
+	marshal := func(env *MsgEnvelope) []byte {
+		b, err := proto.Marshal(env)
+		require.NoError(t, err)
+		return b
+	}
+
+	env, err := NewEnvelope(msg)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(MsgTypePollRequest), env.Type)
+	assert.Equal(t, uint32(1), env.Version)
+
+	decoded := new(MsgPollRequest)
+	require.NoError(t, UnmarshalEnvelope(marshal(env), decoded))
+	assert.True(t, proto.Equal(msg, decoded))
+
+	opened, err := OpenEnvelope(env)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(msg, opened))
+
+	// Wrong message type
+	assert.ErrorIs(t, UnmarshalEnvelope(marshal(env), new(MsgPollResponse)), ErrUnexpectedMessageType)
+
+	// Corrupted payload
+	bad := proto.Clone(env).(*MsgEnvelope)
+	bad.Payload[len(bad.Payload)-1] ^= 0xff
+	assert.ErrorIs(t, UnmarshalEnvelope(marshal(bad), decoded), ErrBadChecksum)
+
+	// Unknown type
+	bad = proto.Clone(env).(*MsgEnvelope)
+	bad.Type = 0xffff
+	_, err = OpenEnvelope(bad)
+	assert.ErrorIs(t, err, ErrUnknownMessageType)
+
+	// Old version
+	bad = proto.Clone(env).(*MsgEnvelope)
+	bad.Version = 0
+	_, err = OpenEnvelope(bad)
+	assert.ErrorIs(t, err, ErrUnsupportedVersion)
+
+	// Newer versions are accepted.
+	newer := proto.Clone(env).(*MsgEnvelope)
+	newer.Version = 2
+	_, err = OpenEnvelope(newer)
+	assert.NoError(t, err)
+
+	// Too large
+	_, err = NewEnvelope(&MsgGetTimeResp{})
+	assert.NoError(t, err)
+	_, err = NewEnvelope(&MsgInvoiceRequest{Memo: string(bytes.Repeat([]byte{'a'}, maxSmallMsgSize))})
+	assert.ErrorIs(t, err, ErrMessageTooLarge)
+
+	// Unregistered message
+	_, err = NewEnvelope(&GetTimeReq{})
+	assert.ErrorIs(t, err, ErrUnknownMessageType)
+}
@@ -29,10 +29,11 @@ const (
 type ErrorResponse int32
 
 const (
-	ErrorResponse_None       ErrorResponse = 0
-	ErrorResponse_NotFound   ErrorResponse = 1
-	ErrorResponse_BadRequest ErrorResponse = 2
-	ErrorResponse_NotCurrent ErrorResponse = 3
+	ErrorResponse_None        ErrorResponse = 0
+	ErrorResponse_NotFound    ErrorResponse = 1
+	ErrorResponse_BadRequest  ErrorResponse = 2
+	ErrorResponse_NotCurrent  ErrorResponse = 3
+	ErrorResponse_Unavailable ErrorResponse = 4
 )
 
 // Enum value maps for ErrorResponse.
@@ -42,12 +43,14 @@ var (
 		1: "NotFound",
 		2: "BadRequest",
 		3: "NotCurrent",
+		4: "Unavailable",
 	}
 	ErrorResponse_value = map[string]int32{
-		"None":       0,
-		"NotFound":   1,
-		"BadRequest": 2,
-		"NotCurrent": 3,
+		"None":        0,
+		"NotFound":    1,
+		"BadRequest":  2,
+		"NotCurrent":  3,
+		"Unavailable": 4,
 	}
 )
 
@@ -78,6 +81,80 @@ func (ErrorResponse) EnumDescriptor() ([]byte, []int) {
 	return file_message_proto_rawDescGZIP(), []int{0}
 }
 
+// MsgEnvelope wraps every message sent over the node's stream
+// protocols. The type and version identify the message carried in
+// the payload and the checksum is the CRC-32C of the payload.
+type MsgEnvelope struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type     uint32 `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`
+	Version  uint32 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Checksum uint32 `protobuf:"fixed32,3,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	Payload  []byte `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
+}
+
+func (x *MsgEnvelope) Reset() {
+	*x = MsgEnvelope{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_message_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MsgEnvelope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MsgEnvelope) ProtoMessage() {}
+
+func (x *MsgEnvelope) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MsgEnvelope.ProtoReflect.Descriptor instead.
+func (*MsgEnvelope) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *MsgEnvelope) GetType() uint32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *MsgEnvelope) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *MsgEnvelope) GetChecksum() uint32 {
+	if x != nil {
+		return x.Checksum
+	}
+	return 0
+}
+
+func (x *MsgEnvelope) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
 type MsgConsensusRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -93,7 +170,7 @@ type MsgConsensusRequest struct {
 func (x *MsgConsensusRequest) Reset() {
 	*x = MsgConsensusRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_message_proto_msgTypes[0]
+		mi := &file_message_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -106,7 +183,7 @@ func (x *MsgConsensusRequest) String() string {
 func (*MsgConsensusRequest) ProtoMessage() {}
 
 func (x *MsgConsensusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[0]
+	mi := &file_message_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -119,7 +196,7 @@ func (x *MsgConsensusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MsgConsensusRequest.ProtoReflect.Descriptor instead.
 func (*MsgConsensusRequest) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{0}
+	return file_message_proto_rawDescGZIP(), []int{1}
 }
 
 func (m *MsgConsensusRequest) GetMsg() isMsgConsensusRequest_Msg {
@@ -171,7 +248,7 @@ type MsgPollRequest struct {
 func (x *MsgPollRequest) Reset() {
 	*x = MsgPollRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_message_proto_msgTypes[1]
+		mi := &file_message_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -184,7 +261,7 @@ func (x *MsgPollRequest) String() string {
 func (*MsgPollRequest) ProtoMessage() {}
 
 func (x *MsgPollRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[1]
+	mi := &file_message_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -197,7 +274,7 @@ func (x *MsgPollRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MsgPollRequest.ProtoReflect.Descriptor instead.
 func (*MsgPollRequest) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{1}
+	return file_message_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *MsgPollRequest) GetRequest_ID() uint32 {
@@ -226,7 +303,7 @@ type MsgPollResponse struct {
 func (x *MsgPollResponse) Reset() {
 	*x = MsgPollResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_message_proto_msgTypes[2]
+		mi := &file_message_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -239,7 +316,7 @@ func (x *MsgPollResponse) String() string {
 func (*MsgPollResponse) ProtoMessage() {}
 
 func (x *MsgPollResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[2]
+	mi := &file_message_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -252,7 +329,7 @@ func (x *MsgPollResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MsgPollResponse.ProtoReflect.Descriptor instead.
 func (*MsgPollResponse) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{2}
+	return file_message_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *MsgPollResponse) GetRequest_ID() uint32 {
@@ -269,6 +346,216 @@ func (x *MsgPollResponse) GetVotes() [][]byte {
 	return nil
 }
 
+// ConsensusRecord is an entry in a file of recorded consensus traffic.
+// The timestamp is in unix nanoseconds.
+type ConsensusRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Timestamp int64  `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Peer_ID   []byte `protobuf:"bytes,2,opt,name=peer_ID,json=peerID,proto3" json:"peer_ID,omitempty"`
+	// Types that are assignable to Event:
+	//
+	//	*ConsensusRecord_NewBlock
+	//	*ConsensusRecord_SentPoll
+	//	*ConsensusRecord_ReceivedPollResponse
+	//	*ConsensusRecord_PollExpired
+	//	*ConsensusRecord_ReceivedPoll
+	//	*ConsensusRecord_SentPollResponse
+	Event isConsensusRecord_Event `protobuf_oneof:"event"`
+}
+
+func (x *ConsensusRecord) Reset() {
+	*x = ConsensusRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_message_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConsensusRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsensusRecord) ProtoMessage() {}
+
+func (x *ConsensusRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsensusRecord.ProtoReflect.Descriptor instead.
+func (*ConsensusRecord) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ConsensusRecord) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *ConsensusRecord) GetPeer_ID() []byte {
+	if x != nil {
+		return x.Peer_ID
+	}
+	return nil
+}
+
+func (m *ConsensusRecord) GetEvent() isConsensusRecord_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (x *ConsensusRecord) GetNewBlock() *ConsensusRecordNewBlock {
+	if x, ok := x.GetEvent().(*ConsensusRecord_NewBlock); ok {
+		return x.NewBlock
+	}
+	return nil
+}
+
+func (x *ConsensusRecord) GetSentPoll() *MsgPollRequest {
+	if x, ok := x.GetEvent().(*ConsensusRecord_SentPoll); ok {
+		return x.SentPoll
+	}
+	return nil
+}
+
+func (x *ConsensusRecord) GetReceivedPollResponse() *MsgPollResponse {
+	if x, ok := x.GetEvent().(*ConsensusRecord_ReceivedPollResponse); ok {
+		return x.ReceivedPollResponse
+	}
+	return nil
+}
+
+func (x *ConsensusRecord) GetPollExpired() uint32 {
+	if x, ok := x.GetEvent().(*ConsensusRecord_PollExpired); ok {
+		return x.PollExpired
+	}
+	return 0
+}
+
+func (x *ConsensusRecord) GetReceivedPoll() *MsgPollRequest {
+	if x, ok := x.GetEvent().(*ConsensusRecord_ReceivedPoll); ok {
+		return x.ReceivedPoll
+	}
+	return nil
+}
+
+func (x *ConsensusRecord) GetSentPollResponse() *MsgPollResponse {
+	if x, ok := x.GetEvent().(*ConsensusRecord_SentPollResponse); ok {
+		return x.SentPollResponse
+	}
+	return nil
+}
+
+type isConsensusRecord_Event interface {
+	isConsensusRecord_Event()
+}
+
+type ConsensusRecord_NewBlock struct {
+	NewBlock *ConsensusRecordNewBlock `protobuf:"bytes,3,opt,name=new_block,json=newBlock,proto3,oneof"`
+}
+
+type ConsensusRecord_SentPoll struct {
+	SentPoll *MsgPollRequest `protobuf:"bytes,4,opt,name=sent_poll,json=sentPoll,proto3,oneof"`
+}
+
+type ConsensusRecord_ReceivedPollResponse struct {
+	ReceivedPollResponse *MsgPollResponse `protobuf:"bytes,5,opt,name=received_poll_response,json=receivedPollResponse,proto3,oneof"`
+}
+
+type ConsensusRecord_PollExpired struct {
+	PollExpired uint32 `protobuf:"varint,6,opt,name=poll_expired,json=pollExpired,proto3,oneof"`
+}
+
+type ConsensusRecord_ReceivedPoll struct {
+	ReceivedPoll *MsgPollRequest `protobuf:"bytes,7,opt,name=received_poll,json=receivedPoll,proto3,oneof"`
+}
+
+type ConsensusRecord_SentPollResponse struct {
+	SentPollResponse *MsgPollResponse `protobuf:"bytes,8,opt,name=sent_poll_response,json=sentPollResponse,proto3,oneof"`
+}
+
+func (*ConsensusRecord_NewBlock) isConsensusRecord_Event() {}
+
+func (*ConsensusRecord_SentPoll) isConsensusRecord_Event() {}
+
+func (*ConsensusRecord_ReceivedPollResponse) isConsensusRecord_Event() {}
+
+func (*ConsensusRecord_PollExpired) isConsensusRecord_Event() {}
+
+func (*ConsensusRecord_ReceivedPoll) isConsensusRecord_Event() {}
+
+func (*ConsensusRecord_SentPollResponse) isConsensusRecord_Event() {}
+
+type ConsensusRecordNewBlock struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Header     *blocks.BlockHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Acceptable bool                `protobuf:"varint,2,opt,name=acceptable,proto3" json:"acceptable,omitempty"`
+}
+
+func (x *ConsensusRecordNewBlock) Reset() {
+	*x = ConsensusRecordNewBlock{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_message_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConsensusRecordNewBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsensusRecordNewBlock) ProtoMessage() {}
+
+func (x *ConsensusRecordNewBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsensusRecordNewBlock.ProtoReflect.Descriptor instead.
+func (*ConsensusRecordNewBlock) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ConsensusRecordNewBlock) GetHeader() *blocks.BlockHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *ConsensusRecordNewBlock) GetAcceptable() bool {
+	if x != nil {
+		return x.Acceptable
+	}
+	return false
+}
+
 type MsgChainServiceRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -283,13 +570,15 @@ type MsgChainServiceRequest struct {
 	//	*MsgChainServiceRequest_GetHeadersStream
 	//	*MsgChainServiceRequest_GetBlockTxsStream
 	//	*MsgChainServiceRequest_GetBest
+	//	*MsgChainServiceRequest_GetTime
+	//	*MsgChainServiceRequest_GetBlockFilter
 	Msg isMsgChainServiceRequest_Msg `protobuf_oneof:"msg"`
 }
 
 func (x *MsgChainServiceRequest) Reset() {
 	*x = MsgChainServiceRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_message_proto_msgTypes[3]
+		mi := &file_message_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -302,7 +591,7 @@ func (x *MsgChainServiceRequest) String() string {
 func (*MsgChainServiceRequest) ProtoMessage() {}
 
 func (x *MsgChainServiceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[3]
+	mi := &file_message_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -315,7 +604,7 @@ func (x *MsgChainServiceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MsgChainServiceRequest.ProtoReflect.Descriptor instead.
 func (*MsgChainServiceRequest) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{3}
+	return file_message_proto_rawDescGZIP(), []int{6}
 }
 
 func (m *MsgChainServiceRequest) GetMsg() isMsgChainServiceRequest_Msg {
@@ -374,6 +663,20 @@ func (x *MsgChainServiceRequest) GetGetBest() *GetBestReq {
 	return nil
 }
 
+func (x *MsgChainServiceRequest) GetGetTime() *GetTimeReq {
+	if x, ok := x.GetMsg().(*MsgChainServiceRequest_GetTime); ok {
+		return x.GetTime
+	}
+	return nil
+}
+
+func (x *MsgChainServiceRequest) GetGetBlockFilter() *GetBlockFilterReq {
+	if x, ok := x.GetMsg().(*MsgChainServiceRequest_GetBlockFilter); ok {
+		return x.GetBlockFilter
+	}
+	return nil
+}
+
 type isMsgChainServiceRequest_Msg interface {
 	isMsgChainServiceRequest_Msg()
 }
@@ -406,6 +709,14 @@ type MsgChainServiceRequest_GetBest struct {
 	GetBest *GetBestReq `protobuf:"bytes,7,opt,name=get_best,json=getBest,proto3,oneof"`
 }
 
+type MsgChainServiceRequest_GetTime struct {
+	GetTime *GetTimeReq `protobuf:"bytes,8,opt,name=get_time,json=getTime,proto3,oneof"`
+}
+
+type MsgChainServiceRequest_GetBlockFilter struct {
+	GetBlockFilter *GetBlockFilterReq `protobuf:"bytes,9,opt,name=get_block_filter,json=getBlockFilter,proto3,oneof"`
+}
+
 func (*MsgChainServiceRequest_GetBlockTxs) isMsgChainServiceRequest_Msg() {}
 
 func (*MsgChainServiceRequest_GetBlockTxids) isMsgChainServiceRequest_Msg() {}
@@ -420,6 +731,10 @@ func (*MsgChainServiceRequest_GetBlockTxsStream) isMsgChainServiceRequest_Msg()
 
 func (*MsgChainServiceRequest_GetBest) isMsgChainServiceRequest_Msg() {}
 
+func (*MsgChainServiceRequest_GetTime) isMsgChainServiceRequest_Msg() {}
+
+func (*MsgChainServiceRequest_GetBlockFilter) isMsgChainServiceRequest_Msg() {}
+
 type GetBlockTxsReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -432,7 +747,7 @@ type GetBlockTxsReq struct {
 func (x *GetBlockTxsReq) Reset() {
 	*x = GetBlockTxsReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_message_proto_msgTypes[4]
+		mi := &file_message_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -445,7 +760,7 @@ func (x *GetBlockTxsReq) String() string {
 func (*GetBlockTxsReq) ProtoMessage() {}
 
 func (x *GetBlockTxsReq) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[4]
+	mi := &file_message_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -458,7 +773,7 @@ func (x *GetBlockTxsReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBlockTxsReq.ProtoReflect.Descriptor instead.
 func (*GetBlockTxsReq) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{4}
+	return file_message_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GetBlockTxsReq) GetBlock_ID() []byte {
@@ -487,7 +802,7 @@ type MsgBlockTxsResp struct {
 func (x *MsgBlockTxsResp) Reset() {
 	*x = MsgBlockTxsResp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_message_proto_msgTypes[5]
+		mi := &file_message_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -500,7 +815,7 @@ func (x *MsgBlockTxsResp) String() string {
 func (*MsgBlockTxsResp) ProtoMessage() {}
 
 func (x *MsgBlockTxsResp) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[5]
+	mi := &file_message_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -513,7 +828,7 @@ func (x *MsgBlockTxsResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MsgBlockTxsResp.ProtoReflect.Descriptor instead.
 func (*MsgBlockTxsResp) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{5}
+	return file_message_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *MsgBlockTxsResp) GetTransactions() []*transactions.Transaction {
@@ -541,7 +856,7 @@ type GetBlockTxidsReq struct {
 func (x *GetBlockTxidsReq) Reset() {
 	*x = GetBlockTxidsReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_message_proto_msgTypes[6]
+		mi := &file_message_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -554,7 +869,7 @@ func (x *GetBlockTxidsReq) String() string {
 func (*GetBlockTxidsReq) ProtoMessage() {}
 
 func (x *GetBlockTxidsReq) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[6]
+	mi := &file_message_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -567,7 +882,7 @@ func (x *GetBlockTxidsReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBlockTxidsReq.ProtoReflect.Descriptor instead.
 func (*GetBlockTxidsReq) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{6}
+	return file_message_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *GetBlockTxidsReq) GetBlock_ID() []byte {
@@ -589,7 +904,7 @@ type MsgBlockTxidsResp struct {
 func (x *MsgBlockTxidsResp) Reset() {
 	*x = MsgBlockTxidsResp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_message_proto_msgTypes[7]
+		mi := &file_message_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -602,7 +917,7 @@ func (x *MsgBlockTxidsResp) String() string {
 func (*MsgBlockTxidsResp) ProtoMessage() {}
 
 func (x *MsgBlockTxidsResp) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[7]
+	mi := &file_message_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -615,7 +930,7 @@ func (x *MsgBlockTxidsResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MsgBlockTxidsResp.ProtoReflect.Descriptor instead.
 func (*MsgBlockTxidsResp) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{7}
+	return file_message_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *MsgBlockTxidsResp) GetTxids() [][]byte {
@@ -643,7 +958,7 @@ type GetBlockReq struct {
 func (x *GetBlockReq) Reset() {
 	*x = GetBlockReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_message_proto_msgTypes[8]
+		mi := &file_message_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -656,7 +971,7 @@ func (x *GetBlockReq) String() string {
 func (*GetBlockReq) ProtoMessage() {}
 
 func (x *GetBlockReq) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[8]
+	mi := &file_message_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -669,7 +984,7 @@ func (x *GetBlockReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBlockReq.ProtoReflect.Descriptor instead.
 func (*GetBlockReq) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{8}
+	return file_message_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *GetBlockReq) GetBlock_ID() []byte {
@@ -691,7 +1006,7 @@ type MsgBlockResp struct {
 func (x *MsgBlockResp) Reset() {
 	*x = MsgBlockResp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_message_proto_msgTypes[9]
+		mi := &file_message_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -704,7 +1019,7 @@ func (x *MsgBlockResp) String() string {
 func (*MsgBlockResp) ProtoMessage() {}
 
 func (x *MsgBlockResp) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[9]
+	mi := &file_message_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -717,7 +1032,7 @@ func (x *MsgBlockResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MsgBlockResp.ProtoReflect.Descriptor instead.
 func (*MsgBlockResp) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{9}
+	return file_message_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *MsgBlockResp) GetBlock() *blocks.Block {
@@ -745,7 +1060,7 @@ type GetBlockIDReq struct {
 func (x *GetBlockIDReq) Reset() {
 	*x = GetBlockIDReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_message_proto_msgTypes[10]
+		mi := &file_message_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -758,7 +1073,7 @@ func (x *GetBlockIDReq) String() string {
 func (*GetBlockIDReq) ProtoMessage() {}
 
 func (x *GetBlockIDReq) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[10]
+	mi := &file_message_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -771,7 +1086,7 @@ func (x *GetBlockIDReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBlockIDReq.ProtoReflect.Descriptor instead.
 func (*GetBlockIDReq) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{10}
+	return file_message_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *GetBlockIDReq) GetHeight() uint32 {
@@ -793,7 +1108,7 @@ type MsgGetBlockIDResp struct {
 func (x *MsgGetBlockIDResp) Reset() {
 	*x = MsgGetBlockIDResp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_message_proto_msgTypes[11]
+		mi := &file_message_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -806,7 +1121,7 @@ func (x *MsgGetBlockIDResp) String() string {
 func (*MsgGetBlockIDResp) ProtoMessage() {}
 
 func (x *MsgGetBlockIDResp) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[11]
+	mi := &file_message_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -819,7 +1134,7 @@ func (x *MsgGetBlockIDResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MsgGetBlockIDResp.ProtoReflect.Descriptor instead.
 func (*MsgGetBlockIDResp) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{11}
+	return file_message_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *MsgGetBlockIDResp) GetBlock_ID() []byte {
@@ -847,7 +1162,7 @@ type GetHeadersStreamReq struct {
 func (x *GetHeadersStreamReq) Reset() {
 	*x = GetHeadersStreamReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_message_proto_msgTypes[12]
+		mi := &file_message_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -860,7 +1175,7 @@ func (x *GetHeadersStreamReq) String() string {
 func (*GetHeadersStreamReq) ProtoMessage() {}
 
 func (x *GetHeadersStreamReq) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[12]
+	mi := &file_message_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -873,7 +1188,7 @@ func (x *GetHeadersStreamReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetHeadersStreamReq.ProtoReflect.Descriptor instead.
 func (*GetHeadersStreamReq) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{12}
+	return file_message_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *GetHeadersStreamReq) GetStartHeight() uint32 {
@@ -895,7 +1210,7 @@ type GetBlockTxsStreamReq struct {
 func (x *GetBlockTxsStreamReq) Reset() {
 	*x = GetBlockTxsStreamReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_message_proto_msgTypes[13]
+		mi := &file_message_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -908,7 +1223,7 @@ func (x *GetBlockTxsStreamReq) String() string {
 func (*GetBlockTxsStreamReq) ProtoMessage() {}
 
 func (x *GetBlockTxsStreamReq) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[13]
+	mi := &file_message_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -921,7 +1236,7 @@ func (x *GetBlockTxsStreamReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBlockTxsStreamReq.ProtoReflect.Descriptor instead.
 func (*GetBlockTxsStreamReq) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{13}
+	return file_message_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *GetBlockTxsStreamReq) GetStartHeight() uint32 {
@@ -947,7 +1262,7 @@ type GetBestReq struct {
 func (x *GetBestReq) Reset() {
 	*x = GetBestReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_message_proto_msgTypes[14]
+		mi := &file_message_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -960,7 +1275,7 @@ func (x *GetBestReq) String() string {
 func (*GetBestReq) ProtoMessage() {}
 
 func (x *GetBestReq) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[14]
+	mi := &file_message_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -973,7 +1288,7 @@ func (x *GetBestReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBestReq.ProtoReflect.Descriptor instead.
 func (*GetBestReq) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{14}
+	return file_message_proto_rawDescGZIP(), []int{17}
 }
 
 type MsgGetBestResp struct {
@@ -989,7 +1304,7 @@ type MsgGetBestResp struct {
 func (x *MsgGetBestResp) Reset() {
 	*x = MsgGetBestResp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_message_proto_msgTypes[15]
+		mi := &file_message_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1002,7 +1317,7 @@ func (x *MsgGetBestResp) String() string {
 func (*MsgGetBestResp) ProtoMessage() {}
 
 func (x *MsgGetBestResp) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[15]
+	mi := &file_message_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1015,7 +1330,7 @@ func (x *MsgGetBestResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MsgGetBestResp.ProtoReflect.Descriptor instead.
 func (*MsgGetBestResp) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{15}
+	return file_message_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *MsgGetBestResp) GetBlock_ID() []byte {
@@ -1039,6 +1354,201 @@ func (x *MsgGetBestResp) GetError() ErrorResponse {
 	return ErrorResponse_None
 }
 
+type GetTimeReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetTimeReq) Reset() {
+	*x = GetTimeReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_message_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTimeReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTimeReq) ProtoMessage() {}
+
+func (x *GetTimeReq) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTimeReq.ProtoReflect.Descriptor instead.
+func (*GetTimeReq) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{19}
+}
+
+type MsgGetTimeResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UnixMillis int64 `protobuf:"varint,1,opt,name=unix_millis,json=unixMillis,proto3" json:"unix_millis,omitempty"`
+}
+
+func (x *MsgGetTimeResp) Reset() {
+	*x = MsgGetTimeResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_message_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MsgGetTimeResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MsgGetTimeResp) ProtoMessage() {}
+
+func (x *MsgGetTimeResp) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MsgGetTimeResp.ProtoReflect.Descriptor instead.
+func (*MsgGetTimeResp) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *MsgGetTimeResp) GetUnixMillis() int64 {
+	if x != nil {
+		return x.UnixMillis
+	}
+	return 0
+}
+
+type GetBlockFilterReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Block_ID []byte `protobuf:"bytes,1,opt,name=block_ID,json=blockID,proto3" json:"block_ID,omitempty"`
+}
+
+func (x *GetBlockFilterReq) Reset() {
+	*x = GetBlockFilterReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_message_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBlockFilterReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBlockFilterReq) ProtoMessage() {}
+
+func (x *GetBlockFilterReq) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBlockFilterReq.ProtoReflect.Descriptor instead.
+func (*GetBlockFilterReq) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetBlockFilterReq) GetBlock_ID() []byte {
+	if x != nil {
+		return x.Block_ID
+	}
+	return nil
+}
+
+type MsgBlockFilterResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Filter       []byte        `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	FilterHeader []byte        `protobuf:"bytes,2,opt,name=filter_header,json=filterHeader,proto3" json:"filter_header,omitempty"`
+	Error        ErrorResponse `protobuf:"varint,3,opt,name=error,proto3,enum=ErrorResponse" json:"error,omitempty"`
+}
+
+func (x *MsgBlockFilterResp) Reset() {
+	*x = MsgBlockFilterResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_message_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MsgBlockFilterResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MsgBlockFilterResp) ProtoMessage() {}
+
+func (x *MsgBlockFilterResp) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MsgBlockFilterResp.ProtoReflect.Descriptor instead.
+func (*MsgBlockFilterResp) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *MsgBlockFilterResp) GetFilter() []byte {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *MsgBlockFilterResp) GetFilterHeader() []byte {
+	if x != nil {
+		return x.FilterHeader
+	}
+	return nil
+}
+
+func (x *MsgBlockFilterResp) GetError() ErrorResponse {
+	if x != nil {
+		return x.Error
+	}
+	return ErrorResponse_None
+}
+
 type MsgPolicyRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1056,7 +1566,7 @@ type MsgPolicyRequest struct {
 func (x *MsgPolicyRequest) Reset() {
 	*x = MsgPolicyRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_message_proto_msgTypes[16]
+		mi := &file_message_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1069,7 +1579,7 @@ func (x *MsgPolicyRequest) String() string {
 func (*MsgPolicyRequest) ProtoMessage() {}
 
 func (x *MsgPolicyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[16]
+	mi := &file_message_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1082,7 +1592,7 @@ func (x *MsgPolicyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MsgPolicyRequest.ProtoReflect.Descriptor instead.
 func (*MsgPolicyRequest) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{16}
+	return file_message_proto_rawDescGZIP(), []int{23}
 }
 
 func (m *MsgPolicyRequest) GetMsg() isMsgPolicyRequest_Msg {
@@ -1157,7 +1667,7 @@ type GetFeePerKB struct {
 func (x *GetFeePerKB) Reset() {
 	*x = GetFeePerKB{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_message_proto_msgTypes[17]
+		mi := &file_message_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1170,7 +1680,7 @@ func (x *GetFeePerKB) String() string {
 func (*GetFeePerKB) ProtoMessage() {}
 
 func (x *GetFeePerKB) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[17]
+	mi := &file_message_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1183,7 +1693,7 @@ func (x *GetFeePerKB) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetFeePerKB.ProtoReflect.Descriptor instead.
 func (*GetFeePerKB) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{17}
+	return file_message_proto_rawDescGZIP(), []int{24}
 }
 
 type MsgGetFeePerKBResp struct {
@@ -1197,7 +1707,7 @@ type MsgGetFeePerKBResp struct {
 func (x *MsgGetFeePerKBResp) Reset() {
 	*x = MsgGetFeePerKBResp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_message_proto_msgTypes[18]
+		mi := &file_message_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1210,7 +1720,7 @@ func (x *MsgGetFeePerKBResp) String() string {
 func (*MsgGetFeePerKBResp) ProtoMessage() {}
 
 func (x *MsgGetFeePerKBResp) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[18]
+	mi := &file_message_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1223,7 +1733,7 @@ func (x *MsgGetFeePerKBResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MsgGetFeePerKBResp.ProtoReflect.Descriptor instead.
 func (*MsgGetFeePerKBResp) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{18}
+	return file_message_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *MsgGetFeePerKBResp) GetFeePerKb() uint64 {
@@ -1242,7 +1752,7 @@ type GetMinStake struct {
 func (x *GetMinStake) Reset() {
 	*x = GetMinStake{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_message_proto_msgTypes[19]
+		mi := &file_message_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1255,7 +1765,7 @@ func (x *GetMinStake) String() string {
 func (*GetMinStake) ProtoMessage() {}
 
 func (x *GetMinStake) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[19]
+	mi := &file_message_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1268,7 +1778,7 @@ func (x *GetMinStake) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetMinStake.ProtoReflect.Descriptor instead.
 func (*GetMinStake) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{19}
+	return file_message_proto_rawDescGZIP(), []int{26}
 }
 
 type MsgGetMinStakeResp struct {
@@ -1282,7 +1792,7 @@ type MsgGetMinStakeResp struct {
 func (x *MsgGetMinStakeResp) Reset() {
 	*x = MsgGetMinStakeResp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_message_proto_msgTypes[20]
+		mi := &file_message_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1295,7 +1805,7 @@ func (x *MsgGetMinStakeResp) String() string {
 func (*MsgGetMinStakeResp) ProtoMessage() {}
 
 func (x *MsgGetMinStakeResp) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[20]
+	mi := &file_message_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1308,7 +1818,7 @@ func (x *MsgGetMinStakeResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MsgGetMinStakeResp.ProtoReflect.Descriptor instead.
 func (*MsgGetMinStakeResp) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{20}
+	return file_message_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *MsgGetMinStakeResp) GetMinStake() uint64 {
@@ -1327,7 +1837,7 @@ type GetBlocksizeSoftLimit struct {
 func (x *GetBlocksizeSoftLimit) Reset() {
 	*x = GetBlocksizeSoftLimit{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_message_proto_msgTypes[21]
+		mi := &file_message_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1340,7 +1850,7 @@ func (x *GetBlocksizeSoftLimit) String() string {
 func (*GetBlocksizeSoftLimit) ProtoMessage() {}
 
 func (x *GetBlocksizeSoftLimit) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[21]
+	mi := &file_message_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1353,7 +1863,7 @@ func (x *GetBlocksizeSoftLimit) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBlocksizeSoftLimit.ProtoReflect.Descriptor instead.
 func (*GetBlocksizeSoftLimit) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{21}
+	return file_message_proto_rawDescGZIP(), []int{28}
 }
 
 type MsgGetBlocksizeSoftLimitResp struct {
@@ -1367,7 +1877,7 @@ type MsgGetBlocksizeSoftLimitResp struct {
 func (x *MsgGetBlocksizeSoftLimitResp) Reset() {
 	*x = MsgGetBlocksizeSoftLimitResp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_message_proto_msgTypes[22]
+		mi := &file_message_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1380,7 +1890,7 @@ func (x *MsgGetBlocksizeSoftLimitResp) String() string {
 func (*MsgGetBlocksizeSoftLimitResp) ProtoMessage() {}
 
 func (x *MsgGetBlocksizeSoftLimitResp) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[22]
+	mi := &file_message_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1393,7 +1903,7 @@ func (x *MsgGetBlocksizeSoftLimitResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MsgGetBlocksizeSoftLimitResp.ProtoReflect.Descriptor instead.
 func (*MsgGetBlocksizeSoftLimitResp) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{22}
+	return file_message_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *MsgGetBlocksizeSoftLimitResp) GetLimit() uint32 {
@@ -1412,7 +1922,7 @@ type GetTreasuryWhitelist struct {
 func (x *GetTreasuryWhitelist) Reset() {
 	*x = GetTreasuryWhitelist{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_message_proto_msgTypes[23]
+		mi := &file_message_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1425,7 +1935,7 @@ func (x *GetTreasuryWhitelist) String() string {
 func (*GetTreasuryWhitelist) ProtoMessage() {}
 
 func (x *GetTreasuryWhitelist) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[23]
+	mi := &file_message_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1438,7 +1948,7 @@ func (x *GetTreasuryWhitelist) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTreasuryWhitelist.ProtoReflect.Descriptor instead.
 func (*GetTreasuryWhitelist) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{23}
+	return file_message_proto_rawDescGZIP(), []int{30}
 }
 
 type MsgGetTreasuryWhitelistResp struct {
@@ -1452,7 +1962,7 @@ type MsgGetTreasuryWhitelistResp struct {
 func (x *MsgGetTreasuryWhitelistResp) Reset() {
 	*x = MsgGetTreasuryWhitelistResp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_message_proto_msgTypes[24]
+		mi := &file_message_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1465,7 +1975,7 @@ func (x *MsgGetTreasuryWhitelistResp) String() string {
 func (*MsgGetTreasuryWhitelistResp) ProtoMessage() {}
 
 func (x *MsgGetTreasuryWhitelistResp) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[24]
+	mi := &file_message_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1478,7 +1988,7 @@ func (x *MsgGetTreasuryWhitelistResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MsgGetTreasuryWhitelistResp.ProtoReflect.Descriptor instead.
 func (*MsgGetTreasuryWhitelistResp) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{24}
+	return file_message_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *MsgGetTreasuryWhitelistResp) GetWhitelist() [][]byte {
@@ -1488,152 +1998,413 @@ func (x *MsgGetTreasuryWhitelistResp) GetWhitelist() [][]byte {
 	return nil
 }
 
+type MsgInvoiceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The payee's address the payer wants to pay
+	PayeeAddress string `protobuf:"bytes,1,opt,name=payee_address,json=payeeAddress,proto3" json:"payee_address,omitempty"`
+	// The amount the payer intends to pay
+	Amount uint64 `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	// An optional memo describing the payment
+	Memo string `protobuf:"bytes,3,opt,name=memo,proto3" json:"memo,omitempty"`
+	// A random nonce chosen by the payer. It is covered by the
+	// payee's signature so that old responses cannot be replayed.
+	Nonce []byte `protobuf:"bytes,4,opt,name=nonce,proto3" json:"nonce,omitempty"`
+}
+
+func (x *MsgInvoiceRequest) Reset() {
+	*x = MsgInvoiceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_message_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MsgInvoiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MsgInvoiceRequest) ProtoMessage() {}
+
+func (x *MsgInvoiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MsgInvoiceRequest.ProtoReflect.Descriptor instead.
+func (*MsgInvoiceRequest) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *MsgInvoiceRequest) GetPayeeAddress() string {
+	if x != nil {
+		return x.PayeeAddress
+	}
+	return ""
+}
+
+func (x *MsgInvoiceRequest) GetAmount() uint64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *MsgInvoiceRequest) GetMemo() string {
+	if x != nil {
+		return x.Memo
+	}
+	return ""
+}
+
+func (x *MsgInvoiceRequest) GetNonce() []byte {
+	if x != nil {
+		return x.Nonce
+	}
+	return nil
+}
+
+type MsgInvoiceResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Error ErrorResponse `protobuf:"varint,1,opt,name=error,proto3,enum=ErrorResponse" json:"error,omitempty"`
+	// A fresh one-time address to pay
+	Address string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	// The amount to pay
+	Amount uint64 `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	// The payment memo
+	Memo string `protobuf:"bytes,4,opt,name=memo,proto3" json:"memo,omitempty"`
+	// The unix timestamp after which the payee no longer
+	// expects payment to the address
+	Expires int64 `protobuf:"varint,5,opt,name=expires,proto3" json:"expires,omitempty"`
+	// The spend public key of the payee address
+	PublicKey []byte `protobuf:"bytes,6,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	// A signature over the terms from the payee address's spend key
+	Signature []byte `protobuf:"bytes,7,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *MsgInvoiceResp) Reset() {
+	*x = MsgInvoiceResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_message_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MsgInvoiceResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MsgInvoiceResp) ProtoMessage() {}
+
+func (x *MsgInvoiceResp) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MsgInvoiceResp.ProtoReflect.Descriptor instead.
+func (*MsgInvoiceResp) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *MsgInvoiceResp) GetError() ErrorResponse {
+	if x != nil {
+		return x.Error
+	}
+	return ErrorResponse_None
+}
+
+func (x *MsgInvoiceResp) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *MsgInvoiceResp) GetAmount() uint64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *MsgInvoiceResp) GetMemo() string {
+	if x != nil {
+		return x.Memo
+	}
+	return ""
+}
+
+func (x *MsgInvoiceResp) GetExpires() int64 {
+	if x != nil {
+		return x.Expires
+	}
+	return 0
+}
+
+func (x *MsgInvoiceResp) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *MsgInvoiceResp) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
 var File_message_proto protoreflect.FileDescriptor
 
 var file_message_proto_rawDesc = []byte{
 	0x0a, 0x0d, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
 	0x12, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x1a, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x22, 0x7f, 0x0a, 0x13, 0x4d, 0x73, 0x67, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x34, 0x0a, 0x0c, 0x70, 0x6f, 0x6c, 0x6c,
-	0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f,
-	0x2e, 0x4d, 0x73, 0x67, 0x50, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48,
-	0x00, 0x52, 0x0b, 0x70, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2b,
-	0x0a, 0x09, 0x67, 0x65, 0x74, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x6f, 0x22, 0x71, 0x0a, 0x0b, 0x4d, 0x73, 0x67, 0x45, 0x6e, 0x76, 0x65, 0x6c, 0x6f, 0x70, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1a,
+	0x0a, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x07,
+	0x52, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61,
+	0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x70, 0x61, 0x79,
+	0x6c, 0x6f, 0x61, 0x64, 0x22, 0x7f, 0x0a, 0x13, 0x4d, 0x73, 0x67, 0x43, 0x6f, 0x6e, 0x73, 0x65,
+	0x6e, 0x73, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x34, 0x0a, 0x0c, 0x70,
+	0x6f, 0x6c, 0x6c, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0f, 0x2e, 0x4d, 0x73, 0x67, 0x50, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x48, 0x00, 0x52, 0x0b, 0x70, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x2b, 0x0a, 0x09, 0x67, 0x65, 0x74, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52,
+	0x65, 0x71, 0x48, 0x00, 0x52, 0x08, 0x67, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x05,
+	0x0a, 0x03, 0x6d, 0x73, 0x67, 0x22, 0x49, 0x0a, 0x0e, 0x4d, 0x73, 0x67, 0x50, 0x6f, 0x6c, 0x6c,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x5f, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x49, 0x44, 0x12, 0x18, 0x0a, 0x07, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x07, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x73,
+	0x22, 0x46, 0x0a, 0x0f, 0x4d, 0x73, 0x67, 0x50, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x49,
+	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x49, 0x44, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0c, 0x52, 0x05, 0x76, 0x6f, 0x74, 0x65, 0x73, 0x22, 0xa3, 0x03, 0x0a, 0x0f, 0x43, 0x6f, 0x6e,
+	0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x1c, 0x0a, 0x09,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x65,
+	0x65, 0x72, 0x5f, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x65, 0x65,
+	0x72, 0x49, 0x44, 0x12, 0x37, 0x0a, 0x09, 0x6e, 0x65, 0x77, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73,
+	0x75, 0x73, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x4e, 0x65, 0x77, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x48, 0x00, 0x52, 0x08, 0x6e, 0x65, 0x77, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x2e, 0x0a, 0x09,
+	0x73, 0x65, 0x6e, 0x74, 0x5f, 0x70, 0x6f, 0x6c, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0f, 0x2e, 0x4d, 0x73, 0x67, 0x50, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x48, 0x00, 0x52, 0x08, 0x73, 0x65, 0x6e, 0x74, 0x50, 0x6f, 0x6c, 0x6c, 0x12, 0x48, 0x0a, 0x16,
+	0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x5f, 0x70, 0x6f, 0x6c, 0x6c, 0x5f, 0x72, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x4d,
+	0x73, 0x67, 0x50, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00,
+	0x52, 0x14, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x50, 0x6f, 0x6c, 0x6c, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x0c, 0x70, 0x6f, 0x6c, 0x6c, 0x5f, 0x65,
+	0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x0b,
+	0x70, 0x6f, 0x6c, 0x6c, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x12, 0x36, 0x0a, 0x0d, 0x72,
+	0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x5f, 0x70, 0x6f, 0x6c, 0x6c, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x4d, 0x73, 0x67, 0x50, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0c, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x50,
+	0x6f, 0x6c, 0x6c, 0x12, 0x40, 0x0a, 0x12, 0x73, 0x65, 0x6e, 0x74, 0x5f, 0x70, 0x6f, 0x6c, 0x6c,
+	0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x10, 0x2e, 0x4d, 0x73, 0x67, 0x50, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x48, 0x00, 0x52, 0x10, 0x73, 0x65, 0x6e, 0x74, 0x50, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x07, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x5f,
+	0x0a, 0x17, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x52, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x4e, 0x65, 0x77, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x24, 0x0a, 0x06, 0x68, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12,
+	0x1e, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0a, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x22,
+	0x98, 0x04, 0x0a, 0x16, 0x4d, 0x73, 0x67, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x35, 0x0a, 0x0d, 0x67, 0x65,
+	0x74, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x74, 0x78, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0f, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x78, 0x73, 0x52,
+	0x65, 0x71, 0x48, 0x00, 0x52, 0x0b, 0x67, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x78,
+	0x73, 0x12, 0x3b, 0x0a, 0x0f, 0x67, 0x65, 0x74, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x74,
+	0x78, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x47, 0x65, 0x74,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x78, 0x69, 0x64, 0x73, 0x52, 0x65, 0x71, 0x48, 0x00, 0x52,
+	0x0d, 0x67, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x78, 0x69, 0x64, 0x73, 0x12, 0x2b,
+	0x0a, 0x09, 0x67, 0x65, 0x74, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28,
 	0x0b, 0x32, 0x0c, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x48,
-	0x00, 0x52, 0x08, 0x67, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x05, 0x0a, 0x03, 0x6d,
-	0x73, 0x67, 0x22, 0x49, 0x0a, 0x0e, 0x4d, 0x73, 0x67, 0x50, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f,
-	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x49, 0x44, 0x12, 0x18, 0x0a, 0x07, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x73, 0x18, 0x02,
-	0x20, 0x03, 0x28, 0x0d, 0x52, 0x07, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x73, 0x22, 0x46, 0x0a,
-	0x0f, 0x4d, 0x73, 0x67, 0x50, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x49, 0x44, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x44, 0x12,
-	0x14, 0x0a, 0x05, 0x76, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x05,
-	0x76, 0x6f, 0x74, 0x65, 0x73, 0x22, 0xae, 0x03, 0x0a, 0x16, 0x4d, 0x73, 0x67, 0x43, 0x68, 0x61,
-	0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x35, 0x0a, 0x0d, 0x67, 0x65, 0x74, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x74, 0x78,
-	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f,
-	0x63, 0x6b, 0x54, 0x78, 0x73, 0x52, 0x65, 0x71, 0x48, 0x00, 0x52, 0x0b, 0x67, 0x65, 0x74, 0x42,
-	0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x78, 0x73, 0x12, 0x3b, 0x0a, 0x0f, 0x67, 0x65, 0x74, 0x5f, 0x62,
-	0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x74, 0x78, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x11, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x78, 0x69, 0x64, 0x73,
-	0x52, 0x65, 0x71, 0x48, 0x00, 0x52, 0x0d, 0x67, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54,
-	0x78, 0x69, 0x64, 0x73, 0x12, 0x2b, 0x0a, 0x09, 0x67, 0x65, 0x74, 0x5f, 0x62, 0x6c, 0x6f, 0x63,
-	0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f,
-	0x63, 0x6b, 0x52, 0x65, 0x71, 0x48, 0x00, 0x52, 0x08, 0x67, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63,
-	0x6b, 0x12, 0x32, 0x0a, 0x0c, 0x67, 0x65, 0x74, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x69,
-	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f,
-	0x63, 0x6b, 0x49, 0x44, 0x52, 0x65, 0x71, 0x48, 0x00, 0x52, 0x0a, 0x67, 0x65, 0x74, 0x42, 0x6c,
-	0x6f, 0x63, 0x6b, 0x49, 0x64, 0x12, 0x44, 0x0a, 0x12, 0x67, 0x65, 0x74, 0x5f, 0x68, 0x65, 0x61,
-	0x64, 0x65, 0x72, 0x73, 0x5f, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x14, 0x2e, 0x47, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x53, 0x74,
-	0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x48, 0x00, 0x52, 0x10, 0x67, 0x65, 0x74, 0x48, 0x65,
-	0x61, 0x64, 0x65, 0x72, 0x73, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x48, 0x0a, 0x14, 0x67,
-	0x65, 0x74, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x74, 0x78, 0x73, 0x5f, 0x73, 0x74, 0x72,
-	0x65, 0x61, 0x6d, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x47, 0x65, 0x74, 0x42,
-	0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x78, 0x73, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71,
-	0x48, 0x00, 0x52, 0x11, 0x67, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x78, 0x73, 0x53,
-	0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x28, 0x0a, 0x08, 0x67, 0x65, 0x74, 0x5f, 0x62, 0x65, 0x73,
-	0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x65, 0x73,
-	0x74, 0x52, 0x65, 0x71, 0x48, 0x00, 0x52, 0x07, 0x67, 0x65, 0x74, 0x42, 0x65, 0x73, 0x74, 0x42,
-	0x05, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x22, 0x4a, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f,
-	0x63, 0x6b, 0x54, 0x78, 0x73, 0x52, 0x65, 0x71, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x6c, 0x6f, 0x63,
-	0x6b, 0x5f, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63,
-	0x6b, 0x49, 0x44, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x78, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x65,
-	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x09, 0x74, 0x78, 0x49, 0x6e, 0x64, 0x65, 0x78,
-	0x65, 0x73, 0x22, 0x69, 0x0a, 0x0f, 0x4d, 0x73, 0x67, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x78,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x30, 0x0a, 0x0c, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x54, 0x72,
-	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x74, 0x72, 0x61, 0x6e, 0x73,
-	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x24, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x2d, 0x0a,
-	0x10, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x78, 0x69, 0x64, 0x73, 0x52, 0x65,
-	0x71, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x49, 0x44, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x22, 0x4f, 0x0a, 0x11,
-	0x4d, 0x73, 0x67, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x78, 0x69, 0x64, 0x73, 0x52, 0x65, 0x73,
-	0x70, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x78, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c,
-	0x52, 0x05, 0x74, 0x78, 0x69, 0x64, 0x73, 0x12, 0x24, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x28, 0x0a,
-	0x0b, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x12, 0x19, 0x0a, 0x08,
-	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07,
-	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x22, 0x52, 0x0a, 0x0c, 0x4d, 0x73, 0x67, 0x42, 0x6c,
-	0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x12, 0x1c, 0x0a, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x06, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x05,
-	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x24, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x27, 0x0a, 0x0d, 0x47,
-	0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06,
-	0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x68, 0x65,
-	0x69, 0x67, 0x68, 0x74, 0x22, 0x54, 0x0a, 0x11, 0x4d, 0x73, 0x67, 0x47, 0x65, 0x74, 0x42, 0x6c,
-	0x6f, 0x63, 0x6b, 0x49, 0x44, 0x52, 0x65, 0x73, 0x70, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x6c, 0x6f,
-	0x63, 0x6b, 0x5f, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x62, 0x6c, 0x6f,
-	0x63, 0x6b, 0x49, 0x44, 0x12, 0x24, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x38, 0x0a, 0x13, 0x47, 0x65,
+	0x00, 0x52, 0x08, 0x67, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x32, 0x0a, 0x0c, 0x67,
+	0x65, 0x74, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0e, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x52, 0x65,
+	0x71, 0x48, 0x00, 0x52, 0x0a, 0x67, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x12,
+	0x44, 0x0a, 0x12, 0x67, 0x65, 0x74, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x5f, 0x73,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x47, 0x65,
 	0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65,
-	0x71, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68,
-	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x72, 0x74, 0x48, 0x65,
-	0x69, 0x67, 0x68, 0x74, 0x22, 0x56, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
-	0x54, 0x78, 0x73, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x12, 0x21, 0x0a, 0x0c,
-	0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x72, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12,
-	0x1b, 0x0a, 0x09, 0x6e, 0x6f, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x08, 0x6e, 0x6f, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x22, 0x0c, 0x0a, 0x0a,
-	0x47, 0x65, 0x74, 0x42, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71, 0x22, 0x69, 0x0a, 0x0e, 0x4d, 0x73,
-	0x67, 0x47, 0x65, 0x74, 0x42, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x12, 0x19, 0x0a, 0x08,
+	0x71, 0x48, 0x00, 0x52, 0x10, 0x67, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x48, 0x0a, 0x14, 0x67, 0x65, 0x74, 0x5f, 0x62, 0x6c, 0x6f,
+	0x63, 0x6b, 0x5f, 0x74, 0x78, 0x73, 0x5f, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x78,
+	0x73, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x48, 0x00, 0x52, 0x11, 0x67, 0x65,
+	0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x78, 0x73, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12,
+	0x28, 0x0a, 0x08, 0x67, 0x65, 0x74, 0x5f, 0x62, 0x65, 0x73, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0b, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71, 0x48, 0x00,
+	0x52, 0x07, 0x67, 0x65, 0x74, 0x42, 0x65, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x08, 0x67, 0x65, 0x74,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x47, 0x65,
+	0x74, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x48, 0x00, 0x52, 0x07, 0x67, 0x65, 0x74, 0x54,
+	0x69, 0x6d, 0x65, 0x12, 0x3e, 0x0a, 0x10, 0x67, 0x65, 0x74, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x65,
+	0x71, 0x48, 0x00, 0x52, 0x0e, 0x67, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x46, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x42, 0x05, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x22, 0x4a, 0x0a, 0x0e, 0x47, 0x65,
+	0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x78, 0x73, 0x52, 0x65, 0x71, 0x12, 0x19, 0x0a, 0x08,
 	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07,
-	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68,
-	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12,
-	0x24, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e,
-	0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x05,
-	0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xa4, 0x02, 0x0a, 0x10, 0x4d, 0x73, 0x67, 0x50, 0x6f, 0x6c,
-	0x69, 0x63, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x0e, 0x67, 0x65,
-	0x74, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x6b, 0x62, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x65, 0x65, 0x50, 0x65, 0x72, 0x4b, 0x42,
-	0x48, 0x00, 0x52, 0x0b, 0x67, 0x65, 0x74, 0x46, 0x65, 0x65, 0x50, 0x65, 0x72, 0x4b, 0x62, 0x12,
-	0x32, 0x0a, 0x0d, 0x67, 0x65, 0x74, 0x5f, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x74, 0x61, 0x6b, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x69, 0x6e, 0x53,
-	0x74, 0x61, 0x6b, 0x65, 0x48, 0x00, 0x52, 0x0b, 0x67, 0x65, 0x74, 0x4d, 0x69, 0x6e, 0x53, 0x74,
-	0x61, 0x6b, 0x65, 0x12, 0x51, 0x0a, 0x18, 0x67, 0x65, 0x74, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
-	0x73, 0x69, 0x7a, 0x65, 0x5f, 0x73, 0x6f, 0x66, 0x74, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
-	0x73, 0x69, 0x7a, 0x65, 0x53, 0x6f, 0x66, 0x74, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x48, 0x00, 0x52,
-	0x15, 0x67, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x69, 0x7a, 0x65, 0x53, 0x6f, 0x66,
-	0x74, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x4d, 0x0a, 0x16, 0x67, 0x65, 0x74, 0x5f, 0x74, 0x72,
-	0x65, 0x61, 0x73, 0x75, 0x72, 0x79, 0x5f, 0x77, 0x68, 0x69, 0x74, 0x65, 0x6c, 0x69, 0x73, 0x74,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x72, 0x65, 0x61,
-	0x73, 0x75, 0x72, 0x79, 0x57, 0x68, 0x69, 0x74, 0x65, 0x6c, 0x69, 0x73, 0x74, 0x48, 0x00, 0x52,
-	0x14, 0x67, 0x65, 0x74, 0x54, 0x72, 0x65, 0x61, 0x73, 0x75, 0x72, 0x79, 0x57, 0x68, 0x69, 0x74,
-	0x65, 0x6c, 0x69, 0x73, 0x74, 0x42, 0x05, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x22, 0x0d, 0x0a, 0x0b,
-	0x47, 0x65, 0x74, 0x46, 0x65, 0x65, 0x50, 0x65, 0x72, 0x4b, 0x42, 0x22, 0x32, 0x0a, 0x12, 0x4d,
-	0x73, 0x67, 0x47, 0x65, 0x74, 0x46, 0x65, 0x65, 0x50, 0x65, 0x72, 0x4b, 0x42, 0x52, 0x65, 0x73,
-	0x70, 0x12, 0x1c, 0x0a, 0x0a, 0x66, 0x65, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x6b, 0x62, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x66, 0x65, 0x65, 0x50, 0x65, 0x72, 0x4b, 0x62, 0x22,
-	0x0d, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x4d, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x6b, 0x65, 0x22, 0x31,
-	0x0a, 0x12, 0x4d, 0x73, 0x67, 0x47, 0x65, 0x74, 0x4d, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x6b, 0x65,
-	0x52, 0x65, 0x73, 0x70, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x74, 0x61, 0x6b,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6d, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x6b,
-	0x65, 0x22, 0x17, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x69, 0x7a,
-	0x65, 0x53, 0x6f, 0x66, 0x74, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x34, 0x0a, 0x1c, 0x4d, 0x73,
-	0x67, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x69, 0x7a, 0x65, 0x53, 0x6f, 0x66,
-	0x74, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69,
-	0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
-	0x22, 0x16, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x54, 0x72, 0x65, 0x61, 0x73, 0x75, 0x72, 0x79, 0x57,
-	0x68, 0x69, 0x74, 0x65, 0x6c, 0x69, 0x73, 0x74, 0x22, 0x3b, 0x0a, 0x1b, 0x4d, 0x73, 0x67, 0x47,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x78, 0x5f, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x09, 0x74, 0x78, 0x49,
+	0x6e, 0x64, 0x65, 0x78, 0x65, 0x73, 0x22, 0x69, 0x0a, 0x0f, 0x4d, 0x73, 0x67, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x54, 0x78, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x30, 0x0a, 0x0c, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0c, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x24, 0x0a, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x22, 0x2d, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x78, 0x69,
+	0x64, 0x73, 0x52, 0x65, 0x71, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x49,
+	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44,
+	0x22, 0x4f, 0x0a, 0x11, 0x4d, 0x73, 0x67, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x78, 0x69, 0x64,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x78, 0x69, 0x64, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0c, 0x52, 0x05, 0x74, 0x78, 0x69, 0x64, 0x73, 0x12, 0x24, 0x0a, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x22, 0x28, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71,
+	0x12, 0x19, 0x0a, 0x08, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x22, 0x52, 0x0a, 0x0c, 0x4d,
+	0x73, 0x67, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x12, 0x1c, 0x0a, 0x05, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x06, 0x2e, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x52, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x24, 0x0a, 0x05, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22,
+	0x27, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x52, 0x65, 0x71,
+	0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x54, 0x0a, 0x11, 0x4d, 0x73, 0x67, 0x47,
+	0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x52, 0x65, 0x73, 0x70, 0x12, 0x19, 0x0a,
+	0x08, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x12, 0x24, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x38,
+	0x0a, 0x13, 0x47, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x52, 0x65, 0x71, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x68,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x56, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x78, 0x73, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71,
+	0x12, 0x21, 0x0a, 0x0c, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x72, 0x74, 0x48, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x6f, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x6e, 0x6f, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x73,
+	0x22, 0x0c, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x42, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71, 0x22, 0x69,
+	0x0a, 0x0e, 0x4d, 0x73, 0x67, 0x47, 0x65, 0x74, 0x42, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x12, 0x19, 0x0a, 0x08, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x68,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x68, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x12, 0x24, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x0c, 0x0a, 0x0a, 0x47, 0x65, 0x74,
+	0x54, 0x69, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x22, 0x31, 0x0a, 0x0e, 0x4d, 0x73, 0x67, 0x47, 0x65,
+	0x74, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x12, 0x1f, 0x0a, 0x0b, 0x75, 0x6e, 0x69,
+	0x78, 0x5f, 0x6d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a,
+	0x75, 0x6e, 0x69, 0x78, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x22, 0x2e, 0x0a, 0x11, 0x47, 0x65,
+	0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x12,
+	0x19, 0x0a, 0x08, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x22, 0x77, 0x0a, 0x12, 0x4d, 0x73,
+	0x67, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70,
+	0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x0c, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x24, 0x0a,
+	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x05, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x22, 0xa4, 0x02, 0x0a, 0x10, 0x4d, 0x73, 0x67, 0x50, 0x6f, 0x6c, 0x69, 0x63,
+	0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x0e, 0x67, 0x65, 0x74, 0x5f,
+	0x66, 0x65, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x6b, 0x62, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0c, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x65, 0x65, 0x50, 0x65, 0x72, 0x4b, 0x42, 0x48, 0x00,
+	0x52, 0x0b, 0x67, 0x65, 0x74, 0x46, 0x65, 0x65, 0x50, 0x65, 0x72, 0x4b, 0x62, 0x12, 0x32, 0x0a,
+	0x0d, 0x67, 0x65, 0x74, 0x5f, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x74, 0x61, 0x6b, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x69, 0x6e, 0x53, 0x74, 0x61,
+	0x6b, 0x65, 0x48, 0x00, 0x52, 0x0b, 0x67, 0x65, 0x74, 0x4d, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x6b,
+	0x65, 0x12, 0x51, 0x0a, 0x18, 0x67, 0x65, 0x74, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x69,
+	0x7a, 0x65, 0x5f, 0x73, 0x6f, 0x66, 0x74, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x69,
+	0x7a, 0x65, 0x53, 0x6f, 0x66, 0x74, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x48, 0x00, 0x52, 0x15, 0x67,
+	0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x69, 0x7a, 0x65, 0x53, 0x6f, 0x66, 0x74, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x12, 0x4d, 0x0a, 0x16, 0x67, 0x65, 0x74, 0x5f, 0x74, 0x72, 0x65, 0x61,
+	0x73, 0x75, 0x72, 0x79, 0x5f, 0x77, 0x68, 0x69, 0x74, 0x65, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x72, 0x65, 0x61, 0x73, 0x75,
+	0x72, 0x79, 0x57, 0x68, 0x69, 0x74, 0x65, 0x6c, 0x69, 0x73, 0x74, 0x48, 0x00, 0x52, 0x14, 0x67,
 	0x65, 0x74, 0x54, 0x72, 0x65, 0x61, 0x73, 0x75, 0x72, 0x79, 0x57, 0x68, 0x69, 0x74, 0x65, 0x6c,
-	0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x12, 0x1c, 0x0a, 0x09, 0x77, 0x68, 0x69, 0x74, 0x65,
-	0x6c, 0x69, 0x73, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x09, 0x77, 0x68, 0x69, 0x74,
-	0x65, 0x6c, 0x69, 0x73, 0x74, 0x2a, 0x47, 0x0a, 0x0d, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65,
+	0x69, 0x73, 0x74, 0x42, 0x05, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x22, 0x0d, 0x0a, 0x0b, 0x47, 0x65,
+	0x74, 0x46, 0x65, 0x65, 0x50, 0x65, 0x72, 0x4b, 0x42, 0x22, 0x32, 0x0a, 0x12, 0x4d, 0x73, 0x67,
+	0x47, 0x65, 0x74, 0x46, 0x65, 0x65, 0x50, 0x65, 0x72, 0x4b, 0x42, 0x52, 0x65, 0x73, 0x70, 0x12,
+	0x1c, 0x0a, 0x0a, 0x66, 0x65, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x6b, 0x62, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x08, 0x66, 0x65, 0x65, 0x50, 0x65, 0x72, 0x4b, 0x62, 0x22, 0x0d, 0x0a,
+	0x0b, 0x47, 0x65, 0x74, 0x4d, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x6b, 0x65, 0x22, 0x31, 0x0a, 0x12,
+	0x4d, 0x73, 0x67, 0x47, 0x65, 0x74, 0x4d, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x6b, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x74, 0x61, 0x6b, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6d, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x6b, 0x65, 0x22,
+	0x17, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x69, 0x7a, 0x65, 0x53,
+	0x6f, 0x66, 0x74, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x34, 0x0a, 0x1c, 0x4d, 0x73, 0x67, 0x47,
+	0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x69, 0x7a, 0x65, 0x53, 0x6f, 0x66, 0x74, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x16,
+	0x0a, 0x14, 0x47, 0x65, 0x74, 0x54, 0x72, 0x65, 0x61, 0x73, 0x75, 0x72, 0x79, 0x57, 0x68, 0x69,
+	0x74, 0x65, 0x6c, 0x69, 0x73, 0x74, 0x22, 0x3b, 0x0a, 0x1b, 0x4d, 0x73, 0x67, 0x47, 0x65, 0x74,
+	0x54, 0x72, 0x65, 0x61, 0x73, 0x75, 0x72, 0x79, 0x57, 0x68, 0x69, 0x74, 0x65, 0x6c, 0x69, 0x73,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x12, 0x1c, 0x0a, 0x09, 0x77, 0x68, 0x69, 0x74, 0x65, 0x6c, 0x69,
+	0x73, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x09, 0x77, 0x68, 0x69, 0x74, 0x65, 0x6c,
+	0x69, 0x73, 0x74, 0x22, 0x7a, 0x0a, 0x11, 0x4d, 0x73, 0x67, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x61, 0x79, 0x65,
+	0x65, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x70, 0x61, 0x79, 0x65, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x16, 0x0a,
+	0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x61,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x65, 0x6d, 0x6f, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x65, 0x6d, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e,
+	0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x22,
+	0xd3, 0x01, 0x0a, 0x0e, 0x4d, 0x73, 0x67, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x12, 0x24, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x0e, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x65,
+	0x6d, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x65, 0x6d, 0x6f, 0x12, 0x18,
+	0x0a, 0x07, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x07, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x75, 0x62, 0x6c,
+	0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x70, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x2a, 0x58, 0x0a, 0x0d, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65,
 	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x08, 0x0a, 0x04, 0x4e, 0x6f, 0x6e, 0x65, 0x10, 0x00,
 	0x12, 0x0c, 0x0a, 0x08, 0x4e, 0x6f, 0x74, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x10, 0x01, 0x12, 0x0e,
 	0x0a, 0x0a, 0x42, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x10, 0x02, 0x12, 0x0e,
-	0x0a, 0x0a, 0x4e, 0x6f, 0x74, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x10, 0x03, 0x42, 0x09,
-	0x5a, 0x07, 0x2e, 0x2e, 0x2f, 0x77, 0x69, 0x72, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x0a, 0x0a, 0x4e, 0x6f, 0x74, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x10, 0x03, 0x12, 0x0f,
+	0x0a, 0x0b, 0x55, 0x6e, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x10, 0x04, 0x42,
+	0x09, 0x5a, 0x07, 0x2e, 0x2e, 0x2f, 0x77, 0x69, 0x72, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
 }
 
 var (
@@ -1649,63 +2420,83 @@ func file_message_proto_rawDescGZIP() []byte {
 }
 
 var file_message_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_message_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_message_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
 var file_message_proto_goTypes = []interface{}{
 	(ErrorResponse)(0),                   // 0: ErrorResponse
-	(*MsgConsensusRequest)(nil),          // 1: MsgConsensusRequest
-	(*MsgPollRequest)(nil),               // 2: MsgPollRequest
-	(*MsgPollResponse)(nil),              // 3: MsgPollResponse
-	(*MsgChainServiceRequest)(nil),       // 4: MsgChainServiceRequest
-	(*GetBlockTxsReq)(nil),               // 5: GetBlockTxsReq
-	(*MsgBlockTxsResp)(nil),              // 6: MsgBlockTxsResp
-	(*GetBlockTxidsReq)(nil),             // 7: GetBlockTxidsReq
-	(*MsgBlockTxidsResp)(nil),            // 8: MsgBlockTxidsResp
-	(*GetBlockReq)(nil),                  // 9: GetBlockReq
-	(*MsgBlockResp)(nil),                 // 10: MsgBlockResp
-	(*GetBlockIDReq)(nil),                // 11: GetBlockIDReq
-	(*MsgGetBlockIDResp)(nil),            // 12: MsgGetBlockIDResp
-	(*GetHeadersStreamReq)(nil),          // 13: GetHeadersStreamReq
-	(*GetBlockTxsStreamReq)(nil),         // 14: GetBlockTxsStreamReq
-	(*GetBestReq)(nil),                   // 15: GetBestReq
-	(*MsgGetBestResp)(nil),               // 16: MsgGetBestResp
-	(*MsgPolicyRequest)(nil),             // 17: MsgPolicyRequest
-	(*GetFeePerKB)(nil),                  // 18: GetFeePerKB
-	(*MsgGetFeePerKBResp)(nil),           // 19: MsgGetFeePerKBResp
-	(*GetMinStake)(nil),                  // 20: GetMinStake
-	(*MsgGetMinStakeResp)(nil),           // 21: MsgGetMinStakeResp
-	(*GetBlocksizeSoftLimit)(nil),        // 22: GetBlocksizeSoftLimit
-	(*MsgGetBlocksizeSoftLimitResp)(nil), // 23: MsgGetBlocksizeSoftLimitResp
-	(*GetTreasuryWhitelist)(nil),         // 24: GetTreasuryWhitelist
-	(*MsgGetTreasuryWhitelistResp)(nil),  // 25: MsgGetTreasuryWhitelistResp
-	(*transactions.Transaction)(nil),     // 26: Transaction
-	(*blocks.Block)(nil),                 // 27: Block
+	(*MsgEnvelope)(nil),                  // 1: MsgEnvelope
+	(*MsgConsensusRequest)(nil),          // 2: MsgConsensusRequest
+	(*MsgPollRequest)(nil),               // 3: MsgPollRequest
+	(*MsgPollResponse)(nil),              // 4: MsgPollResponse
+	(*ConsensusRecord)(nil),              // 5: ConsensusRecord
+	(*ConsensusRecordNewBlock)(nil),      // 6: ConsensusRecordNewBlock
+	(*MsgChainServiceRequest)(nil),       // 7: MsgChainServiceRequest
+	(*GetBlockTxsReq)(nil),               // 8: GetBlockTxsReq
+	(*MsgBlockTxsResp)(nil),              // 9: MsgBlockTxsResp
+	(*GetBlockTxidsReq)(nil),             // 10: GetBlockTxidsReq
+	(*MsgBlockTxidsResp)(nil),            // 11: MsgBlockTxidsResp
+	(*GetBlockReq)(nil),                  // 12: GetBlockReq
+	(*MsgBlockResp)(nil),                 // 13: MsgBlockResp
+	(*GetBlockIDReq)(nil),                // 14: GetBlockIDReq
+	(*MsgGetBlockIDResp)(nil),            // 15: MsgGetBlockIDResp
+	(*GetHeadersStreamReq)(nil),          // 16: GetHeadersStreamReq
+	(*GetBlockTxsStreamReq)(nil),         // 17: GetBlockTxsStreamReq
+	(*GetBestReq)(nil),                   // 18: GetBestReq
+	(*MsgGetBestResp)(nil),               // 19: MsgGetBestResp
+	(*GetTimeReq)(nil),                   // 20: GetTimeReq
+	(*MsgGetTimeResp)(nil),               // 21: MsgGetTimeResp
+	(*GetBlockFilterReq)(nil),            // 22: GetBlockFilterReq
+	(*MsgBlockFilterResp)(nil),           // 23: MsgBlockFilterResp
+	(*MsgPolicyRequest)(nil),             // 24: MsgPolicyRequest
+	(*GetFeePerKB)(nil),                  // 25: GetFeePerKB
+	(*MsgGetFeePerKBResp)(nil),           // 26: MsgGetFeePerKBResp
+	(*GetMinStake)(nil),                  // 27: GetMinStake
+	(*MsgGetMinStakeResp)(nil),           // 28: MsgGetMinStakeResp
+	(*GetBlocksizeSoftLimit)(nil),        // 29: GetBlocksizeSoftLimit
+	(*MsgGetBlocksizeSoftLimitResp)(nil), // 30: MsgGetBlocksizeSoftLimitResp
+	(*GetTreasuryWhitelist)(nil),         // 31: GetTreasuryWhitelist
+	(*MsgGetTreasuryWhitelistResp)(nil),  // 32: MsgGetTreasuryWhitelistResp
+	(*MsgInvoiceRequest)(nil),            // 33: MsgInvoiceRequest
+	(*MsgInvoiceResp)(nil),               // 34: MsgInvoiceResp
+	(*blocks.BlockHeader)(nil),           // 35: BlockHeader
+	(*transactions.Transaction)(nil),     // 36: Transaction
+	(*blocks.Block)(nil),                 // 37: Block
 }
 var file_message_proto_depIdxs = []int32{
-	2,  // 0: MsgConsensusRequest.poll_request:type_name -> MsgPollRequest
-	9,  // 1: MsgConsensusRequest.get_block:type_name -> GetBlockReq
-	5,  // 2: MsgChainServiceRequest.get_block_txs:type_name -> GetBlockTxsReq
-	7,  // 3: MsgChainServiceRequest.get_block_txids:type_name -> GetBlockTxidsReq
-	9,  // 4: MsgChainServiceRequest.get_block:type_name -> GetBlockReq
-	11, // 5: MsgChainServiceRequest.get_block_id:type_name -> GetBlockIDReq
-	13, // 6: MsgChainServiceRequest.get_headers_stream:type_name -> GetHeadersStreamReq
-	14, // 7: MsgChainServiceRequest.get_block_txs_stream:type_name -> GetBlockTxsStreamReq
-	15, // 8: MsgChainServiceRequest.get_best:type_name -> GetBestReq
-	26, // 9: MsgBlockTxsResp.transactions:type_name -> Transaction
-	0,  // 10: MsgBlockTxsResp.error:type_name -> ErrorResponse
-	0,  // 11: MsgBlockTxidsResp.error:type_name -> ErrorResponse
-	27, // 12: MsgBlockResp.block:type_name -> Block
-	0,  // 13: MsgBlockResp.error:type_name -> ErrorResponse
-	0,  // 14: MsgGetBlockIDResp.error:type_name -> ErrorResponse
-	0,  // 15: MsgGetBestResp.error:type_name -> ErrorResponse
-	18, // 16: MsgPolicyRequest.get_fee_per_kb:type_name -> GetFeePerKB
-	20, // 17: MsgPolicyRequest.get_min_stake:type_name -> GetMinStake
-	22, // 18: MsgPolicyRequest.get_blocksize_soft_limit:type_name -> GetBlocksizeSoftLimit
-	24, // 19: MsgPolicyRequest.get_treasury_whitelist:type_name -> GetTreasuryWhitelist
-	20, // [20:20] is the sub-list for method output_type
-	20, // [20:20] is the sub-list for method input_type
-	20, // [20:20] is the sub-list for extension type_name
-	20, // [20:20] is the sub-list for extension extendee
-	0,  // [0:20] is the sub-list for field type_name
+	3,  // 0: MsgConsensusRequest.poll_request:type_name -> MsgPollRequest
+	12, // 1: MsgConsensusRequest.get_block:type_name -> GetBlockReq
+	6,  // 2: ConsensusRecord.new_block:type_name -> ConsensusRecordNewBlock
+	3,  // 3: ConsensusRecord.sent_poll:type_name -> MsgPollRequest
+	4,  // 4: ConsensusRecord.received_poll_response:type_name -> MsgPollResponse
+	3,  // 5: ConsensusRecord.received_poll:type_name -> MsgPollRequest
+	4,  // 6: ConsensusRecord.sent_poll_response:type_name -> MsgPollResponse
+	35, // 7: ConsensusRecordNewBlock.header:type_name -> BlockHeader
+	8,  // 8: MsgChainServiceRequest.get_block_txs:type_name -> GetBlockTxsReq
+	10, // 9: MsgChainServiceRequest.get_block_txids:type_name -> GetBlockTxidsReq
+	12, // 10: MsgChainServiceRequest.get_block:type_name -> GetBlockReq
+	14, // 11: MsgChainServiceRequest.get_block_id:type_name -> GetBlockIDReq
+	16, // 12: MsgChainServiceRequest.get_headers_stream:type_name -> GetHeadersStreamReq
+	17, // 13: MsgChainServiceRequest.get_block_txs_stream:type_name -> GetBlockTxsStreamReq
+	18, // 14: MsgChainServiceRequest.get_best:type_name -> GetBestReq
+	20, // 15: MsgChainServiceRequest.get_time:type_name -> GetTimeReq
+	22, // 16: MsgChainServiceRequest.get_block_filter:type_name -> GetBlockFilterReq
+	36, // 17: MsgBlockTxsResp.transactions:type_name -> Transaction
+	0,  // 18: MsgBlockTxsResp.error:type_name -> ErrorResponse
+	0,  // 19: MsgBlockTxidsResp.error:type_name -> ErrorResponse
+	37, // 20: MsgBlockResp.block:type_name -> Block
+	0,  // 21: MsgBlockResp.error:type_name -> ErrorResponse
+	0,  // 22: MsgGetBlockIDResp.error:type_name -> ErrorResponse
+	0,  // 23: MsgGetBestResp.error:type_name -> ErrorResponse
+	0,  // 24: MsgBlockFilterResp.error:type_name -> ErrorResponse
+	25, // 25: MsgPolicyRequest.get_fee_per_kb:type_name -> GetFeePerKB
+	27, // 26: MsgPolicyRequest.get_min_stake:type_name -> GetMinStake
+	29, // 27: MsgPolicyRequest.get_blocksize_soft_limit:type_name -> GetBlocksizeSoftLimit
+	31, // 28: MsgPolicyRequest.get_treasury_whitelist:type_name -> GetTreasuryWhitelist
+	0,  // 29: MsgInvoiceResp.error:type_name -> ErrorResponse
+	30, // [30:30] is the sub-list for method output_type
+	30, // [30:30] is the sub-list for method input_type
+	30, // [30:30] is the sub-list for extension type_name
+	30, // [30:30] is the sub-list for extension extendee
+	0,  // [0:30] is the sub-list for field type_name
 }
 
 func init() { file_message_proto_init() }
@@ -1715,7 +2506,7 @@ func file_message_proto_init() {
 	}
 	if !protoimpl.UnsafeEnabled {
 		file_message_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MsgConsensusRequest); i {
+			switch v := v.(*MsgEnvelope); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1727,7 +2518,7 @@ func file_message_proto_init() {
 			}
 		}
 		file_message_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MsgPollRequest); i {
+			switch v := v.(*MsgConsensusRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1739,7 +2530,7 @@ func file_message_proto_init() {
 			}
 		}
 		file_message_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MsgPollResponse); i {
+			switch v := v.(*MsgPollRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1751,7 +2542,7 @@ func file_message_proto_init() {
 			}
 		}
 		file_message_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MsgChainServiceRequest); i {
+			switch v := v.(*MsgPollResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1763,7 +2554,7 @@ func file_message_proto_init() {
 			}
 		}
 		file_message_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetBlockTxsReq); i {
+			switch v := v.(*ConsensusRecord); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1775,7 +2566,7 @@ func file_message_proto_init() {
 			}
 		}
 		file_message_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MsgBlockTxsResp); i {
+			switch v := v.(*ConsensusRecordNewBlock); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1787,7 +2578,7 @@ func file_message_proto_init() {
 			}
 		}
 		file_message_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetBlockTxidsReq); i {
+			switch v := v.(*MsgChainServiceRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1799,7 +2590,7 @@ func file_message_proto_init() {
 			}
 		}
 		file_message_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MsgBlockTxidsResp); i {
+			switch v := v.(*GetBlockTxsReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1811,7 +2602,7 @@ func file_message_proto_init() {
 			}
 		}
 		file_message_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetBlockReq); i {
+			switch v := v.(*MsgBlockTxsResp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1823,7 +2614,7 @@ func file_message_proto_init() {
 			}
 		}
 		file_message_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MsgBlockResp); i {
+			switch v := v.(*GetBlockTxidsReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1835,7 +2626,7 @@ func file_message_proto_init() {
 			}
 		}
 		file_message_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetBlockIDReq); i {
+			switch v := v.(*MsgBlockTxidsResp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1847,7 +2638,7 @@ func file_message_proto_init() {
 			}
 		}
 		file_message_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MsgGetBlockIDResp); i {
+			switch v := v.(*GetBlockReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1859,7 +2650,7 @@ func file_message_proto_init() {
 			}
 		}
 		file_message_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetHeadersStreamReq); i {
+			switch v := v.(*MsgBlockResp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1871,7 +2662,7 @@ func file_message_proto_init() {
 			}
 		}
 		file_message_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetBlockTxsStreamReq); i {
+			switch v := v.(*GetBlockIDReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1883,7 +2674,7 @@ func file_message_proto_init() {
 			}
 		}
 		file_message_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetBestReq); i {
+			switch v := v.(*MsgGetBlockIDResp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1895,7 +2686,7 @@ func file_message_proto_init() {
 			}
 		}
 		file_message_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MsgGetBestResp); i {
+			switch v := v.(*GetHeadersStreamReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1907,7 +2698,7 @@ func file_message_proto_init() {
 			}
 		}
 		file_message_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MsgPolicyRequest); i {
+			switch v := v.(*GetBlockTxsStreamReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1919,7 +2710,7 @@ func file_message_proto_init() {
 			}
 		}
 		file_message_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetFeePerKB); i {
+			switch v := v.(*GetBestReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1931,7 +2722,7 @@ func file_message_proto_init() {
 			}
 		}
 		file_message_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MsgGetFeePerKBResp); i {
+			switch v := v.(*MsgGetBestResp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1943,7 +2734,7 @@ func file_message_proto_init() {
 			}
 		}
 		file_message_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetMinStake); i {
+			switch v := v.(*GetTimeReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1955,7 +2746,7 @@ func file_message_proto_init() {
 			}
 		}
 		file_message_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MsgGetMinStakeResp); i {
+			switch v := v.(*MsgGetTimeResp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1967,7 +2758,7 @@ func file_message_proto_init() {
 			}
 		}
 		file_message_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetBlocksizeSoftLimit); i {
+			switch v := v.(*GetBlockFilterReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1979,7 +2770,7 @@ func file_message_proto_init() {
 			}
 		}
 		file_message_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MsgGetBlocksizeSoftLimitResp); i {
+			switch v := v.(*MsgBlockFilterResp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1991,7 +2782,7 @@ func file_message_proto_init() {
 			}
 		}
 		file_message_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetTreasuryWhitelist); i {
+			switch v := v.(*MsgPolicyRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2003,6 +2794,90 @@ func file_message_proto_init() {
 			}
 		}
 		file_message_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetFeePerKB); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_message_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MsgGetFeePerKBResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_message_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetMinStake); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_message_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MsgGetMinStakeResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_message_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetBlocksizeSoftLimit); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_message_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MsgGetBlocksizeSoftLimitResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_message_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTreasuryWhitelist); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_message_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*MsgGetTreasuryWhitelistResp); i {
 			case 0:
 				return &v.state
@@ -2014,12 +2889,44 @@ func file_message_proto_init() {
 				return nil
 			}
 		}
+		file_message_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MsgInvoiceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_message_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MsgInvoiceResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
-	file_message_proto_msgTypes[0].OneofWrappers = []interface{}{
+	file_message_proto_msgTypes[1].OneofWrappers = []interface{}{
 		(*MsgConsensusRequest_PollRequest)(nil),
 		(*MsgConsensusRequest_GetBlock)(nil),
 	}
-	file_message_proto_msgTypes[3].OneofWrappers = []interface{}{
+	file_message_proto_msgTypes[4].OneofWrappers = []interface{}{
+		(*ConsensusRecord_NewBlock)(nil),
+		(*ConsensusRecord_SentPoll)(nil),
+		(*ConsensusRecord_ReceivedPollResponse)(nil),
+		(*ConsensusRecord_PollExpired)(nil),
+		(*ConsensusRecord_ReceivedPoll)(nil),
+		(*ConsensusRecord_SentPollResponse)(nil),
+	}
+	file_message_proto_msgTypes[6].OneofWrappers = []interface{}{
 		(*MsgChainServiceRequest_GetBlockTxs)(nil),
 		(*MsgChainServiceRequest_GetBlockTxids)(nil),
 		(*MsgChainServiceRequest_GetBlock)(nil),
@@ -2027,8 +2934,10 @@ func file_message_proto_init() {
 		(*MsgChainServiceRequest_GetHeadersStream)(nil),
 		(*MsgChainServiceRequest_GetBlockTxsStream)(nil),
 		(*MsgChainServiceRequest_GetBest)(nil),
+		(*MsgChainServiceRequest_GetTime)(nil),
+		(*MsgChainServiceRequest_GetBlockFilter)(nil),
 	}
-	file_message_proto_msgTypes[16].OneofWrappers = []interface{}{
+	file_message_proto_msgTypes[23].OneofWrappers = []interface{}{
 		(*MsgPolicyRequest_GetFeePerKb)(nil),
 		(*MsgPolicyRequest_GetMinStake)(nil),
 		(*MsgPolicyRequest_GetBlocksizeSoftLimit)(nil),
@@ -2040,7 +2949,7 @@ func file_message_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_message_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   25,
+			NumMessages:   34,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
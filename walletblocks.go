@@ -0,0 +1,260 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/project-illium/ilxd/blockchain"
+	"github.com/project-illium/ilxd/crypto"
+	"github.com/project-illium/ilxd/rpc"
+	"github.com/project-illium/ilxd/tasks"
+	"github.com/project-illium/ilxd/types"
+	"github.com/project-illium/ilxd/types/blocks"
+	"github.com/project-illium/ilxd/types/transactions"
+	"github.com/project-illium/ilxd/zk"
+	"github.com/project-illium/walletlib"
+	"google.golang.org/protobuf/proto"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// publicAddrScriptHash is the script hash walletlib puts at the front of
+// the plaintext ciphertexts of outputs sent to public addresses.
+var publicAddrScriptHash []byte
+
+func init() {
+	lockingScript := types.LockingScript{
+		ScriptCommitment: types.NewID(zk.PublicAddressScriptCommitment()),
+	}
+	scriptHash, err := lockingScript.Hash()
+	if err != nil {
+		panic(err)
+	}
+	publicAddrScriptHash = scriptHash.Bytes()
+}
+
+// walletBlockSource serves blocks to the wallet.
+//
+// The wallet trial decrypts every output with every one of its view keys
+// one block at a time while holding its lock, which makes rescanning an
+// old address take hours. Here the blocks in each batch are loaded and
+// trial decrypted by a pool of workers first. The ciphertexts of the
+// transactions which have no outputs for the wallet are dropped from the
+// copy of the block handed to the wallet, so its own scan of them fails
+// immediately.
+//
+// The source also tracks the progress of the wallet's rescans. The wallet
+// only asks for an accumulator checkpoint when it starts a rescan, which
+// is used to detect the start.
+type walletBlockSource struct {
+	chain  *blockchain.Blockchain
+	wallet *walletlib.Wallet
+
+	rescan     rpc.WalletRescanStatus
+	nextHeight uint32
+	task       *tasks.Handle
+	mtx        sync.RWMutex
+}
+
+func newWalletBlockSource(chain *blockchain.Blockchain) *walletBlockSource {
+	return &walletBlockSource{chain: chain}
+}
+
+// setWallet sets the wallet whose view keys are used to scan the blocks.
+// Until it's called blocks are returned as they are.
+func (src *walletBlockSource) setWallet(wallet *walletlib.Wallet) {
+	src.mtx.Lock()
+	defer src.mtx.Unlock()
+
+	src.wallet = wallet
+}
+
+// GetAccumulatorCheckpoint returns the checkpoint to start a rescan from
+// and records the start of the rescan.
+func (src *walletBlockSource) GetAccumulatorCheckpoint(height uint32) (*blockchain.Accumulator, uint32, error) {
+	acc, checkpointHeight, err := src.chain.GetAccumulatorCheckpointByHeight(height)
+	_, bestHeight, _ := src.chain.BestBlock()
+
+	src.mtx.Lock()
+	defer src.mtx.Unlock()
+
+	if src.task != nil {
+		src.task.Done()
+	}
+	src.task = tasks.Start(tasks.KindRescan, "wallet rescan")
+	src.rescan = rpc.WalletRescanStatus{
+		Rescanning:    true,
+		StartHeight:   checkpointHeight,
+		ScannedHeight: checkpointHeight,
+		BestHeight:    bestHeight,
+		Started:       time.Now(),
+	}
+	src.nextHeight = checkpointHeight + 1
+	return acc, checkpointHeight, err
+}
+
+// GetBlocks returns the blocks from the chain at heights from through to.
+// If to is past the tip the blocks up to the tip are returned. It's an
+// error if from is past the tip.
+func (src *walletBlockSource) GetBlocks(from, to uint32) ([]*blocks.Block, uint32, error) {
+	_, bestHeight, _ := src.chain.BestBlock()
+	if from > bestHeight {
+		src.rescanDone(from, bestHeight)
+		return nil, 0, fmt.Errorf("height %d is past the chain tip %d", from, bestHeight)
+	}
+	if to > bestHeight {
+		to = bestHeight
+	}
+
+	keys := src.viewKeys()
+	var (
+		blks    = make([]*blocks.Block, to-from+1)
+		errs    = make([]error, len(blks))
+		work    = make(chan int)
+		workers = runtime.NumCPU()
+		wg      sync.WaitGroup
+	)
+	if workers > len(blks) {
+		workers = len(blks)
+	}
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for n := range work {
+				blk, err := src.chain.GetBlockByHeight(from + uint32(n))
+				if err != nil {
+					errs[n] = err
+					continue
+				}
+				if len(keys) > 0 {
+					blk = dropUnmatchedCiphertexts(blk, keys)
+				}
+				blks[n] = blk
+			}
+		}()
+	}
+	for n := range blks {
+		work <- n
+	}
+	close(work)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			src.rescanDone(from, bestHeight)
+			return nil, 0, err
+		}
+	}
+	src.rescanProgress(from, to, bestHeight)
+	return blks, bestHeight, nil
+}
+
+// RescanStatus returns the progress of the current or last wallet rescan.
+func (src *walletBlockSource) RescanStatus() rpc.WalletRescanStatus {
+	src.mtx.RLock()
+	defer src.mtx.RUnlock()
+
+	return src.rescan
+}
+
+func (src *walletBlockSource) viewKeys() []*crypto.Curve25519PrivateKey {
+	src.mtx.RLock()
+	wallet := src.wallet
+	src.mtx.RUnlock()
+
+	if wallet == nil {
+		return nil
+	}
+	keys, err := wallet.ViewKeys()
+	if err != nil {
+		return nil
+	}
+	return keys
+}
+
+// rescanProgress records the blocks handed to a running rescan. Other
+// requests, such as the wallet filling in a gap while it follows the tip,
+// are ignored.
+func (src *walletBlockSource) rescanProgress(from, to, bestHeight uint32) {
+	src.mtx.Lock()
+	defer src.mtx.Unlock()
+
+	if !src.rescan.Rescanning || from != src.nextHeight {
+		return
+	}
+	src.rescan.ScannedHeight = to
+	src.rescan.BestHeight = bestHeight
+	src.nextHeight = to + 1
+	if to >= bestHeight {
+		src.finishRescan()
+		return
+	}
+	src.task.SetState("height %d of %d", to, bestHeight)
+}
+
+// rescanDone is called when a request fails. The wallet stops its rescan
+// when it can't get the next batch of blocks.
+func (src *walletBlockSource) rescanDone(from, bestHeight uint32) {
+	src.mtx.Lock()
+	defer src.mtx.Unlock()
+
+	if !src.rescan.Rescanning || from != src.nextHeight {
+		return
+	}
+	src.rescan.BestHeight = bestHeight
+	src.finishRescan()
+}
+
+func (src *walletBlockSource) finishRescan() {
+	src.rescan.Rescanning = false
+	src.rescan.Finished = time.Now()
+	src.task.Done()
+	src.task = nil
+}
+
+// dropUnmatchedCiphertexts trial decrypts the block's outputs with the keys
+// and returns a copy of the block in which the transactions without any
+// outputs for the keys have their ciphertexts removed. Transactions with
+// outputs that may be for a public address are left alone. The copies keep
+// the original transaction IDs.
+func dropUnmatchedCiphertexts(blk *blocks.Block, keys []*crypto.Curve25519PrivateKey) *blocks.Block {
+	var ciphertexts [][]byte
+	for _, tx := range blk.Transactions {
+		for _, out := range tx.Outputs() {
+			ciphertexts = append(ciphertexts, out.Ciphertext)
+		}
+	}
+	results := crypto.TrialDecrypt(keys, ciphertexts)
+
+	ret := &blocks.Block{
+		Header:       blk.Header,
+		Transactions: make([]*transactions.Transaction, len(blk.Transactions)),
+	}
+	i := 0
+	for n, tx := range blk.Transactions {
+		outputs := tx.Outputs()
+		matched := false
+		for _, out := range outputs {
+			if results[i].Key >= 0 || (len(out.Ciphertext) >= len(publicAddrScriptHash) && bytes.Equal(out.Ciphertext[:len(publicAddrScriptHash)], publicAddrScriptHash)) {
+				matched = true
+			}
+			i++
+		}
+		if matched || len(outputs) == 0 {
+			ret.Transactions[n] = tx
+			continue
+		}
+		clone := proto.Clone(tx).(*transactions.Transaction)
+		for _, out := range clone.Outputs() {
+			out.Ciphertext = nil
+		}
+		clone.CacheTxid(tx.ID())
+		ret.Transactions[n] = clone
+	}
+	return ret
+}
@@ -0,0 +1,197 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package zk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// batchWorkers is the maximum number of proofs ProveBatch creates at
+// the same time. Each proof holds its own witness and prover state in
+// memory so this bounds the memory used by the batch.
+var batchWorkers int32
+
+func init() {
+	SetBatchWorkers(runtime.NumCPU() / 2)
+}
+
+// SetBatchWorkers sets the maximum number of proofs ProveBatch creates at
+// the same time. Values less than one are treated as one.
+func SetBatchWorkers(n int) {
+	if n < 1 {
+		n = 1
+	}
+	atomic.StoreInt32(&batchWorkers, int32(n))
+}
+
+// ProofRequest is a request to prove a program with the private and public
+// parameters. MaxSteps is optional and defaults to no limit.
+type ProofRequest struct {
+	Program       string
+	PrivateParams Parameters
+	PublicParams  Parameters
+	MaxSteps      uint64
+}
+
+// ProveBatch creates the proofs for each of the requests concurrently using
+// a pool of workers sized by SetBatchWorkers. The public parameters are loaded
+// once and shared by all the workers. The proofs are returned in the same
+// order as the requests. If any proof fails the remaining requests are not
+// started and the first error is returned.
+func ProveBatch(reqs []ProofRequest) ([][]byte, error) {
+	if len(reqs) == 0 {
+		return nil, nil
+	}
+	LoadZKPublicParameters()
+
+	workers := int(atomic.LoadInt32(&batchWorkers))
+	if workers > len(reqs) {
+		workers = len(reqs)
+	}
+
+	_, span := tracer.Start(context.Background(), "zk.proveBatch", trace.WithAttributes(
+		attribute.Int("illium.batch_size", len(reqs)),
+		attribute.Int("illium.workers", workers),
+	))
+	defer span.End()
+
+	var (
+		proofs   = make([][]byte, len(reqs))
+		workChan = make(chan int)
+		done     = make(chan struct{})
+		errOnce  sync.Once
+		firstErr error
+		wg       sync.WaitGroup
+	)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range workChan {
+				req := reqs[n]
+				var maxSteps []uint64
+				if req.MaxSteps > 0 {
+					maxSteps = append(maxSteps, req.MaxSteps)
+				}
+				proof, err := Prove(req.Program, req.PrivateParams, req.PublicParams, maxSteps...)
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = fmt.Errorf("proof %d: %w", n, err)
+						close(done)
+					})
+					continue
+				}
+				proofs[n] = proof
+			}
+		}()
+	}
+
+loop:
+	for n := range reqs {
+		select {
+		case workChan <- n:
+		case <-done:
+			break loop
+		}
+	}
+	close(workChan)
+	wg.Wait()
+
+	if firstErr != nil {
+		span.RecordError(firstErr)
+		span.SetStatus(codes.Error, firstErr.Error())
+		return nil, firstErr
+	}
+	return proofs, nil
+}
+
+// ErrInvalidProof is returned by VerifyBatch when a proof does not verify.
+var ErrInvalidProof = errors.New("invalid proof")
+
+// VerifyRequest is a request to verify a proof of a program with the
+// public parameters.
+type VerifyRequest struct {
+	Program      string
+	PublicParams Parameters
+	Proof        []byte
+}
+
+// VerifyBatch verifies the proofs for each of the requests concurrently with
+// the verifier using a pool of workers sized to the number of CPUs. Unlike
+// proving, verification uses little memory so it isn't bounded by
+// SetBatchWorkers. If any proof is invalid or fails to verify the remaining
+// requests are not started and the first error is returned. An invalid proof
+// returns an error wrapping ErrInvalidProof.
+func VerifyBatch(verifier Verifier, reqs []VerifyRequest) error {
+	if len(reqs) == 0 {
+		return nil
+	}
+
+	workers := runtime.NumCPU()
+	if workers > len(reqs) {
+		workers = len(reqs)
+	}
+
+	_, span := tracer.Start(context.Background(), "zk.verifyBatch", trace.WithAttributes(
+		attribute.Int("illium.batch_size", len(reqs)),
+		attribute.Int("illium.workers", workers),
+	))
+	defer span.End()
+
+	var (
+		workChan = make(chan int)
+		done     = make(chan struct{})
+		errOnce  sync.Once
+		firstErr error
+		wg       sync.WaitGroup
+	)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range workChan {
+				req := reqs[n]
+				valid, err := verifier.Verify(req.Program, req.PublicParams, req.Proof)
+				if err == nil && !valid {
+					err = ErrInvalidProof
+				}
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = fmt.Errorf("proof %d: %w", n, err)
+						close(done)
+					})
+				}
+			}
+		}()
+	}
+
+loop:
+	for n := range reqs {
+		select {
+		case workChan <- n:
+		case <-done:
+			break loop
+		}
+	}
+	close(workChan)
+	wg.Wait()
+
+	if firstErr != nil {
+		span.RecordError(firstErr)
+		span.SetStatus(codes.Error, firstErr.Error())
+		return firstErr
+	}
+	return nil
+}
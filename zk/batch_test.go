@@ -0,0 +1,45 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package zk
+
+import (
+	"bytes"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+)
+
+type proofMatchVerifier struct {
+	calls int32
+}
+
+func (v *proofMatchVerifier) Verify(program string, publicParams Parameters, proof []byte) (bool, error) {
+	atomic.AddInt32(&v.calls, 1)
+	if bytes.Equal(proof, []byte("error")) {
+		return false, errors.New("verifier error")
+	}
+	return bytes.Equal(proof, []byte("valid")), nil
+}
+
+func TestVerifyBatch(t *testing.T) {
+	verifier := &proofMatchVerifier{}
+	reqs := make([]VerifyRequest, 50)
+	for i := range reqs {
+		reqs[i] = VerifyRequest{Program: "(lambda (priv pub) t)", PublicParams: Expr("nil"), Proof: []byte("valid")}
+	}
+	assert.NoError(t, VerifyBatch(verifier, reqs))
+	assert.Equal(t, int32(len(reqs)), atomic.LoadInt32(&verifier.calls))
+	assert.NoError(t, VerifyBatch(verifier, nil))
+
+	reqs[20].Proof = []byte("invalid")
+	err := VerifyBatch(verifier, reqs)
+	assert.True(t, errors.Is(err, ErrInvalidProof))
+
+	reqs[20].Proof = []byte("error")
+	err = VerifyBatch(verifier, reqs)
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, ErrInvalidProof))
+}
@@ -1,5 +1,5 @@
-//go:build cuda
-// +build cuda
+//go:build cuda && !zkdynamic
+// +build cuda,!zkdynamic
 
 // Copyright (c) 2024 The illium developers
 // Use of this source code is governed by an MIT
@@ -21,6 +21,9 @@ const CommitmentLen = 32
 // is the same exact hashing algorithm used inside lurk circuits.
 func LurkCommit(expr string) ([]byte, error) {
 	var out [32]byte
+	if err := LoadProverLibrary(); err != nil {
+		return out[:], err
+	}
 	cExpr := C.CString(expr)
 	defer C.free(unsafe.Pointer(cExpr))
 
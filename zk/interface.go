@@ -30,6 +30,16 @@ type Prover interface {
 	Prove(program string, privateParams Parameters, publicParams Parameters, maxSteps ...uint64) (proof []byte, err error)
 }
 
+// ProgressProver is a Prover which can report the progress of
+// a proof while it's being created.
+type ProgressProver interface {
+	Prover
+
+	// ProveWithProgress is the same as Prove but calls the progress
+	// function with the progress of the proof as it's created.
+	ProveWithProgress(program string, privateParams Parameters, publicParams Parameters, progress ProgressFunc, maxSteps ...uint64) (proof []byte, err error)
+}
+
 // Verifier is an interface to the zk-snark verify function.
 type Verifier interface {
 	// Verify uses the public params and the proof to verify that
@@ -47,6 +57,12 @@ func (l *LurkProver) Prove(program string, privateParams Parameters, publicParam
 	return Prove(program, privateParams, publicParams, maxSteps...)
 }
 
+// ProveWithProgress is the same as Prove but calls the progress
+// function with the progress of the proof as it's created.
+func (l *LurkProver) ProveWithProgress(program string, privateParams Parameters, publicParams Parameters, progress ProgressFunc, maxSteps ...uint64) (proof []byte, err error) {
+	return ProveWithProgress(program, privateParams, publicParams, progress, maxSteps...)
+}
+
 // LurkVerifier is an implementation of the Verifier interface
 // that verifies lurk zk-snark proofs.
 type LurkVerifier struct{}
@@ -69,6 +85,17 @@ type MockProver struct {
 // Prove creates a proof that the private and public params
 // make the program return true.
 func (m *MockProver) Prove(program string, privateParams Parameters, publicParams Parameters, maxSteps ...uint64) ([]byte, error) {
+	return m.ProveWithProgress(program, privateParams, publicParams, nil, maxSteps...)
+}
+
+// ProveWithProgress is the same as Prove but calls the progress
+// function as the mock proof is created.
+func (m *MockProver) ProveWithProgress(program string, privateParams Parameters, publicParams Parameters, progress ProgressFunc, maxSteps ...uint64) ([]byte, error) {
+	if progress == nil {
+		progress = func(ProofProgress) {}
+	}
+	progress(ProofProgress{Phase: PhaseEvaluating})
+
 	priv, err := privateParams.ToExpr()
 	if err != nil {
 		return nil, err
@@ -83,7 +110,7 @@ func (m *MockProver) Prove(program string, privateParams Parameters, publicParam
 		ms = maxSteps[0]
 	}
 
-	tag, val, _, err := evaluate(program, priv, pub, ms, false)
+	tag, val, iterations, err := evaluate(program, priv, pub, ms, false)
 	if err != nil {
 		return nil, err
 	}
@@ -93,6 +120,11 @@ func (m *MockProver) Prove(program string, privateParams Parameters, publicParam
 	if !bytes.Equal(val, OutputTrue) {
 		return nil, errors.New("program did not return true")
 	}
+	progress(ProofProgress{
+		Phase:          PhaseDone,
+		TotalSteps:     uint64(iterations),
+		StepsCompleted: uint64(iterations),
+	})
 	proofLen := EstimatedProofSize
 	m.mtx.RLock()
 	if m.proofLen > 0 {
@@ -0,0 +1,78 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package zk
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ProverLibraryEnv is the environment variable which, in builds with the
+// zkdynamic tag, may be set to the path of the rust prover library. It
+// takes precedence over the prebuilt library for the platform.
+const ProverLibraryEnv = "ILXD_ZK_LIBRARY"
+
+// ErrProverUnavailable is returned by the functions which call into the
+// rust library when the library could not be loaded.
+var ErrProverUnavailable = errors.New("rust prover library is not available")
+
+// prebuiltPlatforms are the GOOS/GOARCH pairs for which a prebuilt rust
+// library is released.
+var prebuiltPlatforms = map[string]bool{
+	"linux/amd64":   true,
+	"linux/arm64":   true,
+	"darwin/amd64":  true,
+	"darwin/arm64":  true,
+	"windows/amd64": true,
+}
+
+var (
+	libOnce sync.Once
+	libErr  error
+)
+
+// LoadProverLibrary loads the rust prover library if it has not already
+// been loaded and returns an error wrapping ErrProverUnavailable if it
+// could not be. In builds without the zkdynamic tag the library is
+// statically linked and this always returns nil.
+func LoadProverLibrary() error {
+	libOnce.Do(func() {
+		if err := loadProverLibrary(); err != nil {
+			libErr = fmt.Errorf("%w: %s", ErrProverUnavailable, err)
+		}
+	})
+	return libErr
+}
+
+// ProverAvailable returns whether the rust prover library is loaded and
+// real proofs can be created and verified.
+func ProverAvailable() bool {
+	return LoadProverLibrary() == nil
+}
+
+// libraryNames returns the file names, in order of preference, the rust
+// library is looked for under on the platform. The platform specific
+// name is what's shipped in the release archives. The plain name is what
+// cargo builds.
+func libraryNames(goos, goarch string) []string {
+	switch goos {
+	case "windows":
+		return []string{
+			fmt.Sprintf("illium_zk-%s-%s.dll", goos, goarch),
+			"illium_zk.dll",
+		}
+	case "darwin":
+		return []string{
+			fmt.Sprintf("libillium_zk-%s-%s.dylib", goos, goarch),
+			"libillium_zk.dylib",
+		}
+	default:
+		return []string{
+			fmt.Sprintf("libillium_zk-%s-%s.so", goos, goarch),
+			"libillium_zk.so",
+		}
+	}
+}
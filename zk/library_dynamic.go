@@ -0,0 +1,150 @@
+//go:build zkdynamic
+// +build zkdynamic
+
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package zk
+
+/*
+#cgo linux LDFLAGS: -ldl
+#include <stdlib.h>
+#include <stdint.h>
+#include <stddef.h>
+#include <stdbool.h>
+#ifdef _WIN32
+#include <windows.h>
+#else
+#include <dlfcn.h>
+#endif
+
+typedef void (*progress_callback)(uintptr_t, uint8_t, size_t, size_t);
+
+static void (*p_load_public_params)(void);
+static int (*p_lurk_commit)(const char*, unsigned char*);
+static int (*p_create_proof_ffi)(const char*, const char*, const char*, size_t*, uint8_t*, size_t*, uint8_t*, uint8_t*, progress_callback, uintptr_t);
+static int (*p_verify_proof_ffi)(const char*, const char*, const uint8_t*, size_t, const uint8_t*, const uint8_t*);
+static int (*p_eval_ffi)(const char*, const char*, const char*, size_t*, uint8_t*, uint8_t*, size_t*, bool);
+
+static void* zk_sym(void* handle, const char* name) {
+#ifdef _WIN32
+	return (void*)GetProcAddress((HMODULE)handle, name);
+#else
+	return dlsym(handle, name);
+#endif
+}
+
+// zk_open opens the library at the path and resolves the rust functions.
+// It returns 0 on success, 1 if the library could not be opened, and 2 if
+// it does not export all the functions.
+static int zk_open(const char* path) {
+#ifdef _WIN32
+	void* handle = (void*)LoadLibraryA(path);
+#else
+	void* handle = dlopen(path, RTLD_NOW | RTLD_LOCAL);
+#endif
+	if (handle == NULL) {
+		return 1;
+	}
+	p_load_public_params = zk_sym(handle, "load_public_params");
+	p_lurk_commit = zk_sym(handle, "lurk_commit");
+	p_create_proof_ffi = zk_sym(handle, "create_proof_ffi");
+	p_verify_proof_ffi = zk_sym(handle, "verify_proof_ffi");
+	p_eval_ffi = zk_sym(handle, "eval_ffi");
+	if (!p_load_public_params || !p_lurk_commit || !p_create_proof_ffi || !p_verify_proof_ffi || !p_eval_ffi) {
+		return 2;
+	}
+	return 0;
+}
+
+static const char* zk_error() {
+#ifdef _WIN32
+	return "LoadLibrary failed";
+#else
+	const char* err = dlerror();
+	return err ? err : "unknown error";
+#endif
+}
+
+// The functions below stand in for the ones the static library would
+// provide and forward to the loaded library.
+
+void load_public_params() {
+	if (p_load_public_params) p_load_public_params();
+}
+
+int lurk_commit(const char* expr, unsigned char* out) {
+	if (!p_lurk_commit) return -1;
+	return p_lurk_commit(expr, out);
+}
+
+int create_proof_ffi(const char* lurk_program, const char* private_params, const char* public_params, size_t* max_steps, uint8_t* proof, size_t* proof_len, uint8_t* output_tag, uint8_t* output_val, progress_callback progress, uintptr_t progress_handle) {
+	if (!p_create_proof_ffi) return -1;
+	return p_create_proof_ffi(lurk_program, private_params, public_params, max_steps, proof, proof_len, output_tag, output_val, progress, progress_handle);
+}
+
+int verify_proof_ffi(const char* lurk_program, const char* public_params, const uint8_t* proof, size_t proof_size, const uint8_t* expected_tag, const uint8_t* expected_output) {
+	if (!p_verify_proof_ffi) return -1;
+	return p_verify_proof_ffi(lurk_program, public_params, proof, proof_size, expected_tag, expected_output);
+}
+
+int eval_ffi(const char* lurk_program, const char* private_params, const char* public_params, size_t* max_steps, uint8_t* output_tag, uint8_t* output_val, size_t* iterations, bool debug) {
+	if (!p_eval_ffi) return -1;
+	return p_eval_ffi(lurk_program, private_params, public_params, max_steps, output_tag, output_val, iterations, debug);
+}
+*/
+import "C"
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"unsafe"
+)
+
+// loadProverLibrary opens the rust library at runtime rather than linking
+// it into the binary. This lets one binary start on a platform for which
+// no library is available and report it, instead of failing to link or
+// load.
+//
+// The library is looked for at the path in ProverLibraryEnv if it's set,
+// otherwise next to the executable and then in the system's library
+// search path.
+func loadProverLibrary() error {
+	var paths []string
+	if path := os.Getenv(ProverLibraryEnv); path != "" {
+		paths = []string{path}
+	} else {
+		platform := runtime.GOOS + "/" + runtime.GOARCH
+		if !prebuiltPlatforms[platform] {
+			return fmt.Errorf("no prebuilt library for %s. Build it for this platform and set %s to its path", platform, ProverLibraryEnv)
+		}
+		var dir string
+		if exe, err := os.Executable(); err == nil {
+			dir = filepath.Dir(exe)
+		}
+		for _, name := range libraryNames(runtime.GOOS, runtime.GOARCH) {
+			if dir != "" {
+				paths = append(paths, filepath.Join(dir, name))
+			}
+			paths = append(paths, name)
+		}
+	}
+
+	errs := make([]string, 0, len(paths))
+	for _, path := range paths {
+		cpath := C.CString(path)
+		ret := C.zk_open(cpath)
+		C.free(unsafe.Pointer(cpath))
+		switch ret {
+		case 0:
+			return nil
+		case 2:
+			return fmt.Errorf("%s is not a compatible prover library", path)
+		}
+		errs = append(errs, C.GoString(C.zk_error()))
+	}
+	return fmt.Errorf("could not load library: %s", strings.Join(errs, "; "))
+}
@@ -0,0 +1,13 @@
+//go:build !zkdynamic
+// +build !zkdynamic
+
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package zk
+
+// loadProverLibrary is a no-op as the library is linked into the binary.
+func loadProverLibrary() error {
+	return nil
+}
@@ -0,0 +1,21 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package zk
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLibraryNames(t *testing.T) {
+	assert.Equal(t, []string{"libillium_zk-linux-arm64.so", "libillium_zk.so"}, libraryNames("linux", "arm64"))
+	assert.Equal(t, []string{"libillium_zk-darwin-arm64.dylib", "libillium_zk.dylib"}, libraryNames("darwin", "arm64"))
+	assert.Equal(t, []string{"illium_zk-windows-amd64.dll", "illium_zk.dll"}, libraryNames("windows", "amd64"))
+}
+
+func TestLoadProverLibrary(t *testing.T) {
+	assert.NoError(t, LoadProverLibrary())
+	assert.True(t, ProverAvailable())
+}
@@ -68,6 +68,7 @@ var paramMap = map[string]string{
 	"fee":                "(car (cdr (cdr (cdr public-params))))",
 	"mint-id":            "(car (cdr (cdr (cdr (cdr public-params)))))",
 	"mint-amount":        "(car (cdr (cdr (cdr (cdr (cdr public-params))))))",
+	"outputs":            "(car (cdr (cdr (cdr (cdr (cdr (cdr public-params)))))))",
 	"locktime":           "(car (cdr (cdr (cdr (cdr (cdr (cdr (cdr public-params))))))))",
 	"locktime-precision": "(car (cdr (cdr (cdr (cdr (cdr (cdr (cdr (cdr public-params)))))))))",
 }
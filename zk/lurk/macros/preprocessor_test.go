@@ -49,6 +49,7 @@ func TestPreProcessValidParentheses(t *testing.T) {
 		{"!(param fee)", "(car (cdr (cdr (cdr public-params))))"},
 		{"!(param mint-id)", "(car (cdr (cdr (cdr (cdr public-params)))))"},
 		{"!(param mint-amount)", "(car (cdr (cdr (cdr (cdr (cdr public-params))))))"},
+		{"!(param outputs)", "(car (cdr (cdr (cdr (cdr (cdr (cdr public-params)))))))"},
 		{"!(param locktime)", "(car (cdr (cdr (cdr (cdr (cdr (cdr (cdr public-params))))))))"},
 		{"!(param locktime-precision)", "(car (cdr (cdr (cdr (cdr (cdr (cdr (cdr (cdr public-params)))))))))"},
 		{"!(param priv-in 2)", "(nth 2 (car private-params))"},
@@ -0,0 +1,216 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package zk
+
+/*
+#include <stdint.h>
+#include <stddef.h>
+*/
+import "C"
+import (
+	"runtime/cgo"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// progressInterval is how often the estimated progress is
+// reported while the steps are being proved.
+const progressInterval = time.Millisecond * 500
+
+// ProofPhase is a phase of creating a proof.
+type ProofPhase uint8
+
+const (
+	// PhaseEvaluating is reported while the program is evaluated
+	// to find the steps which need to be proved.
+	PhaseEvaluating ProofPhase = iota
+	// PhaseProving is reported while the steps are being proved.
+	PhaseProving
+	// PhaseCompressing is reported while the proof is being compressed.
+	PhaseCompressing
+	// PhaseDone is reported once the proof has been created.
+	PhaseDone
+)
+
+func (p ProofPhase) String() string {
+	switch p {
+	case PhaseEvaluating:
+		return "evaluating"
+	case PhaseProving:
+		return "proving"
+	case PhaseCompressing:
+		return "compressing"
+	case PhaseDone:
+		return "done"
+	}
+	return "unknown"
+}
+
+// ProofProgress is the progress of a proof being created.
+type ProofProgress struct {
+	Phase ProofPhase
+
+	// TotalSteps is the number of steps the program takes to
+	// run. It is zero until the program has been evaluated.
+	TotalSteps uint64
+
+	// StepsCompleted is the number of steps which have been proved.
+	// Lurk does not report progress while proving so this is estimated
+	// from the rate at which the previous proof was created.
+	StepsCompleted uint64
+
+	// EstimatedRemaining is the estimated time until the proof is
+	// created. It is zero if there is no estimate yet, which is the
+	// case until the first proof has been created.
+	EstimatedRemaining time.Duration
+}
+
+// ProgressFunc is called with the progress of a proof as it's created.
+// It may be called from more than one goroutine but never concurrently.
+type ProgressFunc func(ProofProgress)
+
+// The time it took to prove each step and to compress the last proof
+// are used to estimate the progress of the next one.
+var (
+	provingTimePerStep int64
+	compressionTime    int64
+)
+
+// progressTracker receives the phases reported by the prover for a
+// single proof and turns them into ProofProgress updates.
+type progressTracker struct {
+	fn ProgressFunc
+
+	phase         ProofPhase
+	totalSteps    uint64
+	provingStart  time.Time
+	compressStart time.Time
+	mtx           sync.Mutex
+
+	quit     chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+func newProgressTracker(fn ProgressFunc) *progressTracker {
+	return &progressTracker{
+		fn:   fn,
+		quit: make(chan struct{}),
+	}
+}
+
+// update is called by the prover when it starts a new phase.
+func (t *progressTracker) update(phase ProofPhase, totalSteps uint64) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	t.phase = phase
+	switch phase {
+	case PhaseEvaluating:
+		t.fn(ProofProgress{Phase: PhaseEvaluating})
+	case PhaseProving:
+		t.totalSteps = totalSteps
+		t.provingStart = time.Now()
+		t.fn(t.estimate())
+
+		t.wg.Add(1)
+		go t.reportLoop()
+	case PhaseCompressing:
+		t.compressStart = time.Now()
+		if t.totalSteps > 0 {
+			atomic.StoreInt64(&provingTimePerStep, int64(time.Since(t.provingStart))/int64(t.totalSteps))
+		}
+		t.fn(ProofProgress{
+			Phase:              PhaseCompressing,
+			TotalSteps:         t.totalSteps,
+			StepsCompleted:     t.totalSteps,
+			EstimatedRemaining: time.Duration(atomic.LoadInt64(&compressionTime)),
+		})
+	}
+}
+
+// finish is called once the proof has been created.
+func (t *progressTracker) finish() {
+	t.stop()
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	if !t.compressStart.IsZero() {
+		atomic.StoreInt64(&compressionTime, int64(time.Since(t.compressStart)))
+	}
+	t.phase = PhaseDone
+	t.fn(ProofProgress{
+		Phase:          PhaseDone,
+		TotalSteps:     t.totalSteps,
+		StepsCompleted: t.totalSteps,
+	})
+}
+
+// stop stops reporting the estimated progress. It's safe
+// to call more than once.
+func (t *progressTracker) stop() {
+	t.stopOnce.Do(func() {
+		close(t.quit)
+	})
+	t.wg.Wait()
+}
+
+// reportLoop reports the estimated progress while the steps
+// are being proved.
+func (t *progressTracker) reportLoop() {
+	defer t.wg.Done()
+
+	ticker := time.NewTicker(progressInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			t.mtx.Lock()
+			if t.phase != PhaseProving {
+				t.mtx.Unlock()
+				return
+			}
+			t.fn(t.estimate())
+			t.mtx.Unlock()
+		case <-t.quit:
+			return
+		}
+	}
+}
+
+// estimate returns the estimated progress of the proving phase.
+// The mutex must be held when calling this.
+func (t *progressTracker) estimate() ProofProgress {
+	p := ProofProgress{
+		Phase:      PhaseProving,
+		TotalSteps: t.totalSteps,
+	}
+	perStep := time.Duration(atomic.LoadInt64(&provingTimePerStep))
+	if perStep == 0 {
+		return p
+	}
+	elapsed := time.Since(t.provingStart)
+	p.StepsCompleted = uint64(elapsed / perStep)
+	if p.StepsCompleted > t.totalSteps {
+		p.StepsCompleted = t.totalSteps
+	}
+	remaining := time.Duration(t.totalSteps)*perStep - elapsed
+	if remaining < 0 {
+		remaining = 0
+	}
+	p.EstimatedRemaining = remaining + time.Duration(atomic.LoadInt64(&compressionTime))
+	return p
+}
+
+// zkProofProgress is the callback passed to create_proof_ffi. The
+// handle refers to the progressTracker for the proof.
+//
+//export zkProofProgress
+func zkProofProgress(handle C.uintptr_t, phase C.uint8_t, stepsCompleted C.size_t, totalSteps C.size_t) {
+	tracker := cgo.Handle(handle).Value().(*progressTracker)
+	tracker.update(ProofPhase(phase), uint64(totalSteps))
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package zk
+
+import (
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestProgressTracker(t *testing.T) {
+	atomic.StoreInt64(&provingTimePerStep, 0)
+	atomic.StoreInt64(&compressionTime, 0)
+
+	var updates []ProofProgress
+	tracker := newProgressTracker(func(p ProofProgress) {
+		updates = append(updates, p)
+	})
+
+	// There is no estimate before the first proof.
+	tracker.update(PhaseEvaluating, 0)
+	tracker.update(PhaseProving, 100)
+	time.Sleep(time.Millisecond * 10)
+	tracker.update(PhaseCompressing, 100)
+	tracker.finish()
+
+	assert.Equal(t, []ProofProgress{
+		{Phase: PhaseEvaluating},
+		{Phase: PhaseProving, TotalSteps: 100},
+		{Phase: PhaseCompressing, TotalSteps: 100, StepsCompleted: 100},
+		{Phase: PhaseDone, TotalSteps: 100, StepsCompleted: 100},
+	}, updates)
+	assert.Greater(t, atomic.LoadInt64(&provingTimePerStep), int64(0))
+	assert.Greater(t, atomic.LoadInt64(&compressionTime), int64(0))
+
+	// The next proof is estimated from the first.
+	updates = nil
+	tracker = newProgressTracker(func(p ProofProgress) {
+		updates = append(updates, p)
+	})
+	tracker.update(PhaseProving, 200)
+	tracker.stop()
+
+	assert.Len(t, updates, 1)
+	assert.Equal(t, PhaseProving, updates[0].Phase)
+	assert.Equal(t, uint64(200), updates[0].TotalSteps)
+	assert.Greater(t, updates[0].EstimatedRemaining, time.Duration(atomic.LoadInt64(&provingTimePerStep))*100)
+}
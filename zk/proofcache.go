@@ -0,0 +1,219 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package zk
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ProofCache is an on-disk LRU cache of proofs. Each proof is stored in
+// its own file named after its cache key. The file modification times
+// record when each proof was last used so the LRU order survives a
+// restart.
+type ProofCache struct {
+	dir        string
+	maxEntries int
+	order      *list.List
+	entries    map[string]*list.Element
+	mtx        sync.Mutex
+}
+
+// NewProofCache opens the proof cache in the directory, creating it if
+// it does not exist. Proofs already in the directory are loaded into the
+// cache, evicting the least recently used if there are more than
+// maxEntries.
+func NewProofCache(dir string, maxEntries int) (*ProofCache, error) {
+	if maxEntries < 1 {
+		maxEntries = 1
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	type cached struct {
+		key     string
+		lastUse time.Time
+	}
+	existing := make([]cached, 0, len(files))
+	for _, f := range files {
+		if f.IsDir() || !isProofCacheKey(f.Name()) {
+			continue
+		}
+		info, err := f.Info()
+		if err != nil {
+			continue
+		}
+		existing = append(existing, cached{key: f.Name(), lastUse: info.ModTime()})
+	}
+	sort.Slice(existing, func(i, j int) bool {
+		return existing[i].lastUse.Before(existing[j].lastUse)
+	})
+
+	c := &ProofCache{
+		dir:        dir,
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+	for _, e := range existing {
+		c.entries[e.key] = c.order.PushFront(e.key)
+	}
+	c.evict()
+	return c, nil
+}
+
+// Get returns the proof for the key if it is in the cache.
+func (c *ProofCache) Get(key string) ([]byte, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	path := filepath.Join(c.dir, key)
+	proof, err := os.ReadFile(path)
+	if err != nil || len(proof) == 0 {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+		os.Remove(path)
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	now := time.Now()
+	os.Chtimes(path, now, now)
+	return proof, true
+}
+
+// Put adds the proof to the cache, evicting the least recently used
+// proof if the cache is full.
+func (c *ProofCache) Put(key string, proof []byte) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	// Write to a temp file first so a crash doesn't leave a
+	// partially written proof in the cache.
+	path := filepath.Join(c.dir, key)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, proof, 0600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if elem, ok := c.entries[key]; ok {
+		c.order.MoveToFront(elem)
+	} else {
+		c.entries[key] = c.order.PushFront(key)
+	}
+	c.evict()
+	return nil
+}
+
+// Len returns the number of proofs in the cache.
+func (c *ProofCache) Len() int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.order.Len()
+}
+
+func (c *ProofCache) evict() {
+	for c.order.Len() > c.maxEntries {
+		elem := c.order.Back()
+		key := elem.Value.(string)
+		c.order.Remove(elem)
+		delete(c.entries, key)
+		os.Remove(filepath.Join(c.dir, key))
+	}
+}
+
+// ProofCacheKey returns the key a proof is cached under. It commits to the
+// hash of the lurk program and the hashes of the private and public
+// parameters, so a change to any of them results in a cache miss.
+func ProofCacheKey(program string, privateParams Parameters, publicParams Parameters) (string, error) {
+	priv, err := privateParams.ToExpr()
+	if err != nil {
+		return "", err
+	}
+	pub, err := publicParams.ToExpr()
+	if err != nil {
+		return "", err
+	}
+	programHash := sha256.Sum256([]byte(program))
+	privHash := sha256.Sum256([]byte(priv))
+	pubHash := sha256.Sum256([]byte(pub))
+
+	h := sha256.New()
+	h.Write(programHash[:])
+	h.Write(privHash[:])
+	h.Write(pubHash[:])
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func isProofCacheKey(name string) bool {
+	if len(name) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(name)
+	return err == nil
+}
+
+// CachingProver wraps a Prover and returns proofs from the cache when
+// the same program is proven with the same parameters again, such as
+// when a transaction is resubmitted after an RPC timeout.
+type CachingProver struct {
+	prover Prover
+	cache  *ProofCache
+}
+
+// NewCachingProver returns a new CachingProver.
+func NewCachingProver(prover Prover, cache *ProofCache) *CachingProver {
+	return &CachingProver{prover: prover, cache: cache}
+}
+
+// Prove returns the cached proof if there is one, otherwise it creates
+// the proof with the wrapped prover and caches it.
+func (p *CachingProver) Prove(program string, privateParams Parameters, publicParams Parameters, maxSteps ...uint64) ([]byte, error) {
+	return p.ProveWithProgress(program, privateParams, publicParams, nil, maxSteps...)
+}
+
+// ProveWithProgress is the same as Prove but reports the progress of the
+// proof if the wrapped prover supports it.
+func (p *CachingProver) ProveWithProgress(program string, privateParams Parameters, publicParams Parameters, progress ProgressFunc, maxSteps ...uint64) ([]byte, error) {
+	key, err := ProofCacheKey(program, privateParams, publicParams)
+	if err != nil {
+		return nil, err
+	}
+	if proof, ok := p.cache.Get(key); ok {
+		if progress != nil {
+			progress(ProofProgress{Phase: PhaseDone})
+		}
+		return proof, nil
+	}
+
+	var proof []byte
+	if pp, ok := p.prover.(ProgressProver); ok && progress != nil {
+		proof, err = pp.ProveWithProgress(program, privateParams, publicParams, progress, maxSteps...)
+	} else {
+		proof, err = p.prover.Prove(program, privateParams, publicParams, maxSteps...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// Failing to cache the proof doesn't stop it from being used.
+	p.cache.Put(key, proof)
+	return proof, nil
+}
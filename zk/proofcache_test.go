@@ -0,0 +1,86 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package zk
+
+import (
+	"crypto/rand"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type countingProver struct {
+	calls int
+}
+
+func (p *countingProver) Prove(program string, privateParams Parameters, publicParams Parameters, maxSteps ...uint64) ([]byte, error) {
+	p.calls++
+	proof := make([]byte, 32)
+	rand.Read(proof)
+	return proof, nil
+}
+
+func TestCachingProver(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewProofCache(dir, 2)
+	assert.NoError(t, err)
+
+	inner := &countingProver{}
+	prover := NewCachingProver(inner, cache)
+
+	proof1, err := prover.Prove("(lambda (priv pub) t)", Expr("1"), Expr("2"))
+	assert.NoError(t, err)
+	proof2, err := prover.Prove("(lambda (priv pub) t)", Expr("1"), Expr("2"))
+	assert.NoError(t, err)
+	assert.Equal(t, proof1, proof2)
+	assert.Equal(t, 1, inner.calls)
+
+	// Changing the program or either of the params is a miss.
+	_, err = prover.Prove("(lambda (priv pub) nil)", Expr("1"), Expr("2"))
+	assert.NoError(t, err)
+	_, err = prover.Prove("(lambda (priv pub) t)", Expr("3"), Expr("2"))
+	assert.NoError(t, err)
+	_, err = prover.Prove("(lambda (priv pub) t)", Expr("1"), Expr("3"))
+	assert.NoError(t, err)
+	assert.Equal(t, 4, inner.calls)
+	assert.Equal(t, 2, cache.Len())
+
+	// The first proof was evicted.
+	_, err = prover.Prove("(lambda (priv pub) t)", Expr("1"), Expr("2"))
+	assert.NoError(t, err)
+	assert.Equal(t, 5, inner.calls)
+}
+
+func TestProofCacheReload(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewProofCache(dir, 3)
+	assert.NoError(t, err)
+
+	keys := make([]string, 3)
+	for i := range keys {
+		keys[i], err = ProofCacheKey("program", Expr("nil"), List(uint64(i)))
+		assert.NoError(t, err)
+		assert.NoError(t, cache.Put(keys[i], []byte{byte(i)}))
+		// Space out the modification times so the order is deterministic.
+		ts := time.Now().Add(time.Duration(i-10) * time.Second)
+		assert.NoError(t, os.Chtimes(filepath.Join(dir, keys[i]), ts, ts))
+	}
+
+	// Reopening with a smaller size evicts the least recently used.
+	cache, err = NewProofCache(dir, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, cache.Len())
+
+	_, ok := cache.Get(keys[0])
+	assert.False(t, ok)
+	_, err = os.Stat(filepath.Join(dir, keys[0]))
+	assert.True(t, os.IsNotExist(err))
+
+	proof, ok := cache.Get(keys[2])
+	assert.True(t, ok)
+	assert.Equal(t, []byte{2}, proof)
+}
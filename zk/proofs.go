@@ -24,6 +24,8 @@ void restore_stderr(int stderr_copy) {
     close(stderr_copy);
 }
 void load_public_params();
+typedef void (*progress_callback)(uintptr_t, uint8_t, size_t, size_t);
+extern void zkProofProgress(uintptr_t, uint8_t, size_t, size_t);
 int create_proof_ffi(
     const char* lurk_program,
     const char* private_params,
@@ -32,7 +34,9 @@ int create_proof_ffi(
     uint8_t* proof,
     size_t* proof_len,
     uint8_t* output_tag,
-    uint8_t* output_val);
+    uint8_t* output_val,
+    progress_callback progress,
+    uintptr_t progress_handle);
 int verify_proof_ffi(
     const char* lurk_program,
     const char* public_params,
@@ -53,8 +57,14 @@ int eval_ffi(
 import "C"
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"runtime/cgo"
 	"sync"
 	"unsafe"
 )
@@ -74,7 +84,11 @@ const (
 	defaultMaxSteps uint64 = 1000000000000
 )
 
-var once sync.Once
+var (
+	once sync.Once
+
+	tracer = otel.Tracer("github.com/project-illium/ilxd/zk")
+)
 
 // Expr is a Parameters type that wraps a string expression
 type Expr string
@@ -117,6 +131,9 @@ func List(args ...any) Expr {
 // into memory or generates them if this is the first startup.
 func LoadZKPublicParameters() {
 	once.Do(func() {
+		if err := LoadProverLibrary(); err != nil {
+			return
+		}
 		// Redirect stderr to /dev/null
 		stderrCopy := C.redirect_stderr()
 		C.load_public_params()
@@ -126,6 +143,13 @@ func LoadZKPublicParameters() {
 }
 
 func Prove(lurkProgram string, privateParams Parameters, publicParams Parameters, maxSteps ...uint64) ([]byte, error) {
+	return ProveWithProgress(lurkProgram, privateParams, publicParams, nil, maxSteps...)
+}
+
+// ProveWithProgress is the same as Prove but reports the progress of the
+// proof to the progress function as it's created. The progress function
+// may be nil.
+func ProveWithProgress(lurkProgram string, privateParams Parameters, publicParams Parameters, progress ProgressFunc, maxSteps ...uint64) ([]byte, error) {
 	priv, err := privateParams.ToExpr()
 	if err != nil {
 		return nil, err
@@ -140,10 +164,16 @@ func Prove(lurkProgram string, privateParams Parameters, publicParams Parameters
 		ms = maxSteps[0]
 	}
 
-	proof, tag, output, err := createProof(lurkProgram, priv, pub, ms)
+	_, span := tracer.Start(context.Background(), "zk.createProof")
+	proof, tag, output, err := createProof(lurkProgram, priv, pub, ms, progress)
 	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		span.End()
 		return nil, err
 	}
+	span.SetAttributes(attribute.Int("illium.proof_size", len(proof)))
+	span.End()
 	if tag != TagSym || !bytes.Equal(output, OutputTrue) {
 		return nil, errors.New("program output is not true")
 	}
@@ -158,7 +188,17 @@ func Verify(lurkProgram string, publicParams Parameters, proof []byte) (bool, er
 	}
 	tagBytes := make([]byte, 32)
 	tagBytes[len(tagBytes)-1] = byte(TagSym)
-	return verifyProof(lurkProgram, pub, proof, tagBytes, OutputTrue)
+
+	_, span := tracer.Start(context.Background(), "zk.verifyProof",
+		trace.WithAttributes(attribute.Int("illium.proof_size", len(proof))))
+	defer span.End()
+	valid, err := verifyProof(lurkProgram, pub, proof, tagBytes, OutputTrue)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.SetAttributes(attribute.Bool("illium.valid", valid))
+	return valid, err
 }
 
 func Eval(lurkProgram string, privateParams Parameters, publicParams Parameters, debug ...bool) (Tag, []byte, int, error) {
@@ -173,7 +213,10 @@ func Eval(lurkProgram string, privateParams Parameters, publicParams Parameters,
 	return evaluate(lurkProgram, priv, pub, defaultMaxSteps, len(debug) > 0 && debug[0])
 }
 
-func createProof(lurkProgram, privateParams, publicParams string, maxSteps uint64) ([]byte, Tag, []byte, error) {
+func createProof(lurkProgram, privateParams, publicParams string, maxSteps uint64, progress ProgressFunc) ([]byte, Tag, []byte, error) {
+	if err := LoadProverLibrary(); err != nil {
+		return nil, TagNil, nil, err
+	}
 	clurkProgram := C.CString(lurkProgram)
 	cprivateParams := C.CString(privateParams)
 	cpublicParams := C.CString(publicParams)
@@ -193,7 +236,19 @@ func createProof(lurkProgram, privateParams, publicParams string, maxSteps uint6
 		proofLen  C.size_t
 		outputTag [32]byte
 		outputVal [32]byte
+
+		tracker  *progressTracker
+		callback C.progress_callback
+		handle   cgo.Handle
 	)
+	if progress != nil {
+		tracker = newProgressTracker(progress)
+		defer tracker.stop()
+
+		handle = cgo.NewHandle(tracker)
+		defer handle.Delete()
+		callback = C.progress_callback(C.zkProofProgress)
+	}
 
 	result := C.create_proof_ffi(
 		clurkProgram,
@@ -204,11 +259,16 @@ func createProof(lurkProgram, privateParams, publicParams string, maxSteps uint6
 		&proofLen,
 		(*C.uint8_t)(unsafe.Pointer(&outputTag[0])),
 		(*C.uint8_t)(unsafe.Pointer(&outputVal[0])),
+		callback,
+		C.uintptr_t(handle),
 	)
 
 	if result != 0 {
 		return nil, TagNil, nil, errors.New("failed to create proof")
 	}
+	if tracker != nil {
+		tracker.finish()
+	}
 
 	var (
 		proofOut = make([]byte, proofLen)
@@ -231,6 +291,9 @@ func verifyProof(lurkProgram, publicParams string, proof, expectedTag, expectedO
 	if len(proof) == 0 {
 		return false, errors.New("proof is nil")
 	}
+	if err := LoadProverLibrary(); err != nil {
+		return false, err
+	}
 	clurkProgram := C.CString(lurkProgram)
 	cpublicParams := C.CString(publicParams)
 
@@ -268,6 +331,9 @@ func verifyProof(lurkProgram, publicParams string, proof, expectedTag, expectedO
 }
 
 func evaluate(lurkProgram, privateParams, publicParams string, maxSteps uint64, debug bool) (Tag, []byte, int, error) {
+	if err := LoadProverLibrary(); err != nil {
+		return TagNil, nil, 0, err
+	}
 	clurkProgram := C.CString(lurkProgram)
 	cprivateParams := C.CString(privateParams)
 	cpublicParams := C.CString(publicParams)
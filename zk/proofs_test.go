@@ -64,6 +64,43 @@ func TestProve(t *testing.T) {
 	assert.True(t, valid)
 }
 
+func TestProveBatch(t *testing.T) {
+	program := "(lambda (priv pub) (= (num (commit priv)) pub))"
+
+	var (
+		reqs    []zk.ProofRequest
+		commits []zk.Expr
+	)
+	for i := 0; i < 4; i++ {
+		r, err := zk.RandomFieldElement()
+		assert.NoError(t, err)
+		h, err := zk.LurkCommit(fmt.Sprintf("0x%x", r))
+		assert.NoError(t, err)
+		commits = append(commits, zk.Expr(fmt.Sprintf("0x%x", h)))
+		reqs = append(reqs, zk.ProofRequest{
+			Program:       program,
+			PrivateParams: zk.Expr(fmt.Sprintf("0x%x", r)),
+			PublicParams:  commits[i],
+		})
+	}
+
+	zk.SetBatchWorkers(2)
+	proofs, err := zk.ProveBatch(reqs)
+	assert.NoError(t, err)
+	assert.Len(t, proofs, len(reqs))
+
+	for i, proof := range proofs {
+		valid, err := zk.Verify(program, commits[i], proof)
+		assert.NoError(t, err)
+		assert.True(t, valid)
+	}
+
+	// A request which does not return true fails the batch.
+	reqs[2].PublicParams = zk.Expr("0x01")
+	_, err = zk.ProveBatch(reqs)
+	assert.Error(t, err)
+}
+
 func TestCoprocessors(t *testing.T) {
 	zk.LoadZKPublicParameters()
 
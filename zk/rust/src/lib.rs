@@ -54,6 +54,16 @@ use lazy_static::lazy_static;
 const OUT_LEN: usize = 32;
 const REDUCTION_COUNT: usize = 10;
 
+// Proof phases reported to the progress callback. These must
+// match the ProofPhase constants in the go package.
+const PHASE_EVALUATING: u8 = 0;
+const PHASE_PROVING: u8 = 1;
+const PHASE_COMPRESSING: u8 = 2;
+
+// ProgressCallback is called with the handle passed into create_proof_ffi,
+// the phase, the number of steps completed and the total number of steps.
+type ProgressCallback = extern "C" fn(usize, u8, usize, usize);
+
 lazy_static! {
     static ref IO_ZERO: Fr = Fr::zero();
     static ref IO_ONE: Fr = Fr::one();
@@ -120,6 +130,8 @@ pub extern "C" fn create_proof_ffi(
     proof_len: *mut usize,
     output_tag: *mut u8,
     output_val: *mut u8,
+    progress: Option<ProgressCallback>,
+    progress_handle: usize,
 ) -> i32 {
     let c_str1 = unsafe { CStr::from_ptr(lurk_program) };
     let program_str = match c_str1.to_str() {
@@ -145,6 +157,11 @@ pub extern "C" fn create_proof_ffi(
         priv_params_str.to_string(),
         pub_params_str.to_string(),
         max_steps,
+        &|phase, steps_completed, total_steps| {
+            if let Some(cb) = progress {
+                cb(progress_handle, phase, steps_completed, total_steps);
+            }
+        },
     ) {
         Ok((vec1, vec2, vec3)) => {
             // Assume output1, output2, and output3 are large enough to hold the data
@@ -294,7 +311,8 @@ fn create_public_params() -> PublicParams<Fr> {
     pp
 }
 
-fn create_proof(lurk_program: String, private_params: String, public_params: String, max_steps: usize) -> Result<(Vec<u8>, Vec<u8>, Vec<u8>), Box<dyn Error>> {
+fn create_proof(lurk_program: String, private_params: String, public_params: String, max_steps: usize, progress: &dyn Fn(u8, usize, usize)) -> Result<(Vec<u8>, Vec<u8>, Vec<u8>), Box<dyn Error>> {
+    progress(PHASE_EVALUATING, 0, 0);
     let store = &Arc::new(Store::<Fr>::default());
 
     let secret = Fr::random(OsRng);
@@ -336,7 +354,12 @@ fn create_proof(lurk_program: String, private_params: String, public_params: Str
 
     let pp = get_public_params();
 
+    // The number of frames is only known once the program has been
+    // evaluated. Lurk does not report progress while folding so the
+    // caller estimates it from the total.
+    progress(PHASE_PROVING, 0, frames.len());
     let (proof, _z0, zi, _num_steps) = supernova_prover.prove_from_frames(&pp, &frames, store, None)?;
+    progress(PHASE_COMPRESSING, frames.len(), frames.len());
     let compressed_proof = proof.compress(&pp).unwrap();
 
     let mut ret_tag = zi[0].to_bytes();
@@ -479,6 +502,7 @@ mod tests {
             "(cons 7 8)".to_string(),
             "(cons 7 8)".to_string(),
             10000000,
+            &|_, _, _| {},
         ).expect("create_proof failed");
         let mut commitment = packed_proof[..32].to_vec();
         commitment.reverse();
@@ -67,10 +67,6 @@ func init() {
 	if err != nil {
 		panic(err)
 	}
-	basicTransferScriptCommitment, err = LurkCommit(basicTransferScriptData)
-	if err != nil {
-		panic(err)
-	}
 
 	data, err = passwordScriptLurk.ReadFile("lurk/password_script.lurk")
 	if err != nil {
@@ -89,10 +85,6 @@ func init() {
 	if err != nil {
 		panic(err)
 	}
-	multisigCommitment, err = LurkCommit(multisigScriptData)
-	if err != nil {
-		panic(err)
-	}
 
 	data, err = timelockedMultisigScriptLurk.ReadFile("lurk/timelocked_multisig.lurk")
 	if err != nil {
@@ -102,10 +94,6 @@ func init() {
 	if err != nil {
 		panic(err)
 	}
-	timeLockedMultisigCommitment, err = LurkCommit(timelockedMultisigScriptData)
-	if err != nil {
-		panic(err)
-	}
 
 	data, err = publicAddressScriptLurk.ReadFile("lurk/public_address_script.lurk")
 	if err != nil {
@@ -115,14 +103,6 @@ func init() {
 	if err != nil {
 		panic(err)
 	}
-	publicAddressScriptCommitment, err = LurkCommit(publicAddressScriptData)
-	if err != nil {
-		panic(err)
-	}
-	publicAddressScriptHash, err = LurkCommit(fmt.Sprintf("(cons 0x%x nil)", publicAddressScriptCommitment))
-	if err != nil {
-		panic(err)
-	}
 
 	data, err = standardValidationScriptLurk.ReadFile("lurk/standard_validation.lurk")
 	if err != nil {
@@ -150,6 +130,33 @@ func init() {
 	}
 
 	stakeValidationScriptData = string(data)
+
+	// The script commitments are computed by the rust library. If it
+	// could not be loaded they are left empty so that the node can start
+	// and report the prover as unavailable.
+	if LoadProverLibrary() != nil {
+		return
+	}
+	basicTransferScriptCommitment, err = LurkCommit(basicTransferScriptData)
+	if err != nil {
+		panic(err)
+	}
+	multisigCommitment, err = LurkCommit(multisigScriptData)
+	if err != nil {
+		panic(err)
+	}
+	timeLockedMultisigCommitment, err = LurkCommit(timelockedMultisigScriptData)
+	if err != nil {
+		panic(err)
+	}
+	publicAddressScriptCommitment, err = LurkCommit(publicAddressScriptData)
+	if err != nil {
+		panic(err)
+	}
+	publicAddressScriptHash, err = LurkCommit(fmt.Sprintf("(cons 0x%x nil)", publicAddressScriptCommitment))
+	if err != nil {
+		panic(err)
+	}
 }
 
 // BasicTransferScript returns the basic transfer lurk script
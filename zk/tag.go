@@ -31,6 +31,37 @@ const (
 	TagCproc
 )
 
+// String returns the name lurk uses for the tag
+func (t Tag) String() string {
+	switch t {
+	case TagNil:
+		return "nil"
+	case TagCons:
+		return "cons"
+	case TagSym:
+		return "sym"
+	case TagFun:
+		return "fun"
+	case TagNum:
+		return "num"
+	case TagThunk:
+		return "thunk"
+	case TagStr:
+		return "str"
+	case TagChar:
+		return "char"
+	case TagComm:
+		return "comm"
+	case TagU64:
+		return "u64"
+	case TagKey:
+		return "key"
+	case TagCproc:
+		return "cproc"
+	}
+	return "unknown"
+}
+
 // TagFromBytes returns a tag from a big endian byte slice
 func TagFromBytes(b []byte) (Tag, error) {
 	if len(b) < 1 {
@@ -28,5 +28,6 @@ func TestTagFromBytes(t *testing.T) {
 		ret, err := TagFromBytes(b)
 		assert.NoError(t, err)
 		assert.Equal(t, tag, ret)
+		assert.NotEqual(t, "unknown", ret.String())
 	}
 }
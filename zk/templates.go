@@ -0,0 +1,323 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package zk
+
+import (
+	"embed"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	icrypto "github.com/project-illium/ilxd/crypto"
+	"github.com/project-illium/ilxd/zk/lurk/macros"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+//go:embed lurk/templates/*.lurk
+var templateScriptsLurk embed.FS
+
+// TemplateParamType is the type of a script template parameter. It
+// determines how the parameter is parsed and encoded in the locking params.
+type TemplateParamType int
+
+const (
+	// TemplateParamPubkey is a Nova public key serialized as a hex
+	// string. It is encoded as its x and y coordinates.
+	TemplateParamPubkey TemplateParamType = iota
+	// TemplateParamTimestamp is a unix timestamp in seconds.
+	TemplateParamTimestamp
+	// TemplateParamFieldElement is a 32 byte field element serialized
+	// as a hex string, such as a hash.
+	TemplateParamFieldElement
+)
+
+func (t TemplateParamType) String() string {
+	switch t {
+	case TemplateParamPubkey:
+		return "pubkey"
+	case TemplateParamTimestamp:
+		return "timestamp"
+	case TemplateParamFieldElement:
+		return "field element"
+	default:
+		return "unknown"
+	}
+}
+
+// TemplateParam is a parameter of a script template.
+type TemplateParam struct {
+	Name        string
+	Type        TemplateParamType
+	Description string
+}
+
+// ScriptTemplate is a locking script for a common use case. The parameters
+// are encoded into the locking params in the order they are listed.
+type ScriptTemplate struct {
+	Name        string
+	Description string
+	Params      []TemplateParam
+
+	file       string
+	script     string
+	commitment []byte
+}
+
+// Script returns the template's lurk script.
+func (t *ScriptTemplate) Script() string {
+	return t.script
+}
+
+// ScriptCommitment returns the script commitment hash for the
+// template's script.
+func (t *ScriptTemplate) ScriptCommitment() []byte {
+	ret := make([]byte, len(t.commitment))
+	copy(ret, t.commitment)
+	return ret
+}
+
+// LockingParams parses the parameters, keyed by name, and returns the
+// locking params for the template. All the template's parameters must
+// be provided.
+func (t *ScriptTemplate) LockingParams(args map[string]string) ([][]byte, error) {
+	for name := range args {
+		if _, ok := t.param(name); !ok {
+			return nil, fmt.Errorf("unknown parameter %s for template %s", name, t.Name)
+		}
+	}
+	var lockingParams [][]byte
+	for _, p := range t.Params {
+		arg, ok := args[p.Name]
+		if !ok {
+			return nil, fmt.Errorf("missing parameter %s", p.Name)
+		}
+		switch p.Type {
+		case TemplateParamPubkey:
+			keyBytes, err := hex.DecodeString(arg)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", p.Name, err)
+			}
+			pubkey, err := crypto.UnmarshalPublicKey(keyBytes)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", p.Name, err)
+			}
+			novaKey, ok := pubkey.(*icrypto.NovaPublicKey)
+			if !ok {
+				return nil, fmt.Errorf("%s: pubkey is not type Nova public key", p.Name)
+			}
+			pubX, pubY := novaKey.ToXY()
+			lockingParams = append(lockingParams, pubX, pubY)
+		case TemplateParamTimestamp:
+			ts, err := strconv.ParseInt(arg, 10, 64)
+			if err != nil || ts <= 0 {
+				return nil, fmt.Errorf("%s: invalid unix timestamp", p.Name)
+			}
+			b := make([]byte, 8)
+			binary.BigEndian.PutUint64(b, uint64(ts))
+			lockingParams = append(lockingParams, b)
+		case TemplateParamFieldElement:
+			b, err := hex.DecodeString(strings.TrimPrefix(arg, "0x"))
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", p.Name, err)
+			}
+			if len(b) != 32 {
+				return nil, fmt.Errorf("%s: must be 32 bytes", p.Name)
+			}
+			fieldMax, _ := new(big.Int).SetString(LurkMaxFieldElement, 16)
+			if new(big.Int).SetBytes(b).Cmp(fieldMax) > 0 {
+				return nil, fmt.Errorf("%s: exceeds the maximum field element", p.Name)
+			}
+			lockingParams = append(lockingParams, b)
+		}
+	}
+	return lockingParams, nil
+}
+
+func (t *ScriptTemplate) param(name string) (TemplateParam, bool) {
+	for _, p := range t.Params {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return TemplateParam{}, false
+}
+
+var scriptTemplates = []*ScriptTemplate{
+	{
+		Name:        "hashlock",
+		Description: "Hash time locked contract. The claim key can spend with the preimage of the hash, or the refund key can spend after the refund time.",
+		Params: []TemplateParam{
+			{Name: "hash", Type: TemplateParamFieldElement, Description: "The lurk commitment of the preimage. It can be computed with :commit in the lurk repl."},
+			{Name: "claimkey", Type: TemplateParamPubkey, Description: "The key that can claim the coins with the preimage"},
+			{Name: "refundafter", Type: TemplateParamTimestamp, Description: "The time after which the coins can be refunded"},
+			{Name: "refundkey", Type: TemplateParamPubkey, Description: "The key that can refund the coins"},
+		},
+		file: "lurk/templates/hashlock.lurk",
+	},
+	{
+		Name:        "vesting",
+		Description: "The coins vest to the beneficiary at the vesting time. Until then the grantor can claw them back. Use one address per tranche for a vesting schedule.",
+		Params: []TemplateParam{
+			{Name: "vestat", Type: TemplateParamTimestamp, Description: "The time the coins vest"},
+			{Name: "beneficiarykey", Type: TemplateParamPubkey, Description: "The key that can spend the coins after they vest"},
+			{Name: "grantorkey", Type: TemplateParamPubkey, Description: "The key that can claw back the coins before they vest"},
+		},
+		file: "lurk/templates/vesting.lurk",
+	},
+	{
+		Name:        "twofactor",
+		Description: "Spending requires the owner key and a second factor key. The owner key alone can spend after the recovery time.",
+		Params: []TemplateParam{
+			{Name: "ownerkey", Type: TemplateParamPubkey, Description: "The owner's key which signs every spend"},
+			{Name: "secondkey", Type: TemplateParamPubkey, Description: "The second factor key"},
+			{Name: "recoverafter", Type: TemplateParamTimestamp, Description: "The time after which the owner key alone can spend"},
+		},
+		file: "lurk/templates/twofactor.lurk",
+	},
+	{
+		Name:        "oracle",
+		Description: "The beneficiary can spend once the oracle signs the outcome, or the refund key can spend after the refund time.",
+		Params: []TemplateParam{
+			{Name: "outcome", Type: TemplateParamFieldElement, Description: "The outcome the oracle signs when the condition is met"},
+			{Name: "oraclekey", Type: TemplateParamPubkey, Description: "The oracle's key"},
+			{Name: "beneficiarykey", Type: TemplateParamPubkey, Description: "The key that can spend the coins once the oracle signs"},
+			{Name: "refundafter", Type: TemplateParamTimestamp, Description: "The time after which the coins can be refunded"},
+			{Name: "refundkey", Type: TemplateParamPubkey, Description: "The key that can refund the coins"},
+		},
+		file: "lurk/templates/oracle.lurk",
+	},
+	{
+		Name:        "atomicswap",
+		Description: "Hash time locked contract for cross chain atomic swaps. The claim key can spend by publishing the sha256 preimage of the hash, or the refund key can spend after the refund time.",
+		Params: []TemplateParam{
+			{Name: "hash", Type: TemplateParamFieldElement, Description: "The sha256 hash of the secret with the three most significant bits set to zero"},
+			{Name: "claimkey", Type: TemplateParamPubkey, Description: "The key that can claim the coins with the secret"},
+			{Name: "refundafter", Type: TemplateParamTimestamp, Description: "The time after which the coins can be refunded"},
+			{Name: "refundkey", Type: TemplateParamPubkey, Description: "The key that can refund the coins"},
+		},
+		file: "lurk/templates/atomicswap.lurk",
+	},
+	{
+		Name:        "delegatedstake",
+		Description: "Cold staking. The delegate key can stake the coins with any validator but cannot spend them. The owner key can spend the coins, revoking the delegation, at any time.",
+		Params: []TemplateParam{
+			{Name: "ownerkey", Type: TemplateParamPubkey, Description: "The owner's key which can spend the coins. It can be kept offline."},
+			{Name: "delegatekey", Type: TemplateParamPubkey, Description: "The online key which can stake the coins"},
+		},
+		file: "lurk/templates/delegatedstake.lurk",
+	},
+}
+
+func init() {
+	mp, err := macros.NewMacroPreprocessor(macros.WithStandardLib(), macros.RemoveComments())
+	if err != nil {
+		panic(err)
+	}
+	for _, t := range scriptTemplates {
+		data, err := templateScriptsLurk.ReadFile(t.file)
+		if err != nil {
+			panic(err)
+		}
+		t.script, err = mp.Preprocess(string(data))
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	// As with the other scripts the commitments are left empty if the
+	// rust library could not be loaded.
+	if LoadProverLibrary() != nil {
+		return
+	}
+	for _, t := range scriptTemplates {
+		t.commitment, err = LurkCommit(t.script)
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+// ScriptTemplates returns the locking script templates.
+func ScriptTemplates() []*ScriptTemplate {
+	return scriptTemplates
+}
+
+// GetScriptTemplate returns the template with the name.
+func GetScriptTemplate(name string) (*ScriptTemplate, error) {
+	for _, t := range scriptTemplates {
+		if t.Name == name {
+			return t, nil
+		}
+	}
+	return nil, errors.New("unknown script template")
+}
+
+// AtomicSwapHash returns the hash used in the atomic swap script for a
+// sha256 hash of the swap secret. This is the output of the sha256
+// coprocessor which sets the three most significant bits to zero so the
+// hash fits within the field.
+func AtomicSwapHash(sha256Hash []byte) []byte {
+	h := make([]byte, len(sha256Hash))
+	copy(h, sha256Hash)
+	if len(h) > 0 {
+		h[0] &= 0b00011111
+	}
+	return h
+}
+
+// MakeAtomicSwapClaimParams returns the unlocking params to claim an atomic
+// swap output with the secret. The secret must be the ciphertext of the
+// transaction output at outputIndex.
+func MakeAtomicSwapClaimParams(secret []byte, sig []byte, outputIndex int) (string, error) {
+	if len(secret) != 32 {
+		return "", errors.New("invalid secret len")
+	}
+	sigExpr, err := signatureExpr(sig)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("(cons 0 (cons 0x%x (cons %s (cons %d nil))))", secret, sigExpr, outputIndex), nil
+}
+
+// MakeAtomicSwapRefundParams returns the unlocking params to refund an
+// atomic swap output after the refund time.
+func MakeAtomicSwapRefundParams(sig []byte) (string, error) {
+	sigExpr, err := signatureExpr(sig)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("(cons 1 (cons %s nil))", sigExpr), nil
+}
+
+// MakeDelegatedStakeOwnerParams returns the unlocking params to spend or
+// stake a delegated stake output with the owner key.
+func MakeDelegatedStakeOwnerParams(sig []byte) (string, error) {
+	sigExpr, err := signatureExpr(sig)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("(cons 0 (cons %s nil))", sigExpr), nil
+}
+
+// MakeDelegatedStakeParams returns the unlocking params to stake a
+// delegated stake output with the delegate key.
+func MakeDelegatedStakeParams(sig []byte) (string, error) {
+	sigExpr, err := signatureExpr(sig)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("(cons 1 (cons %s nil))", sigExpr), nil
+}
+
+func signatureExpr(sig []byte) (string, error) {
+	if len(sig) != 64 {
+		return "", errors.New("invalid signature len")
+	}
+	sigRx, sigRy, sigS := icrypto.UnmarshalSignature(sig)
+	return fmt.Sprintf("(cons 0x%x (cons 0x%x (cons 0x%x nil)))", sigRx, sigRy, sigS), nil
+}
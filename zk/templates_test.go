@@ -0,0 +1,133 @@
+// Copyright (c) 2024 The illium developers
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+
+package zk
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	icrypto "github.com/project-illium/ilxd/crypto"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestScriptTemplateLockingParams(t *testing.T) {
+	_, pub, err := icrypto.GenerateNovaKey(rand.Reader)
+	assert.NoError(t, err)
+	keyBytes, err := crypto.MarshalPublicKey(pub)
+	assert.NoError(t, err)
+	key := hex.EncodeToString(keyBytes)
+	pubX, pubY := pub.(*icrypto.NovaPublicKey).ToXY()
+
+	hash := make([]byte, 32)
+	hash[31] = 0x01
+
+	tmpl, err := GetScriptTemplate("hashlock")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, tmpl.Script())
+
+	params, err := tmpl.LockingParams(map[string]string{
+		"hash":        "0x" + hex.EncodeToString(hash),
+		"claimkey":    key,
+		"refundafter": "1700000000",
+		"refundkey":   key,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, params, 6)
+	assert.Equal(t, hash, params[0])
+	assert.Equal(t, pubX, params[1])
+	assert.Equal(t, pubY, params[2])
+	assert.Equal(t, uint64(1700000000), binary.BigEndian.Uint64(params[3]))
+
+	_, err = tmpl.LockingParams(map[string]string{
+		"hash":      hex.EncodeToString(hash),
+		"claimkey":  key,
+		"refundkey": key,
+	})
+	assert.Error(t, err)
+
+	_, err = tmpl.LockingParams(map[string]string{
+		"hash":        strings.Repeat("ff", 32),
+		"claimkey":    key,
+		"refundafter": "1700000000",
+		"refundkey":   key,
+	})
+	assert.Error(t, err)
+
+	_, err = tmpl.LockingParams(map[string]string{
+		"hash":        hex.EncodeToString(hash),
+		"claimkey":    key,
+		"refundafter": "1700000000",
+		"refundkey":   key,
+		"extra":       "1",
+	})
+	assert.Error(t, err)
+
+	_, err = GetScriptTemplate("notatemplate")
+	assert.Error(t, err)
+
+	for _, tmpl := range ScriptTemplates() {
+		assert.NotEmpty(t, tmpl.Script(), tmpl.Name)
+	}
+}
+
+func TestAtomicSwapParams(t *testing.T) {
+	preimage, err := RandomFieldElement()
+	assert.NoError(t, err)
+	h := sha256.Sum256(preimage[:])
+
+	hash := AtomicSwapHash(h[:])
+	assert.Equal(t, h[0]&0b00011111, hash[0])
+	assert.Equal(t, h[1:], hash[1:])
+	fieldMax, _ := new(big.Int).SetString(LurkMaxFieldElement, 16)
+	assert.True(t, new(big.Int).SetBytes(hash).Cmp(fieldMax) <= 0)
+
+	priv, _, err := icrypto.GenerateNovaKey(rand.Reader)
+	assert.NoError(t, err)
+	sig, err := priv.Sign(hash)
+	assert.NoError(t, err)
+	sigRx, sigRy, sigS := icrypto.UnmarshalSignature(sig)
+
+	claim, err := MakeAtomicSwapClaimParams(preimage[:], sig, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("(cons 0 (cons 0x%x (cons (cons 0x%x (cons 0x%x (cons 0x%x nil))) (cons 1 nil))))", preimage[:], sigRx, sigRy, sigS), claim)
+
+	refund, err := MakeAtomicSwapRefundParams(sig)
+	assert.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("(cons 1 (cons (cons 0x%x (cons 0x%x (cons 0x%x nil))) nil))", sigRx, sigRy, sigS), refund)
+
+	_, err = MakeAtomicSwapClaimParams(preimage[:31], sig, 0)
+	assert.Error(t, err)
+	_, err = MakeAtomicSwapRefundParams(sig[:63])
+	assert.Error(t, err)
+}
+
+func TestDelegatedStakeParams(t *testing.T) {
+	priv, _, err := icrypto.GenerateNovaKey(rand.Reader)
+	assert.NoError(t, err)
+	sig, err := priv.Sign([]byte("sighash"))
+	assert.NoError(t, err)
+	sigRx, sigRy, sigS := icrypto.UnmarshalSignature(sig)
+
+	owner, err := MakeDelegatedStakeOwnerParams(sig)
+	assert.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("(cons 0 (cons (cons 0x%x (cons 0x%x (cons 0x%x nil))) nil))", sigRx, sigRy, sigS), owner)
+
+	delegate, err := MakeDelegatedStakeParams(sig)
+	assert.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("(cons 1 (cons (cons 0x%x (cons 0x%x (cons 0x%x nil))) nil))", sigRx, sigRy, sigS), delegate)
+
+	_, err = MakeDelegatedStakeParams(sig[:63])
+	assert.Error(t, err)
+
+	tmpl, err := GetScriptTemplate("delegatedstake")
+	assert.NoError(t, err)
+	assert.Contains(t, tmpl.Script(), "(car (cdr (cdr (cdr (cdr (cdr (cdr public-params)))))))")
+}